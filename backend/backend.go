@@ -0,0 +1,78 @@
+// Package backend provides the abstraction of where Terraform state is
+// stored and how it is locked, and the backends built into Terraform.
+package backend
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/state/remote"
+)
+
+// Backend is the interface implemented by the places Terraform state can
+// be stored.
+type Backend interface {
+	// Init configures the backend with the given configuration. It must be
+	// called before any other method, and returns an error if the
+	// configuration is invalid.
+	Init(conf map[string]string) error
+
+	// State returns the state stored in the backend. The state isn't
+	// loaded, callers must refresh it first.
+	State() (state.State, error)
+
+	// Locker locks the state stored in the backend. Backends that don't
+	// support locking return an empty lock ID and no error, as
+	// state.Locker documents.
+	state.Locker
+}
+
+// Factory is the factory function to create a backend.
+type Factory func() Backend
+
+// Backends is the list of built-in backends, by type. All the remote state
+// clients are available as backends.
+var Backends map[string]Factory
+
+func init() {
+	Backends = make(map[string]Factory)
+	for name, f := range remote.BuiltinClients {
+		Backends[name] = remoteStateFactory(f)
+	}
+}
+
+// New returns a new, uninitialized backend of the given type.
+func New(t string) (Backend, error) {
+	f, ok := Backends[t]
+	if !ok {
+		return nil, fmt.Errorf("unknown backend type: %s", t)
+	}
+
+	return f(), nil
+}
+
+// Init returns a new backend of the given type, initialized with the given
+// configuration.
+func Init(t string, conf map[string]string) (Backend, error) {
+	b, err := New(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.Init(conf); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Types returns the sorted types of the built-in backends.
+func Types() []string {
+	result := make([]string, 0, len(Backends))
+	for t, _ := range Backends {
+		result = append(result, t)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/state/remote"
+)
+
+func TestBackends(t *testing.T) {
+	for _, name := range []string{"artifactory", "consul", "s3", "swift"} {
+		if _, ok := Backends[name]; !ok {
+			t.Fatalf("missing backend: %s", name)
+		}
+	}
+}
+
+func TestNew_unknown(t *testing.T) {
+	if _, err := New("nope"); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestInit_invalidConfig(t *testing.T) {
+	// Artifactory requires a URL, among others
+	if _, err := Init("artifactory", map[string]string{}); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestRemoteState(t *testing.T) {
+	client := new(remote.InmemClient)
+	b := &RemoteState{
+		Factory: func(map[string]string) (remote.Client, error) {
+			return client, nil
+		},
+	}
+
+	if _, err := b.State(); err == nil {
+		t.Fatal("should error before Init")
+	}
+
+	if err := b.Init(nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	s, err := b.State()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.WriteState(state.TestStateInitial()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.PersistState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	state.TestState(t, s)
+
+	// The state is read back from the client
+	s, err = b.State()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.RefreshState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if s.State().Empty() {
+		t.Fatal("state should not be empty")
+	}
+}
+
+func TestRemoteState_lock(t *testing.T) {
+	b := &RemoteState{
+		Factory: func(map[string]string) (remote.Client, error) {
+			return new(remote.InmemClient), nil
+		},
+	}
+	if err := b.Init(nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	id, err := b.Lock(state.NewLockInfo("test"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, err = b.Lock(state.NewLockInfo("test"))
+	if _, ok := err.(*state.LockError); !ok {
+		t.Fatalf("expected a lock error, got: %#v", err)
+	}
+
+	if err := b.Unlock(id); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestRemoteState_lockUnsupported(t *testing.T) {
+	b := &RemoteState{
+		Factory: func(map[string]string) (remote.Client, error) {
+			return new(testClientNoLock), nil
+		},
+	}
+	if err := b.Init(nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	id, err := b.Lock(state.NewLockInfo("test"))
+	if err != nil || id != "" {
+		t.Fatalf("bad: %q %s", id, err)
+	}
+}
+
+// testClientNoLock is a remote state client that doesn't support locking.
+type testClientNoLock struct {
+	data []byte
+}
+
+func (c *testClientNoLock) Get() (*remote.Payload, error) {
+	if c.data == nil {
+		return nil, nil
+	}
+	return &remote.Payload{Data: c.data}, nil
+}
+
+func (c *testClientNoLock) Put(data []byte) error {
+	c.data = data
+	return nil
+}
+
+func (c *testClientNoLock) Delete() error {
+	c.data = nil
+	return nil
+}
+
+var _ Backend = new(RemoteState)
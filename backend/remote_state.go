@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/state/remote"
+)
+
+// RemoteState is a Backend that stores the state with a remote state
+// client, such as S3, Swift or Artifactory. The state is locked if the
+// client supports locking.
+type RemoteState struct {
+	// Factory creates the client from the configuration given to Init.
+	Factory remote.Factory
+
+	state *remote.State
+}
+
+func remoteStateFactory(f remote.Factory) Factory {
+	return func() Backend {
+		return &RemoteState{Factory: f}
+	}
+}
+
+// Backend impl.
+func (b *RemoteState) Init(conf map[string]string) error {
+	client, err := b.Factory(conf)
+	if err != nil {
+		return err
+	}
+
+	b.state = &remote.State{Client: client}
+	return nil
+}
+
+// Backend impl.
+func (b *RemoteState) State() (state.State, error) {
+	if b.state == nil {
+		return nil, fmt.Errorf("backend not initialized")
+	}
+
+	return b.state, nil
+}
+
+// Locker impl.
+func (b *RemoteState) Lock(info *state.LockInfo) (string, error) {
+	if b.state == nil {
+		return "", fmt.Errorf("backend not initialized")
+	}
+
+	return b.state.Lock(info)
+}
+
+// Locker impl.
+func (b *RemoteState) Unlock(id string) error {
+	if b.state == nil {
+		return fmt.Errorf("backend not initialized")
+	}
+
+	return b.state.Unlock(id)
+}
@@ -11,11 +11,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	awsCredentials "github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/go-ini/ini"
 	"github.com/hashicorp/go-cleanhttp"
+	"github.com/mitchellh/go-homedir"
 )
 
 func GetAccountId(iamconn *iam.IAM, stsconn *sts.STS, authProviderName string) (string, error) {
@@ -99,6 +102,10 @@ func GetCredentials(key, secret, token, profile, credsfile string) *awsCredentia
 			Filename: credsfile,
 			Profile:  profile,
 		},
+		&sharedConfigRoleProvider{
+			Filename: credsfile,
+			Profile:  profile,
+		},
 	}
 
 	// Build isolated HTTP client to avoid issues with globally-shared settings
@@ -132,6 +139,138 @@ func GetCredentials(key, secret, token, profile, credsfile string) *awsCredentia
 	return awsCredentials.NewChainCredentials(providers)
 }
 
+// GetAssumeRoleCredentials returns credentials for the given IAM role, minted
+// by the given STS client. The client is expected to be configured with the
+// base credentials resolved by GetCredentials.
+func GetAssumeRoleCredentials(stsconn *sts.STS, roleArn, sessionName, externalId string) *awsCredentials.Credentials {
+	return stscreds.NewCredentialsWithClient(stsconn, roleArn, func(p *stscreds.AssumeRoleProvider) {
+		if sessionName != "" {
+			p.RoleSessionName = sessionName
+		}
+		if externalId != "" {
+			p.ExternalID = aws.String(externalId)
+		}
+	})
+}
+
+// sharedConfigRoleProviderName is the name of the sharedConfigRoleProvider.
+const sharedConfigRoleProviderName = "SharedConfigRoleProvider"
+
+// sharedConfigRoleProvider retrieves the credentials of a profile of the
+// shared config and credentials files which assumes an IAM role, i.e. which
+// sets role_arn along with source_profile or credential_source, as the AWS
+// CLI does. The assumed role credentials are cached until they expire, so
+// CI runners only need a profile, not static keys.
+type sharedConfigRoleProvider struct {
+	// Filename is the path of the shared credentials file. If empty, the
+	// AWS_SHARED_CREDENTIALS_FILE environment variable or
+	// ~/.aws/credentials is used. The config file is AWS_CONFIG_FILE or
+	// ~/.aws/config.
+	Filename string
+
+	// Profile is the profile to use. If empty, the AWS_PROFILE environment
+	// variable or "default" is used.
+	Profile string
+
+	creds *awsCredentials.Credentials
+}
+
+func (p *sharedConfigRoleProvider) Retrieve() (awsCredentials.Value, error) {
+	if p.creds == nil {
+		profile := p.Profile
+		if profile == "" {
+			profile = os.Getenv("AWS_PROFILE")
+		}
+		if profile == "" {
+			profile = "default"
+		}
+
+		// Only profiles which assume a role are handled here, the session
+		// would otherwise fall back on credential sources already tried.
+		files := sharedConfigFiles(p.Filename)
+		section := sharedConfigProfile(files, profile)
+		if section == nil || !section.HasKey("role_arn") {
+			return awsCredentials.Value{ProviderName: sharedConfigRoleProviderName},
+				awserr.New("SharedConfigRoleNotFound",
+					fmt.Sprintf("profile %q doesn't assume a role", profile), nil)
+		}
+
+		// STS is global, any region can be used to assume the role.
+		cfg := aws.Config{}
+		if !section.HasKey("region") && os.Getenv("AWS_REGION") == "" &&
+			os.Getenv("AWS_DEFAULT_REGION") == "" {
+			cfg.Region = aws.String("us-east-1")
+		}
+
+		log.Printf("[INFO] Assuming the IAM role of profile %q", profile)
+		sess, err := session.NewSessionWithOptions(session.Options{
+			Config:            cfg,
+			Profile:           profile,
+			SharedConfigState: session.SharedConfigEnable,
+			SharedConfigFiles: files,
+		})
+		if err != nil {
+			return awsCredentials.Value{ProviderName: sharedConfigRoleProviderName}, err
+		}
+		p.creds = sess.Config.Credentials
+	}
+
+	return p.creds.Get()
+}
+
+func (p *sharedConfigRoleProvider) IsExpired() bool {
+	return p.creds == nil || p.creds.IsExpired()
+}
+
+// sharedConfigFiles returns the paths of the shared credentials and config
+// files, in the order the AWS SDK loads them.
+func sharedConfigFiles(credsfile string) []string {
+	if credsfile == "" {
+		credsfile = os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	}
+	if credsfile == "" {
+		credsfile = "~/.aws/credentials"
+	}
+
+	configfile := os.Getenv("AWS_CONFIG_FILE")
+	if configfile == "" {
+		configfile = "~/.aws/config"
+	}
+
+	var files []string
+	for _, f := range []string{credsfile, configfile} {
+		if expanded, err := homedir.Expand(f); err == nil {
+			files = append(files, expanded)
+		}
+	}
+	return files
+}
+
+// sharedConfigProfile returns the section of the given profile in the
+// shared config or credentials files, or nil if none of them has it. In the
+// config file, profiles other than the default are prefixed with "profile".
+func sharedConfigProfile(files []string, profile string) *ini.Section {
+	var result *ini.Section
+	for _, f := range files {
+		if _, err := os.Stat(f); err != nil {
+			continue
+		}
+
+		file, err := ini.Load(f)
+		if err != nil {
+			log.Printf("[WARN] Error loading shared config file %s: %s", f, err)
+			continue
+		}
+
+		for _, name := range []string{profile, "profile " + profile} {
+			if section, err := file.GetSection(name); err == nil {
+				result = section
+			}
+		}
+	}
+	return result
+}
+
 func setOptionalEndpoint(cfg *aws.Config) string {
 	endpoint := os.Getenv("AWS_METADATA_URL")
 	if endpoint != "" {
@@ -19,6 +19,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/apigateway"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/batch"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
@@ -27,6 +28,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/codecommit"
 	"github.com/aws/aws-sdk-go/service/codedeploy"
+	"github.com/aws/aws-sdk-go/service/databasemigrationservice"
+	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/aws/aws-sdk-go/service/directoryservice"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -40,7 +43,10 @@ import (
 	"github.com/aws/aws-sdk-go/service/emr"
 	"github.com/aws/aws-sdk-go/service/firehose"
 	"github.com/aws/aws-sdk-go/service/glacier"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/guardduty"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kafka"
 	"github.com/aws/aws-sdk-go/service/kinesis"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/lambda"
@@ -63,15 +69,25 @@ type Config struct {
 	Region        string
 	MaxRetries    int
 
+	AssumeRoleARN         string
+	AssumeRoleSessionName string
+	AssumeRoleExternalID  string
+
 	AllowedAccountIds   []interface{}
 	ForbiddenAccountIds []interface{}
 
-	DynamoDBEndpoint string
-	KinesisEndpoint  string
-	Ec2Endpoint      string
-	IamEndpoint      string
-	ElbEndpoint      string
-	Insecure         bool
+	DynamoDBEndpoint      string
+	KinesisEndpoint       string
+	Ec2Endpoint           string
+	IamEndpoint           string
+	ElbEndpoint           string
+	DirectConnectEndpoint string
+	Insecure              bool
+
+	// HTTPTransport, if set, is the transport all requests to AWS are sent
+	// through. It is used by the acceptance tests to record and replay
+	// requests.
+	HTTPTransport http.RoundTripper
 }
 
 type AWSClient struct {
@@ -81,6 +97,8 @@ type AWSClient struct {
 	cloudwatchconn       *cloudwatch.CloudWatch
 	cloudwatchlogsconn   *cloudwatchlogs.CloudWatchLogs
 	cloudwatcheventsconn *cloudwatchevents.CloudWatchEvents
+	dirconn              directConnectAPI
+	dmsconn              *databasemigrationservice.DatabaseMigrationService
 	dsconn               *directoryservice.DirectoryService
 	dynamodbconn         *dynamodb.DynamoDB
 	ec2conn              *ec2.EC2
@@ -92,6 +110,7 @@ type AWSClient struct {
 	esconn               *elasticsearch.ElasticsearchService
 	apigateway           *apigateway.APIGateway
 	autoscalingconn      *autoscaling.AutoScaling
+	batchconn            *batch.Batch
 	s3conn               *s3.S3
 	sqsconn              *sqs.SQS
 	snsconn              *sns.SNS
@@ -104,14 +123,19 @@ type AWSClient struct {
 	iamconn              *iam.IAM
 	kinesisconn          *kinesis.Kinesis
 	kmsconn              *kms.KMS
+	kafkaconn            *kafka.Kafka
 	firehoseconn         *firehose.Firehose
 	elasticacheconn      *elasticache.ElastiCache
 	elasticbeanstalkconn *elasticbeanstalk.ElasticBeanstalk
 	lambdaconn           *lambda.Lambda
 	opsworksconn         *opsworks.OpsWorks
 	glacierconn          *glacier.Glacier
+	glueconn             *glue.Glue
+	guarddutyconn        *guardduty.GuardDuty
 	codedeployconn       *codedeploy.CodeDeploy
 	codecommitconn       *codecommit.CodeCommit
+
+	dxVifCache *dxVirtualInterfaceCache
 }
 
 // Client configures and returns a fully initialized AWSClient
@@ -150,20 +174,41 @@ func (c *Config) Client() (interface{}, error) {
 
 		log.Printf("[INFO] AWS Auth provider used: %q", cp.ProviderName)
 
+		if c.AssumeRoleARN != "" {
+			log.Printf("[INFO] Attempting to AssumeRole %s (SessionName: %q, ExternalId: %q)",
+				c.AssumeRoleARN, c.AssumeRoleSessionName, c.AssumeRoleExternalID)
+
+			stsconn := sts.New(session.New(&aws.Config{
+				Credentials: creds,
+				Region:      aws.String(c.Region),
+				MaxRetries:  aws.Int(c.MaxRetries),
+				HTTPClient:  c.httpClient(),
+			}))
+			creds = GetAssumeRoleCredentials(stsconn, c.AssumeRoleARN, c.AssumeRoleSessionName, c.AssumeRoleExternalID)
+			cp, err = creds.Get()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("Error assuming role %q for AWS Provider: %s", c.AssumeRoleARN, err))
+				return nil, &multierror.Error{Errors: errs}
+			}
+		}
+
 		awsConfig := &aws.Config{
 			Credentials: creds,
 			Region:      aws.String(c.Region),
 			MaxRetries:  aws.Int(c.MaxRetries),
-			HTTPClient:  cleanhttp.DefaultClient(),
+			HTTPClient:  c.httpClient(),
 		}
 
+		// All service clients are created from the base session below, so
+		// they share the same throttling-aware retry policy.
+		request.WithRetryer(awsConfig, newAwsRetryer(c.MaxRetries))
+
 		if logging.IsDebugOrHigher() {
 			awsConfig.LogLevel = aws.LogLevel(aws.LogDebugWithHTTPBody)
 			awsConfig.Logger = awsLogger{}
 		}
 
-		if c.Insecure {
-			transport := awsConfig.HTTPClient.Transport.(*http.Transport)
+		if transport, ok := awsConfig.HTTPClient.Transport.(*http.Transport); ok && c.Insecure {
 			transport.TLSClientConfig = &tls.Config{
 				InsecureSkipVerify: true,
 			}
@@ -250,6 +295,9 @@ func (c *Config) Client() (interface{}, error) {
 		log.Println("[INFO] Initializing API Gateway")
 		client.apigateway = apigateway.New(sess)
 
+		log.Println("[INFO] Initializing Batch Connection")
+		client.batchconn = batch.New(sess)
+
 		log.Println("[INFO] Initializing ECS Connection")
 		client.ecsconn = ecs.New(sess)
 
@@ -289,12 +337,23 @@ func (c *Config) Client() (interface{}, error) {
 		log.Println("[INFO] Initializing OpsWorks Connection")
 		client.opsworksconn = opsworks.New(usEast1Sess)
 
+		log.Println("[INFO] Initializing Direct Connect connection")
+		dirconnSess := sess.Copy(&aws.Config{Endpoint: aws.String(c.DirectConnectEndpoint)})
+		client.dirconn = directconnect.New(dirconnSess)
+		client.dxVifCache = newDxVirtualInterfaceCache()
+
 		log.Println("[INFO] Initializing Directory Service connection")
 		client.dsconn = directoryservice.New(sess)
 
 		log.Println("[INFO] Initializing Glacier connection")
 		client.glacierconn = glacier.New(sess)
 
+		log.Println("[INFO] Initializing Glue connection")
+		client.glueconn = glue.New(sess)
+
+		log.Println("[INFO] Initializing GuardDuty connection")
+		client.guarddutyconn = guardduty.New(sess)
+
 		log.Println("[INFO] Initializing CodeDeploy Connection")
 		client.codedeployconn = codedeploy.New(sess)
 
@@ -306,6 +365,12 @@ func (c *Config) Client() (interface{}, error) {
 
 		log.Println("[INFO] Initializing KMS connection")
 		client.kmsconn = kms.New(sess)
+
+		log.Println("[INFO] Initializing MSK connection")
+		client.kafkaconn = kafka.New(sess)
+
+		log.Println("[INFO] Initializing DMS connection")
+		client.dmsconn = databasemigrationservice.New(sess)
 	}
 
 	if len(errs) > 0 {
@@ -333,6 +398,15 @@ func (c *Config) ValidateRegion() error {
 // Validate credentials early and fail before we do any graph walking.
 // In the case of an IAM role/profile with insuffecient privileges, fail
 // silently
+// httpClient returns the HTTP client for requests to AWS.
+func (c *Config) httpClient() *http.Client {
+	client := cleanhttp.DefaultClient()
+	if c.HTTPTransport != nil {
+		client.Transport = c.HTTPTransport
+	}
+	return client
+}
+
 func (c *Config) ValidateCredentials(iamconn *iam.IAM) error {
 	_, err := iamconn.GetUser(nil)
 
@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/directconnect"
+)
+
+// Providers with different regions, like aliases of the aws provider, must
+// each have their own clients for their region.
+func TestAWSConfigClient_regions(t *testing.T) {
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprintln(w, iamResponse_GetUser_valid)
+	}))
+	defer ts.Close()
+
+	clients := make(map[string]*AWSClient)
+	for _, region := range []string{"us-west-2", "ap-southeast-2"} {
+		c := &Config{
+			AccessKey:   "accessKey",
+			SecretKey:   "secretKey",
+			Region:      region,
+			IamEndpoint: ts.URL,
+		}
+
+		raw, err := c.Client()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		clients[region] = raw.(*AWSClient)
+	}
+
+	for region, client := range clients {
+		if client.region != region {
+			t.Fatalf("bad region: %s", client.region)
+		}
+		if r := *client.ec2conn.Config.Region; r != region {
+			t.Fatalf("bad EC2 region for %s: %s", region, r)
+		}
+		if r := *client.dirconn.(*directconnect.DirectConnect).Config.Region; r != region {
+			t.Fatalf("bad Direct Connect region for %s: %s", region, r)
+		}
+	}
+
+	if clients["us-west-2"].dxVifCache == clients["ap-southeast-2"].dxVifCache {
+		t.Fatal("clients should not share caches")
+	}
+}
@@ -0,0 +1,174 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsAmi() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsAmiRead,
+
+		Schema: map[string]*schema.Schema{
+			"owners": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateNameRegex,
+			},
+
+			"most_recent": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"owner_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"architecture": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"root_device_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"virtualization_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsAmiRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	owners := d.Get("owners").([]interface{})
+	nameRegex := d.Get("name_regex").(string)
+	tags := d.Get("tags").(map[string]interface{})
+	if len(owners) == 0 && len(tags) == 0 {
+		return fmt.Errorf("One of owners or tags is required")
+	}
+
+	req := &ec2.DescribeImagesInput{}
+	if len(owners) > 0 {
+		req.Owners = expandStringList(owners)
+	}
+	if len(tags) > 0 {
+		keys := make([]string, 0, len(tags))
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			req.Filters = append(req.Filters, &ec2.Filter{
+				Name:   aws.String("tag:" + k),
+				Values: []*string{aws.String(tags[k].(string))},
+			})
+		}
+	}
+
+	log.Printf("[DEBUG] Describing AMIs: %#v", req)
+	resp, err := conn.DescribeImages(req)
+	if err != nil {
+		return fmt.Errorf("Error describing AMIs: %s", err)
+	}
+
+	var matches []*ec2.Image
+	if nameRegex != "" {
+		r := regexp.MustCompile(nameRegex)
+		for _, image := range resp.Images {
+			if r.MatchString(aws.StringValue(image.Name)) {
+				matches = append(matches, image)
+			}
+		}
+	} else {
+		matches = resp.Images
+	}
+
+	if len(matches) == 0 {
+		return fmt.Errorf("No matching AMI found")
+	}
+
+	var image *ec2.Image
+	if len(matches) > 1 {
+		if !d.Get("most_recent").(bool) {
+			return fmt.Errorf(
+				"%d matching AMIs found, set more arguments to narrow down the search or set most_recent",
+				len(matches))
+		}
+		image = mostRecentAmi(matches)
+	} else {
+		image = matches[0]
+	}
+
+	d.SetId(aws.StringValue(image.ImageId))
+	d.Set("name", image.Name)
+	d.Set("owner_id", image.OwnerId)
+	d.Set("description", image.Description)
+	d.Set("creation_date", image.CreationDate)
+	d.Set("architecture", image.Architecture)
+	d.Set("root_device_type", image.RootDeviceType)
+	d.Set("virtualization_type", image.VirtualizationType)
+	d.Set("state", image.State)
+	if err := d.Set("tags", tagsToMap(image.Tags)); err != nil {
+		return fmt.Errorf("Error setting tags of AMI (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// mostRecentAmi returns the image with the latest creation date. The dates
+// are ISO 8601 timestamps, so they sort lexically.
+func mostRecentAmi(images []*ec2.Image) *ec2.Image {
+	latest := images[0]
+	for _, image := range images[1:] {
+		if aws.StringValue(image.CreationDate) > aws.StringValue(latest.CreationDate) {
+			latest = image
+		}
+	}
+	return latest
+}
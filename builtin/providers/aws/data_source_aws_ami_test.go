@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAWSAmiDataSource_mostRecent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAmiDataSourceMostRecentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aws_ami.amzn", "owner_id", "137112412989"),
+					resource.TestCheckResourceAttr("data.aws_ami.amzn", "root_device_type", "ebs"),
+					resource.TestCheckResourceAttr("data.aws_ami.amzn", "virtualization_type", "hvm"),
+					resource.TestCheckResourceAttr("data.aws_ami.amzn", "state", "available"),
+				),
+			},
+		},
+	})
+}
+
+func TestMostRecentAmi(t *testing.T) {
+	images := []*ec2.Image{
+		&ec2.Image{ImageId: aws.String("ami-11111111"), CreationDate: aws.String("2016-08-18T21:34:03.000Z")},
+		&ec2.Image{ImageId: aws.String("ami-22222222"), CreationDate: aws.String("2016-10-03T11:20:46.000Z")},
+		&ec2.Image{ImageId: aws.String("ami-33333333"), CreationDate: aws.String("2016-09-27T07:12:54.000Z")},
+	}
+
+	if id := aws.StringValue(mostRecentAmi(images).ImageId); id != "ami-22222222" {
+		t.Fatalf("Expected ami-22222222 to be the most recent AMI, got: %s", id)
+	}
+}
+
+const testAccAmiDataSourceMostRecentConfig = `
+data "aws_ami" "amzn" {
+  owners = ["amazon"]
+  name_regex = "^amzn-ami-hvm-.*-x86_64-gp2$"
+  most_recent = true
+}
+`
@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsDxConnection() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxConnectionRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"location": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"bandwidth": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"aws_device": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"connection_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsDxConnectionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient)
+	conn := client.dirconn
+
+	name := d.Get("connection_name").(string)
+	location := d.Get("location").(string)
+	bandwidth := d.Get("bandwidth").(string)
+	if name == "" && location == "" {
+		return fmt.Errorf("One of connection_name or location is required")
+	}
+
+	dxLog.Printf("[DEBUG] Describing Direct Connect connections")
+	resp, err := conn.DescribeConnections(&directconnect.DescribeConnectionsInput{})
+	if err != nil {
+		return fmt.Errorf("Error describing Direct Connect connections: %s", err)
+	}
+
+	var matches []*directconnect.Connection
+	for _, c := range resp.Connections {
+		if c == nil {
+			continue
+		}
+		if name != "" && aws.StringValue(c.ConnectionName) != name {
+			continue
+		}
+		if location != "" && aws.StringValue(c.Location) != location {
+			continue
+		}
+		if bandwidth != "" && aws.StringValue(c.Bandwidth) != bandwidth {
+			continue
+		}
+		// Like virtual interfaces, deleted and rejected connections linger
+		// in the API for a while.
+		switch aws.StringValue(c.ConnectionState) {
+		case directconnect.ConnectionStateDeleted, directconnect.ConnectionStateRejected:
+			continue
+		}
+		matches = append(matches, c)
+	}
+
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("No matching Direct Connect connection found")
+	case 1:
+	default:
+		return fmt.Errorf(
+			"%d matching Direct Connect connections found, set more arguments to narrow down the search",
+			len(matches))
+	}
+
+	c := matches[0]
+	d.SetId(aws.StringValue(c.ConnectionId))
+	d.Set("connection_name", c.ConnectionName)
+	d.Set("location", c.Location)
+	d.Set("bandwidth", c.Bandwidth)
+	if c.AwsDeviceV2 != nil {
+		d.Set("aws_device", c.AwsDeviceV2)
+	} else {
+		d.Set("aws_device", c.AwsDevice)
+	}
+	d.Set("connection_state", c.ConnectionState)
+	d.Set("arn", dxConnectionArn(client, c))
+	if err := d.Set("tags", tagsToMapDX(c.Tags)); err != nil {
+		return fmt.Errorf("Error setting tags of Direct Connect connection (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsDxConnectionLoa() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxConnectionLoaRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"provider_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"loa_content_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  directconnect.LoaContentTypeApplicationPdf,
+			},
+
+			"content": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsDxConnectionLoaRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	connectionId := d.Get("connection_id").(string)
+	req := &directconnect.DescribeLoaInput{
+		ConnectionId:   aws.String(connectionId),
+		LoaContentType: aws.String(d.Get("loa_content_type").(string)),
+	}
+	if v, ok := d.GetOk("provider_name"); ok {
+		req.ProviderName = aws.String(v.(string))
+	}
+
+	dxLog.Printf("[DEBUG] Describing Direct Connect LOA-CFA: %#v", req)
+	resp, err := conn.DescribeLoa(req)
+	if err != nil {
+		if isDxLoaNotAvailableErr(err) {
+			// AWS only issues the LOA-CFA once it has allocated a port for
+			// the connection, which happens some time after the connection
+			// is requested.
+			return fmt.Errorf(
+				"The LOA-CFA for Direct Connect connection (%s) is not available yet. "+
+					"AWS issues it once a port has been allocated for the connection; "+
+					"try again later. (%s)", connectionId, err.(awserr.Error).Message())
+		}
+		return fmt.Errorf("Error describing Direct Connect LOA-CFA for connection (%s): %s", connectionId, err)
+	}
+
+	d.SetId(connectionId)
+	d.Set("loa_content_type", resp.LoaContentType)
+	d.Set("content", base64.StdEncoding.EncodeToString(resp.LoaContent))
+
+	return nil
+}
+
+// isDxLoaNotAvailableErr reports whether err is the client exception Direct
+// Connect returns when asked for the LOA-CFA of a connection that has no port
+// allocated yet. Like for missing virtual interfaces there is no dedicated
+// error code, so the message has to be inspected: it says the LOA-CFA is not
+// available for a connection in the state it is in.
+func isDxLoaNotAvailableErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok || awsErr.Code() != "DirectConnectClientException" {
+		return false
+	}
+	msg := strings.ToLower(awsErr.Message())
+	return strings.Contains(msg, "loa") &&
+		(strings.Contains(msg, "not available") || strings.Contains(msg, "state"))
+}
@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDxConnectionLoa_basic(t *testing.T) {
+	connectionId := testAccDxConnectionPreCheck(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDxConnectionLoaConfig, connectionId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxConnectionLoaContent("data.aws_dx_connection_loa.foo"),
+					resource.TestCheckResourceAttr(
+						"data.aws_dx_connection_loa.foo", "loa_content_type", "application/pdf"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceAwsDxConnectionLoaRead_errors(t *testing.T) {
+	cases := map[string]struct {
+		Message  string
+		Expected string
+	}{
+		"not available": {
+			Message:  "LOA is not available for connection dxcon-fgh12345 in state requested",
+			Expected: "is not available yet",
+		},
+		"other": {
+			Message:  "Connection dxcon-fgh12345 does not exist",
+			Expected: "Error describing Direct Connect LOA-CFA for connection (dxcon-fgh12345)",
+		},
+	}
+	for name, tc := range cases {
+		closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+			"DescribeLoa": &dxMockResponse{
+				StatusCode: 400,
+				Body:       fmt.Sprintf(`{"__type": "DirectConnectClientException", "message": %q}`, tc.Message),
+			},
+		})
+
+		d := dataSourceAwsDxConnectionLoa().Data(nil)
+		d.Set("connection_id", "dxcon-fgh12345")
+		err := dataSourceAwsDxConnectionLoaRead(d, &AWSClient{dirconn: conn})
+		closeFunc()
+
+		if err == nil {
+			t.Fatalf("%s: Expected an error", name)
+		}
+		if !strings.Contains(err.Error(), tc.Expected) {
+			t.Fatalf("%s: Expected error to contain %q, got: %s", name, tc.Expected, err)
+		}
+	}
+}
+
+func testAccCheckAwsDxConnectionLoaContent(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.Attributes["content"] == "" {
+			return fmt.Errorf("No LOA-CFA content is set")
+		}
+
+		return nil
+	}
+}
+
+const testAccDxConnectionLoaConfig = `
+data "aws_dx_connection_loa" "foo" {
+  connection_id = "%s"
+}
+`
@@ -0,0 +1,123 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDxConnectionDataSource_basic(t *testing.T) {
+	location := testAccDxLagPreCheck(t)
+	connectionName := fmt.Sprintf("tf-dx-connection-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDxConnectionDataSourceConfig, connectionName, location),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDataSourceId(
+						"data.aws_dx_connection.foo", "aws_directconnect_connection.foo"),
+					resource.TestCheckResourceAttr("data.aws_dx_connection.foo", "connection_name", connectionName),
+					resource.TestCheckResourceAttr("data.aws_dx_connection.foo", "location", location),
+					resource.TestCheckResourceAttr("data.aws_dx_connection.foo", "bandwidth", "1Gbps"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceAwsDxConnectionRead(t *testing.T) {
+	body := `{"connections": [
+		{"connectionId": "dxcon-abcde123", "connectionName": "foo", "location": "EqDC2", "bandwidth": "1Gbps", "connectionState": "available", "ownerAccount": "123456789012", "awsDeviceV2": "EqDC2-123h49s71dabc"},
+		{"connectionId": "dxcon-abcde456", "connectionName": "bar", "location": "EqDC2", "bandwidth": "1Gbps", "connectionState": "available"},
+		{"connectionId": "dxcon-abcde789", "connectionName": "bar", "location": "EqSe2", "bandwidth": "10Gbps", "connectionState": "available"},
+		{"connectionId": "dxcon-abcde000", "connectionName": "baz", "location": "EqDC2", "bandwidth": "1Gbps", "connectionState": "deleted"}
+	]}`
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeConnections": &dxMockResponse{
+			StatusCode: 200,
+			Body:       body,
+		},
+	})
+	defer closeFunc()
+	client := &AWSClient{dirconn: conn, region: "us-east-1"}
+
+	d := testDxConnectionDataSourceData(map[string]string{"connection_name": "foo"})
+	if err := dataSourceAwsDxConnectionRead(d, client); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "dxcon-abcde123" {
+		t.Fatalf("Expected ID dxcon-abcde123, got: %q", d.Id())
+	}
+	if v := d.Get("aws_device").(string); v != "EqDC2-123h49s71dabc" {
+		t.Fatalf("Unexpected aws_device: %q", v)
+	}
+	if v := d.Get("arn").(string); v != "arn:aws:directconnect:us-east-1:123456789012:dxcon/dxcon-abcde123" {
+		t.Fatalf("Unexpected ARN: %q", v)
+	}
+
+	d = testDxConnectionDataSourceData(map[string]string{"connection_name": "bar", "location": "EqSe2"})
+	if err := dataSourceAwsDxConnectionRead(d, client); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "dxcon-abcde789" {
+		t.Fatalf("Expected ID dxcon-abcde789, got: %q", d.Id())
+	}
+
+	errCases := map[string]struct {
+		Attributes map[string]string
+		Expected   string
+	}{
+		"ambiguous": {
+			Attributes: map[string]string{"location": "EqDC2"},
+			Expected:   "2 matching Direct Connect connections",
+		},
+		"deleted": {
+			Attributes: map[string]string{"connection_name": "baz"},
+			Expected:   "No matching Direct Connect connection",
+		},
+		"missing": {
+			Attributes: map[string]string{"connection_name": "missing"},
+			Expected:   "No matching Direct Connect connection",
+		},
+		"no arguments": {
+			Attributes: map[string]string{"bandwidth": "1Gbps"},
+			Expected:   "One of connection_name or location is required",
+		},
+	}
+	for name, tc := range errCases {
+		err := dataSourceAwsDxConnectionRead(testDxConnectionDataSourceData(tc.Attributes), client)
+		if err == nil {
+			t.Fatalf("%s: Expected an error", name)
+		}
+		if !strings.Contains(err.Error(), tc.Expected) {
+			t.Fatalf("%s: Expected error to contain %q, got: %s", name, tc.Expected, err)
+		}
+	}
+}
+
+func testDxConnectionDataSourceData(attributes map[string]string) *schema.ResourceData {
+	return dataSourceAwsDxConnection().Data(&terraform.InstanceState{
+		Attributes: attributes,
+	})
+}
+
+const testAccDxConnectionDataSourceConfig = `
+resource "aws_directconnect_connection" "foo" {
+  connection_name = "%s"
+  bandwidth = "1Gbps"
+  location = "%s"
+}
+
+data "aws_dx_connection" "foo" {
+  connection_name = "${aws_directconnect_connection.foo.connection_name}"
+  location = "${aws_directconnect_connection.foo.location}"
+}
+`
@@ -0,0 +1,114 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsDxVirtualInterface() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxVirtualInterfaceRead,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_interface_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"connection_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"vlan": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"asn": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"amazon_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"customer_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"virtual_gateway_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"virtual_interface_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"bgp_peers": dxBgpPeersSchema(),
+		},
+	}
+}
+
+func dataSourceAwsDxVirtualInterfaceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	name := d.Get("virtual_interface_name").(string)
+	req := &directconnect.DescribeVirtualInterfacesInput{}
+	if v, ok := d.GetOk("connection_id"); ok {
+		req.ConnectionId = aws.String(v.(string))
+	}
+
+	dxLog.Printf("[DEBUG] Describing Direct Connect virtual interfaces: %#v", req)
+	resp, err := conn.DescribeVirtualInterfaces(req)
+	if err != nil {
+		return fmt.Errorf("Error describing Direct Connect virtual interfaces: %s", err)
+	}
+
+	var matches []*directconnect.VirtualInterface
+	for _, vif := range resp.VirtualInterfaces {
+		if vif == nil || aws.StringValue(vif.VirtualInterfaceName) != name {
+			continue
+		}
+		// Deleted interfaces linger in the API for a while, but they are of
+		// no use to anybody looking one up.
+		if aws.StringValue(vif.VirtualInterfaceState) == directconnect.VirtualInterfaceStateDeleted {
+			continue
+		}
+		matches = append(matches, vif)
+	}
+
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("No Direct Connect virtual interface named %q found", name)
+	case 1:
+	default:
+		return fmt.Errorf(
+			"%d Direct Connect virtual interfaces named %q found, set connection_id to narrow down the search",
+			len(matches), name)
+	}
+
+	vif := matches[0]
+	d.SetId(aws.StringValue(vif.VirtualInterfaceId))
+	d.Set("connection_id", vif.ConnectionId)
+	d.Set("vlan", vif.Vlan)
+	d.Set("asn", vif.Asn)
+	d.Set("amazon_address", vif.AmazonAddress)
+	d.Set("customer_address", vif.CustomerAddress)
+	d.Set("virtual_gateway_id", vif.VirtualGatewayId)
+	d.Set("virtual_interface_state", vif.VirtualInterfaceState)
+	if err := d.Set("bgp_peers", flattenDxBgpPeers(vif.BgpPeers)); err != nil {
+		return fmt.Errorf("Error setting bgp_peers of Direct Connect virtual interface (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDxVirtualInterfaceDataSource_basic(t *testing.T) {
+	connectionId := testAccDxConnectionPreCheck(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDxVirtualInterfaceDataSourceConfig, connectionId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDataSourceId(
+						"data.aws_dx_virtual_interface.foo", "aws_directconnect_virtual_interface.foo"),
+					resource.TestCheckResourceAttr(
+						"data.aws_dx_virtual_interface.foo", "vlan", "4094"),
+					resource.TestCheckResourceAttr(
+						"data.aws_dx_virtual_interface.foo", "asn", "65352"),
+					resource.TestCheckResourceAttr(
+						"data.aws_dx_virtual_interface.foo", "connection_id", connectionId),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceAwsDxVirtualInterfaceRead(t *testing.T) {
+	body := `{"virtualInterfaces": [
+		{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceName": "foo", "virtualInterfaceState": "available", "vlan": 4094, "asn": 65352, "connectionId": "dxcon-fgh12345"},
+		{"virtualInterfaceId": "dxvif-abcde456", "virtualInterfaceName": "bar", "virtualInterfaceState": "available", "vlan": 4093},
+		{"virtualInterfaceId": "dxvif-abcde789", "virtualInterfaceName": "bar", "virtualInterfaceState": "down", "vlan": 4092},
+		{"virtualInterfaceId": "dxvif-abcde000", "virtualInterfaceName": "baz", "virtualInterfaceState": "deleted", "vlan": 4091}
+	]}`
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       body,
+		},
+	})
+	defer closeFunc()
+	client := &AWSClient{dirconn: conn}
+
+	d := testDxVirtualInterfaceDataSourceData("foo")
+	if err := dataSourceAwsDxVirtualInterfaceRead(d, client); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "dxvif-abcde123" {
+		t.Fatalf("Expected ID dxvif-abcde123, got: %q", d.Id())
+	}
+	if v := d.Get("vlan").(int); v != 4094 {
+		t.Fatalf("Expected vlan to be 4094, got: %d", v)
+	}
+	if v := d.Get("connection_id").(string); v != "dxcon-fgh12345" {
+		t.Fatalf("Expected connection_id to be dxcon-fgh12345, got: %q", v)
+	}
+
+	errCases := map[string]string{
+		"bar":     "2 Direct Connect virtual interfaces",
+		"baz":     "No Direct Connect virtual interface",
+		"missing": "No Direct Connect virtual interface",
+	}
+	for name, expected := range errCases {
+		err := dataSourceAwsDxVirtualInterfaceRead(testDxVirtualInterfaceDataSourceData(name), client)
+		if err == nil {
+			t.Fatalf("%s: Expected an error", name)
+		}
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%s: Expected error to contain %q, got: %s", name, expected, err)
+		}
+	}
+}
+
+func testDxVirtualInterfaceDataSourceData(name string) *schema.ResourceData {
+	return dataSourceAwsDxVirtualInterface().Data(&terraform.InstanceState{
+		Attributes: map[string]string{"virtual_interface_name": name},
+	})
+}
+
+func testAccCheckAwsDataSourceId(n, vif string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		rs, ok := s.RootModule().Resources[vif]
+		if !ok {
+			return fmt.Errorf("Not found: %s", vif)
+		}
+
+		if ds.Primary.ID != rs.Primary.ID {
+			return fmt.Errorf("Expected %s to find %s, got: %s", n, rs.Primary.ID, ds.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccDxVirtualInterfaceDataSourceConfig = `
+resource "aws_vpn_gateway" "foo" {
+  tags {
+    Name = "terraform-testacc-dx-vif-data-source"
+  }
+}
+
+resource "aws_directconnect_virtual_interface" "foo" {
+  connection_id = "%s"
+  virtual_interface_name = "terraform-testacc-dx-vif-data-source"
+  vlan = 4094
+  asn = 65352
+  virtual_gateway_id = "${aws_vpn_gateway.foo.id}"
+}
+
+data "aws_dx_virtual_interface" "foo" {
+  virtual_interface_name = "${aws_directconnect_virtual_interface.foo.virtual_interface_name}"
+  connection_id = "${aws_directconnect_virtual_interface.foo.connection_id}"
+}
+`
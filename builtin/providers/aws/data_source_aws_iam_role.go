@@ -0,0 +1,117 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsIamRole() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsIamRoleRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"path": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"unique_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"assume_role_policy": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"attached_policy_arns": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"inline_policy_names": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func dataSourceAwsIamRoleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).iamconn
+
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Reading IAM Role: %s", name)
+	resp, err := conn.GetRole(&iam.GetRoleInput{
+		RoleName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading IAM Role %s: %s", name, err)
+	}
+
+	role := resp.Role
+	d.SetId(*role.RoleName)
+	d.Set("arn", role.Arn)
+	d.Set("path", role.Path)
+	d.Set("unique_id", role.RoleId)
+
+	// The policy document comes back URL encoded.
+	policy, err := url.QueryUnescape(aws.StringValue(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return fmt.Errorf("Error decoding assume role policy of IAM Role %s: %s", name, err)
+	}
+	d.Set("assume_role_policy", policy)
+
+	var attached []*string
+	err = conn.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(name),
+	}, func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
+		for _, p := range page.AttachedPolicies {
+			attached = append(attached, p.PolicyArn)
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing policies attached to IAM Role %s: %s", name, err)
+	}
+	if err := d.Set("attached_policy_arns", flattenStringList(attached)); err != nil {
+		return err
+	}
+
+	var inline []*string
+	err = conn.ListRolePoliciesPages(&iam.ListRolePoliciesInput{
+		RoleName: aws.String(name),
+	}, func(page *iam.ListRolePoliciesOutput, lastPage bool) bool {
+		inline = append(inline, page.PolicyNames...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing inline policies of IAM Role %s: %s", name, err)
+	}
+	if err := d.Set("inline_policy_names", flattenStringList(inline)); err != nil {
+		return err
+	}
+
+	return nil
+}
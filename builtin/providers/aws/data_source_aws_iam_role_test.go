@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAWSIamRoleDataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSIamRoleDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aws_iam_role.test", "name", rName),
+					resource.TestCheckResourceAttr("data.aws_iam_role.test", "path", "/"),
+					resource.TestMatchResourceAttr("data.aws_iam_role.test", "arn",
+						regexp.MustCompile(fmt.Sprintf(`^arn:aws:iam::[0-9]{12}:role/%s$`, rName))),
+					resource.TestMatchResourceAttr("data.aws_iam_role.test", "assume_role_policy",
+						regexp.MustCompile(`ec2\.amazonaws\.com`)),
+					resource.TestCheckResourceAttr("data.aws_iam_role.test", "attached_policy_arns.#", "1"),
+					resource.TestCheckResourceAttr("data.aws_iam_role.test", "inline_policy_names.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSIamRoleDataSourceConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = "%[1]s"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "ec2.amazonaws.com"
+      },
+      "Effect": "Allow"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy" "test" {
+  name = "%[1]s-managed"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "ec2:Describe*",
+      "Effect": "Allow",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy_attachment" "test" {
+  name       = "%[1]s"
+  roles      = ["${aws_iam_role.test.name}"]
+  policy_arn = "${aws_iam_policy.test.arn}"
+}
+
+resource "aws_iam_role_policy" "test" {
+  name = "%[1]s-inline"
+  role = "${aws_iam_role.test.id}"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "s3:ListAllMyBuckets",
+      "Effect": "Allow",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+data "aws_iam_role" "test" {
+  name = "${aws_iam_role.test.name}"
+
+  depends_on = ["aws_iam_policy_attachment.test", "aws_iam_role_policy.test"]
+}
+`, rName)
+}
@@ -0,0 +1,133 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsVpc() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsVpcRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"cidr_block": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"default": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+			},
+
+			"dhcp_options_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"instance_tenancy": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsVpcRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	req := &ec2.DescribeVpcsInput{}
+	if v, ok := d.GetOk("id"); ok {
+		req.VpcIds = []*string{aws.String(v.(string))}
+	}
+
+	var filters []*ec2.Filter
+	addFilter := func(name, value string) {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String(name),
+			Values: []*string{aws.String(value)},
+		})
+	}
+	if v, ok := d.GetOk("cidr_block"); ok {
+		addFilter("cidr", v.(string))
+	}
+	// GetOk can't tell an explicit false from an unset bool, so only
+	// default = true narrows down the search.
+	if v, ok := d.GetOk("default"); ok && v.(bool) {
+		addFilter("isDefault", "true")
+	}
+	if v, ok := d.GetOk("state"); ok {
+		addFilter("state", v.(string))
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		tags := v.(map[string]interface{})
+		keys := make([]string, 0, len(tags))
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			addFilter("tag:"+k, tags[k].(string))
+		}
+	}
+	if len(filters) > 0 {
+		req.Filters = filters
+	}
+
+	if req.VpcIds == nil && req.Filters == nil {
+		return fmt.Errorf("At least one of id, cidr_block, default, state or tags is required")
+	}
+
+	log.Printf("[DEBUG] Describing VPCs: %#v", req)
+	resp, err := conn.DescribeVpcs(req)
+	if err != nil {
+		return fmt.Errorf("Error describing VPCs: %s", err)
+	}
+
+	switch len(resp.Vpcs) {
+	case 0:
+		return fmt.Errorf("No matching VPC found")
+	case 1:
+	default:
+		return fmt.Errorf("%d matching VPCs found, set more arguments to narrow down the search", len(resp.Vpcs))
+	}
+
+	vpc := resp.Vpcs[0]
+	d.SetId(aws.StringValue(vpc.VpcId))
+	d.Set("id", vpc.VpcId)
+	d.Set("cidr_block", vpc.CidrBlock)
+	d.Set("default", vpc.IsDefault)
+	d.Set("state", vpc.State)
+	d.Set("dhcp_options_id", vpc.DhcpOptionsId)
+	d.Set("instance_tenancy", vpc.InstanceTenancy)
+	if err := d.Set("tags", tagsToMap(vpc.Tags)); err != nil {
+		return fmt.Errorf("Error setting tags of VPC (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
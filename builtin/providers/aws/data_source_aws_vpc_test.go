@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAWSVpcDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDataSourceId("data.aws_vpc.by_id", "aws_vpc.test"),
+					testAccCheckAwsDataSourceId("data.aws_vpc.by_tags", "aws_vpc.test"),
+					resource.TestCheckResourceAttr("data.aws_vpc.by_tags", "cidr_block", "172.16.0.0/16"),
+					resource.TestCheckResourceAttr("data.aws_vpc.by_tags", "default", "false"),
+					resource.TestCheckResourceAttr("data.aws_vpc.by_id", "tags.Name", "terraform-testacc-vpc-data-source"),
+				),
+			},
+		},
+	})
+}
+
+const testAccVpcDataSourceConfig = `
+resource "aws_vpc" "test" {
+  cidr_block = "172.16.0.0/16"
+
+  tags {
+    Name = "terraform-testacc-vpc-data-source"
+  }
+}
+
+data "aws_vpc" "by_id" {
+  id = "${aws_vpc.test.id}"
+}
+
+data "aws_vpc" "by_tags" {
+  tags {
+    Name = "${aws_vpc.test.tags.Name}"
+  }
+}
+`
@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/service/directconnect"
+)
+
+// directConnectAPI is the part of the Direct Connect API used by the Direct
+// Connect resources. AWSClient holds the Direct Connect client as this
+// interface, so that tests can stand in for real circuits, which can't be
+// provisioned on demand.
+type directConnectAPI interface {
+	AllocateHostedConnection(input *directconnect.AllocateHostedConnectionInput) (*directconnect.Connection, error)
+	AllocatePrivateVirtualInterface(input *directconnect.AllocatePrivateVirtualInterfaceInput) (*directconnect.VirtualInterface, error)
+	AllocatePublicVirtualInterface(input *directconnect.AllocatePublicVirtualInterfaceInput) (*directconnect.VirtualInterface, error)
+	AllocateTransitVirtualInterface(input *directconnect.AllocateTransitVirtualInterfaceInput) (*directconnect.AllocateTransitVirtualInterfaceOutput, error)
+	AssociateConnectionWithLag(input *directconnect.AssociateConnectionWithLagInput) (*directconnect.Connection, error)
+	ConfirmPrivateVirtualInterface(input *directconnect.ConfirmPrivateVirtualInterfaceInput) (*directconnect.ConfirmPrivateVirtualInterfaceOutput, error)
+	ConfirmPublicVirtualInterface(input *directconnect.ConfirmPublicVirtualInterfaceInput) (*directconnect.ConfirmPublicVirtualInterfaceOutput, error)
+	ConfirmTransitVirtualInterface(input *directconnect.ConfirmTransitVirtualInterfaceInput) (*directconnect.ConfirmTransitVirtualInterfaceOutput, error)
+	CreateBGPPeer(input *directconnect.CreateBGPPeerInput) (*directconnect.CreateBGPPeerOutput, error)
+	CreateConnection(input *directconnect.CreateConnectionInput) (*directconnect.Connection, error)
+	CreateDirectConnectGateway(input *directconnect.CreateDirectConnectGatewayInput) (*directconnect.CreateDirectConnectGatewayOutput, error)
+	CreateDirectConnectGatewayAssociation(input *directconnect.CreateDirectConnectGatewayAssociationInput) (*directconnect.CreateDirectConnectGatewayAssociationOutput, error)
+	CreateDirectConnectGatewayAssociationProposal(input *directconnect.CreateDirectConnectGatewayAssociationProposalInput) (*directconnect.CreateDirectConnectGatewayAssociationProposalOutput, error)
+	CreateLag(input *directconnect.CreateLagInput) (*directconnect.Lag, error)
+	CreatePrivateVirtualInterface(input *directconnect.CreatePrivateVirtualInterfaceInput) (*directconnect.VirtualInterface, error)
+	CreatePublicVirtualInterface(input *directconnect.CreatePublicVirtualInterfaceInput) (*directconnect.VirtualInterface, error)
+	CreateTransitVirtualInterface(input *directconnect.CreateTransitVirtualInterfaceInput) (*directconnect.CreateTransitVirtualInterfaceOutput, error)
+	DeleteBGPPeer(input *directconnect.DeleteBGPPeerInput) (*directconnect.DeleteBGPPeerOutput, error)
+	DeleteConnection(input *directconnect.DeleteConnectionInput) (*directconnect.Connection, error)
+	DeleteDirectConnectGateway(input *directconnect.DeleteDirectConnectGatewayInput) (*directconnect.DeleteDirectConnectGatewayOutput, error)
+	DeleteDirectConnectGatewayAssociation(input *directconnect.DeleteDirectConnectGatewayAssociationInput) (*directconnect.DeleteDirectConnectGatewayAssociationOutput, error)
+	DeleteDirectConnectGatewayAssociationProposal(input *directconnect.DeleteDirectConnectGatewayAssociationProposalInput) (*directconnect.DeleteDirectConnectGatewayAssociationProposalOutput, error)
+	DeleteLag(input *directconnect.DeleteLagInput) (*directconnect.Lag, error)
+	DeleteVirtualInterface(input *directconnect.DeleteVirtualInterfaceInput) (*directconnect.DeleteVirtualInterfaceOutput, error)
+	DescribeConnections(input *directconnect.DescribeConnectionsInput) (*directconnect.Connections, error)
+	DescribeDirectConnectGatewayAssociationProposals(input *directconnect.DescribeDirectConnectGatewayAssociationProposalsInput) (*directconnect.DescribeDirectConnectGatewayAssociationProposalsOutput, error)
+	DescribeDirectConnectGatewayAssociations(input *directconnect.DescribeDirectConnectGatewayAssociationsInput) (*directconnect.DescribeDirectConnectGatewayAssociationsOutput, error)
+	DescribeDirectConnectGateways(input *directconnect.DescribeDirectConnectGatewaysInput) (*directconnect.DescribeDirectConnectGatewaysOutput, error)
+	DescribeHostedConnections(input *directconnect.DescribeHostedConnectionsInput) (*directconnect.Connections, error)
+	DescribeLags(input *directconnect.DescribeLagsInput) (*directconnect.DescribeLagsOutput, error)
+	DescribeLoa(input *directconnect.DescribeLoaInput) (*directconnect.Loa, error)
+	DescribeTags(input *directconnect.DescribeTagsInput) (*directconnect.DescribeTagsOutput, error)
+	DescribeVirtualInterfaces(input *directconnect.DescribeVirtualInterfacesInput) (*directconnect.DescribeVirtualInterfacesOutput, error)
+	DisassociateConnectionFromLag(input *directconnect.DisassociateConnectionFromLagInput) (*directconnect.Connection, error)
+	TagResource(input *directconnect.TagResourceInput) (*directconnect.TagResourceOutput, error)
+	UntagResource(input *directconnect.UntagResourceInput) (*directconnect.UntagResourceOutput, error)
+	UpdateDirectConnectGatewayAssociation(input *directconnect.UpdateDirectConnectGatewayAssociationInput) (*directconnect.UpdateDirectConnectGatewayAssociationOutput, error)
+	UpdateLag(input *directconnect.UpdateLagInput) (*directconnect.Lag, error)
+	UpdateVirtualInterfaceAttributes(input *directconnect.UpdateVirtualInterfaceAttributesInput) (*directconnect.UpdateVirtualInterfaceAttributesOutput, error)
+}
+
+var _ directConnectAPI = (*directconnect.DirectConnect)(nil)
@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+)
+
+// dxVirtualInterfaceCache coalesces the DescribeVirtualInterfaces calls made
+// while refreshing many virtual interfaces on the same connection. The first
+// Read for a connection fetches all of its virtual interfaces in one call and
+// every other Read for that connection, including concurrent ones, picks its
+// entry out of the result.
+//
+// Entries are only ever used by Read. Anything that changes a virtual
+// interface must invalidate the entry for its connection, so that the Read
+// at the end of an apply never sees data from before it.
+type dxVirtualInterfaceCache struct {
+	lock    sync.Mutex
+	entries map[string]*dxVirtualInterfaceCacheEntry
+}
+
+type dxVirtualInterfaceCacheEntry struct {
+	once sync.Once
+	vifs []*directconnect.VirtualInterface
+	err  error
+}
+
+func newDxVirtualInterfaceCache() *dxVirtualInterfaceCache {
+	return &dxVirtualInterfaceCache{
+		entries: make(map[string]*dxVirtualInterfaceCacheEntry),
+	}
+}
+
+// get returns the virtual interface vifId on connection connectionId. A nil
+// interface is returned without error if the connection has no such virtual
+// interface.
+func (c *dxVirtualInterfaceCache) get(conn directConnectAPI, connectionId, vifId string) (*directconnect.VirtualInterface, error) {
+	c.lock.Lock()
+	e, ok := c.entries[connectionId]
+	if !ok {
+		e = &dxVirtualInterfaceCacheEntry{}
+		c.entries[connectionId] = e
+	}
+	c.lock.Unlock()
+
+	e.once.Do(func() {
+		dxLog.Printf("[DEBUG] Describing all Direct Connect virtual interfaces of connection %s", connectionId)
+		resp, err := conn.DescribeVirtualInterfaces(&directconnect.DescribeVirtualInterfacesInput{
+			ConnectionId: aws.String(connectionId),
+		})
+		if err != nil {
+			e.err = err
+			return
+		}
+		if resp != nil {
+			e.vifs = resp.VirtualInterfaces
+		}
+	})
+
+	if e.err != nil {
+		// Don't hold on to failures, the next Read gets to try again.
+		c.lock.Lock()
+		if c.entries[connectionId] == e {
+			delete(c.entries, connectionId)
+		}
+		c.lock.Unlock()
+		return nil, e.err
+	}
+
+	for _, vif := range e.vifs {
+		if vif != nil && aws.StringValue(vif.VirtualInterfaceId) == vifId {
+			return vif, nil
+		}
+	}
+	return nil, nil
+}
+
+// invalidate drops the cached virtual interfaces of connection connectionId.
+func (c *dxVirtualInterfaceCache) invalidate(connectionId string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.entries, connectionId)
+}
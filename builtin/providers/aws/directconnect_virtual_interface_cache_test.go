@@ -0,0 +1,143 @@
+package aws
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testDxVirtualInterfaceCacheBody = `{"virtualInterfaces": [
+	{"virtualInterfaceId": "dxvif-abcde123", "connectionId": "dxcon-fgh12345", "virtualInterfaceState": "available"},
+	{"virtualInterfaceId": "dxvif-abcde456", "connectionId": "dxcon-fgh12345", "virtualInterfaceState": "down"}
+]}`
+
+// countDxCalls returns a pointer to the number of API calls made by conn.
+func countDxCalls(conn *directconnect.DirectConnect) *int {
+	var lock sync.Mutex
+	calls := 0
+	conn.Handlers.Send.PushFront(func(*request.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		calls++
+	})
+	return &calls
+}
+
+func TestDxVirtualInterfaceCache_coalesces(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       testDxVirtualInterfaceCacheBody,
+		},
+	})
+	defer closeFunc()
+	calls := countDxCalls(conn)
+
+	cache := newDxVirtualInterfaceCache()
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"dxvif-abcde123", "dxvif-abcde456", "dxvif-abcde123", "dxvif-abcde456"} {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			vif, err := cache.get(conn, "dxcon-fgh12345", id)
+			if err != nil {
+				t.Errorf("Expected no error, got: %s", err)
+				return
+			}
+			if vif == nil || *vif.VirtualInterfaceId != id {
+				t.Errorf("Expected virtual interface %s, got: %#v", id, vif)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	vif, err := cache.get(conn, "dxcon-fgh12345", "dxvif-missing")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if vif != nil {
+		t.Fatalf("Expected no virtual interface, got: %#v", vif)
+	}
+
+	if *calls != 1 {
+		t.Fatalf("Expected 1 API call, got: %d", *calls)
+	}
+}
+
+func TestDxVirtualInterfaceCache_invalidate(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       testDxVirtualInterfaceCacheBody,
+		},
+	})
+	defer closeFunc()
+	calls := countDxCalls(conn)
+
+	cache := newDxVirtualInterfaceCache()
+	for i := 0; i < 2; i++ {
+		if _, err := cache.get(conn, "dxcon-fgh12345", "dxvif-abcde123"); err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+		cache.invalidate("dxcon-fgh12345")
+	}
+
+	if *calls != 2 {
+		t.Fatalf("Expected 2 API calls, got: %d", *calls)
+	}
+}
+
+func TestDxVirtualInterfaceCache_doesNotCacheErrors(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 500,
+			Body:       `{"__type": "DirectConnectServerException", "message": "Internal error"}`,
+		},
+	})
+	defer closeFunc()
+	calls := countDxCalls(conn)
+
+	cache := newDxVirtualInterfaceCache()
+	for i := 0; i < 2; i++ {
+		if _, err := cache.get(conn, "dxcon-fgh12345", "dxvif-abcde123"); err == nil {
+			t.Fatalf("Expected an error")
+		}
+	}
+
+	if *calls != 2 {
+		t.Fatalf("Expected 2 API calls, got: %d", *calls)
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceRead_cached(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       testDxVirtualInterfaceCacheBody,
+		},
+	})
+	defer closeFunc()
+	calls := countDxCalls(conn)
+
+	client := &AWSClient{dirconn: conn, dxVifCache: newDxVirtualInterfaceCache()}
+	for _, id := range []string{"dxvif-abcde123", "dxvif-abcde456"} {
+		d := resourceAwsDirectconnectVirtualInterface().Data(&terraform.InstanceState{
+			ID:         id,
+			Attributes: map[string]string{"connection_id": "dxcon-fgh12345"},
+		})
+		if err := resourceAwsDirectconnectVirtualInterfaceRead(d, client); err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+		if d.Id() != id {
+			t.Fatalf("Expected ID %s to be kept, got: %q", id, d.Id())
+		}
+	}
+
+	if *calls != 1 {
+		t.Fatalf("Expected 1 API call, got: %d", *calls)
+	}
+}
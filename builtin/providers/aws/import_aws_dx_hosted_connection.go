@@ -0,0 +1,26 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Hosted connections can only be looked up through the interconnect or LAG
+// they are allocated on, so they are imported with an ID of
+// CONNECTIONID/HOSTEDCONNECTIONID.
+func resourceAwsDxHostedConnectionImportState(
+	d *schema.ResourceData,
+	meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf(
+			"Unexpected format of ID (%q), expected CONNECTIONID/HOSTEDCONNECTIONID", d.Id())
+	}
+
+	d.Set("connection_id", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,34 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// The import ID of a hosted connection depends on the connection it was
+// allocated on, which the acceptance test framework can't derive from the
+// state, so only the parsing of the ID is tested here.
+func TestResourceAwsDxHostedConnectionImportState(t *testing.T) {
+	d := resourceAwsDxHostedConnection().Data(&terraform.InstanceState{ID: "dxcon-abcde123/dxcon-fgh45678"})
+	results, err := resourceAwsDxHostedConnectionImportState(d, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got: %d", len(results))
+	}
+	if id := results[0].Id(); id != "dxcon-fgh45678" {
+		t.Fatalf("Unexpected ID: %q", id)
+	}
+	if v := results[0].Get("connection_id").(string); v != "dxcon-abcde123" {
+		t.Fatalf("Unexpected connection_id: %q", v)
+	}
+
+	for _, id := range []string{"dxcon-fgh45678", "dxcon-abcde123/", "/dxcon-fgh45678", "a/b/c"} {
+		d := resourceAwsDxHostedConnection().Data(&terraform.InstanceState{ID: id})
+		if _, err := resourceAwsDxHostedConnectionImportState(d, nil); err == nil {
+			t.Fatalf("Expected an error for ID %q", id)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// route53RecordTypes are the record types Route 53 supports. They are used
+// to find where the record name ends in an import ID, since names may
+// themselves contain underscores (e.g. _dmarc).
+var route53RecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CAA":   true,
+	"CNAME": true,
+	"MX":    true,
+	"NAPTR": true,
+	"NS":    true,
+	"PTR":   true,
+	"SOA":   true,
+	"SPF":   true,
+	"SRV":   true,
+	"TXT":   true,
+}
+
+// Route 53 records are imported with an ID of ZONEID_NAME_TYPE, or
+// ZONEID_NAME_TYPE_SETID for records with a routing policy, which is the same
+// format the resource uses for its own IDs.
+func resourceAwsRoute53RecordImportState(
+	d *schema.ResourceData,
+	meta interface{}) ([]*schema.ResourceData, error) {
+	zoneId, name, recordType, setId, err := parseRoute53RecordId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("zone_id", zoneId)
+	d.Set("name", name)
+	d.Set("type", recordType)
+	if setId != "" {
+		d.Set("set_identifier", setId)
+	}
+
+	record, err := findRecord(d, meta)
+	if err != nil {
+		return nil, fmt.Errorf("Error importing Route 53 record %q: %s", d.Id(), err)
+	}
+	if record.Weight == nil {
+		d.Set("weight", -1)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func parseRoute53RecordId(id string) (zoneId, name, recordType, setId string, err error) {
+	parts := strings.Split(id, "_")
+	typeIdx := -1
+	for i := 2; i < len(parts); i++ {
+		if route53RecordTypes[parts[i]] {
+			typeIdx = i
+			break
+		}
+	}
+
+	if typeIdx < 0 || parts[0] == "" {
+		err = fmt.Errorf(
+			"Unexpected format of ID (%q), expected ZONEID_NAME_TYPE or ZONEID_NAME_TYPE_SETID", id)
+		return
+	}
+
+	zoneId = parts[0]
+	name = strings.Join(parts[1:typeIdx], "_")
+	recordType = parts[typeIdx]
+	setId = strings.Join(parts[typeIdx+1:], "_")
+
+	if name == "" || (typeIdx+1 < len(parts) && setId == "") {
+		err = fmt.Errorf(
+			"Unexpected format of ID (%q), expected ZONEID_NAME_TYPE or ZONEID_NAME_TYPE_SETID", id)
+	}
+	return
+}
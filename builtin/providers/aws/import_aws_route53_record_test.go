@@ -0,0 +1,87 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAWSRoute53Record_importBasic(t *testing.T) {
+	resourceName := "aws_route53_record.default"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53RecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53RecordConfig,
+			},
+
+			resource.TestStep{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSRoute53Record_importWeighted(t *testing.T) {
+	resourceName := "aws_route53_record.www-live"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53RecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53WeightedCNAMERecord,
+			},
+
+			resource.TestStep{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseRoute53RecordId(t *testing.T) {
+	cases := []struct {
+		Id         string
+		ZoneId     string
+		Name       string
+		RecordType string
+		SetId      string
+		Err        bool
+	}{
+		{Id: "Z123_www_CNAME", ZoneId: "Z123", Name: "www", RecordType: "CNAME"},
+		{Id: "Z123_www_CNAME_live", ZoneId: "Z123", Name: "www", RecordType: "CNAME", SetId: "live"},
+		{Id: "Z123__dmarc.example.com_TXT", ZoneId: "Z123", Name: "_dmarc.example.com", RecordType: "TXT"},
+		{Id: "Z123_www_A_eu_west", ZoneId: "Z123", Name: "www", RecordType: "A", SetId: "eu_west"},
+		{Id: "Z123_www", Err: true},
+		{Id: "Z123_www_BOGUS", Err: true},
+		{Id: "_www_A", Err: true},
+		{Id: "Z123__A", Err: true},
+		{Id: "Z123_www_A_", Err: true},
+		{Id: "", Err: true},
+	}
+
+	for _, tc := range cases {
+		zoneId, name, recordType, setId, err := parseRoute53RecordId(tc.Id)
+		if tc.Err {
+			if err == nil {
+				t.Fatalf("%q: expected an error", tc.Id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", tc.Id, err)
+		}
+		if zoneId != tc.ZoneId || name != tc.Name || recordType != tc.RecordType || setId != tc.SetId {
+			t.Fatalf("%q: got %q, %q, %q, %q", tc.Id, zoneId, name, recordType, setId)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"bytes"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/mutexkv"
@@ -15,6 +16,10 @@ func Provider() terraform.ResourceProvider {
 	// TODO: Move the validation to this, requires conditional schemas
 	// TODO: Move the configuration to this, requires validation
 
+	// Resources registered under more than one name
+	dxBgpPeer := resourceAwsDxBgpPeer()
+	dxLag := resourceAwsDxLag()
+
 	// The actual provider
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -64,6 +69,8 @@ func Provider() terraform.ResourceProvider {
 				InputDefault: "us-east-1",
 			},
 
+			"assume_role": assumeRoleSchema(),
+
 			"max_retries": &schema.Schema{
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -71,6 +78,14 @@ func Provider() terraform.ResourceProvider {
 				Description: descriptions["max_retries"],
 			},
 
+			"max_concurrent_operations": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateMaxConcurrentOperations,
+				Description:  descriptions["max_concurrent_operations"],
+			},
+
 			"allowed_account_ids": &schema.Schema{
 				Type:          schema.TypeSet,
 				Elem:          &schema.Schema{Type: schema.TypeString},
@@ -110,153 +125,187 @@ func Provider() terraform.ResourceProvider {
 			},
 		},
 
+		DataSourcesMap: map[string]*schema.Resource{
+			"aws_ami":                  dataSourceAwsAmi(),
+			"aws_dx_connection":        dataSourceAwsDxConnection(),
+			"aws_dx_connection_loa":    dataSourceAwsDxConnectionLoa(),
+			"aws_dx_virtual_interface": dataSourceAwsDxVirtualInterface(),
+			"aws_iam_role":             dataSourceAwsIamRole(),
+			"aws_vpc":                  dataSourceAwsVpc(),
+		},
+
 		ResourcesMap: map[string]*schema.Resource{
-			"aws_ami":                                      resourceAwsAmi(),
-			"aws_ami_copy":                                 resourceAwsAmiCopy(),
-			"aws_ami_from_instance":                        resourceAwsAmiFromInstance(),
-			"aws_api_gateway_account":                      resourceAwsApiGatewayAccount(),
-			"aws_api_gateway_api_key":                      resourceAwsApiGatewayApiKey(),
-			"aws_api_gateway_authorizer":                   resourceAwsApiGatewayAuthorizer(),
-			"aws_api_gateway_deployment":                   resourceAwsApiGatewayDeployment(),
-			"aws_api_gateway_integration":                  resourceAwsApiGatewayIntegration(),
-			"aws_api_gateway_integration_response":         resourceAwsApiGatewayIntegrationResponse(),
-			"aws_api_gateway_method":                       resourceAwsApiGatewayMethod(),
-			"aws_api_gateway_method_response":              resourceAwsApiGatewayMethodResponse(),
-			"aws_api_gateway_model":                        resourceAwsApiGatewayModel(),
-			"aws_api_gateway_resource":                     resourceAwsApiGatewayResource(),
-			"aws_api_gateway_rest_api":                     resourceAwsApiGatewayRestApi(),
-			"aws_app_cookie_stickiness_policy":             resourceAwsAppCookieStickinessPolicy(),
-			"aws_autoscaling_group":                        resourceAwsAutoscalingGroup(),
-			"aws_autoscaling_notification":                 resourceAwsAutoscalingNotification(),
-			"aws_autoscaling_policy":                       resourceAwsAutoscalingPolicy(),
-			"aws_autoscaling_schedule":                     resourceAwsAutoscalingSchedule(),
-			"aws_cloudformation_stack":                     resourceAwsCloudFormationStack(),
-			"aws_cloudfront_distribution":                  resourceAwsCloudFrontDistribution(),
-			"aws_cloudfront_origin_access_identity":        resourceAwsCloudFrontOriginAccessIdentity(),
-			"aws_cloudtrail":                               resourceAwsCloudTrail(),
-			"aws_cloudwatch_event_rule":                    resourceAwsCloudWatchEventRule(),
-			"aws_cloudwatch_event_target":                  resourceAwsCloudWatchEventTarget(),
-			"aws_cloudwatch_log_group":                     resourceAwsCloudWatchLogGroup(),
-			"aws_cloudwatch_log_metric_filter":             resourceAwsCloudWatchLogMetricFilter(),
-			"aws_cloudwatch_log_subscription_filter":       resourceAwsCloudwatchLogSubscriptionFilter(),
-			"aws_autoscaling_lifecycle_hook":               resourceAwsAutoscalingLifecycleHook(),
-			"aws_cloudwatch_metric_alarm":                  resourceAwsCloudWatchMetricAlarm(),
-			"aws_codedeploy_app":                           resourceAwsCodeDeployApp(),
-			"aws_codedeploy_deployment_group":              resourceAwsCodeDeployDeploymentGroup(),
-			"aws_codecommit_repository":                    resourceAwsCodeCommitRepository(),
-			"aws_customer_gateway":                         resourceAwsCustomerGateway(),
-			"aws_db_event_subscription":                    resourceAwsDbEventSubscription(),
-			"aws_db_instance":                              resourceAwsDbInstance(),
-			"aws_db_option_group":                          resourceAwsDbOptionGroup(),
-			"aws_db_parameter_group":                       resourceAwsDbParameterGroup(),
-			"aws_db_security_group":                        resourceAwsDbSecurityGroup(),
-			"aws_db_subnet_group":                          resourceAwsDbSubnetGroup(),
-			"aws_directory_service_directory":              resourceAwsDirectoryServiceDirectory(),
-			"aws_dynamodb_table":                           resourceAwsDynamoDbTable(),
-			"aws_ebs_volume":                               resourceAwsEbsVolume(),
-			"aws_ecr_repository":                           resourceAwsEcrRepository(),
-			"aws_ecr_repository_policy":                    resourceAwsEcrRepositoryPolicy(),
-			"aws_ecs_cluster":                              resourceAwsEcsCluster(),
-			"aws_ecs_service":                              resourceAwsEcsService(),
-			"aws_ecs_task_definition":                      resourceAwsEcsTaskDefinition(),
-			"aws_efs_file_system":                          resourceAwsEfsFileSystem(),
-			"aws_efs_mount_target":                         resourceAwsEfsMountTarget(),
-			"aws_eip":                                      resourceAwsEip(),
-			"aws_eip_association":                          resourceAwsEipAssociation(),
-			"aws_elasticache_cluster":                      resourceAwsElasticacheCluster(),
-			"aws_elasticache_parameter_group":              resourceAwsElasticacheParameterGroup(),
-			"aws_elasticache_security_group":               resourceAwsElasticacheSecurityGroup(),
-			"aws_elasticache_subnet_group":                 resourceAwsElasticacheSubnetGroup(),
-			"aws_elastic_beanstalk_application":            resourceAwsElasticBeanstalkApplication(),
-			"aws_elastic_beanstalk_configuration_template": resourceAwsElasticBeanstalkConfigurationTemplate(),
-			"aws_elastic_beanstalk_environment":            resourceAwsElasticBeanstalkEnvironment(),
-			"aws_elasticsearch_domain":                     resourceAwsElasticSearchDomain(),
-			"aws_elb":                                      resourceAwsElb(),
-			"aws_flow_log":                                 resourceAwsFlowLog(),
-			"aws_glacier_vault":                            resourceAwsGlacierVault(),
-			"aws_iam_access_key":                           resourceAwsIamAccessKey(),
-			"aws_iam_account_password_policy":              resourceAwsIamAccountPasswordPolicy(),
-			"aws_iam_group_policy":                         resourceAwsIamGroupPolicy(),
-			"aws_iam_group":                                resourceAwsIamGroup(),
-			"aws_iam_group_membership":                     resourceAwsIamGroupMembership(),
-			"aws_iam_instance_profile":                     resourceAwsIamInstanceProfile(),
-			"aws_iam_policy":                               resourceAwsIamPolicy(),
-			"aws_iam_policy_attachment":                    resourceAwsIamPolicyAttachment(),
-			"aws_iam_role_policy":                          resourceAwsIamRolePolicy(),
-			"aws_iam_role":                                 resourceAwsIamRole(),
-			"aws_iam_saml_provider":                        resourceAwsIamSamlProvider(),
-			"aws_iam_server_certificate":                   resourceAwsIAMServerCertificate(),
-			"aws_iam_user_policy":                          resourceAwsIamUserPolicy(),
-			"aws_iam_user_ssh_key":                         resourceAwsIamUserSshKey(),
-			"aws_iam_user":                                 resourceAwsIamUser(),
-			"aws_instance":                                 resourceAwsInstance(),
-			"aws_internet_gateway":                         resourceAwsInternetGateway(),
-			"aws_key_pair":                                 resourceAwsKeyPair(),
-			"aws_kinesis_firehose_delivery_stream":         resourceAwsKinesisFirehoseDeliveryStream(),
-			"aws_kinesis_stream":                           resourceAwsKinesisStream(),
-			"aws_kms_alias":                                resourceAwsKmsAlias(),
-			"aws_kms_key":                                  resourceAwsKmsKey(),
-			"aws_lambda_function":                          resourceAwsLambdaFunction(),
-			"aws_lambda_event_source_mapping":              resourceAwsLambdaEventSourceMapping(),
-			"aws_lambda_alias":                             resourceAwsLambdaAlias(),
-			"aws_lambda_permission":                        resourceAwsLambdaPermission(),
-			"aws_launch_configuration":                     resourceAwsLaunchConfiguration(),
-			"aws_lb_cookie_stickiness_policy":              resourceAwsLBCookieStickinessPolicy(),
-			"aws_main_route_table_association":             resourceAwsMainRouteTableAssociation(),
-			"aws_nat_gateway":                              resourceAwsNatGateway(),
-			"aws_network_acl":                              resourceAwsNetworkAcl(),
-			"aws_default_network_acl":                      resourceAwsDefaultNetworkAcl(),
-			"aws_network_acl_rule":                         resourceAwsNetworkAclRule(),
-			"aws_network_interface":                        resourceAwsNetworkInterface(),
-			"aws_opsworks_application":                     resourceAwsOpsworksApplication(),
-			"aws_opsworks_stack":                           resourceAwsOpsworksStack(),
-			"aws_opsworks_java_app_layer":                  resourceAwsOpsworksJavaAppLayer(),
-			"aws_opsworks_haproxy_layer":                   resourceAwsOpsworksHaproxyLayer(),
-			"aws_opsworks_static_web_layer":                resourceAwsOpsworksStaticWebLayer(),
-			"aws_opsworks_php_app_layer":                   resourceAwsOpsworksPhpAppLayer(),
-			"aws_opsworks_rails_app_layer":                 resourceAwsOpsworksRailsAppLayer(),
-			"aws_opsworks_nodejs_app_layer":                resourceAwsOpsworksNodejsAppLayer(),
-			"aws_opsworks_memcached_layer":                 resourceAwsOpsworksMemcachedLayer(),
-			"aws_opsworks_mysql_layer":                     resourceAwsOpsworksMysqlLayer(),
-			"aws_opsworks_ganglia_layer":                   resourceAwsOpsworksGangliaLayer(),
-			"aws_opsworks_custom_layer":                    resourceAwsOpsworksCustomLayer(),
-			"aws_opsworks_instance":                        resourceAwsOpsworksInstance(),
-			"aws_placement_group":                          resourceAwsPlacementGroup(),
-			"aws_proxy_protocol_policy":                    resourceAwsProxyProtocolPolicy(),
-			"aws_rds_cluster":                              resourceAwsRDSCluster(),
-			"aws_rds_cluster_instance":                     resourceAwsRDSClusterInstance(),
-			"aws_rds_cluster_parameter_group":              resourceAwsRDSClusterParameterGroup(),
-			"aws_redshift_cluster":                         resourceAwsRedshiftCluster(),
-			"aws_redshift_security_group":                  resourceAwsRedshiftSecurityGroup(),
-			"aws_redshift_parameter_group":                 resourceAwsRedshiftParameterGroup(),
-			"aws_redshift_subnet_group":                    resourceAwsRedshiftSubnetGroup(),
-			"aws_route53_delegation_set":                   resourceAwsRoute53DelegationSet(),
-			"aws_route53_record":                           resourceAwsRoute53Record(),
-			"aws_route53_zone_association":                 resourceAwsRoute53ZoneAssociation(),
-			"aws_route53_zone":                             resourceAwsRoute53Zone(),
-			"aws_route53_health_check":                     resourceAwsRoute53HealthCheck(),
-			"aws_route":                                    resourceAwsRoute(),
-			"aws_route_table":                              resourceAwsRouteTable(),
-			"aws_route_table_association":                  resourceAwsRouteTableAssociation(),
-			"aws_s3_bucket":                                resourceAwsS3Bucket(),
-			"aws_s3_bucket_object":                         resourceAwsS3BucketObject(),
-			"aws_s3_bucket_notification":                   resourceAwsS3BucketNotification(),
-			"aws_security_group":                           resourceAwsSecurityGroup(),
-			"aws_security_group_rule":                      resourceAwsSecurityGroupRule(),
-			"aws_spot_instance_request":                    resourceAwsSpotInstanceRequest(),
-			"aws_sqs_queue":                                resourceAwsSqsQueue(),
-			"aws_sns_topic":                                resourceAwsSnsTopic(),
-			"aws_sns_topic_subscription":                   resourceAwsSnsTopicSubscription(),
-			"aws_subnet":                                   resourceAwsSubnet(),
-			"aws_volume_attachment":                        resourceAwsVolumeAttachment(),
-			"aws_vpc_dhcp_options_association":             resourceAwsVpcDhcpOptionsAssociation(),
-			"aws_vpc_dhcp_options":                         resourceAwsVpcDhcpOptions(),
-			"aws_vpc_peering_connection":                   resourceAwsVpcPeeringConnection(),
-			"aws_vpc":                                      resourceAwsVpc(),
-			"aws_vpc_endpoint":                             resourceAwsVpcEndpoint(),
-			"aws_vpn_connection":                           resourceAwsVpnConnection(),
-			"aws_vpn_connection_route":                     resourceAwsVpnConnectionRoute(),
-			"aws_vpn_gateway":                              resourceAwsVpnGateway(),
+			"aws_ami":                                                 resourceAwsAmi(),
+			"aws_ami_copy":                                            resourceAwsAmiCopy(),
+			"aws_ami_from_instance":                                   resourceAwsAmiFromInstance(),
+			"aws_api_gateway_account":                                 resourceAwsApiGatewayAccount(),
+			"aws_api_gateway_api_key":                                 resourceAwsApiGatewayApiKey(),
+			"aws_api_gateway_authorizer":                              resourceAwsApiGatewayAuthorizer(),
+			"aws_api_gateway_deployment":                              resourceAwsApiGatewayDeployment(),
+			"aws_api_gateway_integration":                             resourceAwsApiGatewayIntegration(),
+			"aws_api_gateway_integration_response":                    resourceAwsApiGatewayIntegrationResponse(),
+			"aws_api_gateway_method":                                  resourceAwsApiGatewayMethod(),
+			"aws_api_gateway_method_response":                         resourceAwsApiGatewayMethodResponse(),
+			"aws_api_gateway_model":                                   resourceAwsApiGatewayModel(),
+			"aws_api_gateway_resource":                                resourceAwsApiGatewayResource(),
+			"aws_api_gateway_rest_api":                                resourceAwsApiGatewayRestApi(),
+			"aws_app_cookie_stickiness_policy":                        resourceAwsAppCookieStickinessPolicy(),
+			"aws_autoscaling_group":                                   resourceAwsAutoscalingGroup(),
+			"aws_autoscaling_notification":                            resourceAwsAutoscalingNotification(),
+			"aws_autoscaling_policy":                                  resourceAwsAutoscalingPolicy(),
+			"aws_autoscaling_schedule":                                resourceAwsAutoscalingSchedule(),
+			"aws_batch_compute_environment":                           resourceAwsBatchComputeEnvironment(),
+			"aws_cloudformation_stack":                                resourceAwsCloudFormationStack(),
+			"aws_cloudfront_distribution":                             resourceAwsCloudFrontDistribution(),
+			"aws_cloudfront_origin_access_identity":                   resourceAwsCloudFrontOriginAccessIdentity(),
+			"aws_cloudtrail":                                          resourceAwsCloudTrail(),
+			"aws_cloudwatch_event_rule":                               resourceAwsCloudWatchEventRule(),
+			"aws_cloudwatch_event_target":                             resourceAwsCloudWatchEventTarget(),
+			"aws_cloudwatch_log_group":                                resourceAwsCloudWatchLogGroup(),
+			"aws_cloudwatch_log_metric_filter":                        resourceAwsCloudWatchLogMetricFilter(),
+			"aws_cloudwatch_log_subscription_filter":                  resourceAwsCloudwatchLogSubscriptionFilter(),
+			"aws_autoscaling_lifecycle_hook":                          resourceAwsAutoscalingLifecycleHook(),
+			"aws_cloudwatch_metric_alarm":                             resourceAwsCloudWatchMetricAlarm(),
+			"aws_codedeploy_app":                                      resourceAwsCodeDeployApp(),
+			"aws_codedeploy_deployment_group":                         resourceAwsCodeDeployDeploymentGroup(),
+			"aws_codecommit_repository":                               resourceAwsCodeCommitRepository(),
+			"aws_customer_gateway":                                    resourceAwsCustomerGateway(),
+			"aws_db_event_subscription":                               resourceAwsDbEventSubscription(),
+			"aws_db_instance":                                         resourceAwsDbInstance(),
+			"aws_db_option_group":                                     resourceAwsDbOptionGroup(),
+			"aws_db_parameter_group":                                  resourceAwsDbParameterGroup(),
+			"aws_db_security_group":                                   resourceAwsDbSecurityGroup(),
+			"aws_db_subnet_group":                                     resourceAwsDbSubnetGroup(),
+			"aws_directconnect_bgp_peer":                              schema.DeprecatedResourceAlias(dxBgpPeer, "aws_directconnect_bgp_peer is deprecated, use aws_dx_bgp_peer instead"),
+			"aws_directconnect_connection":                            resourceAwsDirectconnectConnection(),
+			"aws_directconnect_gateway":                               resourceAwsDirectconnectGateway(),
+			"aws_directconnect_gateway_association":                   resourceAwsDirectconnectGatewayAssociation(),
+			"aws_directconnect_hosted_virtual_interface":              resourceAwsDirectconnectHostedVirtualInterface(),
+			"aws_directconnect_lag":                                   schema.DeprecatedResourceAlias(dxLag, "aws_directconnect_lag is deprecated, use aws_dx_lag instead"),
+			"aws_directconnect_hosted_virtual_interface_confirmation": resourceAwsDirectconnectHostedVirtualInterfaceConfirmation(),
+			"aws_directconnect_virtual_interface":                     resourceAwsDirectconnectVirtualInterface(),
+			"aws_directory_service_directory":                         resourceAwsDirectoryServiceDirectory(),
+			"aws_dms_replication_instance":                            resourceAwsDmsReplicationInstance(),
+			"aws_dx_bgp_peer":                                         dxBgpPeer,
+			"aws_dx_connection_association":                           resourceAwsDxConnectionAssociation(),
+			"aws_dx_gateway_association_proposal":                     resourceAwsDxGatewayAssociationProposal(),
+			"aws_dx_hosted_connection":                                resourceAwsDxHostedConnection(),
+			"aws_dx_lag":                                              dxLag,
+			"aws_dynamodb_table":                                      resourceAwsDynamoDbTable(),
+			"aws_ebs_volume":                                          resourceAwsEbsVolume(),
+			"aws_ecr_repository":                                      resourceAwsEcrRepository(),
+			"aws_ecr_repository_policy":                               resourceAwsEcrRepositoryPolicy(),
+			"aws_ecs_cluster":                                         resourceAwsEcsCluster(),
+			"aws_ecs_service":                                         resourceAwsEcsService(),
+			"aws_ecs_task_definition":                                 resourceAwsEcsTaskDefinition(),
+			"aws_efs_file_system":                                     resourceAwsEfsFileSystem(),
+			"aws_efs_mount_target":                                    resourceAwsEfsMountTarget(),
+			"aws_egress_only_internet_gateway":                        resourceAwsEgressOnlyInternetGateway(),
+			"aws_eip":                                                 resourceAwsEip(),
+			"aws_eip_association":                                     resourceAwsEipAssociation(),
+			"aws_elasticache_cluster":                                 resourceAwsElasticacheCluster(),
+			"aws_elasticache_replication_group":                       resourceAwsElasticacheReplicationGroup(),
+			"aws_elasticache_parameter_group":                         resourceAwsElasticacheParameterGroup(),
+			"aws_elasticache_security_group":                          resourceAwsElasticacheSecurityGroup(),
+			"aws_elasticache_subnet_group":                            resourceAwsElasticacheSubnetGroup(),
+			"aws_elastic_beanstalk_application":                       resourceAwsElasticBeanstalkApplication(),
+			"aws_elastic_beanstalk_configuration_template":            resourceAwsElasticBeanstalkConfigurationTemplate(),
+			"aws_elastic_beanstalk_environment":                       resourceAwsElasticBeanstalkEnvironment(),
+			"aws_elasticsearch_domain":                                resourceAwsElasticSearchDomain(),
+			"aws_elb":                                                 resourceAwsElb(),
+			"aws_flow_log":                                            resourceAwsFlowLog(),
+			"aws_glacier_vault":                                       resourceAwsGlacierVault(),
+			"aws_glue_catalog_database":                               resourceAwsGlueCatalogDatabase(),
+			"aws_guardduty_detector":                                  resourceAwsGuardDutyDetector(),
+			"aws_iam_access_key":                                      resourceAwsIamAccessKey(),
+			"aws_iam_account_password_policy":                         resourceAwsIamAccountPasswordPolicy(),
+			"aws_iam_group_policy":                                    resourceAwsIamGroupPolicy(),
+			"aws_iam_group":                                           resourceAwsIamGroup(),
+			"aws_iam_group_membership":                                resourceAwsIamGroupMembership(),
+			"aws_iam_instance_profile":                                resourceAwsIamInstanceProfile(),
+			"aws_iam_policy":                                          resourceAwsIamPolicy(),
+			"aws_iam_policy_attachment":                               resourceAwsIamPolicyAttachment(),
+			"aws_iam_role_policy":                                     resourceAwsIamRolePolicy(),
+			"aws_iam_role":                                            resourceAwsIamRole(),
+			"aws_iam_saml_provider":                                   resourceAwsIamSamlProvider(),
+			"aws_iam_server_certificate":                              resourceAwsIAMServerCertificate(),
+			"aws_iam_user_policy":                                     resourceAwsIamUserPolicy(),
+			"aws_iam_user_ssh_key":                                    resourceAwsIamUserSshKey(),
+			"aws_iam_user":                                            resourceAwsIamUser(),
+			"aws_instance":                                            resourceAwsInstance(),
+			"aws_internet_gateway":                                    resourceAwsInternetGateway(),
+			"aws_key_pair":                                            resourceAwsKeyPair(),
+			"aws_kinesis_firehose_delivery_stream":                    resourceAwsKinesisFirehoseDeliveryStream(),
+			"aws_kinesis_stream":                                      resourceAwsKinesisStream(),
+			"aws_kms_alias":                                           resourceAwsKmsAlias(),
+			"aws_kms_key":                                             resourceAwsKmsKey(),
+			"aws_lambda_function":                                     resourceAwsLambdaFunction(),
+			"aws_lambda_event_source_mapping":                         resourceAwsLambdaEventSourceMapping(),
+			"aws_lambda_alias":                                        resourceAwsLambdaAlias(),
+			"aws_lambda_permission":                                   resourceAwsLambdaPermission(),
+			"aws_launch_configuration":                                resourceAwsLaunchConfiguration(),
+			"aws_lb_cookie_stickiness_policy":                         resourceAwsLBCookieStickinessPolicy(),
+			"aws_main_route_table_association":                        resourceAwsMainRouteTableAssociation(),
+			"aws_msk_cluster":                                         resourceAwsMskCluster(),
+			"aws_nat_gateway":                                         resourceAwsNatGateway(),
+			"aws_network_acl":                                         resourceAwsNetworkAcl(),
+			"aws_default_network_acl":                                 resourceAwsDefaultNetworkAcl(),
+			"aws_network_acl_rule":                                    resourceAwsNetworkAclRule(),
+			"aws_network_interface":                                   resourceAwsNetworkInterface(),
+			"aws_network_interface_attachment":                        resourceAwsNetworkInterfaceAttachment(),
+			"aws_opsworks_application":                                resourceAwsOpsworksApplication(),
+			"aws_opsworks_stack":                                      resourceAwsOpsworksStack(),
+			"aws_opsworks_java_app_layer":                             resourceAwsOpsworksJavaAppLayer(),
+			"aws_opsworks_haproxy_layer":                              resourceAwsOpsworksHaproxyLayer(),
+			"aws_opsworks_static_web_layer":                           resourceAwsOpsworksStaticWebLayer(),
+			"aws_opsworks_php_app_layer":                              resourceAwsOpsworksPhpAppLayer(),
+			"aws_opsworks_rails_app_layer":                            resourceAwsOpsworksRailsAppLayer(),
+			"aws_opsworks_nodejs_app_layer":                           resourceAwsOpsworksNodejsAppLayer(),
+			"aws_opsworks_memcached_layer":                            resourceAwsOpsworksMemcachedLayer(),
+			"aws_opsworks_mysql_layer":                                resourceAwsOpsworksMysqlLayer(),
+			"aws_opsworks_ganglia_layer":                              resourceAwsOpsworksGangliaLayer(),
+			"aws_opsworks_custom_layer":                               resourceAwsOpsworksCustomLayer(),
+			"aws_opsworks_instance":                                   resourceAwsOpsworksInstance(),
+			"aws_placement_group":                                     resourceAwsPlacementGroup(),
+			"aws_proxy_protocol_policy":                               resourceAwsProxyProtocolPolicy(),
+			"aws_rds_cluster":                                         resourceAwsRDSCluster(),
+			"aws_rds_cluster_instance":                                resourceAwsRDSClusterInstance(),
+			"aws_rds_cluster_parameter_group":                         resourceAwsRDSClusterParameterGroup(),
+			"aws_redshift_cluster":                                    resourceAwsRedshiftCluster(),
+			"aws_redshift_security_group":                             resourceAwsRedshiftSecurityGroup(),
+			"aws_redshift_parameter_group":                            resourceAwsRedshiftParameterGroup(),
+			"aws_redshift_subnet_group":                               resourceAwsRedshiftSubnetGroup(),
+			"aws_route53_delegation_set":                              resourceAwsRoute53DelegationSet(),
+			"aws_route53_record":                                      resourceAwsRoute53Record(),
+			"aws_route53_zone_association":                            resourceAwsRoute53ZoneAssociation(),
+			"aws_route53_zone":                                        resourceAwsRoute53Zone(),
+			"aws_route53_health_check":                                resourceAwsRoute53HealthCheck(),
+			"aws_route":                                               resourceAwsRoute(),
+			"aws_route_table":                                         resourceAwsRouteTable(),
+			"aws_route_table_association":                             resourceAwsRouteTableAssociation(),
+			"aws_s3_bucket":                                           resourceAwsS3Bucket(),
+			"aws_s3_bucket_object":                                    resourceAwsS3BucketObject(),
+			"aws_s3_bucket_metric":                                    resourceAwsS3BucketMetric(),
+			"aws_s3_bucket_notification":                              resourceAwsS3BucketNotification(),
+			"aws_security_group":                                      resourceAwsSecurityGroup(),
+			"aws_security_group_rule":                                 resourceAwsSecurityGroupRule(),
+			"aws_spot_instance_request":                               resourceAwsSpotInstanceRequest(),
+			"aws_sqs_queue":                                           resourceAwsSqsQueue(),
+			"aws_sns_sms_preferences":                                 resourceAwsSnsSmsPreferences(),
+			"aws_sns_topic":                                           resourceAwsSnsTopic(),
+			"aws_sns_topic_subscription":                              resourceAwsSnsTopicSubscription(),
+			"aws_subnet":                                              resourceAwsSubnet(),
+			"aws_volume_attachment":                                   resourceAwsVolumeAttachment(),
+			"aws_vpc_dhcp_options_association":                        resourceAwsVpcDhcpOptionsAssociation(),
+			"aws_vpc_dhcp_options":                                    resourceAwsVpcDhcpOptions(),
+			"aws_vpc_peering_connection":                              resourceAwsVpcPeeringConnection(),
+			"aws_vpc_peering_connection_accepter":                     resourceAwsVpcPeeringConnectionAccepter(),
+			"aws_vpc":                                                 resourceAwsVpc(),
+			"aws_vpc_endpoint":                                        resourceAwsVpcEndpoint(),
+			"aws_vpn_connection":                                      resourceAwsVpnConnection(),
+			"aws_vpn_connection_route":                                resourceAwsVpnConnectionRoute(),
+			"aws_vpn_gateway":                                         resourceAwsVpnGateway(),
+			"aws_vpn_gateway_route_propagation":                       resourceAwsVpnGatewayRoutePropagation(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
@@ -275,8 +324,9 @@ func init() {
 		"secret_key": "The secret key for API operations. You can retrieve this\n" +
 			"from the 'Security & Credentials' section of the AWS console.",
 
-		"profile": "The profile for API operations. If not set, the default profile\n" +
-			"created with `aws configure` will be used.",
+		"profile": "The profile for API operations. If not set, the AWS_PROFILE environment\n" +
+			"variable or the default profile created with `aws configure` will be used.\n" +
+			"Profiles which assume an IAM role in the shared config file are supported.",
 
 		"shared_credentials_file": "The path to the shared credentials file. If not set\n" +
 			"this defaults to ~/.aws/credentials.",
@@ -284,10 +334,23 @@ func init() {
 		"token": "session token. A session token is only required if you are\n" +
 			"using temporary security credentials.",
 
+		"assume_role_role_arn": "The ARN of an IAM role to assume prior to making API calls.",
+
+		"assume_role_session_name": "The session name to use when assuming the role. If omitted,\n" +
+			"no session name is passed to the AssumeRole call.",
+
+		"assume_role_external_id": "The external ID to use when assuming the role. If omitted,\n" +
+			"no external ID is passed to the AssumeRole call.",
+
 		"max_retries": "The maximum number of times an AWS API request is\n" +
-			"being executed. If the API request still fails, an error is\n" +
+			"being executed. Throttled requests are retried with an\n" +
+			"exponential backoff. If the API request still fails, an error is\n" +
 			"thrown.",
 
+		"max_concurrent_operations": "The maximum number of resources created,\n" +
+			"updated, deleted or refreshed at once with this provider, to avoid\n" +
+			"being throttled by the AWS APIs. Defaults to 0, for no limit.",
+
 		"dynamodb_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n" +
 			"It's typically used to connect to dynamodb-local.",
 
@@ -300,13 +363,23 @@ func init() {
 
 		"elb_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n",
 
+		"directconnect_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n" +
+			"It's typically used to connect to a Direct Connect mock for testing.",
+
 		"insecure": "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted," +
 			"default value is `false`",
 	}
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	config := Config{
+	config := providerConfig(d)
+	return config.Client()
+}
+
+// providerConfig returns the Config of the provider with the given
+// configuration.
+func providerConfig(d *schema.ResourceData) *Config {
+	config := &Config{
 		AccessKey:        d.Get("access_key").(string),
 		SecretKey:        d.Get("secret_key").(string),
 		Profile:          d.Get("profile").(string),
@@ -319,6 +392,16 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Insecure:         d.Get("insecure").(bool),
 	}
 
+	assumeRoleList := d.Get("assume_role").(*schema.Set).List()
+	if len(assumeRoleList) == 1 {
+		assumeRole := assumeRoleList[0].(map[string]interface{})
+		config.AssumeRoleARN = assumeRole["role_arn"].(string)
+		config.AssumeRoleSessionName = assumeRole["session_name"].(string)
+		config.AssumeRoleExternalID = assumeRole["external_id"].(string)
+		log.Printf("[INFO] assume_role configuration set: (ARN: %q, SessionID: %q, ExternalID: %q)",
+			config.AssumeRoleARN, config.AssumeRoleSessionName, config.AssumeRoleExternalID)
+	}
+
 	endpointsSet := d.Get("endpoints").(*schema.Set)
 
 	for _, endpointsSetI := range endpointsSet.List() {
@@ -326,6 +409,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.IamEndpoint = endpoints["iam"].(string)
 		config.Ec2Endpoint = endpoints["ec2"].(string)
 		config.ElbEndpoint = endpoints["elb"].(string)
+		config.DirectConnectEndpoint = endpoints["directconnect"].(string)
 	}
 
 	if v, ok := d.GetOk("allowed_account_ids"); ok {
@@ -336,12 +420,54 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.ForbiddenAccountIds = v.(*schema.Set).List()
 	}
 
-	return config.Client()
+	return config
 }
 
 // This is a global MutexKV for use within this plugin.
 var awsMutexKV = mutexkv.NewMutexKV()
 
+func assumeRoleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role_arn": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: descriptions["assume_role_role_arn"],
+				},
+
+				"session_name": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: descriptions["assume_role_session_name"],
+				},
+
+				"external_id": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: descriptions["assume_role_external_id"],
+				},
+			},
+		},
+		Set: assumeRoleToHash,
+	}
+}
+
+func assumeRoleToHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["role_arn"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["session_name"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["external_id"].(string)))
+
+	return hashcode.String(buf.String())
+}
+
 func endpointsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeSet,
@@ -368,6 +494,13 @@ func endpointsSchema() *schema.Schema {
 					Default:     "",
 					Description: descriptions["elb_endpoint"],
 				},
+
+				"directconnect": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: descriptions["directconnect_endpoint"],
+				},
 			},
 		},
 		Set: endpointsToHash,
@@ -380,6 +513,7 @@ func endpointsToHash(v interface{}) int {
 	buf.WriteString(fmt.Sprintf("%s-", m["iam"].(string)))
 	buf.WriteString(fmt.Sprintf("%s-", m["ec2"].(string)))
 	buf.WriteString(fmt.Sprintf("%s-", m["elb"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["directconnect"].(string)))
 
 	return hashcode.String(buf.String())
 }
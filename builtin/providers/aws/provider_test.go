@@ -3,8 +3,10 @@ package aws
 import (
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -12,8 +14,19 @@ import (
 var testAccProviders map[string]terraform.ResourceProvider
 var testAccProvider *schema.Provider
 
+// testAccFixture is the fixture the requests of testAccProvider are sent
+// through, if any. See testAccFixtureTransport.
+var testAccFixture *resource.FixtureTransport
+
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
+	testAccProvider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		config := providerConfig(d)
+		if testAccFixture != nil {
+			config.HTTPTransport = testAccFixture
+		}
+		return config.Client()
+	}
 	testAccProviders = map[string]terraform.ResourceProvider{
 		"aws": testAccProvider,
 	}
@@ -29,6 +42,41 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+// testAccFixtureTransport returns the fixture of the test with the given
+// name, at test-fixtures/NAME.json, and sends the requests of
+// testAccProvider through it until the returned function is called. Set
+// TF_ACC_FIXTURE to "record" to record it, with real credentials, and to
+// "replay" to run the test from it, without them. Replaying a test that has
+// no fixture recorded yet fails it.
+func testAccFixtureTransport(t *testing.T, name string) (*resource.FixtureTransport, func()) {
+	path := filepath.Join("test-fixtures", name+".json")
+	if os.Getenv(resource.FixtureEnvVar) == resource.FixtureModeReplay {
+		// Replaying was asked for, so a missing fixture is a failure
+		// rather than a reason to skip.
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("Error reading fixture to replay: %s", err)
+		}
+
+		// Requests are still signed when replaying
+		for k, v := range map[string]string{
+			"AWS_ACCESS_KEY_ID":     "fixture",
+			"AWS_SECRET_ACCESS_KEY": "fixture",
+		} {
+			if os.Getenv(k) == "" {
+				os.Setenv(k, v)
+			}
+		}
+	}
+
+	ft, err := resource.NewFixtureTransport(path, "X-Amz-Target")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testAccFixture = ft
+	return ft, func() { testAccFixture = nil }
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("AWS_PROFILE"); v == "" {
 		if v := os.Getenv("AWS_ACCESS_KEY_ID"); v == "" {
@@ -0,0 +1,421 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/batch"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsBatchComputeEnvironment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsBatchComputeEnvironmentCreate,
+		Read:   resourceAwsBatchComputeEnvironmentRead,
+		Update: resourceAwsBatchComputeEnvironmentUpdate,
+		Delete: resourceAwsBatchComputeEnvironmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"compute_environment_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateBatchComputeEnvironmentType,
+			},
+
+			"service_role": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  batch.CEStateEnabled,
+			},
+
+			// compute_resources is required for MANAGED compute environments
+			// and not allowed for UNMANAGED ones. Only the vCPU counts can be
+			// changed in place.
+			"compute_resources": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateBatchComputeResourceType,
+						},
+
+						"instance_role": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"instance_type": &schema.Schema{
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"max_vcpus": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"min_vcpus": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"desired_vcpus": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+
+						"subnets": &schema.Schema{
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"security_group_ids": &schema.Schema{
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"ec2_key_pair": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"image_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"bid_percentage": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"spot_iam_fleet_role": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ecs_cluster_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status_reason": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsBatchComputeEnvironmentCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).batchconn
+
+	name := d.Get("compute_environment_name").(string)
+	ceType := d.Get("type").(string)
+
+	input := &batch.CreateComputeEnvironmentInput{
+		ComputeEnvironmentName: aws.String(name),
+		ServiceRole:            aws.String(d.Get("service_role").(string)),
+		State:                  aws.String(d.Get("state").(string)),
+		Type:                   aws.String(ceType),
+	}
+
+	computeResources := d.Get("compute_resources").([]interface{})
+	switch {
+	case ceType == batch.CETypeManaged && len(computeResources) == 0:
+		return fmt.Errorf("compute_resources is required for %s compute environments", ceType)
+	case ceType == batch.CETypeUnmanaged && len(computeResources) > 0:
+		return fmt.Errorf("compute_resources is not allowed for %s compute environments", ceType)
+	case len(computeResources) > 0:
+		input.ComputeResources = expandBatchComputeResource(computeResources[0].(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Creating Batch compute environment: %s", input)
+	resp, err := conn.CreateComputeEnvironment(input)
+	if err != nil {
+		return fmt.Errorf("Error creating Batch compute environment %s: %s", name, err)
+	}
+
+	d.SetId(aws.StringValue(resp.ComputeEnvironmentName))
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{batch.CEStatusCreating},
+		Target:     []string{batch.CEStatusValid},
+		Refresh:    batchComputeEnvironmentStatusRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Batch compute environment (%s) to become valid: %s", d.Id(), err)
+	}
+
+	return resourceAwsBatchComputeEnvironmentRead(d, meta)
+}
+
+func resourceAwsBatchComputeEnvironmentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).batchconn
+
+	ce, err := describeBatchComputeEnvironment(conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error describing Batch compute environment (%s): %s", d.Id(), err)
+	}
+	if ce == nil || aws.StringValue(ce.Status) == batch.CEStatusDeleted {
+		log.Printf("[WARN] Batch compute environment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("compute_environment_name", ce.ComputeEnvironmentName)
+	d.Set("type", ce.Type)
+	d.Set("service_role", ce.ServiceRole)
+	d.Set("state", ce.State)
+	d.Set("arn", ce.ComputeEnvironmentArn)
+	d.Set("ecs_cluster_arn", ce.EcsClusterArn)
+	d.Set("status", ce.Status)
+	d.Set("status_reason", ce.StatusReason)
+
+	if err := d.Set("compute_resources", flattenBatchComputeResource(ce.ComputeResources)); err != nil {
+		return fmt.Errorf("Error setting compute_resources of Batch compute environment (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsBatchComputeEnvironmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).batchconn
+
+	input := &batch.UpdateComputeEnvironmentInput{
+		ComputeEnvironment: aws.String(d.Id()),
+	}
+
+	requestUpdate := false
+	if d.HasChange("service_role") {
+		input.ServiceRole = aws.String(d.Get("service_role").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("state") {
+		input.State = aws.String(d.Get("state").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("compute_resources.0.min_vcpus") || d.HasChange("compute_resources.0.max_vcpus") || d.HasChange("compute_resources.0.desired_vcpus") {
+		input.ComputeResources = &batch.ComputeResourceUpdate{
+			MinvCpus: aws.Int64(int64(d.Get("compute_resources.0.min_vcpus").(int))),
+			MaxvCpus: aws.Int64(int64(d.Get("compute_resources.0.max_vcpus").(int))),
+		}
+		if v, ok := d.GetOk("compute_resources.0.desired_vcpus"); ok {
+			input.ComputeResources.DesiredvCpus = aws.Int64(int64(v.(int)))
+		}
+		requestUpdate = true
+	}
+
+	if requestUpdate {
+		log.Printf("[DEBUG] Updating Batch compute environment: %s", input)
+		if _, err := conn.UpdateComputeEnvironment(input); err != nil {
+			return fmt.Errorf("Error updating Batch compute environment (%s): %s", d.Id(), err)
+		}
+
+		if err := waitForBatchComputeEnvironmentUpdate(conn, d.Id()); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsBatchComputeEnvironmentRead(d, meta)
+}
+
+func resourceAwsBatchComputeEnvironmentDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).batchconn
+
+	// Batch refuses to delete an enabled compute environment.
+	if d.Get("state").(string) != batch.CEStateDisabled {
+		log.Printf("[DEBUG] Disabling Batch compute environment: %s", d.Id())
+		_, err := conn.UpdateComputeEnvironment(&batch.UpdateComputeEnvironmentInput{
+			ComputeEnvironment: aws.String(d.Id()),
+			State:              aws.String(batch.CEStateDisabled),
+		})
+		if err != nil {
+			return fmt.Errorf("Error disabling Batch compute environment (%s): %s", d.Id(), err)
+		}
+
+		if err := waitForBatchComputeEnvironmentUpdate(conn, d.Id()); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[DEBUG] Deleting Batch compute environment: %s", d.Id())
+	_, err := conn.DeleteComputeEnvironment(&batch.DeleteComputeEnvironmentInput{
+		ComputeEnvironment: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting Batch compute environment (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{batch.CEStatusDeleting},
+		Target:     []string{batch.CEStatusDeleted},
+		Refresh:    batchComputeEnvironmentStatusRefreshFunc(conn, d.Id()),
+		Timeout:    20 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Batch compute environment (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func waitForBatchComputeEnvironmentUpdate(conn *batch.Batch, name string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{batch.CEStatusUpdating},
+		Target:     []string{batch.CEStatusValid},
+		Refresh:    batchComputeEnvironmentStatusRefreshFunc(conn, name),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Batch compute environment (%s) to be updated: %s", name, err)
+	}
+	return nil
+}
+
+// describeBatchComputeEnvironment returns the compute environment called name,
+// or nil if there is no such compute environment.
+func describeBatchComputeEnvironment(conn *batch.Batch, name string) (*batch.ComputeEnvironmentDetail, error) {
+	resp, err := conn.DescribeComputeEnvironments(&batch.DescribeComputeEnvironmentsInput{
+		ComputeEnvironments: []*string{aws.String(name)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ce := range resp.ComputeEnvironments {
+		if ce != nil && aws.StringValue(ce.ComputeEnvironmentName) == name {
+			return ce, nil
+		}
+	}
+	return nil, nil
+}
+
+// batchComputeEnvironmentStatusRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch the status of a Batch compute environment. A compute
+// environment that can no longer be found is reported as DELETED, and one
+// that turns INVALID fails the wait with the reason Batch gives for it.
+func batchComputeEnvironmentStatusRefreshFunc(conn *batch.Batch, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ce, err := describeBatchComputeEnvironment(conn, name)
+		if err != nil {
+			return nil, "", err
+		}
+		if ce == nil {
+			return "", batch.CEStatusDeleted, nil
+		}
+
+		status := aws.StringValue(ce.Status)
+		if status == batch.CEStatusInvalid {
+			return ce, status, fmt.Errorf("Batch compute environment (%s) is %s: %s", name, status, aws.StringValue(ce.StatusReason))
+		}
+		return ce, status, nil
+	}
+}
+
+func expandBatchComputeResource(m map[string]interface{}) *batch.ComputeResource {
+	cr := &batch.ComputeResource{
+		Type:             aws.String(m["type"].(string)),
+		InstanceRole:     aws.String(m["instance_role"].(string)),
+		InstanceTypes:    expandStringList(m["instance_type"].(*schema.Set).List()),
+		MaxvCpus:         aws.Int64(int64(m["max_vcpus"].(int))),
+		MinvCpus:         aws.Int64(int64(m["min_vcpus"].(int))),
+		Subnets:          expandStringList(m["subnets"].(*schema.Set).List()),
+		SecurityGroupIds: expandStringList(m["security_group_ids"].(*schema.Set).List()),
+	}
+	if v, ok := m["desired_vcpus"].(int); ok && v > 0 {
+		cr.DesiredvCpus = aws.Int64(int64(v))
+	}
+	if v, ok := m["ec2_key_pair"].(string); ok && v != "" {
+		cr.Ec2KeyPair = aws.String(v)
+	}
+	if v, ok := m["image_id"].(string); ok && v != "" {
+		cr.ImageId = aws.String(v)
+	}
+	if v, ok := m["bid_percentage"].(int); ok && v > 0 {
+		cr.BidPercentage = aws.Int64(int64(v))
+	}
+	if v, ok := m["spot_iam_fleet_role"].(string); ok && v != "" {
+		cr.SpotIamFleetRole = aws.String(v)
+	}
+	return cr
+}
+
+func flattenBatchComputeResource(cr *batch.ComputeResource) []map[string]interface{} {
+	if cr == nil {
+		return nil
+	}
+
+	m := map[string]interface{}{
+		"type":                aws.StringValue(cr.Type),
+		"instance_role":       aws.StringValue(cr.InstanceRole),
+		"instance_type":       schema.NewSet(schema.HashString, flattenStringList(cr.InstanceTypes)),
+		"max_vcpus":           int(aws.Int64Value(cr.MaxvCpus)),
+		"min_vcpus":           int(aws.Int64Value(cr.MinvCpus)),
+		"desired_vcpus":       int(aws.Int64Value(cr.DesiredvCpus)),
+		"subnets":             schema.NewSet(schema.HashString, flattenStringList(cr.Subnets)),
+		"security_group_ids":  schema.NewSet(schema.HashString, flattenStringList(cr.SecurityGroupIds)),
+		"ec2_key_pair":        aws.StringValue(cr.Ec2KeyPair),
+		"image_id":            aws.StringValue(cr.ImageId),
+		"bid_percentage":      int(aws.Int64Value(cr.BidPercentage)),
+		"spot_iam_fleet_role": aws.StringValue(cr.SpotIamFleetRole),
+	}
+	return []map[string]interface{}{m}
+}
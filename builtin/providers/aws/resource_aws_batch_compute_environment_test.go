@@ -0,0 +1,215 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/batch"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSBatchComputeEnvironment_basic(t *testing.T) {
+	var ce batch.ComputeEnvironmentDetail
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSBatchComputeEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSBatchComputeEnvironmentConfig, rInt, rInt, rInt, rInt, rInt, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSBatchComputeEnvironmentExists("aws_batch_compute_environment.foo", &ce),
+					resource.TestCheckResourceAttr(
+						"aws_batch_compute_environment.foo", "type", "MANAGED"),
+					resource.TestCheckResourceAttr(
+						"aws_batch_compute_environment.foo", "state", "ENABLED"),
+					resource.TestCheckResourceAttr(
+						"aws_batch_compute_environment.foo", "status", "VALID"),
+					resource.TestCheckResourceAttr(
+						"aws_batch_compute_environment.foo", "compute_resources.0.max_vcpus", "16"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSBatchComputeEnvironment_updateDesiredVcpus(t *testing.T) {
+	var before, after batch.ComputeEnvironmentDetail
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSBatchComputeEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSBatchComputeEnvironmentConfig, rInt, rInt, rInt, rInt, rInt, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSBatchComputeEnvironmentExists("aws_batch_compute_environment.foo", &before),
+					resource.TestCheckResourceAttr(
+						"aws_batch_compute_environment.foo", "compute_resources.0.desired_vcpus", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSBatchComputeEnvironmentConfig, rInt, rInt, rInt, rInt, rInt, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSBatchComputeEnvironmentExists("aws_batch_compute_environment.foo", &after),
+					testAccCheckAWSBatchComputeEnvironmentNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr(
+						"aws_batch_compute_environment.foo", "compute_resources.0.desired_vcpus", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSBatchComputeEnvironmentNotRecreated(before, after *batch.ComputeEnvironmentDetail) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// Every compute environment gets an ECS cluster of its own.
+		if *before.EcsClusterArn != *after.EcsClusterArn {
+			return fmt.Errorf("Expected Batch compute environment to be updated in place, ECS cluster changed from %s to %s",
+				*before.EcsClusterArn, *after.EcsClusterArn)
+		}
+		return nil
+	}
+}
+
+func testAccCheckAWSBatchComputeEnvironmentDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).batchconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_batch_compute_environment" {
+			continue
+		}
+
+		ce, err := describeBatchComputeEnvironment(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if ce != nil && *ce.Status != batch.CEStatusDeleted {
+			return fmt.Errorf("Batch compute environment %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSBatchComputeEnvironmentExists(n string, v *batch.ComputeEnvironmentDetail) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Batch compute environment ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).batchconn
+		ce, err := describeBatchComputeEnvironment(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if ce == nil {
+			return fmt.Errorf("Batch compute environment %s not found", rs.Primary.ID)
+		}
+
+		*v = *ce
+		return nil
+	}
+}
+
+const testAccAWSBatchComputeEnvironmentConfig = `
+resource "aws_iam_role" "ecs_instance_role" {
+  name = "tf_acc_test_batch_inst_role_%d"
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "ec2.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy_attachment" "ecs_instance_role" {
+  name = "${aws_iam_role.ecs_instance_role.name}"
+  roles = ["${aws_iam_role.ecs_instance_role.name}"]
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AmazonEC2ContainerServiceforEC2Role"
+}
+
+resource "aws_iam_instance_profile" "ecs_instance_role" {
+  name = "tf_acc_test_batch_inst_profile_%d"
+  roles = ["${aws_iam_role.ecs_instance_role.name}"]
+}
+
+resource "aws_iam_role" "aws_batch_service_role" {
+  name = "tf_acc_test_batch_svc_role_%d"
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "batch.amazonaws.com"
+      }
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy_attachment" "aws_batch_service_role" {
+  name = "${aws_iam_role.aws_batch_service_role.name}"
+  roles = ["${aws_iam_role.aws_batch_service_role.name}"]
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AWSBatchServiceRole"
+}
+
+resource "aws_vpc" "foo" {
+  cidr_block = "10.1.0.0/16"
+  tags {
+    Name = "tf-acc-test-batch-compute-environment"
+  }
+}
+
+resource "aws_subnet" "foo" {
+  vpc_id = "${aws_vpc.foo.id}"
+  cidr_block = "10.1.1.0/24"
+}
+
+resource "aws_security_group" "foo" {
+  name = "tf_acc_test_batch_sg_%d"
+  vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_batch_compute_environment" "foo" {
+  compute_environment_name = "tf_acc_test_%d"
+  type = "MANAGED"
+  service_role = "${aws_iam_role.aws_batch_service_role.arn}"
+
+  compute_resources {
+    type = "EC2"
+    instance_role = "${aws_iam_instance_profile.ecs_instance_role.arn}"
+    instance_type = ["c4.large"]
+    min_vcpus = 0
+    max_vcpus = 16
+    desired_vcpus = %d
+    subnets = ["${aws_subnet.foo.id}"]
+    security_group_ids = ["${aws_security_group.foo.id}"]
+  }
+
+  depends_on = ["aws_iam_policy_attachment.aws_batch_service_role"]
+}
+`
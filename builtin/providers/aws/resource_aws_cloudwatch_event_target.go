@@ -44,7 +44,7 @@ func resourceAwsCloudWatchEventTarget() *schema.Resource {
 			"input": &schema.Schema{
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"input_path"},
+				ConflictsWith: []string{"input_path", "input_transformer"},
 				// We could be normalizing the JSON here,
 				// but for built-in targets input may not be JSON
 			},
@@ -52,7 +52,26 @@ func resourceAwsCloudWatchEventTarget() *schema.Resource {
 			"input_path": &schema.Schema{
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"input"},
+				ConflictsWith: []string{"input", "input_transformer"},
+			},
+
+			"input_transformer": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"input", "input_path"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"input_paths": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+						"input_template": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -122,6 +141,10 @@ func resourceAwsCloudWatchEventTargetRead(d *schema.ResourceData, meta interface
 	d.Set("input", t.Input)
 	d.Set("input_path", t.InputPath)
 
+	if err := d.Set("input_transformer", flattenCloudWatchEventInputTransformer(t.InputTransformer)); err != nil {
+		return fmt.Errorf("Error setting input_transformer: %s", err)
+	}
+
 	return nil
 }
 
@@ -195,6 +218,9 @@ func buildPutTargetInputStruct(d *schema.ResourceData) *events.PutTargetsInput {
 	if v, ok := d.GetOk("input_path"); ok {
 		e.InputPath = aws.String(v.(string))
 	}
+	if v, ok := d.GetOk("input_transformer"); ok {
+		e.InputTransformer = expandCloudWatchEventInputTransformer(v.([]interface{}))
+	}
 
 	input := events.PutTargetsInput{
 		Rule:    aws.String(d.Get("rule").(string)),
@@ -203,3 +229,39 @@ func buildPutTargetInputStruct(d *schema.ResourceData) *events.PutTargetsInput {
 
 	return &input
 }
+
+func expandCloudWatchEventInputTransformer(l []interface{}) *events.InputTransformer {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	transformer := &events.InputTransformer{
+		InputTemplate: aws.String(m["input_template"].(string)),
+	}
+
+	if v, ok := m["input_paths"].(map[string]interface{}); ok && len(v) > 0 {
+		paths := make(map[string]*string)
+		for k, path := range v {
+			paths[k] = aws.String(path.(string))
+		}
+		transformer.InputPathsMap = paths
+	}
+
+	return transformer
+}
+
+func flattenCloudWatchEventInputTransformer(transformer *events.InputTransformer) []interface{} {
+	if transformer == nil {
+		return []interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"input_paths": aws.StringValueMap(transformer.InputPathsMap),
+	}
+	if transformer.InputTemplate != nil {
+		m["input_template"] = *transformer.InputTemplate
+	}
+
+	return []interface{}{m}
+}
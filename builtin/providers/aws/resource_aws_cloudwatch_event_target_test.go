@@ -87,6 +87,71 @@ func TestAccAWSCloudWatchEventTarget_full(t *testing.T) {
 	})
 }
 
+func TestAccAWSCloudWatchEventTarget_inputTransformer(t *testing.T) {
+	var target events.Target
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudWatchEventTargetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudWatchEventTargetConfigInputTransformer("is in state"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchEventTargetExists("aws_cloudwatch_event_target.transformer", &target),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.transformer", "input_transformer.#", "1"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.transformer",
+						"input_transformer.0.input_paths.instance", "$.detail.instance"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.transformer",
+						"input_transformer.0.input_paths.status", "$.detail.status"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.transformer",
+						"input_transformer.0.input_template", "\"<instance> is in state <status>\""),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSCloudWatchEventTargetConfigInputTransformer("changed to"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchEventTargetExists("aws_cloudwatch_event_target.transformer", &target),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.transformer",
+						"input_transformer.0.input_template", "\"<instance> changed to <status>\""),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudWatchEventInputTransformer_roundTrip(t *testing.T) {
+	l := []interface{}{
+		map[string]interface{}{
+			"input_paths": map[string]interface{}{
+				"instance": "$.detail.instance",
+			},
+			"input_template": "\"<instance>\"",
+		},
+	}
+
+	transformer := expandCloudWatchEventInputTransformer(l)
+	if *transformer.InputTemplate != "\"<instance>\"" {
+		t.Fatalf("Unexpected input template: %s", *transformer.InputTemplate)
+	}
+	if *transformer.InputPathsMap["instance"] != "$.detail.instance" {
+		t.Fatalf("Unexpected input paths: %#v", transformer.InputPathsMap)
+	}
+
+	flattened := flattenCloudWatchEventInputTransformer(transformer)
+	m := flattened[0].(map[string]interface{})
+	if m["input_template"] != "\"<instance>\"" {
+		t.Fatalf("Unexpected flattened input template: %#v", m)
+	}
+	if m["input_paths"].(map[string]string)["instance"] != "$.detail.instance" {
+		t.Fatalf("Unexpected flattened input paths: %#v", m)
+	}
+
+	if v := expandCloudWatchEventInputTransformer([]interface{}{}); v != nil {
+		t.Fatalf("Expected no input transformer, got: %s", v)
+	}
+}
+
 func testAccCheckCloudWatchEventTargetExists(n string, rule *events.Target) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -249,3 +314,30 @@ resource "aws_kinesis_stream" "test_stream" {
     shard_count = 1
 }
 `
+
+func testAccAWSCloudWatchEventTargetConfigInputTransformer(verb string) string {
+	return fmt.Sprintf(`
+resource "aws_cloudwatch_event_rule" "foo" {
+	name = "tf-acc-cw-event-rule-input-transformer"
+	schedule_expression = "rate(1 hour)"
+}
+
+resource "aws_cloudwatch_event_target" "transformer" {
+	rule = "${aws_cloudwatch_event_rule.foo.name}"
+	target_id = "tf-acc-cw-target-input-transformer"
+	arn = "${aws_sns_topic.transformer.arn}"
+
+	input_transformer {
+		input_paths {
+			instance = "$.detail.instance"
+			status = "$.detail.status"
+		}
+		input_template = "\"<instance> %s <status>\""
+	}
+}
+
+resource "aws_sns_topic" "transformer" {
+	name = "tf-acc-input-transformer"
+}
+`, verb)
+}
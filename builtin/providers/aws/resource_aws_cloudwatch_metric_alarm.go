@@ -32,20 +32,24 @@ func resourceAwsCloudWatchMetricAlarm() *schema.Resource {
 				Required: true,
 			},
 			"metric_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"metric_query"},
 			},
 			"namespace": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"metric_query"},
 			},
 			"period": &schema.Schema{
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"metric_query"},
 			},
 			"statistic": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"metric_query"},
 			},
 			"threshold": &schema.Schema{
 				Type:     schema.TypeFloat,
@@ -67,8 +71,9 @@ func resourceAwsCloudWatchMetricAlarm() *schema.Resource {
 				Optional: true,
 			},
 			"dimensions": &schema.Schema{
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:          schema.TypeMap,
+				Optional:      true,
+				ConflictsWith: []string{"metric_query"},
 			},
 			"insufficient_data_actions": &schema.Schema{
 				Type:     schema.TypeSet,
@@ -83,8 +88,69 @@ func resourceAwsCloudWatchMetricAlarm() *schema.Resource {
 				Set:      schema.HashString,
 			},
 			"unit": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"metric_query"},
+				ValidateFunc:  validateCloudWatchMetricAlarmUnit,
+			},
+			"metric_query": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"metric_name", "namespace", "period", "statistic", "dimensions", "unit"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"expression": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"return_data": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"metric": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"metric_name": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"namespace": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"period": &schema.Schema{
+										Type:     schema.TypeInt,
+										Required: true,
+									},
+									"stat": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"unit": &schema.Schema{
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateCloudWatchMetricAlarmUnit,
+									},
+									"dimensions": &schema.Schema{
+										Type:     schema.TypeMap,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -93,6 +159,10 @@ func resourceAwsCloudWatchMetricAlarm() *schema.Resource {
 func resourceAwsCloudWatchMetricAlarmCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).cloudwatchconn
 
+	if err := validateAwsCloudWatchMetricAlarm(d); err != nil {
+		return err
+	}
+
 	params := getAwsCloudWatchPutMetricAlarmInput(d)
 
 	log.Printf("[DEBUG] Creating CloudWatch Metric Alarm: %#v", params)
@@ -126,7 +196,9 @@ func resourceAwsCloudWatchMetricAlarmRead(d *schema.ResourceData, meta interface
 	d.Set("alarm_description", a.AlarmDescription)
 	d.Set("alarm_name", a.AlarmName)
 	d.Set("comparison_operator", a.ComparisonOperator)
-	d.Set("dimensions", a.Dimensions)
+	if err := d.Set("dimensions", flattenCloudWatchMetricAlarmDimensions(a.Dimensions)); err != nil {
+		log.Printf("[WARN] Error setting Dimensions: %s", err)
+	}
 	d.Set("evaluation_periods", a.EvaluationPeriods)
 
 	if err := d.Set("insufficient_data_actions", _strArrPtrToList(a.InsufficientDataActions)); err != nil {
@@ -143,11 +215,40 @@ func resourceAwsCloudWatchMetricAlarmRead(d *schema.ResourceData, meta interface
 	d.Set("threshold", a.Threshold)
 	d.Set("unit", a.Unit)
 
+	if err := d.Set("metric_query", flattenCloudWatchMetricAlarmMetrics(a.Metrics)); err != nil {
+		log.Printf("[WARN] Error setting Metric Query: %s", err)
+	}
+
 	return nil
 }
 
 func resourceAwsCloudWatchMetricAlarmUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).cloudwatchconn
+
+	// Toggling actions on and off (e.g. during maintenance) doesn't need the
+	// whole alarm to be put again.
+	if d.HasChange("actions_enabled") && !resourceAwsCloudWatchMetricAlarmHasOtherChanges(d, "actions_enabled") {
+		names := []*string{aws.String(d.Id())}
+
+		var err error
+		if d.Get("actions_enabled").(bool) {
+			log.Printf("[DEBUG] Enabling actions of CloudWatch Metric Alarm: %s", d.Id())
+			_, err = conn.EnableAlarmActions(&cloudwatch.EnableAlarmActionsInput{AlarmNames: names})
+		} else {
+			log.Printf("[DEBUG] Disabling actions of CloudWatch Metric Alarm: %s", d.Id())
+			_, err = conn.DisableAlarmActions(&cloudwatch.DisableAlarmActionsInput{AlarmNames: names})
+		}
+		if err != nil {
+			return fmt.Errorf("Updating actions of metric alarm failed: %s", err)
+		}
+
+		return resourceAwsCloudWatchMetricAlarmRead(d, meta)
+	}
+
+	if err := validateAwsCloudWatchMetricAlarm(d); err != nil {
+		return err
+	}
+
 	params := getAwsCloudWatchPutMetricAlarmInput(d)
 
 	log.Printf("[DEBUG] Updating CloudWatch Metric Alarm: %#v", params)
@@ -160,6 +261,23 @@ func resourceAwsCloudWatchMetricAlarmUpdate(d *schema.ResourceData, meta interfa
 	return resourceAwsCloudWatchMetricAlarmRead(d, meta)
 }
 
+// resourceAwsCloudWatchMetricAlarmHasOtherChanges reports whether any
+// attribute other than the excluded ones has changed.
+func resourceAwsCloudWatchMetricAlarmHasOtherChanges(d *schema.ResourceData, exclude ...string) bool {
+	excluded := make(map[string]bool, len(exclude))
+	for _, k := range exclude {
+		excluded[k] = true
+	}
+
+	for k := range resourceAwsCloudWatchMetricAlarm().Schema {
+		if !excluded[k] && d.HasChange(k) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func resourceAwsCloudWatchMetricAlarmDelete(d *schema.ResourceData, meta interface{}) error {
 	p, err := getAwsCloudWatchMetricAlarm(d, meta)
 	if err != nil {
@@ -191,13 +309,18 @@ func getAwsCloudWatchPutMetricAlarmInput(d *schema.ResourceData) cloudwatch.PutM
 		AlarmName:          aws.String(d.Get("alarm_name").(string)),
 		ComparisonOperator: aws.String(d.Get("comparison_operator").(string)),
 		EvaluationPeriods:  aws.Int64(int64(d.Get("evaluation_periods").(int))),
-		MetricName:         aws.String(d.Get("metric_name").(string)),
-		Namespace:          aws.String(d.Get("namespace").(string)),
-		Period:             aws.Int64(int64(d.Get("period").(int))),
-		Statistic:          aws.String(d.Get("statistic").(string)),
 		Threshold:          aws.Float64(d.Get("threshold").(float64)),
 	}
 
+	if v, ok := d.GetOk("metric_query"); ok {
+		params.Metrics = expandCloudWatchMetricAlarmMetrics(v.([]interface{}))
+	} else {
+		params.MetricName = aws.String(d.Get("metric_name").(string))
+		params.Namespace = aws.String(d.Get("namespace").(string))
+		params.Period = aws.Int64(int64(d.Get("period").(int)))
+		params.Statistic = aws.String(d.Get("statistic").(string))
+	}
+
 	if v := d.Get("actions_enabled"); v != nil {
 		params.ActionsEnabled = aws.Bool(v.(bool))
 	}
@@ -237,7 +360,31 @@ func getAwsCloudWatchPutMetricAlarmInput(d *schema.ResourceData) cloudwatch.PutM
 		params.OKActions = okActions
 	}
 
-	a := d.Get("dimensions").(map[string]interface{})
+	if _, ok := d.GetOk("metric_query"); !ok {
+		params.Dimensions = expandCloudWatchMetricAlarmDimensions(d.Get("dimensions").(map[string]interface{}))
+	}
+
+	return params
+}
+
+// validateAwsCloudWatchMetricAlarm checks that the alarm watches either a
+// single metric or a set of metric queries. The schema already rejects
+// configurations that set both.
+func validateAwsCloudWatchMetricAlarm(d *schema.ResourceData) error {
+	if _, ok := d.GetOk("metric_query"); ok {
+		return nil
+	}
+
+	for _, k := range []string{"metric_name", "namespace", "period", "statistic"} {
+		if _, ok := d.GetOk(k); !ok {
+			return fmt.Errorf("%q is required unless metric_query is set", k)
+		}
+	}
+
+	return nil
+}
+
+func expandCloudWatchMetricAlarmDimensions(a map[string]interface{}) []*cloudwatch.Dimension {
 	dimensions := make([]*cloudwatch.Dimension, 0, len(a))
 	for k, v := range a {
 		dimensions = append(dimensions, &cloudwatch.Dimension{
@@ -245,9 +392,81 @@ func getAwsCloudWatchPutMetricAlarmInput(d *schema.ResourceData) cloudwatch.PutM
 			Value: aws.String(v.(string)),
 		})
 	}
-	params.Dimensions = dimensions
+	return dimensions
+}
 
-	return params
+func flattenCloudWatchMetricAlarmDimensions(dimensions []*cloudwatch.Dimension) map[string]interface{} {
+	result := make(map[string]interface{}, len(dimensions))
+	for _, dim := range dimensions {
+		result[aws.StringValue(dim.Name)] = aws.StringValue(dim.Value)
+	}
+	return result
+}
+
+func expandCloudWatchMetricAlarmMetrics(l []interface{}) []*cloudwatch.MetricDataQuery {
+	queries := make([]*cloudwatch.MetricDataQuery, 0, len(l))
+	for _, raw := range l {
+		q := raw.(map[string]interface{})
+
+		query := &cloudwatch.MetricDataQuery{
+			Id:         aws.String(q["id"].(string)),
+			ReturnData: aws.Bool(q["return_data"].(bool)),
+		}
+		if v := q["expression"].(string); v != "" {
+			query.Expression = aws.String(v)
+		}
+		if v := q["label"].(string); v != "" {
+			query.Label = aws.String(v)
+		}
+
+		if v := q["metric"].([]interface{}); len(v) > 0 && v[0] != nil {
+			m := v[0].(map[string]interface{})
+			stat := &cloudwatch.MetricStat{
+				Metric: &cloudwatch.Metric{
+					MetricName: aws.String(m["metric_name"].(string)),
+					Namespace:  aws.String(m["namespace"].(string)),
+					Dimensions: expandCloudWatchMetricAlarmDimensions(m["dimensions"].(map[string]interface{})),
+				},
+				Period: aws.Int64(int64(m["period"].(int))),
+				Stat:   aws.String(m["stat"].(string)),
+			}
+			if v := m["unit"].(string); v != "" {
+				stat.Unit = aws.String(v)
+			}
+			query.MetricStat = stat
+		}
+
+		queries = append(queries, query)
+	}
+	return queries
+}
+
+func flattenCloudWatchMetricAlarmMetrics(queries []*cloudwatch.MetricDataQuery) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(queries))
+	for _, query := range queries {
+		q := map[string]interface{}{
+			"id":          aws.StringValue(query.Id),
+			"expression":  aws.StringValue(query.Expression),
+			"label":       aws.StringValue(query.Label),
+			"return_data": aws.BoolValue(query.ReturnData),
+		}
+
+		if stat := query.MetricStat; stat != nil && stat.Metric != nil {
+			q["metric"] = []map[string]interface{}{
+				map[string]interface{}{
+					"metric_name": aws.StringValue(stat.Metric.MetricName),
+					"namespace":   aws.StringValue(stat.Metric.Namespace),
+					"period":      int(aws.Int64Value(stat.Period)),
+					"stat":        aws.StringValue(stat.Stat),
+					"unit":        aws.StringValue(stat.Unit),
+					"dimensions":  flattenCloudWatchMetricAlarmDimensions(stat.Metric.Dimensions),
+				},
+			}
+		}
+
+		result = append(result, q)
+	}
+	return result
 }
 
 func getAwsCloudWatchMetricAlarm(d *schema.ResourceData, meta interface{}) (*cloudwatch.MetricAlarm, error) {
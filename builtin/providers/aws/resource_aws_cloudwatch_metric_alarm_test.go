@@ -2,10 +2,12 @@ package aws
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -30,6 +32,256 @@ func TestAccAWSCloudWatchMetricAlarm_basic(t *testing.T) {
 	})
 }
 
+func TestAccAWSCloudWatchMetricAlarm_actionsEnabled(t *testing.T) {
+	var alarm cloudwatch.MetricAlarm
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudWatchMetricAlarmDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudWatchMetricAlarmConfigActionsEnabled(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchMetricAlarmExists("aws_cloudwatch_metric_alarm.foobar", &alarm),
+					testAccCheckCloudWatchMetricAlarmActionsEnabled(&alarm, false),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "actions_enabled", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSCloudWatchMetricAlarmConfigActionsEnabled(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchMetricAlarmExists("aws_cloudwatch_metric_alarm.foobar", &alarm),
+					testAccCheckCloudWatchMetricAlarmActionsEnabled(&alarm, true),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "actions_enabled", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSCloudWatchMetricAlarmConfigActionsEnabled(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchMetricAlarmExists("aws_cloudwatch_metric_alarm.foobar", &alarm),
+					testAccCheckCloudWatchMetricAlarmActionsEnabled(&alarm, false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSCloudWatchMetricAlarm_unit(t *testing.T) {
+	var before, after cloudwatch.MetricAlarm
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudWatchMetricAlarmDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudWatchMetricAlarmConfigUnit(rInt, "Bytes"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchMetricAlarmExists("aws_cloudwatch_metric_alarm.foobar", &before),
+					testAccCheckCloudWatchMetricAlarmUnit(&before, "Bytes"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "unit", "Bytes"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSCloudWatchMetricAlarmConfigUnit(rInt, "Kilobytes"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchMetricAlarmExists("aws_cloudwatch_metric_alarm.foobar", &after),
+					testAccCheckCloudWatchMetricAlarmUnit(&after, "Kilobytes"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "unit", "Kilobytes"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSCloudWatchMetricAlarm_metricQuery(t *testing.T) {
+	var alarm cloudwatch.MetricAlarm
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudWatchMetricAlarmDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudWatchMetricAlarmConfigMetricQuery(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchMetricAlarmExists("aws_cloudwatch_metric_alarm.foobar", &alarm),
+					testAccCheckCloudWatchMetricAlarmMetricQueries(&alarm, 3),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "metric_query.#", "3"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "metric_query.0.id", "e1"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "metric_query.0.expression", "m1+m2"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "metric_query.0.return_data", "true"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "metric_query.1.metric.0.metric_name", "NetworkIn"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "metric_query.2.metric.0.metric_name", "NetworkOut"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "metric_name", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSCloudWatchMetricAlarm_directconnect(t *testing.T) {
+	var alarm cloudwatch.MetricAlarm
+	location := testAccDxLagPreCheck(t)
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudWatchMetricAlarmDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudWatchMetricAlarmConfigDirectconnect(rInt, location),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchMetricAlarmExists("aws_cloudwatch_metric_alarm.foobar", &alarm),
+					testAccCheckCloudWatchMetricAlarmDimension(&alarm, "aws_directconnect_connection.foo", "ConnectionId"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "namespace", "AWS/DX"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_metric_alarm.foobar", "dimensions.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudWatchMetricAlarmDimensions(t *testing.T) {
+	dimensions := []*cloudwatch.Dimension{
+		&cloudwatch.Dimension{
+			Name:  aws.String("ConnectionId"),
+			Value: aws.String("dxcon-abc123"),
+		},
+	}
+
+	flattened := flattenCloudWatchMetricAlarmDimensions(dimensions)
+	expected := map[string]interface{}{
+		"ConnectionId": "dxcon-abc123",
+	}
+	if !reflect.DeepEqual(flattened, expected) {
+		t.Fatalf("Bad dimensions:\n\nexpected: %#v\n\ngot: %#v", expected, flattened)
+	}
+
+	expanded := expandCloudWatchMetricAlarmDimensions(flattened)
+	if !reflect.DeepEqual(expanded, dimensions) {
+		t.Fatalf("Bad dimensions:\n\nexpected: %#v\n\ngot: %#v", dimensions, expanded)
+	}
+}
+
+func TestCloudWatchMetricAlarmMetrics(t *testing.T) {
+	queries := []interface{}{
+		map[string]interface{}{
+			"id":          "e1",
+			"expression":  "m1+m2",
+			"label":       "Total",
+			"return_data": true,
+			"metric":      []interface{}{},
+		},
+		map[string]interface{}{
+			"id":          "m1",
+			"expression":  "",
+			"label":       "",
+			"return_data": false,
+			"metric": []interface{}{
+				map[string]interface{}{
+					"metric_name": "NetworkIn",
+					"namespace":   "AWS/EC2",
+					"period":      120,
+					"stat":        "Sum",
+					"unit":        "Bytes",
+					"dimensions": map[string]interface{}{
+						"InstanceId": "i-abc123",
+					},
+				},
+			},
+		},
+	}
+
+	expanded := expandCloudWatchMetricAlarmMetrics(queries)
+	if len(expanded) != 2 {
+		t.Fatalf("Expected 2 metric queries, got %d", len(expanded))
+	}
+	if expanded[0].MetricStat != nil {
+		t.Fatalf("Expected no metric stat on an expression, got %#v", expanded[0].MetricStat)
+	}
+	if expanded[1].Expression != nil {
+		t.Fatalf("Expected no expression on a metric, got %q", *expanded[1].Expression)
+	}
+
+	flattened := flattenCloudWatchMetricAlarmMetrics(expanded)
+	if flattened[0]["expression"] != "m1+m2" || flattened[0]["return_data"] != true {
+		t.Fatalf("Bad expression query: %#v", flattened[0])
+	}
+	if _, ok := flattened[0]["metric"]; ok {
+		t.Fatalf("Expected no metric on an expression query: %#v", flattened[0])
+	}
+
+	metric := flattened[1]["metric"].([]map[string]interface{})[0]
+	expected := map[string]interface{}{
+		"metric_name": "NetworkIn",
+		"namespace":   "AWS/EC2",
+		"period":      120,
+		"stat":        "Sum",
+		"unit":        "Bytes",
+		"dimensions": map[string]interface{}{
+			"InstanceId": "i-abc123",
+		},
+	}
+	if !reflect.DeepEqual(metric, expected) {
+		t.Fatalf("Bad metric:\n\nexpected: %#v\n\ngot: %#v", expected, metric)
+	}
+}
+
+func testAccCheckCloudWatchMetricAlarmMetricQueries(alarm *cloudwatch.MetricAlarm, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(alarm.Metrics) != count {
+			return fmt.Errorf("Expected %d metric queries, got %d", count, len(alarm.Metrics))
+		}
+		if alarm.MetricName != nil {
+			return fmt.Errorf("Expected no single metric on the alarm, got %q", *alarm.MetricName)
+		}
+		return nil
+	}
+}
+
+func testAccCheckCloudWatchMetricAlarmDimension(alarm *cloudwatch.MetricAlarm, n, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		for _, dim := range alarm.Dimensions {
+			if aws.StringValue(dim.Name) != name {
+				continue
+			}
+			if v := aws.StringValue(dim.Value); v != rs.Primary.ID {
+				return fmt.Errorf("Expected dimension %s to be %q, got %q", name, rs.Primary.ID, v)
+			}
+			return nil
+		}
+		return fmt.Errorf("Expected a %s dimension, got: %#v", name, alarm.Dimensions)
+	}
+}
+
+func testAccCheckCloudWatchMetricAlarmActionsEnabled(alarm *cloudwatch.MetricAlarm, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if aws.BoolValue(alarm.ActionsEnabled) != enabled {
+			return fmt.Errorf("Expected actions enabled to be %t, got %t", enabled, aws.BoolValue(alarm.ActionsEnabled))
+		}
+		return nil
+	}
+}
+
+func testAccCheckCloudWatchMetricAlarmUnit(alarm *cloudwatch.MetricAlarm, unit string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if aws.StringValue(alarm.Unit) != unit {
+			return fmt.Errorf("Expected unit to be %q, got %q", unit, aws.StringValue(alarm.Unit))
+		}
+		return nil
+	}
+}
+
 func testAccCheckCloudWatchMetricAlarmExists(n string, alarm *cloudwatch.MetricAlarm) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -93,3 +345,104 @@ resource "aws_cloudwatch_metric_alarm" "foobar" {
     insufficient_data_actions = []
 }
 `)
+
+func testAccAWSCloudWatchMetricAlarmConfigActionsEnabled(enabled bool) string {
+	return fmt.Sprintf(`
+resource "aws_cloudwatch_metric_alarm" "foobar" {
+    alarm_name = "terraform-test-foobar6"
+    comparison_operator = "GreaterThanOrEqualToThreshold"
+    evaluation_periods = "2"
+    metric_name = "CPUUtilization"
+    namespace = "AWS/EC2"
+    period = "120"
+    statistic = "Average"
+    threshold = "80"
+    alarm_description = "This metric monitor ec2 cpu utilization"
+    actions_enabled = %t
+}
+`, enabled)
+}
+
+func testAccAWSCloudWatchMetricAlarmConfigUnit(rInt int, unit string) string {
+	return fmt.Sprintf(`
+resource "aws_cloudwatch_metric_alarm" "foobar" {
+    alarm_name = "terraform-test-unit-%d"
+    comparison_operator = "GreaterThanOrEqualToThreshold"
+    evaluation_periods = "2"
+    metric_name = "NetworkIn"
+    namespace = "AWS/EC2"
+    period = "120"
+    statistic = "Average"
+    threshold = "1000000"
+    unit = "%s"
+    alarm_description = "This metric monitors ec2 network in"
+}
+`, rInt, unit)
+}
+
+func testAccAWSCloudWatchMetricAlarmConfigMetricQuery(rInt int) string {
+	return fmt.Sprintf(`
+resource "aws_cloudwatch_metric_alarm" "foobar" {
+    alarm_name = "terraform-test-foobar-%d"
+    comparison_operator = "GreaterThanOrEqualToThreshold"
+    evaluation_periods = "2"
+    threshold = "1000000"
+    alarm_description = "This metric monitors total ec2 network traffic"
+
+    metric_query {
+        id = "e1"
+        expression = "m1+m2"
+        label = "Total network traffic"
+        return_data = true
+    }
+
+    metric_query {
+        id = "m1"
+        metric {
+            metric_name = "NetworkIn"
+            namespace = "AWS/EC2"
+            period = "120"
+            stat = "Sum"
+            unit = "Bytes"
+        }
+    }
+
+    metric_query {
+        id = "m2"
+        metric {
+            metric_name = "NetworkOut"
+            namespace = "AWS/EC2"
+            period = "120"
+            stat = "Sum"
+            unit = "Bytes"
+        }
+    }
+}
+`, rInt)
+}
+
+func testAccAWSCloudWatchMetricAlarmConfigDirectconnect(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "aws_directconnect_connection" "foo" {
+    connection_name = "tf-dx-alarm-%d"
+    bandwidth = "1Gbps"
+    location = "%s"
+}
+
+resource "aws_cloudwatch_metric_alarm" "foobar" {
+    alarm_name = "terraform-test-dx-%d"
+    comparison_operator = "LessThanThreshold"
+    evaluation_periods = "1"
+    metric_name = "ConnectionState"
+    namespace = "AWS/DX"
+    period = "60"
+    statistic = "Minimum"
+    threshold = "1"
+    alarm_description = "This metric monitors the state of a Direct Connect connection"
+
+    dimensions {
+        ConnectionId = "${aws_directconnect_connection.foo.id}"
+    }
+}
+`, rInt, location, rInt)
+}
@@ -44,8 +44,9 @@ func resourceAwsDbInstance() *schema.Resource {
 			},
 
 			"password": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
 			},
 
 			"engine": &schema.Schema{
@@ -233,7 +234,8 @@ func resourceAwsDbInstance() *schema.Resource {
 			},
 
 			// apply_immediately is used to determine when the update modifications
-			// take place.
+			// take place. Unless it is set, modifications are queued up for the
+			// next maintenance window and the update doesn't wait for them.
 			// See http://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/Overview.DBInstance.Modifying.html
 			"apply_immediately": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -296,6 +298,11 @@ func resourceAwsDbInstance() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"iam_database_authentication_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -305,6 +312,12 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 	conn := meta.(*AWSClient).rdsconn
 	tags := tagsFromMapRDS(d.Get("tags").(map[string]interface{}))
 
+	if d.Get("iam_database_authentication_enabled").(bool) {
+		if err := validateDbInstanceIAMDatabaseAuthentication(d.Get("engine").(string)); err != nil {
+			return err
+		}
+	}
+
 	identifier := d.Get("identifier").(string)
 	// Generate a unique ID for the user
 	if identifier == "" {
@@ -362,6 +375,10 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.OptionGroupName = aws.String(attr.(string))
 		}
 
+		if attr, ok := d.GetOk("iam_database_authentication_enabled"); ok {
+			opts.EnableIAMDatabaseAuthentication = aws.Bool(attr.(bool))
+		}
+
 		log.Printf("[DEBUG] DB Instance Replica create configuration: %#v", opts)
 		_, err := conn.CreateDBInstanceReadReplica(&opts)
 		if err != nil {
@@ -422,6 +439,10 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.StorageType = aws.String(attr.(string))
 		}
 
+		if attr, ok := d.GetOk("iam_database_authentication_enabled"); ok {
+			opts.EnableIAMDatabaseAuthentication = aws.Bool(attr.(bool))
+		}
+
 		log.Printf("[DEBUG] DB Instance restore from snapshot configuration: %s", opts)
 		_, err := conn.RestoreDBInstanceFromDBSnapshot(&opts)
 		if err != nil {
@@ -573,6 +594,10 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.KmsKeyId = aws.String(attr.(string))
 		}
 
+		if attr, ok := d.GetOk("iam_database_authentication_enabled"); ok {
+			opts.EnableIAMDatabaseAuthentication = aws.Bool(attr.(bool))
+		}
+
 		log.Printf("[DEBUG] DB Instance create configuration: %#v", opts)
 		var err error
 		err = resource.Retry(5*time.Minute, func() *resource.RetryError {
@@ -666,6 +691,7 @@ func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("status", v.DBInstanceStatus)
 	d.Set("storage_encrypted", v.StorageEncrypted)
+	d.Set("iam_database_authentication_enabled", v.IAMDatabaseAuthenticationEnabled)
 	if v.OptionGroupMemberships != nil {
 		d.Set("option_group_name", v.OptionGroupMemberships[0].OptionGroupName)
 	}
@@ -911,6 +937,18 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 		requestUpdate = true
 	}
 
+	if d.HasChange("iam_database_authentication_enabled") {
+		enabled := d.Get("iam_database_authentication_enabled").(bool)
+		if enabled {
+			if err := validateDbInstanceIAMDatabaseAuthentication(d.Get("engine").(string)); err != nil {
+				return err
+			}
+		}
+		d.SetPartial("iam_database_authentication_enabled")
+		req.EnableIAMDatabaseAuthentication = aws.Bool(enabled)
+		requestUpdate = true
+	}
+
 	log.Printf("[DEBUG] Send DB Instance Modification request: %#v", requestUpdate)
 	if requestUpdate {
 		log.Printf("[DEBUG] DB Instance Modification request: %#v", req)
@@ -918,6 +956,25 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 		if err != nil {
 			return fmt.Errorf("Error modifying DB Instance %s: %s", d.Id(), err)
 		}
+
+		if d.Get("apply_immediately").(bool) {
+			log.Println(
+				"[INFO] Waiting for DB Instance modifications to be applied")
+
+			stateConf := &resource.StateChangeConf{
+				Pending: []string{"backing-up", "modifying", "resetting-master-credentials",
+					"maintenance", "renaming", "rebooting", "upgrading"},
+				Target:     []string{"available"},
+				Refresh:    resourceAwsDbInstanceStateRefreshFunc(d, meta),
+				Timeout:    80 * time.Minute,
+				MinTimeout: 10 * time.Second,
+				Delay:      30 * time.Second, // Wait 30 secs for the modification to start
+			}
+
+			if _, err := stateConf.WaitForState(); err != nil {
+				return fmt.Errorf("Error waiting for DB Instance %s to be modified: %s", d.Id(), err)
+			}
+		}
 	}
 
 	// separate request to promote a database
@@ -987,6 +1044,25 @@ func resourceAwsDbInstanceRetrieve(
 	return resp.DBInstances[0], nil
 }
 
+// validateDbInstanceIAMDatabaseAuthentication returns an error if IAM
+// database authentication can't be used with the given engine. An empty
+// engine, as on replicas and snapshot restores where it is inherited, is
+// left for RDS to check.
+func validateDbInstanceIAMDatabaseAuthentication(engine string) error {
+	if engine == "" {
+		return nil
+	}
+
+	for _, prefix := range []string{"mysql", "postgres", "aurora"} {
+		if strings.HasPrefix(strings.ToLower(engine), prefix) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"iam_database_authentication_enabled is only supported for MySQL, PostgreSQL and Aurora engines, got %q", engine)
+}
+
 func resourceAwsDbInstanceStateRefreshFunc(
 	d *schema.ResourceData, meta interface{}) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
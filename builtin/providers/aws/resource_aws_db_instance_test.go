@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"regexp"
@@ -16,6 +17,9 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/rds"
 )
 
@@ -52,6 +56,57 @@ func TestAccAWSDBInstance_basic(t *testing.T) {
 	})
 }
 
+func TestResourceAwsDbInstanceUpdate_applyImmediately(t *testing.T) {
+	for _, applyImmediately := range []bool{true, false} {
+		// Capture the modify request instead of sending it.
+		var req *rds.ModifyDBInstanceInput
+		errStop := errors.New("request not sent")
+		conn := rds.New(session.New(&aws.Config{
+			Credentials: credentials.NewStaticCredentials("accessKey", "secretKey", ""),
+			Region:      aws.String("us-west-2"),
+		}))
+		conn.Handlers.Send.Clear()
+		conn.Handlers.Send.PushBack(func(r *request.Request) {
+			req, _ = r.Params.(*rds.ModifyDBInstanceInput)
+			r.Error = errStop
+		})
+		conn.Handlers.Retry.Clear()
+
+		state := &terraform.InstanceState{
+			ID: "foobarbaz-test-terraform",
+			Attributes: map[string]string{
+				"allocated_storage": "10",
+			},
+		}
+		diff := &terraform.InstanceDiff{
+			Attributes: map[string]*terraform.ResourceAttrDiff{
+				"allocated_storage": &terraform.ResourceAttrDiff{
+					Old: "10",
+					New: "20",
+				},
+				"apply_immediately": &terraform.ResourceAttrDiff{
+					Old: "",
+					New: fmt.Sprintf("%t", applyImmediately),
+				},
+			},
+		}
+
+		_, err := resourceAwsDbInstance().Apply(state, diff, &AWSClient{rdsconn: conn})
+		if err == nil || !strings.Contains(err.Error(), errStop.Error()) {
+			t.Fatalf("Expected the modify request to be stopped, got: %v", err)
+		}
+		if req == nil {
+			t.Fatalf("Expected a ModifyDBInstance request")
+		}
+		if req.ApplyImmediately == nil || *req.ApplyImmediately != applyImmediately {
+			t.Fatalf("Expected ApplyImmediately to be %t, got: %#v", applyImmediately, req.ApplyImmediately)
+		}
+		if req.AllocatedStorage == nil || *req.AllocatedStorage != 20 {
+			t.Fatalf("Expected AllocatedStorage to be 20, got: %#v", req.AllocatedStorage)
+		}
+	}
+}
+
 func TestAccAWSDBInstance_kmsKey(t *testing.T) {
 	var v rds.DBInstance
 	keyRegex := regexp.MustCompile("^arn:aws:kms:")
@@ -176,6 +231,70 @@ func TestAccAWSDBInstance_enhancedMonitoring(t *testing.T) {
 	})
 }
 
+func TestAccAWSDBInstance_iamAuth(t *testing.T) {
+	var before, after rds.DBInstance
+	ri := acctest.RandInt()
+
+	testCheckNotRecreated := func(*terraform.State) error {
+		if *before.InstanceCreateTime != *after.InstanceCreateTime {
+			return fmt.Errorf("DB Instance was recreated")
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSDBInstanceConfigIAMAuth(ri, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &before),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "iam_database_authentication_enabled", "true"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSDBInstanceConfigIAMAuth(ri, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &after),
+					testCheckNotRecreated,
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "iam_database_authentication_enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateDbInstanceIAMDatabaseAuthentication(t *testing.T) {
+	cases := []struct {
+		Engine   string
+		ErrCount int
+	}{
+		{Engine: "", ErrCount: 0},
+		{Engine: "mysql", ErrCount: 0},
+		{Engine: "MySQL", ErrCount: 0},
+		{Engine: "postgres", ErrCount: 0},
+		{Engine: "aurora-postgresql", ErrCount: 0},
+		{Engine: "mariadb", ErrCount: 1},
+		{Engine: "oracle-se1", ErrCount: 1},
+		{Engine: "sqlserver-ex", ErrCount: 1},
+	}
+
+	for _, tc := range cases {
+		err := validateDbInstanceIAMDatabaseAuthentication(tc.Engine)
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("Expected engine %q to support IAM authentication, got: %s", tc.Engine, err)
+		}
+		if tc.ErrCount > 0 && err == nil {
+			t.Fatalf("Expected engine %q not to support IAM authentication", tc.Engine)
+		}
+	}
+}
+
 func testAccCheckAWSDBInstanceDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).rdsconn
 
@@ -430,6 +549,25 @@ resource "aws_db_instance" "bar" {
 	parameter_group_name = "default.mysql5.6"
 }`
 
+func testAccAWSDBInstanceConfigIAMAuth(rInt int, enabled bool) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "bar" {
+	identifier = "tf-iam-auth-%d"
+	allocated_storage = 10
+	engine = "mysql"
+	engine_version = "5.7.16"
+	instance_class = "db.m4.large"
+	name = "baz"
+	password = "barbarbarbar"
+	username = "foo"
+	backup_retention_period = 0
+	skip_final_snapshot = true
+	apply_immediately = true
+
+	iam_database_authentication_enabled = %t
+}`, rInt, enabled)
+}
+
 var testAccAWSDBInstanceConfigKmsKeyId = `
 resource "aws_kms_key" "foo" {
     description = "Terraform acc test %s"
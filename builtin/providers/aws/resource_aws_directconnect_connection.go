@@ -0,0 +1,238 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/logging"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dxLog is the logger of the Direct Connect resources, so that their logs
+// can be told apart from those of the rest of the provider.
+var dxLog = logging.NewLogger("aws.directconnect")
+
+func resourceAwsDirectconnectConnection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDirectconnectConnectionCreate,
+		Read:   resourceAwsDirectconnectConnectionRead,
+		Update: resourceAwsDirectconnectConnectionUpdate,
+		Delete: resourceAwsDirectconnectConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Direct Connect has no way to rename a connection.
+			"connection_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"bandwidth": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"location": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"aws_device": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"connection_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsDirectconnectConnectionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	req := &directconnect.CreateConnectionInput{
+		ConnectionName: aws.String(d.Get("connection_name").(string)),
+		Bandwidth:      aws.String(d.Get("bandwidth").(string)),
+		Location:       aws.String(d.Get("location").(string)),
+	}
+	if tags := tagsFromMapDX(d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+		req.Tags = tags
+	}
+
+	dxLog.Printf("[DEBUG] Creating Direct Connect connection: %#v", req)
+	resp, err := conn.CreateConnection(req)
+	if err != nil {
+		return fmt.Errorf("Error creating Direct Connect connection: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.ConnectionId))
+	dxLog.Printf("[INFO] Direct Connect connection ID: %s", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.ConnectionStateOrdering,
+			directconnect.ConnectionStateRequested,
+			directconnect.ConnectionStatePending,
+		},
+		Target: []string{
+			directconnect.ConnectionStateAvailable,
+			directconnect.ConnectionStateDown,
+		},
+		Refresh:    dxConnectionStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect connection (%s) to become available: %s", d.Id(), err)
+	}
+
+	return resourceAwsDirectconnectConnectionRead(d, meta)
+}
+
+func resourceAwsDirectconnectConnectionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient)
+	conn := client.dirconn
+
+	// A connection that was just created may not be returned yet, so it is
+	// only considered deleted once it has been found before.
+	var connRaw interface{}
+	var state string
+	err := resource.RetryNotFound(1*time.Minute, func() error {
+		var err error
+		connRaw, state, err = dxConnectionStateRefreshFunc(conn, d.Id())()
+		if err == nil && d.IsNewResource() && state == directconnect.ConnectionStateDeleted {
+			return &resource.NotFoundError{
+				Message: fmt.Sprintf("Direct Connect connection (%s) not found", d.Id()),
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect connection (%s): %s", d.Id(), err)
+	}
+	if state == directconnect.ConnectionStateDeleted || state == directconnect.ConnectionStateRejected {
+		dxLog.Printf("[WARN] Direct Connect connection (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	c := connRaw.(*directconnect.Connection)
+	d.Set("connection_name", c.ConnectionName)
+	d.Set("bandwidth", c.Bandwidth)
+	d.Set("location", c.Location)
+	if c.AwsDeviceV2 != nil {
+		d.Set("aws_device", c.AwsDeviceV2)
+	} else {
+		d.Set("aws_device", c.AwsDevice)
+	}
+	d.Set("connection_state", c.ConnectionState)
+	d.Set("arn", dxConnectionArn(client, c))
+	if err := d.Set("tags", tagsToMapDX(c.Tags)); err != nil {
+		return fmt.Errorf("Error setting tags of Direct Connect connection (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsDirectconnectConnectionUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	if err := setTagsDX(conn, d, d.Get("arn").(string)); err != nil {
+		return fmt.Errorf("Error updating tags of Direct Connect connection (%s): %s", d.Id(), err)
+	}
+
+	return resourceAwsDirectconnectConnectionRead(d, meta)
+}
+
+func resourceAwsDirectconnectConnectionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	dxLog.Printf("[DEBUG] Deleting Direct Connect connection: %s", d.Id())
+	_, err := conn.DeleteConnection(&directconnect.DeleteConnectionInput{
+		ConnectionId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isNoSuchDxConnectionErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Direct Connect connection (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.ConnectionStateOrdering,
+			directconnect.ConnectionStateRequested,
+			directconnect.ConnectionStatePending,
+			directconnect.ConnectionStateAvailable,
+			directconnect.ConnectionStateDown,
+			directconnect.ConnectionStateDeleting,
+		},
+		Target:     []string{directconnect.ConnectionStateDeleted},
+		Refresh:    dxConnectionStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect connection (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// dxConnectionStateRefreshFunc returns a resource.StateRefreshFunc that is
+// used to watch a Direct Connect connection. A connection that can no longer
+// be found is reported in the "deleted" state.
+func dxConnectionStateRefreshFunc(conn directConnectAPI, connectionId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeConnections(&directconnect.DescribeConnectionsInput{
+			ConnectionId: aws.String(connectionId),
+		})
+		if err != nil {
+			if isNoSuchDxConnectionErr(err) {
+				return "", directconnect.ConnectionStateDeleted, nil
+			}
+			return nil, "", err
+		}
+
+		for _, c := range resp.Connections {
+			if c == nil {
+				continue
+			}
+			if aws.StringValue(c.ConnectionId) == connectionId {
+				return c, aws.StringValue(c.ConnectionState), nil
+			}
+		}
+
+		return "", directconnect.ConnectionStateDeleted, nil
+	}
+}
+
+// dxConnectionArn builds the ARN of connection c, which lives in the account
+// owning it.
+func dxConnectionArn(client *AWSClient, c *directconnect.Connection) string {
+	accountId := aws.StringValue(c.OwnerAccount)
+	if accountId == "" {
+		accountId = client.accountid
+	}
+	return fmt.Sprintf("arn:aws:directconnect:%s:%s:dxcon/%s", client.region, accountId, aws.StringValue(c.ConnectionId))
+}
@@ -0,0 +1,152 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDirectconnectConnection_basic(t *testing.T) {
+	location := testAccDxLagPreCheck(t)
+	connectionName := fmt.Sprintf("tf-dx-connection-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDirectconnectConnectionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectConnectionConfig, connectionName, location),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectConnectionExists("aws_directconnect_connection.foo"),
+					resource.TestCheckResourceAttr("aws_directconnect_connection.foo", "connection_name", connectionName),
+					resource.TestCheckResourceAttr("aws_directconnect_connection.foo", "bandwidth", "1Gbps"),
+					resource.TestCheckResourceAttr("aws_directconnect_connection.foo", "location", location),
+					resource.TestCheckResourceAttr("aws_directconnect_connection.foo", "tags.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsDirectconnectConnectionRead_available(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeConnections": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"connections": [{"connectionId": "dxcon-abcde123", "connectionName": "dxcon", "bandwidth": "1Gbps", "location": "EqDC2", "connectionState": "available", "ownerAccount": "123456789012", "awsDeviceV2": "EqDC2-123h49s71dabc"}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDirectconnectConnection().Data(&terraform.InstanceState{ID: "dxcon-abcde123"})
+	client := &AWSClient{dirconn: conn, region: "us-east-1"}
+	if err := resourceAwsDirectconnectConnectionRead(d, client); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if name := d.Get("connection_name").(string); name != "dxcon" {
+		t.Fatalf("Unexpected connection_name: %q", name)
+	}
+	if state := d.Get("connection_state").(string); state != directconnect.ConnectionStateAvailable {
+		t.Fatalf("Expected connection_state to be available, got: %q", state)
+	}
+	if device := d.Get("aws_device").(string); device != "EqDC2-123h49s71dabc" {
+		t.Fatalf("Unexpected aws_device: %q", device)
+	}
+	if arn := d.Get("arn").(string); arn != "arn:aws:directconnect:us-east-1:123456789012:dxcon/dxcon-abcde123" {
+		t.Fatalf("Unexpected ARN: %q", arn)
+	}
+}
+
+func TestResourceAwsDirectconnectConnectionRead_gone(t *testing.T) {
+	cases := map[string]*dxMockResponse{
+		"deleted": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"connections": [{"connectionId": "dxcon-abcde123", "connectionState": "deleted"}]}`,
+		},
+		"rejected": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"connections": [{"connectionId": "dxcon-abcde123", "connectionState": "rejected"}]}`,
+		},
+		"not found": &dxMockResponse{
+			StatusCode: 400,
+			Body:       `{"__type": "DirectConnectClientException", "message": "Connection ID dxcon-abcde123 does not exist"}`,
+		},
+	}
+
+	for name, resp := range cases {
+		closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+			"DescribeConnections": resp,
+		})
+
+		d := resourceAwsDirectconnectConnection().Data(&terraform.InstanceState{ID: "dxcon-abcde123"})
+		err := resourceAwsDirectconnectConnectionRead(d, &AWSClient{dirconn: conn})
+		closeFunc()
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got: %s", name, err)
+		}
+		if d.Id() != "" {
+			t.Fatalf("%s: Expected ID to be cleared, got: %q", name, d.Id())
+		}
+	}
+}
+
+func testAccCheckAwsDirectconnectConnectionDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dirconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_directconnect_connection" {
+			continue
+		}
+
+		_, state, err := dxConnectionStateRefreshFunc(conn, rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state != directconnect.ConnectionStateDeleted {
+			return fmt.Errorf("Direct Connect connection (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAwsDirectconnectConnectionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dirconn
+		_, state, err := dxConnectionStateRefreshFunc(conn, rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state == directconnect.ConnectionStateDeleted {
+			return fmt.Errorf("Direct Connect connection (%s) not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccDirectconnectConnectionConfig = `
+resource "aws_directconnect_connection" "foo" {
+  connection_name = "%s"
+  bandwidth = "1Gbps"
+  location = "%s"
+
+  tags {
+    Name = "terraform-testacc-dxcon"
+  }
+}
+`
@@ -0,0 +1,172 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsDirectconnectGateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDirectconnectGatewayCreate,
+		Read:   resourceAwsDirectconnectGatewayRead,
+		Delete: resourceAwsDirectconnectGatewayDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"amazon_side_asn": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAmazonSideAsn,
+			},
+
+			"owner_account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsDirectconnectGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	req := &directconnect.CreateDirectConnectGatewayInput{
+		DirectConnectGatewayName: aws.String(d.Get("name").(string)),
+	}
+	if v, ok := d.GetOk("amazon_side_asn"); ok {
+		req.AmazonSideAsn = aws.Int64(int64(v.(int)))
+	}
+
+	dxLog.Printf("[DEBUG] Creating Direct Connect gateway: %#v", req)
+	resp, err := conn.CreateDirectConnectGateway(req)
+	if err != nil {
+		return fmt.Errorf("Error creating Direct Connect gateway: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.DirectConnectGateway.DirectConnectGatewayId))
+	dxLog.Printf("[INFO] Direct Connect gateway ID: %s", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{directconnect.GatewayStatePending},
+		Target:     []string{directconnect.GatewayStateAvailable},
+		Refresh:    dxGatewayStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect gateway (%s) to become available: %s", d.Id(), err)
+	}
+
+	return resourceAwsDirectconnectGatewayRead(d, meta)
+}
+
+func resourceAwsDirectconnectGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	gwRaw, state, err := dxGatewayStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect gateway (%s): %s", d.Id(), err)
+	}
+	if state == directconnect.GatewayStateDeleted {
+		dxLog.Printf("[WARN] Direct Connect gateway (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	gw := gwRaw.(*directconnect.Gateway)
+	d.Set("name", gw.DirectConnectGatewayName)
+	d.Set("amazon_side_asn", gw.AmazonSideAsn)
+	d.Set("owner_account_id", gw.OwnerAccount)
+
+	return nil
+}
+
+func resourceAwsDirectconnectGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	dxLog.Printf("[DEBUG] Deleting Direct Connect gateway: %s", d.Id())
+	_, err := conn.DeleteDirectConnectGateway(&directconnect.DeleteDirectConnectGatewayInput{
+		DirectConnectGatewayId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isNoSuchDxGatewayErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Direct Connect gateway (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.GatewayStatePending,
+			directconnect.GatewayStateAvailable,
+			directconnect.GatewayStateDeleting,
+		},
+		Target:     []string{directconnect.GatewayStateDeleted},
+		Refresh:    dxGatewayStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect gateway (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// dxGatewayStateRefreshFunc returns a resource.StateRefreshFunc that is used
+// to watch a Direct Connect gateway. A gateway that can no longer be found is
+// reported in the "deleted" state.
+func dxGatewayStateRefreshFunc(conn directConnectAPI, dxGatewayId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeDirectConnectGateways(&directconnect.DescribeDirectConnectGatewaysInput{
+			DirectConnectGatewayId: aws.String(dxGatewayId),
+		})
+		if err != nil {
+			if isNoSuchDxGatewayErr(err) {
+				return "", directconnect.GatewayStateDeleted, nil
+			}
+			return nil, "", err
+		}
+
+		for _, gw := range resp.DirectConnectGateways {
+			if gw == nil {
+				continue
+			}
+			if aws.StringValue(gw.DirectConnectGatewayId) == dxGatewayId {
+				return gw, aws.StringValue(gw.DirectConnectGatewayState), nil
+			}
+		}
+
+		return "", directconnect.GatewayStateDeleted, nil
+	}
+}
+
+// isNoSuchDxGatewayErr returns true if err is the error Direct Connect
+// returns when asked about a Direct Connect gateway that doesn't exist.
+func isNoSuchDxGatewayErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok || awsErr.Code() != "DirectConnectClientException" {
+		return false
+	}
+	msg := strings.ToLower(awsErr.Message())
+	return strings.Contains(msg, "does not exist") || strings.Contains(msg, "not found")
+}
@@ -0,0 +1,225 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsDirectconnectGatewayAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDirectconnectGatewayAssociationCreate,
+		Read:   resourceAwsDirectconnectGatewayAssociationRead,
+		Update: resourceAwsDirectconnectGatewayAssociationUpdate,
+		Delete: resourceAwsDirectconnectGatewayAssociationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"dx_gateway_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The virtual private gateway or transit gateway to associate
+			// with the Direct Connect gateway.
+			"associated_gateway_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// Without prefixes, AWS advertises the CIDRs of the associated
+			// gateway's VPC. Unlike on virtual interfaces, they can be
+			// changed in place.
+			"allowed_prefixes": func() *schema.Schema {
+				s := dxRouteFilterPrefixesSchema()
+				s.ForceNew = false
+				s.Computed = true
+				return s
+			}(),
+
+			"associated_gateway_owner_account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"associated_gateway_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dx_gateway_owner_account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsDirectconnectGatewayAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	req := &directconnect.CreateDirectConnectGatewayAssociationInput{
+		DirectConnectGatewayId: aws.String(d.Get("dx_gateway_id").(string)),
+		GatewayId:              aws.String(d.Get("associated_gateway_id").(string)),
+	}
+	if v, ok := d.GetOk("allowed_prefixes"); ok {
+		req.AddAllowedPrefixesToDirectConnectGateway = expandDxRouteFilterPrefixes(v.(*schema.Set).List())
+	}
+
+	dxLog.Printf("[DEBUG] Creating Direct Connect gateway association: %#v", req)
+	resp, err := conn.CreateDirectConnectGatewayAssociation(req)
+	if err != nil {
+		return fmt.Errorf("Error creating Direct Connect gateway association: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.DirectConnectGatewayAssociation.AssociationId))
+	dxLog.Printf("[INFO] Direct Connect gateway association ID: %s", d.Id())
+
+	if err := waitForDxGatewayAssociation(conn, d.Id(), directconnect.GatewayAssociationStateAssociating); err != nil {
+		return err
+	}
+
+	return resourceAwsDirectconnectGatewayAssociationRead(d, meta)
+}
+
+func resourceAwsDirectconnectGatewayAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	assocRaw, state, err := dxGatewayAssociationStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect gateway association (%s): %s", d.Id(), err)
+	}
+	if state == directconnect.GatewayAssociationStateDisassociated {
+		dxLog.Printf("[WARN] Direct Connect gateway association (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	assoc := assocRaw.(*directconnect.GatewayAssociation)
+	d.Set("dx_gateway_id", assoc.DirectConnectGatewayId)
+	d.Set("dx_gateway_owner_account_id", assoc.DirectConnectGatewayOwnerAccount)
+	if gw := assoc.AssociatedGateway; gw != nil {
+		d.Set("associated_gateway_id", gw.Id)
+		d.Set("associated_gateway_owner_account_id", gw.OwnerAccount)
+		d.Set("associated_gateway_type", gw.Type)
+	}
+	if err := d.Set("allowed_prefixes", flattenDxRouteFilterPrefixes(assoc.AllowedPrefixesToDirectConnectGateway)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceAwsDirectconnectGatewayAssociationUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	if d.HasChange("allowed_prefixes") {
+		o, n := d.GetChange("allowed_prefixes")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		req := &directconnect.UpdateDirectConnectGatewayAssociationInput{
+			AssociationId:                               aws.String(d.Id()),
+			AddAllowedPrefixesToDirectConnectGateway:    expandDxRouteFilterPrefixes(ns.Difference(os).List()),
+			RemoveAllowedPrefixesToDirectConnectGateway: expandDxRouteFilterPrefixes(os.Difference(ns).List()),
+		}
+
+		dxLog.Printf("[DEBUG] Updating Direct Connect gateway association: %#v", req)
+		if _, err := conn.UpdateDirectConnectGatewayAssociation(req); err != nil {
+			return fmt.Errorf("Error updating Direct Connect gateway association (%s): %s", d.Id(), err)
+		}
+
+		if err := waitForDxGatewayAssociation(conn, d.Id(), directconnect.GatewayAssociationStateUpdating); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsDirectconnectGatewayAssociationRead(d, meta)
+}
+
+func resourceAwsDirectconnectGatewayAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	dxLog.Printf("[DEBUG] Deleting Direct Connect gateway association: %s", d.Id())
+	_, err := conn.DeleteDirectConnectGatewayAssociation(&directconnect.DeleteDirectConnectGatewayAssociationInput{
+		AssociationId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isNoSuchDxGatewayErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Direct Connect gateway association (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.GatewayAssociationStateAssociating,
+			directconnect.GatewayAssociationStateAssociated,
+			directconnect.GatewayAssociationStateUpdating,
+			directconnect.GatewayAssociationStateDisassociating,
+		},
+		Target:     []string{directconnect.GatewayAssociationStateDisassociated},
+		Refresh:    dxGatewayAssociationStateRefreshFunc(conn, d.Id()),
+		Timeout:    30 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect gateway association (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// waitForDxGatewayAssociation waits for the Direct Connect gateway
+// association with the given ID to leave the given pending state. Associating
+// a gateway can take a long time, as the routes are propagated to all the
+// virtual interfaces of the Direct Connect gateway.
+func waitForDxGatewayAssociation(conn directConnectAPI, associationId, pending string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{pending},
+		Target:     []string{directconnect.GatewayAssociationStateAssociated},
+		Refresh:    dxGatewayAssociationStateRefreshFunc(conn, associationId),
+		Timeout:    30 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect gateway association (%s) to become associated: %s", associationId, err)
+	}
+
+	return nil
+}
+
+// dxGatewayAssociationStateRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch a Direct Connect gateway association. An association
+// that can no longer be found is reported in the "disassociated" state.
+func dxGatewayAssociationStateRefreshFunc(conn directConnectAPI, associationId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeDirectConnectGatewayAssociations(&directconnect.DescribeDirectConnectGatewayAssociationsInput{
+			AssociationId: aws.String(associationId),
+		})
+		if err != nil {
+			if isNoSuchDxGatewayErr(err) {
+				return "", directconnect.GatewayAssociationStateDisassociated, nil
+			}
+			return nil, "", err
+		}
+
+		for _, assoc := range resp.DirectConnectGatewayAssociations {
+			if assoc == nil {
+				continue
+			}
+			if aws.StringValue(assoc.AssociationId) == associationId {
+				return assoc, aws.StringValue(assoc.AssociationState), nil
+			}
+		}
+
+		return "", directconnect.GatewayAssociationStateDisassociated, nil
+	}
+}
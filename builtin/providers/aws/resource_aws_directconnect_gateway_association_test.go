@@ -0,0 +1,162 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDirectconnectGatewayAssociation_basic(t *testing.T) {
+	resourceName := "aws_directconnect_gateway_association.foo"
+	gatewayName := fmt.Sprintf("tf-dxg-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDirectconnectGatewayAssociationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectGatewayAssociationConfig, gatewayName, "10.255.255.0/28"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectGatewayAssociationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "associated_gateway_type", directconnect.GatewayTypeVirtualPrivateGateway),
+					resource.TestCheckResourceAttr(resourceName, "allowed_prefixes.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectGatewayAssociationConfig, gatewayName, "10.255.255.16/28"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectGatewayAssociationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "allowed_prefixes.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsDirectconnectGatewayAssociationRead_disassociated(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeDirectConnectGatewayAssociations": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"directConnectGatewayAssociations": []}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDirectconnectGatewayAssociation().Data(&terraform.InstanceState{
+		ID: "c9ea6b93-4be3-4b2b-99bb-0b0bexample",
+	})
+	if err := resourceAwsDirectconnectGatewayAssociationRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected ID to be cleared for a disassociated gateway, got: %q", d.Id())
+	}
+}
+
+func TestResourceAwsDirectconnectGatewayAssociationRead_associated(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeDirectConnectGatewayAssociations": &dxMockResponse{
+			StatusCode: 200,
+			Body: `{"directConnectGatewayAssociations": [{
+				"associationId": "c9ea6b93-4be3-4b2b-99bb-0b0bexample",
+				"associationState": "associated",
+				"directConnectGatewayId": "5f294f92-bafb-4011-916d-9b0bexample",
+				"directConnectGatewayOwnerAccount": "123456789012",
+				"associatedGateway": {"id": "vgw-abcde123", "ownerAccount": "123456789012", "type": "virtualPrivateGateway"},
+				"allowedPrefixesToDirectConnectGateway": [{"cidr": "10.0.0.0/16"}]
+			}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDirectconnectGatewayAssociation().Data(&terraform.InstanceState{
+		ID: "c9ea6b93-4be3-4b2b-99bb-0b0bexample",
+	})
+	if err := resourceAwsDirectconnectGatewayAssociationRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	expected := map[string]string{
+		"dx_gateway_id":           "5f294f92-bafb-4011-916d-9b0bexample",
+		"associated_gateway_id":   "vgw-abcde123",
+		"associated_gateway_type": directconnect.GatewayTypeVirtualPrivateGateway,
+	}
+	for k, v := range expected {
+		if actual := d.Get(k).(string); actual != v {
+			t.Fatalf("Expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+	if n := d.Get("allowed_prefixes.#").(int); n != 1 {
+		t.Fatalf("Expected 1 allowed prefix, got %d", n)
+	}
+}
+
+func testAccCheckAwsDirectconnectGatewayAssociationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dirconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_directconnect_gateway_association" {
+			continue
+		}
+
+		_, state, err := dxGatewayAssociationStateRefreshFunc(conn, rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state != directconnect.GatewayAssociationStateDisassociated {
+			return fmt.Errorf("Direct Connect gateway association (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return testAccCheckAwsDirectconnectGatewayDestroy(s)
+}
+
+func testAccCheckAwsDirectconnectGatewayAssociationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dirconn
+		_, state, err := dxGatewayAssociationStateRefreshFunc(conn, rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state != directconnect.GatewayAssociationStateAssociated {
+			return fmt.Errorf("Direct Connect gateway association (%s) is %s", rs.Primary.ID, state)
+		}
+
+		return nil
+	}
+}
+
+const testAccDirectconnectGatewayAssociationConfig = `
+resource "aws_directconnect_gateway" "foo" {
+  name = "%s"
+  amazon_side_asn = 64512
+}
+
+resource "aws_vpc" "foo" {
+  cidr_block = "10.255.255.0/24"
+}
+
+resource "aws_vpn_gateway" "foo" {
+  vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_directconnect_gateway_association" "foo" {
+  dx_gateway_id = "${aws_directconnect_gateway.foo.id}"
+  associated_gateway_id = "${aws_vpn_gateway.foo.id}"
+  allowed_prefixes = ["%s"]
+}
+`
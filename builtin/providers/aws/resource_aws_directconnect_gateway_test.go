@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDirectconnectGateway_basic(t *testing.T) {
+	resourceName := "aws_directconnect_gateway.foo"
+	gatewayName := fmt.Sprintf("tf-dxg-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDirectconnectGatewayDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectGatewayConfig, gatewayName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectGatewayExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", gatewayName),
+					resource.TestCheckResourceAttr(resourceName, "amazon_side_asn", "64512"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceAwsDirectconnectGatewayRead_deleted(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeDirectConnectGateways": &dxMockResponse{
+			StatusCode: 200,
+			Body: `{"directConnectGateways": [
+				{"directConnectGatewayId": "5f294f92-bafb-4011-916d-9b0bexample", "directConnectGatewayState": "deleted"}
+			]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDirectconnectGateway().Data(&terraform.InstanceState{
+		ID: "5f294f92-bafb-4011-916d-9b0bexample",
+	})
+	if err := resourceAwsDirectconnectGatewayRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected ID to be cleared for a deleted gateway, got: %q", d.Id())
+	}
+}
+
+func testAccCheckAwsDirectconnectGatewayDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dirconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_directconnect_gateway" {
+			continue
+		}
+
+		_, state, err := dxGatewayStateRefreshFunc(conn, rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state != directconnect.GatewayStateDeleted {
+			return fmt.Errorf("Direct Connect gateway (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAwsDirectconnectGatewayExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dirconn
+		_, state, err := dxGatewayStateRefreshFunc(conn, rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state == directconnect.GatewayStateDeleted {
+			return fmt.Errorf("Direct Connect gateway (%s) not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccDirectconnectGatewayConfig = `
+resource "aws_directconnect_gateway" "foo" {
+  name = "%s"
+  amazon_side_asn = 64512
+}
+`
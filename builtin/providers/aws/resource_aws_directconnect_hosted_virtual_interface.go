@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsDirectconnectHostedVirtualInterface is the allocating side of a
+// hosted virtual interface. It is the same as an
+// aws_directconnect_virtual_interface with owner_account_id set, minus the
+// arguments that are up to the owner when confirming the interface, see
+// aws_directconnect_hosted_virtual_interface_confirmation.
+func resourceAwsDirectconnectHostedVirtualInterface() *schema.Resource {
+	r := resourceAwsDirectconnectVirtualInterface()
+
+	r.Schema["owner_account_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	// The owner picks the gateway, which in turn determines the Amazon
+	// side ASN.
+	for _, k := range []string{"virtual_gateway_id", "dx_gateway_id", "amazon_side_asn"} {
+		r.Schema[k] = &schema.Schema{
+			Type:     r.Schema[k].Type,
+			Computed: true,
+		}
+	}
+
+	// Only the owner can change the MTU of the interface once it's there.
+	r.Schema["mtu"].ForceNew = true
+
+	// BGP can't come up before the owner has confirmed the interface.
+	delete(r.Schema, "wait_for_bgp")
+
+	return r
+}
@@ -0,0 +1,199 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsDirectconnectHostedVirtualInterfaceConfirmation confirms a
+// hosted virtual interface on the account it was allocated for.
+func resourceAwsDirectconnectHostedVirtualInterfaceConfirmation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDirectconnectHostedVirtualInterfaceConfirmationCreate,
+		Read:   resourceAwsDirectconnectHostedVirtualInterfaceConfirmationRead,
+		Delete: resourceAwsDirectconnectHostedVirtualInterfaceConfirmationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(dxVirtualInterfaceAvailableTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_interface_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"virtual_gateway_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"dx_gateway_id"},
+			},
+
+			"dx_gateway_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_gateway_id"},
+			},
+
+			"vif_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"virtual_interface_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsDirectconnectHostedVirtualInterfaceConfirmationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+	vifId := d.Get("virtual_interface_id").(string)
+
+	vifRaw, _, err := dxVirtualInterfaceStateRefreshFunc(conn, vifId)()
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect hosted virtual interface (%s): %s", vifId, err)
+	}
+	vif, ok := vifRaw.(*directconnect.VirtualInterface)
+	if !ok {
+		return fmt.Errorf("Direct Connect hosted virtual interface (%s) not found", vifId)
+	}
+
+	if err := confirmDxHostedVirtualInterface(conn, d, vif); err != nil {
+		return err
+	}
+	d.SetId(vifId)
+
+	pending := []string{
+		directconnect.VirtualInterfaceStateConfirming,
+		directconnect.VirtualInterfaceStatePending,
+		directconnect.VirtualInterfaceStateVerifying,
+	}
+	target := []string{
+		directconnect.VirtualInterfaceStateAvailable,
+		directconnect.VirtualInterfaceStateDown,
+	}
+	// Like for our own public virtual interfaces, there is no point in
+	// waiting for AWS to verify the prefixes.
+	if aws.StringValue(vif.VirtualInterfaceType) == dxVirtualInterfaceTypePublic {
+		pending = pending[:2]
+		target = append(target, directconnect.VirtualInterfaceStateVerifying)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     target,
+		Refresh:    dxVirtualInterfaceStateRefreshFunc(conn, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if err := waitForDxVirtualInterface(stateConf); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect hosted virtual interface (%s) to become available: %s", d.Id(), err)
+	}
+
+	invalidateDxVirtualInterfaceCache(meta, aws.StringValue(vif.ConnectionId))
+	return resourceAwsDirectconnectHostedVirtualInterfaceConfirmationRead(d, meta)
+}
+
+func resourceAwsDirectconnectHostedVirtualInterfaceConfirmationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	vifRaw, state, err := dxVirtualInterfaceStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect hosted virtual interface (%s): %s", d.Id(), err)
+	}
+	vif, ok := vifRaw.(*directconnect.VirtualInterface)
+	if !ok || state == directconnect.VirtualInterfaceStateDeleted {
+		dxLog.Printf("[WARN] Direct Connect hosted virtual interface (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("virtual_interface_id", vif.VirtualInterfaceId)
+	d.Set("virtual_gateway_id", vif.VirtualGatewayId)
+	d.Set("dx_gateway_id", vif.DirectConnectGatewayId)
+	d.Set("vif_type", vif.VirtualInterfaceType)
+	d.Set("virtual_interface_state", vif.VirtualInterfaceState)
+
+	return nil
+}
+
+// resourceAwsDirectconnectHostedVirtualInterfaceConfirmationDelete only
+// forgets about the confirmation, which can't be undone. The interface is
+// deleted by whoever allocated it.
+func resourceAwsDirectconnectHostedVirtualInterfaceConfirmationDelete(d *schema.ResourceData, meta interface{}) error {
+	dxLog.Printf("[WARN] Leaving Direct Connect hosted virtual interface (%s) in place, only removing its confirmation from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// confirmDxHostedVirtualInterface confirms the hosted virtual interface vif
+// with the configured gateway, as its type requires. The interface has to
+// be waiting for confirmation.
+func confirmDxHostedVirtualInterface(conn directConnectAPI, d *schema.ResourceData, vif *directconnect.VirtualInterface) error {
+	vifId := aws.StringValue(vif.VirtualInterfaceId)
+	if state := aws.StringValue(vif.VirtualInterfaceState); state != directconnect.VirtualInterfaceStateConfirming {
+		return fmt.Errorf("Direct Connect hosted virtual interface (%s) can't be confirmed in state %q", vifId, state)
+	}
+
+	virtualGatewayId, hasVirtualGateway := d.GetOk("virtual_gateway_id")
+	dxGatewayId, hasDxGateway := d.GetOk("dx_gateway_id")
+
+	var err error
+	switch vifType := aws.StringValue(vif.VirtualInterfaceType); vifType {
+	case dxVirtualInterfaceTypePublic:
+		if hasVirtualGateway || hasDxGateway {
+			return fmt.Errorf("No gateway can be set for %s virtual interfaces", vifType)
+		}
+
+		dxLog.Printf("[DEBUG] Confirming Direct Connect hosted public virtual interface: %s", vifId)
+		_, err = conn.ConfirmPublicVirtualInterface(&directconnect.ConfirmPublicVirtualInterfaceInput{
+			VirtualInterfaceId: aws.String(vifId),
+		})
+
+	case dxVirtualInterfaceTypeTransit:
+		if !hasDxGateway {
+			return fmt.Errorf("dx_gateway_id is required for %s virtual interfaces", vifType)
+		}
+
+		dxLog.Printf("[DEBUG] Confirming Direct Connect hosted transit virtual interface: %s", vifId)
+		_, err = conn.ConfirmTransitVirtualInterface(&directconnect.ConfirmTransitVirtualInterfaceInput{
+			VirtualInterfaceId:     aws.String(vifId),
+			DirectConnectGatewayId: aws.String(dxGatewayId.(string)),
+		})
+
+	default:
+		req := &directconnect.ConfirmPrivateVirtualInterfaceInput{
+			VirtualInterfaceId: aws.String(vifId),
+		}
+		if hasVirtualGateway {
+			req.VirtualGatewayId = aws.String(virtualGatewayId.(string))
+		} else if hasDxGateway {
+			req.DirectConnectGatewayId = aws.String(dxGatewayId.(string))
+		} else {
+			return fmt.Errorf("One of virtual_gateway_id or dx_gateway_id is required for %s virtual interfaces", vifType)
+		}
+
+		dxLog.Printf("[DEBUG] Confirming Direct Connect hosted virtual interface: %#v", req)
+		_, err = conn.ConfirmPrivateVirtualInterface(req)
+	}
+	if err != nil {
+		return fmt.Errorf("Error confirming Direct Connect hosted virtual interface (%s): %s", vifId, err)
+	}
+
+	return nil
+}
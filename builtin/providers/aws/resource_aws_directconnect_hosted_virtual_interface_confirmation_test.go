@@ -0,0 +1,185 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Confirming a hosted virtual interface needs a second account to allocate
+// it, so the acceptance test takes the ID of an interface waiting for
+// confirmation on the test account.
+func TestAccAWSDirectconnectHostedVirtualInterfaceConfirmation_basic(t *testing.T) {
+	vifId := os.Getenv("DX_HOSTED_VIF_ID")
+	vgwId := os.Getenv("DX_HOSTED_VIF_VIRTUAL_GATEWAY_ID")
+	if vifId == "" || vgwId == "" {
+		t.Skip("Environment variables DX_HOSTED_VIF_ID and DX_HOSTED_VIF_VIRTUAL_GATEWAY_ID must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectHostedVirtualInterfaceConfirmationConfig, vifId, vgwId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_hosted_virtual_interface_confirmation.foo", "virtual_gateway_id", vgwId),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_hosted_virtual_interface_confirmation.foo", "vif_type", "private"),
+				),
+			},
+		},
+	})
+}
+
+func TestConfirmDxHostedVirtualInterface_private(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"ConfirmPrivateVirtualInterface": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaceState": "pending"}`,
+		},
+	})
+	defer closeFunc()
+
+	var req *directconnect.ConfirmPrivateVirtualInterfaceInput
+	conn.Handlers.Build.PushBack(func(r *request.Request) {
+		req, _ = r.Params.(*directconnect.ConfirmPrivateVirtualInterfaceInput)
+	})
+
+	d := resourceAwsDirectconnectHostedVirtualInterfaceConfirmation().Data(&terraform.InstanceState{})
+	d.Set("virtual_interface_id", "dxvif-abcde123")
+	d.Set("virtual_gateway_id", "vgw-abcde123")
+
+	vif := &directconnect.VirtualInterface{
+		VirtualInterfaceId:    aws.String("dxvif-abcde123"),
+		VirtualInterfaceType:  aws.String(dxVirtualInterfaceTypePrivate),
+		VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateConfirming),
+	}
+	if err := confirmDxHostedVirtualInterface(conn, d, vif); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if req == nil {
+		t.Fatalf("Expected a ConfirmPrivateVirtualInterface request")
+	}
+	if id := aws.StringValue(req.VirtualGatewayId); id != "vgw-abcde123" {
+		t.Fatalf("Unexpected virtual gateway ID: %q", id)
+	}
+	if req.DirectConnectGatewayId != nil {
+		t.Fatalf("Expected no Direct Connect gateway ID, got: %q", aws.StringValue(req.DirectConnectGatewayId))
+	}
+}
+
+func TestConfirmDxHostedVirtualInterface_invalid(t *testing.T) {
+	cases := map[string]struct {
+		VifType  string
+		State    string
+		Key      string
+		Value    string
+		Expected string
+	}{
+		"not confirming": {
+			VifType:  dxVirtualInterfaceTypePrivate,
+			State:    directconnect.VirtualInterfaceStateAvailable,
+			Key:      "virtual_gateway_id",
+			Value:    "vgw-abcde123",
+			Expected: `Direct Connect hosted virtual interface (dxvif-abcde123) can't be confirmed in state "available"`,
+		},
+		"private without gateway": {
+			VifType:  dxVirtualInterfaceTypePrivate,
+			State:    directconnect.VirtualInterfaceStateConfirming,
+			Expected: "One of virtual_gateway_id or dx_gateway_id is required for private virtual interfaces",
+		},
+		"transit with virtual gateway": {
+			VifType:  dxVirtualInterfaceTypeTransit,
+			State:    directconnect.VirtualInterfaceStateConfirming,
+			Key:      "virtual_gateway_id",
+			Value:    "vgw-abcde123",
+			Expected: "dx_gateway_id is required for transit virtual interfaces",
+		},
+		"public with gateway": {
+			VifType:  dxVirtualInterfaceTypePublic,
+			State:    directconnect.VirtualInterfaceStateConfirming,
+			Key:      "dx_gateway_id",
+			Value:    "abcdef12-3456-7890-abcd-ef1234567890",
+			Expected: "No gateway can be set for public virtual interfaces",
+		},
+	}
+
+	for name, tc := range cases {
+		d := resourceAwsDirectconnectHostedVirtualInterfaceConfirmation().Data(&terraform.InstanceState{})
+		d.Set("virtual_interface_id", "dxvif-abcde123")
+		if tc.Key != "" {
+			d.Set(tc.Key, tc.Value)
+		}
+
+		vif := &directconnect.VirtualInterface{
+			VirtualInterfaceId:    aws.String("dxvif-abcde123"),
+			VirtualInterfaceType:  aws.String(tc.VifType),
+			VirtualInterfaceState: aws.String(tc.State),
+		}
+
+		// No API calls are expected, so there is no need for a mock.
+		err := confirmDxHostedVirtualInterface(nil, d, vif)
+		if err == nil || err.Error() != tc.Expected {
+			t.Fatalf("%s: Expected error %q, got: %v", name, tc.Expected, err)
+		}
+	}
+}
+
+func TestResourceAwsDirectconnectHostedVirtualInterfaceConfirmationRead_available(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceType": "private", "virtualInterfaceState": "available", "virtualGatewayId": "vgw-abcde123"}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDirectconnectHostedVirtualInterfaceConfirmation().Data(&terraform.InstanceState{ID: "dxvif-abcde123"})
+	if err := resourceAwsDirectconnectHostedVirtualInterfaceConfirmationRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if id := d.Get("virtual_interface_id").(string); id != "dxvif-abcde123" {
+		t.Fatalf("Unexpected virtual_interface_id: %q", id)
+	}
+	if id := d.Get("virtual_gateway_id").(string); id != "vgw-abcde123" {
+		t.Fatalf("Unexpected virtual_gateway_id: %q", id)
+	}
+	if state := d.Get("virtual_interface_state").(string); state != directconnect.VirtualInterfaceStateAvailable {
+		t.Fatalf("Expected virtual_interface_state to be available, got: %q", state)
+	}
+}
+
+func TestResourceAwsDirectconnectHostedVirtualInterfaceConfirmationRead_deleted(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 400,
+			Body:       `{"__type": "DirectConnectClientException", "message": "Virtual interface dxvif-abcde123 does not exist"}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDirectconnectHostedVirtualInterfaceConfirmation().Data(&terraform.InstanceState{ID: "dxvif-abcde123"})
+	if err := resourceAwsDirectconnectHostedVirtualInterfaceConfirmationRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected ID to be cleared, got: %q", d.Id())
+	}
+}
+
+const testAccDirectconnectHostedVirtualInterfaceConfirmationConfig = `
+resource "aws_directconnect_hosted_virtual_interface_confirmation" "foo" {
+  virtual_interface_id = "%s"
+  virtual_gateway_id = "%s"
+}
+`
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDirectconnectHostedVirtualInterface_basic(t *testing.T) {
+	var vif directconnect.VirtualInterface
+	connectionId := testAccDxConnectionPreCheck(t)
+	ownerAccountId := os.Getenv("DX_HOSTED_VIF_OWNER_ACCOUNT_ID")
+	if ownerAccountId == "" {
+		t.Skip("Environment variable DX_HOSTED_VIF_OWNER_ACCOUNT_ID is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDirectconnectVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectHostedVirtualInterfaceConfig, connectionId, ownerAccountId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectVirtualInterfaceExists(
+						"aws_directconnect_hosted_virtual_interface.foo", &vif),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_hosted_virtual_interface.foo", "owner_account_id", ownerAccountId),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_hosted_virtual_interface.foo", "virtual_interface_state", "confirming"),
+				),
+			},
+		},
+	})
+}
+
+func TestAllocateDxHostedVirtualInterface_private(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"AllocatePrivateVirtualInterface": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceType": "private", "virtualInterfaceState": "confirming", "ownerAccount": "123456789012"}`,
+		},
+	})
+	defer closeFunc()
+
+	var req *directconnect.AllocatePrivateVirtualInterfaceInput
+	conn.Handlers.Build.PushBack(func(r *request.Request) {
+		req, _ = r.Params.(*directconnect.AllocatePrivateVirtualInterfaceInput)
+	})
+
+	d := resourceAwsDirectconnectHostedVirtualInterface().Data(&terraform.InstanceState{})
+	d.Set("connection_id", "dxcon-abcde123")
+	d.Set("owner_account_id", "123456789012")
+	d.Set("virtual_interface_name", "dxvif")
+	d.Set("vlan", 4094)
+	d.Set("asn", 65352)
+
+	vif, err := allocateDxHostedVirtualInterface(conn, d, d.Get("owner_account_id").(string))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if id := aws.StringValue(vif.VirtualInterfaceId); id != "dxvif-abcde123" {
+		t.Fatalf("Unexpected virtual interface ID: %q", id)
+	}
+
+	if req == nil {
+		t.Fatalf("Expected an AllocatePrivateVirtualInterface request")
+	}
+	if owner := aws.StringValue(req.OwnerAccount); owner != "123456789012" {
+		t.Fatalf("Unexpected owner account: %q", owner)
+	}
+	if vlan := aws.Int64Value(req.NewPrivateVirtualInterfaceAllocation.Vlan); vlan != 4094 {
+		t.Fatalf("Unexpected VLAN: %d", vlan)
+	}
+}
+
+const testAccDirectconnectHostedVirtualInterfaceConfig = `
+resource "aws_directconnect_hosted_virtual_interface" "foo" {
+  connection_id = "%s"
+  owner_account_id = "%s"
+  virtual_interface_name = "terraform-testacc-dxvif-hosted"
+  vlan = 4094
+  asn = 65352
+}
+`
@@ -0,0 +1,1173 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	dxVirtualInterfaceTypePrivate = "private"
+	dxVirtualInterfaceTypePublic  = "public"
+	dxVirtualInterfaceTypeTransit = "transit"
+)
+
+const (
+	// dxVirtualInterfaceAvailableTimeout is the default bound on the wait
+	// for a new virtual interface to come up, see the create timeout.
+	dxVirtualInterfaceAvailableTimeout = 10 * time.Minute
+
+	// dxVirtualInterfaceUpdateTimeout is the default bound on the wait for a
+	// virtual interface to settle after changing its attributes, see the
+	// update timeout.
+	dxVirtualInterfaceUpdateTimeout = 10 * time.Minute
+
+	// dxVirtualInterfaceDeletedTimeout is the default bound on the wait for
+	// a virtual interface to be deleted, see the delete timeout.
+	dxVirtualInterfaceDeletedTimeout = 10 * time.Minute
+)
+
+func resourceAwsDirectconnectVirtualInterface() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDirectconnectVirtualInterfaceCreate,
+		Read:   resourceAwsDirectconnectVirtualInterfaceRead,
+		Update: resourceAwsDirectconnectVirtualInterfaceUpdate,
+		Delete: resourceAwsDirectconnectVirtualInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: resourceAwsDirectconnectVirtualInterfaceCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(dxVirtualInterfaceAvailableTimeout),
+			Update: schema.DefaultTimeout(dxVirtualInterfaceUpdateTimeout),
+			Delete: schema.DefaultTimeout(dxVirtualInterfaceDeletedTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// A virtual interface sits on either a connection or a LAG. Both
+			// have the same API, which just calls the ID a connection ID.
+			"connection_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"lag_id"},
+			},
+
+			"lag_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"connection_id"},
+			},
+
+			"virtual_interface_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vlan": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxVlan,
+			},
+
+			"asn": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxBgpAsn,
+			},
+
+			"vif_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      dxVirtualInterfaceTypePrivate,
+				ValidateFunc: validateDxVirtualInterfaceType,
+			},
+
+			"virtual_gateway_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"dx_gateway_id"},
+			},
+
+			"dx_gateway_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_gateway_id"},
+			},
+
+			// Setting owner_account_id allocates a hosted virtual interface
+			// for another account, which picks the gateway when accepting it.
+			"owner_account_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_gateway_id", "dx_gateway_id"},
+			},
+
+			// The Amazon side ASN of a virtual interface is always that of the
+			// gateway it terminates on, so it can only be pinned for virtual
+			// private gateways, where it is checked before creating the
+			// interface. Direct Connect gateways reject an explicit value.
+			"amazon_side_asn": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ValidateFunc:  validateAmazonSideAsn,
+				ConflictsWith: []string{"dx_gateway_id"},
+			},
+
+			"address_family": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxAddressFamily,
+			},
+
+			"auth_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+
+			"amazon_address": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateDxBgpPeerAddress,
+				DiffSuppressFunc: suppressEquivalentDxBgpPeerAddress,
+			},
+
+			"customer_address": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateDxBgpPeerAddress,
+				DiffSuppressFunc: suppressEquivalentDxBgpPeerAddress,
+			},
+
+			// The MTU is the only attribute of a virtual interface that can be
+			// changed in place. Public virtual interfaces don't support jumbo
+			// frames and are always at 1500.
+			"mtu": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDxVirtualInterfaceMtu,
+			},
+
+			"jumbo_frame_capable": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			// Only public virtual interfaces advertise route filter prefixes.
+			"route_filter_prefixes": dxRouteFilterPrefixesSchema(),
+
+			"virtual_interface_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"bgp_peers": dxBgpPeersSchema(),
+
+			"bgp_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"bgp_peer_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"aws_device": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"wait_for_bgp": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsDirectconnectVirtualInterfaceCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	if dxVirtualInterfaceConnectionId(d) == "" {
+		return fmt.Errorf("One of connection_id or lag_id is required")
+	}
+	if err := validateDxBgpPeerAddressPair(d.Get("amazon_address").(string), d.Get("customer_address").(string)); err != nil {
+		return err
+	}
+
+	if ownerAccountId, ok := d.GetOk("owner_account_id"); ok {
+		vif, err := allocateDxHostedVirtualInterface(conn, d, ownerAccountId.(string))
+		if err != nil {
+			return err
+		}
+
+		d.SetId(aws.StringValue(vif.VirtualInterfaceId))
+		dxLog.Printf("[INFO] Direct Connect hosted virtual interface ID: %s", d.Id())
+
+		if err := tagDxVirtualInterfaceOnCreate(conn, d, meta, vif); err != nil {
+			return err
+		}
+
+		// We only wait for the interface to be handed over to its owner.
+		// From there on it is up to the owner to accept it, which can take
+		// arbitrarily long. The owner may also accept it before we get to
+		// look at it, so it can just as well be past confirming already.
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{directconnect.VirtualInterfaceStatePending},
+			Target: []string{
+				directconnect.VirtualInterfaceStateConfirming,
+				directconnect.VirtualInterfaceStateVerifying,
+				directconnect.VirtualInterfaceStateAvailable,
+				directconnect.VirtualInterfaceStateDown,
+			},
+			Refresh:    dxVirtualInterfaceStateRefreshFunc(conn, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutCreate),
+			Delay:      10 * time.Second,
+			MinTimeout: 5 * time.Second,
+		}
+		if err := waitForDxVirtualInterface(stateConf); err != nil {
+			return fmt.Errorf("Error waiting for Direct Connect hosted virtual interface (%s) to become confirming: %s", d.Id(), err)
+		}
+
+		invalidateDxVirtualInterfaceCache(meta, dxVirtualInterfaceConnectionId(d))
+		return resourceAwsDirectconnectVirtualInterfaceRead(d, meta)
+	}
+
+	vif, err := createDxVirtualInterface(meta, d)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(aws.StringValue(vif.VirtualInterfaceId))
+	dxLog.Printf("[INFO] Direct Connect virtual interface ID: %s", d.Id())
+
+	if err := tagDxVirtualInterfaceOnCreate(conn, d, meta, vif); err != nil {
+		return err
+	}
+
+	pending := []string{
+		directconnect.VirtualInterfaceStatePending,
+		directconnect.VirtualInterfaceStateVerifying,
+	}
+	target := []string{
+		directconnect.VirtualInterfaceStateAvailable,
+		directconnect.VirtualInterfaceStateDown,
+	}
+	// AWS verifies the route filter prefixes of a public virtual interface
+	// by hand, which can take days, so there is no point waiting for it.
+	if d.Get("vif_type").(string) == dxVirtualInterfaceTypePublic {
+		pending = []string{directconnect.VirtualInterfaceStatePending}
+		target = append(target, directconnect.VirtualInterfaceStateVerifying)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     target,
+		Refresh:    dxVirtualInterfaceStateRefreshFunc(conn, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if err := waitForDxVirtualInterface(stateConf); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect virtual interface (%s) to become available: %s", d.Id(), err)
+	}
+
+	if d.Get("wait_for_bgp").(bool) {
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{
+				directconnect.VirtualInterfaceStateAvailable + "/" + directconnect.BGPStatusDown,
+				directconnect.VirtualInterfaceStateAvailable + "/" + directconnect.BGPStatusUnknown,
+				directconnect.VirtualInterfaceStateDown + "/" + directconnect.BGPStatusDown,
+				directconnect.VirtualInterfaceStateDown + "/" + directconnect.BGPStatusUnknown,
+			},
+			Target: []string{
+				directconnect.VirtualInterfaceStateAvailable + "/" + directconnect.BGPStatusUp,
+			},
+			Refresh: resource.ComposeStateRefreshFunc(
+				dxVirtualInterfaceStateRefreshFunc(conn, d.Id()),
+				dxVirtualInterfaceBgpStatusRefreshFunc(conn, d.Id()),
+			),
+			Timeout:    d.Timeout(schema.TimeoutCreate),
+			MinTimeout: 5 * time.Second,
+		}
+		if err := waitForDxVirtualInterface(stateConf); err != nil {
+			return fmt.Errorf("Error waiting for BGP of Direct Connect virtual interface (%s) to come up: %s", d.Id(), err)
+		}
+	}
+
+	invalidateDxVirtualInterfaceCache(meta, dxVirtualInterfaceConnectionId(d))
+	return resourceAwsDirectconnectVirtualInterfaceRead(d, meta)
+}
+
+func resourceAwsDirectconnectVirtualInterfaceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient)
+	conn := client.dirconn
+
+	// Refreshing goes through the cache of the connection's virtual
+	// interfaces where possible. Anything it doesn't know about, like an
+	// interface that was just imported, is looked up on its own.
+	var vif *directconnect.VirtualInterface
+	if connectionId := dxVirtualInterfaceConnectionId(d); client.dxVifCache != nil && connectionId != "" {
+		var err error
+		vif, err = client.dxVifCache.get(conn, connectionId, d.Id())
+		if err != nil && !isNoSuchDxConnectionErr(err) {
+			return fmt.Errorf("Error reading Direct Connect virtual interface (%s): %s", d.Id(), err)
+		}
+	}
+	if vif == nil {
+		vifRaw, _, err := dxVirtualInterfaceStateRefreshFunc(conn, d.Id())()
+		if err != nil {
+			return fmt.Errorf("Error reading Direct Connect virtual interface (%s): %s", d.Id(), err)
+		}
+		vif, _ = vifRaw.(*directconnect.VirtualInterface)
+	}
+	if vif == nil || aws.StringValue(vif.VirtualInterfaceState) == directconnect.VirtualInterfaceStateDeleted {
+		dxLog.Printf("[WARN] Direct Connect virtual interface (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// Interfaces that were configured with a LAG ID as their connection_id
+	// keep it there.
+	if connectionId := aws.StringValue(vif.ConnectionId); isDxLagId(connectionId) && d.Get("connection_id").(string) != connectionId {
+		d.Set("connection_id", "")
+		d.Set("lag_id", connectionId)
+	} else {
+		d.Set("connection_id", connectionId)
+		d.Set("lag_id", "")
+	}
+	d.Set("virtual_interface_name", vif.VirtualInterfaceName)
+	d.Set("vlan", vif.Vlan)
+	d.Set("asn", vif.Asn)
+	d.Set("vif_type", vif.VirtualInterfaceType)
+	d.Set("virtual_gateway_id", vif.VirtualGatewayId)
+	d.Set("dx_gateway_id", vif.DirectConnectGatewayId)
+	d.Set("owner_account_id", vif.OwnerAccount)
+	d.Set("amazon_side_asn", vif.AmazonSideAsn)
+	d.Set("address_family", vif.AddressFamily)
+	d.Set("auth_key", vif.AuthKey)
+	d.Set("amazon_address", vif.AmazonAddress)
+	d.Set("customer_address", vif.CustomerAddress)
+	d.Set("mtu", vif.Mtu)
+	d.Set("jumbo_frame_capable", vif.JumboFrameCapable)
+	d.Set("aws_device", vif.AwsDeviceV2)
+	if err := d.Set("route_filter_prefixes", flattenDxRouteFilterPrefixes(vif.RouteFilterPrefixes)); err != nil {
+		return fmt.Errorf("Error setting route_filter_prefixes of Direct Connect virtual interface (%s): %s", d.Id(), err)
+	}
+	d.Set("virtual_interface_state", vif.VirtualInterfaceState)
+	d.Set("arn", dxVirtualInterfaceArn(client, vif))
+	if err := d.Set("tags", tagsToMapDX(vif.Tags)); err != nil {
+		return fmt.Errorf("Error setting tags of Direct Connect virtual interface (%s): %s", d.Id(), err)
+	}
+
+	// BGP sessions can flap independently of the virtual interface state, so
+	// the peers are refreshed every time.
+	if err := d.Set("bgp_peers", flattenDxBgpPeers(vif.BgpPeers)); err != nil {
+		return fmt.Errorf("Error setting bgp_peers of Direct Connect virtual interface (%s): %s", d.Id(), err)
+	}
+	if peer := dxVirtualInterfaceMainBgpPeer(vif.BgpPeers); peer != nil {
+		d.Set("bgp_status", peer.BgpStatus)
+		d.Set("bgp_peer_state", peer.BgpPeerState)
+	} else {
+		d.Set("bgp_status", "")
+		d.Set("bgp_peer_state", "")
+	}
+
+	return nil
+}
+
+// resourceAwsDirectconnectVirtualInterfaceUpdate updates the MTU and the
+// tags, and saves the arguments that affect nothing but how Terraform
+// creates the interface. Everything else forces a new interface.
+func resourceAwsDirectconnectVirtualInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	if d.HasChange("mtu") {
+		req := &directconnect.UpdateVirtualInterfaceAttributesInput{
+			VirtualInterfaceId: aws.String(d.Id()),
+			Mtu:                aws.Int64(int64(d.Get("mtu").(int))),
+		}
+
+		dxLog.Printf("[DEBUG] Updating Direct Connect virtual interface attributes: %#v", req)
+		if _, err := conn.UpdateVirtualInterfaceAttributes(req); err != nil {
+			return fmt.Errorf("Error updating MTU of Direct Connect virtual interface (%s): %s", d.Id(), err)
+		}
+
+		// The interface goes back to pending while the new MTU is applied.
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{directconnect.VirtualInterfaceStatePending},
+			Target: []string{
+				directconnect.VirtualInterfaceStateAvailable,
+				directconnect.VirtualInterfaceStateDown,
+			},
+			Refresh:    dxVirtualInterfaceStateRefreshFunc(conn, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      10 * time.Second,
+			MinTimeout: 5 * time.Second,
+		}
+		if err := waitForDxVirtualInterface(stateConf); err != nil {
+			return fmt.Errorf("Error waiting for Direct Connect virtual interface (%s) to become available: %s", d.Id(), err)
+		}
+	}
+
+	if err := setTagsDX(conn, d, d.Get("arn").(string)); err != nil {
+		return fmt.Errorf("Error updating tags of Direct Connect virtual interface (%s): %s", d.Id(), err)
+	}
+
+	invalidateDxVirtualInterfaceCache(meta, dxVirtualInterfaceConnectionId(d))
+	return resourceAwsDirectconnectVirtualInterfaceRead(d, meta)
+}
+
+func resourceAwsDirectconnectVirtualInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	dxLog.Printf("[DEBUG] Deleting Direct Connect virtual interface: %s", d.Id())
+	_, err := conn.DeleteVirtualInterface(&directconnect.DeleteVirtualInterfaceInput{
+		VirtualInterfaceId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isNoSuchDxVirtualInterfaceErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Direct Connect virtual interface (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.VirtualInterfaceStateAvailable,
+			directconnect.VirtualInterfaceStateConfirming,
+			directconnect.VirtualInterfaceStateDown,
+			directconnect.VirtualInterfaceStateDeleting,
+			directconnect.VirtualInterfaceStatePending,
+			directconnect.VirtualInterfaceStateRejected,
+			directconnect.VirtualInterfaceStateVerifying,
+		},
+		Target:     []string{directconnect.VirtualInterfaceStateDeleted},
+		Refresh:    dxVirtualInterfaceDeletedRefreshFunc(conn, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if err := waitForDxVirtualInterface(stateConf); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect virtual interface (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	invalidateDxVirtualInterfaceCache(meta, dxVirtualInterfaceConnectionId(d))
+	return nil
+}
+
+// createDxVirtualInterface creates a virtual interface of the configured
+// type on our own account. The configured tags are passed along, so the
+// interface is tagged as soon as it exists.
+func createDxVirtualInterface(meta interface{}, d *schema.ResourceData) (*directconnect.VirtualInterface, error) {
+	client := meta.(*AWSClient)
+	conn := client.dirconn
+
+	switch vifType := d.Get("vif_type").(string); vifType {
+	case dxVirtualInterfaceTypeTransit:
+		dxGatewayId, ok := d.GetOk("dx_gateway_id")
+		if !ok {
+			return nil, fmt.Errorf("dx_gateway_id is required for %s virtual interfaces", vifType)
+		}
+
+		vif := &directconnect.NewTransitVirtualInterface{
+			VirtualInterfaceName:   aws.String(d.Get("virtual_interface_name").(string)),
+			Vlan:                   aws.Int64(int64(d.Get("vlan").(int))),
+			Asn:                    aws.Int64(int64(d.Get("asn").(int))),
+			DirectConnectGatewayId: aws.String(dxGatewayId.(string)),
+		}
+		if v, ok := d.GetOk("address_family"); ok {
+			vif.AddressFamily = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("mtu"); ok {
+			vif.Mtu = aws.Int64(int64(v.(int)))
+		}
+		if v, ok := d.GetOk("auth_key"); ok {
+			vif.AuthKey = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("amazon_address"); ok {
+			vif.AmazonAddress = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("customer_address"); ok {
+			vif.CustomerAddress = aws.String(v.(string))
+		}
+		if tags := tagsFromMapDX(d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+			vif.Tags = tags
+		}
+
+		req := &directconnect.CreateTransitVirtualInterfaceInput{
+			ConnectionId:               aws.String(dxVirtualInterfaceConnectionId(d)),
+			NewTransitVirtualInterface: vif,
+		}
+
+		dxLog.Printf("[DEBUG] Creating Direct Connect transit virtual interface: %#v", req)
+		resp, err := conn.CreateTransitVirtualInterface(req)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating Direct Connect transit virtual interface: %s", err)
+		}
+		if resp.VirtualInterface == nil {
+			return nil, fmt.Errorf("Error creating Direct Connect transit virtual interface: empty response")
+		}
+		return resp.VirtualInterface, nil
+
+	case dxVirtualInterfaceTypePublic:
+		if err := checkDxPublicVirtualInterface(d); err != nil {
+			return nil, err
+		}
+
+		vif := &directconnect.NewPublicVirtualInterface{
+			VirtualInterfaceName: aws.String(d.Get("virtual_interface_name").(string)),
+			Vlan:                 aws.Int64(int64(d.Get("vlan").(int))),
+			Asn:                  aws.Int64(int64(d.Get("asn").(int))),
+			RouteFilterPrefixes:  expandDxRouteFilterPrefixes(d.Get("route_filter_prefixes").(*schema.Set).List()),
+		}
+		if v, ok := d.GetOk("address_family"); ok {
+			vif.AddressFamily = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("auth_key"); ok {
+			vif.AuthKey = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("amazon_address"); ok {
+			vif.AmazonAddress = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("customer_address"); ok {
+			vif.CustomerAddress = aws.String(v.(string))
+		}
+		if tags := tagsFromMapDX(d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+			vif.Tags = tags
+		}
+
+		req := &directconnect.CreatePublicVirtualInterfaceInput{
+			ConnectionId:              aws.String(dxVirtualInterfaceConnectionId(d)),
+			NewPublicVirtualInterface: vif,
+		}
+
+		dxLog.Printf("[DEBUG] Creating Direct Connect public virtual interface: %#v", req)
+		resp, err := conn.CreatePublicVirtualInterface(req)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating Direct Connect public virtual interface: %s", err)
+		}
+		return resp, nil
+
+	default:
+		vif := &directconnect.NewPrivateVirtualInterface{
+			VirtualInterfaceName: aws.String(d.Get("virtual_interface_name").(string)),
+			Vlan:                 aws.Int64(int64(d.Get("vlan").(int))),
+			Asn:                  aws.Int64(int64(d.Get("asn").(int))),
+		}
+		if v, ok := d.GetOk("virtual_gateway_id"); ok {
+			if asn, ok := d.GetOk("amazon_side_asn"); ok {
+				if err := checkVpnGatewayAmazonSideAsn(client.ec2conn, v.(string), int64(asn.(int))); err != nil {
+					return nil, err
+				}
+			}
+			vif.VirtualGatewayId = aws.String(v.(string))
+		} else if v, ok := d.GetOk("dx_gateway_id"); ok {
+			vif.DirectConnectGatewayId = aws.String(v.(string))
+		} else {
+			return nil, fmt.Errorf("One of virtual_gateway_id or dx_gateway_id is required for %s virtual interfaces", vifType)
+		}
+		if v, ok := d.GetOk("address_family"); ok {
+			vif.AddressFamily = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("mtu"); ok {
+			vif.Mtu = aws.Int64(int64(v.(int)))
+		}
+		if v, ok := d.GetOk("auth_key"); ok {
+			vif.AuthKey = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("amazon_address"); ok {
+			vif.AmazonAddress = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("customer_address"); ok {
+			vif.CustomerAddress = aws.String(v.(string))
+		}
+		if tags := tagsFromMapDX(d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+			vif.Tags = tags
+		}
+
+		req := &directconnect.CreatePrivateVirtualInterfaceInput{
+			ConnectionId:               aws.String(dxVirtualInterfaceConnectionId(d)),
+			NewPrivateVirtualInterface: vif,
+		}
+
+		dxLog.Printf("[DEBUG] Creating Direct Connect virtual interface: %#v", req)
+		resp, err := conn.CreatePrivateVirtualInterface(req)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating Direct Connect virtual interface: %s", err)
+		}
+		return resp, nil
+	}
+
+}
+
+// tagDxVirtualInterfaceOnCreate makes sure the new virtual interface vif
+// carries the configured tags. Create requests tag the interface as part of
+// creating it, but should the response come back without the tags, they are
+// applied separately.
+func tagDxVirtualInterfaceOnCreate(conn directConnectAPI, d *schema.ResourceData, meta interface{}, vif *directconnect.VirtualInterface) error {
+	tags := tagsFromMapDX(d.Get("tags").(map[string]interface{}))
+	if len(tags) == 0 || len(vif.Tags) > 0 {
+		return nil
+	}
+
+	arn := dxVirtualInterfaceArn(meta.(*AWSClient), vif)
+	dxLog.Printf("[DEBUG] Tagging Direct Connect virtual interface (%s): %#v", arn, tags)
+	_, err := conn.TagResource(&directconnect.TagResourceInput{
+		ResourceArn: aws.String(arn),
+		Tags:        tags,
+	})
+	if err != nil {
+		return fmt.Errorf("Error tagging Direct Connect virtual interface (%s): %s", d.Id(), err)
+	}
+	return nil
+}
+
+// dxVirtualInterfaceArn builds the ARN of virtual interface vif, which lives
+// in the account owning it.
+func dxVirtualInterfaceArn(client *AWSClient, vif *directconnect.VirtualInterface) string {
+	accountId := aws.StringValue(vif.OwnerAccount)
+	if accountId == "" {
+		accountId = client.accountid
+	}
+	return fmt.Sprintf("arn:aws:directconnect:%s:%s:dxvif/%s", client.region, accountId, aws.StringValue(vif.VirtualInterfaceId))
+}
+
+// allocateDxHostedVirtualInterface allocates a virtual interface of the
+// configured type for account ownerAccountId.
+func allocateDxHostedVirtualInterface(conn directConnectAPI, d *schema.ResourceData, ownerAccountId string) (*directconnect.VirtualInterface, error) {
+	switch vifType := d.Get("vif_type").(string); vifType {
+	case dxVirtualInterfaceTypeTransit:
+		vif := &directconnect.NewTransitVirtualInterfaceAllocation{
+			VirtualInterfaceName: aws.String(d.Get("virtual_interface_name").(string)),
+			Vlan:                 aws.Int64(int64(d.Get("vlan").(int))),
+			Asn:                  aws.Int64(int64(d.Get("asn").(int))),
+		}
+		if v, ok := d.GetOk("address_family"); ok {
+			vif.AddressFamily = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("mtu"); ok {
+			vif.Mtu = aws.Int64(int64(v.(int)))
+		}
+		if v, ok := d.GetOk("auth_key"); ok {
+			vif.AuthKey = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("amazon_address"); ok {
+			vif.AmazonAddress = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("customer_address"); ok {
+			vif.CustomerAddress = aws.String(v.(string))
+		}
+		if tags := tagsFromMapDX(d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+			vif.Tags = tags
+		}
+
+		req := &directconnect.AllocateTransitVirtualInterfaceInput{
+			ConnectionId:                         aws.String(dxVirtualInterfaceConnectionId(d)),
+			OwnerAccount:                         aws.String(ownerAccountId),
+			NewTransitVirtualInterfaceAllocation: vif,
+		}
+
+		dxLog.Printf("[DEBUG] Allocating Direct Connect hosted transit virtual interface: %#v", req)
+		resp, err := conn.AllocateTransitVirtualInterface(req)
+		if err != nil {
+			return nil, fmt.Errorf("Error allocating Direct Connect hosted transit virtual interface: %s", err)
+		}
+		if resp.VirtualInterface == nil {
+			return nil, fmt.Errorf("Error allocating Direct Connect hosted transit virtual interface: empty response")
+		}
+		return resp.VirtualInterface, nil
+
+	case dxVirtualInterfaceTypePublic:
+		if err := checkDxPublicVirtualInterface(d); err != nil {
+			return nil, err
+		}
+
+		vif := &directconnect.NewPublicVirtualInterfaceAllocation{
+			VirtualInterfaceName: aws.String(d.Get("virtual_interface_name").(string)),
+			Vlan:                 aws.Int64(int64(d.Get("vlan").(int))),
+			Asn:                  aws.Int64(int64(d.Get("asn").(int))),
+			RouteFilterPrefixes:  expandDxRouteFilterPrefixes(d.Get("route_filter_prefixes").(*schema.Set).List()),
+		}
+		if v, ok := d.GetOk("address_family"); ok {
+			vif.AddressFamily = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("auth_key"); ok {
+			vif.AuthKey = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("amazon_address"); ok {
+			vif.AmazonAddress = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("customer_address"); ok {
+			vif.CustomerAddress = aws.String(v.(string))
+		}
+		if tags := tagsFromMapDX(d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+			vif.Tags = tags
+		}
+
+		req := &directconnect.AllocatePublicVirtualInterfaceInput{
+			ConnectionId:                        aws.String(dxVirtualInterfaceConnectionId(d)),
+			OwnerAccount:                        aws.String(ownerAccountId),
+			NewPublicVirtualInterfaceAllocation: vif,
+		}
+
+		dxLog.Printf("[DEBUG] Allocating Direct Connect hosted public virtual interface: %#v", req)
+		resp, err := conn.AllocatePublicVirtualInterface(req)
+		if err != nil {
+			return nil, fmt.Errorf("Error allocating Direct Connect hosted public virtual interface: %s", err)
+		}
+		return resp, nil
+
+	default:
+		vif := &directconnect.NewPrivateVirtualInterfaceAllocation{
+			VirtualInterfaceName: aws.String(d.Get("virtual_interface_name").(string)),
+			Vlan:                 aws.Int64(int64(d.Get("vlan").(int))),
+			Asn:                  aws.Int64(int64(d.Get("asn").(int))),
+		}
+		if v, ok := d.GetOk("address_family"); ok {
+			vif.AddressFamily = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("mtu"); ok {
+			vif.Mtu = aws.Int64(int64(v.(int)))
+		}
+		if v, ok := d.GetOk("auth_key"); ok {
+			vif.AuthKey = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("amazon_address"); ok {
+			vif.AmazonAddress = aws.String(v.(string))
+		}
+		if v, ok := d.GetOk("customer_address"); ok {
+			vif.CustomerAddress = aws.String(v.(string))
+		}
+		if tags := tagsFromMapDX(d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+			vif.Tags = tags
+		}
+
+		req := &directconnect.AllocatePrivateVirtualInterfaceInput{
+			ConnectionId:                         aws.String(dxVirtualInterfaceConnectionId(d)),
+			OwnerAccount:                         aws.String(ownerAccountId),
+			NewPrivateVirtualInterfaceAllocation: vif,
+		}
+
+		dxLog.Printf("[DEBUG] Allocating Direct Connect hosted virtual interface: %#v", req)
+		resp, err := conn.AllocatePrivateVirtualInterface(req)
+		if err != nil {
+			return nil, fmt.Errorf("Error allocating Direct Connect hosted virtual interface: %s", err)
+		}
+		return resp, nil
+	}
+}
+
+// dxVirtualInterfaceConnectionId returns the ID of the connection or LAG the
+// virtual interface d sits on.
+func dxVirtualInterfaceConnectionId(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("lag_id"); ok {
+		return v.(string)
+	}
+	return d.Get("connection_id").(string)
+}
+
+// isDxLagId reports whether id, as returned for the connection of a virtual
+// interface, is the ID of a LAG.
+func isDxLagId(id string) bool {
+	return strings.HasPrefix(id, "dxlag-")
+}
+
+// invalidateDxVirtualInterfaceCache drops the cached virtual interfaces of
+// connection connectionId, if the client has a cache.
+func invalidateDxVirtualInterfaceCache(meta interface{}, connectionId string) {
+	if cache := meta.(*AWSClient).dxVifCache; cache != nil {
+		cache.invalidate(connectionId)
+	}
+}
+
+// dxVirtualInterfaceStateRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch a Direct Connect virtual interface. A virtual
+// interface that can't be found is reported with a nil result, so that
+// StateChangeConf retries up to its NotFoundChecks.
+func dxVirtualInterfaceStateRefreshFunc(conn directConnectAPI, vifId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeVirtualInterfaces(&directconnect.DescribeVirtualInterfacesInput{
+			VirtualInterfaceId: aws.String(vifId),
+		})
+		if err != nil {
+			if isNoSuchDxVirtualInterfaceErr(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		// The response can be empty, or even contain a nil entry, while
+		// AWS is catching up with a freshly created or deleted interface.
+		if resp == nil || len(resp.VirtualInterfaces) == 0 || resp.VirtualInterfaces[0] == nil {
+			return nil, "", nil
+		}
+
+		vif := resp.VirtualInterfaces[0]
+		return vif, aws.StringValue(vif.VirtualInterfaceState), nil
+	}
+}
+
+// dxVirtualInterfaceDeletedRefreshFunc is dxVirtualInterfaceStateRefreshFunc
+// for waiting on a deletion: an interface that can't be found any more is
+// as good as deleted.
+func dxVirtualInterfaceDeletedRefreshFunc(conn directConnectAPI, vifId string) resource.StateRefreshFunc {
+	refresh := dxVirtualInterfaceStateRefreshFunc(conn, vifId)
+	return func() (interface{}, string, error) {
+		vif, state, err := refresh()
+		if err == nil && vif == nil {
+			return "", directconnect.VirtualInterfaceStateDeleted, nil
+		}
+		return vif, state, err
+	}
+}
+
+// dxVirtualInterfaceWaitError is returned when waiting for a Direct Connect
+// virtual interface fails. It carries the state the interface was last seen
+// in, which is what tells a timeout in verifying apart from one in pending.
+type dxVirtualInterfaceWaitError struct {
+	// LastState is the last state reported by the refresh func, or empty if
+	// it never reported one.
+	LastState string
+	Err       error
+}
+
+func (e *dxVirtualInterfaceWaitError) Error() string {
+	if e.LastState == "" {
+		return e.Err.Error()
+	}
+	// Direct Connect doesn't say why an interface was rejected, so the state
+	// is all there is to report.
+	return fmt.Sprintf("%s, last observed state: %s", e.Err, e.LastState)
+}
+
+// waitForDxVirtualInterface runs stateConf.WaitForState, keeping track of
+// the states its refresh func reports. Any error is returned as a
+// *dxVirtualInterfaceWaitError.
+func waitForDxVirtualInterface(stateConf *resource.StateChangeConf) error {
+	var lock sync.Mutex
+	var lastState string
+
+	refresh := stateConf.Refresh
+	stateConf.Refresh = func() (interface{}, string, error) {
+		result, state, err := refresh()
+		if err == nil && result != nil {
+			lock.Lock()
+			lastState = state
+			lock.Unlock()
+		}
+		return result, state, err
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		// On timeout the refresh func may still be running.
+		lock.Lock()
+		defer lock.Unlock()
+		return &dxVirtualInterfaceWaitError{LastState: lastState, Err: err}
+	}
+	return nil
+}
+
+// checkVpnGatewayAmazonSideAsn returns an error unless the virtual private
+// gateway vgwId has the Amazon side ASN asn. Direct Connect takes the ASN
+// from the gateway, so this is the only way to honour an explicit value.
+func checkVpnGatewayAmazonSideAsn(conn *ec2.EC2, vgwId string, asn int64) error {
+	resp, err := conn.DescribeVpnGateways(&ec2.DescribeVpnGatewaysInput{
+		VpnGatewayIds: []*string{aws.String(vgwId)},
+	})
+	if err != nil {
+		return fmt.Errorf("Error describing virtual private gateway (%s): %s", vgwId, err)
+	}
+	if resp == nil || len(resp.VpnGateways) == 0 || resp.VpnGateways[0] == nil {
+		return fmt.Errorf("Virtual private gateway (%s) not found", vgwId)
+	}
+
+	if actual := aws.Int64Value(resp.VpnGateways[0].AmazonSideAsn); actual != asn {
+		return fmt.Errorf(
+			"amazon_side_asn (%d) does not match the Amazon side ASN (%d) of virtual private gateway (%s)",
+			asn, actual, vgwId)
+	}
+	return nil
+}
+
+// dxRouteFilterPrefixesSchema returns the schema of the route filter prefixes
+// a public virtual interface advertises. AWS returns the prefixes in its own
+// order and canonical form, so they are kept in a set of normalized CIDRs;
+// reordering them or writing 10.0.0.1/8 for 10.0.0.0/8 produces no diff.
+func dxRouteFilterPrefixesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		ForceNew: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			StateFunc:    normalizeDxRouteFilterPrefix,
+			ValidateFunc: validateDxRouteFilterPrefix,
+		},
+		Set: dxRouteFilterPrefixHash,
+	}
+}
+
+// dxVirtualInterfaceConfig is implemented by both schema.ResourceData and
+// schema.ResourceDiff, so that the configuration of a virtual interface can
+// be checked the same way when planning and when applying.
+type dxVirtualInterfaceConfig interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+// resourceAwsDirectconnectVirtualInterfaceCustomizeDiff rejects invalid
+// combinations of arguments at plan time, rather than after the apply has
+// started. Checks involving values that are only known after apply are
+// skipped, and left to AWS.
+func resourceAwsDirectconnectVirtualInterfaceCustomizeDiff(d *schema.ResourceDiff) error {
+	if d.NewValueKnown("connection_id") && d.NewValueKnown("lag_id") {
+		_, hasConnection := d.GetOk("connection_id")
+		_, hasLag := d.GetOk("lag_id")
+		if !hasConnection && !hasLag {
+			return fmt.Errorf("One of connection_id or lag_id is required")
+		}
+	}
+
+	if d.NewValueKnown("amazon_address") && d.NewValueKnown("customer_address") {
+		if err := validateDxBgpPeerAddressPair(d.Get("amazon_address").(string), d.Get("customer_address").(string)); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown("vif_type") {
+		return nil
+	}
+	vifType := d.Get("vif_type").(string)
+
+	if vifType == dxVirtualInterfaceTypePublic {
+		if !d.NewValueKnown("route_filter_prefixes") {
+			return nil
+		}
+		return checkDxPublicVirtualInterface(d)
+	}
+
+	if d.NewValueKnown("route_filter_prefixes") && d.Get("route_filter_prefixes").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("route_filter_prefixes can only be set for %s virtual interfaces", dxVirtualInterfaceTypePublic)
+	}
+
+	return nil
+}
+
+// checkDxPublicVirtualInterface returns an error if the configuration of a
+// public virtual interface has arguments only private and transit virtual
+// interfaces support. Public interfaces reach the AWS public endpoints
+// rather than a gateway, and must advertise at least one prefix.
+func checkDxPublicVirtualInterface(d dxVirtualInterfaceConfig) error {
+	for _, k := range []string{"virtual_gateway_id", "dx_gateway_id", "amazon_side_asn"} {
+		if _, ok := d.GetOk(k); ok {
+			return fmt.Errorf("%s can't be set for %s virtual interfaces", k, dxVirtualInterfaceTypePublic)
+		}
+	}
+	if v, ok := d.GetOk("mtu"); ok && v.(int) != 1500 {
+		return fmt.Errorf("mtu must be 1500 for %s virtual interfaces", dxVirtualInterfaceTypePublic)
+	}
+	if d.Get("route_filter_prefixes").(*schema.Set).Len() == 0 {
+		return fmt.Errorf("route_filter_prefixes is required for %s virtual interfaces", dxVirtualInterfaceTypePublic)
+	}
+	return nil
+}
+
+// normalizeDxRouteFilterPrefix returns the canonical form of a CIDR, with
+// the host bits masked off. Anything that doesn't parse is left alone, it
+// has been rejected by validateDxRouteFilterPrefix already.
+func normalizeDxRouteFilterPrefix(v interface{}) string {
+	s := v.(string)
+	_, ipnet, err := net.ParseCIDR(s)
+	if err != nil {
+		return s
+	}
+	return ipnet.String()
+}
+
+// suppressEquivalentDxBgpPeerAddress suppresses the diff between two
+// spellings of the same peering CIDR, e.g. IPv6 addresses that AWS returns
+// in lower case and compressed form.
+func suppressEquivalentDxBgpPeerAddress(k, old, new string, d *schema.ResourceData) bool {
+	oldIp, oldNet, err := net.ParseCIDR(old)
+	if err != nil {
+		return false
+	}
+	newIp, newNet, err := net.ParseCIDR(new)
+	if err != nil {
+		return false
+	}
+
+	return oldIp.Equal(newIp) && oldNet.String() == newNet.String()
+}
+
+func dxRouteFilterPrefixHash(v interface{}) int {
+	return hashcode.String(normalizeDxRouteFilterPrefix(v))
+}
+
+// dxVirtualInterfaceBgpStatusRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch the BGP status of a Direct Connect virtual interface,
+// as summarized by dxVirtualInterfaceBgpStatus. An interface without peers
+// yet is reported with an "unknown" status.
+func dxVirtualInterfaceBgpStatusRefreshFunc(conn directConnectAPI, vifId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		vifRaw, _, err := dxVirtualInterfaceStateRefreshFunc(conn, vifId)()
+		if err != nil {
+			return nil, "", err
+		}
+
+		vif, ok := vifRaw.(*directconnect.VirtualInterface)
+		if !ok {
+			return nil, "", nil
+		}
+
+		status := dxVirtualInterfaceBgpStatus(vif.BgpPeers)
+		if status == "" {
+			status = directconnect.BGPStatusUnknown
+		}
+		return vif, status, nil
+	}
+}
+
+// dxBgpPeersSchema returns the schema of the computed BGP peers of a virtual
+// interface, as flattened by flattenDxBgpPeers.
+func dxBgpPeersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"bgp_peer_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"address_family": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"asn": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"amazon_address": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"customer_address": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"bgp_status": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"bgp_peer_state": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func flattenDxBgpPeers(peers []*directconnect.BGPPeer) []interface{} {
+	result := make([]interface{}, 0, len(peers))
+	for _, peer := range peers {
+		if peer == nil {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"bgp_peer_id":      aws.StringValue(peer.BgpPeerId),
+			"address_family":   aws.StringValue(peer.AddressFamily),
+			"asn":              int(aws.Int64Value(peer.Asn)),
+			"amazon_address":   aws.StringValue(peer.AmazonAddress),
+			"customer_address": aws.StringValue(peer.CustomerAddress),
+			"bgp_status":       aws.StringValue(peer.BgpStatus),
+			"bgp_peer_state":   aws.StringValue(peer.BgpPeerState),
+		})
+	}
+	return result
+}
+
+// dxVirtualInterfaceBgpStatus summarizes the BGP status of a virtual
+// interface as the status of its main BGP peer.
+func dxVirtualInterfaceBgpStatus(peers []*directconnect.BGPPeer) string {
+	if peer := dxVirtualInterfaceMainBgpPeer(peers); peer != nil {
+		return aws.StringValue(peer.BgpStatus)
+	}
+	return ""
+}
+
+// dxVirtualInterfaceMainBgpPeer returns the peer that stands for the BGP
+// session of a virtual interface: its IPv4 peer, falling back to the first
+// peer for IPv6-only interfaces. It returns nil if there are no peers.
+func dxVirtualInterfaceMainBgpPeer(peers []*directconnect.BGPPeer) *directconnect.BGPPeer {
+	var first *directconnect.BGPPeer
+	for _, peer := range peers {
+		if peer == nil {
+			continue
+		}
+		if aws.StringValue(peer.AddressFamily) == directconnect.AddressFamilyIpv4 {
+			return peer
+		}
+		if first == nil {
+			first = peer
+		}
+	}
+	return first
+}
+
+// isNoSuchDxVirtualInterfaceErr reports whether err is the client exception
+// Direct Connect returns when asked about a virtual interface that doesn't
+// exist. The API has no dedicated error code for this, so the message has to
+// be inspected; depending on the call it says either "does not exist" or
+// "not found".
+func isNoSuchDxVirtualInterfaceErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok || awsErr.Code() != "DirectConnectClientException" {
+		return false
+	}
+	msg := strings.ToLower(awsErr.Message())
+	return strings.Contains(msg, "does not exist") || strings.Contains(msg, "not found")
+}
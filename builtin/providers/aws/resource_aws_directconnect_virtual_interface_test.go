@@ -0,0 +1,1259 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsCredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/hil/ast"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Direct Connect virtual interfaces can only be created on top of a
+// physical (and billable) connection, so the acceptance tests need an
+// existing connection ID. When the provider's directconnect endpoint is
+// pointed at a mock, any connection ID the mock accepts will do. Tests
+// with a fixture, see testAccFixtureTransport, can also be replayed
+// without a connection.
+func testAccDxConnectionPreCheck(t *testing.T) string {
+	connectionId := os.Getenv("DX_CONNECTION_ID")
+	if connectionId == "" {
+		t.Skip("Environment variable DX_CONNECTION_ID is not set")
+	}
+	return connectionId
+}
+
+func TestAccAWSDirectconnectVirtualInterface_basic(t *testing.T) {
+	var vif directconnect.VirtualInterface
+	ft, done := testAccFixtureTransport(t, "dx_vif_basic")
+	defer done()
+	connectionId := ft.Getenv("DX_CONNECTION_ID")
+	if connectionId == "" {
+		t.Skip("Environment variable DX_CONNECTION_ID is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		Fixture:      ft,
+		CheckDestroy: testAccCheckAwsDirectconnectVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectVirtualInterfaceConfig, connectionId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectVirtualInterfaceExists(
+						"aws_directconnect_virtual_interface.foo", &vif),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "virtual_interface_name", "terraform-testacc-dxvif"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "vlan", "4094"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "asn", "65352"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "address_family", "ipv4"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "tags.Name", "terraform-testacc-dxvif"),
+					testAccCheckDXTags(&vif.Tags, "Name", "terraform-testacc-dxvif"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDirectconnectVirtualInterface_mtu(t *testing.T) {
+	var before, after directconnect.VirtualInterface
+	connectionId := testAccDxConnectionPreCheck(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDirectconnectVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectVirtualInterfaceConfig_mtu, connectionId, 1500),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectVirtualInterfaceExists(
+						"aws_directconnect_virtual_interface.foo", &before),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "mtu", "1500"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectVirtualInterfaceConfig_mtu, connectionId, 9001),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectVirtualInterfaceExists(
+						"aws_directconnect_virtual_interface.foo", &after),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "mtu", "9001"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "jumbo_frame_capable", "true"),
+					func(*terraform.State) error {
+						if aws.StringValue(before.VirtualInterfaceId) != aws.StringValue(after.VirtualInterfaceId) {
+							return fmt.Errorf("Expected the MTU to be updated in place")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDirectconnectVirtualInterface_lag(t *testing.T) {
+	var vif directconnect.VirtualInterface
+	lagId := os.Getenv("DX_LAG_ID")
+	if lagId == "" {
+		t.Skip("Environment variable DX_LAG_ID is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDirectconnectVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectVirtualInterfaceConfig_lag, lagId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectVirtualInterfaceExists(
+						"aws_directconnect_virtual_interface.foo", &vif),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "lag_id", lagId),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "connection_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDirectconnectVirtualInterface_transit(t *testing.T) {
+	var vif directconnect.VirtualInterface
+	connectionId := testAccDxConnectionPreCheck(t)
+	dxGatewayId := os.Getenv("DX_GATEWAY_ID")
+	if dxGatewayId == "" {
+		t.Skip("Environment variable DX_GATEWAY_ID is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDirectconnectVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectVirtualInterfaceConfig_transit, connectionId, dxGatewayId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectVirtualInterfaceExists(
+						"aws_directconnect_virtual_interface.foo", &vif),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "vif_type", "transit"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "dx_gateway_id", dxGatewayId),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDirectconnectVirtualInterface_hosted(t *testing.T) {
+	var vif directconnect.VirtualInterface
+	connectionId := testAccDxConnectionPreCheck(t)
+	ownerAccountId := os.Getenv("DX_HOSTED_VIF_OWNER_ACCOUNT_ID")
+	if ownerAccountId == "" {
+		t.Skip("Environment variable DX_HOSTED_VIF_OWNER_ACCOUNT_ID is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDirectconnectVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectVirtualInterfaceConfig_hosted, connectionId, ownerAccountId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectVirtualInterfaceExists(
+						"aws_directconnect_virtual_interface.foo", &vif),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "owner_account_id", ownerAccountId),
+					// Nobody accepts the interface on the owner's side, so
+					// creating it must not wait for it to become available.
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "virtual_interface_state", "confirming"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDirectconnectVirtualInterface_public(t *testing.T) {
+	var vif directconnect.VirtualInterface
+	connectionId := testAccDxConnectionPreCheck(t)
+	amazonAddress := os.Getenv("DX_PUBLIC_VIF_AMAZON_ADDRESS")
+	customerAddress := os.Getenv("DX_PUBLIC_VIF_CUSTOMER_ADDRESS")
+	prefix := os.Getenv("DX_PUBLIC_VIF_ROUTE_FILTER_PREFIX")
+	if amazonAddress == "" || customerAddress == "" || prefix == "" {
+		t.Skip("Environment variables DX_PUBLIC_VIF_AMAZON_ADDRESS, DX_PUBLIC_VIF_CUSTOMER_ADDRESS and DX_PUBLIC_VIF_ROUTE_FILTER_PREFIX must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDirectconnectVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectVirtualInterfaceConfig_public,
+					connectionId, amazonAddress, customerAddress, prefix),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDirectconnectVirtualInterfaceExists(
+						"aws_directconnect_virtual_interface.foo", &vif),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "vif_type", "public"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_virtual_interface.foo", "route_filter_prefixes.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceRead_deletedState(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceState": "deleted"}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+	if err := resourceAwsDirectconnectVirtualInterfaceRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected ID to be cleared for a deleted virtual interface, got: %q", d.Id())
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceRead_notFound(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 400,
+			Body:       `{"__type": "DirectConnectClientException", "message": "Virtual interface dxvif-abcde123 not found"}`,
+		},
+	})
+	defer closeFunc()
+
+	d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+	if err := resourceAwsDirectconnectVirtualInterfaceRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected ID to be cleared for a missing virtual interface, got: %q", d.Id())
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceRead_emptyResponse(t *testing.T) {
+	for _, body := range []string{`{"virtualInterfaces": []}`, `{}`, `{"virtualInterfaces": [null]}`} {
+		closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+			"DescribeVirtualInterfaces": &dxMockResponse{
+				StatusCode: 200,
+				Body:       body,
+			},
+		})
+
+		d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+		err := resourceAwsDirectconnectVirtualInterfaceRead(d, &AWSClient{dirconn: conn})
+		closeFunc()
+
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got: %s", body, err)
+		}
+		if d.Id() != "" {
+			t.Fatalf("Expected ID to be cleared for %s, got: %q", body, d.Id())
+		}
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceRead_available(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceState": "available", "vlan": 4094, "asn": 65352, "amazonSideAsn": 4200000000}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+	if err := resourceAwsDirectconnectVirtualInterfaceRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "dxvif-abcde123" {
+		t.Fatalf("Expected ID to be kept, got: %q", d.Id())
+	}
+	if v := d.Get("virtual_interface_state").(string); v != "available" {
+		t.Fatalf("Expected virtual_interface_state to be available, got: %q", v)
+	}
+	if v := d.Get("vlan").(int); v != 4094 {
+		t.Fatalf("Expected vlan to be 4094, got: %d", v)
+	}
+	if v := d.Get("amazon_side_asn").(int); v != 4200000000 {
+		t.Fatalf("Expected amazon_side_asn to be 4200000000, got: %d", v)
+	}
+}
+
+// An imported virtual interface only has its ID, so everything else,
+// including the connection it sits on, has to come from reading it by ID.
+func TestResourceAwsDirectconnectVirtualInterfaceRead_imported(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "connectionId": "dxcon-abcde123", "virtualInterfaceName": "dxvif", "virtualInterfaceType": "private", "virtualInterfaceState": "available", "virtualGatewayId": "vgw-abcde123", "ownerAccount": "123456789012", "vlan": 4094, "asn": 65352, "addressFamily": "ipv4", "authKey": "secret", "amazonAddress": "169.254.0.1/30", "customerAddress": "169.254.0.2/30", "mtu": 1500}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+	client := &AWSClient{dirconn: conn, region: "us-east-1", dxVifCache: newDxVirtualInterfaceCache()}
+	if err := resourceAwsDirectconnectVirtualInterfaceRead(d, client); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"connection_id":          "dxcon-abcde123",
+		"virtual_interface_name": "dxvif",
+		"vif_type":               "private",
+		"virtual_gateway_id":     "vgw-abcde123",
+		"owner_account_id":       "123456789012",
+		"vlan":                   4094,
+		"asn":                    65352,
+		"address_family":         "ipv4",
+		"auth_key":               "secret",
+		"amazon_address":         "169.254.0.1/30",
+		"customer_address":       "169.254.0.2/30",
+		"mtu":                    1500,
+		"arn":                    "arn:aws:directconnect:us-east-1:123456789012:dxvif/dxvif-abcde123",
+	}
+	for k, v := range expected {
+		if actual := d.Get(k); actual != v {
+			t.Fatalf("Expected %s to be %v, got: %v", k, v, actual)
+		}
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceRead_lag(t *testing.T) {
+	cases := map[string]struct {
+		ConnectionId string
+		LagId        string
+		Expected     map[string]string
+	}{
+		"imported": {
+			Expected: map[string]string{"connection_id": "", "lag_id": "dxlag-fgh45678"},
+		},
+		"lag_id": {
+			LagId:    "dxlag-fgh45678",
+			Expected: map[string]string{"connection_id": "", "lag_id": "dxlag-fgh45678"},
+		},
+		"LAG as connection_id": {
+			ConnectionId: "dxlag-fgh45678",
+			Expected:     map[string]string{"connection_id": "dxlag-fgh45678", "lag_id": ""},
+		},
+	}
+
+	for name, tc := range cases {
+		closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+			"DescribeVirtualInterfaces": &dxMockResponse{
+				StatusCode: 200,
+				Body:       `{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "connectionId": "dxlag-fgh45678", "virtualInterfaceState": "available"}]}`,
+			},
+		})
+
+		d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+		d.Set("connection_id", tc.ConnectionId)
+		d.Set("lag_id", tc.LagId)
+		err := resourceAwsDirectconnectVirtualInterfaceRead(d, &AWSClient{dirconn: conn})
+		closeFunc()
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got: %s", name, err)
+		}
+
+		for k, v := range tc.Expected {
+			if actual := d.Get(k).(string); actual != v {
+				t.Fatalf("%s: Expected %s to be %q, got: %q", name, k, v, actual)
+			}
+		}
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceRead_bgpPeers(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body: `{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceState": "available", "awsDeviceV2": "EqDC2-123h49s71dabc", "jumboFrameCapable": true, "bgpPeers": [
+				{"bgpPeerId": "dxpeer-1", "addressFamily": "ipv6", "asn": 65351, "bgpStatus": "up", "bgpPeerState": "available"},
+				{"bgpPeerId": "dxpeer-2", "addressFamily": "ipv4", "asn": 65352, "bgpStatus": "down", "bgpPeerState": "pending"}
+			]}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+	if err := resourceAwsDirectconnectVirtualInterfaceRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if v := d.Get("bgp_peers.#").(int); v != 2 {
+		t.Fatalf("Expected 2 BGP peers, got: %d", v)
+	}
+	if v := d.Get("bgp_peers.0.bgp_status").(string); v != "up" {
+		t.Fatalf("Expected first BGP peer to be up, got: %q", v)
+	}
+	if v := d.Get("bgp_peers.1.asn").(int); v != 65352 {
+		t.Fatalf("Expected second BGP peer ASN to be 65352, got: %d", v)
+	}
+	if v := d.Get("bgp_status").(string); v != "down" {
+		t.Fatalf("Expected bgp_status to follow the IPv4 peer (down), got: %q", v)
+	}
+	if v := d.Get("bgp_peer_state").(string); v != "pending" {
+		t.Fatalf("Expected bgp_peer_state to follow the IPv4 peer (pending), got: %q", v)
+	}
+	if v := d.Get("aws_device").(string); v != "EqDC2-123h49s71dabc" {
+		t.Fatalf("Expected aws_device to be EqDC2-123h49s71dabc, got: %q", v)
+	}
+	if v := d.Get("jumbo_frame_capable").(bool); !v {
+		t.Fatal("Expected jumbo_frame_capable to be true")
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceRead_otherError(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 400,
+			Body:       `{"__type": "DirectConnectClientException", "message": "Access denied"}`,
+		},
+	})
+	defer closeFunc()
+
+	d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+	if err := resourceAwsDirectconnectVirtualInterfaceRead(d, &AWSClient{dirconn: conn}); err == nil {
+		t.Fatal("Expected an error, got none")
+	}
+	if d.Id() != "dxvif-abcde123" {
+		t.Fatalf("Expected ID to be kept on error, got: %q", d.Id())
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceDelete_timeout(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DeleteVirtualInterface": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaceState": "deleting"}`,
+		},
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceState": "deleting"}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDirectconnectVirtualInterface().Data(&terraform.InstanceState{
+		ID:   "dxvif-abcde123",
+		Meta: map[string]string{"timeouts.delete": "1s"},
+	})
+
+	err := resourceAwsDirectconnectVirtualInterfaceDelete(d, &AWSClient{dirconn: conn})
+	if err == nil {
+		t.Fatalf("Expected to time out waiting for the virtual interface to be deleted")
+	}
+	if !strings.Contains(err.Error(), "timeout while waiting") {
+		t.Fatalf("Expected a timeout, got: %s", err)
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceDelete_notFound(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DeleteVirtualInterface": &dxMockResponse{
+			StatusCode: 400,
+			Body:       `{"__type": "DirectConnectClientException", "message": "Virtual interface dxvif-abcde123 does not exist"}`,
+		},
+	})
+	defer closeFunc()
+
+	d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+	if err := resourceAwsDirectconnectVirtualInterfaceDelete(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+}
+
+func TestDxVirtualInterfaceStateRefreshFunc_notFound(t *testing.T) {
+	responses := []*dxMockResponse{
+		&dxMockResponse{StatusCode: 200, Body: `{"virtualInterfaces": []}`},
+		&dxMockResponse{StatusCode: 200, Body: `{"virtualInterfaces": [null]}`},
+		&dxMockResponse{
+			StatusCode: 400,
+			Body:       `{"__type": "DirectConnectClientException", "message": "Virtual interface dxvif-abcde123 not found"}`,
+		},
+	}
+	for _, resp := range responses {
+		closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+			"DescribeVirtualInterfaces": resp,
+		})
+
+		vif, state, err := dxVirtualInterfaceStateRefreshFunc(conn, "dxvif-abcde123")()
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got: %s", resp.Body, err)
+		}
+		if vif != nil || state != "" {
+			t.Fatalf("Expected not found for %s, got: %#v, %q", resp.Body, vif, state)
+		}
+
+		// Waiting for the deletion is done once it can't be found.
+		_, state, err = dxVirtualInterfaceDeletedRefreshFunc(conn, "dxvif-abcde123")()
+		closeFunc()
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got: %s", resp.Body, err)
+		}
+		if state != directconnect.VirtualInterfaceStateDeleted {
+			t.Fatalf("Expected state %q for %s, got: %q", directconnect.VirtualInterfaceStateDeleted, resp.Body, state)
+		}
+	}
+}
+
+func TestDxVirtualInterfaceBgpStatusRefreshFunc(t *testing.T) {
+	cases := map[string]string{
+		`{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceState": "available"}]}`: "available/unknown",
+		`{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceState": "available", "bgpPeers": [
+			{"bgpPeerId": "dxpeer-1", "addressFamily": "ipv4", "bgpStatus": "up"}
+		]}]}`: "available/up",
+	}
+	for body, expected := range cases {
+		closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+			"DescribeVirtualInterfaces": &dxMockResponse{
+				StatusCode: 200,
+				Body:       body,
+			},
+		})
+
+		_, state, err := resource.ComposeStateRefreshFunc(
+			dxVirtualInterfaceStateRefreshFunc(conn, "dxvif-abcde123"),
+			dxVirtualInterfaceBgpStatusRefreshFunc(conn, "dxvif-abcde123"),
+		)()
+		closeFunc()
+		if err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+		if state != expected {
+			t.Fatalf("Expected state %q, got: %q", expected, state)
+		}
+	}
+}
+
+func TestWaitForDxVirtualInterface_lastState(t *testing.T) {
+	cases := map[string]struct {
+		State    string
+		Timeout  time.Duration
+		Expected string
+	}{
+		"timeout": {
+			State:    "verifying",
+			Timeout:  500 * time.Millisecond,
+			Expected: "last observed state: verifying",
+		},
+		"rejected": {
+			State:    "rejected",
+			Timeout:  time.Minute,
+			Expected: "last observed state: rejected",
+		},
+	}
+	for name, tc := range cases {
+		closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+			"DescribeVirtualInterfaces": &dxMockResponse{
+				StatusCode: 200,
+				Body:       fmt.Sprintf(`{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceState": %q}]}`, tc.State),
+			},
+		})
+
+		err := waitForDxVirtualInterface(&resource.StateChangeConf{
+			Pending: []string{"pending", "verifying"},
+			Target:  []string{"available"},
+			Refresh: dxVirtualInterfaceStateRefreshFunc(conn, "dxvif-abcde123"),
+			Timeout: tc.Timeout,
+		})
+		closeFunc()
+
+		waitErr, ok := err.(*dxVirtualInterfaceWaitError)
+		if !ok {
+			t.Fatalf("%s: Expected a *dxVirtualInterfaceWaitError, got: %#v", name, err)
+		}
+		if waitErr.LastState != tc.State {
+			t.Fatalf("%s: Expected last state %q, got: %q", name, tc.State, waitErr.LastState)
+		}
+		if !strings.HasSuffix(err.Error(), tc.Expected) {
+			t.Fatalf("%s: Expected error to end with %q, got: %q", name, tc.Expected, err)
+		}
+	}
+}
+
+func TestDxRouteFilterPrefixes_noDiff(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"route_filter_prefixes": dxRouteFilterPrefixesSchema(),
+		},
+	}
+
+	// State as written by Read from the canonical prefixes AWS returns.
+	d := r.Data(&terraform.InstanceState{ID: "dxvif-abcde123"})
+	if err := d.Set("route_filter_prefixes", []interface{}{"10.0.0.0/8", "2001:db8::/32", "192.168.0.0/16"}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	state := d.State()
+
+	cases := map[string][]interface{}{
+		"same":          []interface{}{"10.0.0.0/8", "2001:db8::/32", "192.168.0.0/16"},
+		"reordered":     []interface{}{"192.168.0.0/16", "10.0.0.0/8", "2001:db8::/32"},
+		"non-canonical": []interface{}{"10.1.2.3/8", "2001:DB8:0:0::1/32", "192.168.10.0/16"},
+	}
+	for name, prefixes := range cases {
+		rc, err := config.NewRawConfig(map[string]interface{}{
+			"route_filter_prefixes": prefixes,
+		})
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got: %s", name, err)
+		}
+
+		diff, err := r.Diff(state, terraform.NewResourceConfig(rc))
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got: %s", name, err)
+		}
+		if diff != nil && !diff.Empty() {
+			t.Fatalf("%s: Expected no diff, got: %#v", name, diff.Attributes)
+		}
+	}
+
+	rc, err := config.NewRawConfig(map[string]interface{}{
+		"route_filter_prefixes": []interface{}{"10.0.0.0/8", "2001:db8::/32", "172.16.0.0/12"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	diff, err := r.Diff(state, terraform.NewResourceConfig(rc))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Fatalf("Expected a diff for a changed prefix")
+	}
+}
+
+func TestDxBgpPeerAddress_noDiff(t *testing.T) {
+	r := resourceAwsDirectconnectVirtualInterface()
+
+	// State as written by Read from the addresses AWS returns.
+	state := &terraform.InstanceState{
+		ID: "dxvif-abcde123",
+		Attributes: map[string]string{
+			"connection_id":          "dxcon-abcde123",
+			"virtual_interface_name": "foo",
+			"vlan":                   "101",
+			"asn":                    "65000",
+			"vif_type":               "private",
+			"virtual_gateway_id":     "vgw-abcde123",
+			"address_family":         "ipv6",
+			"amazon_address":         "2001:db8::1/125",
+			"customer_address":       "2001:db8::2/125",
+		},
+	}
+
+	cases := map[string]struct {
+		AmazonAddress   string
+		CustomerAddress string
+		Diff            bool
+	}{
+		"same":          {"2001:db8::1/125", "2001:DB8::2/125", false},
+		"upper case":    {"2001:DB8::1/125", "2001:DB8::2/125", false},
+		"uncompressed":  {"2001:0db8:0000:0000:0000:0000:0000:0001/125", "2001:DB8::2/125", false},
+		"other address": {"2001:db8::3/125", "2001:DB8::2/125", true},
+		"other mask":    {"2001:db8::1/126", "2001:DB8::2/126", true},
+	}
+	for name, tc := range cases {
+		rc, err := config.NewRawConfig(map[string]interface{}{
+			"connection_id":          "dxcon-abcde123",
+			"virtual_interface_name": "foo",
+			"vlan":                   101,
+			"asn":                    65000,
+			"vif_type":               "private",
+			"virtual_gateway_id":     "vgw-abcde123",
+			"address_family":         "ipv6",
+			"amazon_address":         tc.AmazonAddress,
+			"customer_address":       tc.CustomerAddress,
+		})
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got: %s", name, err)
+		}
+
+		diff, err := r.Diff(state, terraform.NewResourceConfig(rc))
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got: %s", name, err)
+		}
+		if !tc.Diff {
+			if diff == nil {
+				continue
+			}
+			for _, k := range []string{"amazon_address", "customer_address"} {
+				if attr, ok := diff.Attributes[k]; ok {
+					t.Fatalf("%s: Expected no diff of %s, got: %#v", name, k, attr)
+				}
+			}
+			continue
+		}
+
+		if diff == nil {
+			t.Fatalf("%s: Expected a diff of amazon_address", name)
+		}
+		if attr, ok := diff.Attributes["amazon_address"]; !ok || !attr.RequiresNew {
+			t.Fatalf("%s: Expected amazon_address to force a new interface, got: %#v", name, attr)
+		}
+	}
+}
+
+func TestResourceAwsDirectconnectVirtualInterfaceCustomizeDiff(t *testing.T) {
+	r := resourceAwsDirectconnectVirtualInterface()
+
+	base := func() map[string]interface{} {
+		return map[string]interface{}{
+			"connection_id":          "dxcon-abcde123",
+			"virtual_interface_name": "foo",
+			"vlan":                   101,
+			"asn":                    65000,
+			"address_family":         "ipv4",
+		}
+	}
+
+	cases := map[string]struct {
+		Config map[string]interface{}
+		Err    string
+	}{
+		"private": {
+			Config: map[string]interface{}{
+				"virtual_gateway_id": "vgw-abcde123",
+				"amazon_address":     "169.254.0.1/30",
+				"customer_address":   "169.254.0.2/30",
+			},
+		},
+		"private, computed gateway": {
+			Config: map[string]interface{}{
+				"virtual_gateway_id": "${aws_vpn_gateway.foo.id}",
+			},
+		},
+		"no connection or LAG": {
+			Config: map[string]interface{}{
+				"connection_id":      "",
+				"virtual_gateway_id": "vgw-abcde123",
+			},
+			Err: "One of connection_id or lag_id is required",
+		},
+		"addresses in different subnets": {
+			Config: map[string]interface{}{
+				"virtual_gateway_id": "vgw-abcde123",
+				"amazon_address":     "169.254.0.1/30",
+				"customer_address":   "169.254.0.6/30",
+			},
+			Err: "must be in the same subnet",
+		},
+		"private with prefixes": {
+			Config: map[string]interface{}{
+				"virtual_gateway_id":    "vgw-abcde123",
+				"route_filter_prefixes": []interface{}{"203.0.113.0/24"},
+			},
+			Err: "route_filter_prefixes can only be set for public virtual interfaces",
+		},
+		"public without prefixes": {
+			Config: map[string]interface{}{
+				"vif_type":         "public",
+				"amazon_address":   "203.0.113.1/30",
+				"customer_address": "203.0.113.2/30",
+			},
+			Err: "route_filter_prefixes",
+		},
+		"public, computed prefixes": {
+			Config: map[string]interface{}{
+				"vif_type":              "public",
+				"amazon_address":        "203.0.113.1/30",
+				"customer_address":      "203.0.113.2/30",
+				"route_filter_prefixes": []interface{}{"${var.prefix}"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		c := base()
+		for k, v := range tc.Config {
+			c[k] = v
+		}
+		rc, err := config.NewRawConfig(c)
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got: %s", name, err)
+		}
+		err = rc.Interpolate(map[string]ast.Variable{
+			"aws_vpn_gateway.foo.id": ast.Variable{Type: ast.TypeString, Value: config.UnknownVariableValue},
+			"var.prefix":             ast.Variable{Type: ast.TypeString, Value: config.UnknownVariableValue},
+		})
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got: %s", name, err)
+		}
+
+		_, err = r.Diff(nil, terraform.NewResourceConfig(rc))
+		if tc.Err == "" {
+			if err != nil {
+				t.Fatalf("%s: Expected no error, got: %s", name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.Err) {
+			t.Fatalf("%s: Expected error containing %q, got: %v", name, tc.Err, err)
+		}
+	}
+}
+
+func testDxVirtualInterfaceResourceData(id string) *schema.ResourceData {
+	return resourceAwsDirectconnectVirtualInterface().Data(&terraform.InstanceState{ID: id})
+}
+
+type dxMockResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// getMockedAwsDirectConnectApi establishes a httptest server to simulate
+// behaviour of a real AWS Direct Connect server. Responses are keyed on the
+// name of the API operation.
+func getMockedAwsDirectConnectApi(responses map[string]*dxMockResponse) (func(), *directconnect.DirectConnect) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "OvertureService.")
+		log.Printf("[DEBUG] Received Direct Connect API %q request", op)
+
+		resp, ok := responses[op]
+		if !ok {
+			w.WriteHeader(400)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.Header().Set("X-Amzn-Requestid", "1b206dd1-f9a8-11e5-becf-051c60f11c4a")
+		w.WriteHeader(resp.StatusCode)
+		fmt.Fprintln(w, resp.Body)
+	}))
+
+	sess := session.New(&aws.Config{
+		Credentials: awsCredentials.NewStaticCredentials("accessKey", "secretKey", ""),
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(ts.URL),
+		MaxRetries:  aws.Int(0),
+	})
+	return ts.Close, directconnect.New(sess)
+}
+
+func testAccCheckAwsDirectconnectVirtualInterfaceDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dirconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_directconnect_virtual_interface" && rs.Type != "aws_directconnect_hosted_virtual_interface" {
+			continue
+		}
+
+		resp, err := conn.DescribeVirtualInterfaces(&directconnect.DescribeVirtualInterfacesInput{
+			VirtualInterfaceId: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if isNoSuchDxVirtualInterfaceErr(err) {
+				continue
+			}
+			return err
+		}
+
+		for _, vif := range resp.VirtualInterfaces {
+			if aws.StringValue(vif.VirtualInterfaceId) == rs.Primary.ID &&
+				aws.StringValue(vif.VirtualInterfaceState) != directconnect.VirtualInterfaceStateDeleted {
+				return fmt.Errorf("Direct Connect virtual interface (%s) still exists", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestCreateDxVirtualInterface_tags(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"CreatePrivateVirtualInterface": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceState": "pending", "ownerAccount": "123456789012", "tags": [{"key": "Name", "value": "dxvif"}]}`,
+		},
+	})
+	defer closeFunc()
+
+	var req *directconnect.CreatePrivateVirtualInterfaceInput
+	conn.Handlers.Build.PushBack(func(r *request.Request) {
+		req, _ = r.Params.(*directconnect.CreatePrivateVirtualInterfaceInput)
+	})
+
+	d := testDxVirtualInterfaceResourceData("")
+	d.Set("connection_id", "dxcon-abcde123")
+	d.Set("virtual_interface_name", "dxvif")
+	d.Set("vlan", 4094)
+	d.Set("asn", 65352)
+	d.Set("dx_gateway_id", "abcdef12-3456-7890-abcd-ef1234567890")
+	d.Set("tags", map[string]interface{}{"Name": "dxvif"})
+
+	// The mock doesn't answer TagResource, so tagging the interface in a
+	// separate call would fail.
+	client := &AWSClient{dirconn: conn, region: "us-east-1"}
+	vif, err := createDxVirtualInterface(client, d)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	d.SetId(aws.StringValue(vif.VirtualInterfaceId))
+	if err := tagDxVirtualInterfaceOnCreate(conn, d, client, vif); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if req == nil {
+		t.Fatalf("Expected a CreatePrivateVirtualInterface request")
+	}
+	if tags := tagsToMapDX(req.NewPrivateVirtualInterface.Tags); len(tags) != 1 || tags["Name"] != "dxvif" {
+		t.Fatalf("Expected the tags to be part of the create request, got: %#v", tags)
+	}
+}
+
+func TestCreateDxVirtualInterface_mtu(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"CreatePrivateVirtualInterface": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceState": "pending", "mtu": 9001, "jumboFrameCapable": true}`,
+		},
+	})
+	defer closeFunc()
+
+	var req *directconnect.CreatePrivateVirtualInterfaceInput
+	conn.Handlers.Build.PushBack(func(r *request.Request) {
+		req, _ = r.Params.(*directconnect.CreatePrivateVirtualInterfaceInput)
+	})
+
+	d := testDxVirtualInterfaceResourceData("")
+	d.Set("connection_id", "dxcon-abcde123")
+	d.Set("virtual_interface_name", "dxvif")
+	d.Set("vlan", 4094)
+	d.Set("asn", 65352)
+	d.Set("dx_gateway_id", "abcdef12-3456-7890-abcd-ef1234567890")
+	d.Set("mtu", 9001)
+
+	if _, err := createDxVirtualInterface(&AWSClient{dirconn: conn, region: "us-east-1"}, d); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if req == nil {
+		t.Fatalf("Expected a CreatePrivateVirtualInterface request")
+	}
+	vif := req.NewPrivateVirtualInterface
+	if vlan, asn := aws.Int64Value(vif.Vlan), aws.Int64Value(vif.Asn); vlan != 4094 || asn != 65352 {
+		t.Fatalf("Unexpected VLAN and ASN: %d, %d", vlan, asn)
+	}
+	if mtu := aws.Int64Value(vif.Mtu); mtu != 9001 {
+		t.Fatalf("Unexpected MTU: %d", mtu)
+	}
+	if vif.AuthKey != nil || vif.AmazonAddress != nil || vif.CustomerAddress != nil {
+		t.Fatalf("Expected AWS to pick the BGP key and addresses, got: %#v", vif)
+	}
+}
+
+func TestCreateDxVirtualInterface_lag(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"CreatePrivateVirtualInterface": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaceId": "dxvif-abcde123", "connectionId": "dxlag-fgh45678", "virtualInterfaceState": "pending"}`,
+		},
+	})
+	defer closeFunc()
+
+	var req *directconnect.CreatePrivateVirtualInterfaceInput
+	conn.Handlers.Build.PushBack(func(r *request.Request) {
+		req, _ = r.Params.(*directconnect.CreatePrivateVirtualInterfaceInput)
+	})
+
+	d := testDxVirtualInterfaceResourceData("")
+	d.Set("lag_id", "dxlag-fgh45678")
+	d.Set("virtual_interface_name", "dxvif")
+	d.Set("vlan", 4094)
+	d.Set("asn", 65352)
+	d.Set("dx_gateway_id", "abcdef12-3456-7890-abcd-ef1234567890")
+
+	if _, err := createDxVirtualInterface(&AWSClient{dirconn: conn, region: "us-east-1"}, d); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if req == nil {
+		t.Fatalf("Expected a CreatePrivateVirtualInterface request")
+	}
+	if id := aws.StringValue(req.ConnectionId); id != "dxlag-fgh45678" {
+		t.Fatalf("Expected the virtual interface to be created on the LAG, got: %q", id)
+	}
+}
+
+func TestCreateDxVirtualInterface_public(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"CreatePublicVirtualInterface": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaceId": "dxvif-abcde123", "virtualInterfaceType": "public", "virtualInterfaceState": "verifying"}`,
+		},
+	})
+	defer closeFunc()
+
+	var req *directconnect.CreatePublicVirtualInterfaceInput
+	conn.Handlers.Build.PushBack(func(r *request.Request) {
+		req, _ = r.Params.(*directconnect.CreatePublicVirtualInterfaceInput)
+	})
+
+	d := testDxVirtualInterfaceResourceData("")
+	d.Set("connection_id", "dxcon-abcde123")
+	d.Set("vif_type", "public")
+	d.Set("virtual_interface_name", "dxvif")
+	d.Set("vlan", 4094)
+	d.Set("asn", 65352)
+	d.Set("amazon_address", "203.0.113.1/30")
+	d.Set("customer_address", "203.0.113.2/30")
+	d.Set("route_filter_prefixes", []interface{}{"203.0.113.0/24", "198.51.100.0/24"})
+
+	vif, err := createDxVirtualInterface(&AWSClient{dirconn: conn, region: "us-east-1"}, d)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if id := aws.StringValue(vif.VirtualInterfaceId); id != "dxvif-abcde123" {
+		t.Fatalf("Unexpected virtual interface ID: %q", id)
+	}
+
+	if req == nil {
+		t.Fatalf("Expected a CreatePublicVirtualInterface request")
+	}
+	prefixes := map[string]bool{}
+	for _, p := range req.NewPublicVirtualInterface.RouteFilterPrefixes {
+		prefixes[aws.StringValue(p.Cidr)] = true
+	}
+	if len(prefixes) != 2 || !prefixes["203.0.113.0/24"] || !prefixes["198.51.100.0/24"] {
+		t.Fatalf("Expected the route filter prefixes in the create request, got: %#v", prefixes)
+	}
+}
+
+func TestCreateDxVirtualInterface_publicInvalid(t *testing.T) {
+	cases := map[string]struct {
+		Key      string
+		Value    interface{}
+		Expected string
+	}{
+		"no prefixes": {
+			Expected: "route_filter_prefixes is required for public virtual interfaces",
+		},
+		"virtual gateway": {
+			Key:      "virtual_gateway_id",
+			Value:    "vgw-abcde123",
+			Expected: "virtual_gateway_id can't be set for public virtual interfaces",
+		},
+		"dx gateway": {
+			Key:      "dx_gateway_id",
+			Value:    "abcdef12-3456-7890-abcd-ef1234567890",
+			Expected: "dx_gateway_id can't be set for public virtual interfaces",
+		},
+		"jumbo frames": {
+			Key:      "mtu",
+			Value:    9001,
+			Expected: "mtu must be 1500 for public virtual interfaces",
+		},
+	}
+
+	for name, tc := range cases {
+		d := testDxVirtualInterfaceResourceData("")
+		d.Set("connection_id", "dxcon-abcde123")
+		d.Set("vif_type", "public")
+		d.Set("virtual_interface_name", "dxvif")
+		d.Set("vlan", 4094)
+		d.Set("asn", 65352)
+		if tc.Key != "" {
+			d.Set(tc.Key, tc.Value)
+			d.Set("route_filter_prefixes", []interface{}{"203.0.113.0/24"})
+		}
+
+		// No API calls are expected, so there is no need for a mock.
+		_, err := createDxVirtualInterface(&AWSClient{}, d)
+		if err == nil || err.Error() != tc.Expected {
+			t.Fatalf("%s: Expected error %q, got: %v", name, tc.Expected, err)
+		}
+	}
+}
+
+func TestTagDxVirtualInterfaceOnCreate_untagged(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"TagResource": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{}`,
+		},
+	})
+	defer closeFunc()
+
+	var req *directconnect.TagResourceInput
+	conn.Handlers.Build.PushBack(func(r *request.Request) {
+		req, _ = r.Params.(*directconnect.TagResourceInput)
+	})
+
+	d := testDxVirtualInterfaceResourceData("dxvif-abcde123")
+	d.Set("tags", map[string]interface{}{"Name": "dxvif"})
+
+	vif := &directconnect.VirtualInterface{
+		VirtualInterfaceId: aws.String("dxvif-abcde123"),
+		OwnerAccount:       aws.String("123456789012"),
+	}
+	client := &AWSClient{dirconn: conn, region: "us-east-1"}
+	if err := tagDxVirtualInterfaceOnCreate(conn, d, client, vif); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if req == nil {
+		t.Fatalf("Expected a TagResource request")
+	}
+	if arn := aws.StringValue(req.ResourceArn); arn != "arn:aws:directconnect:us-east-1:123456789012:dxvif/dxvif-abcde123" {
+		t.Fatalf("Unexpected ARN: %q", arn)
+	}
+	if tags := tagsToMapDX(req.Tags); len(tags) != 1 || tags["Name"] != "dxvif" {
+		t.Fatalf("Unexpected tags: %#v", tags)
+	}
+}
+
+func testAccCheckAwsDirectconnectVirtualInterfaceExists(n string, vif *directconnect.VirtualInterface) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dirconn
+		resp, err := conn.DescribeVirtualInterfaces(&directconnect.DescribeVirtualInterfacesInput{
+			VirtualInterfaceId: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(resp.VirtualInterfaces) != 1 ||
+			aws.StringValue(resp.VirtualInterfaces[0].VirtualInterfaceId) != rs.Primary.ID {
+			return fmt.Errorf("Direct Connect virtual interface (%s) not found", rs.Primary.ID)
+		}
+
+		*vif = *resp.VirtualInterfaces[0]
+
+		return nil
+	}
+}
+
+const testAccDirectconnectVirtualInterfaceConfig = `
+resource "aws_vpn_gateway" "foo" {
+  tags {
+    Name = "terraform-testacc-dxvif"
+  }
+}
+
+resource "aws_directconnect_virtual_interface" "foo" {
+  connection_id = "%s"
+  virtual_interface_name = "terraform-testacc-dxvif"
+  vlan = 4094
+  asn = 65352
+  address_family = "ipv4"
+  virtual_gateway_id = "${aws_vpn_gateway.foo.id}"
+
+  tags {
+    Name = "terraform-testacc-dxvif"
+  }
+}
+`
+
+const testAccDirectconnectVirtualInterfaceConfig_mtu = `
+resource "aws_vpn_gateway" "foo" {
+  tags {
+    Name = "terraform-testacc-dxvif-mtu"
+  }
+}
+
+resource "aws_directconnect_virtual_interface" "foo" {
+  connection_id = "%s"
+  virtual_interface_name = "terraform-testacc-dxvif-mtu"
+  vlan = 4094
+  asn = 65352
+  virtual_gateway_id = "${aws_vpn_gateway.foo.id}"
+  mtu = %d
+}
+`
+
+const testAccDirectconnectVirtualInterfaceConfig_lag = `
+resource "aws_vpn_gateway" "foo" {
+  tags {
+    Name = "terraform-testacc-dxvif-lag"
+  }
+}
+
+resource "aws_directconnect_virtual_interface" "foo" {
+  lag_id = "%s"
+  virtual_interface_name = "terraform-testacc-dxvif-lag"
+  vlan = 4094
+  asn = 65352
+  virtual_gateway_id = "${aws_vpn_gateway.foo.id}"
+}
+`
+
+const testAccDirectconnectVirtualInterfaceConfig_transit = `
+resource "aws_directconnect_virtual_interface" "foo" {
+  connection_id = "%s"
+  vif_type = "transit"
+  virtual_interface_name = "terraform-testacc-dxvif-transit"
+  vlan = 4093
+  asn = 65352
+  address_family = "ipv4"
+  dx_gateway_id = "%s"
+}
+`
+
+const testAccDirectconnectVirtualInterfaceConfig_public = `
+resource "aws_directconnect_virtual_interface" "foo" {
+  connection_id = "%s"
+  vif_type = "public"
+  virtual_interface_name = "terraform-testacc-dxvif-public"
+  vlan = 4091
+  asn = 65352
+  address_family = "ipv4"
+  amazon_address = "%s"
+  customer_address = "%s"
+  route_filter_prefixes = ["%s"]
+}
+`
+
+const testAccDirectconnectVirtualInterfaceConfig_hosted = `
+resource "aws_directconnect_virtual_interface" "foo" {
+  connection_id = "%s"
+  virtual_interface_name = "terraform-testacc-dxvif-hosted"
+  vlan = 4092
+  asn = 65352
+  address_family = "ipv4"
+  owner_account_id = "%s"
+}
+`
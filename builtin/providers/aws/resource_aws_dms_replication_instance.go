@@ -0,0 +1,395 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	dms "github.com/aws/aws-sdk-go/service/databasemigrationservice"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsDmsReplicationInstance() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDmsReplicationInstanceCreate,
+		Read:   resourceAwsDmsReplicationInstanceRead,
+		Update: resourceAwsDmsReplicationInstanceUpdate,
+		Delete: resourceAwsDmsReplicationInstanceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"replication_instance_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDmsReplicationInstanceId,
+			},
+
+			"replication_instance_class": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"allocated_storage": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"multi_az": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"availability_zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"engine_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"auto_minor_version_upgrade": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"preferred_maintenance_window": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"publicly_accessible": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"kms_key_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"replication_subnet_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"vpc_security_group_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			// apply_immediately is used to determine when the update
+			// modifications take place.
+			"apply_immediately": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"replication_instance_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"replication_instance_private_ips": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"replication_instance_public_ips": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAwsDmsReplicationInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dmsconn
+
+	req := &dms.CreateReplicationInstanceInput{
+		ReplicationInstanceIdentifier: aws.String(d.Get("replication_instance_id").(string)),
+		ReplicationInstanceClass:      aws.String(d.Get("replication_instance_class").(string)),
+	}
+
+	if v, ok := d.GetOk("allocated_storage"); ok {
+		req.AllocatedStorage = aws.Int64(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("multi_az"); ok {
+		req.MultiAZ = aws.Bool(v.(bool))
+	}
+	if v, ok := d.GetOk("availability_zone"); ok {
+		req.AvailabilityZone = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("engine_version"); ok {
+		req.EngineVersion = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("auto_minor_version_upgrade"); ok {
+		req.AutoMinorVersionUpgrade = aws.Bool(v.(bool))
+	}
+	if v, ok := d.GetOk("preferred_maintenance_window"); ok {
+		req.PreferredMaintenanceWindow = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("publicly_accessible"); ok {
+		req.PubliclyAccessible = aws.Bool(v.(bool))
+	}
+	if v, ok := d.GetOk("kms_key_arn"); ok {
+		req.KmsKeyId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("replication_subnet_group_id"); ok {
+		req.ReplicationSubnetGroupIdentifier = aws.String(v.(string))
+	}
+	if v := d.Get("vpc_security_group_ids").(*schema.Set); v.Len() > 0 {
+		req.VpcSecurityGroupIds = expandStringList(v.List())
+	}
+
+	log.Printf("[DEBUG] Creating DMS replication instance: %s", req)
+	resp, err := conn.CreateReplicationInstance(req)
+	if err != nil {
+		return fmt.Errorf("Error creating DMS replication instance: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.ReplicationInstance.ReplicationInstanceIdentifier))
+	log.Printf("[INFO] DMS replication instance ID: %s", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating", "modifying"},
+		Target:     []string{"available"},
+		Refresh:    dmsReplicationInstanceStateRefreshFunc(conn, d.Id(), false),
+		Timeout:    30 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for DMS replication instance (%s) to become available: %s", d.Id(), err)
+	}
+
+	return resourceAwsDmsReplicationInstanceRead(d, meta)
+}
+
+func resourceAwsDmsReplicationInstanceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dmsconn
+
+	instance, err := describeDmsReplicationInstance(conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading DMS replication instance (%s): %s", d.Id(), err)
+	}
+	if instance == nil {
+		log.Printf("[WARN] DMS replication instance (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("replication_instance_id", instance.ReplicationInstanceIdentifier)
+	d.Set("replication_instance_class", instance.ReplicationInstanceClass)
+	d.Set("replication_instance_arn", instance.ReplicationInstanceArn)
+	d.Set("allocated_storage", instance.AllocatedStorage)
+	d.Set("multi_az", instance.MultiAZ)
+	d.Set("availability_zone", instance.AvailabilityZone)
+	d.Set("engine_version", instance.EngineVersion)
+	d.Set("auto_minor_version_upgrade", instance.AutoMinorVersionUpgrade)
+	d.Set("preferred_maintenance_window", instance.PreferredMaintenanceWindow)
+	d.Set("publicly_accessible", instance.PubliclyAccessible)
+	d.Set("kms_key_arn", instance.KmsKeyId)
+	if instance.ReplicationSubnetGroup != nil {
+		d.Set("replication_subnet_group_id", instance.ReplicationSubnetGroup.ReplicationSubnetGroupIdentifier)
+	}
+
+	sgs := make([]string, 0, len(instance.VpcSecurityGroups))
+	for _, sg := range instance.VpcSecurityGroups {
+		sgs = append(sgs, aws.StringValue(sg.VpcSecurityGroupId))
+	}
+	if err := d.Set("vpc_security_group_ids", sgs); err != nil {
+		return fmt.Errorf("Error setting vpc_security_group_ids of DMS replication instance (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("replication_instance_private_ips", aws.StringValueSlice(instance.ReplicationInstancePrivateIpAddresses)); err != nil {
+		return fmt.Errorf("Error setting replication_instance_private_ips of DMS replication instance (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("replication_instance_public_ips", aws.StringValueSlice(instance.ReplicationInstancePublicIpAddresses)); err != nil {
+		return fmt.Errorf("Error setting replication_instance_public_ips of DMS replication instance (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsDmsReplicationInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dmsconn
+
+	applyImmediately := d.Get("apply_immediately").(bool)
+	req := &dms.ModifyReplicationInstanceInput{
+		ReplicationInstanceArn: aws.String(d.Get("replication_instance_arn").(string)),
+		ApplyImmediately:       aws.Bool(applyImmediately),
+	}
+
+	requestUpdate := false
+	if d.HasChange("replication_instance_class") {
+		req.ReplicationInstanceClass = aws.String(d.Get("replication_instance_class").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("allocated_storage") {
+		req.AllocatedStorage = aws.Int64(int64(d.Get("allocated_storage").(int)))
+		requestUpdate = true
+	}
+
+	if d.HasChange("multi_az") {
+		req.MultiAZ = aws.Bool(d.Get("multi_az").(bool))
+		requestUpdate = true
+	}
+
+	if d.HasChange("engine_version") {
+		req.EngineVersion = aws.String(d.Get("engine_version").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("auto_minor_version_upgrade") {
+		req.AutoMinorVersionUpgrade = aws.Bool(d.Get("auto_minor_version_upgrade").(bool))
+		requestUpdate = true
+	}
+
+	if d.HasChange("preferred_maintenance_window") {
+		req.PreferredMaintenanceWindow = aws.String(d.Get("preferred_maintenance_window").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("vpc_security_group_ids") {
+		req.VpcSecurityGroupIds = expandStringList(d.Get("vpc_security_group_ids").(*schema.Set).List())
+		requestUpdate = true
+	}
+
+	if requestUpdate {
+		log.Printf("[DEBUG] Modifying DMS replication instance: %s", req)
+		if _, err := conn.ModifyReplicationInstance(req); err != nil {
+			return fmt.Errorf("Error modifying DMS replication instance (%s): %s", d.Id(), err)
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"modifying", "upgrading", "rebooting"},
+			Target:     []string{"available"},
+			Refresh:    dmsReplicationInstanceStateRefreshFunc(conn, d.Id(), applyImmediately),
+			Timeout:    30 * time.Minute,
+			Delay:      30 * time.Second,
+			MinTimeout: 10 * time.Second,
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("Error waiting for DMS replication instance (%s) to be modified: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsDmsReplicationInstanceRead(d, meta)
+}
+
+func resourceAwsDmsReplicationInstanceDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dmsconn
+
+	log.Printf("[DEBUG] Deleting DMS replication instance: %s", d.Id())
+	_, err := conn.DeleteReplicationInstance(&dms.DeleteReplicationInstanceInput{
+		ReplicationInstanceArn: aws.String(d.Get("replication_instance_arn").(string)),
+	})
+	if err != nil {
+		if isDmsResourceNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting DMS replication instance (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"available", "deleting"},
+		Target:     []string{},
+		Refresh:    dmsReplicationInstanceStateRefreshFunc(conn, d.Id(), false),
+		Timeout:    30 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for DMS replication instance (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// describeDmsReplicationInstance returns the replication instance with
+// identifier id, or nil if there is none.
+func describeDmsReplicationInstance(conn *dms.DatabaseMigrationService, id string) (*dms.ReplicationInstance, error) {
+	resp, err := conn.DescribeReplicationInstances(&dms.DescribeReplicationInstancesInput{
+		Filters: []*dms.Filter{
+			&dms.Filter{
+				Name:   aws.String("replication-instance-id"),
+				Values: []*string{aws.String(id)},
+			},
+		},
+	})
+	if err != nil {
+		if isDmsResourceNotFoundErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, instance := range resp.ReplicationInstances {
+		if aws.StringValue(instance.ReplicationInstanceIdentifier) == id {
+			return instance, nil
+		}
+	}
+	return nil, nil
+}
+
+// dmsReplicationInstanceStateRefreshFunc watches the status of replication
+// instance id. With pendingModifications set, an available instance that has
+// yet to pick up its modifications is still reported as modifying.
+func dmsReplicationInstanceStateRefreshFunc(conn *dms.DatabaseMigrationService, id string, pendingModifications bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		instance, err := describeDmsReplicationInstance(conn, id)
+		if err != nil {
+			return nil, "", err
+		}
+		if instance == nil {
+			return nil, "", nil
+		}
+
+		status := aws.StringValue(instance.ReplicationInstanceStatus)
+		if status == "available" && pendingModifications && dmsReplicationInstanceHasPendingModifications(instance) {
+			status = "modifying"
+		}
+		return instance, status, nil
+	}
+}
+
+// dmsReplicationInstanceHasPendingModifications reports whether any
+// modifications of replication instance instance have yet to be applied.
+func dmsReplicationInstanceHasPendingModifications(instance *dms.ReplicationInstance) bool {
+	p := instance.PendingModifiedValues
+	if p == nil {
+		return false
+	}
+	return p.AllocatedStorage != nil || p.EngineVersion != nil || p.MultiAZ != nil || p.ReplicationInstanceClass != nil
+}
+
+func isDmsResourceNotFoundErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == dms.ErrCodeResourceNotFoundFault
+}
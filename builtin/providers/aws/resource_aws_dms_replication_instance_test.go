@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	dms "github.com/aws/aws-sdk-go/service/databasemigrationservice"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDmsReplicationInstance_basic(t *testing.T) {
+	var instance dms.ReplicationInstance
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDmsReplicationInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSDmsReplicationInstanceConfig(rName, "dms.t2.micro", 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDmsReplicationInstanceExists("aws_dms_replication_instance.test", &instance),
+					resource.TestCheckResourceAttr(
+						"aws_dms_replication_instance.test", "replication_instance_id", rName),
+					resource.TestCheckResourceAttr(
+						"aws_dms_replication_instance.test", "replication_instance_class", "dms.t2.micro"),
+					resource.TestCheckResourceAttr(
+						"aws_dms_replication_instance.test", "allocated_storage", "20"),
+					resource.TestCheckResourceAttr(
+						"aws_dms_replication_instance.test", "multi_az", "false"),
+					resource.TestMatchResourceAttr(
+						"aws_dms_replication_instance.test", "replication_instance_arn", regexp.MustCompile(`^arn:aws:dms:`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDmsReplicationInstance_modify(t *testing.T) {
+	var before, after dms.ReplicationInstance
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDmsReplicationInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSDmsReplicationInstanceConfig(rName, "dms.t2.micro", 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDmsReplicationInstanceExists("aws_dms_replication_instance.test", &before),
+				),
+			},
+
+			// Changing class and storage has to modify the instance in
+			// place rather than replace it.
+			resource.TestStep{
+				Config: testAccAWSDmsReplicationInstanceConfig(rName, "dms.t2.small", 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDmsReplicationInstanceExists("aws_dms_replication_instance.test", &after),
+					testAccCheckAWSDmsReplicationInstanceNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr(
+						"aws_dms_replication_instance.test", "replication_instance_class", "dms.t2.small"),
+					resource.TestCheckResourceAttr(
+						"aws_dms_replication_instance.test", "allocated_storage", "30"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSDmsReplicationInstanceExists(n string, instance *dms.ReplicationInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No DMS replication instance ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dmsconn
+		found, err := describeDmsReplicationInstance(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("DMS replication instance %s not found", rs.Primary.ID)
+		}
+
+		*instance = *found
+		return nil
+	}
+}
+
+func testAccCheckAWSDmsReplicationInstanceNotRecreated(before, after *dms.ReplicationInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if *before.ReplicationInstanceArn != *after.ReplicationInstanceArn {
+			return fmt.Errorf("DMS replication instance was recreated: %s is now %s",
+				*before.ReplicationInstanceArn, *after.ReplicationInstanceArn)
+		}
+		return nil
+	}
+}
+
+func testAccCheckAWSDmsReplicationInstanceDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dmsconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_dms_replication_instance" {
+			continue
+		}
+
+		instance, err := describeDmsReplicationInstance(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if instance != nil {
+			return fmt.Errorf("DMS replication instance %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSDmsReplicationInstanceConfig(rName, class string, storage int) string {
+	return fmt.Sprintf(`
+resource "aws_dms_replication_instance" "test" {
+  replication_instance_id    = "%s"
+  replication_instance_class = "%s"
+  allocated_storage          = %d
+  publicly_accessible        = false
+  apply_immediately          = true
+}
+`, rName, class, storage)
+}
@@ -0,0 +1,305 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsDxBgpPeer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDxBgpPeerCreate,
+		Read:   resourceAwsDxBgpPeerRead,
+		Delete: resourceAwsDxBgpPeerDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_interface_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"address_family": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxAddressFamily,
+			},
+
+			"asn": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxBgpAsn,
+			},
+
+			"auth_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+
+			"amazon_address": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateDxBgpPeerAddress,
+				DiffSuppressFunc: suppressEquivalentDxBgpPeerAddress,
+			},
+
+			"customer_address": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateDxBgpPeerAddress,
+				DiffSuppressFunc: suppressEquivalentDxBgpPeerAddress,
+			},
+
+			"bgp_peer_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"bgp_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsDxBgpPeerCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	vifId := d.Get("virtual_interface_id").(string)
+	addrFamily := d.Get("address_family").(string)
+	asn := int64(d.Get("asn").(int))
+
+	if err := validateDxBgpPeerAddressPair(d.Get("amazon_address").(string), d.Get("customer_address").(string)); err != nil {
+		return err
+	}
+
+	req := &directconnect.CreateBGPPeerInput{
+		VirtualInterfaceId: aws.String(vifId),
+		NewBGPPeer: &directconnect.NewBGPPeer{
+			AddressFamily: aws.String(addrFamily),
+			Asn:           aws.Int64(asn),
+		},
+	}
+	if v, ok := d.GetOk("auth_key"); ok {
+		req.NewBGPPeer.AuthKey = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("amazon_address"); ok {
+		req.NewBGPPeer.AmazonAddress = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("customer_address"); ok {
+		req.NewBGPPeer.CustomerAddress = aws.String(v.(string))
+	}
+
+	// A virtual interface can have more than one peer of the same address
+	// family and ASN, so the new peer is told apart from the existing ones
+	// by its ID.
+	vifRaw, _, err := dxVirtualInterfaceStateRefreshFunc(conn, vifId)()
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect virtual interface (%s): %s", vifId, err)
+	}
+	existing := map[string]bool{}
+	if vif, ok := vifRaw.(*directconnect.VirtualInterface); ok {
+		for _, peer := range vif.BgpPeers {
+			if peer != nil {
+				existing[aws.StringValue(peer.BgpPeerId)] = true
+			}
+		}
+	}
+
+	dxLog.Printf("[DEBUG] Creating Direct Connect BGP peer: %#v", req)
+	resp, err := conn.CreateBGPPeer(req)
+	if err != nil {
+		return fmt.Errorf("Error creating Direct Connect BGP peer: %s", err)
+	}
+
+	peer := findNewDxBgpPeer(resp.VirtualInterface, existing, addrFamily, asn)
+	if peer == nil {
+		return fmt.Errorf("Error creating Direct Connect BGP peer: new peer not found on virtual interface (%s)", vifId)
+	}
+
+	d.SetId(aws.StringValue(peer.BgpPeerId))
+	d.Set("bgp_peer_id", peer.BgpPeerId)
+	dxLog.Printf("[INFO] Direct Connect BGP peer ID: %s", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.BGPPeerStatePending,
+			directconnect.BGPPeerStateVerifying,
+		},
+		Target:     []string{directconnect.BGPPeerStateAvailable},
+		Refresh:    dxBgpPeerIdStateRefreshFunc(conn, vifId, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect BGP peer (%s) to become available: %s", d.Id(), err)
+	}
+
+	// The peers are part of the virtual interface, so the cached interface
+	// is out of date now.
+	invalidateDxVirtualInterfaceCache(meta, aws.StringValue(resp.VirtualInterface.ConnectionId))
+	return resourceAwsDxBgpPeerRead(d, meta)
+}
+
+func resourceAwsDxBgpPeerRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	peerRaw, state, err := resourceAwsDxBgpPeerStateRefreshFunc(conn, d)()
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect BGP peer (%s): %s", d.Id(), err)
+	}
+	if peerRaw == nil || state == directconnect.BGPPeerStateDeleted {
+		dxLog.Printf("[WARN] Direct Connect BGP peer (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	peer := peerRaw.(*directconnect.BGPPeer)
+	d.Set("bgp_peer_id", peer.BgpPeerId)
+	d.Set("auth_key", peer.AuthKey)
+	d.Set("amazon_address", peer.AmazonAddress)
+	d.Set("customer_address", peer.CustomerAddress)
+	d.Set("bgp_status", peer.BgpStatus)
+
+	return nil
+}
+
+func resourceAwsDxBgpPeerDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	req := &directconnect.DeleteBGPPeerInput{
+		VirtualInterfaceId: aws.String(d.Get("virtual_interface_id").(string)),
+	}
+	if v, ok := d.GetOk("bgp_peer_id"); ok {
+		req.BgpPeerId = aws.String(v.(string))
+	} else {
+		req.Asn = aws.Int64(int64(d.Get("asn").(int)))
+		req.CustomerAddress = aws.String(d.Get("customer_address").(string))
+	}
+
+	dxLog.Printf("[DEBUG] Deleting Direct Connect BGP peer: %#v", req)
+	resp, err := conn.DeleteBGPPeer(req)
+	if err != nil {
+		if isNoSuchDxVirtualInterfaceErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Direct Connect BGP peer (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.BGPPeerStateAvailable,
+			directconnect.BGPPeerStateDeleting,
+			directconnect.BGPPeerStatePending,
+			directconnect.BGPPeerStateVerifying,
+		},
+		Target:     []string{directconnect.BGPPeerStateDeleted},
+		Refresh:    resourceAwsDxBgpPeerStateRefreshFunc(conn, d),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect BGP peer (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	if resp.VirtualInterface != nil {
+		invalidateDxVirtualInterfaceCache(meta, aws.StringValue(resp.VirtualInterface.ConnectionId))
+	}
+	return nil
+}
+
+// resourceAwsDxBgpPeerStateRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch the BGP peer d, by its ID where known. Peers created
+// before their ID was tracked are found by address family and ASN instead.
+func resourceAwsDxBgpPeerStateRefreshFunc(conn directConnectAPI, d *schema.ResourceData) resource.StateRefreshFunc {
+	vifId := d.Get("virtual_interface_id").(string)
+	if peerId, ok := d.GetOk("bgp_peer_id"); ok {
+		return dxBgpPeerIdStateRefreshFunc(conn, vifId, peerId.(string))
+	}
+	return dxBgpPeerStateRefreshFunc(conn, vifId, d.Get("address_family").(string), int64(d.Get("asn").(int)))
+}
+
+// dxBgpPeerStateRefreshFunc returns a resource.StateRefreshFunc that is used
+// to watch a BGP peer on a Direct Connect virtual interface. A peer that has
+// gone away is reported in the "deleted" state.
+func dxBgpPeerStateRefreshFunc(conn directConnectAPI, vifId, addrFamily string, asn int64) resource.StateRefreshFunc {
+	return dxBgpPeerMatchStateRefreshFunc(conn, vifId, func(peer *directconnect.BGPPeer) bool {
+		return aws.StringValue(peer.AddressFamily) == addrFamily && aws.Int64Value(peer.Asn) == asn
+	})
+}
+
+// dxBgpPeerIdStateRefreshFunc is like dxBgpPeerStateRefreshFunc, but finds
+// the peer by its ID.
+func dxBgpPeerIdStateRefreshFunc(conn directConnectAPI, vifId, peerId string) resource.StateRefreshFunc {
+	return dxBgpPeerMatchStateRefreshFunc(conn, vifId, func(peer *directconnect.BGPPeer) bool {
+		return aws.StringValue(peer.BgpPeerId) == peerId
+	})
+}
+
+func dxBgpPeerMatchStateRefreshFunc(conn directConnectAPI, vifId string, match func(*directconnect.BGPPeer) bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeVirtualInterfaces(&directconnect.DescribeVirtualInterfacesInput{
+			VirtualInterfaceId: aws.String(vifId),
+		})
+		if err != nil {
+			if isNoSuchDxVirtualInterfaceErr(err) {
+				return "", directconnect.BGPPeerStateDeleted, nil
+			}
+			return nil, "", err
+		}
+
+		if resp == nil || len(resp.VirtualInterfaces) == 0 || resp.VirtualInterfaces[0] == nil {
+			return "", directconnect.BGPPeerStateDeleted, nil
+		}
+
+		for _, peer := range resp.VirtualInterfaces[0].BgpPeers {
+			if peer == nil {
+				continue
+			}
+			if match(peer) {
+				return peer, aws.StringValue(peer.BgpPeerState), nil
+			}
+		}
+
+		return "", directconnect.BGPPeerStateDeleted, nil
+	}
+}
+
+// findNewDxBgpPeer returns the peer of address family addrFamily and ASN asn
+// on virtual interface vif that is not among the existing peer IDs, or nil
+// if there is none.
+func findNewDxBgpPeer(vif *directconnect.VirtualInterface, existing map[string]bool, addrFamily string, asn int64) *directconnect.BGPPeer {
+	if vif == nil {
+		return nil
+	}
+	for _, peer := range vif.BgpPeers {
+		if peer == nil || existing[aws.StringValue(peer.BgpPeerId)] {
+			continue
+		}
+		if aws.StringValue(peer.AddressFamily) == addrFamily && aws.Int64Value(peer.Asn) == asn {
+			return peer
+		}
+	}
+	return nil
+}
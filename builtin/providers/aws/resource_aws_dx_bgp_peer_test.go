@@ -0,0 +1,219 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDxBgpPeer_basic(t *testing.T) {
+	vifId := os.Getenv("DX_VIRTUAL_INTERFACE_ID")
+	if vifId == "" {
+		t.Skip("Environment variable DX_VIRTUAL_INTERFACE_ID is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxBgpPeerDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDxBgpPeerConfig, vifId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxBgpPeerExists("aws_dx_bgp_peer.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_dx_bgp_peer.foo", "address_family", "ipv6"),
+					resource.TestCheckResourceAttr(
+						"aws_dx_bgp_peer.foo", "asn", "65351"),
+				),
+			},
+		},
+	})
+}
+
+// A second IPv4 session next to the one created with the virtual interface.
+func TestAccAWSDirectconnectBgpPeer_secondSession(t *testing.T) {
+	vifId := os.Getenv("DX_VIRTUAL_INTERFACE_ID")
+	if vifId == "" {
+		t.Skip("Environment variable DX_VIRTUAL_INTERFACE_ID is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxBgpPeerDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDirectconnectBgpPeerConfig_secondSession, vifId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxBgpPeerExists("aws_directconnect_bgp_peer.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_bgp_peer.foo", "address_family", "ipv4"),
+					resource.TestCheckResourceAttr(
+						"aws_directconnect_bgp_peer.foo", "customer_address", "169.254.255.2/30"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsDxBgpPeerRead_emptyResponse(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaces": []}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDxBgpPeer().Data(&terraform.InstanceState{
+		ID: "dxvif-abcde123-ipv6",
+		Attributes: map[string]string{
+			"virtual_interface_id": "dxvif-abcde123",
+			"address_family":       "ipv6",
+			"asn":                  "65351",
+		},
+	})
+	if err := resourceAwsDxBgpPeerRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected ID to be cleared for a missing BGP peer, got: %q", d.Id())
+	}
+}
+
+func TestResourceAwsDxBgpPeerRead_byId(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeVirtualInterfaces": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"virtualInterfaces": [{"virtualInterfaceId": "dxvif-abcde123", "bgpPeers": [{"bgpPeerId": "dxpeer-abcde123", "addressFamily": "ipv4", "asn": 65351, "customerAddress": "169.254.0.2/30", "bgpPeerState": "available"}, {"bgpPeerId": "dxpeer-fgh45678", "addressFamily": "ipv4", "asn": 65351, "customerAddress": "169.254.255.2/30", "bgpPeerState": "available", "bgpStatus": "up"}]}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDxBgpPeer().Data(&terraform.InstanceState{
+		ID: "dxpeer-fgh45678",
+		Attributes: map[string]string{
+			"virtual_interface_id": "dxvif-abcde123",
+			"address_family":       "ipv4",
+			"asn":                  "65351",
+			"bgp_peer_id":          "dxpeer-fgh45678",
+		},
+	})
+	if err := resourceAwsDxBgpPeerRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if v := d.Get("customer_address").(string); v != "169.254.255.2/30" {
+		t.Fatalf("Expected the second session to be read, got customer_address: %q", v)
+	}
+	if v := d.Get("bgp_status").(string); v != "up" {
+		t.Fatalf("Unexpected bgp_status: %q", v)
+	}
+}
+
+func TestFindNewDxBgpPeer(t *testing.T) {
+	vif := &directconnect.VirtualInterface{
+		BgpPeers: []*directconnect.BGPPeer{
+			&directconnect.BGPPeer{
+				BgpPeerId:     aws.String("dxpeer-abcde123"),
+				AddressFamily: aws.String("ipv4"),
+				Asn:           aws.Int64(65351),
+			},
+			nil,
+			&directconnect.BGPPeer{
+				BgpPeerId:     aws.String("dxpeer-fgh45678"),
+				AddressFamily: aws.String("ipv4"),
+				Asn:           aws.Int64(65351),
+			},
+		},
+	}
+
+	existing := map[string]bool{"dxpeer-abcde123": true}
+	peer := findNewDxBgpPeer(vif, existing, "ipv4", 65351)
+	if peer == nil || aws.StringValue(peer.BgpPeerId) != "dxpeer-fgh45678" {
+		t.Fatalf("Expected the new peer dxpeer-fgh45678, got: %#v", peer)
+	}
+
+	if peer := findNewDxBgpPeer(vif, existing, "ipv6", 65351); peer != nil {
+		t.Fatalf("Expected no IPv6 peer, got: %#v", peer)
+	}
+	if peer := findNewDxBgpPeer(nil, existing, "ipv4", 65351); peer != nil {
+		t.Fatalf("Expected no peer without a virtual interface, got: %#v", peer)
+	}
+}
+
+func testAccCheckAwsDxBgpPeerDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dirconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_dx_bgp_peer" && rs.Type != "aws_directconnect_bgp_peer" {
+			continue
+		}
+
+		_, state, err := dxBgpPeerIdStateRefreshFunc(conn,
+			rs.Primary.Attributes["virtual_interface_id"],
+			rs.Primary.Attributes["bgp_peer_id"])()
+		if err != nil {
+			return err
+		}
+		if state != directconnect.BGPPeerStateDeleted {
+			return fmt.Errorf("Direct Connect BGP peer (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAwsDxBgpPeerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dirconn
+		resp, err := conn.DescribeVirtualInterfaces(&directconnect.DescribeVirtualInterfacesInput{
+			VirtualInterfaceId: aws.String(rs.Primary.Attributes["virtual_interface_id"]),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, vif := range resp.VirtualInterfaces {
+			for _, peer := range vif.BgpPeers {
+				if aws.StringValue(peer.BgpPeerId) == rs.Primary.Attributes["bgp_peer_id"] {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("Direct Connect BGP peer (%s) not found", rs.Primary.ID)
+	}
+}
+
+const testAccDxBgpPeerConfig = `
+resource "aws_dx_bgp_peer" "foo" {
+  virtual_interface_id = "%s"
+  address_family = "ipv6"
+  asn = 65351
+}
+`
+
+const testAccDirectconnectBgpPeerConfig_secondSession = `
+resource "aws_directconnect_bgp_peer" "foo" {
+  virtual_interface_id = "%s"
+  address_family = "ipv4"
+  asn = 65351
+  amazon_address = "169.254.255.1/30"
+  customer_address = "169.254.255.2/30"
+}
+`
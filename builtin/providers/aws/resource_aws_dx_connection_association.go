@@ -0,0 +1,182 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// States of the association of a Direct Connect connection with a LAG, as
+// reported by dxConnectionAssociationStateRefreshFunc.
+const (
+	dxConnectionAssociationStateAssociating   = "associating"
+	dxConnectionAssociationStateAssociated    = "associated"
+	dxConnectionAssociationStateDisassociated = "disassociated"
+)
+
+func resourceAwsDxConnectionAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDxConnectionAssociationCreate,
+		Read:   resourceAwsDxConnectionAssociationRead,
+		Delete: resourceAwsDxConnectionAssociationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"lag_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsDxConnectionAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	connectionId := d.Get("connection_id").(string)
+	lagId := d.Get("lag_id").(string)
+
+	req := &directconnect.AssociateConnectionWithLagInput{
+		ConnectionId: aws.String(connectionId),
+		LagId:        aws.String(lagId),
+	}
+
+	dxLog.Printf("[DEBUG] Associating Direct Connect connection with LAG: %#v", req)
+	if _, err := conn.AssociateConnectionWithLag(req); err != nil {
+		return fmt.Errorf("Error associating Direct Connect connection (%s) with LAG (%s): %s", connectionId, lagId, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-%s", connectionId, lagId))
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			dxConnectionAssociationStateDisassociated,
+			dxConnectionAssociationStateAssociating,
+		},
+		Target:     []string{dxConnectionAssociationStateAssociated},
+		Refresh:    dxConnectionAssociationStateRefreshFunc(conn, connectionId, lagId),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect connection (%s) to be associated with LAG (%s): %s", connectionId, lagId, err)
+	}
+
+	return resourceAwsDxConnectionAssociationRead(d, meta)
+}
+
+func resourceAwsDxConnectionAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	connectionId := d.Get("connection_id").(string)
+	lagId := d.Get("lag_id").(string)
+
+	_, state, err := dxConnectionAssociationStateRefreshFunc(conn, connectionId, lagId)()
+	if err != nil {
+		return fmt.Errorf("Error describing Direct Connect connection (%s): %s", connectionId, err)
+	}
+	if state == dxConnectionAssociationStateDisassociated {
+		dxLog.Printf("[WARN] Direct Connect connection (%s) is no longer associated with LAG (%s), removing from state", connectionId, lagId)
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceAwsDxConnectionAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	connectionId := d.Get("connection_id").(string)
+	lagId := d.Get("lag_id").(string)
+
+	dxLog.Printf("[DEBUG] Disassociating Direct Connect connection (%s) from LAG (%s)", connectionId, lagId)
+	_, err := conn.DisassociateConnectionFromLag(&directconnect.DisassociateConnectionFromLagInput{
+		ConnectionId: aws.String(connectionId),
+		LagId:        aws.String(lagId),
+	})
+	if err != nil {
+		if isNoSuchDxConnectionErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error disassociating Direct Connect connection (%s) from LAG (%s): %s", connectionId, lagId, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			dxConnectionAssociationStateAssociating,
+			dxConnectionAssociationStateAssociated,
+		},
+		Target:     []string{dxConnectionAssociationStateDisassociated},
+		Refresh:    dxConnectionAssociationStateRefreshFunc(conn, connectionId, lagId),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect connection (%s) to be disassociated from LAG (%s): %s", connectionId, lagId, err)
+	}
+
+	return nil
+}
+
+// dxConnectionAssociationStateRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch the association of connectionId with lagId. The
+// association is "associating" while the connection is brought up again in
+// the LAG, and "disassociated" once the connection is in another LAG, in no
+// LAG, or can no longer be found.
+func dxConnectionAssociationStateRefreshFunc(conn directConnectAPI, connectionId, lagId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeConnections(&directconnect.DescribeConnectionsInput{
+			ConnectionId: aws.String(connectionId),
+		})
+		if err != nil {
+			if isNoSuchDxConnectionErr(err) {
+				return "", dxConnectionAssociationStateDisassociated, nil
+			}
+			return nil, "", err
+		}
+
+		for _, c := range resp.Connections {
+			if c == nil || aws.StringValue(c.ConnectionId) != connectionId {
+				continue
+			}
+			if aws.StringValue(c.LagId) != lagId {
+				break
+			}
+
+			switch aws.StringValue(c.ConnectionState) {
+			case directconnect.ConnectionStateRequested,
+				directconnect.ConnectionStateOrdering,
+				directconnect.ConnectionStatePending:
+				return c, dxConnectionAssociationStateAssociating, nil
+			}
+			return c, dxConnectionAssociationStateAssociated, nil
+		}
+
+		return "", dxConnectionAssociationStateDisassociated, nil
+	}
+}
+
+// isNoSuchDxConnectionErr reports whether err is the client exception Direct
+// Connect returns when asked about a connection or LAG that doesn't exist.
+func isNoSuchDxConnectionErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok || awsErr.Code() != "DirectConnectClientException" {
+		return false
+	}
+	msg := strings.ToLower(awsErr.Message())
+	return strings.Contains(msg, "does not exist") || strings.Contains(msg, "not found")
+}
@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDxConnectionAssociation_basic(t *testing.T) {
+	connectionId := testAccDxConnectionPreCheck(t)
+	lagId := os.Getenv("DX_LAG_ID")
+	if lagId == "" {
+		t.Skip("Environment variable DX_LAG_ID is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxConnectionAssociationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDxConnectionAssociationConfig, connectionId, lagId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxConnectionAssociationExists("aws_dx_connection_association.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_dx_connection_association.foo", "connection_id", connectionId),
+					resource.TestCheckResourceAttr(
+						"aws_dx_connection_association.foo", "lag_id", lagId),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAwsDxConnectionAssociationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dirconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_dx_connection_association" {
+			continue
+		}
+
+		resp, err := conn.DescribeConnections(&directconnect.DescribeConnectionsInput{
+			ConnectionId: aws.String(rs.Primary.Attributes["connection_id"]),
+		})
+		if err != nil {
+			if isNoSuchDxConnectionErr(err) {
+				continue
+			}
+			return err
+		}
+
+		for _, c := range resp.Connections {
+			if aws.StringValue(c.LagId) == rs.Primary.Attributes["lag_id"] {
+				return fmt.Errorf("Direct Connect connection (%s) is still associated with LAG (%s)",
+					rs.Primary.Attributes["connection_id"], rs.Primary.Attributes["lag_id"])
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAwsDxConnectionAssociationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dirconn
+		resp, err := conn.DescribeConnections(&directconnect.DescribeConnectionsInput{
+			ConnectionId: aws.String(rs.Primary.Attributes["connection_id"]),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, c := range resp.Connections {
+			if aws.StringValue(c.LagId) == rs.Primary.Attributes["lag_id"] {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Direct Connect connection association (%s) not found", rs.Primary.ID)
+	}
+}
+
+const testAccDxConnectionAssociationConfig = `
+resource "aws_dx_connection_association" "foo" {
+  connection_id = "%s"
+  lag_id = "%s"
+}
+`
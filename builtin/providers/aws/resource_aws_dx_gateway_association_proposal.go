@@ -0,0 +1,175 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsDxGatewayAssociationProposal() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDxGatewayAssociationProposalCreate,
+		Read:   resourceAwsDxGatewayAssociationProposalRead,
+		Delete: resourceAwsDxGatewayAssociationProposalDelete,
+
+		Schema: map[string]*schema.Schema{
+			"dx_gateway_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"dx_gateway_owner_account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"associated_gateway_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// Without prefixes, AWS proposes the CIDRs of the associated
+			// gateway's VPC.
+			"allowed_prefixes": func() *schema.Schema {
+				s := dxRouteFilterPrefixesSchema()
+				s.Computed = true
+				return s
+			}(),
+
+			"associated_gateway_owner_account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"associated_gateway_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"proposal_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsDxGatewayAssociationProposalCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	req := &directconnect.CreateDirectConnectGatewayAssociationProposalInput{
+		DirectConnectGatewayId:           aws.String(d.Get("dx_gateway_id").(string)),
+		DirectConnectGatewayOwnerAccount: aws.String(d.Get("dx_gateway_owner_account_id").(string)),
+		GatewayId:                        aws.String(d.Get("associated_gateway_id").(string)),
+	}
+	if v, ok := d.GetOk("allowed_prefixes"); ok {
+		req.AddAllowedPrefixesToDirectConnectGateway = expandDxRouteFilterPrefixes(v.(*schema.Set).List())
+	}
+
+	dxLog.Printf("[DEBUG] Creating Direct Connect gateway association proposal: %#v", req)
+	resp, err := conn.CreateDirectConnectGatewayAssociationProposal(req)
+	if err != nil {
+		return fmt.Errorf("Error creating Direct Connect gateway association proposal: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.DirectConnectGatewayAssociationProposal.ProposalId))
+	dxLog.Printf("[INFO] Direct Connect gateway association proposal ID: %s", d.Id())
+
+	return resourceAwsDxGatewayAssociationProposalRead(d, meta)
+}
+
+func resourceAwsDxGatewayAssociationProposalRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	proposal, err := describeDxGatewayAssociationProposal(conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect gateway association proposal (%s): %s", d.Id(), err)
+	}
+	if proposal == nil || aws.StringValue(proposal.ProposalState) == directconnect.GatewayAssociationProposalStateDeleted {
+		dxLog.Printf("[WARN] Direct Connect gateway association proposal (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("dx_gateway_id", proposal.DirectConnectGatewayId)
+	d.Set("dx_gateway_owner_account_id", proposal.DirectConnectGatewayOwnerAccount)
+	d.Set("proposal_state", proposal.ProposalState)
+	if gw := proposal.AssociatedGateway; gw != nil {
+		d.Set("associated_gateway_id", gw.Id)
+		d.Set("associated_gateway_owner_account_id", gw.OwnerAccount)
+		d.Set("associated_gateway_type", gw.Type)
+	}
+	if err := d.Set("allowed_prefixes", flattenDxRouteFilterPrefixes(proposal.RequestedAllowedPrefixesToDirectConnectGateway)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceAwsDxGatewayAssociationProposalDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	// Once the gateway owner has accepted or rejected the proposal, it is
+	// gone from the API, and there is nothing left to delete.
+	proposal, err := describeDxGatewayAssociationProposal(conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect gateway association proposal (%s): %s", d.Id(), err)
+	}
+	if proposal == nil || aws.StringValue(proposal.ProposalState) != directconnect.GatewayAssociationProposalStateRequested {
+		return nil
+	}
+
+	dxLog.Printf("[DEBUG] Deleting Direct Connect gateway association proposal: %s", d.Id())
+	_, err = conn.DeleteDirectConnectGatewayAssociationProposal(&directconnect.DeleteDirectConnectGatewayAssociationProposalInput{
+		ProposalId: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting Direct Connect gateway association proposal (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// describeDxGatewayAssociationProposal returns the proposal with the given
+// ID, or nil if there is no such proposal.
+func describeDxGatewayAssociationProposal(conn directConnectAPI, proposalId string) (*directconnect.GatewayAssociationProposal, error) {
+	resp, err := conn.DescribeDirectConnectGatewayAssociationProposals(&directconnect.DescribeDirectConnectGatewayAssociationProposalsInput{
+		ProposalId: aws.String(proposalId),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range resp.DirectConnectGatewayAssociationProposals {
+		if p != nil && aws.StringValue(p.ProposalId) == proposalId {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func expandDxRouteFilterPrefixes(cidrs []interface{}) []*directconnect.RouteFilterPrefix {
+	prefixes := make([]*directconnect.RouteFilterPrefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefixes = append(prefixes, &directconnect.RouteFilterPrefix{
+			Cidr: aws.String(cidr.(string)),
+		})
+	}
+	return prefixes
+}
+
+func flattenDxRouteFilterPrefixes(prefixes []*directconnect.RouteFilterPrefix) []interface{} {
+	cidrs := make([]interface{}, 0, len(prefixes))
+	for _, p := range prefixes {
+		if p != nil {
+			cidrs = append(cidrs, aws.StringValue(p.Cidr))
+		}
+	}
+	return cidrs
+}
@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDxGatewayAssociationProposal_basic(t *testing.T) {
+	dxGatewayId := os.Getenv("DX_GATEWAY_ID")
+	dxGatewayOwnerAccountId := os.Getenv("DX_GATEWAY_OWNER_ACCOUNT_ID")
+	if dxGatewayId == "" || dxGatewayOwnerAccountId == "" {
+		t.Skip("Environment variables DX_GATEWAY_ID and DX_GATEWAY_OWNER_ACCOUNT_ID must be set " +
+			"to a Direct Connect gateway of another account")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxGatewayAssociationProposalDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDxGatewayAssociationProposalConfig, dxGatewayId, dxGatewayOwnerAccountId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxGatewayAssociationProposalExists("aws_dx_gateway_association_proposal.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_dx_gateway_association_proposal.foo", "dx_gateway_id", dxGatewayId),
+					resource.TestCheckResourceAttr(
+						"aws_dx_gateway_association_proposal.foo", "dx_gateway_owner_account_id", dxGatewayOwnerAccountId),
+					resource.TestCheckResourceAttr(
+						"aws_dx_gateway_association_proposal.foo", "associated_gateway_type", directconnect.GatewayTypeVirtualPrivateGateway),
+					resource.TestCheckResourceAttr(
+						"aws_dx_gateway_association_proposal.foo", "allowed_prefixes.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_dx_gateway_association_proposal.foo", "proposal_state", directconnect.GatewayAssociationProposalStateRequested),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsDxGatewayAssociationProposalRead_deleted(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeDirectConnectGatewayAssociationProposals": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"directConnectGatewayAssociationProposals": []}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDxGatewayAssociationProposal().Data(&terraform.InstanceState{
+		ID: "ac90e981-b718-4364-872d-65478c84fafe",
+	})
+	if err := resourceAwsDxGatewayAssociationProposalRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected ID to be cleared for a deleted proposal, got: %q", d.Id())
+	}
+}
+
+func TestResourceAwsDxGatewayAssociationProposalDelete_accepted(t *testing.T) {
+	// An accepted proposal can't be deleted any more, so no
+	// DeleteDirectConnectGatewayAssociationProposal call is mocked; making it
+	// would fail the test.
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeDirectConnectGatewayAssociationProposals": &dxMockResponse{
+			StatusCode: 200,
+			Body: `{"directConnectGatewayAssociationProposals": [
+				{"proposalId": "ac90e981-b718-4364-872d-65478c84fafe", "proposalState": "accepted"}
+			]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDxGatewayAssociationProposal().Data(&terraform.InstanceState{
+		ID: "ac90e981-b718-4364-872d-65478c84fafe",
+	})
+	if err := resourceAwsDxGatewayAssociationProposalDelete(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+}
+
+func testAccCheckAwsDxGatewayAssociationProposalDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dirconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_dx_gateway_association_proposal" {
+			continue
+		}
+
+		proposal, err := describeDxGatewayAssociationProposal(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if proposal != nil && *proposal.ProposalState != directconnect.GatewayAssociationProposalStateDeleted {
+			return fmt.Errorf("Direct Connect gateway association proposal (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAwsDxGatewayAssociationProposalExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dirconn
+		proposal, err := describeDxGatewayAssociationProposal(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if proposal == nil {
+			return fmt.Errorf("Direct Connect gateway association proposal (%s) not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccDxGatewayAssociationProposalConfig = `
+resource "aws_vpc" "foo" {
+  cidr_block = "10.255.255.0/28"
+  tags {
+    Name = "terraform-testacc-dxgwassocproposal"
+  }
+}
+
+resource "aws_vpn_gateway" "foo" {
+  vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_dx_gateway_association_proposal" "foo" {
+  dx_gateway_id = "%s"
+  dx_gateway_owner_account_id = "%s"
+  associated_gateway_id = "${aws_vpn_gateway.foo.id}"
+  allowed_prefixes = ["10.255.255.0/28"]
+}
+`
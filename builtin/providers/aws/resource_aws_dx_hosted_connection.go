@@ -0,0 +1,191 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsDxHostedConnection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDxHostedConnectionCreate,
+		Read:   resourceAwsDxHostedConnectionRead,
+		Delete: resourceAwsDxHostedConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsDxHostedConnectionImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"owner_account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"bandwidth": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vlan": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxVlan,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"connection_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"location": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsDxHostedConnectionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	req := &directconnect.AllocateHostedConnectionInput{
+		ConnectionId:   aws.String(d.Get("connection_id").(string)),
+		OwnerAccount:   aws.String(d.Get("owner_account_id").(string)),
+		Bandwidth:      aws.String(d.Get("bandwidth").(string)),
+		Vlan:           aws.Int64(int64(d.Get("vlan").(int))),
+		ConnectionName: aws.String(d.Get("name").(string)),
+	}
+
+	dxLog.Printf("[DEBUG] Allocating Direct Connect hosted connection: %#v", req)
+	resp, err := conn.AllocateHostedConnection(req)
+	if err != nil {
+		return fmt.Errorf("Error allocating Direct Connect hosted connection: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.ConnectionId))
+	dxLog.Printf("[INFO] Direct Connect hosted connection ID: %s", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.ConnectionStateOrdering,
+			directconnect.ConnectionStatePending,
+		},
+		Target:     []string{directconnect.ConnectionStateAvailable},
+		Refresh:    dxHostedConnectionStateRefreshFunc(conn, d.Get("connection_id").(string), d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect hosted connection (%s) to become available: %s", d.Id(), err)
+	}
+
+	return resourceAwsDxHostedConnectionRead(d, meta)
+}
+
+func resourceAwsDxHostedConnectionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	connRaw, state, err := dxHostedConnectionStateRefreshFunc(conn, d.Get("connection_id").(string), d.Id())()
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect hosted connection (%s): %s", d.Id(), err)
+	}
+	if state == directconnect.ConnectionStateDeleted || state == directconnect.ConnectionStateRejected {
+		dxLog.Printf("[WARN] Direct Connect hosted connection (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	c := connRaw.(*directconnect.Connection)
+	d.Set("owner_account_id", c.OwnerAccount)
+	d.Set("bandwidth", c.Bandwidth)
+	d.Set("vlan", c.Vlan)
+	d.Set("name", c.ConnectionName)
+	d.Set("connection_state", c.ConnectionState)
+	d.Set("location", c.Location)
+
+	return nil
+}
+
+func resourceAwsDxHostedConnectionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	dxLog.Printf("[DEBUG] Deleting Direct Connect hosted connection: %s", d.Id())
+	_, err := conn.DeleteConnection(&directconnect.DeleteConnectionInput{
+		ConnectionId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isNoSuchDxConnectionErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Direct Connect hosted connection (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.ConnectionStateOrdering,
+			directconnect.ConnectionStatePending,
+			directconnect.ConnectionStateAvailable,
+			directconnect.ConnectionStateDown,
+			directconnect.ConnectionStateDeleting,
+		},
+		Target:     []string{directconnect.ConnectionStateDeleted},
+		Refresh:    dxHostedConnectionStateRefreshFunc(conn, d.Get("connection_id").(string), d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect hosted connection (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// dxHostedConnectionStateRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch a hosted connection allocated on the interconnect or
+// LAG parentId. A hosted connection that can no longer be found is reported
+// in the "deleted" state.
+func dxHostedConnectionStateRefreshFunc(conn directConnectAPI, parentId, connectionId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeHostedConnections(&directconnect.DescribeHostedConnectionsInput{
+			ConnectionId: aws.String(parentId),
+		})
+		if err != nil {
+			if isNoSuchDxConnectionErr(err) {
+				return "", directconnect.ConnectionStateDeleted, nil
+			}
+			return nil, "", err
+		}
+
+		for _, c := range resp.Connections {
+			if c == nil {
+				continue
+			}
+			if aws.StringValue(c.ConnectionId) == connectionId {
+				return c, aws.StringValue(c.ConnectionState), nil
+			}
+		}
+
+		return "", directconnect.ConnectionStateDeleted, nil
+	}
+}
@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSDxHostedConnection_basic(t *testing.T) {
+	connectionId := testAccDxConnectionPreCheck(t)
+	ownerAccountId := os.Getenv("DX_HOSTED_CONNECTION_OWNER_ACCOUNT_ID")
+	if ownerAccountId == "" {
+		t.Skip("Environment variable DX_HOSTED_CONNECTION_OWNER_ACCOUNT_ID is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxHostedConnectionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDxHostedConnectionConfig, connectionId, ownerAccountId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxHostedConnectionExists("aws_dx_hosted_connection.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_dx_hosted_connection.foo", "name", "terraform-testacc-dxhconn"),
+					resource.TestCheckResourceAttr(
+						"aws_dx_hosted_connection.foo", "bandwidth", "100Mbps"),
+					resource.TestCheckResourceAttr(
+						"aws_dx_hosted_connection.foo", "vlan", "4092"),
+					resource.TestCheckResourceAttr(
+						"aws_dx_hosted_connection.foo", "owner_account_id", ownerAccountId),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsDxHostedConnectionRead_deleted(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeHostedConnections": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"connections": [{"connectionId": "dxcon-abcde123", "connectionState": "deleted"}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDxHostedConnection().Data(&terraform.InstanceState{
+		ID: "dxcon-abcde123",
+		Attributes: map[string]string{
+			"connection_id": "dxlag-fgh45678",
+		},
+	})
+	if err := resourceAwsDxHostedConnectionRead(d, &AWSClient{dirconn: conn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected ID to be cleared for a deleted hosted connection, got: %q", d.Id())
+	}
+}
+
+func testAccCheckAwsDxHostedConnectionDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dirconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_dx_hosted_connection" {
+			continue
+		}
+
+		_, state, err := dxHostedConnectionStateRefreshFunc(conn, rs.Primary.Attributes["connection_id"], rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state != directconnect.ConnectionStateDeleted {
+			return fmt.Errorf("Direct Connect hosted connection (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAwsDxHostedConnectionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dirconn
+		_, state, err := dxHostedConnectionStateRefreshFunc(conn, rs.Primary.Attributes["connection_id"], rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state == directconnect.ConnectionStateDeleted {
+			return fmt.Errorf("Direct Connect hosted connection (%s) not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccDxHostedConnectionConfig = `
+resource "aws_dx_hosted_connection" "foo" {
+  connection_id = "%s"
+  owner_account_id = "%s"
+  bandwidth = "100Mbps"
+  vlan = 4092
+  name = "terraform-testacc-dxhconn"
+}
+`
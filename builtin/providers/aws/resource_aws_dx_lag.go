@@ -0,0 +1,298 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsDxLag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDxLagCreate,
+		Read:   resourceAwsDxLagRead,
+		Update: resourceAwsDxLagUpdate,
+		Delete: resourceAwsDxLagDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"connections_bandwidth": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"location": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"number_of_connections": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"connection_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"force_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"aws_device": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"lag_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsDxLagCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	req := &directconnect.CreateLagInput{
+		LagName:              aws.String(d.Get("name").(string)),
+		ConnectionsBandwidth: aws.String(d.Get("connections_bandwidth").(string)),
+		Location:             aws.String(d.Get("location").(string)),
+		NumberOfConnections:  aws.Int64(1),
+	}
+	if v, ok := d.GetOk("number_of_connections"); ok {
+		req.NumberOfConnections = aws.Int64(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("connection_id"); ok {
+		req.ConnectionId = aws.String(v.(string))
+	}
+	if tags := tagsFromMapDX(d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+		req.Tags = tags
+	}
+
+	dxLog.Printf("[DEBUG] Creating Direct Connect LAG: %#v", req)
+	resp, err := conn.CreateLag(req)
+	if err != nil {
+		return fmt.Errorf("Error creating Direct Connect LAG: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.LagId))
+	dxLog.Printf("[INFO] Direct Connect LAG ID: %s", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.LagStateRequested,
+			directconnect.LagStatePending,
+		},
+		Target: []string{
+			directconnect.LagStateAvailable,
+			directconnect.LagStateDown,
+		},
+		Refresh:    dxLagStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect LAG (%s) to become available: %s", d.Id(), err)
+	}
+
+	return resourceAwsDxLagRead(d, meta)
+}
+
+func resourceAwsDxLagRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient)
+	conn := client.dirconn
+
+	lagRaw, state, err := dxLagStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect LAG (%s): %s", d.Id(), err)
+	}
+	if state == directconnect.LagStateDeleted {
+		dxLog.Printf("[WARN] Direct Connect LAG (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	lag := lagRaw.(*directconnect.Lag)
+	d.Set("name", lag.LagName)
+	d.Set("connections_bandwidth", lag.ConnectionsBandwidth)
+	d.Set("location", lag.Location)
+	d.Set("number_of_connections", lag.NumberOfConnections)
+	if lag.AwsDeviceV2 != nil {
+		d.Set("aws_device", lag.AwsDeviceV2)
+	} else {
+		d.Set("aws_device", lag.AwsDevice)
+	}
+	d.Set("lag_state", lag.LagState)
+	d.Set("arn", dxLagArn(client, lag))
+	if err := d.Set("tags", tagsToMapDX(lag.Tags)); err != nil {
+		return fmt.Errorf("Error setting tags of Direct Connect LAG (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsDxLagUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	if d.HasChange("name") {
+		req := &directconnect.UpdateLagInput{
+			LagId:   aws.String(d.Id()),
+			LagName: aws.String(d.Get("name").(string)),
+		}
+
+		dxLog.Printf("[DEBUG] Updating Direct Connect LAG: %#v", req)
+		if _, err := conn.UpdateLag(req); err != nil {
+			return fmt.Errorf("Error updating Direct Connect LAG (%s): %s", d.Id(), err)
+		}
+	}
+
+	if err := setTagsDX(conn, d, d.Get("arn").(string)); err != nil {
+		return fmt.Errorf("Error updating tags of Direct Connect LAG (%s): %s", d.Id(), err)
+	}
+
+	return resourceAwsDxLagRead(d, meta)
+}
+
+func resourceAwsDxLagDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dirconn
+
+	lagRaw, state, err := dxLagStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return fmt.Errorf("Error reading Direct Connect LAG (%s): %s", d.Id(), err)
+	}
+	if state == directconnect.LagStateDeleted {
+		return nil
+	}
+
+	lag := lagRaw.(*directconnect.Lag)
+	if len(lag.Connections) > 0 {
+		if !d.Get("force_destroy").(bool) {
+			return fmt.Errorf(
+				"Direct Connect LAG (%s) still has %d member connection(s). "+
+					"Remove them from the LAG first, or set force_destroy to delete them along with the LAG.",
+				d.Id(), len(lag.Connections))
+		}
+
+		for _, c := range lag.Connections {
+			if c == nil {
+				continue
+			}
+			connectionId := aws.StringValue(c.ConnectionId)
+			dxLog.Printf("[DEBUG] Deleting Direct Connect LAG (%s) member connection: %s", d.Id(), connectionId)
+			_, err := conn.DeleteConnection(&directconnect.DeleteConnectionInput{
+				ConnectionId: aws.String(connectionId),
+			})
+			if err != nil && !isNoSuchDxConnectionErr(err) {
+				return fmt.Errorf("Error deleting Direct Connect LAG (%s) member connection (%s): %s", d.Id(), connectionId, err)
+			}
+		}
+	}
+
+	dxLog.Printf("[DEBUG] Deleting Direct Connect LAG: %s", d.Id())
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		_, err := conn.DeleteLag(&directconnect.DeleteLagInput{
+			LagId: aws.String(d.Id()),
+		})
+		if err != nil {
+			if isNoSuchDxConnectionErr(err) {
+				return nil
+			}
+			// Member connections that were just deleted may still be
+			// counted against the LAG for a little while.
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "DirectConnectClientException" && len(lag.Connections) > 0 {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting Direct Connect LAG (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.LagStateRequested,
+			directconnect.LagStatePending,
+			directconnect.LagStateAvailable,
+			directconnect.LagStateDown,
+			directconnect.LagStateDeleting,
+		},
+		Target:     []string{directconnect.LagStateDeleted},
+		Refresh:    dxLagStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Direct Connect LAG (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// dxLagStateRefreshFunc returns a resource.StateRefreshFunc that is used to
+// watch a Direct Connect LAG. A LAG that can no longer be found is reported
+// in the "deleted" state.
+func dxLagStateRefreshFunc(conn directConnectAPI, lagId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeLags(&directconnect.DescribeLagsInput{
+			LagId: aws.String(lagId),
+		})
+		if err != nil {
+			if isNoSuchDxConnectionErr(err) {
+				return "", directconnect.LagStateDeleted, nil
+			}
+			return nil, "", err
+		}
+
+		for _, lag := range resp.Lags {
+			if lag == nil {
+				continue
+			}
+			if aws.StringValue(lag.LagId) == lagId {
+				return lag, aws.StringValue(lag.LagState), nil
+			}
+		}
+
+		return "", directconnect.LagStateDeleted, nil
+	}
+}
+
+// dxLagArn builds the ARN of LAG lag, which lives in the account owning it.
+func dxLagArn(client *AWSClient, lag *directconnect.Lag) string {
+	accountId := aws.StringValue(lag.OwnerAccount)
+	if accountId == "" {
+		accountId = client.accountid
+	}
+	return fmt.Sprintf("arn:aws:directconnect:%s:%s:dxlag/%s", client.region, accountId, aws.StringValue(lag.LagId))
+}
@@ -0,0 +1,133 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// LAGs are billed once their connections are provisioned, so the acceptance
+// tests only run when a Direct Connect location to create them in is given.
+func testAccDxLagPreCheck(t *testing.T) string {
+	location := os.Getenv("DX_LOCATION")
+	if location == "" {
+		t.Skip("Environment variable DX_LOCATION is not set")
+	}
+	return location
+}
+
+func TestAccAWSDxLag_basic(t *testing.T) {
+	location := testAccDxLagPreCheck(t)
+	lagName1 := fmt.Sprintf("tf-dx-lag-%s", acctest.RandString(5))
+	lagName2 := fmt.Sprintf("tf-dx-lag-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxLagDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDxLagConfig, lagName1, location, lagName1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxLagExists("aws_dx_lag.foo"),
+					resource.TestCheckResourceAttr("aws_dx_lag.foo", "name", lagName1),
+					resource.TestCheckResourceAttr("aws_dx_lag.foo", "connections_bandwidth", "1Gbps"),
+					resource.TestCheckResourceAttr("aws_dx_lag.foo", "number_of_connections", "2"),
+					resource.TestCheckResourceAttr("aws_dx_lag.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("aws_dx_lag.foo", "tags.Name", lagName1),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccDxLagConfig, lagName2, location, lagName2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxLagExists("aws_dx_lag.foo"),
+					resource.TestCheckResourceAttr("aws_dx_lag.foo", "name", lagName2),
+					resource.TestCheckResourceAttr("aws_dx_lag.foo", "tags.Name", lagName2),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsDxLagDelete_refusesWithConnections(t *testing.T) {
+	closeFunc, conn := getMockedAwsDirectConnectApi(map[string]*dxMockResponse{
+		"DescribeLags": &dxMockResponse{
+			StatusCode: 200,
+			Body:       `{"lags": [{"lagId": "dxlag-abcde123", "lagState": "available", "connections": [{"connectionId": "dxcon-fgh45678"}]}]}`,
+		},
+	})
+	defer closeFunc()
+
+	d := resourceAwsDxLag().Data(&terraform.InstanceState{ID: "dxlag-abcde123"})
+	err := resourceAwsDxLagDelete(d, &AWSClient{dirconn: conn})
+	if err == nil {
+		t.Fatal("Expected an error deleting a LAG with member connections, got none")
+	}
+	if !strings.Contains(err.Error(), "force_destroy") {
+		t.Fatalf("Expected the error to mention force_destroy, got: %s", err)
+	}
+}
+
+func testAccCheckAwsDxLagDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dirconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_dx_lag" {
+			continue
+		}
+
+		_, state, err := dxLagStateRefreshFunc(conn, rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state != directconnect.LagStateDeleted {
+			return fmt.Errorf("Direct Connect LAG (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAwsDxLagExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dirconn
+		_, state, err := dxLagStateRefreshFunc(conn, rs.Primary.ID)()
+		if err != nil {
+			return err
+		}
+		if state == directconnect.LagStateDeleted {
+			return fmt.Errorf("Direct Connect LAG (%s) not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccDxLagConfig = `
+resource "aws_dx_lag" "foo" {
+  name = "%s"
+  connections_bandwidth = "1Gbps"
+  location = "%s"
+  number_of_connections = 2
+  force_destroy = true
+
+  tags {
+    Name = "%s"
+  }
+}
+`
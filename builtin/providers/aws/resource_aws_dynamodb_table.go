@@ -53,13 +53,23 @@ func resourceAwsDynamoDbTable() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"billing_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      dynamodb.BillingModeProvisioned,
+				ValidateFunc: validateDynamoDbBillingMode,
+			},
+			// Capacities are only used with PROVISIONED billing; for
+			// PAY_PER_REQUEST tables they are neither sent nor read back.
 			"write_capacity": &schema.Schema{
 				Type:     schema.TypeInt,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"read_capacity": &schema.Schema{
 				Type:     schema.TypeInt,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"attribute": &schema.Schema{
 				Type:     schema.TypeSet,
@@ -125,11 +135,11 @@ func resourceAwsDynamoDbTable() *schema.Resource {
 						},
 						"write_capacity": &schema.Schema{
 							Type:     schema.TypeInt,
-							Required: true,
+							Optional: true,
 						},
 						"read_capacity": &schema.Schema{
 							Type:     schema.TypeInt,
-							Required: true,
+							Optional: true,
 						},
 						"hash_key": &schema.Schema{
 							Type:     schema.TypeString,
@@ -179,6 +189,57 @@ func resourceAwsDynamoDbTable() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"point_in_time_recovery": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+			"server_side_encryption": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"kms_key_arn": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"ttl": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attribute_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -190,9 +251,10 @@ func resourceAwsDynamoDbTableCreate(d *schema.ResourceData, meta interface{}) er
 
 	log.Printf("[DEBUG] DynamoDB table create: %s", name)
 
-	throughput := &dynamodb.ProvisionedThroughput{
-		ReadCapacityUnits:  aws.Int64(int64(d.Get("read_capacity").(int))),
-		WriteCapacityUnits: aws.Int64(int64(d.Get("write_capacity").(int))),
+	billingMode := d.Get("billing_mode").(string)
+	throughput, err := expandDynamoDbProvisionedThroughput(d, billingMode)
+	if err != nil {
+		return err
 	}
 
 	hash_key_name := d.Get("hash_key").(string)
@@ -278,7 +340,7 @@ func resourceAwsDynamoDbTableCreate(d *schema.ResourceData, meta interface{}) er
 		gsiSet := gsidata.(*schema.Set)
 		for _, gsiObject := range gsiSet.List() {
 			gsi := gsiObject.(map[string]interface{})
-			gsiObject := createGSIFromData(&gsi)
+			gsiObject := createGSIFromData(&gsi, billingMode)
 			globalSecondaryIndexes = append(globalSecondaryIndexes, &gsiObject)
 		}
 		req.GlobalSecondaryIndexes = globalSecondaryIndexes
@@ -294,6 +356,10 @@ func resourceAwsDynamoDbTableCreate(d *schema.ResourceData, meta interface{}) er
 		fmt.Printf("[DEBUG] Adding StreamSpecifications to the table")
 	}
 
+	if v, ok := d.GetOk("server_side_encryption"); ok {
+		req.SSESpecification = expandDynamoDbEncryptAtRestOptions(v.([]interface{}))
+	}
+
 	attemptCount := 1
 	for attemptCount <= DYNAMODB_MAX_THROTTLE_RETRIES {
 		output, err := dynamodbconn.CreateTable(req)
@@ -322,6 +388,25 @@ func resourceAwsDynamoDbTableCreate(d *schema.ResourceData, meta interface{}) er
 				return err
 			}
 
+			if _, ok := d.GetOk("ttl"); ok {
+				// TTL can only be configured once the table is ACTIVE
+				if err := waitForTableToBeActive(d.Id(), meta); err != nil {
+					return err
+				}
+				if err := updateDynamoDbTimeToLive(d, dynamodbconn); err != nil {
+					return err
+				}
+			}
+
+			if v, ok := d.GetOk("point_in_time_recovery"); ok && len(v.([]interface{})) > 0 {
+				if err := waitForTableToBeActive(d.Id(), meta); err != nil {
+					return err
+				}
+				if err := updateDynamoDbPointInTimeRecovery(d, dynamodbconn); err != nil {
+					return err
+				}
+			}
+
 			return resourceAwsDynamoDbTableRead(d, meta)
 		}
 	}
@@ -351,18 +436,49 @@ func resourceAwsDynamoDbTableUpdate(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("Range key can only be specified at creation, you cannot modify it.")
 	}
 
-	if d.HasChange("read_capacity") || d.HasChange("write_capacity") {
+	billingMode := d.Get("billing_mode").(string)
+	if d.HasChange("billing_mode") || (billingMode == dynamodb.BillingModeProvisioned &&
+		(d.HasChange("read_capacity") || d.HasChange("write_capacity"))) {
 		req := &dynamodb.UpdateTableInput{
 			TableName: aws.String(d.Id()),
 		}
 
-		throughput := &dynamodb.ProvisionedThroughput{
-			ReadCapacityUnits:  aws.Int64(int64(d.Get("read_capacity").(int))),
-			WriteCapacityUnits: aws.Int64(int64(d.Get("write_capacity").(int))),
+		if d.HasChange("billing_mode") {
+			req.BillingMode = aws.String(billingMode)
+
+			// Switching back to PROVISIONED needs the throughput of any
+			// existing indexes in the same request.
+			if billingMode == dynamodb.BillingModeProvisioned {
+				o, n := d.GetChange("global_secondary_index")
+				existing := make(map[string]bool)
+				for _, gsidata := range o.(*schema.Set).List() {
+					existing[gsidata.(map[string]interface{})["name"].(string)] = true
+				}
+				for _, gsidata := range n.(*schema.Set).List() {
+					gsi := gsidata.(map[string]interface{})
+					if !existing[gsi["name"].(string)] {
+						continue
+					}
+					req.GlobalSecondaryIndexUpdates = append(req.GlobalSecondaryIndexUpdates, &dynamodb.GlobalSecondaryIndexUpdate{
+						Update: &dynamodb.UpdateGlobalSecondaryIndexAction{
+							IndexName: aws.String(gsi["name"].(string)),
+							ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+								WriteCapacityUnits: aws.Int64(int64(gsi["write_capacity"].(int))),
+								ReadCapacityUnits:  aws.Int64(int64(gsi["read_capacity"].(int))),
+							},
+						},
+					})
+				}
+			}
+		}
+
+		throughput, err := expandDynamoDbProvisionedThroughput(d, billingMode)
+		if err != nil {
+			return err
 		}
 		req.ProvisionedThroughput = throughput
 
-		_, err := dynamodbconn.UpdateTable(req)
+		_, err = dynamodbconn.UpdateTable(req)
 
 		if err != nil {
 			return err
@@ -390,6 +506,35 @@ func resourceAwsDynamoDbTableUpdate(d *schema.ResourceData, meta interface{}) er
 		waitForTableToBeActive(d.Id(), meta)
 	}
 
+	if d.HasChange("server_side_encryption") {
+		req := &dynamodb.UpdateTableInput{
+			TableName:        aws.String(d.Id()),
+			SSESpecification: expandDynamoDbEncryptAtRestOptions(d.Get("server_side_encryption").([]interface{})),
+		}
+
+		log.Printf("[DEBUG] Updating DynamoDB table server-side encryption: %#v", req)
+		_, err := dynamodbconn.UpdateTable(req)
+		if err != nil {
+			return fmt.Errorf("Error updating server-side encryption of DynamoDB table (%s): %s", d.Id(), err)
+		}
+
+		if err := waitForDynamoDbSSEUpdateToBeCompleted(d.Id(), dynamodbconn); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("ttl") {
+		if err := updateDynamoDbTimeToLive(d, dynamodbconn); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("point_in_time_recovery") {
+		if err := updateDynamoDbPointInTimeRecovery(d, dynamodbconn); err != nil {
+			return err
+		}
+	}
+
 	if d.HasChange("global_secondary_index") {
 		log.Printf("[DEBUG] Changed GSI data")
 		req := &dynamodb.UpdateTableInput{
@@ -425,7 +570,7 @@ func resourceAwsDynamoDbTableUpdate(d *schema.ResourceData, meta interface{}) er
 			if _, exists := oldGsiNameSet[newGsiName]; !exists {
 				attributes := []*dynamodb.AttributeDefinition{}
 				gsidata := newgsidata.(map[string]interface{})
-				gsi := createGSIFromData(&gsidata)
+				gsi := createGSIFromData(&gsidata, d.Get("billing_mode").(string))
 				log.Printf("[DEBUG] Adding GSI %s", *gsi.IndexName)
 				update := &dynamodb.GlobalSecondaryIndexUpdate{
 					Create: &dynamodb.CreateGlobalSecondaryIndexAction{
@@ -500,8 +645,9 @@ func resourceAwsDynamoDbTableUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
-	// Update any out-of-date read / write capacity
-	if gsiObjects, ok := d.GetOk("global_secondary_index"); ok {
+	// Update any out-of-date read / write capacity, unless the table is
+	// billed on demand and has no capacity to update
+	if gsiObjects, ok := d.GetOk("global_secondary_index"); ok && billingMode == dynamodb.BillingModeProvisioned {
 		gsiSet := gsiObjects.(*schema.Set)
 		if len(gsiSet.List()) > 0 {
 			log.Printf("Updating capacity as needed!")
@@ -597,8 +743,18 @@ func resourceAwsDynamoDbTableRead(d *schema.ResourceData, meta interface{}) erro
 
 	table := result.Table
 
-	d.Set("write_capacity", table.ProvisionedThroughput.WriteCapacityUnits)
-	d.Set("read_capacity", table.ProvisionedThroughput.ReadCapacityUnits)
+	billingMode := dynamodb.BillingModeProvisioned
+	if table.BillingModeSummary != nil && table.BillingModeSummary.BillingMode != nil {
+		billingMode = *table.BillingModeSummary.BillingMode
+	}
+	d.Set("billing_mode", billingMode)
+
+	// On-demand tables report zero capacity; leave whatever is in the
+	// configuration alone so it doesn't show up as a diff.
+	if billingMode == dynamodb.BillingModeProvisioned {
+		d.Set("write_capacity", table.ProvisionedThroughput.WriteCapacityUnits)
+		d.Set("read_capacity", table.ProvisionedThroughput.ReadCapacityUnits)
+	}
 
 	attributes := []interface{}{}
 	for _, attrdef := range table.AttributeDefinitions {
@@ -655,6 +811,30 @@ func resourceAwsDynamoDbTableRead(d *schema.ResourceData, meta interface{}) erro
 
 	d.Set("arn", table.TableArn)
 
+	if err := d.Set("server_side_encryption", flattenDynamoDbEncryptAtRestOptions(table.SSEDescription)); err != nil {
+		return err
+	}
+
+	ttlResult, err := dynamodbconn.DescribeTimeToLive(&dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error describing TTL of DynamoDB table (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("ttl", flattenDynamoDbTimeToLive(d, ttlResult.TimeToLiveDescription)); err != nil {
+		return err
+	}
+
+	pitrResult, err := dynamodbconn.DescribeContinuousBackups(&dynamodb.DescribeContinuousBackupsInput{
+		TableName: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error describing continuous backups of DynamoDB table (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("point_in_time_recovery", flattenDynamoDbPointInTimeRecovery(pitrResult.ContinuousBackupsDescription)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -705,7 +885,7 @@ func resourceAwsDynamoDbTableDelete(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func createGSIFromData(data *map[string]interface{}) dynamodb.GlobalSecondaryIndex {
+func createGSIFromData(data *map[string]interface{}, billingMode string) dynamodb.GlobalSecondaryIndex {
 
 	projection := &dynamodb.Projection{
 		ProjectionType: aws.String((*data)["projection_type"].(string)),
@@ -739,15 +919,41 @@ func createGSIFromData(data *map[string]interface{}) dynamodb.GlobalSecondaryInd
 		key_schema = append(key_schema, range_key_element)
 	}
 
-	return dynamodb.GlobalSecondaryIndex{
+	gsi := dynamodb.GlobalSecondaryIndex{
 		IndexName:  aws.String((*data)["name"].(string)),
 		KeySchema:  key_schema,
 		Projection: projection,
-		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+	}
+
+	// Indexes of on-demand tables must not specify any throughput
+	if billingMode == dynamodb.BillingModeProvisioned {
+		gsi.ProvisionedThroughput = &dynamodb.ProvisionedThroughput{
 			WriteCapacityUnits: aws.Int64(int64(writeCapacity)),
 			ReadCapacityUnits:  aws.Int64(int64(readCapacity)),
-		},
+		}
 	}
+
+	return gsi
+}
+
+// expandDynamoDbProvisionedThroughput returns the table throughput for the
+// given billing mode. PAY_PER_REQUEST tables have none, PROVISIONED tables
+// need both capacities to be set.
+func expandDynamoDbProvisionedThroughput(d *schema.ResourceData, billingMode string) (*dynamodb.ProvisionedThroughput, error) {
+	if billingMode == dynamodb.BillingModePayPerRequest {
+		return nil, nil
+	}
+
+	readCapacity := d.Get("read_capacity").(int)
+	writeCapacity := d.Get("write_capacity").(int)
+	if readCapacity <= 0 || writeCapacity <= 0 {
+		return nil, fmt.Errorf("read_capacity and write_capacity must be set when billing_mode is %q", billingMode)
+	}
+
+	return &dynamodb.ProvisionedThroughput{
+		ReadCapacityUnits:  aws.Int64(int64(readCapacity)),
+		WriteCapacityUnits: aws.Int64(int64(writeCapacity)),
+	}, nil
 }
 
 func getGlobalSecondaryIndex(indexName string, indexList []*dynamodb.GlobalSecondaryIndexDescription) (*dynamodb.GlobalSecondaryIndexDescription, error) {
@@ -816,6 +1022,225 @@ func waitForGSIToBeActive(tableName string, gsiName string, meta interface{}) er
 
 }
 
+// updateDynamoDbTimeToLive brings the TTL settings of the table in line with
+// the ttl block. DynamoDB requires the currently enabled attribute name when
+// disabling TTL, so removing the block or renaming the attribute disables TTL
+// on the old attribute first.
+func updateDynamoDbTimeToLive(d *schema.ResourceData, conn *dynamodb.DynamoDB) error {
+	o, n := d.GetChange("ttl")
+	oldTtl := o.([]interface{})
+	newTtl := n.([]interface{})
+
+	var newName string
+	var newEnabled bool
+	if len(newTtl) > 0 {
+		m := newTtl[0].(map[string]interface{})
+		newName = m["attribute_name"].(string)
+		newEnabled = m["enabled"].(bool)
+	}
+
+	if len(oldTtl) > 0 {
+		m := oldTtl[0].(map[string]interface{})
+		oldName := m["attribute_name"].(string)
+		if m["enabled"].(bool) && (oldName != newName || !newEnabled) {
+			if err := putDynamoDbTimeToLive(conn, d.Id(), oldName, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	if newEnabled {
+		return putDynamoDbTimeToLive(conn, d.Id(), newName, true)
+	}
+
+	return nil
+}
+
+func putDynamoDbTimeToLive(conn *dynamodb.DynamoDB, tableName, attributeName string, enabled bool) error {
+	req := &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(tableName),
+		TimeToLiveSpecification: &dynamodb.TimeToLiveSpecification{
+			AttributeName: aws.String(attributeName),
+			Enabled:       aws.Bool(enabled),
+		},
+	}
+
+	log.Printf("[DEBUG] Updating DynamoDB table TTL: %#v", req)
+	if _, err := conn.UpdateTimeToLive(req); err != nil {
+		return fmt.Errorf("Error updating TTL of DynamoDB table (%s): %s", tableName, err)
+	}
+
+	return nil
+}
+
+// flattenDynamoDbTimeToLive converts the TTL description of a table into the
+// ttl block. DynamoDB forgets the attribute name once TTL is disabled, so a
+// disabled TTL keeps whatever attribute name is currently configured.
+func flattenDynamoDbTimeToLive(d *schema.ResourceData, ttl *dynamodb.TimeToLiveDescription) []interface{} {
+	enabled := false
+	if ttl != nil {
+		status := aws.StringValue(ttl.TimeToLiveStatus)
+		enabled = status == dynamodb.TimeToLiveStatusEnabled || status == dynamodb.TimeToLiveStatusEnabling
+	}
+
+	if ttl != nil && ttl.AttributeName != nil && enabled {
+		return []interface{}{map[string]interface{}{
+			"attribute_name": aws.StringValue(ttl.AttributeName),
+			"enabled":        true,
+		}}
+	}
+
+	if v, ok := d.GetOk("ttl"); ok && len(v.([]interface{})) > 0 {
+		m := v.([]interface{})[0].(map[string]interface{})
+		return []interface{}{map[string]interface{}{
+			"attribute_name": m["attribute_name"].(string),
+			"enabled":        false,
+		}}
+	}
+
+	return []interface{}{}
+}
+
+// updateDynamoDbPointInTimeRecovery enables or disables point-in-time
+// recovery according to the point_in_time_recovery block. Continuous backups
+// are unavailable for a short while after a table is created, and the new
+// status takes a moment to be reported back, so both steps are retried.
+func updateDynamoDbPointInTimeRecovery(d *schema.ResourceData, conn *dynamodb.DynamoDB) error {
+	enabled := false
+	if v := d.Get("point_in_time_recovery").([]interface{}); len(v) > 0 && v[0] != nil {
+		enabled = v[0].(map[string]interface{})["enabled"].(bool)
+	}
+
+	req := &dynamodb.UpdateContinuousBackupsInput{
+		TableName: aws.String(d.Id()),
+		PointInTimeRecoverySpecification: &dynamodb.PointInTimeRecoverySpecification{
+			PointInTimeRecoveryEnabled: aws.Bool(enabled),
+		},
+	}
+
+	log.Printf("[DEBUG] Updating DynamoDB table point-in-time recovery: %#v", req)
+	err := resource.Retry(2*time.Minute, func() *resource.RetryError {
+		_, err := conn.UpdateContinuousBackups(req)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeContinuousBackupsUnavailableException {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating point-in-time recovery of DynamoDB table (%s): %s", d.Id(), err)
+	}
+
+	want := dynamodb.PointInTimeRecoveryStatusDisabled
+	if enabled {
+		want = dynamodb.PointInTimeRecoveryStatusEnabled
+	}
+
+	return resource.Retry(2*time.Minute, func() *resource.RetryError {
+		resp, err := conn.DescribeContinuousBackups(&dynamodb.DescribeContinuousBackupsInput{
+			TableName: aws.String(d.Id()),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if got := dynamoDbPointInTimeRecoveryStatus(resp.ContinuousBackupsDescription); got != want {
+			return resource.RetryableError(fmt.Errorf(
+				"Point-in-time recovery of DynamoDB table (%s) is %s, waiting for %s", d.Id(), got, want))
+		}
+		return nil
+	})
+}
+
+func dynamoDbPointInTimeRecoveryStatus(desc *dynamodb.ContinuousBackupsDescription) string {
+	if desc == nil || desc.PointInTimeRecoveryDescription == nil {
+		return dynamodb.PointInTimeRecoveryStatusDisabled
+	}
+	return aws.StringValue(desc.PointInTimeRecoveryDescription.PointInTimeRecoveryStatus)
+}
+
+func flattenDynamoDbPointInTimeRecovery(desc *dynamodb.ContinuousBackupsDescription) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"enabled": dynamoDbPointInTimeRecoveryStatus(desc) == dynamodb.PointInTimeRecoveryStatusEnabled,
+	}}
+}
+
+// expandDynamoDbEncryptAtRestOptions builds the SSE specification of a table
+// from the server_side_encryption block. Tables without the block, or with it
+// disabled, are encrypted with a key owned by DynamoDB.
+func expandDynamoDbEncryptAtRestOptions(l []interface{}) *dynamodb.SSESpecification {
+	options := &dynamodb.SSESpecification{
+		Enabled: aws.Bool(false),
+	}
+	if len(l) == 0 || l[0] == nil {
+		return options
+	}
+
+	m := l[0].(map[string]interface{})
+	if enabled := m["enabled"].(bool); enabled {
+		options.Enabled = aws.Bool(true)
+		options.SSEType = aws.String(dynamodb.SSETypeKms)
+		if v, ok := m["kms_key_arn"].(string); ok && v != "" {
+			options.KMSMasterKeyId = aws.String(v)
+		}
+	}
+
+	return options
+}
+
+func flattenDynamoDbEncryptAtRestOptions(desc *dynamodb.SSEDescription) []interface{} {
+	m := map[string]interface{}{
+		"enabled": false,
+	}
+
+	if desc != nil {
+		switch aws.StringValue(desc.Status) {
+		case dynamodb.SSEStatusEnabled, dynamodb.SSEStatusEnabling, dynamodb.SSEStatusUpdating:
+			m["enabled"] = true
+			m["kms_key_arn"] = aws.StringValue(desc.KMSMasterKeyArn)
+		}
+	}
+
+	return []interface{}{m}
+}
+
+func waitForDynamoDbSSEUpdateToBeCompleted(tableName string, conn *dynamodb.DynamoDB) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			dynamodb.SSEStatusDisabling,
+			dynamodb.SSEStatusEnabling,
+			dynamodb.SSEStatusUpdating,
+		},
+		Target: []string{
+			dynamodb.SSEStatusDisabled,
+			dynamodb.SSEStatusEnabled,
+		},
+		Timeout: 10 * time.Minute,
+		Refresh: func() (interface{}, string, error) {
+			result, err := conn.DescribeTable(&dynamodb.DescribeTableInput{
+				TableName: aws.String(tableName),
+			})
+			if err != nil {
+				return nil, "", err
+			}
+
+			// A table encrypted with a DynamoDB owned key has no SSE description
+			if result.Table.SSEDescription == nil {
+				return result, dynamodb.SSEStatusDisabled, nil
+			}
+			return result, aws.StringValue(result.Table.SSEDescription.Status), nil
+		},
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for server-side encryption of DynamoDB table (%s) to be updated: %s", tableName, err)
+	}
+
+	return nil
+}
+
 func waitForTableToBeActive(tableName string, meta interface{}) error {
 	dynamodbconn := meta.(*AWSClient).dynamodbconn
 	req := &dynamodb.DescribeTableInput{
@@ -55,6 +55,156 @@ func TestAccAWSDynamoDbTable_streamSpecification(t *testing.T) {
 	})
 }
 
+func TestAccAWSDynamoDbTable_ttl(t *testing.T) {
+	rName := acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDynamoDbTableDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSDynamoDbConfigTtl(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynamoDbTableTimeToLive("aws_dynamodb_table.basic-dynamodb-table", dynamodb.TimeToLiveStatusEnabled),
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "ttl.0.attribute_name", "TestTTL"),
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "ttl.0.enabled", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSDynamoDbConfigTtl(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynamoDbTableTimeToLive("aws_dynamodb_table.basic-dynamodb-table", dynamodb.TimeToLiveStatusDisabled),
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "ttl.0.enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDynamoDbTable_pointInTimeRecovery(t *testing.T) {
+	rName := acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDynamoDbTableDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSDynamoDbConfigPointInTimeRecovery(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynamoDbTablePointInTimeRecovery("aws_dynamodb_table.basic-dynamodb-table", true),
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "point_in_time_recovery.0.enabled", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSDynamoDbConfigPointInTimeRecovery(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynamoDbTablePointInTimeRecovery("aws_dynamodb_table.basic-dynamodb-table", false),
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "point_in_time_recovery.0.enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDynamoDbTable_encryption(t *testing.T) {
+	rName := acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDynamoDbTableDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSDynamoDbConfigEncryption(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "server_side_encryption.0.enabled", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSDynamoDbConfigEncryption(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "server_side_encryption.0.enabled", "true"),
+					testAccCheckDynamoDbTableEncryptionKey("aws_dynamodb_table.basic-dynamodb-table", "aws_kms_key.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDynamoDbTable_billingMode(t *testing.T) {
+	rName := acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDynamoDbTableDestroy,
+		Steps: []resource.TestStep{
+			// The plan after each step has to be empty, so on-demand
+			// tables must not keep diffing on capacity.
+			resource.TestStep{
+				Config: testAccAWSDynamoDbConfigBillingModePayPerRequest(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynamoDbTableBillingMode("aws_dynamodb_table.basic-dynamodb-table", dynamodb.BillingModePayPerRequest),
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "billing_mode", "PAY_PER_REQUEST"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSDynamoDbConfigBillingModeProvisioned(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDynamoDbTableBillingMode("aws_dynamodb_table.basic-dynamodb-table", dynamodb.BillingModeProvisioned),
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "billing_mode", "PROVISIONED"),
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "read_capacity", "5"),
+					resource.TestCheckResourceAttr(
+						"aws_dynamodb_table.basic-dynamodb-table", "write_capacity", "5"),
+				),
+			},
+		},
+	})
+}
+
+func TestDynamoDbEncryptAtRestOptions(t *testing.T) {
+	if v := expandDynamoDbEncryptAtRestOptions([]interface{}{}); *v.Enabled || v.SSEType != nil {
+		t.Fatalf("Expected encryption to be disabled, got: %s", v)
+	}
+
+	v := expandDynamoDbEncryptAtRestOptions([]interface{}{
+		map[string]interface{}{
+			"enabled":     true,
+			"kms_key_arn": "arn:aws:kms:us-west-2:123456789012:key/test",
+		},
+	})
+	if !*v.Enabled || *v.SSEType != dynamodb.SSETypeKms || *v.KMSMasterKeyId != "arn:aws:kms:us-west-2:123456789012:key/test" {
+		t.Fatalf("Unexpected SSE specification: %s", v)
+	}
+
+	flattened := flattenDynamoDbEncryptAtRestOptions(&dynamodb.SSEDescription{
+		Status:          aws.String(dynamodb.SSEStatusEnabled),
+		SSEType:         aws.String(dynamodb.SSETypeKms),
+		KMSMasterKeyArn: aws.String("arn:aws:kms:us-west-2:123456789012:key/test"),
+	})
+	m := flattened[0].(map[string]interface{})
+	if m["enabled"] != true || m["kms_key_arn"] != "arn:aws:kms:us-west-2:123456789012:key/test" {
+		t.Fatalf("Unexpected flattened encryption: %#v", m)
+	}
+
+	flattened = flattenDynamoDbEncryptAtRestOptions(nil)
+	if m := flattened[0].(map[string]interface{}); m["enabled"] != false {
+		t.Fatalf("Expected encryption to be disabled, got: %#v", m)
+	}
+}
+
 func TestResourceAWSDynamoDbTableStreamViewType_validation(t *testing.T) {
 	cases := []struct {
 		Value    string
@@ -196,6 +346,133 @@ func testAccCheckInitialAWSDynamoDbTableExists(n string) resource.TestCheckFunc
 	}
 }
 
+func testAccCheckDynamoDbTableTimeToLive(n, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No DynamoDB table name specified!")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dynamodbconn
+		resp, err := conn.DescribeTimeToLive(&dynamodb.DescribeTimeToLiveInput{
+			TableName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		// Enabling and disabling TTL can take up to an hour to settle, so
+		// the transitional status counts as having reached the target.
+		got := aws.StringValue(resp.TimeToLiveDescription.TimeToLiveStatus)
+		switch status {
+		case dynamodb.TimeToLiveStatusEnabled:
+			if got != dynamodb.TimeToLiveStatusEnabled && got != dynamodb.TimeToLiveStatusEnabling {
+				return fmt.Errorf("Expected TTL of DynamoDB table (%s) to be enabled, got %s", rs.Primary.ID, got)
+			}
+		case dynamodb.TimeToLiveStatusDisabled:
+			if got != dynamodb.TimeToLiveStatusDisabled && got != dynamodb.TimeToLiveStatusDisabling {
+				return fmt.Errorf("Expected TTL of DynamoDB table (%s) to be disabled, got %s", rs.Primary.ID, got)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDynamoDbTablePointInTimeRecovery(n string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No DynamoDB table name specified!")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dynamodbconn
+		resp, err := conn.DescribeContinuousBackups(&dynamodb.DescribeContinuousBackupsInput{
+			TableName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		got := dynamoDbPointInTimeRecoveryStatus(resp.ContinuousBackupsDescription) == dynamodb.PointInTimeRecoveryStatusEnabled
+		if got != enabled {
+			return fmt.Errorf("Expected point-in-time recovery of DynamoDB table (%s) enabled to be %t, got %t",
+				rs.Primary.ID, enabled, got)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDynamoDbTableEncryptionKey(n, keyName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		key, ok := s.RootModule().Resources[keyName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", keyName)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dynamodbconn
+		resp, err := conn.DescribeTable(&dynamodb.DescribeTableInput{
+			TableName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		desc := resp.Table.SSEDescription
+		if desc == nil || aws.StringValue(desc.Status) != dynamodb.SSEStatusEnabled {
+			return fmt.Errorf("Expected DynamoDB table (%s) to be encrypted, got: %s", rs.Primary.ID, desc)
+		}
+		if got, want := aws.StringValue(desc.KMSMasterKeyArn), key.Primary.Attributes["arn"]; got != want {
+			return fmt.Errorf("Expected DynamoDB table (%s) to be encrypted with %q, got %q", rs.Primary.ID, want, got)
+		}
+		if got, want := rs.Primary.Attributes["server_side_encryption.0.kms_key_arn"], key.Primary.Attributes["arn"]; got != want {
+			return fmt.Errorf("Expected kms_key_arn to be %q, got %q", want, got)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDynamoDbTableBillingMode(n, billingMode string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).dynamodbconn
+		resp, err := conn.DescribeTable(&dynamodb.DescribeTableInput{
+			TableName: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		got := dynamodb.BillingModeProvisioned
+		if resp.Table.BillingModeSummary != nil {
+			got = aws.StringValue(resp.Table.BillingModeSummary.BillingMode)
+		}
+		if got != billingMode {
+			return fmt.Errorf("Expected billing mode of DynamoDB table (%s) to be %s, got %s", rs.Primary.ID, billingMode, got)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckDynamoDbTableWasUpdated(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -404,3 +681,120 @@ resource "aws_dynamodb_table" "basic-dynamodb-table" {
 }
 `, acctest.RandInt())
 }
+
+func testAccAWSDynamoDbConfigTtl(rName string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "aws_dynamodb_table" "basic-dynamodb-table" {
+	name = "TerraformTestTTLTable-%s"
+	read_capacity = 10
+	write_capacity = 10
+	hash_key = "TestTableHashKey"
+	attribute {
+		name = "TestTableHashKey"
+		type = "S"
+	}
+	ttl {
+		attribute_name = "TestTTL"
+		enabled = %t
+	}
+}
+`, rName, enabled)
+}
+
+func testAccAWSDynamoDbConfigPointInTimeRecovery(rName string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "aws_dynamodb_table" "basic-dynamodb-table" {
+	name = "TerraformTestPITRTable-%s"
+	read_capacity = 10
+	write_capacity = 10
+	hash_key = "TestTableHashKey"
+	attribute {
+		name = "TestTableHashKey"
+		type = "S"
+	}
+	point_in_time_recovery {
+		enabled = %t
+	}
+}
+`, rName, enabled)
+}
+
+func testAccAWSDynamoDbConfigBillingModePayPerRequest(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_dynamodb_table" "basic-dynamodb-table" {
+	name = "TerraformTestBillingTable-%s"
+	billing_mode = "PAY_PER_REQUEST"
+	hash_key = "TestTableHashKey"
+	attribute {
+		name = "TestTableHashKey"
+		type = "S"
+	}
+	attribute {
+		name = "TestGSIHashKey"
+		type = "S"
+	}
+	global_secondary_index {
+		name = "TestGSI"
+		hash_key = "TestGSIHashKey"
+		projection_type = "KEYS_ONLY"
+	}
+}
+`, rName)
+}
+
+func testAccAWSDynamoDbConfigBillingModeProvisioned(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_dynamodb_table" "basic-dynamodb-table" {
+	name = "TerraformTestBillingTable-%s"
+	billing_mode = "PROVISIONED"
+	read_capacity = 5
+	write_capacity = 5
+	hash_key = "TestTableHashKey"
+	attribute {
+		name = "TestTableHashKey"
+		type = "S"
+	}
+	attribute {
+		name = "TestGSIHashKey"
+		type = "S"
+	}
+	global_secondary_index {
+		name = "TestGSI"
+		hash_key = "TestGSIHashKey"
+		read_capacity = 5
+		write_capacity = 5
+		projection_type = "KEYS_ONLY"
+	}
+}
+`, rName)
+}
+
+func testAccAWSDynamoDbConfigEncryption(rName string, enabled bool) string {
+	encryption := ""
+	if enabled {
+		encryption = `
+	server_side_encryption {
+		enabled = true
+		kms_key_arn = "${aws_kms_key.test.arn}"
+	}`
+	}
+
+	return fmt.Sprintf(`
+resource "aws_kms_key" "test" {
+	description = "Terraform acc test DynamoDB %s"
+	deletion_window_in_days = 7
+}
+
+resource "aws_dynamodb_table" "basic-dynamodb-table" {
+	name = "TerraformTestSSETable-%s"
+	read_capacity = 10
+	write_capacity = 10
+	hash_key = "TestTableHashKey"
+	attribute {
+		name = "TestTableHashKey"
+		type = "S"
+	}
+%s
+}
+`, rName, rName, encryption)
+}
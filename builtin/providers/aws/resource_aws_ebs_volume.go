@@ -39,7 +39,6 @@ func resourceAwsEbsVolume() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"kms_key_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -51,7 +50,6 @@ func resourceAwsEbsVolume() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"snapshot_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -63,7 +61,6 @@ func resourceAwsEbsVolume() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"tags": tagsSchema(),
 		},
@@ -148,9 +145,76 @@ func resourceAWSEbsVolumeUpdate(d *schema.ResourceData, meta interface{}) error
 	if _, ok := d.GetOk("tags"); ok {
 		setTags(conn, d)
 	}
+
+	if d.HasChange("size") || d.HasChange("type") || d.HasChange("iops") {
+		params := &ec2.ModifyVolumeInput{
+			VolumeId: aws.String(d.Id()),
+		}
+		if d.HasChange("size") {
+			params.Size = aws.Int64(int64(d.Get("size").(int)))
+		}
+		if d.HasChange("type") {
+			params.VolumeType = aws.String(d.Get("type").(string))
+		}
+		// As on create, IOPs only apply to io1 volumes
+		if d.Get("type").(string) == "io1" && (d.HasChange("iops") || d.HasChange("type")) {
+			params.Iops = aws.Int64(int64(d.Get("iops").(int)))
+		}
+
+		log.Printf("[DEBUG] Modifying EBS Volume: %s", params)
+		result, err := conn.ModifyVolume(params)
+		if err != nil {
+			return fmt.Errorf("Error modifying EBS Volume %s: %s", d.Id(), err)
+		}
+
+		// The volume can be used with its new size, type and IOPs as soon as
+		// the modification reaches optimizing, so don't wait for it to
+		// complete, which can take hours.
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{ec2.VolumeModificationStateModifying},
+			Target:     []string{ec2.VolumeModificationStateOptimizing, ec2.VolumeModificationStateCompleted},
+			Refresh:    volumeModificationStateRefreshFunc(conn, *result.VolumeModification.VolumeId),
+			Timeout:    5 * time.Minute,
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf(
+				"Error waiting for Volume (%s) modification to take effect: %s",
+				d.Id(), err)
+		}
+	}
+
 	return resourceAwsEbsVolumeRead(d, meta)
 }
 
+// volumeModificationStateRefreshFunc returns a resource.StateRefreshFunc that
+// is used to watch the latest modification of a Volume.
+func volumeModificationStateRefreshFunc(conn *ec2.EC2, volumeID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeVolumesModifications(&ec2.DescribeVolumesModificationsInput{
+			VolumeIds: []*string{aws.String(volumeID)},
+		})
+		if err != nil {
+			log.Printf("Error on Volume Modification State Refresh: %s", err)
+			return nil, "", err
+		}
+
+		if resp == nil || len(resp.VolumesModifications) == 0 || resp.VolumesModifications[0] == nil {
+			return nil, "", nil
+		}
+
+		m := resp.VolumesModifications[0]
+		state := aws.StringValue(m.ModificationState)
+		if state == ec2.VolumeModificationStateFailed {
+			return m, state, fmt.Errorf("Modification of Volume %s failed: %s", volumeID, aws.StringValue(m.StatusMessage))
+		}
+
+		return m, state, nil
+	}
+}
+
 // volumeStateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
 // a the state of a Volume. Returns successfully when volume is available
 func volumeStateRefreshFunc(conn *ec2.EC2, volumeID string) resource.StateRefreshFunc {
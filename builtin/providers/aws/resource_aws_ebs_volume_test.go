@@ -60,6 +60,36 @@ func TestAccAWSEBSVolume_withTags(t *testing.T) {
 	})
 }
 
+func TestAccAWSEBSVolume_updateSize(t *testing.T) {
+	var before, after ec2.Volume
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAwsEbsVolumeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVolumeExists("aws_ebs_volume.test", &before),
+					resource.TestCheckResourceAttr("aws_ebs_volume.test", "size", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAwsEbsVolumeConfigUpdateSize,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVolumeExists("aws_ebs_volume.test", &after),
+					resource.TestCheckResourceAttr("aws_ebs_volume.test", "size", "2"),
+					func(*terraform.State) error {
+						if *before.VolumeId != *after.VolumeId {
+							return fmt.Errorf("Expected volume %s to be modified in place, got %s", *before.VolumeId, *after.VolumeId)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVolumeExists(n string, v *ec2.Volume) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -95,6 +125,13 @@ resource "aws_ebs_volume" "test" {
 }
 `
 
+const testAccAwsEbsVolumeConfigUpdateSize = `
+resource "aws_ebs_volume" "test" {
+	availability_zone = "us-west-2a"
+	size = 2
+}
+`
+
 const testAccAwsEbsVolumeConfigWithTags = `
 resource "aws_ebs_volume" "tags_test" {
 	availability_zone = "us-west-2a"
@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsEgressOnlyInternetGateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsEgressOnlyInternetGatewayCreate,
+		Read:   resourceAwsEgressOnlyInternetGatewayRead,
+		Delete: resourceAwsEgressOnlyInternetGatewayDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsEgressOnlyInternetGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[DEBUG] Creating egress-only internet gateway")
+	resp, err := conn.CreateEgressOnlyInternetGateway(&ec2.CreateEgressOnlyInternetGatewayInput{
+		VpcId: aws.String(d.Get("vpc_id").(string)),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating egress-only internet gateway: %s", err)
+	}
+
+	d.SetId(*resp.EgressOnlyInternetGateway.EgressOnlyInternetGatewayId)
+	log.Printf("[INFO] Egress-only internet gateway ID: %s", d.Id())
+
+	return resourceAwsEgressOnlyInternetGatewayRead(d, meta)
+}
+
+func resourceAwsEgressOnlyInternetGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	// A gateway that was just created may not be returned yet
+	var gw *ec2.EgressOnlyInternetGateway
+	err := resource.RetryNotFound(1*time.Minute, func() error {
+		var err error
+		gw, err = findEgressOnlyInternetGateway(conn, d.Id())
+		if err == nil && gw == nil && d.IsNewResource() {
+			return &resource.NotFoundError{
+				Message: fmt.Sprintf("Egress-only internet gateway (%s) not found", d.Id()),
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading egress-only internet gateway (%s): %s", d.Id(), err)
+	}
+	if gw == nil {
+		log.Printf("[WARN] Egress-only internet gateway (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if len(gw.Attachments) > 0 {
+		d.Set("vpc_id", gw.Attachments[0].VpcId)
+	}
+
+	return nil
+}
+
+func resourceAwsEgressOnlyInternetGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	log.Printf("[INFO] Deleting egress-only internet gateway: %s", d.Id())
+	_, err := conn.DeleteEgressOnlyInternetGateway(&ec2.DeleteEgressOnlyInternetGatewayInput{
+		EgressOnlyInternetGatewayId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isNoSuchEgressOnlyInternetGatewayErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting egress-only internet gateway (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// findEgressOnlyInternetGateway returns the egress-only internet gateway with
+// the given ID, or nil if it doesn't exist.
+func findEgressOnlyInternetGateway(conn *ec2.EC2, id string) (*ec2.EgressOnlyInternetGateway, error) {
+	resp, err := conn.DescribeEgressOnlyInternetGateways(&ec2.DescribeEgressOnlyInternetGatewaysInput{
+		EgressOnlyInternetGatewayIds: []*string{aws.String(id)},
+	})
+	if err != nil {
+		if isNoSuchEgressOnlyInternetGatewayErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, gw := range resp.EgressOnlyInternetGateways {
+		if gw != nil && aws.StringValue(gw.EgressOnlyInternetGatewayId) == id {
+			return gw, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func isNoSuchEgressOnlyInternetGatewayErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == "InvalidGatewayID.NotFound"
+}
@@ -0,0 +1,115 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSEgressOnlyInternetGateway_basic(t *testing.T) {
+	var gw ec2.EgressOnlyInternetGateway
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "aws_egress_only_internet_gateway.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckAWSEgressOnlyInternetGatewayDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSEgressOnlyInternetGatewayConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSEgressOnlyInternetGatewayExists(
+						"aws_egress_only_internet_gateway.foo", &gw),
+					resource.TestMatchResourceAttr(
+						"aws_vpc.foo", "ipv6_cidr_block", regexp.MustCompile("::/56$")),
+					resource.TestMatchResourceAttr(
+						"aws_subnet.foo", "ipv6_cidr_block", regexp.MustCompile("::/64$")),
+					resource.TestCheckResourceAttr(
+						"aws_route.ipv6", "destination_ipv6_cidr_block", "::/0"),
+					func(s *terraform.State) error {
+						return resource.TestCheckResourceAttr(
+							"aws_route.ipv6", "egress_only_gateway_id",
+							*gw.EgressOnlyInternetGatewayId)(s)
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSEgressOnlyInternetGatewayDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_egress_only_internet_gateway" {
+			continue
+		}
+
+		gw, err := findEgressOnlyInternetGateway(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if gw != nil {
+			return fmt.Errorf("Egress-only internet gateway %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSEgressOnlyInternetGatewayExists(n string, gw *ec2.EgressOnlyInternetGateway) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+		resp, err := findEgressOnlyInternetGateway(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if resp == nil {
+			return fmt.Errorf("Egress-only internet gateway not found")
+		}
+
+		*gw = *resp
+		return nil
+	}
+}
+
+const testAccAWSEgressOnlyInternetGatewayConfig = `
+resource "aws_vpc" "foo" {
+	cidr_block                       = "10.1.0.0/16"
+	assign_generated_ipv6_cidr_block = true
+}
+
+resource "aws_subnet" "foo" {
+	vpc_id                          = "${aws_vpc.foo.id}"
+	cidr_block                      = "10.1.1.0/24"
+	ipv6_cidr_block                 = "${cidrsubnet(aws_vpc.foo.ipv6_cidr_block, 8, 1)}"
+	assign_ipv6_address_on_creation = true
+}
+
+resource "aws_egress_only_internet_gateway" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_route_table" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_route" "ipv6" {
+	route_table_id              = "${aws_route_table.foo.id}"
+	destination_ipv6_cidr_block = "::/0"
+	egress_only_gateway_id      = "${aws_egress_only_internet_gateway.foo.id}"
+}
+`
@@ -192,6 +192,12 @@ func resourceAwsElasticacheCluster() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+
+			"auto_minor_version_upgrade": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 		},
 	}
 }
@@ -224,6 +230,7 @@ func resourceAwsElasticacheClusterCreate(d *schema.ResourceData, meta interface{
 		CacheSecurityGroupNames: securityNames,
 		SecurityGroupIds:        securityIds,
 		Tags:                    tags,
+		AutoMinorVersionUpgrade: aws.Bool(d.Get("auto_minor_version_upgrade").(bool)),
 	}
 
 	// parameter groups are optional and can be defaulted by AWS
@@ -335,6 +342,7 @@ func resourceAwsElasticacheClusterRead(d *schema.ResourceData, meta interface{})
 		d.Set("maintenance_window", c.PreferredMaintenanceWindow)
 		d.Set("snapshot_window", c.SnapshotWindow)
 		d.Set("snapshot_retention_limit", c.SnapshotRetentionLimit)
+		d.Set("auto_minor_version_upgrade", c.AutoMinorVersionUpgrade)
 		if c.NotificationConfiguration != nil {
 			if *c.NotificationConfiguration.TopicStatus == "active" {
 				d.Set("notification_topic_arn", c.NotificationConfiguration.TopicArn)
@@ -429,6 +437,11 @@ func resourceAwsElasticacheClusterUpdate(d *schema.ResourceData, meta interface{
 		requestUpdate = true
 	}
 
+	if d.HasChange("auto_minor_version_upgrade") {
+		req.AutoMinorVersionUpgrade = aws.Bool(d.Get("auto_minor_version_upgrade").(bool))
+		requestUpdate = true
+	}
+
 	if d.HasChange("num_cache_nodes") {
 		oraw, nraw := d.GetChange("num_cache_nodes")
 		o := oraw.(int)
@@ -454,26 +467,49 @@ func resourceAwsElasticacheClusterUpdate(d *schema.ResourceData, meta interface{
 			return fmt.Errorf("[WARN] Error updating ElastiCache cluster (%s), error: %s", d.Id(), err)
 		}
 
-		log.Printf("[DEBUG] Waiting for update: %s", d.Id())
-		pending := []string{"modifying", "rebooting cache cluster nodes", "snapshotting"}
-		stateConf := &resource.StateChangeConf{
-			Pending:    pending,
-			Target:     []string{"available"},
-			Refresh:    cacheClusterStateRefreshFunc(conn, d.Id(), "available", pending),
-			Timeout:    5 * time.Minute,
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
+		// Without apply_immediately the modifications are queued for the
+		// next maintenance window, so there is nothing to wait for.
+		if d.Get("apply_immediately").(bool) {
+			log.Printf("[DEBUG] Waiting for update: %s", d.Id())
+			pending := []string{"modifying", "rebooting cache cluster nodes", "snapshotting"}
+			refresh := cacheClusterStateRefreshFunc(conn, d.Id(), "available", pending)
+			stateConf := &resource.StateChangeConf{
+				Pending: pending,
+				Target:  []string{"available"},
+				Refresh: func() (interface{}, string, error) {
+					// The cluster can still report available for a bit
+					// before it picks up the modifications.
+					c, state, err := refresh()
+					if err == nil && state == "available" && cacheClusterHasPendingModifications(c.(*elasticache.CacheCluster)) {
+						return c, "modifying", nil
+					}
+					return c, state, err
+				},
+				Timeout:    5 * time.Minute,
+				Delay:      5 * time.Second,
+				MinTimeout: 3 * time.Second,
+			}
 
-		_, sterr := stateConf.WaitForState()
-		if sterr != nil {
-			return fmt.Errorf("Error waiting for elasticache (%s) to update: %s", d.Id(), sterr)
+			_, sterr := stateConf.WaitForState()
+			if sterr != nil {
+				return fmt.Errorf("Error waiting for elasticache (%s) to update: %s", d.Id(), sterr)
+			}
 		}
 	}
 
 	return resourceAwsElasticacheClusterRead(d, meta)
 }
 
+// cacheClusterHasPendingModifications reports whether any modifications of
+// cache cluster c have yet to be applied.
+func cacheClusterHasPendingModifications(c *elasticache.CacheCluster) bool {
+	p := c.PendingModifiedValues
+	if p == nil {
+		return false
+	}
+	return p.CacheNodeType != nil || p.EngineVersion != nil || p.NumCacheNodes != nil || len(p.CacheNodeIdsToRemove) > 0
+}
+
 func getCacheNodesToRemove(d *schema.ResourceData, oldNumberOfNodes int, cacheNodesToRemove int) []*string {
 	nodesIdsToRemove := []*string{}
 	for i := oldNumberOfNodes; i > oldNumberOfNodes-cacheNodesToRemove && i > 0; i-- {
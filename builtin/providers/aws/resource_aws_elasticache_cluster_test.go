@@ -150,6 +150,95 @@ func TestAccAWSElasticacheCluster_multiAZInVpc(t *testing.T) {
 	})
 }
 
+func TestAccAWSElasticacheCluster_applyImmediately(t *testing.T) {
+	var ec elasticache.CacheCluster
+
+	ri := acctest.RandInt()
+	rName := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheClusterDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSElasticacheClusterConfigApplyImmediately(ri, rName, 1, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheClusterExists("aws_elasticache_cluster.bar", &ec),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_cluster.bar", "num_cache_nodes", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_cluster.bar", "auto_minor_version_upgrade", "true"),
+				),
+			},
+
+			// Without apply_immediately both changes would be queued for the
+			// maintenance window instead of showing up right away.
+			resource.TestStep{
+				Config: testAccAWSElasticacheClusterConfigApplyImmediately(ri, rName, 2, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheClusterExists("aws_elasticache_cluster.bar", &ec),
+					testAccCheckAWSElasticacheClusterNoPendingModifications(&ec),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_cluster.bar", "num_cache_nodes", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_cluster.bar", "cache_nodes.#", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_cluster.bar", "auto_minor_version_upgrade", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestCacheClusterHasPendingModifications(t *testing.T) {
+	cases := []struct {
+		Cluster  *elasticache.CacheCluster
+		Expected bool
+	}{
+		{
+			Cluster:  &elasticache.CacheCluster{},
+			Expected: false,
+		},
+		{
+			Cluster: &elasticache.CacheCluster{
+				PendingModifiedValues: &elasticache.PendingModifiedValues{},
+			},
+			Expected: false,
+		},
+		{
+			Cluster: &elasticache.CacheCluster{
+				PendingModifiedValues: &elasticache.PendingModifiedValues{
+					NumCacheNodes: aws.Int64(2),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Cluster: &elasticache.CacheCluster{
+				PendingModifiedValues: &elasticache.PendingModifiedValues{
+					CacheNodeIdsToRemove: []*string{aws.String("0002")},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Cluster: &elasticache.CacheCluster{
+				PendingModifiedValues: &elasticache.PendingModifiedValues{
+					EngineVersion: aws.String("1.4.24"),
+				},
+			},
+			Expected: true,
+		},
+	}
+
+	for i, tc := range cases {
+		if actual := cacheClusterHasPendingModifications(tc.Cluster); actual != tc.Expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.Expected, actual)
+		}
+	}
+}
+
 func testAccCheckAWSElasticacheClusterAttributes(v *elasticache.CacheCluster) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if v.NotificationConfiguration == nil {
@@ -164,6 +253,16 @@ func testAccCheckAWSElasticacheClusterAttributes(v *elasticache.CacheCluster) re
 	}
 }
 
+func testAccCheckAWSElasticacheClusterNoPendingModifications(v *elasticache.CacheCluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if cacheClusterHasPendingModifications(v) {
+			return fmt.Errorf("Expected no pending modifications for ElastiCache Cluster (%s), got: %s", *v.CacheClusterId, v.PendingModifiedValues)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAWSElasticacheClusterDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).elasticacheconn
 
@@ -249,6 +348,43 @@ resource "aws_elasticache_cluster" "bar" {
 }
 `, acctest.RandInt(), acctest.RandInt(), acctest.RandString(10))
 
+func testAccAWSElasticacheClusterConfigApplyImmediately(ri int, rName string, numCacheNodes int, autoMinorVersionUpgrade bool) string {
+	return fmt.Sprintf(`
+provider "aws" {
+	region = "us-east-1"
+}
+resource "aws_security_group" "bar" {
+    name = "tf-test-security-group-%[1]03d"
+    description = "tf-test-security-group-descr"
+    ingress {
+        from_port = -1
+        to_port = -1
+        protocol = "icmp"
+        cidr_blocks = ["0.0.0.0/0"]
+    }
+}
+
+resource "aws_elasticache_security_group" "bar" {
+    name = "tf-test-security-group-%[1]03d"
+    description = "tf-test-security-group-descr"
+    security_group_names = ["${aws_security_group.bar.name}"]
+}
+
+resource "aws_elasticache_cluster" "bar" {
+    cluster_id = "tf-%[2]s"
+    engine = "memcached"
+    node_type = "cache.m1.small"
+    num_cache_nodes = %[3]d
+    port = 11211
+    parameter_group_name = "default.memcached1.4"
+    security_group_names = ["${aws_elasticache_security_group.bar.name}"]
+    maintenance_window = "sun:05:00-sun:06:00"
+    auto_minor_version_upgrade = %[4]t
+    apply_immediately = true
+}
+`, ri, rName, numCacheNodes, autoMinorVersionUpgrade)
+}
+
 var testAccAWSElasticacheClusterConfig_snapshots = `
 provider "aws" {
 	region = "us-east-1"
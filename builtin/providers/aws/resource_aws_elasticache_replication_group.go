@@ -0,0 +1,507 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsElasticacheReplicationGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsElasticacheReplicationGroupCreate,
+		Read:   resourceAwsElasticacheReplicationGroupRead,
+		Update: resourceAwsElasticacheReplicationGroupUpdate,
+		Delete: resourceAwsElasticacheReplicationGroupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"replication_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					// Like cluster ids, replication group ids are
+					// normalized to lowercase by Elasticache.
+					return strings.ToLower(val.(string))
+				},
+			},
+			"replication_group_description": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"node_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"engine": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "redis",
+			},
+			"engine_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"parameter_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"subnet_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"security_group_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			// number_cache_clusters is for replication groups with cluster
+			// mode disabled, i.e. a single shard with a primary and replicas.
+			"number_cache_clusters": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"cluster_mode"},
+			},
+			"cluster_mode": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// The number of shards can be changed in place,
+						// see resourceAwsElasticacheReplicationGroupUpdate.
+						"num_node_groups": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"replicas_per_node_group": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			// Automatic failover places the replicas of every shard in a
+			// different availability zone from their primary, and is required
+			// when cluster_mode is set.
+			"automatic_failover_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"apply_immediately": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			// Exported Attributes
+			"configuration_endpoint_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"primary_endpoint_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"member_clusters": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceAwsElasticacheReplicationGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	req := &elasticache.CreateReplicationGroupInput{
+		ReplicationGroupId:          aws.String(d.Get("replication_group_id").(string)),
+		ReplicationGroupDescription: aws.String(d.Get("replication_group_description").(string)),
+		CacheNodeType:               aws.String(d.Get("node_type").(string)),
+		Engine:                      aws.String(d.Get("engine").(string)),
+		AutomaticFailoverEnabled:    aws.Bool(d.Get("automatic_failover_enabled").(bool)),
+	}
+
+	if v, ok := d.GetOk("engine_version"); ok {
+		req.EngineVersion = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("port"); ok {
+		req.Port = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("parameter_group_name"); ok {
+		req.CacheParameterGroupName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("subnet_group_name"); ok {
+		req.CacheSubnetGroupName = aws.String(v.(string))
+	}
+
+	if v := d.Get("security_group_ids").(*schema.Set); v.Len() > 0 {
+		req.SecurityGroupIds = expandStringList(v.List())
+	}
+
+	if v, ok := d.GetOk("number_cache_clusters"); ok {
+		req.NumCacheClusters = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("cluster_mode"); ok {
+		clusterMode := v.([]interface{})[0].(map[string]interface{})
+		req.NumNodeGroups = aws.Int64(int64(clusterMode["num_node_groups"].(int)))
+		req.ReplicasPerNodeGroup = aws.Int64(int64(clusterMode["replicas_per_node_group"].(int)))
+	}
+
+	log.Printf("[DEBUG] Creating Elasticache Replication Group: %s", req)
+	resp, err := conn.CreateReplicationGroup(req)
+	if err != nil {
+		return fmt.Errorf("Error creating Elasticache Replication Group: %s", err)
+	}
+
+	d.SetId(strings.ToLower(*resp.ReplicationGroup.ReplicationGroupId))
+
+	pending := []string{"creating", "modifying"}
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     []string{"available"},
+		Refresh:    replicationGroupStateRefreshFunc(conn, d.Id(), "available", pending),
+		Timeout:    40 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for state to become available: %v", d.Id())
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for elasticache replication group (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsElasticacheReplicationGroupRead(d, meta)
+}
+
+func resourceAwsElasticacheReplicationGroupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	res, err := conn.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{
+		ReplicationGroupId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if eccErr, ok := err.(awserr.Error); ok && eccErr.Code() == "ReplicationGroupNotFoundFault" {
+			log.Printf("[WARN] Elasticache Replication Group (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if len(res.ReplicationGroups) != 1 {
+		log.Printf("[WARN] Elasticache Replication Group (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	rg := res.ReplicationGroups[0]
+	d.Set("replication_group_id", rg.ReplicationGroupId)
+	d.Set("replication_group_description", rg.Description)
+	d.Set("node_type", rg.CacheNodeType)
+	d.Set("member_clusters", flattenStringList(rg.MemberClusters))
+	if rg.AutomaticFailover != nil {
+		switch *rg.AutomaticFailover {
+		case elasticache.AutomaticFailoverStatusEnabled, elasticache.AutomaticFailoverStatusEnabling:
+			d.Set("automatic_failover_enabled", true)
+		default:
+			d.Set("automatic_failover_enabled", false)
+		}
+	}
+
+	if rg.ConfigurationEndpoint != nil {
+		d.Set("port", rg.ConfigurationEndpoint.Port)
+		d.Set("configuration_endpoint_address", rg.ConfigurationEndpoint.Address)
+	} else if len(rg.NodeGroups) > 0 && rg.NodeGroups[0].PrimaryEndpoint != nil {
+		d.Set("port", rg.NodeGroups[0].PrimaryEndpoint.Port)
+		d.Set("primary_endpoint_address", rg.NodeGroups[0].PrimaryEndpoint.Address)
+	}
+
+	if rg.ClusterEnabled != nil && *rg.ClusterEnabled {
+		clusterMode := map[string]interface{}{
+			"num_node_groups": len(rg.NodeGroups),
+			// Every shard has a primary, the rest of its members are the
+			// replicas.
+			"replicas_per_node_group": 0,
+		}
+		if len(rg.NodeGroups) > 0 && len(rg.NodeGroups[0].NodeGroupMembers) > 0 {
+			clusterMode["replicas_per_node_group"] = len(rg.NodeGroups[0].NodeGroupMembers) - 1
+		}
+		if err := d.Set("cluster_mode", []map[string]interface{}{clusterMode}); err != nil {
+			return fmt.Errorf("Error setting cluster_mode for Elasticache Replication Group (%s): %s", d.Id(), err)
+		}
+	} else {
+		d.Set("number_cache_clusters", len(rg.MemberClusters))
+	}
+
+	// The remaining attributes are only available on the member clusters.
+	if len(rg.MemberClusters) > 0 {
+		resp, err := conn.DescribeCacheClusters(&elasticache.DescribeCacheClustersInput{
+			CacheClusterId: rg.MemberClusters[0],
+		})
+		if err != nil {
+			return fmt.Errorf("Error describing member cluster of Elasticache Replication Group (%s): %s", d.Id(), err)
+		}
+		if len(resp.CacheClusters) == 1 {
+			c := resp.CacheClusters[0]
+			d.Set("engine", c.Engine)
+			d.Set("engine_version", c.EngineVersion)
+			d.Set("subnet_group_name", c.CacheSubnetGroupName)
+			if c.CacheParameterGroup != nil {
+				d.Set("parameter_group_name", c.CacheParameterGroup.CacheParameterGroupName)
+			}
+			securityGroupIds := make([]string, 0, len(c.SecurityGroups))
+			for _, sg := range c.SecurityGroups {
+				if sg.SecurityGroupId != nil {
+					securityGroupIds = append(securityGroupIds, *sg.SecurityGroupId)
+				}
+			}
+			d.Set("security_group_ids", securityGroupIds)
+		}
+	}
+
+	return nil
+}
+
+func resourceAwsElasticacheReplicationGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	if d.HasChange("cluster_mode.0.num_node_groups") {
+		if err := resourceAwsElasticacheReplicationGroupReshard(conn, d); err != nil {
+			return err
+		}
+	}
+
+	req := &elasticache.ModifyReplicationGroupInput{
+		ReplicationGroupId: aws.String(d.Id()),
+		ApplyImmediately:   aws.Bool(d.Get("apply_immediately").(bool)),
+	}
+
+	requestUpdate := false
+	if d.HasChange("replication_group_description") {
+		req.ReplicationGroupDescription = aws.String(d.Get("replication_group_description").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("automatic_failover_enabled") {
+		req.AutomaticFailoverEnabled = aws.Bool(d.Get("automatic_failover_enabled").(bool))
+		requestUpdate = true
+	}
+
+	if d.HasChange("parameter_group_name") {
+		req.CacheParameterGroupName = aws.String(d.Get("parameter_group_name").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("security_group_ids") {
+		if attr := d.Get("security_group_ids").(*schema.Set); attr.Len() > 0 {
+			req.SecurityGroupIds = expandStringList(attr.List())
+			requestUpdate = true
+		}
+	}
+
+	if requestUpdate {
+		log.Printf("[DEBUG] Modifying Elasticache Replication Group (%s), opts:\n%s", d.Id(), req)
+		if _, err := conn.ModifyReplicationGroup(req); err != nil {
+			return fmt.Errorf("Error updating Elasticache Replication Group (%s): %s", d.Id(), err)
+		}
+
+		if err := waitForElasticacheReplicationGroupUpdate(conn, d.Id()); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsElasticacheReplicationGroupRead(d, meta)
+}
+
+// resourceAwsElasticacheReplicationGroupReshard changes the number of shards
+// of a replication group with cluster mode enabled. Resharding always happens
+// online and immediately, regardless of apply_immediately.
+func resourceAwsElasticacheReplicationGroupReshard(conn *elasticache.ElastiCache, d *schema.ResourceData) error {
+	o, n := d.GetChange("cluster_mode.0.num_node_groups")
+	oldNumNodeGroups, newNumNodeGroups := o.(int), n.(int)
+
+	req := &elasticache.ModifyReplicationGroupShardConfigurationInput{
+		ReplicationGroupId: aws.String(d.Id()),
+		NodeGroupCount:     aws.Int64(int64(newNumNodeGroups)),
+		ApplyImmediately:   aws.Bool(true),
+	}
+
+	if newNumNodeGroups < oldNumNodeGroups {
+		res, err := conn.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{
+			ReplicationGroupId: aws.String(d.Id()),
+		})
+		if err != nil {
+			return fmt.Errorf("Error describing Elasticache Replication Group (%s): %s", d.Id(), err)
+		}
+		if len(res.ReplicationGroups) != 1 {
+			return fmt.Errorf("Elasticache Replication Group (%s) not found", d.Id())
+		}
+
+		nodeGroupsToRemove := getNodeGroupsToRemove(res.ReplicationGroups[0].NodeGroups, oldNumNodeGroups-newNumNodeGroups)
+		log.Printf("[INFO] Replication Group %s is marked for removing node groups %s", d.Id(), aws.StringValueSlice(nodeGroupsToRemove))
+		req.NodeGroupsToRemove = nodeGroupsToRemove
+	}
+
+	log.Printf("[DEBUG] Resharding Elasticache Replication Group (%s) from %d to %d node groups", d.Id(), oldNumNodeGroups, newNumNodeGroups)
+	if _, err := conn.ModifyReplicationGroupShardConfiguration(req); err != nil {
+		return fmt.Errorf("Error resharding Elasticache Replication Group (%s): %s", d.Id(), err)
+	}
+
+	return waitForElasticacheReplicationGroupUpdate(conn, d.Id())
+}
+
+// getNodeGroupsToRemove picks the node groups with the highest ids, mirroring
+// what getCacheNodesToRemove does for cache nodes.
+func getNodeGroupsToRemove(nodeGroups []*elasticache.NodeGroup, count int) []*string {
+	ids := make([]string, 0, len(nodeGroups))
+	for _, ng := range nodeGroups {
+		if ng != nil && ng.NodeGroupId != nil {
+			ids = append(ids, *ng.NodeGroupId)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+
+	if count > len(ids) {
+		count = len(ids)
+	}
+	return aws.StringSlice(ids[:count])
+}
+
+func waitForElasticacheReplicationGroupUpdate(conn *elasticache.ElastiCache, replicationGroupId string) error {
+	log.Printf("[DEBUG] Waiting for update: %s", replicationGroupId)
+	pending := []string{"modifying", "snapshotting"}
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     []string{"available"},
+		Refresh:    replicationGroupStateRefreshFunc(conn, replicationGroupId, "available", pending),
+		Timeout:    60 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for elasticache replication group (%s) to update: %s", replicationGroupId, err)
+	}
+	return nil
+}
+
+func resourceAwsElasticacheReplicationGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	_, err := conn.DeleteReplicationGroup(&elasticache.DeleteReplicationGroupInput{
+		ReplicationGroupId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if eccErr, ok := err.(awserr.Error); ok && eccErr.Code() == "ReplicationGroupNotFoundFault" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error deleting Elasticache Replication Group (%s): %s", d.Id(), err)
+	}
+
+	log.Printf("[DEBUG] Waiting for deletion: %v", d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating", "available", "deleting", "modifying"},
+		Target:     []string{},
+		Refresh:    replicationGroupStateRefreshFunc(conn, d.Id(), "", []string{}),
+		Timeout:    40 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for elasticache replication group (%s) to delete: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func replicationGroupStateRefreshFunc(conn *elasticache.ElastiCache, replicationGroupId, givenState string, pending []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{
+			ReplicationGroupId: aws.String(replicationGroupId),
+		})
+		if err != nil {
+			if eccErr, ok := err.(awserr.Error); ok && eccErr.Code() == "ReplicationGroupNotFoundFault" {
+				log.Printf("[DEBUG] Detect deletion")
+				return nil, "", nil
+			}
+
+			log.Printf("[ERROR] ReplicationGroupStateRefreshFunc: %s", err)
+			return nil, "", err
+		}
+
+		if len(resp.ReplicationGroups) == 0 {
+			return nil, "", fmt.Errorf("[WARN] Error: no Replication Groups found for id (%s)", replicationGroupId)
+		}
+
+		var rg *elasticache.ReplicationGroup
+		for _, replicationGroup := range resp.ReplicationGroups {
+			if *replicationGroup.ReplicationGroupId == replicationGroupId {
+				log.Printf("[DEBUG] Found matching Elasticache Replication Group: %s", *replicationGroup.ReplicationGroupId)
+				rg = replicationGroup
+			}
+		}
+
+		if rg == nil {
+			return nil, "", fmt.Errorf("[WARN] Error: no matching Elasticache Replication Group for id (%s)", replicationGroupId)
+		}
+
+		log.Printf("[DEBUG] Elasticache Replication Group (%s) status: %v", replicationGroupId, *rg.Status)
+
+		// return the current state if it's in the pending array
+		for _, p := range pending {
+			if p == *rg.Status {
+				log.Printf("[DEBUG] Return with status: %v", *rg.Status)
+				return rg, p, nil
+			}
+		}
+
+		// return given state if it's not in pending
+		if givenState != "" {
+			// A reshard reports the group as available before its slots
+			// have finished moving, so keep waiting while it's pending.
+			if rg.PendingModifiedValues != nil && rg.PendingModifiedValues.Resharding != nil {
+				log.Printf("[DEBUG] Elasticache Replication Group (%s) is still resharding", replicationGroupId)
+				return rg, "modifying", nil
+			}
+			return rg, givenState, nil
+		}
+		log.Printf("[DEBUG] current status: %v", *rg.Status)
+		return rg, *rg.Status, nil
+	}
+}
@@ -0,0 +1,328 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSElasticacheReplicationGroup_basic(t *testing.T) {
+	var rg elasticache.ReplicationGroup
+	ri := acctest.RandInt()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheReplicationGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSElasticacheReplicationGroupConfig, ri, ri, acctest.RandString(10)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.bar", &rg),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "number_cache_clusters", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "automatic_failover_enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSElasticacheReplicationGroup_clusterMode(t *testing.T) {
+	var rg elasticache.ReplicationGroup
+	ri := acctest.RandInt()
+	rName := acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheReplicationGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSElasticacheReplicationGroupClusterModeConfig, ri, ri, rName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.bar", &rg),
+					testAccCheckAWSElasticacheReplicationGroupNodeGroups(&rg, 2),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "cluster_mode.0.num_node_groups", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "cluster_mode.0.replicas_per_node_group", "1"),
+					testAccCheckAWSElasticacheReplicationGroupConfigurationEndpoint(&rg),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSElasticacheReplicationGroup_clusterModeReshard(t *testing.T) {
+	var before, after elasticache.ReplicationGroup
+	ri := acctest.RandInt()
+	rName := acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheReplicationGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSElasticacheReplicationGroupClusterModeConfig, ri, ri, rName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.bar", &before),
+					testAccCheckAWSElasticacheReplicationGroupNodeGroups(&before, 2),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSElasticacheReplicationGroupClusterModeConfig, ri, ri, rName, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.bar", &after),
+					testAccCheckAWSElasticacheReplicationGroupNodeGroups(&after, 3),
+					testAccCheckAWSElasticacheReplicationGroupNotRecreated(&before, &after),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "cluster_mode.0.num_node_groups", "3"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSElasticacheReplicationGroupClusterModeConfig, ri, ri, rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.bar", &after),
+					testAccCheckAWSElasticacheReplicationGroupNodeGroups(&after, 1),
+					testAccCheckAWSElasticacheReplicationGroupNotRecreated(&before, &after),
+				),
+			},
+		},
+	})
+}
+
+func TestGetNodeGroupsToRemove(t *testing.T) {
+	nodeGroups := []*elasticache.NodeGroup{
+		&elasticache.NodeGroup{NodeGroupId: aws.String("0002")},
+		&elasticache.NodeGroup{NodeGroupId: aws.String("0001")},
+		&elasticache.NodeGroup{NodeGroupId: aws.String("0004")},
+		&elasticache.NodeGroup{NodeGroupId: aws.String("0003")},
+	}
+
+	cases := []struct {
+		Count    int
+		Expected []string
+	}{
+		{1, []string{"0004"}},
+		{2, []string{"0004", "0003"}},
+		{5, []string{"0004", "0003", "0002", "0001"}},
+	}
+
+	for _, tc := range cases {
+		actual := aws.StringValueSlice(getNodeGroupsToRemove(nodeGroups, tc.Count))
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("Removing %d node groups, expected %v, got: %v", tc.Count, tc.Expected, actual)
+		}
+	}
+}
+
+func testAccCheckAWSElasticacheReplicationGroupNodeGroups(rg *elasticache.ReplicationGroup, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(rg.NodeGroups) != expected {
+			return fmt.Errorf("Expected %d node groups, got: %d", expected, len(rg.NodeGroups))
+		}
+		return nil
+	}
+}
+
+func testAccCheckAWSElasticacheReplicationGroupConfigurationEndpoint(rg *elasticache.ReplicationGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if rg.ConfigurationEndpoint == nil || rg.ConfigurationEndpoint.Address == nil {
+			return fmt.Errorf("Expected a configuration endpoint for a replication group with cluster mode enabled")
+		}
+		return nil
+	}
+}
+
+func testAccCheckAWSElasticacheReplicationGroupNotRecreated(before, after *elasticache.ReplicationGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// Member cluster ids are generated from the replication group id, so
+		// compare the shared configuration endpoint instead.
+		if *before.ConfigurationEndpoint.Address != *after.ConfigurationEndpoint.Address {
+			return fmt.Errorf("Expected replication group to be resharded in place, configuration endpoint changed from %s to %s",
+				*before.ConfigurationEndpoint.Address, *after.ConfigurationEndpoint.Address)
+		}
+		return nil
+	}
+}
+
+func testAccCheckAWSElasticacheReplicationGroupDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elasticacheconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_elasticache_replication_group" {
+			continue
+		}
+		res, err := conn.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{
+			ReplicationGroupId: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			// Verify the error is what we want
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ReplicationGroupNotFoundFault" {
+				continue
+			}
+			return err
+		}
+		if len(res.ReplicationGroups) > 0 {
+			return fmt.Errorf("still exist.")
+		}
+	}
+	return nil
+}
+
+func testAccCheckAWSElasticacheReplicationGroupExists(n string, v *elasticache.ReplicationGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No replication group ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elasticacheconn
+		resp, err := conn.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{
+			ReplicationGroupId: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return fmt.Errorf("Elasticache error: %v", err)
+		}
+
+		for _, rg := range resp.ReplicationGroups {
+			if *rg.ReplicationGroupId == rs.Primary.ID {
+				*v = *rg
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Replication group %s not found", rs.Primary.ID)
+	}
+}
+
+var testAccAWSElasticacheReplicationGroupConfig = `
+resource "aws_vpc" "foo" {
+    cidr_block = "192.168.0.0/16"
+    tags {
+            Name = "tf-test"
+    }
+}
+
+resource "aws_subnet" "foo" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "192.168.0.0/20"
+    availability_zone = "us-west-2a"
+    tags {
+            Name = "tf-test"
+    }
+}
+
+resource "aws_subnet" "bar" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "192.168.16.0/20"
+    availability_zone = "us-west-2b"
+    tags {
+            Name = "tf-test"
+    }
+}
+
+resource "aws_elasticache_subnet_group" "bar" {
+    name = "tf-test-cache-subnet-%03d"
+    description = "tf-test-cache-subnet-group-descr"
+    subnet_ids = ["${aws_subnet.foo.id}", "${aws_subnet.bar.id}"]
+}
+
+resource "aws_security_group" "bar" {
+    name = "tf-test-security-group-%03d"
+    description = "tf-test-security-group-descr"
+    vpc_id = "${aws_vpc.foo.id}"
+    ingress {
+        from_port = -1
+        to_port = -1
+        protocol = "icmp"
+        cidr_blocks = ["0.0.0.0/0"]
+    }
+}
+
+resource "aws_elasticache_replication_group" "bar" {
+    replication_group_id = "tf-%s"
+    replication_group_description = "test description"
+    node_type = "cache.m3.medium"
+    number_cache_clusters = 2
+    port = 6379
+    parameter_group_name = "default.redis2.8"
+    subnet_group_name = "${aws_elasticache_subnet_group.bar.name}"
+    security_group_ids = ["${aws_security_group.bar.id}"]
+    automatic_failover_enabled = true
+}
+`
+
+var testAccAWSElasticacheReplicationGroupClusterModeConfig = `
+resource "aws_vpc" "foo" {
+    cidr_block = "192.168.0.0/16"
+    tags {
+            Name = "tf-test"
+    }
+}
+
+resource "aws_subnet" "foo" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "192.168.0.0/20"
+    availability_zone = "us-west-2a"
+    tags {
+            Name = "tf-test"
+    }
+}
+
+resource "aws_subnet" "bar" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "192.168.16.0/20"
+    availability_zone = "us-west-2b"
+    tags {
+            Name = "tf-test"
+    }
+}
+
+resource "aws_elasticache_subnet_group" "bar" {
+    name = "tf-test-cache-subnet-%03d"
+    description = "tf-test-cache-subnet-group-descr"
+    subnet_ids = ["${aws_subnet.foo.id}", "${aws_subnet.bar.id}"]
+}
+
+resource "aws_security_group" "bar" {
+    name = "tf-test-security-group-%03d"
+    description = "tf-test-security-group-descr"
+    vpc_id = "${aws_vpc.foo.id}"
+    ingress {
+        from_port = -1
+        to_port = -1
+        protocol = "icmp"
+        cidr_blocks = ["0.0.0.0/0"]
+    }
+}
+
+resource "aws_elasticache_replication_group" "bar" {
+    replication_group_id = "tf-%s"
+    replication_group_description = "test description"
+    node_type = "cache.m3.medium"
+    engine_version = "3.2.10"
+    port = 6379
+    parameter_group_name = "default.redis3.2.cluster.on"
+    subnet_group_name = "${aws_elasticache_subnet_group.bar.name}"
+    security_group_ids = ["${aws_security_group.bar.id}"]
+    automatic_failover_enabled = true
+    apply_immediately = true
+
+    cluster_mode {
+        num_node_groups = %d
+        replicas_per_node_group = 1
+    }
+}
+`
@@ -22,9 +22,10 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"access_policies": &schema.Schema{
-				Type:      schema.TypeString,
-				StateFunc: normalizeJson,
-				Optional:  true,
+				Type:         schema.TypeString,
+				StateFunc:    normalizeJson,
+				Optional:     true,
+				ValidateFunc: validateJsonString,
 			},
 			"advanced_options": &schema.Schema{
 				Type:     schema.TypeMap,
@@ -120,6 +121,7 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 			"snapshot_options": &schema.Schema{
 				Type:     schema.TypeList,
 				Optional: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"automated_snapshot_start_hour": &schema.Schema{
@@ -255,9 +257,7 @@ func resourceAwsElasticSearchDomainRead(d *schema.ResourceData, meta interface{}
 
 	ds := out.DomainStatus
 
-	if ds.AccessPolicies != nil && *ds.AccessPolicies != "" {
-		d.Set("access_policies", normalizeJson(*ds.AccessPolicies))
-	}
+	d.Set("access_policies", normalizeJson(aws.StringValue(ds.AccessPolicies)))
 	err = d.Set("advanced_options", pointersMapToStringList(ds.AdvancedOptions))
 	if err != nil {
 		return err
@@ -277,9 +277,14 @@ func resourceAwsElasticSearchDomainRead(d *schema.ResourceData, meta interface{}
 		return err
 	}
 	if ds.SnapshotOptions != nil {
-		d.Set("snapshot_options", map[string]interface{}{
-			"automated_snapshot_start_hour": *ds.SnapshotOptions.AutomatedSnapshotStartHour,
+		err = d.Set("snapshot_options", []map[string]interface{}{
+			map[string]interface{}{
+				"automated_snapshot_start_hour": int(aws.Int64Value(ds.SnapshotOptions.AutomatedSnapshotStartHour)),
+			},
 		})
+		if err != nil {
+			return err
+		}
 	}
 
 	d.Set("arn", *ds.ARN)
@@ -316,15 +321,19 @@ func resourceAwsElasticSearchDomainUpdate(d *schema.ResourceData, meta interface
 		DomainName: aws.String(d.Get("domain_name").(string)),
 	}
 
+	requestUpdate := false
 	if d.HasChange("access_policies") {
 		input.AccessPolicies = aws.String(d.Get("access_policies").(string))
+		requestUpdate = true
 	}
 
 	if d.HasChange("advanced_options") {
 		input.AdvancedOptions = stringMapToPointers(d.Get("advanced_options").(map[string]interface{}))
+		requestUpdate = true
 	}
 
 	if d.HasChange("ebs_options") {
+		requestUpdate = true
 		options := d.Get("ebs_options").([]interface{})
 
 		if len(options) > 1 {
@@ -336,6 +345,7 @@ func resourceAwsElasticSearchDomainUpdate(d *schema.ResourceData, meta interface
 	}
 
 	if d.HasChange("cluster_config") {
+		requestUpdate = true
 		config := d.Get("cluster_config").([]interface{})
 
 		if len(config) > 1 {
@@ -347,6 +357,7 @@ func resourceAwsElasticSearchDomainUpdate(d *schema.ResourceData, meta interface
 	}
 
 	if d.HasChange("snapshot_options") {
+		requestUpdate = true
 		options := d.Get("snapshot_options").([]interface{})
 
 		if len(options) > 1 {
@@ -362,28 +373,31 @@ func resourceAwsElasticSearchDomainUpdate(d *schema.ResourceData, meta interface
 		}
 	}
 
-	_, err := conn.UpdateElasticsearchDomainConfig(&input)
-	if err != nil {
-		return err
-	}
-
-	err = resource.Retry(50*time.Minute, func() *resource.RetryError {
-		out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
-			DomainName: aws.String(d.Get("domain_name").(string)),
-		})
+	if requestUpdate {
+		log.Printf("[DEBUG] Updating ElasticSearch domain: %s", input)
+		_, err := conn.UpdateElasticsearchDomainConfig(&input)
 		if err != nil {
-			return resource.NonRetryableError(err)
+			return err
 		}
 
-		if *out.DomainStatus.Processing == false {
-			return nil
-		}
+		err = resource.Retry(50*time.Minute, func() *resource.RetryError {
+			out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
+				DomainName: aws.String(d.Get("domain_name").(string)),
+			})
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
 
-		return resource.RetryableError(
-			fmt.Errorf("%q: Timeout while waiting for changes to be processed", d.Id()))
-	})
-	if err != nil {
-		return err
+			if *out.DomainStatus.Processing == false {
+				return nil
+			}
+
+			return resource.RetryableError(
+				fmt.Errorf("%q: Timeout while waiting for changes to be processed", d.Id()))
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	d.Partial(false)
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -47,6 +48,45 @@ func TestAccAWSElasticSearchDomain_complex(t *testing.T) {
 	})
 }
 
+func TestAccAWSElasticSearchDomain_policyAndSnapshotOptions(t *testing.T) {
+	var before, after elasticsearch.ElasticsearchDomainStatus
+
+	testCheckNotRecreated := func(*terraform.State) error {
+		if aws.StringValue(before.Endpoint) != aws.StringValue(after.Endpoint) {
+			return fmt.Errorf("Domain was recreated: endpoint %s is now %s",
+				aws.StringValue(before.Endpoint), aws.StringValue(after.Endpoint))
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckESDomainDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccESDomainConfig_policy("192.0.2.0/24", 23),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckESDomainExists("aws_elasticsearch_domain.example", &before),
+					resource.TestCheckResourceAttr(
+						"aws_elasticsearch_domain.example", "snapshot_options.0.automated_snapshot_start_hour", "23"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccESDomainConfig_policy("198.51.100.0/24", 4),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckESDomainExists("aws_elasticsearch_domain.example", &after),
+					testCheckNotRecreated,
+					testAccCheckESDomainAccessPolicies(&after, "198.51.100.0/24"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticsearch_domain.example", "snapshot_options.0.automated_snapshot_start_hour", "4"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSElasticSearch_tags(t *testing.T) {
 	var domain elasticsearch.ElasticsearchDomainStatus
 	var td elasticsearch.ListTagsOutput
@@ -94,6 +134,15 @@ func testAccLoadESTags(conf *elasticsearch.ElasticsearchDomainStatus, td *elasti
 	}
 }
 
+func testAccCheckESDomainAccessPolicies(domain *elasticsearch.ElasticsearchDomainStatus, sourceIp string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !strings.Contains(aws.StringValue(domain.AccessPolicies), sourceIp) {
+			return fmt.Errorf("Expected access policies to allow %s, got: %s", sourceIp, aws.StringValue(domain.AccessPolicies))
+		}
+		return nil
+	}
+}
+
 func testAccCheckESDomainExists(n string, domain *elasticsearch.ElasticsearchDomainStatus) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -161,6 +210,35 @@ resource "aws_elasticsearch_domain" "example" {
 }
 `
 
+func testAccESDomainConfig_policy(sourceIp string, snapshotHour int) string {
+	return fmt.Sprintf(`
+resource "aws_elasticsearch_domain" "example" {
+  domain_name = "tf-test-3"
+
+  access_policies = <<CONFIG
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "es:*",
+      "Principal": "*",
+      "Effect": "Allow",
+      "Resource": "arn:aws:es:*:*:domain/tf-test-3/*",
+      "Condition": {
+        "IpAddress": {"aws:SourceIp": "%s"}
+      }
+    }
+  ]
+}
+CONFIG
+
+  snapshot_options {
+    automated_snapshot_start_hour = %d
+  }
+}
+`, sourceIp, snapshotHour)
+}
+
 const testAccESDomainConfig_complex = `
 resource "aws_elasticsearch_domain" "example" {
   domain_name = "tf-test-2"
@@ -63,6 +63,12 @@ func resourceAwsElb() *schema.Resource {
 				Set:      schema.HashString,
 			},
 
+			"skip_instance_validation": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"security_groups": &schema.Schema{
 				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
@@ -469,6 +475,13 @@ func resourceAwsElbUpdate(d *schema.ResourceData, meta interface{}) error {
 		add := expandInstanceString(ns.Difference(os).List())
 
 		if len(add) > 0 {
+			if !d.Get("skip_instance_validation").(bool) {
+				ec2conn := meta.(*AWSClient).ec2conn
+				if err := validateElbInstancesExist(ec2conn, add); err != nil {
+					return err
+				}
+			}
+
 			registerInstancesOpts := elb.RegisterInstancesWithLoadBalancerInput{
 				LoadBalancerName: aws.String(d.Id()),
 				Instances:        add,
@@ -711,6 +724,57 @@ func resourceAwsElbUpdate(d *schema.ResourceData, meta interface{}) error {
 	return resourceAwsElbRead(d, meta)
 }
 
+// validateElbInstancesExist checks that all of the given instances exist
+// before they are registered. The ELB API accepts unknown instance IDs and
+// just reports them as OutOfService, which is easy to miss.
+func validateElbInstancesExist(conn *ec2.EC2, instances []*elb.Instance) error {
+	ids := make([]*string, 0, len(instances))
+	for _, i := range instances {
+		ids = append(ids, i.InstanceId)
+	}
+
+	// Filtering by ID rather than passing InstanceIds means unknown IDs are
+	// left out of the result instead of failing the whole call, so all of
+	// them can be reported at once.
+	found := make(map[string]bool, len(ids))
+	err := conn.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			&ec2.Filter{
+				Name:   aws.String("instance-id"),
+				Values: ids,
+			},
+		},
+	}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, r := range page.Reservations {
+			for _, i := range r.Instances {
+				if i.State != nil && aws.StringValue(i.State.Name) == ec2.InstanceStateNameTerminated {
+					continue
+				}
+				found[aws.StringValue(i.InstanceId)] = true
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("Error validating instances to register with ELB: %s", err)
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if !found[*id] {
+			missing = append(missing, *id)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"Can't register instances with ELB, they don't exist: %s. "+
+				"Set skip_instance_validation to register instances of another account.",
+			strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
 func resourceAwsElbDelete(d *schema.ResourceData, meta interface{}) error {
 	elbconn := meta.(*AWSClient).elbconn
 
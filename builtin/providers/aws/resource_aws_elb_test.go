@@ -6,11 +6,16 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
@@ -334,6 +339,65 @@ func TestAccAWSELBUpdate_Listener(t *testing.T) {
 	})
 }
 
+func TestAccAWSELBUpdate_ListenerAddRemove(t *testing.T) {
+	var before, after elb.LoadBalancerDescription
+
+	testCheckListenerCount := func(conf *elb.LoadBalancerDescription, count int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if len(conf.ListenerDescriptions) != count {
+				return fmt.Errorf("Expected %d listeners, got %d", count, len(conf.ListenerDescriptions))
+			}
+			return nil
+		}
+	}
+
+	testCheckNotRecreated := func(*terraform.State) error {
+		if !before.CreatedTime.Equal(*after.CreatedTime) {
+			return fmt.Errorf("ELB was recreated: created %s, then %s", before.CreatedTime, after.CreatedTime)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "aws_elb.bar",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckAWSELBDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSELBConfigListener_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSELBExists("aws_elb.bar", &before),
+					testCheckListenerCount(&before, 1),
+					resource.TestCheckResourceAttr("aws_elb.bar", "listener.#", "1"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSELBConfigListener_multipleListeners,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSELBExists("aws_elb.bar", &after),
+					testCheckListenerCount(&after, 2),
+					testCheckNotRecreated,
+					resource.TestCheckResourceAttr("aws_elb.bar", "listener.#", "2"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccAWSELBConfigListener_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSELBExists("aws_elb.bar", &after),
+					testCheckListenerCount(&after, 1),
+					testCheckNotRecreated,
+					resource.TestCheckResourceAttr("aws_elb.bar", "listener.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_elb.bar", "listener.3931999347.instance_port", "8080"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSELB_HealthCheck(t *testing.T) {
 	var conf elb.LoadBalancerDescription
 
@@ -599,6 +663,50 @@ func TestResourceAWSELB_validateElbNameCannotEndWithHyphen(t *testing.T) {
 	}
 }
 
+func TestResourceAWSELB_validateElbInstancesExist(t *testing.T) {
+	// Answer DescribeInstances locally with a single known instance.
+	var filters []*ec2.Filter
+	conn := ec2.New(session.New(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("accessKey", "secretKey", ""),
+		Region:      aws.String("us-west-2"),
+	}))
+	conn.Handlers.Send.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		filters = r.Params.(*ec2.DescribeInstancesInput).Filters
+		*r.Data.(*ec2.DescribeInstancesOutput) = ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{
+				&ec2.Reservation{
+					Instances: []*ec2.Instance{
+						&ec2.Instance{
+							InstanceId: aws.String("i-12345678"),
+							State:      &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+						},
+					},
+				},
+			},
+		}
+	})
+	conn.Handlers.UnmarshalMeta.Clear()
+	conn.Handlers.Unmarshal.Clear()
+	conn.Handlers.ValidateResponse.Clear()
+
+	err := validateElbInstancesExist(conn, expandInstanceString([]interface{}{"i-12345678"}))
+	if err != nil {
+		t.Fatalf("Expected an existing instance to pass validation, got: %s", err)
+	}
+	if len(filters) != 1 || *filters[0].Name != "instance-id" {
+		t.Fatalf("Expected instances to be filtered by ID, got: %#v", filters)
+	}
+
+	err = validateElbInstancesExist(conn, expandInstanceString([]interface{}{"i-12345678", "i-deadbeef"}))
+	if err == nil {
+		t.Fatalf("Expected a bogus instance ID to fail validation")
+	}
+	if !strings.Contains(err.Error(), "i-deadbeef") || strings.Contains(err.Error(), "i-12345678") {
+		t.Fatalf("Expected only the bogus instance ID to be reported, got: %s", err)
+	}
+}
+
 func testAccCheckAWSELBDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).elbconn
 
@@ -968,6 +1076,26 @@ resource "aws_elb" "bar" {
 }
 `
 
+const testAccAWSELBConfigListener_multipleListeners = `
+resource "aws_elb" "bar" {
+  availability_zones = ["us-west-2a", "us-west-2b", "us-west-2c"]
+
+  listener {
+    instance_port = 8080
+    instance_protocol = "http"
+    lb_port = 80
+    lb_protocol = "http"
+  }
+
+  listener {
+    instance_port = 8081
+    instance_protocol = "tcp"
+    lb_port = 8081
+    lb_protocol = "tcp"
+  }
+}
+`
+
 const testAccAWSELBConfigIdleTimeout = `
 resource "aws_elb" "bar" {
 	availability_zones = ["us-west-2a"]
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -54,9 +55,15 @@ func resourceAwsFlowLog() *schema.Resource {
 			},
 
 			"traffic_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateFlowLogTrafficType,
+			},
+
+			"deliver_logs_status": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Computed: true,
 			},
 		},
 	}
@@ -69,9 +76,9 @@ func resourceAwsLogFlowCreate(d *schema.ResourceData, meta interface{}) error {
 		ID   string
 		Type string
 	}{
-		{ID: d.Get("vpc_id").(string), Type: "VPC"},
-		{ID: d.Get("subnet_id").(string), Type: "Subnet"},
-		{ID: d.Get("eni_id").(string), Type: "NetworkInterface"},
+		{ID: d.Get("vpc_id").(string), Type: ec2.FlowLogsResourceTypeVpc},
+		{ID: d.Get("subnet_id").(string), Type: ec2.FlowLogsResourceTypeSubnet},
+		{ID: d.Get("eni_id").(string), Type: ec2.FlowLogsResourceTypeNetworkInterface},
 	}
 
 	var resourceId string
@@ -121,9 +128,12 @@ func resourceAwsLogFlowRead(d *schema.ResourceData, meta interface{}) error {
 
 	resp, err := conn.DescribeFlowLogs(opts)
 	if err != nil {
-		log.Printf("[WARN] Error describing Flow Logs for id (%s)", d.Id())
-		d.SetId("")
-		return nil
+		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidFlowLogId.NotFound" {
+			log.Printf("[WARN] Flow Log (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error describing Flow Log (%s): %s", d.Id(), err)
 	}
 
 	if len(resp.FlowLogs) == 0 {
@@ -136,6 +146,7 @@ func resourceAwsLogFlowRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("traffic_type", fl.TrafficType)
 	d.Set("log_group_name", fl.LogGroupName)
 	d.Set("iam_role_arn", fl.DeliverLogsPermissionArn)
+	d.Set("deliver_logs_status", fl.DeliverLogsStatus)
 
 	var resourceKey string
 	if strings.HasPrefix(*fl.ResourceId, "vpc-") {
@@ -0,0 +1,139 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsGlueCatalogDatabase() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsGlueCatalogDatabaseCreate,
+		Read:   resourceAwsGlueCatalogDatabaseRead,
+		Update: resourceAwsGlueCatalogDatabaseUpdate,
+		Delete: resourceAwsGlueCatalogDatabaseDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"location_uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceAwsGlueCatalogDatabaseCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).glueconn
+
+	name := d.Get("name").(string)
+	input := &glue.CreateDatabaseInput{
+		DatabaseInput: expandGlueDatabaseInput(d),
+	}
+
+	log.Printf("[DEBUG] Creating Glue Catalog Database: %s", input)
+	if _, err := conn.CreateDatabase(input); err != nil {
+		return fmt.Errorf("Error creating Glue Catalog Database %s: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return resourceAwsGlueCatalogDatabaseRead(d, meta)
+}
+
+func resourceAwsGlueCatalogDatabaseRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).glueconn
+
+	resp, err := conn.GetDatabase(&glue.GetDatabaseInput{
+		Name: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isGlueEntityNotFoundErr(err) {
+			log.Printf("[WARN] Glue Catalog Database (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Glue Catalog Database %s: %s", d.Id(), err)
+	}
+
+	if resp.Database == nil {
+		log.Printf("[WARN] Glue Catalog Database (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", resp.Database.Name)
+	d.Set("description", resp.Database.Description)
+	d.Set("location_uri", resp.Database.LocationUri)
+
+	return nil
+}
+
+func resourceAwsGlueCatalogDatabaseUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).glueconn
+
+	if d.HasChange("description") || d.HasChange("location_uri") {
+		// UpdateDatabase replaces the whole definition, so everything is
+		// sent along, not just what changed.
+		input := &glue.UpdateDatabaseInput{
+			Name:          aws.String(d.Id()),
+			DatabaseInput: expandGlueDatabaseInput(d),
+		}
+
+		log.Printf("[DEBUG] Updating Glue Catalog Database: %s", input)
+		if _, err := conn.UpdateDatabase(input); err != nil {
+			return fmt.Errorf("Error updating Glue Catalog Database %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsGlueCatalogDatabaseRead(d, meta)
+}
+
+func resourceAwsGlueCatalogDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).glueconn
+
+	log.Printf("[DEBUG] Deleting Glue Catalog Database: %s", d.Id())
+	_, err := conn.DeleteDatabase(&glue.DeleteDatabaseInput{
+		Name: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isGlueEntityNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Glue Catalog Database %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandGlueDatabaseInput(d *schema.ResourceData) *glue.DatabaseInput {
+	input := &glue.DatabaseInput{
+		Name: aws.String(d.Get("name").(string)),
+	}
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("location_uri"); ok {
+		input.LocationUri = aws.String(v.(string))
+	}
+	return input
+}
+
+func isGlueEntityNotFoundErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == glue.ErrCodeEntityNotFoundException
+}
@@ -0,0 +1,135 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSGlueCatalogDatabase_basic(t *testing.T) {
+	var db glue.Database
+	rName := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSGlueCatalogDatabaseDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSGlueCatalogDatabaseConfig, rName, "First description", "my-location"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSGlueCatalogDatabaseExists("aws_glue_catalog_database.test", &db),
+					resource.TestCheckResourceAttr(
+						"aws_glue_catalog_database.test", "name", rName),
+					resource.TestCheckResourceAttr(
+						"aws_glue_catalog_database.test", "description", "First description"),
+					resource.TestCheckResourceAttr(
+						"aws_glue_catalog_database.test", "location_uri", "my-location"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSGlueCatalogDatabaseConfig, rName, "Second description", "my-other-location"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSGlueCatalogDatabaseExists("aws_glue_catalog_database.test", &db),
+					resource.TestCheckResourceAttr(
+						"aws_glue_catalog_database.test", "description", "Second description"),
+					resource.TestCheckResourceAttr(
+						"aws_glue_catalog_database.test", "location_uri", "my-other-location"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSGlueCatalogDatabase_disappears(t *testing.T) {
+	var db glue.Database
+	rName := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSGlueCatalogDatabaseDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccAWSGlueCatalogDatabaseConfig, rName, "First description", "my-location"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSGlueCatalogDatabaseExists("aws_glue_catalog_database.test", &db),
+					testAccAWSGlueCatalogDatabaseDisappears(&db),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccAWSGlueCatalogDatabaseDisappears(db *glue.Database) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).glueconn
+		_, err := conn.DeleteDatabase(&glue.DeleteDatabaseInput{
+			Name: db.Name,
+		})
+		return err
+	}
+}
+
+func testAccCheckAWSGlueCatalogDatabaseDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).glueconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_glue_catalog_database" {
+			continue
+		}
+
+		_, err := conn.GetDatabase(&glue.GetDatabaseInput{
+			Name: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("Glue Catalog Database %s still exists", rs.Primary.ID)
+		}
+		if !isGlueEntityNotFoundErr(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSGlueCatalogDatabaseExists(n string, v *glue.Database) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Glue Catalog Database ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).glueconn
+		resp, err := conn.GetDatabase(&glue.GetDatabaseInput{
+			Name: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Database == nil {
+			return fmt.Errorf("Glue Catalog Database %s not found", rs.Primary.ID)
+		}
+
+		*v = *resp.Database
+		return nil
+	}
+}
+
+const testAccAWSGlueCatalogDatabaseConfig = `
+resource "aws_glue_catalog_database" "test" {
+  name = "%s"
+  description = "%s"
+  location_uri = "%s"
+}
+`
@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/guardduty"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsGuardDutyDetector() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsGuardDutyDetectorCreate,
+		Read:   resourceAwsGuardDutyDetectorRead,
+		Update: resourceAwsGuardDutyDetectorUpdate,
+		Delete: resourceAwsGuardDutyDetectorDelete,
+
+		Schema: map[string]*schema.Schema{
+			"enable": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"finding_publishing_frequency": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateGuardDutyFindingPublishingFrequency,
+			},
+
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsGuardDutyDetectorCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).guarddutyconn
+
+	// There can only be one detector per account and region, so a second
+	// one fails here with a BadRequestException from the API.
+	input := &guardduty.CreateDetectorInput{
+		Enable: aws.Bool(d.Get("enable").(bool)),
+	}
+	if v, ok := d.GetOk("finding_publishing_frequency"); ok {
+		input.FindingPublishingFrequency = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Creating GuardDuty Detector: %s", input)
+	output, err := conn.CreateDetector(input)
+	if err != nil {
+		return fmt.Errorf("Error creating GuardDuty Detector: %s", err)
+	}
+
+	d.SetId(*output.DetectorId)
+
+	return resourceAwsGuardDutyDetectorRead(d, meta)
+}
+
+func resourceAwsGuardDutyDetectorRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).guarddutyconn
+
+	resp, err := conn.GetDetector(&guardduty.GetDetectorInput{
+		DetectorId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isGuardDutyDetectorNotFoundErr(err) {
+			log.Printf("[WARN] GuardDuty Detector (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading GuardDuty Detector %s: %s", d.Id(), err)
+	}
+
+	d.Set("account_id", meta.(*AWSClient).accountid)
+	d.Set("enable", aws.StringValue(resp.Status) == guardduty.DetectorStatusEnabled)
+	d.Set("finding_publishing_frequency", resp.FindingPublishingFrequency)
+
+	return nil
+}
+
+func resourceAwsGuardDutyDetectorUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).guarddutyconn
+
+	if d.HasChange("enable") || d.HasChange("finding_publishing_frequency") {
+		input := &guardduty.UpdateDetectorInput{
+			DetectorId: aws.String(d.Id()),
+			Enable:     aws.Bool(d.Get("enable").(bool)),
+		}
+		if v, ok := d.GetOk("finding_publishing_frequency"); ok {
+			input.FindingPublishingFrequency = aws.String(v.(string))
+		}
+
+		log.Printf("[DEBUG] Updating GuardDuty Detector: %s", input)
+		if _, err := conn.UpdateDetector(input); err != nil {
+			return fmt.Errorf("Error updating GuardDuty Detector %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsGuardDutyDetectorRead(d, meta)
+}
+
+func resourceAwsGuardDutyDetectorDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).guarddutyconn
+
+	log.Printf("[DEBUG] Deleting GuardDuty Detector: %s", d.Id())
+	_, err := conn.DeleteDetector(&guardduty.DeleteDetectorInput{
+		DetectorId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isGuardDutyDetectorNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting GuardDuty Detector %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// GuardDuty has no dedicated not found error code; a detector that is gone
+// is reported as a BadRequestException about the detector ID.
+func isGuardDutyDetectorNotFoundErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == guardduty.ErrCodeBadRequestException &&
+		strings.Contains(awsErr.Message(), "is not owned by the current account")
+}
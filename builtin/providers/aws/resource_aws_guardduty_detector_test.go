@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/guardduty"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSGuardDutyDetector_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSGuardDutyDetectorDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSGuardDutyDetectorConfig(true, "SIX_HOURS"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSGuardDutyDetectorStatus("aws_guardduty_detector.test", guardduty.DetectorStatusEnabled),
+					resource.TestCheckResourceAttr("aws_guardduty_detector.test", "enable", "true"),
+					resource.TestCheckResourceAttr("aws_guardduty_detector.test", "finding_publishing_frequency", "SIX_HOURS"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSGuardDutyDetectorConfig(false, "SIX_HOURS"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSGuardDutyDetectorStatus("aws_guardduty_detector.test", guardduty.DetectorStatusDisabled),
+					resource.TestCheckResourceAttr("aws_guardduty_detector.test", "enable", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSGuardDutyDetectorConfig(true, "FIFTEEN_MINUTES"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSGuardDutyDetectorStatus("aws_guardduty_detector.test", guardduty.DetectorStatusEnabled),
+					resource.TestCheckResourceAttr("aws_guardduty_detector.test", "enable", "true"),
+					resource.TestCheckResourceAttr("aws_guardduty_detector.test", "finding_publishing_frequency", "FIFTEEN_MINUTES"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSGuardDutyDetectorStatus(n, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No GuardDuty Detector ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).guarddutyconn
+		resp, err := conn.GetDetector(&guardduty.GetDetectorInput{
+			DetectorId: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if aws.StringValue(resp.Status) != status {
+			return fmt.Errorf("Expected GuardDuty Detector %s to be %s, got %s",
+				rs.Primary.ID, status, aws.StringValue(resp.Status))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSGuardDutyDetectorDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).guarddutyconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_guardduty_detector" {
+			continue
+		}
+
+		_, err := conn.GetDetector(&guardduty.GetDetectorInput{
+			DetectorId: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("GuardDuty Detector %s still exists", rs.Primary.ID)
+		}
+		if !isGuardDutyDetectorNotFoundErr(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSGuardDutyDetectorConfig(enable bool, frequency string) string {
+	return fmt.Sprintf(`
+resource "aws_guardduty_detector" "test" {
+  enable                       = %t
+  finding_publishing_frequency = "%s"
+}
+`, enable, frequency)
+}
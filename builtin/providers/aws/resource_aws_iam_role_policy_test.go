@@ -40,10 +40,41 @@ func TestAccAWSIAMRolePolicy_basic(t *testing.T) {
 					),
 				),
 			},
+			resource.TestStep{
+				Config: testAccIAMRolePolicyConfigUpdatePolicy(role, policy1, policy2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIAMRolePolicy(
+						"aws_iam_role.role",
+						"aws_iam_role_policy.bar",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_iam_role_policy.bar", "policy",
+						"{\"Statement\":{\"Action\":\"s3:*\",\"Effect\":\"Allow\",\"Resource\":\"*\"},\"Version\":\"2012-10-17\"}"),
+				),
+			},
 		},
 	})
 }
 
+func TestResourceAwsIamRolePolicyRead_noSuchEntity(t *testing.T) {
+	iamEndpoints := []*iamEndpoint{
+		&iamEndpoint{
+			Request:  &iamRequest{"POST", "/", "Action=GetRolePolicy&PolicyName=tf_test_policy&RoleName=tf_test_role&Version=2010-05-08"},
+			Response: &iamResponse{404, iamResponse_GetRolePolicy_noSuchEntity, "text/xml"},
+		},
+	}
+	ts, iamConn, _ := getMockedAwsIamStsApi(iamEndpoints)
+	defer ts()
+
+	d := resourceAwsIamRolePolicy().Data(&terraform.InstanceState{ID: "tf_test_role:tf_test_policy"})
+	if err := resourceAwsIamRolePolicyRead(d, &AWSClient{iamconn: iamConn}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected ID to be cleared for a missing inline policy, got: %q", d.Id())
+	}
+}
+
 func testAccCheckIAMRolePolicyDestroy(s *terraform.State) error {
 	iamconn := testAccProvider.Meta().(*AWSClient).iamconn
 
@@ -64,7 +95,7 @@ func testAccCheckIAMRolePolicyDestroy(s *terraform.State) error {
 		if err != nil {
 			if iamerr, ok := err.(awserr.Error); ok && iamerr.Code() == "NoSuchEntity" {
 				// none found, that's good
-				return nil
+				continue
 			}
 			return fmt.Errorf("Error reading IAM policy %s from role %s: %s", name, role, err)
 		}
@@ -147,3 +178,43 @@ resource "aws_iam_role_policy" "bar" {
 }
 `, role, policy1, policy2)
 }
+
+func testAccIAMRolePolicyConfigUpdatePolicy(role, policy1, policy2 string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "role" {
+	name = "tf_test_role_%s"
+	path = "/"
+	assume_role_policy = "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Action\":\"sts:AssumeRole\",\"Principal\":{\"Service\":\"ec2.amazonaws.com\"},\"Effect\":\"Allow\",\"Sid\":\"\"}]}"
+}
+
+resource "aws_iam_role_policy" "foo" {
+	name = "tf_test_policy_%s"
+	role = "${aws_iam_role.role.name}"
+	policy = "{\"Version\":\"2012-10-17\",\"Statement\":{\"Effect\":\"Allow\",\"Action\":\"*\",\"Resource\":\"*\"}}"
+}
+
+resource "aws_iam_role_policy" "bar" {
+	name = "tf_test_policy_2_%s"
+	role = "${aws_iam_role.role.name}"
+	policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": {
+    "Effect": "Allow",
+    "Action": "s3:*",
+    "Resource": "*"
+  }
+}
+EOF
+}
+`, role, policy1, policy2)
+}
+
+const iamResponse_GetRolePolicy_noSuchEntity = `<ErrorResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <Error>
+    <Type>Sender</Type>
+    <Code>NoSuchEntity</Code>
+    <Message>The role policy with name tf_test_policy cannot be found.</Message>
+  </Error>
+  <RequestId>7a62c49f-347e-4fc4-9331-6e8eEXAMPLE</RequestId>
+</ErrorResponse>`
@@ -27,6 +27,7 @@ func resourceAwsInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceAwsInstanceCustomizeDiff,
 
 		SchemaVersion: 1,
 		MigrateState:  resourceAwsInstanceMigrateState,
@@ -42,6 +43,7 @@ func resourceAwsInstance() *schema.Resource {
 				Type:     schema.TypeBool,
 				ForceNew: true,
 				Optional: true,
+				Computed: true,
 			},
 
 			"availability_zone": &schema.Schema{
@@ -85,27 +87,86 @@ func resourceAwsInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"ipv6_address_count": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				Computed: true,
+			},
+
+			"ipv6_addresses": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"hibernation": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"metadata_options": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"http_endpoint": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      ec2.InstanceMetadataEndpointStateEnabled,
+							ValidateFunc: validateInstanceMetadataHttpEndpoint,
+						},
+
+						"http_tokens": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      ec2.HttpTokensStateOptional,
+							ValidateFunc: validateInstanceMetadataHttpTokens,
+						},
+
+						"http_put_response_hop_limit": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validateIntegerInRange(1, 64),
+						},
+					},
+				},
+			},
+
 			"source_dest_check": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
 			},
 
+			// Changing user_data replaces the instance unless
+			// user_data_replace_on_change is false, see
+			// resourceAwsInstanceCustomizeDiff.
 			"user_data": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				StateFunc: func(v interface{}) string {
 					switch v.(type) {
 					case string:
-						hash := sha1.Sum([]byte(v.(string)))
-						return hex.EncodeToString(hash[:])
+						return userDataHashSum(v.(string))
 					default:
 						return ""
 					}
 				},
 			},
 
+			"user_data_replace_on_change": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
 			"security_groups": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -177,6 +238,20 @@ func resourceAwsInstance() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// Moving an instance to another dedicated host requires
+			// stopping it, which only happens with allow_stopping_for_update.
+			"host_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"allow_stopping_for_update": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"tags": tagsSchema(),
 
 			"block_device": &schema.Schema{
@@ -296,6 +371,13 @@ func resourceAwsInstance() *schema.Resource {
 							ForceNew: true,
 						},
 
+						"encrypted": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+
 						"iops": &schema.Schema{
 							Type:     schema.TypeInt,
 							Optional: true,
@@ -303,11 +385,12 @@ func resourceAwsInstance() *schema.Resource {
 							ForceNew: true,
 						},
 
+						// Growing the root volume is done in place with ModifyVolume,
+						// see resourceAwsInstanceResizeRootVolume.
 						"volume_size": &schema.Schema{
 							Type:     schema.TypeInt,
 							Optional: true,
 							Computed: true,
-							ForceNew: true,
 						},
 
 						"volume_type": &schema.Schema{
@@ -330,6 +413,13 @@ func resourceAwsInstance() *schema.Resource {
 func resourceAwsInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
+	hibernation := d.Get("hibernation").(bool)
+	if hibernation {
+		if err := validateAwsInstanceHibernation(d); err != nil {
+			return err
+		}
+	}
+
 	instanceOpts, err := buildAwsInstanceOpts(d, meta)
 	if err != nil {
 		return err
@@ -357,6 +447,20 @@ func resourceAwsInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 		UserData:                          instanceOpts.UserData64,
 	}
 
+	if hibernation {
+		runOpts.HibernationOptions = &ec2.HibernationOptionsRequest{
+			Configured: aws.Bool(true),
+		}
+	}
+
+	if v, ok := d.GetOk("host_id"); ok {
+		runOpts.Placement.HostId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("metadata_options"); ok {
+		runOpts.MetadataOptions = expandEc2InstanceMetadataOptions(v.([]interface{}))
+	}
+
 	// Create the instance
 	log.Printf("[DEBUG] Run configuration: %s", runOpts)
 
@@ -474,9 +578,25 @@ func resourceAwsInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	if instance.Placement != nil {
 		d.Set("availability_zone", instance.Placement.AvailabilityZone)
 	}
+	if instance.HibernationOptions != nil && instance.HibernationOptions.Configured != nil {
+		d.Set("hibernation", *instance.HibernationOptions.Configured)
+	} else {
+		d.Set("hibernation", false)
+	}
 	if instance.Placement.Tenancy != nil {
 		d.Set("tenancy", instance.Placement.Tenancy)
 	}
+	d.Set("host_id", instance.Placement.HostId)
+
+	// allow_stopping_for_update and user_data_replace_on_change only live
+	// in the configuration, default them when there's nothing to go on,
+	// e.g. on import.
+	if _, ok := d.GetOkExists("allow_stopping_for_update"); !ok {
+		d.Set("allow_stopping_for_update", false)
+	}
+	if _, ok := d.GetOkExists("user_data_replace_on_change"); !ok {
+		d.Set("user_data_replace_on_change", true)
+	}
 
 	d.Set("ami", instance.ImageId)
 	d.Set("instance_type", instance.InstanceType)
@@ -492,6 +612,27 @@ func resourceAwsInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	} else {
 		d.Set("subnet_id", instance.SubnetId)
 	}
+
+	// The IPv6 addresses are those of the primary network interface
+	var ipv6Addresses []string
+	for _, ni := range instance.NetworkInterfaces {
+		if ni.Attachment == nil || aws.Int64Value(ni.Attachment.DeviceIndex) != 0 {
+			continue
+		}
+		for _, a := range ni.Ipv6Addresses {
+			ipv6Addresses = append(ipv6Addresses, aws.StringValue(a.Ipv6Address))
+		}
+	}
+	if err := d.Set("ipv6_addresses", ipv6Addresses); err != nil {
+		return err
+	}
+	d.Set("ipv6_address_count", len(ipv6Addresses))
+
+	// A stopped instance gives up its public IP, so only a running instance
+	// tells us whether one was associated at launch.
+	if instance.State != nil && aws.StringValue(instance.State.Name) == "running" {
+		d.Set("associate_public_ip_address", instanceHasAmazonPublicIp(instance))
+	}
 	d.Set("ebs_optimized", instance.EbsOptimized)
 	if instance.SubnetId != nil && *instance.SubnetId != "" {
 		d.Set("source_dest_check", instance.SourceDestCheck)
@@ -502,6 +643,10 @@ func resourceAwsInstanceRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("monitoring", monitoringState == "enabled" || monitoringState == "pending")
 	}
 
+	if err := d.Set("metadata_options", flattenEc2InstanceMetadataOptions(instance.MetadataOptions)); err != nil {
+		return err
+	}
+
 	d.Set("tags", tagsToMap(instance.Tags))
 
 	// Determine whether we're referring to security groups with
@@ -577,6 +722,10 @@ func resourceAwsInstanceRead(d *schema.ResourceData, meta interface{}) error {
 func resourceAwsInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
+	if d.HasChange("host_id") && !d.Get("allow_stopping_for_update").(bool) {
+		return fmt.Errorf("Changing host_id of instance %s requires stopping it; set allow_stopping_for_update to allow this", d.Id())
+	}
+
 	d.Partial(true)
 	if err := setTags(conn, d); err != nil {
 		return err
@@ -664,6 +813,36 @@ func resourceAwsInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.HasChange("root_block_device.0.volume_size") {
+		if err := resourceAwsInstanceResizeRootVolume(conn, d); err != nil {
+			return err
+		}
+		d.SetPartial("root_block_device")
+	}
+
+	if d.HasChange("host_id") {
+		if err := resourceAwsInstanceChangeHost(conn, d); err != nil {
+			return err
+		}
+		d.SetPartial("host_id")
+	}
+
+	if d.HasChange("metadata_options") {
+		if err := resourceAwsInstanceChangeMetadataOptions(conn, d); err != nil {
+			return err
+		}
+		d.SetPartial("metadata_options")
+	}
+
+	// user_data only changes in place with user_data_replace_on_change
+	// disabled, otherwise the instance is replaced.
+	if d.HasChange("user_data") {
+		if err := resourceAwsInstanceChangeUserData(conn, d); err != nil {
+			return err
+		}
+		d.SetPartial("user_data")
+	}
+
 	// TODO(mitchellh): wait for the attributes we modified to
 	// persist the change...
 
@@ -711,6 +890,22 @@ func InstanceStateRefreshFunc(conn *ec2.EC2, instanceID string) resource.StateRe
 	}
 }
 
+// instanceHasAmazonPublicIp reports whether the primary network interface
+// of the instance has a public IP assigned by Amazon, as opposed to an
+// Elastic IP associated later on.
+func instanceHasAmazonPublicIp(instance *ec2.Instance) bool {
+	for _, ni := range instance.NetworkInterfaces {
+		if ni.Attachment == nil || aws.Int64Value(ni.Attachment.DeviceIndex) != 0 {
+			continue
+		}
+		return ni.Association != nil &&
+			ni.Association.PublicIp != nil &&
+			aws.StringValue(ni.Association.IpOwnerId) == "amazon"
+	}
+
+	return false
+}
+
 func readBlockDevices(d *schema.ResourceData, instance *ec2.Instance, conn *ec2.EC2) error {
 	ibds, err := readBlockDevicesFromInstance(instance, conn)
 	if err != nil {
@@ -784,6 +979,9 @@ func readBlockDevicesFromInstance(instance *ec2.Instance, conn *ec2.EC2) (map[st
 		if vol.Iops != nil {
 			bd["iops"] = *vol.Iops
 		}
+		if vol.Encrypted != nil {
+			bd["encrypted"] = *vol.Encrypted
+		}
 
 		if blockDeviceIsRoot(instanceBd, instance) {
 			blockDevices["root"] = bd
@@ -791,9 +989,6 @@ func readBlockDevicesFromInstance(instance *ec2.Instance, conn *ec2.EC2) (map[st
 			if instanceBd.DeviceName != nil {
 				bd["device_name"] = *instanceBd.DeviceName
 			}
-			if vol.Encrypted != nil {
-				bd["encrypted"] = *vol.Encrypted
-			}
 			if vol.SnapshotId != nil {
 				bd["snapshot_id"] = *vol.SnapshotId
 			}
@@ -934,6 +1129,10 @@ func readBlockDeviceMappingsFromConfig(
 				ebs.Iops = aws.Int64(int64(v))
 			}
 
+			if v, ok := bd["encrypted"].(bool); ok && v {
+				ebs.Encrypted = aws.Bool(v)
+			}
+
 			if dn, err := fetchRootDeviceName(d.Get("ami").(string), conn); err == nil {
 				if dn == nil {
 					return nil, fmt.Errorf(
@@ -954,6 +1153,113 @@ func readBlockDeviceMappingsFromConfig(
 	return blockDevices, nil
 }
 
+// validateAwsInstanceHibernation checks that an instance launched with
+// hibernation enabled has an encrypted root volume, which EC2 requires in
+// order to write the memory contents to it.
+func validateAwsInstanceHibernation(d *schema.ResourceData) error {
+	if v, ok := d.GetOk("root_block_device"); ok {
+		for _, v := range v.(*schema.Set).List() {
+			bd := v.(map[string]interface{})
+			if encrypted, ok := bd["encrypted"].(bool); ok && encrypted {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("hibernation requires an encrypted root_block_device")
+}
+
+// validateAwsInstanceRootVolumeSize checks that a change of the root volume
+// size can be done in place. EBS volumes can only ever grow.
+func validateAwsInstanceRootVolumeSize(oldSize, newSize int) error {
+	if newSize < oldSize {
+		return fmt.Errorf(
+			"root_block_device.0.volume_size can't be decreased from %d to %d GiB, EBS volumes can only grow",
+			oldSize, newSize)
+	}
+	return nil
+}
+
+// resourceAwsInstanceResizeRootVolume grows the root EBS volume of a
+// running instance to the configured volume_size and waits for the
+// modification to take effect.
+func resourceAwsInstanceResizeRootVolume(conn *ec2.EC2, d *schema.ResourceData) error {
+	o, n := d.GetChange("root_block_device.0.volume_size")
+	oldSize, newSize := o.(int), n.(int)
+	if err := validateAwsInstanceRootVolumeSize(oldSize, newSize); err != nil {
+		return err
+	}
+
+	resp, err := conn.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return fmt.Errorf("Error resizing root volume: instance %s not found", d.Id())
+	}
+
+	instance := resp.Reservations[0].Instances[0]
+	var volumeId string
+	for _, bd := range instance.BlockDeviceMappings {
+		if bd.Ebs != nil && blockDeviceIsRoot(bd, instance) {
+			volumeId = *bd.Ebs.VolumeId
+		}
+	}
+	if volumeId == "" {
+		return fmt.Errorf("Error resizing root volume: instance %s has no EBS root volume", d.Id())
+	}
+
+	log.Printf("[INFO] Resizing root volume %s of instance %s from %d to %d GiB",
+		volumeId, d.Id(), oldSize, newSize)
+	_, err = conn.ModifyVolume(&ec2.ModifyVolumeInput{
+		VolumeId: aws.String(volumeId),
+		Size:     aws.Int64(int64(newSize)),
+	})
+	if err != nil {
+		return fmt.Errorf("Error resizing root volume %s: %s", volumeId, err)
+	}
+
+	// The new size is usable as soon as the volume is optimizing, which can
+	// itself take hours for large volumes, so that's where waiting stops.
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.VolumeModificationStateModifying},
+		Target: []string{
+			ec2.VolumeModificationStateOptimizing,
+			ec2.VolumeModificationStateCompleted,
+		},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := conn.DescribeVolumesModifications(&ec2.DescribeVolumesModificationsInput{
+				VolumeIds: []*string{aws.String(volumeId)},
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			if len(resp.VolumesModifications) == 0 {
+				return nil, "", fmt.Errorf("No modification found for volume %s", volumeId)
+			}
+
+			m := resp.VolumesModifications[0]
+			state := aws.StringValue(m.ModificationState)
+			if state == ec2.VolumeModificationStateFailed {
+				return nil, state, fmt.Errorf("Modification of volume %s failed: %s",
+					volumeId, aws.StringValue(m.StatusMessage))
+			}
+			return m, state, nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for root volume %s to be resized: %s", volumeId, err)
+	}
+
+	return nil
+}
+
 type awsInstanceOpts struct {
 	BlockDeviceMappings               []*ec2.BlockDeviceMapping
 	DisableAPITermination             *bool
@@ -974,6 +1280,191 @@ type awsInstanceOpts struct {
 	UserData64                        *string
 }
 
+// resourceAwsInstanceChangeHost moves the instance to the configured
+// dedicated host. The placement of an instance can only be modified while it
+// is stopped, so the instance is stopped around the call and started again.
+func resourceAwsInstanceChangeHost(conn *ec2.EC2, d *schema.ResourceData) error {
+	hostId := d.Get("host_id").(string)
+
+	log.Printf("[INFO] Stopping instance %s to move it to host %s", d.Id(), hostId)
+	return stopModifyStartAwsInstance(conn, d.Id(), func() error {
+		input := &ec2.ModifyInstancePlacementInput{
+			InstanceId: aws.String(d.Id()),
+		}
+		if hostId != "" {
+			input.HostId = aws.String(hostId)
+			input.Affinity = aws.String(ec2.AffinityHost)
+		}
+		log.Printf("[DEBUG] Modifying instance placement: %s", input)
+		if _, err := conn.ModifyInstancePlacement(input); err != nil {
+			return fmt.Errorf("Error modifying placement of instance %s: %s", d.Id(), err)
+		}
+		return nil
+	})
+}
+
+// resourceAwsInstanceChangeUserData replaces the user data of the instance,
+// which EC2 only allows while the instance is stopped.
+func resourceAwsInstanceChangeUserData(conn *ec2.EC2, d *schema.ResourceData) error {
+	userData := []byte(d.Get("user_data").(string))
+
+	// Like on create, user_data may already be Base64 encoded. The SDK
+	// encodes the attribute value itself.
+	if decoded, err := base64.StdEncoding.DecodeString(string(userData)); err == nil {
+		userData = decoded
+	}
+
+	log.Printf("[INFO] Stopping instance %s to change its user data", d.Id())
+	return stopModifyStartAwsInstance(conn, d.Id(), func() error {
+		_, err := conn.ModifyInstanceAttribute(&ec2.ModifyInstanceAttributeInput{
+			InstanceId: aws.String(d.Id()),
+			UserData: &ec2.BlobAttributeValue{
+				Value: userData,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Error modifying user data of instance %s: %s", d.Id(), err)
+		}
+		return nil
+	})
+}
+
+// stopModifyStartAwsInstance stops instance id, calls modify once it is
+// stopped, and starts the instance again.
+func stopModifyStartAwsInstance(conn *ec2.EC2, id string, modify func() error) error {
+	_, err := conn.StopInstances(&ec2.StopInstancesInput{
+		InstanceIds: []*string{aws.String(id)},
+	})
+	if err != nil {
+		return fmt.Errorf("Error stopping instance %s: %s", id, err)
+	}
+	if err := waitForAwsInstanceState(conn, id, []string{"pending", "running", "stopping"}, "stopped"); err != nil {
+		return fmt.Errorf("Error waiting for instance %s to stop: %s", id, err)
+	}
+
+	if err := modify(); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Starting instance %s", id)
+	_, err = conn.StartInstances(&ec2.StartInstancesInput{
+		InstanceIds: []*string{aws.String(id)},
+	})
+	if err != nil {
+		return fmt.Errorf("Error starting instance %s: %s", id, err)
+	}
+	if err := waitForAwsInstanceState(conn, id, []string{"pending", "stopped"}, "running"); err != nil {
+		return fmt.Errorf("Error waiting for instance %s to start: %s", id, err)
+	}
+
+	return nil
+}
+
+// userDataHashSum returns the form user_data is kept in the state in.
+func userDataHashSum(userData string) string {
+	hash := sha1.Sum([]byte(userData))
+	return hex.EncodeToString(hash[:])
+}
+
+// resourceAwsInstanceCustomizeDiff replaces the instance when its user data
+// changes, unless user_data_replace_on_change is disabled.
+func resourceAwsInstanceCustomizeDiff(d *schema.ResourceDiff) error {
+	if d.HasChange("user_data") && d.Get("user_data_replace_on_change").(bool) {
+		return d.ForceNew("user_data")
+	}
+	return nil
+}
+
+// resourceAwsInstanceChangeMetadataOptions applies the configured
+// metadata_options and waits for the instance to pick them up, which
+// doesn't require a stop.
+func resourceAwsInstanceChangeMetadataOptions(conn *ec2.EC2, d *schema.ResourceData) error {
+	opts := expandEc2InstanceMetadataOptions(d.Get("metadata_options").([]interface{}))
+	if opts == nil {
+		return nil
+	}
+
+	log.Printf("[INFO] Modifying metadata options of instance %s: %s", d.Id(), opts)
+	_, err := conn.ModifyInstanceMetadataOptions(&ec2.ModifyInstanceMetadataOptionsInput{
+		InstanceId:              aws.String(d.Id()),
+		HttpEndpoint:            opts.HttpEndpoint,
+		HttpTokens:              opts.HttpTokens,
+		HttpPutResponseHopLimit: opts.HttpPutResponseHopLimit,
+	})
+	if err != nil {
+		return fmt.Errorf("Error modifying metadata options of instance %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.InstanceMetadataOptionsStatePending},
+		Target:  []string{ec2.InstanceMetadataOptionsStateApplied},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := conn.DescribeInstances(&ec2.DescribeInstancesInput{
+				InstanceIds: []*string{aws.String(d.Id())},
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+				return nil, "", fmt.Errorf("Instance %s not found", d.Id())
+			}
+			mo := resp.Reservations[0].Instances[0].MetadataOptions
+			if mo == nil {
+				return 42, ec2.InstanceMetadataOptionsStateApplied, nil
+			}
+			return mo, aws.StringValue(mo.State), nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for metadata options of instance %s to be applied: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandEc2InstanceMetadataOptions(l []interface{}) *ec2.InstanceMetadataOptionsRequest {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	return &ec2.InstanceMetadataOptionsRequest{
+		HttpEndpoint:            aws.String(m["http_endpoint"].(string)),
+		HttpTokens:              aws.String(m["http_tokens"].(string)),
+		HttpPutResponseHopLimit: aws.Int64(int64(m["http_put_response_hop_limit"].(int))),
+	}
+}
+
+func flattenEc2InstanceMetadataOptions(opts *ec2.InstanceMetadataOptionsResponse) []interface{} {
+	if opts == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"http_endpoint":               aws.StringValue(opts.HttpEndpoint),
+			"http_tokens":                 aws.StringValue(opts.HttpTokens),
+			"http_put_response_hop_limit": int(aws.Int64Value(opts.HttpPutResponseHopLimit)),
+		},
+	}
+}
+
+func waitForAwsInstanceState(conn *ec2.EC2, id string, pending []string, target string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     []string{target},
+		Refresh:    InstanceStateRefreshFunc(conn, id),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
 func buildAwsInstanceOpts(
 	d *schema.ResourceData, meta interface{}) (*awsInstanceOpts, error) {
 	conn := meta.(*AWSClient).ec2conn
@@ -1028,7 +1519,15 @@ func buildAwsInstanceOpts(
 		opts.Placement.Tenancy = aws.String(v)
 	}
 
-	associatePublicIPAddress := d.Get("associate_public_ip_address").(bool)
+	// An explicit false is meaningful here: it overrides a subnet that maps
+	// public IPs on launch, so it has to be told apart from not being set.
+	var associatePublicIPAddress *bool
+	if v, ok := d.GetOkExists("associate_public_ip_address"); ok {
+		associatePublicIPAddress = aws.Bool(v.(bool))
+		if !hasSubnet {
+			log.Printf("[WARN] associate_public_ip_address is only used for VPC instances with a subnet_id, ignoring")
+		}
+	}
 
 	var groups []*string
 	if v := d.Get("security_groups"); v != nil {
@@ -1045,19 +1544,43 @@ func buildAwsInstanceOpts(
 		}
 	}
 
-	if hasSubnet && associatePublicIPAddress {
+	// IPv6 addresses can only be requested for a network interface
+	var ipv6AddressCount *int64
+	var ipv6Addresses []*ec2.InstanceIpv6Address
+	if v, ok := d.GetOk("ipv6_address_count"); ok {
+		ipv6AddressCount = aws.Int64(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("ipv6_addresses"); ok {
+		for _, address := range v.([]interface{}) {
+			ipv6Addresses = append(ipv6Addresses, &ec2.InstanceIpv6Address{
+				Ipv6Address: aws.String(address.(string)),
+			})
+		}
+	}
+	if ipv6AddressCount != nil && len(ipv6Addresses) > 0 {
+		return nil, fmt.Errorf("Only one of ipv6_address_count or ipv6_addresses can be specified")
+	}
+	requestIpv6 := ipv6AddressCount != nil || len(ipv6Addresses) > 0
+	if requestIpv6 && !hasSubnet {
+		return nil, fmt.Errorf("ipv6_address_count and ipv6_addresses can only be used for VPC instances with a subnet_id")
+	}
+
+	if hasSubnet && (associatePublicIPAddress != nil || requestIpv6) {
 		// If we have a non-default VPC / Subnet specified, we can flag
-		// AssociatePublicIpAddress to get a Public IP assigned. By default these are not provided.
+		// AssociatePublicIpAddress to get a Public IP assigned, or to withhold
+		// one the subnet would otherwise map. By default the subnet decides.
 		// You cannot specify both SubnetId and the NetworkInterface.0.* parameters though, otherwise
 		// you get: Network interfaces and an instance-level subnet ID may not be specified on the same request
 		// You also need to attach Security Groups to the NetworkInterface instead of the instance,
 		// to avoid: Network interfaces and an instance-level security groups may not be specified on
 		// the same request
 		ni := &ec2.InstanceNetworkInterfaceSpecification{
-			AssociatePublicIpAddress: aws.Bool(associatePublicIPAddress),
+			AssociatePublicIpAddress: associatePublicIPAddress,
 			DeviceIndex:              aws.Int64(int64(0)),
 			SubnetId:                 aws.String(subnetID),
 			Groups:                   groups,
+			Ipv6AddressCount:         ipv6AddressCount,
+			Ipv6Addresses:            ipv6Addresses,
 		}
 
 		if v, ok := d.GetOk("private_ip"); ok {
@@ -2,12 +2,15 @@ package aws
 
 import (
 	"fmt"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -422,6 +425,356 @@ func TestAccAWSInstance_tags(t *testing.T) {
 	})
 }
 
+func TestAccAWSInstance_monitoring(t *testing.T) {
+	var before, after ec2.Instance
+
+	// Changes in monitoring state take a moment to settle, so the
+	// transitional state is accepted as well.
+	testCheckMonitoring := func(v *ec2.Instance, states ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if v.Monitoring == nil || v.Monitoring.State == nil {
+				return fmt.Errorf("No monitoring state for instance")
+			}
+			for _, state := range states {
+				if *v.Monitoring.State == state {
+					return nil
+				}
+			}
+			return fmt.Errorf("Expected monitoring state to be one of %v, got %s", states, *v.Monitoring.State)
+		}
+	}
+
+	testCheckNotRecreated := func(*terraform.State) error {
+		if *before.InstanceId != *after.InstanceId {
+			return fmt.Errorf("Instance was recreated: %s != %s", *before.InstanceId, *after.InstanceId)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigMonitoring(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &before),
+					testCheckMonitoring(&before, "enabled", "pending"),
+					resource.TestCheckResourceAttr("aws_instance.foo", "monitoring", "true"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccInstanceConfigMonitoring(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &after),
+					testCheckMonitoring(&after, "disabled", "disabling"),
+					testCheckNotRecreated,
+					resource.TestCheckResourceAttr("aws_instance.foo", "monitoring", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSInstance_hibernation(t *testing.T) {
+	var v ec2.Instance
+
+	testCheckHibernation := func(*terraform.State) error {
+		if v.HibernationOptions == nil || v.HibernationOptions.Configured == nil || !*v.HibernationOptions.Configured {
+			return fmt.Errorf("Expected hibernation to be configured, got: %#v", v.HibernationOptions)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigHibernation,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &v),
+					testCheckHibernation,
+					resource.TestCheckResourceAttr("aws_instance.foo", "hibernation", "true"),
+					resource.TestCheckResourceAttr("aws_instance.foo", "root_block_device.0.encrypted", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSInstance_metadataOptions(t *testing.T) {
+	var before, after ec2.Instance
+
+	testCheckNotRecreated := func(*terraform.State) error {
+		if *before.InstanceId != *after.InstanceId {
+			return fmt.Errorf("Instance was recreated: %s != %s", *before.InstanceId, *after.InstanceId)
+		}
+		return nil
+	}
+
+	testCheckTokensRequired := func(*terraform.State) error {
+		if after.MetadataOptions == nil || aws.StringValue(after.MetadataOptions.HttpTokens) != ec2.HttpTokensStateRequired {
+			return fmt.Errorf("Expected IMDSv2 to be required, got: %s", after.MetadataOptions)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigMetadataOptions("optional", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &before),
+					resource.TestCheckResourceAttr("aws_instance.foo", "metadata_options.0.http_endpoint", "enabled"),
+					resource.TestCheckResourceAttr("aws_instance.foo", "metadata_options.0.http_tokens", "optional"),
+					resource.TestCheckResourceAttr("aws_instance.foo", "metadata_options.0.http_put_response_hop_limit", "1"),
+				),
+			},
+
+			// Enforcing IMDSv2 has to happen in place.
+			resource.TestStep{
+				Config: testAccInstanceConfigMetadataOptions("required", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &after),
+					testCheckNotRecreated,
+					testCheckTokensRequired,
+					resource.TestCheckResourceAttr("aws_instance.foo", "metadata_options.0.http_tokens", "required"),
+					resource.TestCheckResourceAttr("aws_instance.foo", "metadata_options.0.http_put_response_hop_limit", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateAwsInstanceHibernation(t *testing.T) {
+	cases := []struct {
+		Encrypted bool
+		ErrCount  int
+	}{
+		{Encrypted: true, ErrCount: 0},
+		{Encrypted: false, ErrCount: 1},
+	}
+
+	for _, tc := range cases {
+		d := resourceAwsInstance().TestResourceData()
+		d.Set("hibernation", true)
+		d.Set("root_block_device", []interface{}{
+			map[string]interface{}{
+				"delete_on_termination": true,
+				"encrypted":             tc.Encrypted,
+			},
+		})
+
+		err := validateAwsInstanceHibernation(d)
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("Expected an encrypted root volume to pass validation, got: %s", err)
+		}
+		if tc.ErrCount > 0 && err == nil {
+			t.Fatalf("Expected an unencrypted root volume to fail validation")
+		}
+	}
+}
+
+func TestAccAWSInstance_rootBlockDeviceResize(t *testing.T) {
+	var before, after ec2.Instance
+
+	testCheckNotRecreated := func(*terraform.State) error {
+		if *before.InstanceId != *after.InstanceId {
+			return fmt.Errorf("Instance was recreated: %s != %s", *before.InstanceId, *after.InstanceId)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigRootBlockDeviceSize(10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &before),
+					resource.TestCheckResourceAttr("aws_instance.foo", "root_block_device.0.volume_size", "10"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccInstanceConfigRootBlockDeviceSize(20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &after),
+					testCheckNotRecreated,
+					resource.TestCheckResourceAttr("aws_instance.foo", "root_block_device.0.volume_size", "20"),
+				),
+			},
+		},
+	})
+}
+
+// Moving instances between dedicated hosts needs two hosts of the same
+// instance family in one availability zone, which the acceptance test can't
+// allocate on its own.
+func testAccEc2DedicatedHostsPreCheck(t *testing.T) (string, string, string) {
+	hostIds := strings.Split(os.Getenv("EC2_DEDICATED_HOST_IDS"), ",")
+	instanceType := os.Getenv("EC2_DEDICATED_HOST_INSTANCE_TYPE")
+	if len(hostIds) != 2 || instanceType == "" {
+		t.Skip("Environment variables EC2_DEDICATED_HOST_IDS and EC2_DEDICATED_HOST_INSTANCE_TYPE must be set to two dedicated hosts and an instance type they support")
+	}
+	return hostIds[0], hostIds[1], instanceType
+}
+
+func TestAccAWSInstance_changeHost(t *testing.T) {
+	var before, after ec2.Instance
+	hostId, otherHostId, instanceType := testAccEc2DedicatedHostsPreCheck(t)
+
+	testCheckNotRecreated := func(*terraform.State) error {
+		if *before.InstanceId != *after.InstanceId {
+			return fmt.Errorf("Instance was recreated: %s != %s", *before.InstanceId, *after.InstanceId)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigHost(instanceType, hostId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &before),
+					resource.TestCheckResourceAttr("aws_instance.foo", "host_id", hostId),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccInstanceConfigHost(instanceType, otherHostId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &after),
+					testCheckNotRecreated,
+					resource.TestCheckResourceAttr("aws_instance.foo", "host_id", otherHostId),
+					resource.TestCheckResourceAttr("aws_instance.foo", "instance_state", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSInstance_userDataInPlace(t *testing.T) {
+	var before, after ec2.Instance
+
+	testCheckNotRecreated := func(*terraform.State) error {
+		if *before.InstanceId != *after.InstanceId {
+			return fmt.Errorf("Instance was recreated: %s != %s", *before.InstanceId, *after.InstanceId)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInstanceConfigUserDataInPlace("foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &before),
+					resource.TestCheckResourceAttr("aws_instance.foo", "user_data_replace_on_change", "false"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccInstanceConfigUserDataInPlace("bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &after),
+					testCheckNotRecreated,
+					resource.TestCheckResourceAttr("aws_instance.foo", "user_data", userDataHashSum("bar")),
+					resource.TestCheckResourceAttr("aws_instance.foo", "instance_state", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsInstanceCustomizeDiff_userData(t *testing.T) {
+	cases := map[string]struct {
+		ReplaceOnChange bool
+		RequiresNew     bool
+	}{
+		"replace": {
+			ReplaceOnChange: true,
+			RequiresNew:     true,
+		},
+		"in place": {
+			ReplaceOnChange: false,
+			RequiresNew:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		state := &terraform.InstanceState{
+			ID: "i-abcd1234",
+			Attributes: map[string]string{
+				"id":                          "i-abcd1234",
+				"ami":                         "ami-55a7ea65",
+				"instance_type":               "t2.micro",
+				"user_data":                   userDataHashSum("foo"),
+				"user_data_replace_on_change": fmt.Sprintf("%t", tc.ReplaceOnChange),
+			},
+		}
+
+		c, err := config.NewRawConfig(map[string]interface{}{
+			"ami":                         "ami-55a7ea65",
+			"instance_type":               "t2.micro",
+			"user_data":                   "bar",
+			"user_data_replace_on_change": tc.ReplaceOnChange,
+		})
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+
+		diff, err := resourceAwsInstance().Diff(state, terraform.NewResourceConfig(c))
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+
+		attr, ok := diff.Attributes["user_data"]
+		if !ok {
+			t.Fatalf("%s: expected a user_data diff, got: %#v", name, diff)
+		}
+		if attr.RequiresNew != tc.RequiresNew {
+			t.Fatalf("%s: expected user_data RequiresNew %t, got: %#v", name, tc.RequiresNew, attr)
+		}
+	}
+}
+
+func TestValidateAwsInstanceRootVolumeSize(t *testing.T) {
+	cases := []struct {
+		Old, New int
+		ErrCount int
+	}{
+		{Old: 10, New: 20, ErrCount: 0},
+		{Old: 10, New: 10, ErrCount: 0},
+		{Old: 20, New: 10, ErrCount: 1},
+	}
+
+	for _, tc := range cases {
+		err := validateAwsInstanceRootVolumeSize(tc.Old, tc.New)
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("Expected resizing from %d to %d to pass validation, got: %s", tc.Old, tc.New, err)
+		}
+		if tc.ErrCount > 0 && err == nil {
+			t.Fatalf("Expected resizing from %d to %d to fail validation", tc.Old, tc.New)
+		}
+	}
+}
+
 func TestAccAWSInstance_privateIP(t *testing.T) {
 	var v ec2.Instance
 
@@ -483,6 +836,99 @@ func TestAccAWSInstance_associatePublicIPAndPrivateIP(t *testing.T) {
 	})
 }
 
+func TestAccAWSInstance_associatePublicIP(t *testing.T) {
+	var v ec2.Instance
+
+	testCheckPublicIP := func(expected bool) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if got := v.PublicIpAddress != nil; got != expected {
+				return fmt.Errorf("Expected public IP to be associated: %t, got: %s", expected, aws.StringValue(v.PublicIpAddress))
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "aws_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			// Force a public IP in a subnet that doesn't map one on launch
+			resource.TestStep{
+				Config: testAccInstanceConfigAssociatePublicIP(false, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &v),
+					testCheckPublicIP(true),
+					resource.TestCheckResourceAttr("aws_instance.foo", "associate_public_ip_address", "true"),
+				),
+			},
+
+			// Withhold the public IP in a subnet that maps one on launch
+			resource.TestStep{
+				Config: testAccInstanceConfigAssociatePublicIP(true, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("aws_instance.foo", &v),
+					testCheckPublicIP(false),
+					resource.TestCheckResourceAttr("aws_instance.foo", "associate_public_ip_address", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestInstanceHasAmazonPublicIp(t *testing.T) {
+	primary := func(ownerId string) *ec2.InstanceNetworkInterface {
+		ni := &ec2.InstanceNetworkInterface{
+			Attachment: &ec2.InstanceNetworkInterfaceAttachment{
+				DeviceIndex: aws.Int64(0),
+			},
+		}
+		if ownerId != "" {
+			ni.Association = &ec2.InstanceNetworkInterfaceAssociation{
+				IpOwnerId: aws.String(ownerId),
+				PublicIp:  aws.String("203.0.113.10"),
+			}
+		}
+		return ni
+	}
+
+	cases := []struct {
+		Instance *ec2.Instance
+		Expected bool
+	}{
+		{
+			Instance: &ec2.Instance{},
+			Expected: false,
+		},
+		{
+			Instance: &ec2.Instance{
+				NetworkInterfaces: []*ec2.InstanceNetworkInterface{primary("amazon")},
+			},
+			Expected: true,
+		},
+		{
+			// Elastic IPs are owned by the account
+			Instance: &ec2.Instance{
+				NetworkInterfaces: []*ec2.InstanceNetworkInterface{primary("123456789012")},
+			},
+			Expected: false,
+		},
+		{
+			Instance: &ec2.Instance{
+				NetworkInterfaces: []*ec2.InstanceNetworkInterface{primary("")},
+			},
+			Expected: false,
+		},
+	}
+
+	for i, tc := range cases {
+		if got := instanceHasAmazonPublicIp(tc.Instance); got != tc.Expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.Expected, got)
+		}
+	}
+}
+
 // Guard against regression with KeyPairs
 // https://github.com/hashicorp/terraform/issues/2302
 func TestAccAWSInstance_keyPairCheck(t *testing.T) {
@@ -668,6 +1114,21 @@ func testAccCheckInstanceExistsWithProviders(n string, i *ec2.Instance, provider
 	}
 }
 
+func TestInstanceHostIdSchema(t *testing.T) {
+	actualSchema := resourceAwsInstance().Schema["host_id"]
+	expectedSchema := &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	if !reflect.DeepEqual(actualSchema, expectedSchema) {
+		t.Fatalf(
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			actualSchema,
+			expectedSchema)
+	}
+}
+
 func TestInstanceTenancySchema(t *testing.T) {
 	actualSchema := resourceAwsInstance().Schema["tenancy"]
 	expectedSchema := &schema.Schema{
@@ -883,6 +1344,17 @@ resource "aws_instance" "bar" {
 }
 `
 
+func testAccInstanceConfigMonitoring(monitoring bool) string {
+	return fmt.Sprintf(`
+resource "aws_instance" "foo" {
+	# us-west-2
+	ami = "ami-4fccb37f"
+	instance_type = "m1.small"
+	monitoring = %t
+}
+`, monitoring)
+}
+
 const testAccCheckInstanceConfigTags = `
 resource "aws_instance" "foo" {
 	ami = "ami-4fccb37f"
@@ -940,6 +1412,27 @@ resource "aws_instance" "foo" {
 }
 `
 
+func testAccInstanceConfigAssociatePublicIP(mapPublicIP, associatePublicIP bool) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_subnet" "foo" {
+	cidr_block = "10.1.1.0/24"
+	vpc_id = "${aws_vpc.foo.id}"
+	map_public_ip_on_launch = %t
+}
+
+resource "aws_instance" "foo" {
+	ami = "ami-c5eabbf5"
+	instance_type = "t2.micro"
+	subnet_id = "${aws_subnet.foo.id}"
+	associate_public_ip_address = %t
+}
+`, mapPublicIP, associatePublicIP)
+}
+
 const testAccInstanceNetworkInstanceSecurityGroups = `
 resource "aws_internet_gateway" "gw" {
   vpc_id = "${aws_vpc.foo.id}"
@@ -1102,3 +1595,73 @@ resource "aws_instance" "foo" {
 	subnet_id = "${aws_subnet.foo.id}"
 }
 `
+
+func testAccInstanceConfigRootBlockDeviceSize(size int) string {
+	return fmt.Sprintf(`
+resource "aws_instance" "foo" {
+	# us-west-2
+	ami = "ami-55a7ea65"
+	instance_type = "m3.medium"
+
+	root_block_device {
+		volume_type = "gp2"
+		volume_size = %d
+	}
+}
+`, size)
+}
+
+func testAccInstanceConfigHost(instanceType, hostId string) string {
+	return fmt.Sprintf(`
+resource "aws_instance" "foo" {
+	# us-west-2
+	ami = "ami-55a7ea65"
+	instance_type = "%s"
+	tenancy = "host"
+	host_id = "%s"
+	allow_stopping_for_update = true
+}
+`, instanceType, hostId)
+}
+
+func testAccInstanceConfigUserDataInPlace(userData string) string {
+	return fmt.Sprintf(`
+resource "aws_instance" "foo" {
+	# us-west-2
+	ami = "ami-4fccb37f"
+	instance_type = "m1.small"
+	user_data = "%s"
+	user_data_replace_on_change = false
+}
+`, userData)
+}
+
+func testAccInstanceConfigMetadataOptions(httpTokens string, hopLimit int) string {
+	return fmt.Sprintf(`
+resource "aws_instance" "foo" {
+	# us-west-2
+	ami = "ami-4fccb37f"
+	instance_type = "m1.small"
+
+	metadata_options {
+		http_endpoint = "enabled"
+		http_tokens = "%s"
+		http_put_response_hop_limit = %d
+	}
+}
+`, httpTokens, hopLimit)
+}
+
+const testAccInstanceConfigHibernation = `
+resource "aws_instance" "foo" {
+	# us-west-2, Amazon Linux 2 (hibernation capable)
+	ami = "ami-0cb72367e98845d43"
+	instance_type = "m5.large"
+	hibernation = true
+
+	root_block_device {
+		volume_size = 20
+		encrypted = true
+	}
+}
+`
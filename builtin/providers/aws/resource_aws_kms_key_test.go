@@ -126,6 +126,61 @@ func testAccCheckAWSKmsKeyExists(name string, key *kms.KeyMetadata) resource.Tes
 	}
 }
 
+func TestAccAWSKmsKey_rotation(t *testing.T) {
+	var before, after kms.KeyMetadata
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSKmsKeyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSKmsKey_rotation(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSKmsKeyExists("aws_kms_key.rotation", &before),
+					testAccCheckAWSKmsKeyHasRotation(&before, true),
+					resource.TestCheckResourceAttr("aws_kms_key.rotation", "enable_key_rotation", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSKmsKey_rotation(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSKmsKeyExists("aws_kms_key.rotation", &after),
+					testAccCheckAWSKmsKeyHasRotation(&after, false),
+					resource.TestCheckResourceAttr("aws_kms_key.rotation", "enable_key_rotation", "false"),
+					resource.TestCheckResourceAttr("aws_kms_key.rotation", "is_enabled", "true"),
+					func(*terraform.State) error {
+						if *before.Arn != *after.Arn {
+							return fmt.Errorf("Expected key %q to be updated in place, got %q", *before.Arn, *after.Arn)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSKmsKeyHasRotation(key *kms.KeyMetadata, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).kmsconn
+
+		resp, err := conn.GetKeyRotationStatus(&kms.GetKeyRotationStatusInput{
+			KeyId: key.KeyId,
+		})
+		if err != nil {
+			return err
+		}
+
+		if *resp.KeyRotationEnabled != expected {
+			return fmt.Errorf("Expected key %q to have key rotation %t, given %t",
+				*key.Arn, expected, *resp.KeyRotationEnabled)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAWSKmsKeyIsEnabled(key *kms.KeyMetadata, isEnabled bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if *key.Enabled != isEnabled {
@@ -187,3 +242,12 @@ resource "aws_kms_key" "bar" {
     enable_key_rotation = true
     is_enabled = true
 }`, kmsTimestamp)
+
+func testAccAWSKmsKey_rotation(enabled bool) string {
+	return fmt.Sprintf(`
+resource "aws_kms_key" "rotation" {
+    description = "Terraform acc test rotation %s"
+    deletion_window_in_days = 7
+    enable_key_rotation = %t
+}`, kmsTimestamp, enabled)
+}
@@ -52,11 +52,11 @@ func resourceAwsLaunchConfiguration() *schema.Resource {
 				ForceNew: true,
 				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
 					// https://github.com/boto/botocore/blob/9f322b1/botocore/data/autoscaling/2011-01-01/service-2.json#L1932-L1939
-					// uuid is 26 characters, limit the prefix to 229.
+					// The unique suffix is 26 characters, limit the prefix to 229.
 					value := v.(string)
-					if len(value) > 229 {
+					if len(value) > 255-resource.UniqueIDSuffixLength {
 						errors = append(errors, fmt.Errorf(
-							"%q cannot be longer than 229 characters, name is limited to 255", k))
+							"%q cannot be longer than %d characters, name is limited to 255", k, 255-resource.UniqueIDSuffixLength))
 					}
 					return
 				},
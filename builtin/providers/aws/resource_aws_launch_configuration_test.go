@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -42,6 +43,52 @@ func TestAccAWSLaunchConfiguration_basic(t *testing.T) {
 	})
 }
 
+func TestAccAWSLaunchConfiguration_namePrefixCreateBeforeDestroy(t *testing.T) {
+	var before, after autoscaling.LaunchConfiguration
+	var group autoscaling.Group
+	rInt := acctest.RandInt()
+
+	testCheckReplaced := func(*terraform.State) error {
+		if *before.LaunchConfigurationName == *after.LaunchConfigurationName {
+			return fmt.Errorf("Expected a new name for the replacement, got %s twice", *after.LaunchConfigurationName)
+		}
+		if *group.LaunchConfigurationName != *after.LaunchConfigurationName {
+			return fmt.Errorf("Expected the autoscaling group to use %s, got %s",
+				*after.LaunchConfigurationName, *group.LaunchConfigurationName)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLaunchConfigurationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSLaunchConfigurationPrefixNameConfigCreateBeforeDestroy(rInt, "t1.micro"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLaunchConfigurationExists("aws_launch_configuration.baz", &before),
+					testAccCheckAWSLaunchConfigurationGeneratedNamePrefix(
+						"aws_launch_configuration.baz", "baz-"),
+				),
+			},
+
+			// Changing the instance type replaces the launch configuration
+			// while the autoscaling group still references the old one.
+			resource.TestStep{
+				Config: testAccAWSLaunchConfigurationPrefixNameConfigCreateBeforeDestroy(rInt, "t2.micro"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLaunchConfigurationExists("aws_launch_configuration.baz", &after),
+					testAccCheckAWSAutoScalingGroupExists("aws_autoscaling_group.baz", &group),
+					testAccCheckAWSLaunchConfigurationGeneratedNamePrefix(
+						"aws_launch_configuration.baz", "baz-"),
+					testCheckReplaced,
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSLaunchConfiguration_withBlockDevices(t *testing.T) {
 	var conf autoscaling.LaunchConfiguration
 
@@ -338,6 +385,28 @@ resource "aws_launch_configuration" "baz" {
 }
 `
 
+func testAccAWSLaunchConfigurationPrefixNameConfigCreateBeforeDestroy(rInt int, instanceType string) string {
+	return fmt.Sprintf(`
+resource "aws_launch_configuration" "baz" {
+   name_prefix = "baz-"
+   image_id = "ami-21f78e11"
+   instance_type = "%s"
+
+   lifecycle {
+      create_before_destroy = true
+   }
+}
+
+resource "aws_autoscaling_group" "baz" {
+   availability_zones = ["us-west-2a"]
+   name = "tf-lc-cbd-test-%d"
+   max_size = 0
+   min_size = 0
+   launch_configuration = "${aws_launch_configuration.baz.name}"
+}
+`, instanceType, rInt)
+}
+
 const testAccAWSLaunchConfigurationWithEncryption = `
 resource "aws_launch_configuration" "baz" {
    image_id = "ami-5189a661"
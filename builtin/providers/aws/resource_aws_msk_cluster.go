@@ -0,0 +1,372 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/kafka"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsMskCluster() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsMskClusterCreate,
+		Read:   resourceAwsMskClusterRead,
+		Update: resourceAwsMskClusterUpdate,
+		Delete: resourceAwsMskClusterDelete,
+
+		Schema: map[string]*schema.Schema{
+			"cluster_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"kafka_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"number_of_broker_nodes": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"broker_node_group_info": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"client_subnets": &schema.Schema{
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"security_groups": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"ebs_volume_size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"az_distribution": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Default:  kafka.BrokerAZDistributionDefault,
+						},
+					},
+				},
+			},
+
+			"configuration_info": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"arn": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"revision": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"current_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"bootstrap_brokers": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"bootstrap_brokers_tls": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"zookeeper_connect_string": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsMskClusterCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kafkaconn
+
+	input := &kafka.CreateClusterInput{
+		ClusterName:         aws.String(d.Get("cluster_name").(string)),
+		KafkaVersion:        aws.String(d.Get("kafka_version").(string)),
+		NumberOfBrokerNodes: aws.Int64(int64(d.Get("number_of_broker_nodes").(int))),
+		BrokerNodeGroupInfo: expandMskClusterBrokerNodeGroupInfo(d.Get("broker_node_group_info").([]interface{})),
+		ConfigurationInfo:   expandMskClusterConfigurationInfo(d.Get("configuration_info").([]interface{})),
+	}
+
+	log.Printf("[DEBUG] Creating MSK Cluster: %s", input)
+	out, err := conn.CreateCluster(input)
+	if err != nil {
+		return fmt.Errorf("Error creating MSK Cluster: %s", err)
+	}
+
+	d.SetId(*out.ClusterArn)
+
+	log.Printf("[DEBUG] Waiting for MSK Cluster (%s) to become active", d.Id())
+	if err := waitForMskClusterActive(conn, d.Id(), []string{kafka.ClusterStateCreating}, 60*time.Minute); err != nil {
+		return fmt.Errorf("Error waiting for MSK Cluster (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAwsMskClusterRead(d, meta)
+}
+
+func resourceAwsMskClusterRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kafkaconn
+
+	resp, err := conn.DescribeCluster(&kafka.DescribeClusterInput{
+		ClusterArn: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isMskClusterNotFoundErr(err) {
+			log.Printf("[WARN] MSK Cluster (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading MSK Cluster %s: %s", d.Id(), err)
+	}
+
+	cluster := resp.ClusterInfo
+	d.Set("arn", cluster.ClusterArn)
+	d.Set("cluster_name", cluster.ClusterName)
+	d.Set("current_version", cluster.CurrentVersion)
+	d.Set("number_of_broker_nodes", cluster.NumberOfBrokerNodes)
+	d.Set("zookeeper_connect_string", cluster.ZookeeperConnectString)
+
+	if info := cluster.CurrentBrokerSoftwareInfo; info != nil {
+		d.Set("kafka_version", info.KafkaVersion)
+
+		if err := d.Set("configuration_info", flattenMskClusterConfigurationInfo(info)); err != nil {
+			return fmt.Errorf("Error setting configuration_info: %s", err)
+		}
+	}
+
+	if err := d.Set("broker_node_group_info", flattenMskClusterBrokerNodeGroupInfo(cluster.BrokerNodeGroupInfo)); err != nil {
+		return fmt.Errorf("Error setting broker_node_group_info: %s", err)
+	}
+
+	brokers, err := conn.GetBootstrapBrokers(&kafka.GetBootstrapBrokersInput{
+		ClusterArn: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading bootstrap brokers of MSK Cluster %s: %s", d.Id(), err)
+	}
+	d.Set("bootstrap_brokers", brokers.BootstrapBrokerString)
+	d.Set("bootstrap_brokers_tls", brokers.BootstrapBrokerStringTls)
+
+	return nil
+}
+
+func resourceAwsMskClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kafkaconn
+
+	if d.HasChange("configuration_info") {
+		input := &kafka.UpdateClusterConfigurationInput{
+			ClusterArn:        aws.String(d.Id()),
+			ConfigurationInfo: expandMskClusterConfigurationInfo(d.Get("configuration_info").([]interface{})),
+			CurrentVersion:    aws.String(d.Get("current_version").(string)),
+		}
+
+		log.Printf("[DEBUG] Updating MSK Cluster configuration: %s", input)
+		if _, err := conn.UpdateClusterConfiguration(input); err != nil {
+			return fmt.Errorf("Error updating MSK Cluster %s configuration: %s", d.Id(), err)
+		}
+
+		if err := waitForMskClusterActive(conn, d.Id(), []string{kafka.ClusterStateUpdating}, 60*time.Minute); err != nil {
+			return fmt.Errorf("Error waiting for MSK Cluster (%s) to be updated: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsMskClusterRead(d, meta)
+}
+
+func resourceAwsMskClusterDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kafkaconn
+
+	log.Printf("[DEBUG] Deleting MSK Cluster: %s", d.Id())
+	_, err := conn.DeleteCluster(&kafka.DeleteClusterInput{
+		ClusterArn: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isMskClusterNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting MSK Cluster %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{kafka.ClusterStateActive, kafka.ClusterStateDeleting},
+		Target:  []string{},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := conn.DescribeCluster(&kafka.DescribeClusterInput{
+				ClusterArn: aws.String(d.Id()),
+			})
+			if err != nil {
+				if isMskClusterNotFoundErr(err) {
+					return nil, "", nil
+				}
+				return nil, "error", err
+			}
+			return resp.ClusterInfo, aws.StringValue(resp.ClusterInfo.State), nil
+		},
+		Timeout:    60 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for MSK Cluster (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func waitForMskClusterActive(conn *kafka.Kafka, arn string, pending []string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: pending,
+		Target:  []string{kafka.ClusterStateActive},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := conn.DescribeCluster(&kafka.DescribeClusterInput{
+				ClusterArn: aws.String(arn),
+			})
+			if err != nil {
+				return nil, "error", err
+			}
+
+			state := aws.StringValue(resp.ClusterInfo.State)
+			if state == kafka.ClusterStateFailed {
+				return nil, state, fmt.Errorf("MSK Cluster %s failed", arn)
+			}
+
+			log.Printf("[DEBUG] MSK Cluster (%s) is %s", arn, state)
+			return resp.ClusterInfo, state, nil
+		},
+		Timeout:    timeout,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func expandMskClusterBrokerNodeGroupInfo(l []interface{}) *kafka.BrokerNodeGroupInfo {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	info := &kafka.BrokerNodeGroupInfo{
+		BrokerAZDistribution: aws.String(m["az_distribution"].(string)),
+		ClientSubnets:        expandStringList(m["client_subnets"].(*schema.Set).List()),
+		InstanceType:         aws.String(m["instance_type"].(string)),
+		StorageInfo: &kafka.StorageInfo{
+			EbsStorageInfo: &kafka.EBSStorageInfo{
+				VolumeSize: aws.Int64(int64(m["ebs_volume_size"].(int))),
+			},
+		},
+	}
+
+	if v := m["security_groups"].(*schema.Set); v.Len() > 0 {
+		info.SecurityGroups = expandStringList(v.List())
+	}
+
+	return info
+}
+
+func flattenMskClusterBrokerNodeGroupInfo(info *kafka.BrokerNodeGroupInfo) []map[string]interface{} {
+	if info == nil {
+		return []map[string]interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"az_distribution": aws.StringValue(info.BrokerAZDistribution),
+		"client_subnets":  schema.NewSet(schema.HashString, flattenStringList(info.ClientSubnets)),
+		"instance_type":   aws.StringValue(info.InstanceType),
+		"security_groups": schema.NewSet(schema.HashString, flattenStringList(info.SecurityGroups)),
+	}
+
+	if info.StorageInfo != nil && info.StorageInfo.EbsStorageInfo != nil {
+		m["ebs_volume_size"] = int(aws.Int64Value(info.StorageInfo.EbsStorageInfo.VolumeSize))
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandMskClusterConfigurationInfo(l []interface{}) *kafka.ConfigurationInfo {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	return &kafka.ConfigurationInfo{
+		Arn:      aws.String(m["arn"].(string)),
+		Revision: aws.Int64(int64(m["revision"].(int))),
+	}
+}
+
+func flattenMskClusterConfigurationInfo(info *kafka.BrokerSoftwareInfo) []map[string]interface{} {
+	// Clusters running the default MSK configuration report no ARN.
+	if info == nil || aws.StringValue(info.ConfigurationArn) == "" {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		map[string]interface{}{
+			"arn":      aws.StringValue(info.ConfigurationArn),
+			"revision": int(aws.Int64Value(info.ConfigurationRevision)),
+		},
+	}
+}
+
+func isMskClusterNotFoundErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == kafka.ErrCodeNotFoundException
+}
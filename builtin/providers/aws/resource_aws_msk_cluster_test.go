@@ -0,0 +1,180 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kafka"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSMskCluster_basic(t *testing.T) {
+	var cluster kafka.ClusterInfo
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSMskClusterDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSMskClusterConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSMskClusterExists("aws_msk_cluster.test", &cluster),
+					resource.TestCheckResourceAttr("aws_msk_cluster.test", "cluster_name", rName),
+					resource.TestCheckResourceAttr("aws_msk_cluster.test", "kafka_version", "2.2.1"),
+					resource.TestCheckResourceAttr("aws_msk_cluster.test", "number_of_broker_nodes", "2"),
+					resource.TestCheckResourceAttr("aws_msk_cluster.test", "broker_node_group_info.#", "1"),
+					resource.TestCheckResourceAttr("aws_msk_cluster.test", "broker_node_group_info.0.instance_type", "kafka.m5.large"),
+					resource.TestCheckResourceAttr("aws_msk_cluster.test", "broker_node_group_info.0.client_subnets.#", "2"),
+					resource.TestCheckResourceAttr("aws_msk_cluster.test", "broker_node_group_info.0.security_groups.#", "1"),
+					resource.TestCheckResourceAttr("aws_msk_cluster.test", "broker_node_group_info.0.ebs_volume_size", "10"),
+					resource.TestMatchResourceAttr("aws_msk_cluster.test", "arn", regexp.MustCompile(`^arn:aws:kafka:`)),
+					resource.TestMatchResourceAttr("aws_msk_cluster.test", "zookeeper_connect_string", regexp.MustCompile(`:2181`)),
+					resource.TestMatchResourceAttr("aws_msk_cluster.test", "bootstrap_brokers_tls", regexp.MustCompile(`:9094`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSMskCluster_disappears(t *testing.T) {
+	var cluster kafka.ClusterInfo
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSMskClusterDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSMskClusterConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSMskClusterExists("aws_msk_cluster.test", &cluster),
+					testAccAWSMskClusterDisappears(&cluster),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccAWSMskClusterDisappears(cluster *kafka.ClusterInfo) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).kafkaconn
+		_, err := conn.DeleteCluster(&kafka.DeleteClusterInput{
+			ClusterArn: cluster.ClusterArn,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Wait for the cluster to be gone so the follow-up plan sees it
+		// as missing rather than deleting.
+		return resource.Retry(60*time.Minute, func() *resource.RetryError {
+			_, err := conn.DescribeCluster(&kafka.DescribeClusterInput{
+				ClusterArn: cluster.ClusterArn,
+			})
+			if err == nil {
+				return resource.RetryableError(fmt.Errorf("MSK Cluster %s still exists", *cluster.ClusterArn))
+			}
+			if isMskClusterNotFoundErr(err) {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		})
+	}
+}
+
+func testAccCheckAWSMskClusterExists(n string, cluster *kafka.ClusterInfo) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No MSK Cluster ARN is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).kafkaconn
+		resp, err := conn.DescribeCluster(&kafka.DescribeClusterInput{
+			ClusterArn: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*cluster = *resp.ClusterInfo
+		return nil
+	}
+}
+
+func testAccCheckAWSMskClusterDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).kafkaconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_msk_cluster" {
+			continue
+		}
+
+		_, err := conn.DescribeCluster(&kafka.DescribeClusterInput{
+			ClusterArn: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("MSK Cluster %s still exists", rs.Primary.ID)
+		}
+		if !isMskClusterNotFoundErr(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSMskClusterConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "test" {
+  cidr_block = "10.1.0.0/16"
+
+  tags {
+    Name = "%s"
+  }
+}
+
+resource "aws_subnet" "a" {
+  vpc_id            = "${aws_vpc.test.id}"
+  cidr_block        = "10.1.1.0/24"
+  availability_zone = "us-west-2a"
+}
+
+resource "aws_subnet" "b" {
+  vpc_id            = "${aws_vpc.test.id}"
+  cidr_block        = "10.1.2.0/24"
+  availability_zone = "us-west-2b"
+}
+
+resource "aws_security_group" "test" {
+  name   = "%s"
+  vpc_id = "${aws_vpc.test.id}"
+}
+
+resource "aws_msk_cluster" "test" {
+  cluster_name           = "%s"
+  kafka_version          = "2.2.1"
+  number_of_broker_nodes = 2
+
+  broker_node_group_info {
+    instance_type   = "kafka.m5.large"
+    client_subnets  = ["${aws_subnet.a.id}", "${aws_subnet.b.id}"]
+    security_groups = ["${aws_security_group.test.id}"]
+    ebs_volume_size = 10
+  }
+}
+`, rName, rName, rName)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/resource"
@@ -117,6 +118,17 @@ func resourceAwsNetworkAclRuleCreate(d *schema.ResourceData, meta interface{}) e
 	log.Printf("[INFO] Creating Network Acl Rule: %d (%t)", d.Get("rule_number").(int), d.Get("egress").(bool))
 	_, err := conn.CreateNetworkAclEntry(params)
 	if err != nil {
+		// The rule number is already taken, by a rule defined inline in an
+		// aws_network_acl, by another aws_network_acl_rule or outside of
+		// Terraform.
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NetworkAclEntryAlreadyExists" {
+			return fmt.Errorf(
+				"Error Creating Network Acl Rule: an %s rule with number %d already exists in %s. "+
+					"Rule numbers must be unique within a Network ACL, including rules defined inline "+
+					"in the aws_network_acl resource.",
+				networkAclRuleDirection(d.Get("egress").(bool)), d.Get("rule_number").(int),
+				d.Get("network_acl_id").(string))
+		}
 		return fmt.Errorf("Error Creating Network Acl Rule: %s", err.Error())
 	}
 	d.SetId(networkAclIdRuleNumberEgressHash(d.Get("network_acl_id").(string), d.Get("rule_number").(int), d.Get("egress").(bool), d.Get("protocol").(string)))
@@ -125,10 +137,14 @@ func resourceAwsNetworkAclRuleCreate(d *schema.ResourceData, meta interface{}) e
 	// API (see issue GH-4721). Retry the `findNetworkAclRule` function until it is
 	// visible (which in most cases is likely immediately).
 	err = resource.Retry(3*time.Minute, func() *resource.RetryError {
-		_, findErr := findNetworkAclRule(d, meta)
+		r, findErr := findNetworkAclRule(d, meta)
 		if findErr != nil {
 			return resource.RetryableError(findErr)
 		}
+		if r == nil {
+			return resource.RetryableError(fmt.Errorf(
+				"Network ACL Rule %d not found", d.Get("rule_number").(int)))
+		}
 
 		return nil
 	})
@@ -144,6 +160,11 @@ func resourceAwsNetworkAclRuleRead(d *schema.ResourceData, meta interface{}) err
 	if err != nil {
 		return err
 	}
+	if resp == nil {
+		log.Printf("[WARN] Network ACL Rule %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
 
 	d.Set("rule_number", resp.RuleNumber)
 	d.Set("cidr_block", resp.CidrBlock)
@@ -215,26 +236,37 @@ func findNetworkAclRule(d *schema.ResourceData, meta interface{}) (*ec2.NetworkA
 	log.Printf("[INFO] Describing Network Acl with the Filters %#v", params)
 	resp, err := conn.DescribeNetworkAcls(params)
 	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InvalidNetworkAclID.NotFound" {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("Error Finding Network Acl Rule %d: %s", d.Get("rule_number").(int), err.Error())
 	}
 
-	if resp == nil || len(resp.NetworkAcls) != 1 || resp.NetworkAcls[0] == nil {
+	if resp == nil || len(resp.NetworkAcls) > 1 {
 		return nil, fmt.Errorf(
 			"Expected to find one Network ACL, got: %#v",
 			resp.NetworkAcls)
 	}
+	// The Network ACL is gone, or doesn't have the rule anymore
+	if len(resp.NetworkAcls) == 0 || resp.NetworkAcls[0] == nil {
+		return nil, nil
+	}
+
 	networkAcl := resp.NetworkAcls[0]
-	if networkAcl.Entries != nil {
-		for _, i := range networkAcl.Entries {
-			if *i.RuleNumber == int64(d.Get("rule_number").(int)) && *i.Egress == d.Get("egress").(bool) {
-				return i, nil
-			}
+	for _, i := range networkAcl.Entries {
+		if *i.RuleNumber == int64(d.Get("rule_number").(int)) && *i.Egress == d.Get("egress").(bool) {
+			return i, nil
 		}
 	}
-	return nil, fmt.Errorf(
-		"Expected the Network ACL to have Entries, got: %#v",
-		networkAcl)
+	return nil, nil
+}
 
+// networkAclRuleDirection returns the direction of a rule for messages.
+func networkAclRuleDirection(egress bool) string {
+	if egress {
+		return "egress"
+	}
+	return "ingress"
 }
 
 func networkAclIdRuleNumberEgressHash(networkAclId string, ruleNumber int, egress bool, protocol string) string {
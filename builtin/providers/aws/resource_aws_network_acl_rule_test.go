@@ -30,6 +30,42 @@ func TestAccAWSNetworkAclRule_basic(t *testing.T) {
 	})
 }
 
+func TestAccAWSNetworkAclRule_disappears(t *testing.T) {
+	var networkAcl ec2.NetworkAcl
+
+	testDeleteRule := func(s *terraform.State) error {
+		// Delete the rule outside of Terraform, like someone in the console
+		rs := s.RootModule().Resources["aws_network_acl_rule.bar"]
+		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+		_, err := conn.DeleteNetworkAclEntry(&ec2.DeleteNetworkAclEntryInput{
+			NetworkAclId: aws.String(rs.Primary.Attributes["network_acl_id"]),
+			RuleNumber:   aws.Int64(200),
+			Egress:       aws.Bool(false),
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting Network ACL Rule in test: %s", err)
+		}
+
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSNetworkAclRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSNetworkAclRuleBasicConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSNetworkAclRuleExists("aws_network_acl_rule.bar", &networkAcl),
+					testDeleteRule,
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckAWSNetworkAclRuleDestroy(s *terraform.State) error {
 
 	for _, rs := range s.RootModule().Resources {
@@ -41,6 +41,22 @@ func resourceAwsNetworkInterface() *schema.Resource {
 				Set:      schema.HashString,
 			},
 
+			"ipv6_address_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			"ipv6_addresses": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
 			"security_groups": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -106,6 +122,24 @@ func resourceAwsNetworkInterfaceCreate(d *schema.ResourceData, meta interface{})
 		request.PrivateIpAddresses = expandPrivateIPAddresses(private_ips)
 	}
 
+	_, hasCount := d.GetOk("ipv6_address_count")
+	_, hasAddresses := d.GetOk("ipv6_addresses")
+	if hasCount && hasAddresses {
+		return fmt.Errorf("Only one of ipv6_address_count or ipv6_addresses can be specified")
+	}
+
+	if v, ok := d.GetOk("ipv6_address_count"); ok {
+		request.Ipv6AddressCount = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("ipv6_addresses"); ok {
+		for _, address := range v.(*schema.Set).List() {
+			request.Ipv6Addresses = append(request.Ipv6Addresses, &ec2.InstanceIpv6Address{
+				Ipv6Address: aws.String(address.(string)),
+			})
+		}
+	}
+
 	if v, ok := d.GetOk("description"); ok {
 		request.Description = aws.String(v.(string))
 	}
@@ -146,6 +180,13 @@ func resourceAwsNetworkInterfaceRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("subnet_id", eni.SubnetId)
 	d.Set("private_ips", flattenNetworkInterfacesPrivateIPAddresses(eni.PrivateIpAddresses))
 	d.Set("security_groups", flattenGroupIdentifiers(eni.Groups))
+
+	ipv6Addresses := make([]string, 0, len(eni.Ipv6Addresses))
+	for _, a := range eni.Ipv6Addresses {
+		ipv6Addresses = append(ipv6Addresses, aws.StringValue(a.Ipv6Address))
+	}
+	d.Set("ipv6_addresses", ipv6Addresses)
+	d.Set("ipv6_address_count", len(ipv6Addresses))
 	d.Set("source_dest_check", eni.SourceDestCheck)
 
 	if eni.Description != nil {
@@ -0,0 +1,203 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsNetworkInterfaceAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsNetworkInterfaceAttachmentCreate,
+		Read:   resourceAwsNetworkInterfaceAttachmentRead,
+		Delete: resourceAwsNetworkInterfaceAttachmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"network_interface_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"device_index": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"attachment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsNetworkInterfaceAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	iID := d.Get("instance_id").(string)
+	eniID := d.Get("network_interface_id").(string)
+	deviceIndex := d.Get("device_index").(int)
+
+	opts := &ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(int64(deviceIndex)),
+		InstanceId:         aws.String(iID),
+		NetworkInterfaceId: aws.String(eniID),
+	}
+
+	log.Printf("[DEBUG] Attaching network interface (%s) to instance (%s)", eniID, iID)
+	resp, err := conn.AttachNetworkInterface(opts)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			return fmt.Errorf("[WARN] Error attaching network interface (%s) to instance (%s), message: \"%s\", code: \"%s\"",
+				eniID, iID, awsErr.Message(), awsErr.Code())
+		}
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{ec2.AttachmentStatusAttaching},
+		Target:     []string{ec2.AttachmentStatusAttached},
+		Refresh:    networkInterfaceAttachmentStatusRefreshFunc(conn, eniID, aws.StringValue(resp.AttachmentId)),
+		Timeout:    5 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for network interface (%s) to attach to instance (%s): %s",
+			eniID, iID, err)
+	}
+
+	d.SetId(networkInterfaceAttachmentID(eniID, iID, deviceIndex))
+	log.Printf("[INFO] Network interface attachment ID: %s", aws.StringValue(resp.AttachmentId))
+
+	return resourceAwsNetworkInterfaceAttachmentRead(d, meta)
+}
+
+func resourceAwsNetworkInterfaceAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	eniID := d.Get("network_interface_id").(string)
+
+	resp, err := conn.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: []*string{aws.String(eniID)},
+	})
+	if err != nil {
+		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidNetworkInterfaceID.NotFound" {
+			log.Printf("[WARN] Network interface (%s) not found, removing attachment from state", eniID)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading network interface (%s): %s", eniID, err)
+	}
+
+	// The attachment is gone once the network interface is detached, or
+	// attached somewhere else.
+	var a *ec2.NetworkInterfaceAttachment
+	if len(resp.NetworkInterfaces) == 1 {
+		a = resp.NetworkInterfaces[0].Attachment
+	}
+	if a == nil || aws.StringValue(a.InstanceId) != d.Get("instance_id").(string) ||
+		int(aws.Int64Value(a.DeviceIndex)) != d.Get("device_index").(int) ||
+		aws.StringValue(a.Status) == ec2.AttachmentStatusDetached {
+		log.Printf("[WARN] Network interface attachment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("attachment_id", a.AttachmentId)
+	d.Set("status", a.Status)
+
+	return nil
+}
+
+func resourceAwsNetworkInterfaceAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	eniID := d.Get("network_interface_id").(string)
+	attachmentID := d.Get("attachment_id").(string)
+
+	log.Printf("[DEBUG] Detaching network interface (%s) from instance (%s)", eniID, d.Get("instance_id").(string))
+	_, err := conn.DetachNetworkInterface(&ec2.DetachNetworkInterfaceInput{
+		AttachmentId: aws.String(attachmentID),
+	})
+	if err != nil {
+		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidAttachmentID.NotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error detaching network interface (%s): %s", eniID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{ec2.AttachmentStatusAttached, ec2.AttachmentStatusDetaching},
+		Target:     []string{ec2.AttachmentStatusDetached},
+		Refresh:    networkInterfaceAttachmentStatusRefreshFunc(conn, eniID, attachmentID),
+		Timeout:    5 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for network interface (%s) to detach: %s", eniID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// networkInterfaceAttachmentStatusRefreshFunc watches the status of
+// attachment attachmentID of network interface eniID. An attachment that
+// can no longer be found counts as detached.
+func networkInterfaceAttachmentStatusRefreshFunc(conn *ec2.EC2, eniID, attachmentID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+			NetworkInterfaceIds: []*string{aws.String(eniID)},
+		})
+		if err != nil {
+			if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidNetworkInterfaceID.NotFound" {
+				return 42, ec2.AttachmentStatusDetached, nil
+			}
+			return nil, "", err
+		}
+
+		if len(resp.NetworkInterfaces) == 1 {
+			a := resp.NetworkInterfaces[0].Attachment
+			if a != nil && aws.StringValue(a.AttachmentId) == attachmentID {
+				return a, aws.StringValue(a.Status), nil
+			}
+		}
+
+		return 42, ec2.AttachmentStatusDetached, nil
+	}
+}
+
+func networkInterfaceAttachmentID(eniID, instanceID string, deviceIndex int) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s-", eniID))
+	buf.WriteString(fmt.Sprintf("%s-", instanceID))
+	buf.WriteString(fmt.Sprintf("%d-", deviceIndex))
+
+	return fmt.Sprintf("eai-%d", hashcode.String(buf.String()))
+}
@@ -0,0 +1,152 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSNetworkInterfaceAttachment_basic(t *testing.T) {
+	var conf ec2.NetworkInterface
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSENIDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSNetworkInterfaceAttachmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSENIExists("aws_network_interface.bar", &conf),
+					testAccCheckAWSNetworkInterfaceAttachmentAttached("aws_network_interface_attachment.test", &conf),
+					resource.TestCheckResourceAttr(
+						"aws_network_interface_attachment.test", "device_index", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_network_interface_attachment.test", "status", "attached"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSNetworkInterfaceAttachment_delete(t *testing.T) {
+	var conf ec2.NetworkInterface
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSENIDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSNetworkInterfaceAttachmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSENIExists("aws_network_interface.bar", &conf),
+					testAccCheckAWSNetworkInterfaceAttachmentAttached("aws_network_interface_attachment.test", &conf),
+				),
+			},
+
+			// Dropping the attachment has to leave the network interface
+			// detached, but in place.
+			resource.TestStep{
+				Config: testAccAWSNetworkInterfaceAttachmentConfig_detached,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSENIExists("aws_network_interface.bar", &conf),
+					testAccCheckAWSNetworkInterfaceDetached(&conf),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSNetworkInterfaceAttachmentAttached(n string, eni *ec2.NetworkInterface) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		if eni.Attachment == nil {
+			return fmt.Errorf("Network interface %s is not attached", *eni.NetworkInterfaceId)
+		}
+		if id := aws.StringValue(eni.Attachment.AttachmentId); id != rs.Primary.Attributes["attachment_id"] {
+			return fmt.Errorf("Expected attachment %s, got %s", rs.Primary.Attributes["attachment_id"], id)
+		}
+		if id := aws.StringValue(eni.Attachment.InstanceId); id != rs.Primary.Attributes["instance_id"] {
+			return fmt.Errorf("Expected network interface to be attached to %s, got %s", rs.Primary.Attributes["instance_id"], id)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSNetworkInterfaceDetached(eni *ec2.NetworkInterface) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if eni.Attachment != nil && aws.StringValue(eni.Attachment.Status) != ec2.AttachmentStatusDetached {
+			return fmt.Errorf("Expected network interface %s to be detached, got attachment: %s", *eni.NetworkInterfaceId, eni.Attachment)
+		}
+
+		return nil
+	}
+}
+
+const testAccAWSNetworkInterfaceAttachmentConfig_base = `
+resource "aws_vpc" "foo" {
+    cidr_block = "172.16.0.0/16"
+        tags {
+            Name = "tf-eni-attachment-test"
+        }
+}
+
+resource "aws_subnet" "foo" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "172.16.10.0/24"
+    availability_zone = "us-west-2a"
+        tags {
+            Name = "tf-eni-attachment-test"
+        }
+}
+
+resource "aws_security_group" "foo" {
+  vpc_id = "${aws_vpc.foo.id}"
+  description = "foo"
+  name = "foo"
+}
+
+resource "aws_network_interface" "bar" {
+    subnet_id = "${aws_subnet.foo.id}"
+    private_ips = ["172.16.10.100"]
+    security_groups = ["${aws_security_group.foo.id}"]
+    tags {
+        Name = "bar_interface"
+    }
+}
+
+resource "aws_instance" "foo" {
+    ami = "ami-c5eabbf5"
+    instance_type = "t2.micro"
+    subnet_id = "${aws_subnet.foo.id}"
+    associate_public_ip_address = false
+    private_ip = "172.16.10.50"
+    tags {
+        Name = "foo-tf-eni-attachment-test"
+    }
+}
+`
+
+const testAccAWSNetworkInterfaceAttachmentConfig = testAccAWSNetworkInterfaceAttachmentConfig_base + `
+resource "aws_network_interface_attachment" "test" {
+    device_index = 1
+    instance_id = "${aws_instance.foo.id}"
+    network_interface_id = "${aws_network_interface.bar.id}"
+}
+`
+
+const testAccAWSNetworkInterfaceAttachmentConfig_detached = testAccAWSNetworkInterfaceAttachmentConfig_base
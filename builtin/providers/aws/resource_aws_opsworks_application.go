@@ -85,8 +85,9 @@ func resourceAwsOpsworksApplication() *schema.Resource {
 						},
 
 						"password": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
 						},
 
 						"revision": &schema.Schema{
@@ -347,10 +348,9 @@ func resourceAwsOpsworksApplicationUpdate(d *schema.ResourceData, meta interface
 
 	log.Printf("[DEBUG] Updating OpsWorks layer: %s", d.Id())
 
-	var resp *opsworks.UpdateAppOutput
 	err := resource.Retry(2*time.Minute, func() *resource.RetryError {
 		var cerr error
-		resp, cerr = client.UpdateApp(req)
+		_, cerr = client.UpdateApp(req)
 		if cerr != nil {
 			log.Printf("[INFO] client error")
 			if opserr, ok := cerr.(awserr.Error); ok {
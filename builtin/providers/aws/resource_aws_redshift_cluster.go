@@ -53,8 +53,9 @@ func resourceAwsRedshiftCluster() *schema.Resource {
 			},
 
 			"master_password": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
 			},
 
 			"cluster_security_groups": &schema.Schema{
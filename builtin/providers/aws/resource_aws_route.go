@@ -15,10 +15,13 @@ import (
 )
 
 // How long to sleep if a limit-exceeded event happens
-var routeTargetValidationError = errors.New("Error: more than 1 target specified. Only 1 of gateway_id" +
-	"nat_gateway_id, instance_id, network_interface_id, route_table_id or" +
+var routeTargetValidationError = errors.New("Error: more than 1 target specified. Only 1 of gateway_id, " +
+	"egress_only_gateway_id, nat_gateway_id, instance_id, network_interface_id, route_table_id or " +
 	"vpc_peering_connection_id is allowed.")
 
+var routeDestinationValidationError = errors.New("Error: exactly 1 of destination_cidr_block or " +
+	"destination_ipv6_cidr_block must be specified.")
+
 // AWS Route resource Schema declaration
 func resourceAwsRoute() *schema.Resource {
 	return &schema.Resource{
@@ -31,7 +34,13 @@ func resourceAwsRoute() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"destination_cidr_block": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"destination_ipv6_cidr_block": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
 				ForceNew: true,
 			},
 
@@ -46,6 +55,12 @@ func resourceAwsRoute() *schema.Resource {
 				Computed: true,
 			},
 
+			"egress_only_gateway_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
 			"nat_gateway_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -98,6 +113,7 @@ func resourceAwsRouteCreate(d *schema.ResourceData, meta interface{}) error {
 	var setTarget string
 	allowedTargets := []string{
 		"gateway_id",
+		"egress_only_gateway_id",
 		"nat_gateway_id",
 		"instance_id",
 		"network_interface_id",
@@ -116,39 +132,34 @@ func resourceAwsRouteCreate(d *schema.ResourceData, meta interface{}) error {
 		return routeTargetValidationError
 	}
 
-	createOpts := &ec2.CreateRouteInput{}
-	// Formulate CreateRouteInput based on the target type
+	if err := validateRouteDestination(d); err != nil {
+		return err
+	}
+
+	createOpts := &ec2.CreateRouteInput{
+		RouteTableId: aws.String(d.Get("route_table_id").(string)),
+	}
+	if v, ok := d.GetOk("destination_cidr_block"); ok {
+		createOpts.DestinationCidrBlock = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("destination_ipv6_cidr_block"); ok {
+		createOpts.DestinationIpv6CidrBlock = aws.String(v.(string))
+	}
+
+	// Set the target of CreateRouteInput based on the target type
 	switch setTarget {
 	case "gateway_id":
-		createOpts = &ec2.CreateRouteInput{
-			RouteTableId:         aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
-			GatewayId:            aws.String(d.Get("gateway_id").(string)),
-		}
+		createOpts.GatewayId = aws.String(d.Get("gateway_id").(string))
+	case "egress_only_gateway_id":
+		createOpts.EgressOnlyInternetGatewayId = aws.String(d.Get("egress_only_gateway_id").(string))
 	case "nat_gateway_id":
-		createOpts = &ec2.CreateRouteInput{
-			RouteTableId:         aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
-			NatGatewayId:         aws.String(d.Get("nat_gateway_id").(string)),
-		}
+		createOpts.NatGatewayId = aws.String(d.Get("nat_gateway_id").(string))
 	case "instance_id":
-		createOpts = &ec2.CreateRouteInput{
-			RouteTableId:         aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
-			InstanceId:           aws.String(d.Get("instance_id").(string)),
-		}
+		createOpts.InstanceId = aws.String(d.Get("instance_id").(string))
 	case "network_interface_id":
-		createOpts = &ec2.CreateRouteInput{
-			RouteTableId:         aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
-			NetworkInterfaceId:   aws.String(d.Get("network_interface_id").(string)),
-		}
+		createOpts.NetworkInterfaceId = aws.String(d.Get("network_interface_id").(string))
 	case "vpc_peering_connection_id":
-		createOpts = &ec2.CreateRouteInput{
-			RouteTableId:           aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock:   aws.String(d.Get("destination_cidr_block").(string)),
-			VpcPeeringConnectionId: aws.String(d.Get("vpc_peering_connection_id").(string)),
-		}
+		createOpts.VpcPeeringConnectionId = aws.String(d.Get("vpc_peering_connection_id").(string))
 	default:
 		return fmt.Errorf("Error: invalid target type specified.")
 	}
@@ -179,7 +190,7 @@ func resourceAwsRouteCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error creating route: %s", err)
 	}
 
-	route, err := findResourceRoute(conn, d.Get("route_table_id").(string), d.Get("destination_cidr_block").(string))
+	route, err := findResourceRoute(conn, d.Get("route_table_id").(string), d.Get("destination_cidr_block").(string), d.Get("destination_ipv6_cidr_block").(string))
 	if err != nil {
 		return err
 	}
@@ -191,13 +202,14 @@ func resourceAwsRouteCreate(d *schema.ResourceData, meta interface{}) error {
 
 func resourceAwsRouteRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
-	route, err := findResourceRoute(conn, d.Get("route_table_id").(string), d.Get("destination_cidr_block").(string))
+	route, err := findResourceRoute(conn, d.Get("route_table_id").(string), d.Get("destination_cidr_block").(string), d.Get("destination_ipv6_cidr_block").(string))
 	if err != nil {
 		return err
 	}
 
 	d.Set("destination_prefix_list_id", route.DestinationPrefixListId)
 	d.Set("gateway_id", route.GatewayId)
+	d.Set("egress_only_gateway_id", route.EgressOnlyInternetGatewayId)
 	d.Set("nat_gateway_id", route.NatGatewayId)
 	d.Set("instance_id", route.InstanceId)
 	d.Set("instance_owner_id", route.InstanceOwnerId)
@@ -215,12 +227,12 @@ func resourceAwsRouteUpdate(d *schema.ResourceData, meta interface{}) error {
 	var setTarget string
 	allowedTargets := []string{
 		"gateway_id",
+		"egress_only_gateway_id",
 		"nat_gateway_id",
 		"instance_id",
 		"network_interface_id",
 		"vpc_peering_connection_id",
 	}
-	replaceOpts := &ec2.ReplaceRouteInput{}
 
 	// Check if more than 1 target is specified
 	for _, target := range allowedTargets {
@@ -234,40 +246,32 @@ func resourceAwsRouteUpdate(d *schema.ResourceData, meta interface{}) error {
 		return routeTargetValidationError
 	}
 
-	// Formulate ReplaceRouteInput based on the target type
+	replaceOpts := &ec2.ReplaceRouteInput{
+		RouteTableId: aws.String(d.Get("route_table_id").(string)),
+	}
+	if v, ok := d.GetOk("destination_cidr_block"); ok {
+		replaceOpts.DestinationCidrBlock = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("destination_ipv6_cidr_block"); ok {
+		replaceOpts.DestinationIpv6CidrBlock = aws.String(v.(string))
+	}
+
+	// Set the target of ReplaceRouteInput based on the target type
 	switch setTarget {
 	case "gateway_id":
-		replaceOpts = &ec2.ReplaceRouteInput{
-			RouteTableId:         aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
-			GatewayId:            aws.String(d.Get("gateway_id").(string)),
-		}
+		replaceOpts.GatewayId = aws.String(d.Get("gateway_id").(string))
+	case "egress_only_gateway_id":
+		replaceOpts.EgressOnlyInternetGatewayId = aws.String(d.Get("egress_only_gateway_id").(string))
 	case "nat_gateway_id":
-		replaceOpts = &ec2.ReplaceRouteInput{
-			RouteTableId:         aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
-			NatGatewayId:         aws.String(d.Get("nat_gateway_id").(string)),
-		}
+		replaceOpts.NatGatewayId = aws.String(d.Get("nat_gateway_id").(string))
 	case "instance_id":
-		replaceOpts = &ec2.ReplaceRouteInput{
-			RouteTableId:         aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
-			InstanceId:           aws.String(d.Get("instance_id").(string)),
-			//NOOP: Ensure we don't blow away network interface id that is set after instance is launched
-			NetworkInterfaceId: aws.String(d.Get("network_interface_id").(string)),
-		}
+		replaceOpts.InstanceId = aws.String(d.Get("instance_id").(string))
+		//NOOP: Ensure we don't blow away network interface id that is set after instance is launched
+		replaceOpts.NetworkInterfaceId = aws.String(d.Get("network_interface_id").(string))
 	case "network_interface_id":
-		replaceOpts = &ec2.ReplaceRouteInput{
-			RouteTableId:         aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
-			NetworkInterfaceId:   aws.String(d.Get("network_interface_id").(string)),
-		}
+		replaceOpts.NetworkInterfaceId = aws.String(d.Get("network_interface_id").(string))
 	case "vpc_peering_connection_id":
-		replaceOpts = &ec2.ReplaceRouteInput{
-			RouteTableId:           aws.String(d.Get("route_table_id").(string)),
-			DestinationCidrBlock:   aws.String(d.Get("destination_cidr_block").(string)),
-			VpcPeeringConnectionId: aws.String(d.Get("vpc_peering_connection_id").(string)),
-		}
+		replaceOpts.VpcPeeringConnectionId = aws.String(d.Get("vpc_peering_connection_id").(string))
 	default:
 		return fmt.Errorf("Error: invalid target type specified.")
 	}
@@ -286,8 +290,13 @@ func resourceAwsRouteDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 
 	deleteOpts := &ec2.DeleteRouteInput{
-		RouteTableId:         aws.String(d.Get("route_table_id").(string)),
-		DestinationCidrBlock: aws.String(d.Get("destination_cidr_block").(string)),
+		RouteTableId: aws.String(d.Get("route_table_id").(string)),
+	}
+	if v, ok := d.GetOk("destination_cidr_block"); ok {
+		deleteOpts.DestinationCidrBlock = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("destination_ipv6_cidr_block"); ok {
+		deleteOpts.DestinationIpv6CidrBlock = aws.String(v.(string))
 	}
 	log.Printf("[DEBUG] Route delete opts: %s", deleteOpts)
 
@@ -342,22 +351,34 @@ func resourceAwsRouteExists(d *schema.ResourceData, meta interface{}) (bool, err
 	}
 
 	cidr := d.Get("destination_cidr_block").(string)
-	for _, route := range (*res.RouteTables[0]).Routes {
-		if route.DestinationCidrBlock != nil && *route.DestinationCidrBlock == cidr {
-			return true, nil
-		}
-	}
-
-	return false, nil
+	ipv6Cidr := d.Get("destination_ipv6_cidr_block").(string)
+	return findRouteByCidr(res.RouteTables[0].Routes, cidr, ipv6Cidr) != nil, nil
 }
 
 // Create an ID for a route
 func routeIDHash(d *schema.ResourceData, r *ec2.Route) string {
+	if r.DestinationIpv6CidrBlock != nil && *r.DestinationIpv6CidrBlock != "" {
+		return fmt.Sprintf("r-%s%d", d.Get("route_table_id").(string), hashcode.String(*r.DestinationIpv6CidrBlock))
+	}
+
 	return fmt.Sprintf("r-%s%d", d.Get("route_table_id").(string), hashcode.String(*r.DestinationCidrBlock))
 }
 
-// Helper: retrieve a route
-func findResourceRoute(conn *ec2.EC2, rtbid string, cidr string) (*ec2.Route, error) {
+// validateRouteDestination checks that a route has exactly one IPv4 or IPv6
+// destination.
+func validateRouteDestination(d *schema.ResourceData) error {
+	_, ok := d.GetOk("destination_cidr_block")
+	_, ipv6Ok := d.GetOk("destination_ipv6_cidr_block")
+	if ok == ipv6Ok {
+		return routeDestinationValidationError
+	}
+
+	return nil
+}
+
+// Helper: retrieve a route to an IPv4 destination cidr, or to an IPv6
+// destination ipv6Cidr if cidr is empty.
+func findResourceRoute(conn *ec2.EC2, rtbid string, cidr string, ipv6Cidr string) (*ec2.Route, error) {
 	routeTableID := rtbid
 
 	findOpts := &ec2.DescribeRouteTablesInput{
@@ -374,13 +395,36 @@ func findResourceRoute(conn *ec2.EC2, rtbid string, cidr string) (*ec2.Route, er
 			routeTableID)
 	}
 
-	for _, route := range (*resp.RouteTables[0]).Routes {
-		if route.DestinationCidrBlock != nil && *route.DestinationCidrBlock == cidr {
-			return route, nil
-		}
+	if route := findRouteByCidr(resp.RouteTables[0].Routes, cidr, ipv6Cidr); route != nil {
+		return route, nil
 	}
 
+	if cidr == "" {
+		cidr = ipv6Cidr
+	}
 	return nil, fmt.Errorf(`
 error finding matching route for Route table (%s) and destination CIDR block (%s)`,
 		rtbid, cidr)
 }
+
+// findRouteByCidr returns the route to the given destination CIDR block, or
+// to the given IPv6 destination CIDR block if cidr is empty, among the routes
+// of a route table, or nil if there is none. Routes
+// propagated by a virtual private gateway are skipped, as they can overlap
+// the routes created by Terraform, e.g. a route to a VGW used for Direct
+// Connect, and they are not managed by it.
+func findRouteByCidr(routes []*ec2.Route, cidr string, ipv6Cidr string) *ec2.Route {
+	for _, route := range routes {
+		if aws.StringValue(route.Origin) == ec2.RouteOriginEnableVgwRoutePropagation {
+			continue
+		}
+		if cidr != "" && aws.StringValue(route.DestinationCidrBlock) == cidr {
+			return route
+		}
+		if cidr == "" && ipv6Cidr != "" && aws.StringValue(route.DestinationIpv6CidrBlock) == ipv6Cidr {
+			return route
+		}
+	}
+
+	return nil
+}
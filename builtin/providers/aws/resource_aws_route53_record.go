@@ -26,6 +26,9 @@ func resourceAwsRoute53Record() *schema.Resource {
 		Read:   resourceAwsRoute53RecordRead,
 		Update: resourceAwsRoute53RecordUpdate,
 		Delete: resourceAwsRoute53RecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsRoute53RecordImportState,
+		},
 
 		SchemaVersion: 1,
 		MigrateState:  resourceAwsRoute53RecordMigrateState,
@@ -121,6 +124,12 @@ func resourceAwsRoute53Record() *schema.Resource {
 				Optional: true,
 			},
 
+			"multivalue_answer_routing_policy": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"alias", "failover", "weight"},
+			},
+
 			"records": &schema.Schema{
 				Type:          schema.TypeSet,
 				ConflictsWith: []string{"alias"},
@@ -249,14 +258,17 @@ func resourceAwsRoute53RecordCreate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAwsRoute53RecordRead(d *schema.ResourceData, meta interface{}) error {
-	// If we don't have a zone ID we're doing an import. Parse it from the ID.
+	// If we don't have a zone ID we only know the ID. Parse it.
 	if _, ok := d.GetOk("zone_id"); !ok {
-		parts := strings.Split(d.Id(), "_")
-		d.Set("zone_id", parts[0])
-		d.Set("name", parts[1])
-		d.Set("type", parts[2])
-		if len(parts) > 3 {
-			d.Set("set_identifier", parts[3])
+		zoneId, name, recordType, setId, err := parseRoute53RecordId(d.Id())
+		if err != nil {
+			return err
+		}
+		d.Set("zone_id", zoneId)
+		d.Set("name", name)
+		d.Set("type", recordType)
+		if setId != "" {
+			d.Set("set_identifier", setId)
 		}
 
 		d.Set("weight", -1)
@@ -301,6 +313,7 @@ func resourceAwsRoute53RecordRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("set_identifier", record.SetIdentifier)
 	d.Set("failover", record.Failover)
 	d.Set("health_check_id", record.HealthCheckId)
+	d.Set("multivalue_answer_routing_policy", record.MultiValueAnswer)
 
 	return nil
 }
@@ -344,6 +357,12 @@ func findRecord(d *schema.ResourceData, meta interface{}) (*route53.ResourceReco
 		StartRecordType: aws.String(d.Get("type").(string)),
 	}
 
+	// Records sharing a name and type are listed by set identifier, so start
+	// from ours rather than risk it falling past the first page.
+	if v, ok := d.GetOk("set_identifier"); ok {
+		lopts.StartRecordIdentifier = aws.String(v.(string))
+	}
+
 	log.Printf("[DEBUG] List resource records sets for zone: %s, opts: %s",
 		zone, lopts)
 	resp, err := conn.ListResourceRecordSets(lopts)
@@ -498,6 +517,16 @@ func resourceAwsRoute53RecordBuildSet(d *schema.ResourceData, zoneName string) (
 		rec.SetIdentifier = aws.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("multivalue_answer_routing_policy"); ok && v.(bool) {
+		if _, ok := d.GetOk("set_identifier"); !ok {
+			return nil, fmt.Errorf(`provider.aws: aws_route53_record: %s: "set_identifier": required field is not set when "multivalue_answer_routing_policy" is set`, d.Get("name").(string))
+		}
+		if _, ok := d.GetOk("health_check_id"); !ok {
+			return nil, fmt.Errorf(`provider.aws: aws_route53_record: %s: "health_check_id": required field is not set when "multivalue_answer_routing_policy" is set`, d.Get("name").(string))
+		}
+		rec.MultiValueAnswer = aws.Bool(true)
+	}
+
 	w := d.Get("weight").(int)
 	if w > -1 {
 		if _, ok := d.GetOk("set_identifier"); !ok {
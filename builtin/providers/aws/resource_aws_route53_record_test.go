@@ -194,6 +194,28 @@ func TestAccAWSRoute53Record_failover(t *testing.T) {
 	})
 }
 
+func TestAccAWSRoute53Record_multivalue_answer_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "aws_route53_record.www-server1",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckRoute53RecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53MultiValueAnswerARecord,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53RecordExists("aws_route53_record.www-server1"),
+					testAccCheckRoute53RecordExists("aws_route53_record.www-server2"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.www-server1", "multivalue_answer_routing_policy", "true"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.www-server2", "multivalue_answer_routing_policy", "true"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSRoute53Record_weighted_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:      func() { testAccPreCheck(t) },
@@ -520,7 +542,7 @@ resource "aws_route53_record" "wildcard" {
     name = "*.notexample.com"
     type = "A"
     ttl = "30"
-    records = ["127.0.0.1"]
+    records = ["192.0.2.1"]
 }
 `
 
@@ -612,6 +634,52 @@ resource "aws_route53_record" "www-secondary" {
 }
 `
 
+const testAccRoute53MultiValueAnswerARecord = `
+resource "aws_route53_zone" "main" {
+	name = "notexample.com"
+}
+
+resource "aws_route53_health_check" "server1" {
+  fqdn = "server1.notexample.com"
+  port = 80
+  type = "HTTP"
+  resource_path = "/"
+  failure_threshold = "2"
+  request_interval = "30"
+}
+
+resource "aws_route53_health_check" "server2" {
+  fqdn = "server2.notexample.com"
+  port = 80
+  type = "HTTP"
+  resource_path = "/"
+  failure_threshold = "2"
+  request_interval = "30"
+}
+
+resource "aws_route53_record" "www-server1" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "A"
+  ttl = "300"
+  multivalue_answer_routing_policy = true
+  health_check_id = "${aws_route53_health_check.server1.id}"
+  set_identifier = "server1"
+  records = ["127.0.0.1"]
+}
+
+resource "aws_route53_record" "www-server2" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "A"
+  ttl = "300"
+  multivalue_answer_routing_policy = true
+  health_check_id = "${aws_route53_health_check.server2.id}"
+  set_identifier = "server2"
+  records = ["192.0.2.2"]
+}
+`
+
 const testAccRoute53WeightedCNAMERecord = `
 resource "aws_route53_zone" "main" {
 	name = "notexample.com"
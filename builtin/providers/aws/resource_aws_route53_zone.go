@@ -69,6 +69,12 @@ func resourceAwsRoute53Zone() *schema.Resource {
 			},
 
 			"tags": tagsSchema(),
+
+			"force_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -256,6 +262,17 @@ func resourceAwsRoute53ZoneUpdate(d *schema.ResourceData, meta interface{}) erro
 func resourceAwsRoute53ZoneDelete(d *schema.ResourceData, meta interface{}) error {
 	r53 := meta.(*AWSClient).r53conn
 
+	if d.Get("force_destroy").(bool) {
+		if err := deleteAllRoute53RecordsInZone(r53, d.Id(), d.Get("name").(string)); err != nil {
+			if r53err, ok := err.(awserr.Error); ok && r53err.Code() == "NoSuchHostedZone" {
+				log.Printf("[DEBUG] No matching Route 53 Zone found for: %s, removing from state file", d.Id())
+				d.SetId("")
+				return nil
+			}
+			return fmt.Errorf("Error deleting records of Route53 hosted zone %s: %s", d.Id(), err)
+		}
+	}
+
 	log.Printf("[DEBUG] Deleting Route53 hosted zone: %s (ID: %s)",
 		d.Get("name").(string), d.Id())
 	_, err := r53.DeleteHostedZone(&route53.DeleteHostedZoneInput{Id: aws.String(d.Id())})
@@ -271,6 +288,57 @@ func resourceAwsRoute53ZoneDelete(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
+// deleteAllRoute53RecordsInZone deletes every record set of the hosted zone
+// except the SOA and NS records at its apex, which Route 53 manages itself
+// and removes along with the zone.
+func deleteAllRoute53RecordsInZone(r53 *route53.Route53, zoneId, zoneName string) error {
+	zoneName = strings.TrimSuffix(zoneName, ".")
+
+	var changes []*route53.Change
+	err := r53.ListResourceRecordSetsPages(&route53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneId),
+	}, func(page *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		for _, set := range page.ResourceRecordSets {
+			t := aws.StringValue(set.Type)
+			if (t == route53.RRTypeSoa || t == route53.RRTypeNs) &&
+				strings.TrimSuffix(aws.StringValue(set.Name), ".") == zoneName {
+				continue
+			}
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: set,
+			})
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+
+	// ChangeResourceRecordSets accepts at most 1000 changes per batch.
+	for len(changes) > 0 {
+		batch := changes
+		if len(batch) > 1000 {
+			batch = batch[:1000]
+		}
+		changes = changes[len(batch):]
+
+		log.Printf("[DEBUG] Deleting %d record sets of Route53 hosted zone: %s", len(batch), zoneId)
+		_, err := r53.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String(zoneId),
+			ChangeBatch: &route53.ChangeBatch{
+				Comment: aws.String("Deleted by Terraform"),
+				Changes: batch,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func resourceAwsGoRoute53Wait(r53 *route53.Route53, ref *route53.GetChangeInput) (result interface{}, state string, err error) {
 
 	status, err := r53.GetChange(ref)
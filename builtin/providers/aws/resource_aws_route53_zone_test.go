@@ -86,6 +86,27 @@ func TestAccAWSRoute53Zone_basic(t *testing.T) {
 	})
 }
 
+func TestAccAWSRoute53Zone_forceDestroy(t *testing.T) {
+	var zone route53.GetHostedZoneOutput
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53ZoneDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53ZoneConfigForceDestroy,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53ZoneExists("aws_route53_zone.destroyable", &zone),
+					// Add records out of band, the zone has to be
+					// destroyed regardless.
+					testAccCreateRoute53RecordsInZone(&zone, 100),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSRoute53Zone_updateComment(t *testing.T) {
 	var zone route53.GetHostedZoneOutput
 	var td route53.ResourceTagSet
@@ -289,6 +310,36 @@ func testAccCheckRoute53ZoneAssociatesWithVpc(n string, zone *route53.GetHostedZ
 	}
 }
 
+func testAccCreateRoute53RecordsInZone(zone *route53.GetHostedZoneOutput, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).r53conn
+
+		changes := make([]*route53.Change, 0, count)
+		for i := 0; i < count; i++ {
+			changes = append(changes, &route53.Change{
+				Action: aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name: aws.String(fmt.Sprintf("%d-tf-acc-record.%s", i, *zone.HostedZone.Name)),
+					Type: aws.String(route53.RRTypeCname),
+					ResourceRecords: []*route53.ResourceRecord{
+						&route53.ResourceRecord{Value: aws.String("www.terraform.io")},
+					},
+					TTL: aws.Int64(30),
+				},
+			})
+		}
+
+		_, err := conn.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: zone.HostedZone.Id,
+			ChangeBatch: &route53.ChangeBatch{
+				Comment: aws.String("Generated by Terraform"),
+				Changes: changes,
+			},
+		})
+		return err
+	}
+}
+
 func testAccLoadTagsR53(zone *route53.GetHostedZoneOutput, td *route53.ResourceTagSet) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := testAccProvider.Meta().(*AWSClient).r53conn
@@ -324,6 +375,13 @@ resource "aws_route53_zone" "main" {
 }
 `
 
+const testAccRoute53ZoneConfigForceDestroy = `
+resource "aws_route53_zone" "destroyable" {
+	name = "terraform.io."
+	force_destroy = true
+}
+`
+
 const testAccRoute53ZoneConfigUpdateComment = `
 resource "aws_route53_zone" "main" {
 	name = "hashicorp.com."
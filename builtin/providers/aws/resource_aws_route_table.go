@@ -33,9 +33,12 @@ func resourceAwsRouteTable() *schema.Resource {
 
 			"tags": tagsSchema(),
 
+			// Computed, so that aws_vpn_gateway_route_propagation can
+			// manage the propagations of route tables which don't.
 			"propagating_vgws": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
@@ -48,7 +51,17 @@ func resourceAwsRouteTable() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"cidr_block": &schema.Schema{
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
+						},
+
+						"ipv6_cidr_block": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"egress_only_gateway_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
 						},
 
 						"gateway_id": &schema.Schema{
@@ -166,6 +179,12 @@ func resourceAwsRouteTableRead(d *schema.ResourceData, meta interface{}) error {
 		if r.DestinationCidrBlock != nil {
 			m["cidr_block"] = *r.DestinationCidrBlock
 		}
+		if r.DestinationIpv6CidrBlock != nil {
+			m["ipv6_cidr_block"] = *r.DestinationIpv6CidrBlock
+		}
+		if r.EgressOnlyInternetGatewayId != nil {
+			m["egress_only_gateway_id"] = *r.EgressOnlyInternetGatewayId
+		}
 		if r.GatewayId != nil {
 			m["gateway_id"] = *r.GatewayId
 		}
@@ -267,13 +286,17 @@ func resourceAwsRouteTableUpdate(d *schema.ResourceData, meta interface{}) error
 			m := route.(map[string]interface{})
 
 			// Delete the route as it no longer exists in the config
-			log.Printf(
-				"[INFO] Deleting route from %s: %s",
-				d.Id(), m["cidr_block"].(string))
-			_, err := conn.DeleteRoute(&ec2.DeleteRouteInput{
-				RouteTableId:         aws.String(d.Id()),
-				DestinationCidrBlock: aws.String(m["cidr_block"].(string)),
-			})
+			deleteOpts := &ec2.DeleteRouteInput{
+				RouteTableId: aws.String(d.Id()),
+			}
+			if ipv6Cidr := m["ipv6_cidr_block"].(string); ipv6Cidr != "" {
+				deleteOpts.DestinationIpv6CidrBlock = aws.String(ipv6Cidr)
+				log.Printf("[INFO] Deleting route from %s: %s", d.Id(), ipv6Cidr)
+			} else {
+				deleteOpts.DestinationCidrBlock = aws.String(m["cidr_block"].(string))
+				log.Printf("[INFO] Deleting route from %s: %s", d.Id(), m["cidr_block"].(string))
+			}
+			_, err := conn.DeleteRoute(deleteOpts)
 			if err != nil {
 				return err
 			}
@@ -289,13 +312,24 @@ func resourceAwsRouteTableUpdate(d *schema.ResourceData, meta interface{}) error
 
 			opts := ec2.CreateRouteInput{
 				RouteTableId:           aws.String(d.Id()),
-				DestinationCidrBlock:   aws.String(m["cidr_block"].(string)),
 				GatewayId:              aws.String(m["gateway_id"].(string)),
 				InstanceId:             aws.String(m["instance_id"].(string)),
 				VpcPeeringConnectionId: aws.String(m["vpc_peering_connection_id"].(string)),
 				NetworkInterfaceId:     aws.String(m["network_interface_id"].(string)),
 			}
 
+			if m["cidr_block"].(string) != "" {
+				opts.DestinationCidrBlock = aws.String(m["cidr_block"].(string))
+			}
+
+			if m["ipv6_cidr_block"].(string) != "" {
+				opts.DestinationIpv6CidrBlock = aws.String(m["ipv6_cidr_block"].(string))
+			}
+
+			if m["egress_only_gateway_id"].(string) != "" {
+				opts.EgressOnlyInternetGatewayId = aws.String(m["egress_only_gateway_id"].(string))
+			}
+
 			if m["nat_gateway_id"].(string) != "" {
 				opts.NatGatewayId = aws.String(m["nat_gateway_id"].(string))
 			}
@@ -394,6 +428,16 @@ func resourceAwsRouteTableHash(v interface{}) int {
 		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
 	}
 
+	// The IPv6 fields are only hashed when they are set, so that the hashes
+	// of IPv4 routes don't change.
+	if v, ok := m["ipv6_cidr_block"]; ok && v.(string) != "" {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+
+	if v, ok := m["egress_only_gateway_id"]; ok && v.(string) != "" {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+
 	if v, ok := m["gateway_id"]; ok {
 		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
 	}
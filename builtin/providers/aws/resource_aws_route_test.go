@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
@@ -238,6 +239,102 @@ func TestAccAWSRoute_mix(t *testing.T) {
 }
 */
 
+func TestAccAWSRoute_vpnGateway(t *testing.T) {
+	var route ec2.Route
+
+	testCheck := func(s *terraform.State) error {
+		name := "aws_vpn_gateway.foo"
+		gwres, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s\n", name)
+		}
+
+		if aws.StringValue(route.GatewayId) != gwres.Primary.ID {
+			return fmt.Errorf("VPN Gateway Id (Expected=%s, Actual=%s)\n", gwres.Primary.ID, aws.StringValue(route.GatewayId))
+		}
+
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSRouteDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSRouteVpnGatewayConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSRouteExists("aws_route.bar", &route),
+					testCheck,
+					resource.TestCheckResourceAttr(
+						"aws_route.bar", "origin", ec2.RouteOriginCreateRoute),
+					resource.TestCheckResourceAttr(
+						"aws_route.bar", "state", ec2.RouteStateActive),
+				),
+			},
+		},
+	})
+}
+
+func TestFindRouteByCidr(t *testing.T) {
+	routes := []*ec2.Route{
+		&ec2.Route{
+			DestinationCidrBlock: aws.String("10.1.0.0/16"),
+			GatewayId:            aws.String("local"),
+			Origin:               aws.String(ec2.RouteOriginCreateRouteTable),
+		},
+		&ec2.Route{
+			DestinationCidrBlock: aws.String("10.2.0.0/16"),
+			GatewayId:            aws.String("vgw-propagated"),
+			Origin:               aws.String(ec2.RouteOriginEnableVgwRoutePropagation),
+		},
+		&ec2.Route{
+			DestinationCidrBlock: aws.String("10.2.0.0/16"),
+			GatewayId:            aws.String("vgw-static"),
+			Origin:               aws.String(ec2.RouteOriginCreateRoute),
+		},
+		&ec2.Route{
+			DestinationCidrBlock: aws.String("10.3.0.0/16"),
+			GatewayId:            aws.String("vgw-propagated"),
+			Origin:               aws.String(ec2.RouteOriginEnableVgwRoutePropagation),
+		},
+		&ec2.Route{
+			DestinationIpv6CidrBlock:    aws.String("::/0"),
+			EgressOnlyInternetGatewayId: aws.String("eigw-static"),
+			Origin:                      aws.String(ec2.RouteOriginCreateRoute),
+		},
+	}
+
+	cases := map[string]string{
+		"10.1.0.0/16": "local",
+		"10.2.0.0/16": "vgw-static",
+		"10.3.0.0/16": "",
+		"10.4.0.0/16": "",
+	}
+	for cidr, expected := range cases {
+		route := findRouteByCidr(routes, cidr, "")
+		if expected == "" {
+			if route != nil {
+				t.Fatalf("%s: expected no route, got %s", cidr, route)
+			}
+			continue
+		}
+		if route == nil || aws.StringValue(route.GatewayId) != expected {
+			t.Fatalf("%s: expected route to %s, got %s", cidr, expected, route)
+		}
+	}
+
+	route := findRouteByCidr(routes, "", "::/0")
+	if route == nil || aws.StringValue(route.EgressOnlyInternetGatewayId) != "eigw-static" {
+		t.Fatalf("::/0: expected route to eigw-static, got %s", route)
+	}
+	if route := findRouteByCidr(routes, "", "2001:db8::/32"); route != nil {
+		t.Fatalf("2001:db8::/32: expected no route, got %s", route)
+	}
+}
+
 func testAccCheckAWSRouteExists(n string, res *ec2.Route) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -254,6 +351,7 @@ func testAccCheckAWSRouteExists(n string, res *ec2.Route) resource.TestCheckFunc
 			conn,
 			rs.Primary.Attributes["route_table_id"],
 			rs.Primary.Attributes["destination_cidr_block"],
+			rs.Primary.Attributes["destination_ipv6_cidr_block"],
 		)
 
 		if err != nil {
@@ -281,6 +379,7 @@ func testAccCheckAWSRouteDestroy(s *terraform.State) error {
 			conn,
 			rs.Primary.Attributes["route_table_id"],
 			rs.Primary.Attributes["destination_cidr_block"],
+			rs.Primary.Attributes["destination_ipv6_cidr_block"],
 		)
 
 		if route == nil && err == nil {
@@ -412,3 +511,24 @@ resource "aws_vpc_endpoint" "baz" {
   route_table_ids = ["${aws_route_table.foo.id}"]
 }
 `)
+
+var testAccAWSRouteVpnGatewayConfig = fmt.Sprint(`
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_vpn_gateway" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_route_table" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+	propagating_vgws = ["${aws_vpn_gateway.foo.id}"]
+}
+
+resource "aws_route" "bar" {
+	route_table_id = "${aws_route_table.foo.id}"
+	destination_cidr_block = "10.2.0.0/16"
+	gateway_id = "${aws_vpn_gateway.foo.id}"
+}
+`)
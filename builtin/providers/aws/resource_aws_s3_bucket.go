@@ -300,6 +300,55 @@ func resourceAwsS3Bucket() *schema.Resource {
 				Computed:     true,
 				ValidateFunc: validateS3BucketAccelerationStatus,
 			},
+
+			// Object lock can only be enabled when creating the bucket, so
+			// enabling it on an existing bucket recreates the bucket.
+			"object_lock_configuration": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_lock_enabled": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateS3BucketObjectLockEnabled,
+						},
+						"rule": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"default_retention": &schema.Schema{
+										Type:     schema.TypeList,
+										Required: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"mode": &schema.Schema{
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validateS3BucketObjectLockRetentionMode,
+												},
+												"days": &schema.Schema{
+													Type:     schema.TypeInt,
+													Optional: true,
+												},
+												"years": &schema.Schema{
+													Type:     schema.TypeInt,
+													Optional: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -327,6 +376,15 @@ func resourceAwsS3BucketCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if v := d.Get("object_lock_configuration").([]interface{}); len(v) > 0 {
+		// S3 turns on versioning for buckets with object lock, which has to
+		// be reflected in the configuration so it doesn't show up as a diff.
+		if err := validateS3BucketObjectLockVersioning(d); err != nil {
+			return err
+		}
+		req.ObjectLockEnabledForBucket = aws.Bool(true)
+	}
+
 	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
 		log.Printf("[DEBUG] Trying to create new S3 bucket: %q", bucket)
 		_, err := s3conn.CreateBucket(req)
@@ -408,6 +466,12 @@ func resourceAwsS3BucketUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.HasChange("object_lock_configuration") {
+		if err := resourceAwsS3BucketObjectLockConfigurationUpdate(s3conn, d); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsS3BucketRead(d, meta)
 }
 
@@ -548,6 +612,21 @@ func resourceAwsS3BucketRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	d.Set("acceleration_status", accelerate.Status)
 
+	// Read the object lock configuration
+	objectLock, err := s3conn.GetObjectLockConfiguration(&s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(d.Id()),
+	})
+	log.Printf("[DEBUG] S3 bucket: %s, read object lock configuration: %v", d.Id(), objectLock)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "ObjectLockConfigurationNotFoundError" {
+			return fmt.Errorf("error reading S3 bucket \"%s\" object lock configuration: %s", d.Id(), err)
+		}
+		objectLock = &s3.GetObjectLockConfigurationOutput{}
+	}
+	if err := d.Set("object_lock_configuration", flattenS3ObjectLockConfiguration(objectLock.ObjectLockConfiguration)); err != nil {
+		return err
+	}
+
 	// Read the logging configuration
 	logging, err := s3conn.GetBucketLogging(&s3.GetBucketLoggingInput{
 		Bucket: aws.String(d.Id()),
@@ -1138,6 +1217,42 @@ func resourceAwsS3BucketAccelerationUpdate(s3conn *s3.S3, d *schema.ResourceData
 	return nil
 }
 
+func resourceAwsS3BucketObjectLockConfigurationUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+
+	conf, err := expandS3ObjectLockConfiguration(d.Get("object_lock_configuration").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if conf == nil {
+		return nil
+	}
+
+	i := &s3.PutObjectLockConfigurationInput{
+		Bucket:                  aws.String(bucket),
+		ObjectLockConfiguration: conf,
+	}
+	log.Printf("[DEBUG] S3 put bucket object lock configuration: %#v", i)
+
+	_, err = s3conn.PutObjectLockConfiguration(i)
+	if err != nil {
+		return fmt.Errorf("Error putting S3 object lock configuration: %s", err)
+	}
+
+	return nil
+}
+
+// validateS3BucketObjectLockVersioning checks that a bucket configured with
+// object lock also has versioning enabled.
+func validateS3BucketObjectLockVersioning(d *schema.ResourceData) error {
+	for _, v := range d.Get("versioning").(*schema.Set).List() {
+		if v.(map[string]interface{})["enabled"].(bool) {
+			return nil
+		}
+	}
+	return fmt.Errorf("object_lock_configuration requires versioning to be enabled on S3 bucket %q", d.Get("bucket").(string))
+}
+
 func resourceAwsS3BucketLifecycleUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
 	bucket := d.Get("bucket").(string)
 
@@ -1269,6 +1384,77 @@ func resourceAwsS3BucketLifecycleUpdate(s3conn *s3.S3, d *schema.ResourceData) e
 	return nil
 }
 
+// expandS3ObjectLockConfiguration builds the object lock configuration from
+// object_lock_configuration. The default retention period has to be given in
+// exactly one of days or years.
+func expandS3ObjectLockConfiguration(l []interface{}) (*s3.ObjectLockConfiguration, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	m := l[0].(map[string]interface{})
+
+	conf := &s3.ObjectLockConfiguration{
+		ObjectLockEnabled: aws.String(m["object_lock_enabled"].(string)),
+	}
+
+	rules := m["rule"].([]interface{})
+	if len(rules) == 0 || rules[0] == nil {
+		return conf, nil
+	}
+	retentions := rules[0].(map[string]interface{})["default_retention"].([]interface{})
+	if len(retentions) == 0 || retentions[0] == nil {
+		return conf, nil
+	}
+	r := retentions[0].(map[string]interface{})
+
+	retention := &s3.DefaultRetention{
+		Mode: aws.String(r["mode"].(string)),
+	}
+	days, years := r["days"].(int), r["years"].(int)
+	switch {
+	case days > 0 && years > 0:
+		return nil, fmt.Errorf("Only one of days or years can be set for the object lock default retention")
+	case days > 0:
+		retention.Days = aws.Int64(int64(days))
+	case years > 0:
+		retention.Years = aws.Int64(int64(years))
+	default:
+		return nil, fmt.Errorf("One of days or years must be set for the object lock default retention")
+	}
+
+	conf.Rule = &s3.ObjectLockRule{
+		DefaultRetention: retention,
+	}
+	return conf, nil
+}
+
+func flattenS3ObjectLockConfiguration(conf *s3.ObjectLockConfiguration) []interface{} {
+	if conf == nil || aws.StringValue(conf.ObjectLockEnabled) == "" {
+		return []interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"object_lock_enabled": aws.StringValue(conf.ObjectLockEnabled),
+	}
+
+	if conf.Rule != nil && conf.Rule.DefaultRetention != nil {
+		r := conf.Rule.DefaultRetention
+		m["rule"] = []interface{}{
+			map[string]interface{}{
+				"default_retention": []interface{}{
+					map[string]interface{}{
+						"mode":  aws.StringValue(r.Mode),
+						"days":  int(aws.Int64Value(r.Days)),
+						"years": int(aws.Int64Value(r.Years)),
+					},
+				},
+			},
+		}
+	}
+
+	return []interface{}{m}
+}
+
 func normalizeRoutingRules(w []*s3.RoutingRule) (string, error) {
 	withNulls, err := json.Marshal(w)
 	if err != nil {
@@ -0,0 +1,204 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsS3BucketMetric() *schema.Resource {
+	return &schema.Resource{
+		// PutBucketMetricsConfiguration replaces the whole configuration, so
+		// these can be the same.
+		Create: resourceAwsS3BucketMetricPut,
+		Update: resourceAwsS3BucketMetricPut,
+
+		Read:   resourceAwsS3BucketMetricRead,
+		Delete: resourceAwsS3BucketMetricDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"filter": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"tags": tagsSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsS3BucketMetricPut(d *schema.ResourceData, meta interface{}) error {
+	s3conn := meta.(*AWSClient).s3conn
+
+	bucket := d.Get("bucket").(string)
+	name := d.Get("name").(string)
+
+	metricsConfiguration := &s3.MetricsConfiguration{
+		Id: aws.String(name),
+	}
+	if v, ok := d.GetOk("filter"); ok {
+		filterList := v.([]interface{})
+		if len(filterList) > 0 && filterList[0] != nil {
+			metricsConfiguration.Filter = expandS3MetricsFilter(filterList[0].(map[string]interface{}))
+		}
+	}
+
+	input := &s3.PutBucketMetricsConfigurationInput{
+		Bucket:               aws.String(bucket),
+		Id:                   aws.String(name),
+		MetricsConfiguration: metricsConfiguration,
+	}
+
+	log.Printf("[DEBUG] Putting S3 bucket metrics configuration: %s", input)
+	if _, err := s3conn.PutBucketMetricsConfiguration(input); err != nil {
+		return fmt.Errorf("Error putting S3 bucket metrics configuration %s for bucket %s: %s", name, bucket, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", bucket, name))
+	return resourceAwsS3BucketMetricRead(d, meta)
+}
+
+func resourceAwsS3BucketMetricRead(d *schema.ResourceData, meta interface{}) error {
+	s3conn := meta.(*AWSClient).s3conn
+
+	bucket, name, err := resourceAwsS3BucketMetricParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := s3conn.GetBucketMetricsConfiguration(&s3.GetBucketMetricsConfigurationInput{
+		Bucket: aws.String(bucket),
+		Id:     aws.String(name),
+	})
+	if err != nil {
+		if isS3BucketMetricNotFoundErr(err) {
+			log.Printf("[WARN] S3 bucket metrics configuration %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading S3 bucket metrics configuration %s: %s", d.Id(), err)
+	}
+
+	d.Set("bucket", bucket)
+	d.Set("name", name)
+
+	filter := []interface{}{}
+	if resp.MetricsConfiguration != nil && resp.MetricsConfiguration.Filter != nil {
+		filter = append(filter, flattenS3MetricsFilter(resp.MetricsConfiguration.Filter))
+	}
+	if err := d.Set("filter", filter); err != nil {
+		return fmt.Errorf("Error setting filter of S3 bucket metrics configuration %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsS3BucketMetricDelete(d *schema.ResourceData, meta interface{}) error {
+	s3conn := meta.(*AWSClient).s3conn
+
+	bucket, name, err := resourceAwsS3BucketMetricParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting S3 bucket metrics configuration: %s", d.Id())
+	_, err = s3conn.DeleteBucketMetricsConfiguration(&s3.DeleteBucketMetricsConfigurationInput{
+		Bucket: aws.String(bucket),
+		Id:     aws.String(name),
+	})
+	if err != nil {
+		if isS3BucketMetricNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting S3 bucket metrics configuration %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsS3BucketMetricParseId(id string) (bucket, name string, err error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Unexpected format of ID (%s), expected BUCKET:NAME", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func isS3BucketMetricNotFoundErr(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && (awsErr.Code() == "NoSuchConfiguration" || awsErr.Code() == s3.ErrCodeNoSuchBucket)
+}
+
+// expandS3MetricsFilter builds the metrics filter for a filter block. S3
+// takes a bare prefix or a single tag as is, but anything more has to be
+// wrapped in an And operator.
+func expandS3MetricsFilter(m map[string]interface{}) *s3.MetricsFilter {
+	prefix, _ := m["prefix"].(string)
+
+	var tags []*s3.Tag
+	if v, ok := m["tags"].(map[string]interface{}); ok {
+		tags = tagsFromMapS3(v)
+	}
+
+	filter := &s3.MetricsFilter{}
+	switch {
+	case prefix != "" && len(tags) > 0, len(tags) > 1:
+		filter.And = &s3.MetricsAndOperator{
+			Tags: tags,
+		}
+		if prefix != "" {
+			filter.And.Prefix = aws.String(prefix)
+		}
+	case len(tags) == 1:
+		filter.Tag = tags[0]
+	default:
+		filter.Prefix = aws.String(prefix)
+	}
+
+	return filter
+}
+
+func flattenS3MetricsFilter(filter *s3.MetricsFilter) map[string]interface{} {
+	m := map[string]interface{}{}
+
+	if filter.And != nil {
+		if filter.And.Prefix != nil {
+			m["prefix"] = *filter.And.Prefix
+		}
+		if len(filter.And.Tags) > 0 {
+			m["tags"] = tagsToMapS3(filter.And.Tags)
+		}
+	} else if filter.Prefix != nil {
+		m["prefix"] = *filter.Prefix
+	} else if filter.Tag != nil {
+		m["tags"] = tagsToMapS3([]*s3.Tag{filter.Tag})
+	}
+
+	return m
+}
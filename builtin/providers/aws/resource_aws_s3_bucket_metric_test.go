@@ -0,0 +1,233 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSS3BucketMetric_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "aws_s3_bucket_metric.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketMetricDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSS3BucketMetricConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketMetricExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "EntireBucket"),
+					resource.TestCheckResourceAttr(resourceName, "filter.#", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSS3BucketMetricConfigWithFilter(rInt, "prefix1/"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketMetricExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "filter.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "filter.0.prefix", "prefix1/"),
+					resource.TestCheckResourceAttr(resourceName, "filter.0.tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "filter.0.tags.Environment", "test"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSS3BucketMetricConfigWithFilter(rInt, "prefix2/"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketMetricExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "filter.0.prefix", "prefix2/"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandS3MetricsFilter(t *testing.T) {
+	cases := []struct {
+		Input    map[string]interface{}
+		Expected *s3.MetricsFilter
+	}{
+		{
+			Input: map[string]interface{}{
+				"prefix": "logs/",
+			},
+			Expected: &s3.MetricsFilter{
+				Prefix: aws.String("logs/"),
+			},
+		},
+		{
+			Input: map[string]interface{}{
+				"tags": map[string]interface{}{"Environment": "test"},
+			},
+			Expected: &s3.MetricsFilter{
+				Tag: &s3.Tag{Key: aws.String("Environment"), Value: aws.String("test")},
+			},
+		},
+		{
+			Input: map[string]interface{}{
+				"prefix": "logs/",
+				"tags":   map[string]interface{}{"Environment": "test"},
+			},
+			Expected: &s3.MetricsFilter{
+				And: &s3.MetricsAndOperator{
+					Prefix: aws.String("logs/"),
+					Tags: []*s3.Tag{
+						&s3.Tag{Key: aws.String("Environment"), Value: aws.String("test")},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		filter := expandS3MetricsFilter(tc.Input)
+		if !reflect.DeepEqual(filter, tc.Expected) {
+			t.Fatalf("Expected %s for %#v, got: %s", tc.Expected, tc.Input, filter)
+		}
+	}
+}
+
+func TestFlattenS3MetricsFilter(t *testing.T) {
+	cases := []struct {
+		Input    *s3.MetricsFilter
+		Expected map[string]interface{}
+	}{
+		{
+			Input: &s3.MetricsFilter{
+				Prefix: aws.String("logs/"),
+			},
+			Expected: map[string]interface{}{
+				"prefix": "logs/",
+			},
+		},
+		{
+			Input: &s3.MetricsFilter{
+				Tag: &s3.Tag{Key: aws.String("Environment"), Value: aws.String("test")},
+			},
+			Expected: map[string]interface{}{
+				"tags": map[string]string{"Environment": "test"},
+			},
+		},
+		{
+			Input: &s3.MetricsFilter{
+				And: &s3.MetricsAndOperator{
+					Prefix: aws.String("logs/"),
+					Tags: []*s3.Tag{
+						&s3.Tag{Key: aws.String("Environment"), Value: aws.String("test")},
+						&s3.Tag{Key: aws.String("Team"), Value: aws.String("storage")},
+					},
+				},
+			},
+			Expected: map[string]interface{}{
+				"prefix": "logs/",
+				"tags":   map[string]string{"Environment": "test", "Team": "storage"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		m := flattenS3MetricsFilter(tc.Input)
+		if !reflect.DeepEqual(m, tc.Expected) {
+			t.Fatalf("Expected %#v for %s, got: %#v", tc.Expected, tc.Input, m)
+		}
+	}
+}
+
+func testAccCheckAWSS3BucketMetricDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).s3conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_s3_bucket_metric" {
+			continue
+		}
+
+		bucket, name, err := resourceAwsS3BucketMetricParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.GetBucketMetricsConfiguration(&s3.GetBucketMetricsConfigurationInput{
+			Bucket: aws.String(bucket),
+			Id:     aws.String(name),
+		})
+		if err != nil {
+			if isS3BucketMetricNotFoundErr(err) {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("S3 bucket metrics configuration %s still exists", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccCheckAWSS3BucketMetricExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		bucket, name, err := resourceAwsS3BucketMetricParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).s3conn
+		_, err = conn.GetBucketMetricsConfiguration(&s3.GetBucketMetricsConfigurationInput{
+			Bucket: aws.String(bucket),
+			Id:     aws.String(name),
+		})
+
+		return err
+	}
+}
+
+func testAccAWSS3BucketMetricConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-metric-%d"
+	acl = "private"
+}
+
+resource "aws_s3_bucket_metric" "test" {
+	bucket = "${aws_s3_bucket.bucket.id}"
+	name = "EntireBucket"
+}
+`, rInt)
+}
+
+func testAccAWSS3BucketMetricConfigWithFilter(rInt int, prefix string) string {
+	return fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-metric-%d"
+	acl = "private"
+}
+
+resource "aws_s3_bucket_metric" "test" {
+	bucket = "${aws_s3_bucket.bucket.id}"
+	name = "EntireBucket"
+
+	filter {
+		prefix = "%s"
+		tags {
+			Environment = "test"
+		}
+	}
+}
+`, rInt, prefix)
+}
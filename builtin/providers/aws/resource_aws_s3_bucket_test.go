@@ -327,6 +327,70 @@ func TestAccAWSS3Bucket_Versioning(t *testing.T) {
 	})
 }
 
+func TestAccAWSS3Bucket_objectLock(t *testing.T) {
+	rInt := acctest.RandInt()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSS3BucketConfigWithObjectLock(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					testAccCheckAWSS3BucketObjectLock(
+						"aws_s3_bucket.bucket", s3.ObjectLockRetentionModeCompliance),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "object_lock_configuration.0.object_lock_enabled", "Enabled"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "object_lock_configuration.0.rule.0.default_retention.0.mode", "COMPLIANCE"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "object_lock_configuration.0.rule.0.default_retention.0.days", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandS3ObjectLockConfiguration(t *testing.T) {
+	retention := func(days, years int) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"object_lock_enabled": "Enabled",
+				"rule": []interface{}{
+					map[string]interface{}{
+						"default_retention": []interface{}{
+							map[string]interface{}{
+								"mode":  "COMPLIANCE",
+								"days":  days,
+								"years": years,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	conf, err := expandS3ObjectLockConfiguration(retention(3, 0))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if v := aws.Int64Value(conf.Rule.DefaultRetention.Days); v != 3 {
+		t.Fatalf("Expected 3 days of default retention, got: %d", v)
+	}
+	if conf.Rule.DefaultRetention.Years != nil {
+		t.Fatalf("Expected no years of default retention, got: %d", *conf.Rule.DefaultRetention.Years)
+	}
+
+	if _, err := expandS3ObjectLockConfiguration(retention(3, 1)); err == nil {
+		t.Fatalf("Expected an error for both days and years")
+	}
+	if _, err := expandS3ObjectLockConfiguration(retention(0, 0)); err == nil {
+		t.Fatalf("Expected an error for neither days nor years")
+	}
+}
+
 func TestAccAWSS3Bucket_Cors(t *testing.T) {
 	rInt := acctest.RandInt()
 	resource.Test(t, resource.TestCase{
@@ -668,6 +732,30 @@ func testAccCheckAWSS3BucketVersioning(n string, versioningStatus string) resour
 	}
 }
 
+func testAccCheckAWSS3BucketObjectLock(n string, mode string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, _ := s.RootModule().Resources[n]
+		conn := testAccProvider.Meta().(*AWSClient).s3conn
+
+		out, err := conn.GetObjectLockConfiguration(&s3.GetObjectLockConfigurationInput{
+			Bucket: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return fmt.Errorf("GetObjectLockConfiguration error: %v", err)
+		}
+
+		conf := out.ObjectLockConfiguration
+		if conf == nil || aws.StringValue(conf.ObjectLockEnabled) != s3.ObjectLockEnabledEnabled {
+			return fmt.Errorf("bad object lock configuration, expected object lock to be enabled, got: %s", out)
+		}
+		if conf.Rule == nil || conf.Rule.DefaultRetention == nil || aws.StringValue(conf.Rule.DefaultRetention.Mode) != mode {
+			return fmt.Errorf("bad object lock configuration, expected default retention mode %s, got: %s", mode, out)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAWSS3BucketCors(n string, corsRules []*s3.CORSRule) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, _ := s.RootModule().Resources[n]
@@ -885,6 +973,27 @@ resource "aws_s3_bucket" "bucket" {
 `, randInt)
 }
 
+func testAccAWSS3BucketConfigWithObjectLock(randInt int) string {
+	return fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+	versioning {
+	  enabled = true
+	}
+	object_lock_configuration {
+	  object_lock_enabled = "Enabled"
+	  rule {
+	    default_retention {
+	      mode = "COMPLIANCE"
+	      days = 3
+	    }
+	  }
+	}
+}
+`, randInt)
+}
+
 func testAccAWSS3BucketConfigWithDisableVersioning(randInt int) string {
 	return fmt.Sprintf(`
 resource "aws_s3_bucket" "bucket" {
@@ -86,6 +86,20 @@ func TestIpPermissionIDHash(t *testing.T) {
 		},
 	}
 
+	prefix_list_source := &ec2.IpPermission{
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(int64(443)),
+		ToPort:     aws.Int64(int64(443)),
+		PrefixListIds: []*ec2.PrefixListId{
+			&ec2.PrefixListId{
+				PrefixListId: aws.String("pl-68a54001"),
+			},
+			&ec2.PrefixListId{
+				PrefixListId: aws.String("pl-12345678"),
+			},
+		},
+	}
+
 	// hardcoded hashes, to detect future change
 	cases := []struct {
 		Input  *ec2.IpPermission
@@ -97,6 +111,7 @@ func TestIpPermissionIDHash(t *testing.T) {
 		{egress_all, "egress", "sgrule-766323498"},
 		{vpc_security_group_source, "egress", "sgrule-351225364"},
 		{security_group_source, "egress", "sgrule-2198807188"},
+		{prefix_list_source, "egress", "sgrule-3502254139"},
 	}
 
 	for _, tc := range cases {
@@ -289,6 +304,28 @@ func TestAccAWSSecurityGroupRule_SelfReference(t *testing.T) {
 				Config: testAccAWSSecurityGroupRuleConfigSelfReference,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSSecurityGroupRuleExists("aws_security_group.web", &group),
+					resource.TestCheckResourceAttr("aws_security_group_rule.self", "self", "true"),
+					testAccCheckAWSSecurityGroupRuleSelfIngress(&group),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSecurityGroupRule_PrefixListEgress(t *testing.T) {
+	var group ec2.SecurityGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSecurityGroupRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSSecurityGroupRulePrefixListEgressConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSecurityGroupRuleExists("aws_security_group.egress", &group),
+					resource.TestCheckResourceAttr("aws_security_group_rule.egress_1", "prefix_list_ids.#", "1"),
+					testAccCheckAWSSecurityGroupRulePrefixListEgress(&group, "aws_vpc_endpoint.s3-us-west-2"),
 				),
 			},
 		},
@@ -479,6 +516,44 @@ func testAccCheckAWSSecurityGroupRuleExists(n string, group *ec2.SecurityGroup)
 	}
 }
 
+func testAccCheckAWSSecurityGroupRuleSelfIngress(group *ec2.SecurityGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range group.IpPermissions {
+			for _, pair := range r.UserIdGroupPairs {
+				if pair.GroupId != nil && *pair.GroupId == *group.GroupId {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("No self-referencing ingress rule found in %s", group.IpPermissions)
+	}
+}
+
+func testAccCheckAWSSecurityGroupRulePrefixListEgress(group *ec2.SecurityGroup, endpoint string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[endpoint]
+		if !ok {
+			return fmt.Errorf("Not found: %s", endpoint)
+		}
+
+		prefixListID := rs.Primary.Attributes["prefix_list_id"]
+		if prefixListID == "" {
+			return fmt.Errorf("No prefix list ID is set on %s", endpoint)
+		}
+
+		for _, r := range group.IpPermissionsEgress {
+			for _, pl := range r.PrefixListIds {
+				if *pl.PrefixListId == prefixListID {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("No egress rule for prefix list %s found in %s", prefixListID, group.IpPermissionsEgress)
+	}
+}
+
 func testAccCheckAWSSecurityGroupRuleAttributes(n string, group *ec2.SecurityGroup, p *ec2.IpPermission, ruleType string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -741,6 +816,44 @@ resource "aws_security_group_rule" "self" {
 }
 `
 
+const testAccAWSSecurityGroupRulePrefixListEgressConfig = `
+provider "aws" {
+  region = "us-west-2"
+}
+
+resource "aws_vpc" "tf_sg_prefix_list_egress_test" {
+  cidr_block = "10.0.0.0/16"
+  tags {
+    Name = "tf_sg_prefix_list_egress_test"
+  }
+}
+
+resource "aws_route_table" "default" {
+  vpc_id = "${aws_vpc.tf_sg_prefix_list_egress_test.id}"
+}
+
+resource "aws_vpc_endpoint" "s3-us-west-2" {
+  vpc_id = "${aws_vpc.tf_sg_prefix_list_egress_test.id}"
+  service_name = "com.amazonaws.us-west-2.s3"
+  route_table_ids = ["${aws_route_table.default.id}"]
+}
+
+resource "aws_security_group" "egress" {
+  name = "terraform_acceptance_test_prefix_list_egress"
+  description = "Used in the terraform acceptance tests"
+  vpc_id = "${aws_vpc.tf_sg_prefix_list_egress_test.id}"
+}
+
+resource "aws_security_group_rule" "egress_1" {
+  type = "egress"
+  protocol = "-1"
+  from_port = 0
+  to_port = 0
+  prefix_list_ids = ["${aws_vpc_endpoint.s3-us-west-2.prefix_list_id}"]
+  security_group_id = "${aws_security_group.egress.id}"
+}
+`
+
 const testAccAWSSecurityGroupRulePartialMatching = `
 resource "aws_vpc" "default" {
   cidr_block = "10.0.0.0/16"
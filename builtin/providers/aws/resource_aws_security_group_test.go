@@ -288,6 +288,49 @@ func TestAccAWSSecurityGroup_namePrefix(t *testing.T) {
 	})
 }
 
+func TestAccAWSSecurityGroup_namePrefixCreateBeforeDestroy(t *testing.T) {
+	var before, after ec2.SecurityGroup
+
+	testCheckReplaced := func(*terraform.State) error {
+		if *before.GroupId == *after.GroupId {
+			return fmt.Errorf("Expected security group %s to be replaced", *before.GroupId)
+		}
+		if *before.GroupName == *after.GroupName {
+			return fmt.Errorf("Expected a new name for the replacement, got %s twice", *after.GroupName)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSSecurityGroupPrefixNameConfigCreateBeforeDestroy("first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSecurityGroupExists("aws_security_group.baz", &before),
+					testAccCheckAWSSecurityGroupGeneratedNamePrefix(
+						"aws_security_group.baz", "baz-"),
+				),
+			},
+
+			// Changing the description replaces the group. The replacement
+			// is created while the old group still exists, so it can't
+			// reuse its name.
+			resource.TestStep{
+				Config: testAccAWSSecurityGroupPrefixNameConfigCreateBeforeDestroy("second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSecurityGroupExists("aws_security_group.baz", &after),
+					testAccCheckAWSSecurityGroupGeneratedNamePrefix(
+						"aws_security_group.baz", "baz-"),
+					testCheckReplaced,
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSSecurityGroup_self(t *testing.T) {
 	var group ec2.SecurityGroup
 
@@ -1293,6 +1336,19 @@ resource "aws_security_group" "baz" {
 }
 `
 
+func testAccAWSSecurityGroupPrefixNameConfigCreateBeforeDestroy(description string) string {
+	return fmt.Sprintf(`
+resource "aws_security_group" "baz" {
+   name_prefix = "baz-"
+   description = "Used in the terraform acceptance tests (%s)"
+
+   lifecycle {
+      create_before_destroy = true
+   }
+}
+`, description)
+}
+
 func testAccAWSSecurityGroupConfig_drift() string {
 	return fmt.Sprintf(`
 resource "aws_security_group" "web" {
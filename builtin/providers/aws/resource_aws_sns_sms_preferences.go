@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Mutable attributes
+var SNSSMSAttributeMap = map[string]string{
+	"monthly_spend_limit": "MonthlySpendLimit",
+	"default_sender_id":   "DefaultSenderID",
+	"default_sms_type":    "DefaultSMSType",
+}
+
+// The SMS preferences are a per-account, per-region setting, so there is
+// only ever one of these resources and it has a fixed ID.
+const snsSmsPreferencesId = "aws_sns_sms_id"
+
+func resourceAwsSnsSmsPreferences() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSnsSmsPreferencesSet,
+		Read:   resourceAwsSnsSmsPreferencesGet,
+		Update: resourceAwsSnsSmsPreferencesSet,
+		Delete: resourceAwsSnsSmsPreferencesDelete,
+
+		Schema: map[string]*schema.Schema{
+			"monthly_spend_limit": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSnsSmsMonthlySpendLimit,
+			},
+			"default_sender_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"default_sms_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSnsSmsType,
+			},
+		},
+	}
+}
+
+func resourceAwsSnsSmsPreferencesSet(d *schema.ResourceData, meta interface{}) error {
+	snsconn := meta.(*AWSClient).snsconn
+
+	attributes := make(map[string]*string)
+	for k, attrKey := range SNSSMSAttributeMap {
+		attributes[attrKey] = aws.String(d.Get(k).(string))
+	}
+
+	log.Printf("[DEBUG] Setting SNS SMS preferences: %#v", aws.StringValueMap(attributes))
+	_, err := snsconn.SetSMSAttributes(&sns.SetSMSAttributesInput{
+		Attributes: attributes,
+	})
+	if err != nil {
+		return fmt.Errorf("Error setting SNS SMS preferences: %s", err)
+	}
+
+	d.SetId(snsSmsPreferencesId)
+
+	return resourceAwsSnsSmsPreferencesGet(d, meta)
+}
+
+func resourceAwsSnsSmsPreferencesGet(d *schema.ResourceData, meta interface{}) error {
+	snsconn := meta.(*AWSClient).snsconn
+
+	attrNames := make([]*string, 0, len(SNSSMSAttributeMap))
+	for _, attrKey := range SNSSMSAttributeMap {
+		attrNames = append(attrNames, aws.String(attrKey))
+	}
+
+	output, err := snsconn.GetSMSAttributes(&sns.GetSMSAttributesInput{
+		Attributes: attrNames,
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading SNS SMS preferences: %s", err)
+	}
+
+	// iKey = internal struct key, oKey = AWS Attribute Map key
+	for iKey, oKey := range SNSSMSAttributeMap {
+		value := ""
+		if v, ok := output.Attributes[oKey]; ok && v != nil {
+			value = *v
+		}
+		log.Printf("[DEBUG] Reading %s => %s -> %s", iKey, oKey, value)
+		d.Set(iKey, value)
+	}
+
+	return nil
+}
+
+func resourceAwsSnsSmsPreferencesDelete(d *schema.ResourceData, meta interface{}) error {
+	snsconn := meta.(*AWSClient).snsconn
+
+	// Destroying the resource puts the account back on the AWS defaults,
+	// which the API represents as empty attribute values.
+	attributes := make(map[string]*string)
+	for _, attrKey := range SNSSMSAttributeMap {
+		attributes[attrKey] = aws.String("")
+	}
+
+	log.Printf("[DEBUG] Resetting SNS SMS preferences")
+	_, err := snsconn.SetSMSAttributes(&sns.SetSMSAttributesInput{
+		Attributes: attributes,
+	})
+	if err != nil {
+		return fmt.Errorf("Error resetting SNS SMS preferences: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,146 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSSNSSMSPreferences_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSNSSMSPreferencesDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSSNSSMSPreferencesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSNSSMSPreferences("MonthlySpendLimit", "1"),
+					testAccCheckAWSSNSSMSPreferences("DefaultSenderID", "tf-test"),
+					testAccCheckAWSSNSSMSPreferences("DefaultSMSType", "Promotional"),
+					resource.TestCheckResourceAttr(
+						"aws_sns_sms_preferences.test", "default_sms_type", "Promotional"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSSNSSMSPreferencesConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSNSSMSPreferences("DefaultSenderID", "tf-updated"),
+					testAccCheckAWSSNSSMSPreferences("DefaultSMSType", "Transactional"),
+					resource.TestCheckResourceAttr(
+						"aws_sns_sms_preferences.test", "default_sms_type", "Transactional"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsSnsSmsPreferencesDelete_reset(t *testing.T) {
+	// Capture the reset request instead of sending it.
+	var req *sns.SetSMSAttributesInput
+	errStop := errors.New("request not sent")
+	conn := sns.New(session.New(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("accessKey", "secretKey", ""),
+		Region:      aws.String("us-west-2"),
+	}))
+	conn.Handlers.Send.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		req, _ = r.Params.(*sns.SetSMSAttributesInput)
+		r.Error = errStop
+	})
+	conn.Handlers.Retry.Clear()
+
+	state := &terraform.InstanceState{
+		ID: snsSmsPreferencesId,
+		Attributes: map[string]string{
+			"monthly_spend_limit": "10",
+			"default_sender_id":   "tf-test",
+			"default_sms_type":    "Transactional",
+		},
+	}
+	diff := &terraform.InstanceDiff{Destroy: true}
+
+	_, err := resourceAwsSnsSmsPreferences().Apply(state, diff, &AWSClient{snsconn: conn})
+	if err == nil || !strings.Contains(err.Error(), errStop.Error()) {
+		t.Fatalf("Expected the reset request to be stopped, got: %v", err)
+	}
+	if req == nil {
+		t.Fatalf("Expected a SetSMSAttributes request")
+	}
+	for _, attrKey := range SNSSMSAttributeMap {
+		v, ok := req.Attributes[attrKey]
+		if !ok || v == nil || *v != "" {
+			t.Fatalf("Expected %s to be reset to an empty value, got: %#v", attrKey, req.Attributes)
+		}
+	}
+}
+
+func testAccCheckAWSSNSSMSPreferences(attrKey, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).snsconn
+
+		output, err := conn.GetSMSAttributes(&sns.GetSMSAttributesInput{
+			Attributes: []*string{aws.String(attrKey)},
+		})
+		if err != nil {
+			return err
+		}
+
+		actual := ""
+		if v, ok := output.Attributes[attrKey]; ok && v != nil {
+			actual = *v
+		}
+		if actual != expected {
+			return fmt.Errorf("Expected SMS attribute %s to be %q, got %q", attrKey, expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccCheckAWSSNSSMSPreferencesDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).snsconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_sns_sms_preferences" {
+			continue
+		}
+
+		output, err := conn.GetSMSAttributes(&sns.GetSMSAttributesInput{})
+		if err != nil {
+			return err
+		}
+
+		for _, attrKey := range []string{"DefaultSenderID", "DefaultSMSType"} {
+			if v, ok := output.Attributes[attrKey]; ok && v != nil && *v != "" {
+				return fmt.Errorf("SMS attribute %s was not reset, got %q", attrKey, *v)
+			}
+		}
+	}
+
+	return nil
+}
+
+const testAccAWSSNSSMSPreferencesConfig = `
+resource "aws_sns_sms_preferences" "test" {
+  monthly_spend_limit = "1"
+  default_sender_id   = "tf-test"
+  default_sms_type    = "Promotional"
+}
+`
+
+const testAccAWSSNSSMSPreferencesConfigUpdate = `
+resource "aws_sns_sms_preferences" "test" {
+  monthly_spend_limit = "1"
+  default_sender_id   = "tf-updated"
+  default_sms_type    = "Transactional"
+}
+`
@@ -23,6 +23,19 @@ func resourceAwsSpotInstanceRequest() *schema.Resource {
 			// The Spot Instance Request Schema is based on the AWS Instance schema.
 			s := resourceAwsInstance().Schema
 
+			// Spot requests can't be launched with hibernation configured
+			delete(s, "hibernation")
+
+			// Spot launch specifications have no metadata options
+			delete(s, "metadata_options")
+
+			// Spot instances can't be placed on dedicated hosts
+			delete(s, "host_id")
+			delete(s, "allow_stopping_for_update")
+
+			// Spot instances are replaced on any change of user_data
+			delete(s, "user_data_replace_on_change")
+
 			// Everything on a spot instance is ForceNew except tags
 			for k, v := range s {
 				if k == "tags" {
@@ -15,14 +15,16 @@ import (
 )
 
 var AttributeMap = map[string]string{
-	"delay_seconds":              "DelaySeconds",
-	"max_message_size":           "MaximumMessageSize",
-	"message_retention_seconds":  "MessageRetentionPeriod",
-	"receive_wait_time_seconds":  "ReceiveMessageWaitTimeSeconds",
-	"visibility_timeout_seconds": "VisibilityTimeout",
-	"policy":                     "Policy",
-	"redrive_policy":             "RedrivePolicy",
-	"arn":                        "QueueArn",
+	"delay_seconds":                     "DelaySeconds",
+	"max_message_size":                  "MaximumMessageSize",
+	"message_retention_seconds":         "MessageRetentionPeriod",
+	"receive_wait_time_seconds":         "ReceiveMessageWaitTimeSeconds",
+	"visibility_timeout_seconds":        "VisibilityTimeout",
+	"policy":                            "Policy",
+	"redrive_policy":                    "RedrivePolicy",
+	"arn":                               "QueueArn",
+	"kms_master_key_id":                 "KmsMasterKeyId",
+	"kms_data_key_reuse_period_seconds": "KmsDataKeyReusePeriodSeconds",
 }
 
 // A number of these are marked as computed because if you don't
@@ -92,6 +94,16 @@ func resourceAwsSqsQueue() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"kms_master_key_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"kms_data_key_reuse_period_seconds": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateIntegerInRange(60, 86400),
+			},
 		},
 	}
 }
@@ -163,7 +175,9 @@ func resourceAwsSqsQueueUpdate(d *schema.ResourceData, meta interface{}) error {
 			QueueUrl:   aws.String(d.Id()),
 			Attributes: attributes,
 		}
-		sqsconn.SetQueueAttributes(req)
+		if _, err := sqsconn.SetQueueAttributes(req); err != nil {
+			return fmt.Errorf("Error updating SQS queue (%s) attributes: %s", d.Id(), err)
+		}
 	}
 
 	return resourceAwsSqsQueueRead(d, meta)
@@ -67,6 +67,36 @@ func TestAccAWSSQSQueue_Policybasic(t *testing.T) {
 	})
 }
 
+func TestAccAWSSQSQueue_kmsEncryption(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSQSQueueDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSSQSConfigWithKms(rName, 300),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSQSQueueKms("aws_sqs_queue.queue-with-kms", "alias/aws/sqs", "300"),
+					resource.TestCheckResourceAttr(
+						"aws_sqs_queue.queue-with-kms", "kms_master_key_id", "alias/aws/sqs"),
+					resource.TestCheckResourceAttr(
+						"aws_sqs_queue.queue-with-kms", "kms_data_key_reuse_period_seconds", "300"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSSQSConfigWithKms(rName, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSQSQueueKms("aws_sqs_queue.queue-with-kms", "alias/aws/sqs", "3600"),
+					resource.TestCheckResourceAttr(
+						"aws_sqs_queue.queue-with-kms", "kms_data_key_reuse_period_seconds", "3600"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAWSSQSQueueDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).sqsconn
 
@@ -194,6 +224,40 @@ func testAccCheckAWSSQSExistsWithOverrides(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckAWSSQSQueueKms(n, keyId, reusePeriod string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Queue URL specified!")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).sqsconn
+		resp, err := conn.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+			QueueUrl: aws.String(rs.Primary.ID),
+			AttributeNames: []*string{
+				aws.String(sqs.QueueAttributeNameKmsMasterKeyId),
+				aws.String(sqs.QueueAttributeNameKmsDataKeyReusePeriodSeconds),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if v := aws.StringValue(resp.Attributes[sqs.QueueAttributeNameKmsMasterKeyId]); v != keyId {
+			return fmt.Errorf("KmsMasterKeyId (%s) was not set to %s", v, keyId)
+		}
+		if v := aws.StringValue(resp.Attributes[sqs.QueueAttributeNameKmsDataKeyReusePeriodSeconds]); v != reusePeriod {
+			return fmt.Errorf("KmsDataKeyReusePeriodSeconds (%s) was not set to %s", v, reusePeriod)
+		}
+
+		return nil
+	}
+}
+
 const testAccAWSSQSConfigWithDefaults = `
 resource "aws_sqs_queue" "queue-with-defaults" {
     name = "test-sqs-queue-with-defaults"
@@ -211,6 +275,16 @@ resource "aws_sqs_queue" "queue-with-overrides" {
 }
 `
 
+func testAccAWSSQSConfigWithKms(name string, reusePeriod int) string {
+	return fmt.Sprintf(`
+resource "aws_sqs_queue" "queue-with-kms" {
+  name                              = "tftestqueue-kms-%s"
+  kms_master_key_id                 = "alias/aws/sqs"
+  kms_data_key_reuse_period_seconds = %d
+}
+`, name, reusePeriod)
+}
+
 func testAccAWSSQSConfigWithRedrive(name string) string {
 	return fmt.Sprintf(`
 resource "aws_sqs_queue" "my_queue" {
@@ -35,6 +35,13 @@ func resourceAwsSubnet() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"ipv6_cidr_block": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+			},
+
 			"availability_zone": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -48,6 +55,17 @@ func resourceAwsSubnet() *schema.Resource {
 				Default:  false,
 			},
 
+			"assign_ipv6_address_on_creation": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"ipv6_cidr_block_association_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -61,6 +79,9 @@ func resourceAwsSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 		CidrBlock:        aws.String(d.Get("cidr_block").(string)),
 		VpcId:            aws.String(d.Get("vpc_id").(string)),
 	}
+	if v, ok := d.GetOk("ipv6_cidr_block"); ok {
+		createOpts.Ipv6CidrBlock = aws.String(v.(string))
+	}
 
 	var err error
 	resp, err := conn.CreateSubnet(createOpts)
@@ -119,6 +140,21 @@ func resourceAwsSubnetRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("availability_zone", subnet.AvailabilityZone)
 	d.Set("cidr_block", subnet.CidrBlock)
 	d.Set("map_public_ip_on_launch", subnet.MapPublicIpOnLaunch)
+	d.Set("assign_ipv6_address_on_creation", subnet.AssignIpv6AddressOnCreation)
+
+	d.Set("ipv6_cidr_block", "")
+	d.Set("ipv6_cidr_block_association_id", "")
+	for _, a := range subnet.Ipv6CidrBlockAssociationSet {
+		if a.Ipv6CidrBlockState == nil {
+			continue
+		}
+		if state := *a.Ipv6CidrBlockState.State; state != ec2.SubnetCidrBlockStateCodeAssociating && state != ec2.SubnetCidrBlockStateCodeAssociated {
+			continue
+		}
+		d.Set("ipv6_cidr_block", a.Ipv6CidrBlock)
+		d.Set("ipv6_cidr_block_association_id", a.AssociationId)
+		break
+	}
 	d.Set("tags", tagsToMap(subnet.Tags))
 
 	return nil
@@ -154,6 +190,25 @@ func resourceAwsSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.HasChange("assign_ipv6_address_on_creation") {
+		modifyOpts := &ec2.ModifySubnetAttributeInput{
+			SubnetId: aws.String(d.Id()),
+			AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{
+				Value: aws.Bool(d.Get("assign_ipv6_address_on_creation").(bool)),
+			},
+		}
+
+		log.Printf("[DEBUG] Subnet modify attributes: %#v", modifyOpts)
+
+		_, err := conn.ModifySubnetAttribute(modifyOpts)
+
+		if err != nil {
+			return err
+		} else {
+			d.SetPartial("assign_ipv6_address_on_creation")
+		}
+	}
+
 	d.Partial(false)
 
 	return resourceAwsSubnetRead(d, meta)
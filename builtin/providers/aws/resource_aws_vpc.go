@@ -55,6 +55,22 @@ func resourceAwsVpc() *schema.Resource {
 				Computed: true,
 			},
 
+			"assign_generated_ipv6_cidr_block": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"ipv6_association_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ipv6_cidr_block": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"main_route_table_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -88,8 +104,9 @@ func resourceAwsVpcCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	// Create the VPC
 	createOpts := &ec2.CreateVpcInput{
-		CidrBlock:       aws.String(d.Get("cidr_block").(string)),
-		InstanceTenancy: aws.String(instance_tenancy),
+		CidrBlock:                   aws.String(d.Get("cidr_block").(string)),
+		InstanceTenancy:             aws.String(instance_tenancy),
+		AmazonProvidedIpv6CidrBlock: aws.Bool(d.Get("assign_generated_ipv6_cidr_block").(bool)),
 	}
 	log.Printf("[DEBUG] VPC create config: %#v", *createOpts)
 	vpcResp, err := conn.CreateVpc(createOpts)
@@ -149,6 +166,23 @@ func resourceAwsVpcRead(d *schema.ResourceData, meta interface{}) error {
 	// Tags
 	d.Set("tags", tagsToMap(vpc.Tags))
 
+	// The VPC has at most one Amazon provided IPv6 CIDR block
+	d.Set("assign_generated_ipv6_cidr_block", false)
+	d.Set("ipv6_association_id", "")
+	d.Set("ipv6_cidr_block", "")
+	for _, a := range vpc.Ipv6CidrBlockAssociationSet {
+		if a.Ipv6CidrBlockState == nil {
+			continue
+		}
+		if state := *a.Ipv6CidrBlockState.State; state != ec2.VpcCidrBlockStateCodeAssociating && state != ec2.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		d.Set("assign_generated_ipv6_cidr_block", true)
+		d.Set("ipv6_association_id", a.AssociationId)
+		d.Set("ipv6_cidr_block", a.Ipv6CidrBlock)
+		break
+	}
+
 	// Attributes
 	attribute := "enableDnsSupport"
 	DescribeAttrOpts := &ec2.DescribeVpcAttributeInput{
@@ -295,6 +329,15 @@ func resourceAwsVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 		d.SetPartial("enable_classiclink")
 	}
 
+	// On create, the IPv6 CIDR block is requested with the VPC
+	if d.HasChange("assign_generated_ipv6_cidr_block") && !d.IsNewResource() {
+		if err := resourceAwsVpcUpdateIpv6CidrBlock(conn, d); err != nil {
+			return err
+		}
+
+		d.SetPartial("assign_generated_ipv6_cidr_block")
+	}
+
 	if err := setTags(conn, d); err != nil {
 		return err
 	} else {
@@ -305,6 +348,51 @@ func resourceAwsVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 	return resourceAwsVpcRead(d, meta)
 }
 
+// resourceAwsVpcUpdateIpv6CidrBlock associates an Amazon provided IPv6 CIDR
+// block with the VPC, or disassociates it, and waits for the change.
+func resourceAwsVpcUpdateIpv6CidrBlock(conn *ec2.EC2, d *schema.ResourceData) error {
+	var associationId, target string
+	if d.Get("assign_generated_ipv6_cidr_block").(bool) {
+		log.Printf("[INFO] Associating an IPv6 CIDR block with VPC: %s", d.Id())
+		resp, err := conn.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{
+			VpcId:                       aws.String(d.Id()),
+			AmazonProvidedIpv6CidrBlock: aws.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("Error associating an IPv6 CIDR block with VPC (%s): %s", d.Id(), err)
+		}
+		associationId = *resp.Ipv6CidrBlockAssociation.AssociationId
+		target = ec2.VpcCidrBlockStateCodeAssociated
+	} else {
+		associationId = d.Get("ipv6_association_id").(string)
+		log.Printf("[INFO] Disassociating IPv6 CIDR block (%s) from VPC: %s", associationId, d.Id())
+		_, err := conn.DisassociateVpcCidrBlock(&ec2.DisassociateVpcCidrBlockInput{
+			AssociationId: aws.String(associationId),
+		})
+		if err != nil {
+			return fmt.Errorf("Error disassociating IPv6 CIDR block from VPC (%s): %s", d.Id(), err)
+		}
+		target = ec2.VpcCidrBlockStateCodeDisassociated
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			ec2.VpcCidrBlockStateCodeAssociating,
+			ec2.VpcCidrBlockStateCodeDisassociating,
+		},
+		Target:  []string{target},
+		Refresh: VpcIpv6CidrBlockStateRefreshFunc(conn, d.Id(), associationId),
+		Timeout: 1 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf(
+			"Error waiting for IPv6 CIDR block of VPC (%s) to become %s: %s",
+			d.Id(), target, err)
+	}
+
+	return nil
+}
+
 func resourceAwsVpcDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 	vpcID := d.Id()
@@ -363,6 +451,26 @@ func VPCStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
 	}
 }
 
+// VpcIpv6CidrBlockStateRefreshFunc returns a resource.StateRefreshFunc that
+// is used to watch the association of an IPv6 CIDR block with a VPC.
+func VpcIpv6CidrBlockStateRefreshFunc(conn *ec2.EC2, id, associationId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		vpcRaw, _, err := VPCStateRefreshFunc(conn, id)()
+		if err != nil || vpcRaw == nil {
+			return nil, "", err
+		}
+
+		for _, a := range vpcRaw.(*ec2.Vpc).Ipv6CidrBlockAssociationSet {
+			if *a.AssociationId == associationId && a.Ipv6CidrBlockState != nil {
+				return a, *a.Ipv6CidrBlockState.State, nil
+			}
+		}
+
+		// Disassociated blocks eventually disappear from the VPC
+		return "", ec2.VpcCidrBlockStateCodeDisassociated, nil
+	}
+}
+
 func resourceAwsVpcSetDefaultNetworkAcl(conn *ec2.EC2, d *schema.ResourceData) error {
 	filter1 := &ec2.Filter{
 		Name:   aws.String("default"),
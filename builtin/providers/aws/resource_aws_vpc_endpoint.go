@@ -3,10 +3,12 @@ package aws
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -39,6 +41,57 @@ func resourceAwsVpcEndpoint() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
+			"vpc_endpoint_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      ec2.VpcEndpointTypeGateway,
+				ValidateFunc: validateVpcEndpointType,
+			},
+			"subnet_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"security_group_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"private_dns_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"prefix_list_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"network_interface_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"dns_entry": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dns_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hosted_zone_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -46,9 +99,21 @@ func resourceAwsVpcEndpoint() *schema.Resource {
 func resourceAwsVPCEndpointCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ec2conn
 	input := &ec2.CreateVpcEndpointInput{
-		VpcId:         aws.String(d.Get("vpc_id").(string)),
-		RouteTableIds: expandStringList(d.Get("route_table_ids").(*schema.Set).List()),
-		ServiceName:   aws.String(d.Get("service_name").(string)),
+		VpcId:           aws.String(d.Get("vpc_id").(string)),
+		ServiceName:     aws.String(d.Get("service_name").(string)),
+		VpcEndpointType: aws.String(d.Get("vpc_endpoint_type").(string)),
+	}
+
+	// Gateway endpoints are routed to, interface endpoints get network
+	// interfaces in subnets instead.
+	if d.Get("vpc_endpoint_type").(string) == ec2.VpcEndpointTypeInterface {
+		input.SubnetIds = expandStringList(d.Get("subnet_ids").(*schema.Set).List())
+		input.PrivateDnsEnabled = aws.Bool(d.Get("private_dns_enabled").(bool))
+		if v, ok := d.GetOk("security_group_ids"); ok {
+			input.SecurityGroupIds = expandStringList(v.(*schema.Set).List())
+		}
+	} else {
+		input.RouteTableIds = expandStringList(d.Get("route_table_ids").(*schema.Set).List())
 	}
 
 	if v, ok := d.GetOk("policy"); ok {
@@ -65,6 +130,18 @@ func resourceAwsVPCEndpointCreate(d *schema.ResourceData, meta interface{}) erro
 
 	d.SetId(*output.VpcEndpoint.VpcEndpointId)
 
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     []string{"available", "pendingAcceptance"},
+		Refresh:    vpcEndpointStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to become available: %s", d.Id(), err)
+	}
+
 	return resourceAwsVPCEndpointRead(d, meta)
 }
 
@@ -98,11 +175,57 @@ func resourceAwsVPCEndpointRead(d *schema.ResourceData, meta interface{}) error
 	vpce := output.VpcEndpoints[0]
 
 	d.Set("vpc_id", vpce.VpcId)
-	d.Set("policy", normalizeJson(*vpce.PolicyDocument))
+	if vpce.PolicyDocument != nil {
+		d.Set("policy", normalizeJson(*vpce.PolicyDocument))
+	}
 	d.Set("service_name", vpce.ServiceName)
+	d.Set("vpc_endpoint_type", vpce.VpcEndpointType)
+	d.Set("private_dns_enabled", vpce.PrivateDnsEnabled)
 	if err := d.Set("route_table_ids", aws.StringValueSlice(vpce.RouteTableIds)); err != nil {
 		return err
 	}
+	if err := d.Set("subnet_ids", aws.StringValueSlice(vpce.SubnetIds)); err != nil {
+		return err
+	}
+	if err := d.Set("network_interface_ids", aws.StringValueSlice(vpce.NetworkInterfaceIds)); err != nil {
+		return err
+	}
+
+	var groups []string
+	for _, g := range vpce.Groups {
+		groups = append(groups, *g.GroupId)
+	}
+	if err := d.Set("security_group_ids", groups); err != nil {
+		return err
+	}
+
+	var dnsEntries []map[string]interface{}
+	for _, e := range vpce.DnsEntries {
+		dnsEntries = append(dnsEntries, map[string]interface{}{
+			"dns_name":       aws.StringValue(e.DnsName),
+			"hosted_zone_id": aws.StringValue(e.HostedZoneId),
+		})
+	}
+	if err := d.Set("dns_entry", dnsEntries); err != nil {
+		return err
+	}
+
+	// The prefix list of the service is what security group rules need to
+	// reference to allow traffic through the endpoint.
+	plOutput, err := conn.DescribePrefixLists(&ec2.DescribePrefixListsInput{
+		Filters: []*ec2.Filter{
+			&ec2.Filter{
+				Name:   aws.String("prefix-list-name"),
+				Values: []*string{vpce.ServiceName},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading prefix list of VPC Endpoint (%s): %s", d.Id(), err)
+	}
+	if len(plOutput.PrefixLists) > 0 && plOutput.PrefixLists[0] != nil {
+		d.Set("prefix_list_id", plOutput.PrefixLists[0].PrefixListId)
+	}
 
 	return nil
 }
@@ -114,19 +237,19 @@ func resourceAwsVPCEndpointUpdate(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	if d.HasChange("route_table_ids") {
-		o, n := d.GetChange("route_table_ids")
-		os := o.(*schema.Set)
-		ns := n.(*schema.Set)
+		input.AddRouteTableIds, input.RemoveRouteTableIds = vpcEndpointSetChange(d, "route_table_ids")
+	}
 
-		add := expandStringList(ns.Difference(os).List())
-		if len(add) > 0 {
-			input.AddRouteTableIds = add
-		}
+	if d.HasChange("subnet_ids") {
+		input.AddSubnetIds, input.RemoveSubnetIds = vpcEndpointSetChange(d, "subnet_ids")
+	}
 
-		remove := expandStringList(os.Difference(ns).List())
-		if len(remove) > 0 {
-			input.RemoveRouteTableIds = remove
-		}
+	if d.HasChange("security_group_ids") {
+		input.AddSecurityGroupIds, input.RemoveSecurityGroupIds = vpcEndpointSetChange(d, "security_group_ids")
+	}
+
+	if d.HasChange("private_dns_enabled") {
+		input.PrivateDnsEnabled = aws.Bool(d.Get("private_dns_enabled").(bool))
 	}
 
 	if d.HasChange("policy") {
@@ -141,6 +264,18 @@ func resourceAwsVPCEndpointUpdate(d *schema.ResourceData, meta interface{}) erro
 	}
 	log.Printf("[DEBUG] VPC Endpoint %q updated", input.VpcEndpointId)
 
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     []string{"available", "pendingAcceptance"},
+		Refresh:    vpcEndpointStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to become available: %s", d.Id(), err)
+	}
+
 	return resourceAwsVPCEndpointRead(d, meta)
 }
 
@@ -166,8 +301,62 @@ func resourceAwsVPCEndpointDelete(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"available", "pending", "pendingAcceptance", "deleting"},
+		Target:     []string{"deleted"},
+		Refresh:    vpcEndpointStateRefreshFunc(conn, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for VPC Endpoint (%s) to be deleted: %s", d.Id(), err)
+	}
+
 	log.Printf("[DEBUG] VPC Endpoint %q deleted", d.Id())
 	d.SetId("")
 
 	return nil
 }
+
+// vpcEndpointStateRefreshFunc returns a resource.StateRefreshFunc that is used
+// to watch a VPC Endpoint. An endpoint that can no longer be found is
+// reported in the "deleted" state.
+func vpcEndpointStateRefreshFunc(conn *ec2.EC2, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+			VpcEndpointIds: []*string{aws.String(id)},
+		})
+		if err != nil {
+			if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidVpcEndpointId.NotFound" {
+				return "", "deleted", nil
+			}
+			log.Printf("Error on VPC Endpoint State Refresh: %s", err)
+			return nil, "", err
+		}
+
+		if resp == nil || len(resp.VpcEndpoints) == 0 || resp.VpcEndpoints[0] == nil {
+			return "", "deleted", nil
+		}
+
+		vpce := resp.VpcEndpoints[0]
+		return vpce, *vpce.State, nil
+	}
+}
+
+// vpcEndpointSetChange returns the elements added to and removed from the
+// set attribute k of a VPC Endpoint, as expected by ModifyVpcEndpoint.
+func vpcEndpointSetChange(d *schema.ResourceData, k string) ([]*string, []*string) {
+	o, n := d.GetChange(k)
+	os := o.(*schema.Set)
+	ns := n.(*schema.Set)
+
+	var add, remove []*string
+	if v := ns.Difference(os).List(); len(v) > 0 {
+		add = expandStringList(v)
+	}
+	if v := os.Difference(ns).List(); len(v) > 0 {
+		remove = expandStringList(v)
+	}
+	return add, remove
+}
@@ -59,6 +59,39 @@ func TestAccAWSVpcEndpoint_withRouteTableAndPolicy(t *testing.T) {
 	})
 }
 
+func TestAccAWSVpcEndpoint_interfaceType(t *testing.T) {
+	var endpoint ec2.VpcEndpoint
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "aws_vpc_endpoint.ec2",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVpcEndpointDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpcEndpointInterfaceTypeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.ec2", &endpoint),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.ec2", "vpc_endpoint_type", "Interface"),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.ec2", "subnet_ids.#", "1"),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.ec2", "security_group_ids.#", "1"),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.ec2", "network_interface_ids.#", "1"),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.ec2", "private_dns_enabled", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVpcEndpointInterfaceTypeConfigModified,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcEndpointExists("aws_vpc_endpoint.ec2", &endpoint),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.ec2", "subnet_ids.#", "2"),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.ec2", "network_interface_ids.#", "2"),
+					resource.TestCheckResourceAttr("aws_vpc_endpoint.ec2", "private_dns_enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVpcEndpointDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).ec2conn
 
@@ -206,3 +239,64 @@ resource "aws_route_table_association" "main" {
     route_table_id = "${aws_route_table.default.id}"
 }
 `
+
+const testAccVpcEndpointInterfaceTypeConfig = `
+resource "aws_vpc" "foo" {
+    cidr_block = "10.0.0.0/16"
+    enable_dns_support = true
+    enable_dns_hostnames = true
+}
+
+resource "aws_subnet" "foo" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "10.0.1.0/24"
+    availability_zone = "us-west-2a"
+}
+
+resource "aws_security_group" "foo" {
+    vpc_id = "${aws_vpc.foo.id}"
+    name = "tf-vpc-endpoint-interface-test"
+}
+
+resource "aws_vpc_endpoint" "ec2" {
+    vpc_id = "${aws_vpc.foo.id}"
+    service_name = "com.amazonaws.us-west-2.ec2"
+    vpc_endpoint_type = "Interface"
+    subnet_ids = ["${aws_subnet.foo.id}"]
+    security_group_ids = ["${aws_security_group.foo.id}"]
+}
+`
+
+const testAccVpcEndpointInterfaceTypeConfigModified = `
+resource "aws_vpc" "foo" {
+    cidr_block = "10.0.0.0/16"
+    enable_dns_support = true
+    enable_dns_hostnames = true
+}
+
+resource "aws_subnet" "foo" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "10.0.1.0/24"
+    availability_zone = "us-west-2a"
+}
+
+resource "aws_subnet" "bar" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "10.0.2.0/24"
+    availability_zone = "us-west-2b"
+}
+
+resource "aws_security_group" "foo" {
+    vpc_id = "${aws_vpc.foo.id}"
+    name = "tf-vpc-endpoint-interface-test"
+}
+
+resource "aws_vpc_endpoint" "ec2" {
+    vpc_id = "${aws_vpc.foo.id}"
+    service_name = "com.amazonaws.us-west-2.ec2"
+    vpc_endpoint_type = "Interface"
+    subnet_ids = ["${aws_subnet.foo.id}", "${aws_subnet.bar.id}"]
+    security_group_ids = ["${aws_security_group.foo.id}"]
+    private_dns_enabled = true
+}
+`
@@ -0,0 +1,165 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAwsVpcPeeringConnectionAccepter manages the accepter's side of a
+// VPC peering connection, typically in another account than the requester.
+// It doesn't create the peering connection, it adopts an existing one.
+func resourceAwsVpcPeeringConnectionAccepter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVPCPeeringAccepterCreate,
+		Read:   resourceAwsVPCPeeringAccepterRead,
+		Update: resourceAwsVPCPeeringAccepterUpdate,
+		Delete: resourceAwsVPCPeeringAccepterDelete,
+
+		Schema: map[string]*schema.Schema{
+			"vpc_peering_connection_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"auto_accept": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"accept_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_vpc_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_owner_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsVPCPeeringAccepterCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	id := d.Get("vpc_peering_connection_id").(string)
+	d.SetId(id)
+
+	// The peering connection may not be visible yet from the accepter's
+	// account, the refresh func reports it as not found until it is.
+	log.Printf(
+		"[DEBUG] Waiting for vpc peering connection (%s) to be available for acceptance",
+		d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"initiating-request", "pending"},
+		Target:  []string{"pending-acceptance", "provisioning", "active"},
+		Refresh: resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, d.Id()),
+		Timeout: 1 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		d.SetId("")
+		return fmt.Errorf(
+			"Error waiting for vpc peering (%s) to be available for acceptance: %s",
+			id, err)
+	}
+
+	return resourceAwsVPCPeeringAccepterUpdate(d, meta)
+}
+
+func resourceAwsVPCPeeringAccepterRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+	pcRaw, _, err := resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, d.Id())()
+	if err != nil {
+		return err
+	}
+	if pcRaw == nil {
+		d.SetId("")
+		return nil
+	}
+
+	pc := pcRaw.(*ec2.VpcPeeringConnection)
+
+	// Same as for the requester, see resourceAwsVPCPeeringRead.
+	if pc.Status != nil {
+		if *pc.Status.Code == "failed" || *pc.Status.Code == "deleted" {
+			log.Printf("[DEBUG] VPC Peering Connect (%s) in state (%s), removing", d.Id(), *pc.Status.Code)
+			d.SetId("")
+			return nil
+		}
+	}
+
+	// From the accepter's point of view, the peer is the requester.
+	d.Set("vpc_peering_connection_id", pc.VpcPeeringConnectionId)
+	d.Set("accept_status", *pc.Status.Code)
+	d.Set("vpc_id", pc.AccepterVpcInfo.VpcId)
+	d.Set("peer_vpc_id", pc.RequesterVpcInfo.VpcId)
+	d.Set("peer_owner_id", pc.RequesterVpcInfo.OwnerId)
+	d.Set("tags", tagsToMap(pc.Tags))
+
+	return nil
+}
+
+func resourceAwsVPCPeeringAccepterUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if err := setTags(conn, d); err != nil {
+		return err
+	} else {
+		d.SetPartial("tags")
+	}
+
+	if _, ok := d.GetOk("auto_accept"); ok {
+		pcRaw, _, err := resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, d.Id())()
+		if err != nil {
+			return err
+		}
+		if pcRaw == nil {
+			d.SetId("")
+			return nil
+		}
+		pc := pcRaw.(*ec2.VpcPeeringConnection)
+
+		if pc.Status != nil && *pc.Status.Code == "pending-acceptance" {
+			status, err := resourceVPCPeeringConnectionAccept(conn, d.Id())
+			if err != nil {
+				return err
+			}
+			log.Printf(
+				"[DEBUG] VPC Peering connection accept status: %s",
+				status)
+
+			stateConf := &resource.StateChangeConf{
+				Pending: []string{"pending-acceptance", "provisioning"},
+				Target:  []string{"active"},
+				Refresh: resourceAwsVPCPeeringConnectionStateRefreshFunc(conn, d.Id()),
+				Timeout: 1 * time.Minute,
+			}
+			if _, err := stateConf.WaitForState(); err != nil {
+				return fmt.Errorf(
+					"Error waiting for vpc peering (%s) to become active: %s",
+					d.Id(), err)
+			}
+		}
+	}
+
+	return resourceAwsVPCPeeringAccepterRead(d, meta)
+}
+
+func resourceAwsVPCPeeringAccepterDelete(d *schema.ResourceData, meta interface{}) error {
+	// The peering connection is owned by the requester, which deletes it.
+	log.Printf("[WARN] Will not delete VPC peering connection %s, it is only removed from the state", d.Id())
+	d.SetId("")
+	return nil
+}
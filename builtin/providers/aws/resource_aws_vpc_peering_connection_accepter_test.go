@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// The requester and the accepter are in the same account here, accepting
+// a peering connection works the same across accounts.
+func TestAccAWSVPCPeeringConnectionAccepter_sameAccount(t *testing.T) {
+	var connection ec2.VpcPeeringConnection
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSVpcPeeringConnectionAccepterDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAwsVPCPeeringConnectionAccepterSameAccountConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSVpcPeeringConnectionExists("aws_vpc_peering_connection_accepter.peer", &connection),
+					resource.TestCheckResourceAttr(
+						"aws_vpc_peering_connection_accepter.peer", "accept_status", "active"),
+					resource.TestCheckResourceAttr(
+						"aws_vpc_peering_connection_accepter.peer", "tags.Side", "Accepter"),
+					testAccCheckTags(&connection.Tags, "Side", "Accepter"),
+				),
+			},
+		},
+	})
+}
+
+// Destroying the accepter leaves the peering connection, the requester
+// deletes it.
+func testAccCheckAWSVpcPeeringConnectionAccepterDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_vpc_peering_connection_accepter" {
+			continue
+		}
+
+		resp, err := conn.DescribeVpcPeeringConnections(
+			&ec2.DescribeVpcPeeringConnectionsInput{
+				VpcPeeringConnectionIds: []*string{aws.String(rs.Primary.ID)},
+			})
+		if err != nil {
+			return err
+		}
+
+		for _, pc := range resp.VpcPeeringConnections {
+			if pc.Status != nil && *pc.Status.Code != "deleted" {
+				return fmt.Errorf("Found vpc peering connection in unexpected state: %s", pc)
+			}
+		}
+	}
+
+	return nil
+}
+
+const testAccAwsVPCPeeringConnectionAccepterSameAccountConfig = `
+resource "aws_vpc" "main" {
+	cidr_block = "10.0.0.0/16"
+	tags {
+		Name = "TestAccAWSVPCPeeringConnectionAccepter_sameAccount"
+	}
+}
+
+resource "aws_vpc" "peer" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_vpc_peering_connection" "main" {
+	vpc_id = "${aws_vpc.main.id}"
+	peer_vpc_id = "${aws_vpc.peer.id}"
+	auto_accept = false
+}
+
+resource "aws_vpc_peering_connection_accepter" "peer" {
+	vpc_peering_connection_id = "${aws_vpc_peering_connection.main.id}"
+	auto_accept = true
+	tags {
+		Side = "Accepter"
+	}
+}
+`
@@ -22,15 +22,27 @@ type XmlVpnConnectionConfig struct {
 }
 
 type XmlIpsecTunnel struct {
-	OutsideAddress string `xml:"vpn_gateway>tunnel_outside_address>ip_address"`
-	PreSharedKey   string `xml:"ike>pre_shared_key"`
+	OutsideAddress   string `xml:"vpn_gateway>tunnel_outside_address>ip_address"`
+	BGPASN           string `xml:"vpn_gateway>bgp>asn"`
+	BGPHoldTime      int    `xml:"vpn_gateway>bgp>hold_time"`
+	PreSharedKey     string `xml:"ike>pre_shared_key"`
+	CgwInsideAddress string `xml:"customer_gateway>tunnel_inside_address>ip_address"`
+	VgwInsideAddress string `xml:"vpn_gateway>tunnel_inside_address>ip_address"`
 }
 
 type TunnelInfo struct {
-	Tunnel1Address      string
-	Tunnel1PreSharedKey string
-	Tunnel2Address      string
-	Tunnel2PreSharedKey string
+	Tunnel1Address          string
+	Tunnel1CgwInsideAddress string
+	Tunnel1VgwInsideAddress string
+	Tunnel1PreSharedKey     string
+	Tunnel1BGPASN           string
+	Tunnel1BGPHoldTime      int
+	Tunnel2Address          string
+	Tunnel2CgwInsideAddress string
+	Tunnel2VgwInsideAddress string
+	Tunnel2PreSharedKey     string
+	Tunnel2BGPASN           string
+	Tunnel2BGPHoldTime      int
 }
 
 func (slice XmlVpnConnectionConfig) Len() int {
@@ -96,6 +108,26 @@ func resourceAwsVpnConnection() *schema.Resource {
 				Computed: true,
 			},
 
+			"tunnel1_cgw_inside_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tunnel1_vgw_inside_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tunnel1_bgp_asn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tunnel1_bgp_holdtime": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
 			"tunnel2_address": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -106,6 +138,26 @@ func resourceAwsVpnConnection() *schema.Resource {
 				Computed: true,
 			},
 
+			"tunnel2_cgw_inside_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tunnel2_vgw_inside_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tunnel2_bgp_asn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tunnel2_bgp_holdtime": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
 			"routes": &schema.Schema{
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -306,11 +358,23 @@ func resourceAwsVpnConnectionRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("customer_gateway_configuration", vpnConnection.CustomerGatewayConfiguration)
 
 	if vpnConnection.CustomerGatewayConfiguration != nil {
-		tunnelInfo := xmlConfigToTunnelInfo(*vpnConnection.CustomerGatewayConfiguration)
+		tunnelInfo, err := xmlConfigToTunnelInfo(*vpnConnection.CustomerGatewayConfiguration)
+		if err != nil {
+			return fmt.Errorf("Error reading the configuration of VPN connection %s: %s", d.Id(), err)
+		}
+
 		d.Set("tunnel1_address", tunnelInfo.Tunnel1Address)
+		d.Set("tunnel1_cgw_inside_address", tunnelInfo.Tunnel1CgwInsideAddress)
+		d.Set("tunnel1_vgw_inside_address", tunnelInfo.Tunnel1VgwInsideAddress)
 		d.Set("tunnel1_preshared_key", tunnelInfo.Tunnel1PreSharedKey)
+		d.Set("tunnel1_bgp_asn", tunnelInfo.Tunnel1BGPASN)
+		d.Set("tunnel1_bgp_holdtime", tunnelInfo.Tunnel1BGPHoldTime)
 		d.Set("tunnel2_address", tunnelInfo.Tunnel2Address)
+		d.Set("tunnel2_cgw_inside_address", tunnelInfo.Tunnel2CgwInsideAddress)
+		d.Set("tunnel2_vgw_inside_address", tunnelInfo.Tunnel2VgwInsideAddress)
 		d.Set("tunnel2_preshared_key", tunnelInfo.Tunnel2PreSharedKey)
+		d.Set("tunnel2_bgp_asn", tunnelInfo.Tunnel2BGPASN)
+		d.Set("tunnel2_bgp_holdtime", tunnelInfo.Tunnel2BGPHoldTime)
 	}
 
 	if err := d.Set("vgw_telemetry", telemetryToMapList(vpnConnection.VgwTelemetry)); err != nil {
@@ -413,20 +477,33 @@ func telemetryToMapList(telemetry []*ec2.VgwTelemetry) []map[string]interface{}
 	return result
 }
 
-func xmlConfigToTunnelInfo(xmlConfig string) TunnelInfo {
+func xmlConfigToTunnelInfo(xmlConfig string) (*TunnelInfo, error) {
 	var vpnConfig XmlVpnConnectionConfig
-	xml.Unmarshal([]byte(xmlConfig), &vpnConfig)
+	if err := xml.Unmarshal([]byte(xmlConfig), &vpnConfig); err != nil {
+		return nil, fmt.Errorf("Error unmarshalling XML: %s", err)
+	}
+	if len(vpnConfig.Tunnels) != 2 {
+		return nil, fmt.Errorf("Expected 2 tunnels, found %d", len(vpnConfig.Tunnels))
+	}
 
 	// don't expect consistent ordering from the XML
 	sort.Sort(vpnConfig)
 
-	tunnelInfo := TunnelInfo{
-		Tunnel1Address:      vpnConfig.Tunnels[0].OutsideAddress,
-		Tunnel1PreSharedKey: vpnConfig.Tunnels[0].PreSharedKey,
-
-		Tunnel2Address:      vpnConfig.Tunnels[1].OutsideAddress,
-		Tunnel2PreSharedKey: vpnConfig.Tunnels[1].PreSharedKey,
+	tunnelInfo := &TunnelInfo{
+		Tunnel1Address:          vpnConfig.Tunnels[0].OutsideAddress,
+		Tunnel1CgwInsideAddress: vpnConfig.Tunnels[0].CgwInsideAddress,
+		Tunnel1VgwInsideAddress: vpnConfig.Tunnels[0].VgwInsideAddress,
+		Tunnel1PreSharedKey:     vpnConfig.Tunnels[0].PreSharedKey,
+		Tunnel1BGPASN:           vpnConfig.Tunnels[0].BGPASN,
+		Tunnel1BGPHoldTime:      vpnConfig.Tunnels[0].BGPHoldTime,
+
+		Tunnel2Address:          vpnConfig.Tunnels[1].OutsideAddress,
+		Tunnel2CgwInsideAddress: vpnConfig.Tunnels[1].CgwInsideAddress,
+		Tunnel2VgwInsideAddress: vpnConfig.Tunnels[1].VgwInsideAddress,
+		Tunnel2PreSharedKey:     vpnConfig.Tunnels[1].PreSharedKey,
+		Tunnel2BGPASN:           vpnConfig.Tunnels[1].BGPASN,
+		Tunnel2BGPHoldTime:      vpnConfig.Tunnels[1].BGPHoldTime,
 	}
 
-	return tunnelInfo
+	return tunnelInfo, nil
 }
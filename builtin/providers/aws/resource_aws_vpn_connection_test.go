@@ -119,7 +119,10 @@ func testAccAwsVpnConnection(
 }
 
 func TestAWSVpnConnection_xmlconfig(t *testing.T) {
-	tunnelInfo := xmlConfigToTunnelInfo(testAccAwsVpnTunnelInfoXML)
+	tunnelInfo, err := xmlConfigToTunnelInfo(testAccAwsVpnTunnelInfoXML)
+	if err != nil {
+		t.Fatalf("Error unmarshalling XML: %s", err)
+	}
 	if tunnelInfo.Tunnel1Address != "FIRST_ADDRESS" {
 		t.Fatalf("First address from tunnel XML was incorrect.")
 	}
@@ -132,6 +135,33 @@ func TestAWSVpnConnection_xmlconfig(t *testing.T) {
 	if tunnelInfo.Tunnel2PreSharedKey != "SECOND_KEY" {
 		t.Fatalf("Second key from tunnel XML was incorrect.")
 	}
+	if tunnelInfo.Tunnel1CgwInsideAddress != "169.254.12.2" {
+		t.Fatalf("First customer gateway inside address from tunnel XML was incorrect.")
+	}
+	if tunnelInfo.Tunnel1VgwInsideAddress != "169.254.12.1" {
+		t.Fatalf("First VPN gateway inside address from tunnel XML was incorrect.")
+	}
+	if tunnelInfo.Tunnel2CgwInsideAddress != "169.254.11.2" {
+		t.Fatalf("Second customer gateway inside address from tunnel XML was incorrect.")
+	}
+	if tunnelInfo.Tunnel2VgwInsideAddress != "169.254.11.1" {
+		t.Fatalf("Second VPN gateway inside address from tunnel XML was incorrect.")
+	}
+	if tunnelInfo.Tunnel1BGPASN != "7224" || tunnelInfo.Tunnel2BGPASN != "7224" {
+		t.Fatalf("BGP ASN from tunnel XML was incorrect.")
+	}
+	if tunnelInfo.Tunnel1BGPHoldTime != 30 || tunnelInfo.Tunnel2BGPHoldTime != 30 {
+		t.Fatalf("BGP hold time from tunnel XML was incorrect.")
+	}
+}
+
+func TestAWSVpnConnection_xmlconfigBad(t *testing.T) {
+	if _, err := xmlConfigToTunnelInfo("<vpn_connection></vpn_connection>"); err == nil {
+		t.Fatalf("Expected an error for XML without tunnels.")
+	}
+	if _, err := xmlConfigToTunnelInfo("not XML"); err == nil {
+		t.Fatalf("Expected an error for invalid XML.")
+	}
 }
 
 const testAccAwsVpnConnectionConfig = `
@@ -181,20 +211,66 @@ resource "aws_vpn_connection" "foo" {
 const testAccAwsVpnTunnelInfoXML = `
 <vpn_connection id="vpn-abc123">
   <ipsec_tunnel>
+    <customer_gateway>
+      <tunnel_outside_address>
+        <ip_address>178.0.0.1</ip_address>
+      </tunnel_outside_address>
+      <tunnel_inside_address>
+        <ip_address>169.254.11.2</ip_address>
+        <network_mask>255.255.255.252</network_mask>
+        <network_cidr>30</network_cidr>
+      </tunnel_inside_address>
+      <bgp>
+        <asn>60000</asn>
+        <hold_time>30</hold_time>
+      </bgp>
+    </customer_gateway>
     <vpn_gateway>
       <tunnel_outside_address>
         <ip_address>SECOND_ADDRESS</ip_address>
       </tunnel_outside_address>
+      <tunnel_inside_address>
+        <ip_address>169.254.11.1</ip_address>
+        <network_mask>255.255.255.252</network_mask>
+        <network_cidr>30</network_cidr>
+      </tunnel_inside_address>
+      <bgp>
+        <asn>7224</asn>
+        <hold_time>30</hold_time>
+      </bgp>
     </vpn_gateway>
     <ike>
       <pre_shared_key>SECOND_KEY</pre_shared_key>
     </ike>
   </ipsec_tunnel>
   <ipsec_tunnel>
+    <customer_gateway>
+      <tunnel_outside_address>
+        <ip_address>178.0.0.1</ip_address>
+      </tunnel_outside_address>
+      <tunnel_inside_address>
+        <ip_address>169.254.12.2</ip_address>
+        <network_mask>255.255.255.252</network_mask>
+        <network_cidr>30</network_cidr>
+      </tunnel_inside_address>
+      <bgp>
+        <asn>60000</asn>
+        <hold_time>30</hold_time>
+      </bgp>
+    </customer_gateway>
     <vpn_gateway>
       <tunnel_outside_address>
         <ip_address>FIRST_ADDRESS</ip_address>
       </tunnel_outside_address>
+      <tunnel_inside_address>
+        <ip_address>169.254.12.1</ip_address>
+        <network_mask>255.255.255.252</network_mask>
+        <network_cidr>30</network_cidr>
+      </tunnel_inside_address>
+      <bgp>
+        <asn>7224</asn>
+        <hold_time>30</hold_time>
+      </bgp>
     </vpn_gateway>
     <ike>
       <pre_shared_key>FIRST_KEY</pre_shared_key>
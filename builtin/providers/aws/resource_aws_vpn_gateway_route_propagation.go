@@ -0,0 +1,120 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsVpnGatewayRoutePropagation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsVpnGatewayRoutePropagationEnable,
+		Read:   resourceAwsVpnGatewayRoutePropagationRead,
+		Delete: resourceAwsVpnGatewayRoutePropagationDisable,
+
+		Schema: map[string]*schema.Schema{
+			"vpn_gateway_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"route_table_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsVpnGatewayRoutePropagationEnable(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	gwId := d.Get("vpn_gateway_id").(string)
+	rtId := d.Get("route_table_id").(string)
+
+	log.Printf("[INFO] Enabling VGW propagation from %s to %s", gwId, rtId)
+	err := resource.Retry(2*time.Minute, func() *resource.RetryError {
+		_, err := conn.EnableVgwRoutePropagation(&ec2.EnableVgwRoutePropagationInput{
+			GatewayId:    aws.String(gwId),
+			RouteTableId: aws.String(rtId),
+		})
+		if err != nil {
+			// A gateway that was just attached to the VPC may not be seen
+			// as attached yet, due to eventual consistency.
+			if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "Gateway.NotAttached" {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error enabling VGW propagation from %s to %s: %s", gwId, rtId, err)
+	}
+
+	d.SetId(vpnGatewayRoutePropagationId(rtId, gwId))
+	return nil
+}
+
+func resourceAwsVpnGatewayRoutePropagationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	gwId := d.Get("vpn_gateway_id").(string)
+	rtId := d.Get("route_table_id").(string)
+
+	rtRaw, _, err := resourceAwsRouteTableStateRefreshFunc(conn, rtId)()
+	if err != nil {
+		return err
+	}
+	if rtRaw == nil {
+		log.Printf("[WARN] Route table %s not found, removing VGW propagation %s from state", rtId, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	rt := rtRaw.(*ec2.RouteTable)
+	for _, vgw := range rt.PropagatingVgws {
+		if aws.StringValue(vgw.GatewayId) == gwId {
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] VGW %s is no longer propagating to %s, removing from state", gwId, rtId)
+	d.SetId("")
+	return nil
+}
+
+func resourceAwsVpnGatewayRoutePropagationDisable(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	gwId := d.Get("vpn_gateway_id").(string)
+	rtId := d.Get("route_table_id").(string)
+
+	log.Printf("[INFO] Disabling VGW propagation from %s to %s", gwId, rtId)
+	_, err := conn.DisableVgwRoutePropagation(&ec2.DisableVgwRoutePropagationInput{
+		GatewayId:    aws.String(gwId),
+		RouteTableId: aws.String(rtId),
+	})
+	if err != nil {
+		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "InvalidRouteTableID.NotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error disabling VGW propagation from %s to %s: %s", gwId, rtId, err)
+	}
+
+	return nil
+}
+
+// vpnGatewayRoutePropagationId returns the ID of the propagation of the
+// routes of the given virtual private gateway to the given route table.
+func vpnGatewayRoutePropagationId(rtId, gwId string) string {
+	return fmt.Sprintf("%s_%s", rtId, gwId)
+}
@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSVPNGatewayRoutePropagation_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpnGatewayRoutePropagationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVpnGatewayRoutePropagationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpnGatewayRoutePropagationExists(
+						"aws_vpn_gateway_route_propagation.foo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpnGatewayRoutePropagationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		propagating, err := testAccVpnGatewayRoutePropagates(rs)
+		if err != nil {
+			return err
+		}
+		if !propagating {
+			return fmt.Errorf("VGW propagation %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVpnGatewayRoutePropagationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_vpn_gateway_route_propagation" {
+			continue
+		}
+
+		propagating, err := testAccVpnGatewayRoutePropagates(rs)
+		if err != nil {
+			return err
+		}
+		if propagating {
+			return fmt.Errorf("VGW propagation %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+// testAccVpnGatewayRoutePropagates returns whether the VGW of the given
+// propagation propagates to its route table.
+func testAccVpnGatewayRoutePropagates(rs *terraform.ResourceState) (bool, error) {
+	conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+	rtRaw, _, err := resourceAwsRouteTableStateRefreshFunc(
+		conn, rs.Primary.Attributes["route_table_id"])()
+	if err != nil {
+		return false, err
+	}
+	if rtRaw == nil {
+		return false, nil
+	}
+
+	for _, vgw := range rtRaw.(*ec2.RouteTable).PropagatingVgws {
+		if aws.StringValue(vgw.GatewayId) == rs.Primary.Attributes["vpn_gateway_id"] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func TestVpnGatewayRoutePropagationId(t *testing.T) {
+	id := vpnGatewayRoutePropagationId("rtb-abcde123", "vgw-abcde123")
+	if id != "rtb-abcde123_vgw-abcde123" {
+		t.Fatalf("bad: %s", id)
+	}
+}
+
+const testAccVpnGatewayRoutePropagationConfig = `
+resource "aws_vpc" "foo" {
+	cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_vpn_gateway" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_route_table" "foo" {
+	vpc_id = "${aws_vpc.foo.id}"
+}
+
+resource "aws_vpn_gateway_route_propagation" "foo" {
+	vpn_gateway_id = "${aws_vpn_gateway.foo.id}"
+	route_table_id = "${aws_route_table.foo.id}"
+}
+`
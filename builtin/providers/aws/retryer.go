@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const (
+	// awsRetryerMinThrottleDelay is the initial backoff applied once a request
+	// has been throttled. It doubles on each subsequent attempt.
+	awsRetryerMinThrottleDelay = 1 * time.Second
+
+	// awsRetryerMaxThrottleDelay caps the backoff between throttled attempts.
+	awsRetryerMaxThrottleDelay = 60 * time.Second
+)
+
+// awsRetryer is the request.Retryer shared by every service client created
+// from the provider's base session. It backs off exponentially on throttling
+// errors, including those some services (e.g. Direct Connect) only report via
+// a "Rate exceeded" message on a generic error code, so that individual
+// resources do not need to hand-roll their own retries.
+type awsRetryer struct {
+	client.DefaultRetryer
+}
+
+func newAwsRetryer(maxRetries int) awsRetryer {
+	return awsRetryer{
+		DefaultRetryer: client.DefaultRetryer{
+			NumMaxRetries:    maxRetries,
+			MinThrottleDelay: awsRetryerMinThrottleDelay,
+			MaxThrottleDelay: awsRetryerMaxThrottleDelay,
+		},
+	}
+}
+
+// ShouldRetry reports whether the failed request should be retried.
+func (r awsRetryer) ShouldRetry(req *request.Request) bool {
+	if awsErr, ok := req.Error.(awserr.Error); ok && isAwsRateExceededErr(awsErr) {
+		// Register the code as a throttle for this request so that RetryRules
+		// applies the throttling backoff rather than the regular one.
+		req.ThrottleErrorCodes = append(req.ThrottleErrorCodes, awsErr.Code())
+	}
+
+	retry := r.DefaultRetryer.ShouldRetry(req)
+	if retry && req.IsErrorThrottle() {
+		log.Printf("[WARN] %s/%s request throttled (attempt %d of %d), retrying: %s",
+			req.ClientInfo.ServiceName, req.Operation.Name, req.RetryCount+1, r.MaxRetries(), req.Error)
+	}
+
+	return retry
+}
+
+func isAwsRateExceededErr(err awserr.Error) bool {
+	return strings.Contains(strings.ToLower(err.Message()), "rate exceeded")
+}
@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+func testAwsRetryerRequest(r awsRetryer, err error, statusCode int) *request.Request {
+	req := request.New(aws.Config{}, metadata.ClientInfo{ServiceName: "directconnect"}, request.Handlers{},
+		r, &request.Operation{Name: "DescribeVirtualInterfaces"}, nil, nil)
+	req.Error = err
+	req.HTTPResponse = &http.Response{StatusCode: statusCode}
+	return req
+}
+
+func TestAwsRetryer_throttling(t *testing.T) {
+	r := newAwsRetryer(3)
+
+	cases := []struct {
+		Err      error
+		Status   int
+		Retry    bool
+		Throttle bool
+	}{
+		{
+			Err:      awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil),
+			Status:   503,
+			Retry:    true,
+			Throttle: true,
+		},
+		{
+			Err:      awserr.New("Throttling", "Rate exceeded", nil),
+			Status:   400,
+			Retry:    true,
+			Throttle: true,
+		},
+		{
+			Err:      awserr.New("DirectConnectClientException", "Rate exceeded", nil),
+			Status:   400,
+			Retry:    true,
+			Throttle: true,
+		},
+		{
+			Err:      awserr.New("DirectConnectClientException", "Connection dxcon-abcd1234 not found", nil),
+			Status:   400,
+			Retry:    false,
+			Throttle: false,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testAwsRetryerRequest(r, tc.Err, tc.Status)
+
+		if retry := r.ShouldRetry(req); retry != tc.Retry {
+			t.Fatalf("expected ShouldRetry to be %t for %s, got %t", tc.Retry, tc.Err, retry)
+		}
+		if throttle := req.IsErrorThrottle(); throttle != tc.Throttle {
+			t.Fatalf("expected IsErrorThrottle to be %t for %s, got %t", tc.Throttle, tc.Err, throttle)
+		}
+	}
+}
+
+func TestAwsRetryer_backoff(t *testing.T) {
+	r := newAwsRetryer(5)
+	req := testAwsRetryerRequest(r, awserr.New("DirectConnectClientException", "Rate exceeded", nil), 400)
+
+	if !r.ShouldRetry(req) {
+		t.Fatal("expected throttled request to be retried")
+	}
+	if delay := r.RetryRules(req); delay < awsRetryerMinThrottleDelay {
+		t.Fatalf("expected first throttle delay to be at least %s, got %s", awsRetryerMinThrottleDelay, delay)
+	}
+
+	req.RetryCount = 4
+	if delay := r.RetryRules(req); delay > awsRetryerMaxThrottleDelay {
+		t.Fatalf("expected throttle delay to be capped at %s, got %s", awsRetryerMaxThrottleDelay, delay)
+	}
+}
+
+func TestAwsRetryer_noRetries(t *testing.T) {
+	r := newAwsRetryer(0)
+	req := testAwsRetryerRequest(r, awserr.New("Throttling", "Rate exceeded", nil), 400)
+
+	if r.ShouldRetry(req) {
+		t.Fatal("expected no retries when max_retries is 0")
+	}
+}
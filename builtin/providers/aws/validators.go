@@ -1,11 +1,18 @@
 package aws
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"regexp"
 	"time"
 
+	"github.com/aws/aws-sdk-go/service/batch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/guardduty"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -104,6 +111,31 @@ func validateDbParamGroupName(v interface{}, k string) (ws []string, errors []er
 
 }
 
+func validateDmsReplicationInstanceId(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(`^[0-9A-Za-z-]+$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"only alphanumeric characters and hyphens allowed in %q", k))
+	}
+	if !regexp.MustCompile(`^[A-Za-z]`).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"first character of %q must be a letter", k))
+	}
+	if regexp.MustCompile(`--`).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot contain two consecutive hyphens", k))
+	}
+	if regexp.MustCompile(`-$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot end with a hyphen", k))
+	}
+	if len(value) > 63 {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be greater than 63 characters", k))
+	}
+	return
+}
+
 func validateStreamViewType(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	viewTypes := map[string]bool{
@@ -119,6 +151,62 @@ func validateStreamViewType(v interface{}, k string) (ws []string, errors []erro
 	return
 }
 
+func validateDynamoDbBillingMode(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != dynamodb.BillingModeProvisioned && value != dynamodb.BillingModePayPerRequest {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q or %q, got %q", k,
+			dynamodb.BillingModeProvisioned, dynamodb.BillingModePayPerRequest, value))
+	}
+	return
+}
+
+func validateJsonString(v interface{}, k string) (ws []string, errors []error) {
+	var j interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &j); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+	}
+	return
+}
+
+func validateCloudWatchMetricAlarmUnit(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	units := map[string]bool{
+		cloudwatch.StandardUnitSeconds:         true,
+		cloudwatch.StandardUnitMicroseconds:    true,
+		cloudwatch.StandardUnitMilliseconds:    true,
+		cloudwatch.StandardUnitBytes:           true,
+		cloudwatch.StandardUnitKilobytes:       true,
+		cloudwatch.StandardUnitMegabytes:       true,
+		cloudwatch.StandardUnitGigabytes:       true,
+		cloudwatch.StandardUnitTerabytes:       true,
+		cloudwatch.StandardUnitBits:            true,
+		cloudwatch.StandardUnitKilobits:        true,
+		cloudwatch.StandardUnitMegabits:        true,
+		cloudwatch.StandardUnitGigabits:        true,
+		cloudwatch.StandardUnitTerabits:        true,
+		cloudwatch.StandardUnitPercent:         true,
+		cloudwatch.StandardUnitCount:           true,
+		cloudwatch.StandardUnitBytesSecond:     true,
+		cloudwatch.StandardUnitKilobytesSecond: true,
+		cloudwatch.StandardUnitMegabytesSecond: true,
+		cloudwatch.StandardUnitGigabytesSecond: true,
+		cloudwatch.StandardUnitTerabytesSecond: true,
+		cloudwatch.StandardUnitBitsSecond:      true,
+		cloudwatch.StandardUnitKilobitsSecond:  true,
+		cloudwatch.StandardUnitMegabitsSecond:  true,
+		cloudwatch.StandardUnitGigabitsSecond:  true,
+		cloudwatch.StandardUnitTerabitsSecond:  true,
+		cloudwatch.StandardUnitCountSecond:     true,
+		cloudwatch.StandardUnitNone:            true,
+	}
+
+	if !units[value] {
+		errors = append(errors, fmt.Errorf("%q must be a valid CloudWatch unit, got %q", k, value))
+	}
+	return
+}
+
 func validateElbName(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if !regexp.MustCompile(`^[0-9A-Za-z-]+$`).MatchString(value) {
@@ -439,6 +527,24 @@ func validateS3BucketLifecycleRuleId(v interface{}, k string) (ws []string, erro
 	return
 }
 
+func validateS3BucketObjectLockEnabled(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != s3.ObjectLockEnabledEnabled {
+		errors = append(errors, fmt.Errorf(
+			"%q must be %q", k, s3.ObjectLockEnabledEnabled))
+	}
+	return
+}
+
+func validateS3BucketObjectLockRetentionMode(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != s3.ObjectLockRetentionModeGovernance && value != s3.ObjectLockRetentionModeCompliance {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of '%q', '%q'", k, s3.ObjectLockRetentionModeGovernance, s3.ObjectLockRetentionModeCompliance))
+	}
+	return
+}
+
 func validateDbEventSubscriptionName(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if !regexp.MustCompile(`^[0-9A-Za-z-]+$`).MatchString(value) {
@@ -451,3 +557,237 @@ func validateDbEventSubscriptionName(v interface{}, k string) (ws []string, erro
 	}
 	return
 }
+
+func validateDxAddressFamily(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != directconnect.AddressFamilyIpv4 && value != directconnect.AddressFamilyIpv6 {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q or %q", k, directconnect.AddressFamilyIpv4, directconnect.AddressFamilyIpv6))
+	}
+	return
+}
+
+// validateDxBgpPeerAddress checks that v is a peering CIDR as required by
+// Direct Connect BGP sessions: a /30 for IPv4 or a /125 for IPv6.
+func validateDxBgpPeerAddress(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	ip, ipnet, err := net.ParseCIDR(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a CIDR such as 169.254.0.1/30, got %q: %s", k, value, err))
+		return
+	}
+
+	ones, _ := ipnet.Mask.Size()
+	if ip.To4() != nil {
+		if ones != 30 {
+			errors = append(errors, fmt.Errorf(
+				"%q must be an IPv4 /30 CIDR, got %q", k, value))
+		}
+	} else if ones != 125 {
+		errors = append(errors, fmt.Errorf(
+			"%q must be an IPv6 /125 CIDR, got %q", k, value))
+	}
+	return
+}
+
+// validateDxBgpPeerAddressPair checks that the Amazon and customer sides of a
+// BGP session are distinct addresses of the same subnet, e.g. the same /30.
+// Either may be empty, in which case AWS allocates the addresses.
+func validateDxBgpPeerAddressPair(amazonAddress, customerAddress string) error {
+	if amazonAddress == "" || customerAddress == "" {
+		return nil
+	}
+
+	amazonIp, amazonNet, err := net.ParseCIDR(amazonAddress)
+	if err != nil {
+		return fmt.Errorf("amazon_address %q is not a valid CIDR: %s", amazonAddress, err)
+	}
+	customerIp, customerNet, err := net.ParseCIDR(customerAddress)
+	if err != nil {
+		return fmt.Errorf("customer_address %q is not a valid CIDR: %s", customerAddress, err)
+	}
+
+	if (amazonIp.To4() == nil) != (customerIp.To4() == nil) {
+		return fmt.Errorf("amazon_address %q and customer_address %q must be of the same address family",
+			amazonAddress, customerAddress)
+	}
+	if amazonIp.Equal(customerIp) {
+		return fmt.Errorf("amazon_address and customer_address must not be the same address, got %q",
+			amazonAddress)
+	}
+	if amazonNet.String() != customerNet.String() {
+		return fmt.Errorf("amazon_address %q and customer_address %q must be in the same subnet",
+			amazonAddress, customerAddress)
+	}
+
+	return nil
+}
+
+// validateDxVlan checks that v is an 802.1Q VLAN ID.
+func validateDxVlan(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value < 1 || value > 4094 {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a VLAN ID in the range 1-4094, got %d", k, value))
+	}
+	return
+}
+
+// validateDxBgpAsn checks that v is a 2-byte ASN Direct Connect can peer
+// with.
+func validateDxBgpAsn(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value < 1 || value > 65534 {
+		errors = append(errors, fmt.Errorf(
+			"%q must be an ASN in the range 1-65534, got %d", k, value))
+	}
+	return
+}
+
+// validateDxRouteFilterPrefix checks that v is a CIDR. Host bits are allowed,
+// as prefixes are normalized before being stored.
+func validateDxRouteFilterPrefix(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q must contain CIDRs such as 203.0.113.0/24, got %q: %s", k, value, err))
+	}
+	return
+}
+
+func validateDxVirtualInterfaceType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != dxVirtualInterfaceTypePrivate && value != dxVirtualInterfaceTypePublic && value != dxVirtualInterfaceTypeTransit {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q, %q or %q", k, dxVirtualInterfaceTypePrivate, dxVirtualInterfaceTypePublic, dxVirtualInterfaceTypeTransit))
+	}
+	return
+}
+
+// validateDxVirtualInterfaceMtu checks that v is an MTU some type of virtual
+// interface supports: 1500 for all of them, 9001 for private and 8500 for
+// transit virtual interfaces.
+func validateDxVirtualInterfaceMtu(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value != 1500 && value != 8500 && value != 9001 {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of 1500, 8500 or 9001, got %d", k, value))
+	}
+	return
+}
+
+func validateInstanceMetadataHttpEndpoint(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != ec2.InstanceMetadataEndpointStateEnabled && value != ec2.InstanceMetadataEndpointStateDisabled {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q or %q", k, ec2.InstanceMetadataEndpointStateEnabled, ec2.InstanceMetadataEndpointStateDisabled))
+	}
+	return
+}
+
+func validateInstanceMetadataHttpTokens(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != ec2.HttpTokensStateOptional && value != ec2.HttpTokensStateRequired {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q or %q", k, ec2.HttpTokensStateOptional, ec2.HttpTokensStateRequired))
+	}
+	return
+}
+
+func validateAmazonSideAsn(v interface{}, k string) (ws []string, errors []error) {
+	value := int64(v.(int))
+	if (value < 64512 || value > 65534) && (value < 4200000000 || value > 4294967294) {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a private ASN in the range 64512-65534 or 4200000000-4294967294, got %d", k, value))
+	}
+	return
+}
+
+func validateVpcEndpointType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != ec2.VpcEndpointTypeGateway && value != ec2.VpcEndpointTypeInterface {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q or %q", k, ec2.VpcEndpointTypeGateway, ec2.VpcEndpointTypeInterface))
+	}
+	return
+}
+
+func validateFlowLogTrafficType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != ec2.TrafficTypeAccept && value != ec2.TrafficTypeReject && value != ec2.TrafficTypeAll {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q, %q or %q", k, ec2.TrafficTypeAccept, ec2.TrafficTypeReject, ec2.TrafficTypeAll))
+	}
+	return
+}
+
+func validateBatchComputeEnvironmentType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != batch.CETypeManaged && value != batch.CETypeUnmanaged {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q or %q", k, batch.CETypeManaged, batch.CETypeUnmanaged))
+	}
+	return
+}
+
+func validateBatchComputeResourceType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != batch.CRTypeEc2 && value != batch.CRTypeSpot {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q or %q", k, batch.CRTypeEc2, batch.CRTypeSpot))
+	}
+	return
+}
+
+func validateSnsSmsType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "Promotional" && value != "Transactional" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q or %q", k, "Promotional", "Transactional"))
+	}
+	return
+}
+
+func validateSnsSmsMonthlySpendLimit(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(`^[0-9]+$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a whole number of US dollars, got %q", k, value))
+	}
+	return
+}
+
+func validateGuardDutyFindingPublishingFrequency(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	switch value {
+	case guardduty.FindingPublishingFrequencyFifteenMinutes,
+		guardduty.FindingPublishingFrequencyOneHour,
+		guardduty.FindingPublishingFrequencySixHours:
+	default:
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q, %q or %q, got %q", k,
+			guardduty.FindingPublishingFrequencyFifteenMinutes,
+			guardduty.FindingPublishingFrequencyOneHour,
+			guardduty.FindingPublishingFrequencySixHours, value))
+	}
+	return
+}
+
+func validateNameRegex(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, err := regexp.Compile(value); err != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a valid regular expression, got %q: %s", k, value, err))
+	}
+	return
+}
+
+func validateMaxConcurrentOperations(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value < 0 {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be negative, got %d", k, value))
+	}
+	return
+}
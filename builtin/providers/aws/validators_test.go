@@ -434,6 +434,31 @@ func TestValidateS3BucketLifecycleStorageClass(t *testing.T) {
 	}
 }
 
+func TestValidateS3BucketObjectLockRetentionMode(t *testing.T) {
+	validModes := []string{
+		"GOVERNANCE",
+		"COMPLIANCE",
+	}
+	for _, v := range validModes {
+		_, errors := validateS3BucketObjectLockRetentionMode(v, "mode")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid object lock retention mode: %q", v, errors)
+		}
+	}
+
+	invalidModes := []string{
+		"compliance",
+		"LEGAL_HOLD",
+		"",
+	}
+	for _, v := range invalidModes {
+		_, errors := validateS3BucketObjectLockRetentionMode(v, "mode")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid object lock retention mode", v)
+		}
+	}
+}
+
 func TestValidateS3BucketLifecycleRuleId(t *testing.T) {
 	validId := []string{
 		"YadaHereAndThere",
@@ -553,3 +578,546 @@ func TestValidateDbEventSubscriptionName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateJsonString(t *testing.T) {
+	validJson := []string{
+		`{}`,
+		`{"Version": "2012-10-17", "Statement": []}`,
+	}
+	for _, v := range validJson {
+		_, errors := validateJsonString(v, "json")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid JSON string: %q", v, errors)
+		}
+	}
+
+	invalidJson := []string{
+		`{`,
+		`{"Version": "2012-10-17",}`,
+		`Version`,
+	}
+	for _, v := range invalidJson {
+		_, errors := validateJsonString(v, "json")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid JSON string", v)
+		}
+	}
+}
+
+func TestValidateInstanceMetadataOptions(t *testing.T) {
+	for _, v := range []string{"enabled", "disabled"} {
+		if _, errors := validateInstanceMetadataHttpEndpoint(v, "http_endpoint"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid http_endpoint: %q", v, errors)
+		}
+	}
+	for _, v := range []string{"", "on", "Enabled"} {
+		if _, errors := validateInstanceMetadataHttpEndpoint(v, "http_endpoint"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid http_endpoint", v)
+		}
+	}
+
+	for _, v := range []string{"optional", "required"} {
+		if _, errors := validateInstanceMetadataHttpTokens(v, "http_tokens"); len(errors) != 0 {
+			t.Fatalf("%q should be valid http_tokens: %q", v, errors)
+		}
+	}
+	for _, v := range []string{"", "v2", "Required"} {
+		if _, errors := validateInstanceMetadataHttpTokens(v, "http_tokens"); len(errors) == 0 {
+			t.Fatalf("%q should be invalid http_tokens", v)
+		}
+	}
+}
+
+func TestValidateDynamoDbBillingMode(t *testing.T) {
+	validModes := []string{
+		"PROVISIONED",
+		"PAY_PER_REQUEST",
+	}
+	for _, v := range validModes {
+		_, errors := validateDynamoDbBillingMode(v, "billing_mode")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid billing mode: %q", v, errors)
+		}
+	}
+
+	invalidModes := []string{
+		"",
+		"provisioned",
+		"ON_DEMAND",
+	}
+	for _, v := range invalidModes {
+		_, errors := validateDynamoDbBillingMode(v, "billing_mode")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid billing mode", v)
+		}
+	}
+}
+
+func TestValidateCloudWatchMetricAlarmUnit(t *testing.T) {
+	validUnits := []string{
+		"Bytes",
+		"Percent",
+		"Count/Second",
+		"None",
+	}
+	for _, v := range validUnits {
+		_, errors := validateCloudWatchMetricAlarmUnit(v, "unit")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid CloudWatch unit: %q", v, errors)
+		}
+	}
+
+	invalidUnits := []string{
+		"bytes",
+		"Byte",
+		"Bytes/Minute",
+		"",
+	}
+	for _, v := range invalidUnits {
+		_, errors := validateCloudWatchMetricAlarmUnit(v, "unit")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid CloudWatch unit", v)
+		}
+	}
+}
+
+func TestValidateDmsReplicationInstanceId(t *testing.T) {
+	validIds := []string{
+		"tf-test-replication-instance-1",
+		"tfTestReplicationInstance",
+	}
+	for _, v := range validIds {
+		_, errors := validateDmsReplicationInstanceId(v, "replication_instance_id")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid DMS replication instance id: %q", v, errors)
+		}
+	}
+
+	invalidIds := []string{
+		"tf_test_replication-instance_1",
+		"tf.test.replication.instance.1",
+		"tf test replication instance 1",
+		"tf-test-replication-instance-1!",
+		"1-tf-test-replication-instance",
+		"tf-test-replication-instance-",
+		"tf--test-replication-instance",
+		"",
+		strings.Repeat("W", 64),
+	}
+	for _, v := range invalidIds {
+		_, errors := validateDmsReplicationInstanceId(v, "replication_instance_id")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid DMS replication instance id", v)
+		}
+	}
+}
+
+func TestValidateDxAddressFamily(t *testing.T) {
+	validFamilies := []string{
+		"ipv4",
+		"ipv6",
+	}
+	for _, v := range validFamilies {
+		_, errors := validateDxAddressFamily(v, "address_family")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid address family: %q", v, errors)
+		}
+	}
+
+	invalidFamilies := []string{
+		"IPv4",
+		"ipv5",
+		"",
+	}
+	for _, v := range invalidFamilies {
+		_, errors := validateDxAddressFamily(v, "address_family")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid address family", v)
+		}
+	}
+}
+
+func TestValidateDxBgpPeerAddress(t *testing.T) {
+	validAddresses := []string{
+		"169.254.0.1/30",
+		"169.254.255.2/30",
+		"2001:db8::1/125",
+	}
+	for _, v := range validAddresses {
+		_, errors := validateDxBgpPeerAddress(v, "amazon_address")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid BGP peer address: %q", v, errors)
+		}
+	}
+
+	invalidAddresses := []string{
+		"169.254.0.1",
+		"169.254.0.1/29",
+		"169.254.0.1/31",
+		"2001:db8::1",
+		"2001:db8::1/64",
+		"not-an-address",
+		"",
+	}
+	for _, v := range invalidAddresses {
+		_, errors := validateDxBgpPeerAddress(v, "amazon_address")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid BGP peer address", v)
+		}
+	}
+}
+
+func TestValidateDxBgpPeerAddressPair(t *testing.T) {
+	cases := []struct {
+		Amazon   string
+		Customer string
+		ErrCount int
+	}{
+		{"169.254.0.1/30", "169.254.0.2/30", 0},
+		{"2001:db8::1/125", "2001:db8::2/125", 0},
+		{"", "169.254.0.2/30", 0},
+		{"169.254.0.1/30", "", 0},
+		{"169.254.0.1/30", "169.254.0.1/30", 1},
+		{"169.254.0.1/30", "2001:db8::2/125", 1},
+		{"2001:db8::1/125", "169.254.0.2/30", 1},
+		{"169.254.0.1/30", "169.254.0.5/30", 1},
+		{"169.254.0.1/30", "169.254.0.2/29", 1},
+		{"2001:db8::1/125", "2001:db8::9/125", 1},
+	}
+
+	for _, tc := range cases {
+		err := validateDxBgpPeerAddressPair(tc.Amazon, tc.Customer)
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("Expected %q and %q to be a valid pair, got: %s", tc.Amazon, tc.Customer, err)
+		}
+		if tc.ErrCount != 0 && err == nil {
+			t.Fatalf("Expected %q and %q to be an invalid pair", tc.Amazon, tc.Customer)
+		}
+	}
+}
+
+func TestValidateDxVirtualInterfaceType(t *testing.T) {
+	validTypes := []string{
+		"private",
+		"public",
+		"transit",
+	}
+	for _, v := range validTypes {
+		_, errors := validateDxVirtualInterfaceType(v, "vif_type")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid virtual interface type: %q", v, errors)
+		}
+	}
+
+	invalidTypes := []string{
+		"Private",
+		"Public",
+		"",
+	}
+	for _, v := range invalidTypes {
+		_, errors := validateDxVirtualInterfaceType(v, "vif_type")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid virtual interface type", v)
+		}
+	}
+}
+
+func TestValidateDxVlan(t *testing.T) {
+	validVlans := []int{
+		1,
+		101,
+		4094,
+	}
+	for _, v := range validVlans {
+		_, errors := validateDxVlan(v, "vlan")
+		if len(errors) != 0 {
+			t.Fatalf("%d should be a valid VLAN: %q", v, errors)
+		}
+	}
+
+	invalidVlans := []int{
+		0,
+		4095,
+		-1,
+	}
+	for _, v := range invalidVlans {
+		_, errors := validateDxVlan(v, "vlan")
+		if len(errors) == 0 {
+			t.Fatalf("%d should be an invalid VLAN", v)
+		}
+	}
+}
+
+func TestValidateDxBgpAsn(t *testing.T) {
+	validAsns := []int{
+		1,
+		7224,
+		65000,
+		65534,
+	}
+	for _, v := range validAsns {
+		_, errors := validateDxBgpAsn(v, "asn")
+		if len(errors) != 0 {
+			t.Fatalf("%d should be a valid ASN: %q", v, errors)
+		}
+	}
+
+	invalidAsns := []int{
+		0,
+		65535,
+		4200000000,
+	}
+	for _, v := range invalidAsns {
+		_, errors := validateDxBgpAsn(v, "asn")
+		if len(errors) == 0 {
+			t.Fatalf("%d should be an invalid ASN", v)
+		}
+	}
+}
+
+func TestValidateDxRouteFilterPrefix(t *testing.T) {
+	validPrefixes := []string{
+		"203.0.113.0/24",
+		"203.0.113.1/24",
+		"2001:db8::/32",
+	}
+	for _, v := range validPrefixes {
+		_, errors := validateDxRouteFilterPrefix(v, "route_filter_prefixes")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid route filter prefix: %q", v, errors)
+		}
+	}
+
+	invalidPrefixes := []string{
+		"203.0.113.0",
+		"203.0.113.0/33",
+		"foo",
+	}
+	for _, v := range invalidPrefixes {
+		_, errors := validateDxRouteFilterPrefix(v, "route_filter_prefixes")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid route filter prefix", v)
+		}
+	}
+}
+
+func TestValidateDxVirtualInterfaceMtu(t *testing.T) {
+	validMtus := []int{
+		1500,
+		8500,
+		9001,
+	}
+	for _, v := range validMtus {
+		_, errors := validateDxVirtualInterfaceMtu(v, "mtu")
+		if len(errors) != 0 {
+			t.Fatalf("%d should be a valid MTU: %q", v, errors)
+		}
+	}
+
+	invalidMtus := []int{
+		0,
+		1499,
+		9000,
+	}
+	for _, v := range invalidMtus {
+		_, errors := validateDxVirtualInterfaceMtu(v, "mtu")
+		if len(errors) == 0 {
+			t.Fatalf("%d should be an invalid MTU", v)
+		}
+	}
+}
+
+func TestValidateAmazonSideAsn(t *testing.T) {
+	validAsns := []int{
+		64512,
+		65534,
+		4200000000,
+		4294967294,
+	}
+	for _, v := range validAsns {
+		_, errors := validateAmazonSideAsn(v, "amazon_side_asn")
+		if len(errors) != 0 {
+			t.Fatalf("%d should be a valid Amazon side ASN: %q", v, errors)
+		}
+	}
+
+	invalidAsns := []int{
+		0,
+		7224,
+		64511,
+		65535,
+		4199999999,
+		4294967295,
+	}
+	for _, v := range invalidAsns {
+		_, errors := validateAmazonSideAsn(v, "amazon_side_asn")
+		if len(errors) == 0 {
+			t.Fatalf("%d should be an invalid Amazon side ASN", v)
+		}
+	}
+}
+
+func TestValidateVpcEndpointType(t *testing.T) {
+	validTypes := []string{
+		"Gateway",
+		"Interface",
+	}
+	for _, v := range validTypes {
+		_, errors := validateVpcEndpointType(v, "vpc_endpoint_type")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid VPC endpoint type: %q", v, errors)
+		}
+	}
+
+	invalidTypes := []string{
+		"gateway",
+		"GatewayLoadBalancer",
+		"",
+	}
+	for _, v := range invalidTypes {
+		_, errors := validateVpcEndpointType(v, "vpc_endpoint_type")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid VPC endpoint type", v)
+		}
+	}
+}
+
+func TestValidateFlowLogTrafficType(t *testing.T) {
+	validTypes := []string{
+		"ACCEPT",
+		"REJECT",
+		"ALL",
+	}
+	for _, v := range validTypes {
+		_, errors := validateFlowLogTrafficType(v, "traffic_type")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid traffic type: %q", v, errors)
+		}
+	}
+
+	invalidTypes := []string{
+		"all",
+		"NONE",
+		"",
+	}
+	for _, v := range invalidTypes {
+		_, errors := validateFlowLogTrafficType(v, "traffic_type")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid traffic type", v)
+		}
+	}
+}
+
+func TestValidateBatchComputeEnvironmentType(t *testing.T) {
+	validTypes := []string{
+		"MANAGED",
+		"UNMANAGED",
+	}
+	for _, v := range validTypes {
+		_, errors := validateBatchComputeEnvironmentType(v, "type")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid Batch compute environment type: %q", v, errors)
+		}
+	}
+
+	invalidTypes := []string{
+		"managed",
+		"EC2",
+		"",
+	}
+	for _, v := range invalidTypes {
+		_, errors := validateBatchComputeEnvironmentType(v, "type")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid Batch compute environment type", v)
+		}
+	}
+}
+
+func TestValidateSnsSmsType(t *testing.T) {
+	validTypes := []string{
+		"Promotional",
+		"Transactional",
+	}
+	for _, v := range validTypes {
+		_, errors := validateSnsSmsType(v, "default_sms_type")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid SMS type: %q", v, errors)
+		}
+	}
+
+	invalidTypes := []string{
+		"promotional",
+		"Marketing",
+		"",
+	}
+	for _, v := range invalidTypes {
+		_, errors := validateSnsSmsType(v, "default_sms_type")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid SMS type", v)
+		}
+	}
+}
+
+func TestValidateGuardDutyFindingPublishingFrequency(t *testing.T) {
+	validFrequencies := []string{
+		"FIFTEEN_MINUTES",
+		"ONE_HOUR",
+		"SIX_HOURS",
+	}
+	for _, v := range validFrequencies {
+		_, errors := validateGuardDutyFindingPublishingFrequency(v, "finding_publishing_frequency")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid GuardDuty finding publishing frequency: %q", v, errors)
+		}
+	}
+
+	invalidFrequencies := []string{
+		"one_hour",
+		"TWO_HOURS",
+		"",
+	}
+	for _, v := range invalidFrequencies {
+		_, errors := validateGuardDutyFindingPublishingFrequency(v, "finding_publishing_frequency")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid GuardDuty finding publishing frequency", v)
+		}
+	}
+}
+
+func TestValidateNameRegex(t *testing.T) {
+	validRegexes := []string{
+		"^amzn-ami-hvm-.*-x86_64-gp2$",
+		"ubuntu",
+	}
+	for _, v := range validRegexes {
+		_, errors := validateNameRegex(v, "name_regex")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid regular expression: %q", v, errors)
+		}
+	}
+
+	invalidRegexes := []string{
+		"[amzn",
+		"*ubuntu",
+	}
+	for _, v := range invalidRegexes {
+		_, errors := validateNameRegex(v, "name_regex")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid regular expression", v)
+		}
+	}
+}
+
+func TestValidateMaxConcurrentOperations(t *testing.T) {
+	for _, v := range []int{0, 1, 10} {
+		_, errors := validateMaxConcurrentOperations(v, "max_concurrent_operations")
+		if len(errors) != 0 {
+			t.Fatalf("%d should be valid: %q", v, errors)
+		}
+	}
+
+	_, errors := validateMaxConcurrentOperations(-1, "max_concurrent_operations")
+	if len(errors) == 0 {
+		t.Fatal("-1 should be invalid")
+	}
+}
@@ -32,6 +32,8 @@ type ArmClient struct {
 	vmClient               compute.VirtualMachinesClient
 
 	appGatewayClient             network.ApplicationGatewaysClient
+	expressRouteCircuitClient    network.ExpressRouteCircuitsClient
+	expressRoutePeeringClient    network.ExpressRouteCircuitPeeringsClient
 	ifaceClient                  network.InterfacesClient
 	loadBalancerClient           network.LoadBalancersClient
 	localNetConnClient           network.LocalNetworkGatewaysClient
@@ -179,6 +181,18 @@ func (c *Config) getArmClient() (*ArmClient, error) {
 	agc.Sender = autorest.CreateSender(withRequestLogging())
 	client.appGatewayClient = agc
 
+	ercc := network.NewExpressRouteCircuitsClient(c.SubscriptionID)
+	setUserAgent(&ercc.Client)
+	ercc.Authorizer = spt
+	ercc.Sender = autorest.CreateSender(withRequestLogging())
+	client.expressRouteCircuitClient = ercc
+
+	erpc := network.NewExpressRouteCircuitPeeringsClient(c.SubscriptionID)
+	setUserAgent(&erpc.Client)
+	erpc.Authorizer = spt
+	erpc.Sender = autorest.CreateSender(withRequestLogging())
+	client.expressRoutePeeringClient = erpc
+
 	ifc := network.NewInterfacesClient(c.SubscriptionID)
 	setUserAgent(&ifc.Client)
 	ifc.Authorizer = spt
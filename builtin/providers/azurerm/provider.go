@@ -47,37 +47,39 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"azurerm_availability_set":       resourceArmAvailabilitySet(),
-			"azurerm_cdn_endpoint":           resourceArmCdnEndpoint(),
-			"azurerm_cdn_profile":            resourceArmCdnProfile(),
-			"azurerm_dns_a_record":           resourceArmDnsARecord(),
-			"azurerm_dns_aaaa_record":        resourceArmDnsAAAARecord(),
-			"azurerm_dns_cname_record":       resourceArmDnsCNameRecord(),
-			"azurerm_dns_mx_record":          resourceArmDnsMxRecord(),
-			"azurerm_dns_ns_record":          resourceArmDnsNsRecord(),
-			"azurerm_dns_srv_record":         resourceArmDnsSrvRecord(),
-			"azurerm_dns_txt_record":         resourceArmDnsTxtRecord(),
-			"azurerm_dns_zone":               resourceArmDnsZone(),
-			"azurerm_local_network_gateway":  resourceArmLocalNetworkGateway(),
-			"azurerm_network_interface":      resourceArmNetworkInterface(),
-			"azurerm_network_security_group": resourceArmNetworkSecurityGroup(),
-			"azurerm_network_security_rule":  resourceArmNetworkSecurityRule(),
-			"azurerm_public_ip":              resourceArmPublicIp(),
-			"azurerm_resource_group":         resourceArmResourceGroup(),
-			"azurerm_route":                  resourceArmRoute(),
-			"azurerm_route_table":            resourceArmRouteTable(),
-			"azurerm_search_service":         resourceArmSearchService(),
-			"azurerm_sql_database":           resourceArmSqlDatabase(),
-			"azurerm_sql_firewall_rule":      resourceArmSqlFirewallRule(),
-			"azurerm_sql_server":             resourceArmSqlServer(),
-			"azurerm_storage_account":        resourceArmStorageAccount(),
-			"azurerm_storage_blob":           resourceArmStorageBlob(),
-			"azurerm_storage_container":      resourceArmStorageContainer(),
-			"azurerm_storage_queue":          resourceArmStorageQueue(),
-			"azurerm_subnet":                 resourceArmSubnet(),
-			"azurerm_template_deployment":    resourceArmTemplateDeployment(),
-			"azurerm_virtual_machine":        resourceArmVirtualMachine(),
-			"azurerm_virtual_network":        resourceArmVirtualNetwork(),
+			"azurerm_availability_set":              resourceArmAvailabilitySet(),
+			"azurerm_cdn_endpoint":                  resourceArmCdnEndpoint(),
+			"azurerm_cdn_profile":                   resourceArmCdnProfile(),
+			"azurerm_dns_a_record":                  resourceArmDnsARecord(),
+			"azurerm_dns_aaaa_record":               resourceArmDnsAAAARecord(),
+			"azurerm_dns_cname_record":              resourceArmDnsCNameRecord(),
+			"azurerm_dns_mx_record":                 resourceArmDnsMxRecord(),
+			"azurerm_dns_ns_record":                 resourceArmDnsNsRecord(),
+			"azurerm_dns_srv_record":                resourceArmDnsSrvRecord(),
+			"azurerm_dns_txt_record":                resourceArmDnsTxtRecord(),
+			"azurerm_dns_zone":                      resourceArmDnsZone(),
+			"azurerm_express_route_circuit":         resourceArmExpressRouteCircuit(),
+			"azurerm_express_route_circuit_peering": resourceArmExpressRouteCircuitPeering(),
+			"azurerm_local_network_gateway":         resourceArmLocalNetworkGateway(),
+			"azurerm_network_interface":             resourceArmNetworkInterface(),
+			"azurerm_network_security_group":        resourceArmNetworkSecurityGroup(),
+			"azurerm_network_security_rule":         resourceArmNetworkSecurityRule(),
+			"azurerm_public_ip":                     resourceArmPublicIp(),
+			"azurerm_resource_group":                resourceArmResourceGroup(),
+			"azurerm_route":                         resourceArmRoute(),
+			"azurerm_route_table":                   resourceArmRouteTable(),
+			"azurerm_search_service":                resourceArmSearchService(),
+			"azurerm_sql_database":                  resourceArmSqlDatabase(),
+			"azurerm_sql_firewall_rule":             resourceArmSqlFirewallRule(),
+			"azurerm_sql_server":                    resourceArmSqlServer(),
+			"azurerm_storage_account":               resourceArmStorageAccount(),
+			"azurerm_storage_blob":                  resourceArmStorageBlob(),
+			"azurerm_storage_container":             resourceArmStorageContainer(),
+			"azurerm_storage_queue":                 resourceArmStorageQueue(),
+			"azurerm_subnet":                        resourceArmSubnet(),
+			"azurerm_template_deployment":           resourceArmTemplateDeployment(),
+			"azurerm_virtual_machine":               resourceArmVirtualMachine(),
+			"azurerm_virtual_network":               resourceArmVirtualNetwork(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
@@ -0,0 +1,259 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/arm/network"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceArmExpressRouteCircuit() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmExpressRouteCircuitCreate,
+		Read:   resourceArmExpressRouteCircuitRead,
+		Update: resourceArmExpressRouteCircuitCreate,
+		Delete: resourceArmExpressRouteCircuitDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"location": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: azureRMNormalizeLocation,
+			},
+
+			"resource_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"service_provider_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"peering_location": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"bandwidth_in_mbps": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"sku_tier": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateExpressRouteCircuitSkuTier,
+			},
+
+			"sku_family": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateExpressRouteCircuitSkuFamily,
+			},
+
+			"service_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"service_provider_provisioning_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmExpressRouteCircuitCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+	circuitClient := client.expressRouteCircuitClient
+
+	log.Printf("[INFO] preparing arguments for Azure ARM ExpressRoute Circuit creation.")
+
+	name := d.Get("name").(string)
+	location := d.Get("location").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	serviceProviderName := d.Get("service_provider_name").(string)
+	peeringLocation := d.Get("peering_location").(string)
+	bandwidth := d.Get("bandwidth_in_mbps").(int)
+	skuTier := d.Get("sku_tier").(string)
+	skuFamily := d.Get("sku_family").(string)
+	tags := d.Get("tags").(map[string]interface{})
+
+	// The name of the SKU is derived from its tier and family, e.g.
+	// Standard_MeteredData.
+	skuName := fmt.Sprintf("%s_%s", skuTier, skuFamily)
+
+	circuit := network.ExpressRouteCircuit{
+		Name:     &name,
+		Location: &location,
+		Sku: &network.ExpressRouteCircuitSku{
+			Name:   &skuName,
+			Tier:   network.ExpressRouteCircuitSkuTier(skuTier),
+			Family: network.ExpressRouteCircuitSkuFamily(skuFamily),
+		},
+		Properties: &network.ExpressRouteCircuitPropertiesFormat{
+			ServiceProviderProperties: &network.ExpressRouteCircuitServiceProviderProperties{
+				ServiceProviderName: &serviceProviderName,
+				PeeringLocation:     &peeringLocation,
+				BandwidthInMbps:     &bandwidth,
+			},
+		},
+		Tags: expandTags(tags),
+	}
+
+	resp, err := circuitClient.CreateOrUpdate(resGroup, name, circuit)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*resp.ID)
+
+	log.Printf("[DEBUG] Waiting for ExpressRoute Circuit (%s) to become available", name)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Accepted", "Updating"},
+		Target:  []string{"Succeeded"},
+		Refresh: expressRouteCircuitStateRefreshFunc(client, resGroup, name),
+		Timeout: 10 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for ExpressRoute Circuit (%s) to become available: %s", name, err)
+	}
+
+	return resourceArmExpressRouteCircuitRead(d, meta)
+}
+
+func resourceArmExpressRouteCircuitRead(d *schema.ResourceData, meta interface{}) error {
+	circuitClient := meta.(*ArmClient).expressRouteCircuitClient
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["expressRouteCircuits"]
+
+	resp, err := circuitClient.Get(resGroup, name)
+	if resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error making Read request on Azure ExpressRoute Circuit %s: %s", name, err)
+	}
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resGroup)
+	d.Set("location", azureRMNormalizeLocation(*resp.Location))
+
+	if sku := resp.Sku; sku != nil {
+		d.Set("sku_tier", string(sku.Tier))
+		d.Set("sku_family", string(sku.Family))
+	}
+
+	if props := resp.Properties; props != nil {
+		if sp := props.ServiceProviderProperties; sp != nil {
+			d.Set("service_provider_name", sp.ServiceProviderName)
+			d.Set("peering_location", sp.PeeringLocation)
+			d.Set("bandwidth_in_mbps", sp.BandwidthInMbps)
+		}
+
+		d.Set("service_key", props.ServiceKey)
+		d.Set("service_provider_provisioning_state", string(props.ServiceProviderProvisioningState))
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmExpressRouteCircuitDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+	circuitClient := client.expressRouteCircuitClient
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["expressRouteCircuits"]
+
+	if _, err := circuitClient.Delete(resGroup, name); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Waiting for ExpressRoute Circuit (%s) to be deleted", name)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Accepted", "Deleting", "Succeeded", "Updating"},
+		Target:  []string{"Deleted"},
+		Refresh: expressRouteCircuitStateRefreshFunc(client, resGroup, name),
+		Timeout: 10 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for ExpressRoute Circuit (%s) to be deleted: %s", name, err)
+	}
+
+	return nil
+}
+
+// expressRouteCircuitStateRefreshFunc returns a resource.StateRefreshFunc
+// that is used to watch the provisioning state of an ExpressRoute Circuit.
+// A circuit that can no longer be found is reported in the "Deleted" state.
+func expressRouteCircuitStateRefreshFunc(client *ArmClient, resourceGroupName string, circuitName string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		res, err := client.expressRouteCircuitClient.Get(resourceGroupName, circuitName)
+		if res.StatusCode == http.StatusNotFound {
+			return res, "Deleted", nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("Error issuing read request in expressRouteCircuitStateRefreshFunc to Azure ARM for ExpressRoute Circuit '%s' (RG: '%s'): %s", circuitName, resourceGroupName, err)
+		}
+
+		return res, *res.Properties.ProvisioningState, nil
+	}
+}
+
+func validateExpressRouteCircuitSkuTier(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	tiers := map[string]bool{
+		string(network.ExpressRouteCircuitSkuTierStandard): true,
+		string(network.ExpressRouteCircuitSkuTierPremium):  true,
+	}
+
+	if !tiers[value] {
+		errors = append(errors, fmt.Errorf("ExpressRoute Circuit SKU tier can only be Standard or Premium"))
+	}
+	return
+}
+
+func validateExpressRouteCircuitSkuFamily(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	families := map[string]bool{
+		string(network.MeteredData):   true,
+		string(network.UnlimitedData): true,
+	}
+
+	if !families[value] {
+		errors = append(errors, fmt.Errorf("ExpressRoute Circuit SKU family can only be MeteredData or UnlimitedData"))
+	}
+	return
+}
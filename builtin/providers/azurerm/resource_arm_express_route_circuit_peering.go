@@ -0,0 +1,331 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/arm/network"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceArmExpressRouteCircuitPeering() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmExpressRouteCircuitPeeringCreate,
+		Read:   resourceArmExpressRouteCircuitPeeringRead,
+		Update: resourceArmExpressRouteCircuitPeeringCreate,
+		Delete: resourceArmExpressRouteCircuitPeeringDelete,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"express_route_circuit_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"peering_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateExpressRouteCircuitPeeringType,
+			},
+
+			"primary_peer_address_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"secondary_peer_address_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"vlan_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"peer_asn": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"shared_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"microsoft_peering_config": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"advertised_public_prefixes": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"customer_asn": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"routing_registry_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"azure_asn": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"primary_azure_port": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"secondary_azure_port": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmExpressRouteCircuitPeeringCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+	peeringClient := client.expressRoutePeeringClient
+
+	resGroup := d.Get("resource_group_name").(string)
+	circuitName := d.Get("express_route_circuit_name").(string)
+	peeringType := d.Get("peering_type").(string)
+
+	primaryPeerAddressPrefix := d.Get("primary_peer_address_prefix").(string)
+	secondaryPeerAddressPrefix := d.Get("secondary_peer_address_prefix").(string)
+	vlanID := d.Get("vlan_id").(int)
+	peerASN := d.Get("peer_asn").(int)
+
+	armMutexKV.Lock(circuitName)
+	defer armMutexKV.Unlock(circuitName)
+
+	// Peerings can only be configured once the connectivity provider has
+	// provisioned the circuit, using its service key.
+	circuit, err := client.expressRouteCircuitClient.Get(resGroup, circuitName)
+	if err != nil {
+		return fmt.Errorf("Error reading ExpressRoute Circuit %s: %s", circuitName, err)
+	}
+	if state := circuit.Properties.ServiceProviderProvisioningState; state != network.Provisioned {
+		return fmt.Errorf(
+			"ExpressRoute Circuit %s must be provisioned by its service provider before peerings can be configured, its service provider provisioning state is %q",
+			circuitName, state)
+	}
+
+	properties := network.ExpressRouteCircuitPeeringPropertiesFormat{
+		PeeringType:                network.ExpressRouteCircuitPeeringType(peeringType),
+		PrimaryPeerAddressPrefix:   &primaryPeerAddressPrefix,
+		SecondaryPeerAddressPrefix: &secondaryPeerAddressPrefix,
+		VlanID:                     &vlanID,
+		PeerASN:                    &peerASN,
+	}
+
+	if v, ok := d.GetOk("shared_key"); ok {
+		sharedKey := v.(string)
+		properties.SharedKey = &sharedKey
+	}
+
+	if v, ok := d.GetOk("microsoft_peering_config"); ok {
+		properties.MicrosoftPeeringConfig = expandAzureRmExpressRouteCircuitPeeringConfig(v.([]interface{}))
+	}
+
+	// The name of a peering is its type, there can only be one peering of
+	// each type on a circuit.
+	peering := network.ExpressRouteCircuitPeering{
+		Name:       &peeringType,
+		Properties: &properties,
+	}
+
+	resp, err := peeringClient.CreateOrUpdate(resGroup, circuitName, peeringType, peering)
+	if err != nil {
+		return err
+	}
+	d.SetId(*resp.ID)
+
+	log.Printf("[DEBUG] Waiting for ExpressRoute Circuit Peering (%s) to become available", peeringType)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Accepted", "Updating"},
+		Target:  []string{"Succeeded"},
+		Refresh: expressRouteCircuitPeeringStateRefreshFunc(client, resGroup, circuitName, peeringType),
+		Timeout: 10 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for ExpressRoute Circuit Peering (%s) to become available: %s", peeringType, err)
+	}
+
+	return resourceArmExpressRouteCircuitPeeringRead(d, meta)
+}
+
+func resourceArmExpressRouteCircuitPeeringRead(d *schema.ResourceData, meta interface{}) error {
+	peeringClient := meta.(*ArmClient).expressRoutePeeringClient
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	circuitName := id.Path["expressRouteCircuits"]
+	peeringName := id.Path["peerings"]
+
+	resp, err := peeringClient.Get(resGroup, circuitName, peeringName)
+	if resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error making Read request on Azure ExpressRoute Circuit Peering %s: %s", peeringName, err)
+	}
+
+	d.Set("resource_group_name", resGroup)
+	d.Set("express_route_circuit_name", circuitName)
+
+	if props := resp.Properties; props != nil {
+		d.Set("peering_type", string(props.PeeringType))
+		d.Set("primary_peer_address_prefix", props.PrimaryPeerAddressPrefix)
+		d.Set("secondary_peer_address_prefix", props.SecondaryPeerAddressPrefix)
+		d.Set("vlan_id", props.VlanID)
+		d.Set("peer_asn", props.PeerASN)
+		d.Set("azure_asn", props.AzureASN)
+		d.Set("primary_azure_port", props.PrimaryAzurePort)
+		d.Set("secondary_azure_port", props.SecondaryAzurePort)
+
+		if props.MicrosoftPeeringConfig != nil {
+			if err := d.Set("microsoft_peering_config", flattenAzureRmExpressRouteCircuitPeeringConfig(props.MicrosoftPeeringConfig)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceArmExpressRouteCircuitPeeringDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+	peeringClient := client.expressRoutePeeringClient
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	circuitName := id.Path["expressRouteCircuits"]
+	peeringName := id.Path["peerings"]
+
+	armMutexKV.Lock(circuitName)
+	defer armMutexKV.Unlock(circuitName)
+
+	if _, err := peeringClient.Delete(resGroup, circuitName, peeringName); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Waiting for ExpressRoute Circuit Peering (%s) to be deleted", peeringName)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Accepted", "Deleting", "Succeeded", "Updating"},
+		Target:  []string{"Deleted"},
+		Refresh: expressRouteCircuitPeeringStateRefreshFunc(client, resGroup, circuitName, peeringName),
+		Timeout: 10 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for ExpressRoute Circuit Peering (%s) to be deleted: %s", peeringName, err)
+	}
+
+	return nil
+}
+
+func expandAzureRmExpressRouteCircuitPeeringConfig(configs []interface{}) *network.ExpressRouteCircuitPeeringConfig {
+	data := configs[0].(map[string]interface{})
+
+	rawPrefixes := data["advertised_public_prefixes"].([]interface{})
+	prefixes := make([]string, 0, len(rawPrefixes))
+	for _, p := range rawPrefixes {
+		prefixes = append(prefixes, p.(string))
+	}
+
+	config := network.ExpressRouteCircuitPeeringConfig{
+		AdvertisedPublicPrefixes: &prefixes,
+	}
+
+	if v := data["customer_asn"].(int); v != 0 {
+		config.CustomerASN = &v
+	}
+
+	if v := data["routing_registry_name"].(string); v != "" {
+		config.RoutingRegistryName = &v
+	}
+
+	return &config
+}
+
+func flattenAzureRmExpressRouteCircuitPeeringConfig(config *network.ExpressRouteCircuitPeeringConfig) []interface{} {
+	result := make(map[string]interface{})
+
+	if config.AdvertisedPublicPrefixes != nil {
+		result["advertised_public_prefixes"] = *config.AdvertisedPublicPrefixes
+	}
+
+	if config.CustomerASN != nil {
+		result["customer_asn"] = *config.CustomerASN
+	}
+
+	if config.RoutingRegistryName != nil {
+		result["routing_registry_name"] = *config.RoutingRegistryName
+	}
+
+	return []interface{}{result}
+}
+
+// expressRouteCircuitPeeringStateRefreshFunc returns a
+// resource.StateRefreshFunc that is used to watch the provisioning state of
+// an ExpressRoute Circuit Peering. A peering that can no longer be found is
+// reported in the "Deleted" state.
+func expressRouteCircuitPeeringStateRefreshFunc(client *ArmClient, resourceGroupName string, circuitName string, peeringName string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		res, err := client.expressRoutePeeringClient.Get(resourceGroupName, circuitName, peeringName)
+		if res.StatusCode == http.StatusNotFound {
+			return res, "Deleted", nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("Error issuing read request in expressRouteCircuitPeeringStateRefreshFunc to Azure ARM for ExpressRoute Circuit Peering '%s' (RG: '%s') (Circuit: '%s'): %s", peeringName, resourceGroupName, circuitName, err)
+		}
+
+		return res, *res.Properties.ProvisioningState, nil
+	}
+}
+
+func validateExpressRouteCircuitPeeringType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	types := map[string]bool{
+		string(network.AzurePrivatePeering): true,
+		string(network.AzurePublicPeering):  true,
+		string(network.MicrosoftPeering):    true,
+	}
+
+	if !types[value] {
+		errors = append(errors, fmt.Errorf("ExpressRoute Circuit Peering type can only be AzurePrivatePeering, AzurePublicPeering or MicrosoftPeering"))
+	}
+	return
+}
@@ -0,0 +1,176 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceAzureRMExpressRouteCircuitPeeringType_validation(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{"AzurePrivatePeering", 0},
+		{"AzurePublicPeering", 0},
+		{"MicrosoftPeering", 0},
+		{"azureprivatepeering", 1},
+		{"PrivatePeering", 1},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateExpressRouteCircuitPeeringType(tc.Value, "azurerm_express_route_circuit_peering")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d validation errors for %q, got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestExpandFlattenAzureRmExpressRouteCircuitPeeringConfig(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"advertised_public_prefixes": []interface{}{"123.1.0.0/24", "123.2.0.0/24"},
+			"customer_asn":               65000,
+			"routing_registry_name":      "ARIN",
+		},
+	}
+
+	config := expandAzureRmExpressRouteCircuitPeeringConfig(raw)
+	if len(*config.AdvertisedPublicPrefixes) != 2 || (*config.AdvertisedPublicPrefixes)[1] != "123.2.0.0/24" {
+		t.Fatalf("bad: %#v", config.AdvertisedPublicPrefixes)
+	}
+	if *config.CustomerASN != 65000 {
+		t.Fatalf("bad: %d", *config.CustomerASN)
+	}
+	if *config.RoutingRegistryName != "ARIN" {
+		t.Fatalf("bad: %s", *config.RoutingRegistryName)
+	}
+
+	flattened := flattenAzureRmExpressRouteCircuitPeeringConfig(config)[0].(map[string]interface{})
+	if flattened["customer_asn"] != 65000 || flattened["routing_registry_name"] != "ARIN" {
+		t.Fatalf("bad: %#v", flattened)
+	}
+
+	// Optional values are left unset
+	config = expandAzureRmExpressRouteCircuitPeeringConfig([]interface{}{
+		map[string]interface{}{
+			"advertised_public_prefixes": []interface{}{"123.1.0.0/24"},
+			"customer_asn":               0,
+			"routing_registry_name":      "",
+		},
+	})
+	if config.CustomerASN != nil || config.RoutingRegistryName != nil {
+		t.Fatalf("bad: %#v", config)
+	}
+}
+
+// Peerings can only be configured on a circuit that has been provisioned by
+// its service provider, which can't be done as part of the test.
+func TestAccAzureRMExpressRouteCircuitPeering_basic(t *testing.T) {
+	resourceGroup := os.Getenv("ARM_TEST_EXPRESS_ROUTE_RESOURCE_GROUP")
+	circuitName := os.Getenv("ARM_TEST_EXPRESS_ROUTE_CIRCUIT_NAME")
+	if resourceGroup == "" || circuitName == "" {
+		t.Skip("Environment variables ARM_TEST_EXPRESS_ROUTE_RESOURCE_GROUP and ARM_TEST_EXPRESS_ROUTE_CIRCUIT_NAME are not set")
+	}
+
+	preConfig := fmt.Sprintf(testAccAzureRMExpressRouteCircuitPeering_basic, resourceGroup, circuitName, 100)
+	postConfig := fmt.Sprintf(testAccAzureRMExpressRouteCircuitPeering_basic, resourceGroup, circuitName, 200)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMExpressRouteCircuitPeeringDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRouteCircuitPeeringExists("azurerm_express_route_circuit_peering.test"),
+					resource.TestCheckResourceAttr(
+						"azurerm_express_route_circuit_peering.test", "vlan_id", "100"),
+				),
+			},
+
+			resource.TestStep{
+				Config: postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRouteCircuitPeeringExists("azurerm_express_route_circuit_peering.test"),
+					resource.TestCheckResourceAttr(
+						"azurerm_express_route_circuit_peering.test", "vlan_id", "200"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMExpressRouteCircuitPeeringExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		peeringType := rs.Primary.Attributes["peering_type"]
+		circuitName := rs.Primary.Attributes["express_route_circuit_name"]
+		resourceGroup, hasResourceGroup := rs.Primary.Attributes["resource_group_name"]
+		if !hasResourceGroup {
+			return fmt.Errorf("Bad: no resource group found in state for ExpressRoute Circuit Peering: %s", peeringType)
+		}
+
+		conn := testAccProvider.Meta().(*ArmClient).expressRoutePeeringClient
+
+		resp, err := conn.Get(resourceGroup, circuitName, peeringType)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on expressRoutePeeringClient: %s", err)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: ExpressRoute Circuit Peering %q (circuit: %q) does not exist", peeringType, circuitName)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMExpressRouteCircuitPeeringDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*ArmClient).expressRoutePeeringClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_express_route_circuit_peering" {
+			continue
+		}
+
+		peeringType := rs.Primary.Attributes["peering_type"]
+		circuitName := rs.Primary.Attributes["express_route_circuit_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := conn.Get(resourceGroup, circuitName, peeringType)
+
+		if err != nil {
+			return nil
+		}
+
+		if resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("ExpressRoute Circuit Peering still exists:\n%#v", resp.Properties)
+		}
+	}
+
+	return nil
+}
+
+var testAccAzureRMExpressRouteCircuitPeering_basic = `
+resource "azurerm_express_route_circuit_peering" "test" {
+    resource_group_name = "%s"
+    express_route_circuit_name = "%s"
+    peering_type = "AzurePrivatePeering"
+    primary_peer_address_prefix = "192.168.1.0/30"
+    secondary_peer_address_prefix = "192.168.2.0/30"
+    vlan_id = %d
+    peer_asn = 65000
+}
+`
@@ -0,0 +1,147 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceAzureRMExpressRouteCircuitSku_validation(t *testing.T) {
+	cases := []struct {
+		Value    string
+		Func     func(interface{}, string) ([]string, []error)
+		ErrCount int
+	}{
+		{"Standard", validateExpressRouteCircuitSkuTier, 0},
+		{"Premium", validateExpressRouteCircuitSkuTier, 0},
+		{"Basic", validateExpressRouteCircuitSkuTier, 1},
+		{"MeteredData", validateExpressRouteCircuitSkuFamily, 0},
+		{"UnlimitedData", validateExpressRouteCircuitSkuFamily, 0},
+		{"Unmetered", validateExpressRouteCircuitSkuFamily, 1},
+	}
+
+	for _, tc := range cases {
+		_, errors := tc.Func(tc.Value, "azurerm_express_route_circuit")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d validation errors for %q, got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestAccAzureRMExpressRouteCircuit_basic(t *testing.T) {
+
+	ri := acctest.RandInt()
+	preConfig := fmt.Sprintf(testAccAzureRMExpressRouteCircuit_basic, ri, ri, 50)
+	postConfig := fmt.Sprintf(testAccAzureRMExpressRouteCircuit_basic, ri, ri, 100)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMExpressRouteCircuitDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRouteCircuitExists("azurerm_express_route_circuit.test"),
+					resource.TestCheckResourceAttr(
+						"azurerm_express_route_circuit.test", "bandwidth_in_mbps", "50"),
+					resource.TestCheckResourceAttr(
+						"azurerm_express_route_circuit.test", "service_provider_provisioning_state", "NotProvisioned"),
+					resource.TestMatchResourceAttr(
+						"azurerm_express_route_circuit.test", "service_key", regexp.MustCompile(".+")),
+				),
+			},
+
+			resource.TestStep{
+				Config: postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRouteCircuitExists("azurerm_express_route_circuit.test"),
+					resource.TestCheckResourceAttr(
+						"azurerm_express_route_circuit.test", "bandwidth_in_mbps", "100"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMExpressRouteCircuitExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup, hasResourceGroup := rs.Primary.Attributes["resource_group_name"]
+		if !hasResourceGroup {
+			return fmt.Errorf("Bad: no resource group found in state for ExpressRoute Circuit: %s", name)
+		}
+
+		conn := testAccProvider.Meta().(*ArmClient).expressRouteCircuitClient
+
+		resp, err := conn.Get(resourceGroup, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on expressRouteCircuitClient: %s", err)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: ExpressRoute Circuit %q (resource group: %q) does not exist", name, resourceGroup)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMExpressRouteCircuitDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*ArmClient).expressRouteCircuitClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_express_route_circuit" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := conn.Get(resourceGroup, name)
+
+		if err != nil {
+			return nil
+		}
+
+		if resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("ExpressRoute Circuit still exists:\n%#v", resp.Properties)
+		}
+	}
+
+	return nil
+}
+
+var testAccAzureRMExpressRouteCircuit_basic = `
+resource "azurerm_resource_group" "test" {
+    name = "acctestrg-%d"
+    location = "West US"
+}
+
+resource "azurerm_express_route_circuit" "test" {
+    name = "acctesterc%d"
+    location = "West US"
+    resource_group_name = "${azurerm_resource_group.test.name}"
+    service_provider_name = "Equinix"
+    peering_location = "Silicon Valley"
+    bandwidth_in_mbps = %d
+    sku_tier = "Standard"
+    sku_family = "MeteredData"
+
+    tags {
+        environment = "Production"
+    }
+}
+`
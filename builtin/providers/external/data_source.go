@@ -0,0 +1,105 @@
+package external
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"program": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"query": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"result": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	programRaw := d.Get("program").([]interface{})
+	if len(programRaw) == 0 {
+		return fmt.Errorf("program must have at least one element")
+	}
+
+	program := make([]string, len(programRaw))
+	for i, v := range programRaw {
+		s, ok := v.(string)
+		if !ok || s == "" {
+			return fmt.Errorf("program element %d must be a non-empty string", i)
+		}
+		program[i] = s
+	}
+
+	query := make(map[string]string)
+	for k, v := range d.Get("query").(map[string]interface{}) {
+		query[k] = v.(string)
+	}
+
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return fmt.Errorf("Error encoding query as JSON: %s", err)
+	}
+
+	log.Printf("[DEBUG] Running external program: %s", strings.Join(program, " "))
+	cmd := exec.Command(program[0], program[1:]...)
+	cmd.Stdin = bytes.NewReader(queryJSON)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	resultJSON, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("Error running %q: %s: %s", program[0], err, msg)
+		}
+		return fmt.Errorf("Error running %q: %s", program[0], err)
+	}
+
+	// The result must be a flat JSON object with only string values, so
+	// it can be exposed as a map attribute.
+	var raw map[string]interface{}
+	if err := json.Unmarshal(resultJSON, &raw); err != nil {
+		return fmt.Errorf(
+			"Error parsing the output of %q as a JSON object: %s", program[0], err)
+	}
+
+	result := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf(
+				"The output of %q must only contain string values, but %q is %T",
+				program[0], k, v)
+		}
+		result[k] = s
+	}
+
+	if err := d.Set("result", result); err != nil {
+		return err
+	}
+
+	d.SetId("-")
+	return nil
+}
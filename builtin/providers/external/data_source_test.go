@@ -0,0 +1,82 @@
+package external
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSource_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		Providers:                 testProviders,
+		PreventPostDestroyRefresh: true,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.external.ipam", "result.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.external.ipam", "result.vlan", "101"),
+					resource.TestCheckResourceAttr(
+						"data.external.ipam", "result.asn", "64512"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceRead_errors(t *testing.T) {
+	cases := map[string]struct {
+		Program []interface{}
+		Error   string
+	}{
+		"Failure": {
+			Program: []interface{}{"sh", "-c", "echo no free VLAN >&2; exit 1"},
+			Error:   "no free VLAN",
+		},
+
+		"NotFound": {
+			Program: []interface{}{"tf-acc-external-not-found"},
+			Error:   "Error running",
+		},
+
+		"NotJSON": {
+			Program: []interface{}{"echo", "101"},
+			Error:   "as a JSON object",
+		},
+
+		"NotString": {
+			Program: []interface{}{"echo", `{"vlan": 101}`},
+			Error:   "must only contain string values",
+		},
+	}
+
+	for k, tc := range cases {
+		d := dataSource().Data(nil)
+		if err := d.Set("program", tc.Program); err != nil {
+			t.Fatalf("%s: err: %s", k, err)
+		}
+
+		err := dataSourceRead(d, nil)
+		if err == nil {
+			t.Fatalf("%s: should error", k)
+		}
+		if !strings.Contains(err.Error(), tc.Error) {
+			t.Fatalf("%s: bad error: %s", k, err)
+		}
+	}
+}
+
+// The query is passed on stdin, so cat returns it as the result
+const testDataSourceConfig_basic = `
+data "external" "ipam" {
+	program = ["cat"]
+
+	query = {
+		vlan = "101"
+		asn  = "64512"
+	}
+}
+`
@@ -0,0 +1,114 @@
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// The vendored compute API predates Cloud Interconnect, so the
+// interconnectAttachments methods are called here directly, in the same way
+// as the generated API client does.
+
+// computeInterconnectAttachment is an interconnect attachment, or VLAN
+// attachment, as returned by the compute API.
+type computeInterconnectAttachment struct {
+	Name                    string   `json:"name,omitempty"`
+	Description             string   `json:"description,omitempty"`
+	Region                  string   `json:"region,omitempty"`
+	Router                  string   `json:"router,omitempty"`
+	Type                    string   `json:"type,omitempty"`
+	Interconnect            string   `json:"interconnect,omitempty"`
+	EdgeAvailabilityDomain  string   `json:"edgeAvailabilityDomain,omitempty"`
+	CandidateSubnets        []string `json:"candidateSubnets,omitempty"`
+	VlanTag8021q            int64    `json:"vlanTag8021q,omitempty"`
+	PairingKey              string   `json:"pairingKey,omitempty"`
+	CloudRouterIpAddress    string   `json:"cloudRouterIpAddress,omitempty"`
+	CustomerRouterIpAddress string   `json:"customerRouterIpAddress,omitempty"`
+	GoogleReferenceId       string   `json:"googleReferenceId,omitempty"`
+	State                   string   `json:"state,omitempty"`
+	SelfLink                string   `json:"selfLink,omitempty"`
+}
+
+func computeInterconnectAttachmentInsert(config *Config, project, region string, attachment *computeInterconnectAttachment) (*compute.Operation, error) {
+	body, err := json.Marshal(attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	op := new(compute.Operation)
+	err = computeInterconnectAttachmentDo(config, "POST",
+		"{project}/regions/{region}/interconnectAttachments",
+		map[string]string{
+			"project": project,
+			"region":  region,
+		}, bytes.NewReader(body), op)
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func computeInterconnectAttachmentGet(config *Config, project, region, name string) (*computeInterconnectAttachment, error) {
+	attachment := new(computeInterconnectAttachment)
+	err := computeInterconnectAttachmentDo(config, "GET",
+		"{project}/regions/{region}/interconnectAttachments/{interconnectAttachment}",
+		map[string]string{
+			"project":                project,
+			"region":                 region,
+			"interconnectAttachment": name,
+		}, nil, attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func computeInterconnectAttachmentDelete(config *Config, project, region, name string) (*compute.Operation, error) {
+	op := new(compute.Operation)
+	err := computeInterconnectAttachmentDo(config, "DELETE",
+		"{project}/regions/{region}/interconnectAttachments/{interconnectAttachment}",
+		map[string]string{
+			"project":                project,
+			"region":                 region,
+			"interconnectAttachment": name,
+		}, nil, op)
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// computeInterconnectAttachmentDo makes a request to the compute API and
+// decodes the response into result. Errors are returned as *googleapi.Error,
+// like those of the generated API client.
+func computeInterconnectAttachmentDo(config *Config, method, path string, expansions map[string]string, body io.Reader, result interface{}) error {
+	urls := googleapi.ResolveRelative(config.clientCompute.BasePath, path) + "?alt=json"
+	req, err := http.NewRequest(method, urls, body)
+	if err != nil {
+		return err
+	}
+	googleapi.Expand(req.URL, expansions)
+	req.Header.Set("User-Agent", config.userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := config.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return err
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
@@ -34,6 +34,12 @@ type Config struct {
 	clientStorage   *storage.Service
 	clientSqlAdmin  *sqladmin.Service
 	clientPubsub    *pubsub.Service
+
+	// client is the authenticated HTTP client the API clients are built on,
+	// for the API calls they don't support, see
+	// compute_interconnect_attachments.go.
+	client    *http.Client
+	userAgent string
 }
 
 func (c *Config) loadAndValidate() error {
@@ -95,6 +101,9 @@ func (c *Config) loadAndValidate() error {
 
 	var err error
 
+	c.client = client
+	c.userAgent = userAgent
+
 	log.Printf("[INFO] Instantiating GCE client...")
 	c.clientCompute, err = compute.New(client)
 	if err != nil {
@@ -54,43 +54,44 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"google_compute_autoscaler":             resourceComputeAutoscaler(),
-			"google_compute_address":                resourceComputeAddress(),
-			"google_compute_backend_service":        resourceComputeBackendService(),
-			"google_compute_disk":                   resourceComputeDisk(),
-			"google_compute_firewall":               resourceComputeFirewall(),
-			"google_compute_forwarding_rule":        resourceComputeForwardingRule(),
-			"google_compute_global_address":         resourceComputeGlobalAddress(),
-			"google_compute_global_forwarding_rule": resourceComputeGlobalForwardingRule(),
-			"google_compute_http_health_check":      resourceComputeHttpHealthCheck(),
-			"google_compute_https_health_check":     resourceComputeHttpsHealthCheck(),
-			"google_compute_instance":               resourceComputeInstance(),
-			"google_compute_instance_group":         resourceComputeInstanceGroup(),
-			"google_compute_instance_group_manager": resourceComputeInstanceGroupManager(),
-			"google_compute_instance_template":      resourceComputeInstanceTemplate(),
-			"google_compute_network":                resourceComputeNetwork(),
-			"google_compute_project_metadata":       resourceComputeProjectMetadata(),
-			"google_compute_route":                  resourceComputeRoute(),
-			"google_compute_ssl_certificate":        resourceComputeSslCertificate(),
-			"google_compute_subnetwork":             resourceComputeSubnetwork(),
-			"google_compute_target_http_proxy":      resourceComputeTargetHttpProxy(),
-			"google_compute_target_https_proxy":     resourceComputeTargetHttpsProxy(),
-			"google_compute_target_pool":            resourceComputeTargetPool(),
-			"google_compute_url_map":                resourceComputeUrlMap(),
-			"google_compute_vpn_gateway":            resourceComputeVpnGateway(),
-			"google_compute_vpn_tunnel":             resourceComputeVpnTunnel(),
-			"google_container_cluster":              resourceContainerCluster(),
-			"google_dns_managed_zone":               resourceDnsManagedZone(),
-			"google_dns_record_set":                 resourceDnsRecordSet(),
-			"google_sql_database":                   resourceSqlDatabase(),
-			"google_sql_database_instance":          resourceSqlDatabaseInstance(),
-			"google_sql_user":                       resourceSqlUser(),
-			"google_pubsub_topic":                   resourcePubsubTopic(),
-			"google_pubsub_subscription":            resourcePubsubSubscription(),
-			"google_storage_bucket":                 resourceStorageBucket(),
-			"google_storage_bucket_acl":             resourceStorageBucketAcl(),
-			"google_storage_bucket_object":          resourceStorageBucketObject(),
-			"google_storage_object_acl":             resourceStorageObjectAcl(),
+			"google_compute_autoscaler":              resourceComputeAutoscaler(),
+			"google_compute_address":                 resourceComputeAddress(),
+			"google_compute_backend_service":         resourceComputeBackendService(),
+			"google_compute_disk":                    resourceComputeDisk(),
+			"google_compute_firewall":                resourceComputeFirewall(),
+			"google_compute_forwarding_rule":         resourceComputeForwardingRule(),
+			"google_compute_global_address":          resourceComputeGlobalAddress(),
+			"google_compute_global_forwarding_rule":  resourceComputeGlobalForwardingRule(),
+			"google_compute_http_health_check":       resourceComputeHttpHealthCheck(),
+			"google_compute_https_health_check":      resourceComputeHttpsHealthCheck(),
+			"google_compute_instance":                resourceComputeInstance(),
+			"google_compute_instance_group":          resourceComputeInstanceGroup(),
+			"google_compute_instance_group_manager":  resourceComputeInstanceGroupManager(),
+			"google_compute_instance_template":       resourceComputeInstanceTemplate(),
+			"google_compute_interconnect_attachment": resourceComputeInterconnectAttachment(),
+			"google_compute_network":                 resourceComputeNetwork(),
+			"google_compute_project_metadata":        resourceComputeProjectMetadata(),
+			"google_compute_route":                   resourceComputeRoute(),
+			"google_compute_ssl_certificate":         resourceComputeSslCertificate(),
+			"google_compute_subnetwork":              resourceComputeSubnetwork(),
+			"google_compute_target_http_proxy":       resourceComputeTargetHttpProxy(),
+			"google_compute_target_https_proxy":      resourceComputeTargetHttpsProxy(),
+			"google_compute_target_pool":             resourceComputeTargetPool(),
+			"google_compute_url_map":                 resourceComputeUrlMap(),
+			"google_compute_vpn_gateway":             resourceComputeVpnGateway(),
+			"google_compute_vpn_tunnel":              resourceComputeVpnTunnel(),
+			"google_container_cluster":               resourceContainerCluster(),
+			"google_dns_managed_zone":                resourceDnsManagedZone(),
+			"google_dns_record_set":                  resourceDnsRecordSet(),
+			"google_sql_database":                    resourceSqlDatabase(),
+			"google_sql_database_instance":           resourceSqlDatabaseInstance(),
+			"google_sql_user":                        resourceSqlUser(),
+			"google_pubsub_topic":                    resourcePubsubTopic(),
+			"google_pubsub_subscription":             resourcePubsubSubscription(),
+			"google_storage_bucket":                  resourceStorageBucket(),
+			"google_storage_bucket_acl":              resourceStorageBucketAcl(),
+			"google_storage_bucket_object":           resourceStorageBucketObject(),
+			"google_storage_object_acl":              resourceStorageObjectAcl(),
 		},
 
 		ConfigureFunc: providerConfigure,
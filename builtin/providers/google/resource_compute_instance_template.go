@@ -40,7 +40,7 @@ func resourceComputeInstanceTemplate() *schema.Resource {
 				ForceNew: true,
 				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
 					// https://cloud.google.com/compute/docs/reference/latest/instanceTemplates#resource
-					// uuid is 26 characters, limit the prefix to 37.
+					// The unique suffix is 26 characters, limit the prefix to 37.
 					value := v.(string)
 					if len(value) > 37 {
 						errors = append(errors, fmt.Errorf(
@@ -0,0 +1,280 @@
+package google
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"google.golang.org/api/googleapi"
+)
+
+func resourceComputeInterconnectAttachment() *schema.Resource {
+	return &schema.Resource{
+		// Interconnect attachments can't be updated in place, except for
+		// fields that aren't supported here.
+		Create: resourceComputeInterconnectAttachmentCreate,
+		Read:   resourceComputeInterconnectAttachmentRead,
+		Delete: resourceComputeInterconnectAttachmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"router": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "DEDICATED",
+				ValidateFunc: validateInterconnectAttachmentType,
+			},
+
+			"interconnect": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"edge_availability_domain": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validateInterconnectAttachmentEdgeAvailabilityDomain,
+			},
+
+			"candidate_subnets": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"vlan_tag8021q": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"pairing_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cloud_router_ip_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"customer_router_ip_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"google_reference_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"self_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceComputeInterconnectAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	attachmentType := d.Get("type").(string)
+
+	attachment := &computeInterconnectAttachment{
+		Name:   name,
+		Router: d.Get("router").(string),
+		Type:   attachmentType,
+	}
+
+	// Dedicated attachments are on an interconnect of the project, partner
+	// attachments are on an interconnect of the partner, which is selected
+	// by its edge availability domain.
+	switch attachmentType {
+	case "DEDICATED":
+		v, ok := d.GetOk("interconnect")
+		if !ok {
+			return fmt.Errorf("interconnect must be set for DEDICATED interconnect attachment %s", name)
+		}
+		attachment.Interconnect = v.(string)
+	case "PARTNER":
+		if _, ok := d.GetOk("interconnect"); ok {
+			return fmt.Errorf("interconnect can't be set for PARTNER interconnect attachment %s", name)
+		}
+		attachment.EdgeAvailabilityDomain = "AVAILABILITY_DOMAIN_ANY"
+		if v, ok := d.GetOk("edge_availability_domain"); ok {
+			attachment.EdgeAvailabilityDomain = v.(string)
+		}
+	}
+
+	if v, ok := d.GetOk("candidate_subnets"); ok {
+		for _, s := range v.([]interface{}) {
+			attachment.CandidateSubnets = append(attachment.CandidateSubnets, s.(string))
+		}
+	}
+
+	if v, ok := d.GetOk("vlan_tag8021q"); ok {
+		attachment.VlanTag8021q = int64(v.(int))
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		attachment.Description = v.(string)
+	}
+
+	op, err := computeInterconnectAttachmentInsert(config, project, region, attachment)
+	if err != nil {
+		return fmt.Errorf("Error Inserting Interconnect Attachment %s: %s", name, err)
+	}
+
+	err = computeOperationWaitRegion(config, op, region, "Inserting Interconnect Attachment")
+	if err != nil {
+		return fmt.Errorf("Error Waiting to Insert Interconnect Attachment %s: %s", name, err)
+	}
+
+	return resourceComputeInterconnectAttachmentRead(d, meta)
+}
+
+func resourceComputeInterconnectAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	attachment, err := computeInterconnectAttachmentGet(config, project, region, name)
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing Interconnect Attachment %q because it's gone", name)
+			// The resource doesn't exist anymore
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error Reading Interconnect Attachment %s: %s", name, err)
+	}
+
+	d.Set("edge_availability_domain", attachment.EdgeAvailabilityDomain)
+	d.Set("vlan_tag8021q", attachment.VlanTag8021q)
+	d.Set("pairing_key", attachment.PairingKey)
+	d.Set("cloud_router_ip_address", attachment.CloudRouterIpAddress)
+	d.Set("customer_router_ip_address", attachment.CustomerRouterIpAddress)
+	d.Set("google_reference_id", attachment.GoogleReferenceId)
+	d.Set("state", attachment.State)
+	d.Set("self_link", attachment.SelfLink)
+	d.SetId(name)
+
+	return nil
+}
+
+func resourceComputeInterconnectAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	op, err := computeInterconnectAttachmentDelete(config, project, region, name)
+	if err != nil {
+		return fmt.Errorf("Error Deleting Interconnect Attachment %s: %s", name, err)
+	}
+
+	err = computeOperationWaitRegion(config, op, region, "Deleting Interconnect Attachment")
+	if err != nil {
+		return fmt.Errorf("Error Waiting to Delete Interconnect Attachment %s: %s", name, err)
+	}
+
+	return nil
+}
+
+func validateInterconnectAttachmentType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "DEDICATED" && value != "PARTNER" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be DEDICATED or PARTNER, got %q", k, value))
+	}
+	return
+}
+
+func validateInterconnectAttachmentEdgeAvailabilityDomain(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	domains := map[string]bool{
+		"AVAILABILITY_DOMAIN_ANY": true,
+		"AVAILABILITY_DOMAIN_1":   true,
+		"AVAILABILITY_DOMAIN_2":   true,
+	}
+
+	if !domains[value] {
+		errors = append(errors, fmt.Errorf(
+			"%q must be AVAILABILITY_DOMAIN_ANY, AVAILABILITY_DOMAIN_1 or AVAILABILITY_DOMAIN_2, got %q", k, value))
+	}
+	return
+}
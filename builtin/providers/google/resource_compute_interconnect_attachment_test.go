@@ -0,0 +1,208 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestComputeInterconnectAttachmentAPI(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case "POST":
+			var a computeInterconnectAttachment
+			if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if a.Type != "PARTNER" || a.EdgeAvailabilityDomain != "AVAILABILITY_DOMAIN_1" {
+				t.Fatalf("bad: %#v", a)
+			}
+			fmt.Fprint(w, `{"name": "op-insert", "status": "PENDING"}`)
+		case "GET":
+			if r.URL.Path == "/projects/foo/regions/us-east1/interconnectAttachments/missing" {
+				w.WriteHeader(404)
+				fmt.Fprint(w, `{"error": {"code": 404, "message": "not found"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"name": "bar", "pairingKey": "key/us-east1/1", "state": "PENDING_PARTNER"}`)
+		case "DELETE":
+			fmt.Fprint(w, `{"name": "op-delete", "status": "PENDING"}`)
+		}
+	}))
+	defer ts.Close()
+
+	clientCompute, err := compute.New(http.DefaultClient)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	clientCompute.BasePath = ts.URL + "/projects/"
+	config := &Config{
+		clientCompute: clientCompute,
+		client:        http.DefaultClient,
+	}
+
+	op, err := computeInterconnectAttachmentInsert(config, "foo", "us-east1", &computeInterconnectAttachment{
+		Name:                   "bar",
+		Type:                   "PARTNER",
+		EdgeAvailabilityDomain: "AVAILABILITY_DOMAIN_1",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if op.Name != "op-insert" {
+		t.Fatalf("bad: %#v", op)
+	}
+
+	attachment, err := computeInterconnectAttachmentGet(config, "foo", "us-east1", "bar")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if attachment.PairingKey != "key/us-east1/1" || attachment.State != "PENDING_PARTNER" {
+		t.Fatalf("bad: %#v", attachment)
+	}
+
+	_, err = computeInterconnectAttachmentGet(config, "foo", "us-east1", "missing")
+	if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != 404 {
+		t.Fatalf("bad: %#v", err)
+	}
+
+	op, err = computeInterconnectAttachmentDelete(config, "foo", "us-east1", "bar")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if op.Name != "op-delete" {
+		t.Fatalf("bad: %#v", op)
+	}
+
+	expected := []string{
+		"POST /projects/foo/regions/us-east1/interconnectAttachments",
+		"GET /projects/foo/regions/us-east1/interconnectAttachments/bar",
+		"GET /projects/foo/regions/us-east1/interconnectAttachments/missing",
+		"DELETE /projects/foo/regions/us-east1/interconnectAttachments/bar",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Fatalf("bad: %#v", requests)
+	}
+}
+
+func TestValidateInterconnectAttachmentEdgeAvailabilityDomain(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{"AVAILABILITY_DOMAIN_ANY", 0},
+		{"AVAILABILITY_DOMAIN_1", 0},
+		{"AVAILABILITY_DOMAIN_2", 0},
+		{"AVAILABILITY_DOMAIN_3", 1},
+		{"availability_domain_1", 1},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateInterconnectAttachmentEdgeAvailabilityDomain(tc.Value, "edge_availability_domain")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d validation errors for %q, got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+// Interconnect attachments need a Cloud Router, which can't be managed by
+// the provider yet.
+func TestAccComputeInterconnectAttachment_partner(t *testing.T) {
+	router := os.Getenv("GOOGLE_TEST_ROUTER")
+	if router == "" {
+		t.Skip("Environment variable GOOGLE_TEST_ROUTER is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeInterconnectAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccComputeInterconnectAttachment_partner, acctest.RandString(10), router),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeInterconnectAttachmentExists(
+						"google_compute_interconnect_attachment.foobar"),
+					resource.TestCheckResourceAttr(
+						"google_compute_interconnect_attachment.foobar", "state", "PENDING_PARTNER"),
+					resource.TestCheckResourceAttr(
+						"google_compute_interconnect_attachment.foobar", "edge_availability_domain", "AVAILABILITY_DOMAIN_1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComputeInterconnectAttachmentDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	project := config.Project
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_compute_interconnect_attachment" {
+			continue
+		}
+
+		region := rs.Primary.Attributes["region"]
+		name := rs.Primary.Attributes["name"]
+
+		_, err := computeInterconnectAttachmentGet(config, project, region, name)
+
+		if err == nil {
+			return fmt.Errorf("Error, Interconnect Attachment %s in region %s still exists",
+				name, region)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckComputeInterconnectAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		name := rs.Primary.Attributes["name"]
+		region := rs.Primary.Attributes["region"]
+		project := config.Project
+
+		attachment, err := computeInterconnectAttachmentGet(config, project, region, name)
+		if err != nil {
+			return fmt.Errorf("Error Reading Interconnect Attachment %s: %s", name, err)
+		}
+
+		if attachment.PairingKey == "" {
+			return fmt.Errorf("Interconnect Attachment %s has no pairing key", name)
+		}
+
+		return nil
+	}
+}
+
+var testAccComputeInterconnectAttachment_partner = `
+resource "google_compute_interconnect_attachment" "foobar" {
+	name = "interconnect-attachment-test-%s"
+	region = "us-central1"
+	router = "%s"
+	type = "PARTNER"
+	edge_availability_domain = "AVAILABILITY_DOMAIN_1"
+}`
@@ -0,0 +1,84 @@
+package null
+
+import (
+	"fmt"
+	"testing"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResource_triggers(t *testing.T) {
+	var first, second string
+
+	r.UnitTest(t, r.TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"null": Provider(),
+		},
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: testResourceConfig_triggers("169.254.255.1/30"),
+				Check: r.ComposeTestCheckFunc(
+					testResourceID("null_resource.router", &first),
+					r.TestCheckResourceAttr(
+						"null_resource.router", "triggers.amazon_address", "169.254.255.1/30"),
+				),
+			},
+
+			// Changing a trigger replaces the resource, so its
+			// provisioners run again
+			r.TestStep{
+				Config: testResourceConfig_triggers("169.254.255.5/30"),
+				Check: r.ComposeTestCheckFunc(
+					testResourceID("null_resource.router", &second),
+					func(*terraform.State) error {
+						if first == second {
+							return fmt.Errorf("resource should have been replaced: %s", first)
+						}
+						return nil
+					},
+				),
+			},
+
+			// Unchanged triggers leave the resource alone
+			r.TestStep{
+				Config: testResourceConfig_triggers("169.254.255.5/30"),
+				Check: r.ComposeTestCheckFunc(
+					testResourceID("null_resource.router", &first),
+					func(*terraform.State) error {
+						if first != second {
+							return fmt.Errorf("resource should not have been replaced: %s", first)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testResourceID(n string, id *string) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testResourceConfig_triggers(address string) string {
+	return fmt.Sprintf(`
+resource "null_resource" "router" {
+	triggers {
+		amazon_address = "%s"
+		auth_key       = "secret"
+	}
+}
+`, address)
+}
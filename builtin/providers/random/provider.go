@@ -0,0 +1,41 @@
+package random
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"random_id":       resourceId(),
+			"random_password": resourcePassword(),
+			"random_shuffle":  resourceShuffle(),
+		},
+	}
+}
+
+// keepersSchema returns the schema of the keepers of a random resource,
+// which replace the resource, and so generate a new random value, when
+// they change.
+func keepersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		ForceNew: true,
+	}
+}
+
+// removeResource is the Delete function of the random resources, which
+// only exist in the state.
+func removeResource(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// readNil is the Read function of the random resources, whose values
+// never change once they are generated.
+func readNil(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package random
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceId() *schema.Resource {
+	return &schema.Resource{
+		Create: createID,
+		Read:   readID,
+		Delete: removeResource,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"keepers": keepersSchema(),
+
+			"byte_length": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePositiveInt,
+			},
+
+			"prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"b64_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"b64_std": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hex": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dec": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createID(d *schema.ResourceData, meta interface{}) error {
+	bytes := make([]byte, d.Get("byte_length").(int))
+	if _, err := rand.Read(bytes); err != nil {
+		return fmt.Errorf("Error generating random bytes: %s", err)
+	}
+
+	// The ID holds the bytes, so the other attributes can be derived
+	// from it when importing.
+	d.SetId(base64.RawURLEncoding.EncodeToString(bytes))
+
+	return readID(d, meta)
+}
+
+func readID(d *schema.ResourceData, meta interface{}) error {
+	bytes, err := base64.RawURLEncoding.DecodeString(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error decoding random_id ID %q: %s", d.Id(), err)
+	}
+
+	prefix := d.Get("prefix").(string)
+	dec := new(big.Int).SetBytes(bytes).String()
+
+	d.Set("byte_length", len(bytes))
+	d.Set("b64_url", prefix+d.Id())
+	d.Set("b64_std", prefix+base64.StdEncoding.EncodeToString(bytes))
+	d.Set("hex", prefix+hex.EncodeToString(bytes))
+	d.Set("dec", prefix+dec)
+
+	return nil
+}
+
+func validatePositiveInt(v interface{}, k string) (ws []string, es []error) {
+	if v.(int) < 1 {
+		es = append(es, fmt.Errorf("%s: must be at least 1", k))
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package random
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceId(t *testing.T) {
+	r.UnitTest(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: testRandomIdConfig,
+				Check: r.ComposeTestCheckFunc(
+					testAccResourceIdCheck("random_id.foo"),
+					r.TestMatchResourceAttr(
+						"random_id.bar", "hex", regexp.MustCompile("^cloud-[0-9a-f]{8}$")),
+				),
+			},
+
+			r.TestStep{
+				ResourceName:      "random_id.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceIdCheck(id string) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[id]
+		if !ok {
+			return fmt.Errorf("Not found: %s", id)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		attrs := rs.Primary.Attributes
+		if len(attrs["b64_url"]) != 6 {
+			return fmt.Errorf("bad b64_url: %s", attrs["b64_url"])
+		}
+		if len(attrs["b64_std"]) != 8 {
+			return fmt.Errorf("bad b64_std: %s", attrs["b64_std"])
+		}
+		if len(attrs["hex"]) != 8 {
+			return fmt.Errorf("bad hex: %s", attrs["hex"])
+		}
+		if attrs["dec"] == "" {
+			return fmt.Errorf("bad dec: %s", attrs["dec"])
+		}
+
+		return nil
+	}
+}
+
+const testRandomIdConfig = `
+resource "random_id" "foo" {
+	byte_length = 4
+}
+
+resource "random_id" "bar" {
+	byte_length = 4
+	prefix      = "cloud-"
+}
+`
@@ -0,0 +1,210 @@
+package random
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	passwordLowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordNumberChars  = "0123456789"
+	passwordSpecialChars = "!@#$%&*()-_=+[]{}<>:?"
+)
+
+func resourcePassword() *schema.Resource {
+	return &schema.Resource{
+		Create: createPassword,
+		Read:   readNil,
+		Delete: removeResource,
+
+		Schema: map[string]*schema.Schema{
+			"keepers": keepersSchema(),
+
+			"length": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePositiveInt,
+			},
+
+			"lower": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"upper": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"number": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"special": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"override_special": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"min_lower": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"min_upper": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"min_numeric": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"min_special": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"result": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// passwordCharClass is a class of characters a password can contain,
+// along with the minimum number of characters of the class.
+type passwordCharClass struct {
+	Chars string
+	Min   int
+}
+
+func createPassword(d *schema.ResourceData, meta interface{}) error {
+	special := passwordSpecialChars
+	if v, ok := d.GetOk("override_special"); ok {
+		special = v.(string)
+	}
+
+	var classes []passwordCharClass
+	for _, c := range []struct {
+		Enabled, Min string
+		Chars        string
+	}{
+		{"lower", "min_lower", passwordLowerChars},
+		{"upper", "min_upper", passwordUpperChars},
+		{"number", "min_numeric", passwordNumberChars},
+		{"special", "min_special", special},
+	} {
+		if !d.Get(c.Enabled).(bool) || c.Chars == "" {
+			continue
+		}
+		classes = append(classes, passwordCharClass{
+			Chars: c.Chars,
+			Min:   d.Get(c.Min).(int),
+		})
+	}
+
+	result, err := generatePassword(d.Get("length").(int), classes)
+	if err != nil {
+		return err
+	}
+
+	d.Set("result", result)
+	d.SetId("none")
+	return nil
+}
+
+// generatePassword returns a password of the given length, with at least
+// the minimum number of characters of each class.
+func generatePassword(length int, classes []passwordCharClass) (string, error) {
+	if len(classes) == 0 {
+		return "", fmt.Errorf("At least one of lower, upper, number or special must be enabled")
+	}
+
+	var all string
+	var min int
+	for _, c := range classes {
+		all += c.Chars
+		min += c.Min
+	}
+	if min > length {
+		return "", fmt.Errorf(
+			"The minimum numbers of characters add up to %d, which is more than the length %d",
+			min, length)
+	}
+
+	result := make([]byte, 0, length)
+	for _, c := range classes {
+		chars, err := randomChars(c.Chars, c.Min)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, chars...)
+	}
+
+	chars, err := randomChars(all, length-len(result))
+	if err != nil {
+		return "", err
+	}
+	result = append(result, chars...)
+
+	// Shuffle the password so the characters required by the minimums
+	// aren't always at the start
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		result[i], result[j] = result[j], result[i]
+	}
+
+	return string(result), nil
+}
+
+// randomChars returns n characters picked at random from chars.
+func randomChars(chars string, n int) ([]byte, error) {
+	result := make([]byte, n)
+	for i := range result {
+		j, err := randomInt(len(chars))
+		if err != nil {
+			return nil, err
+		}
+		result[i] = chars[j]
+	}
+	return result, nil
+}
+
+// randomInt returns a cryptographically secure random int in [0, max).
+func randomInt(max int) (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, fmt.Errorf("Error generating random number: %s", err)
+	}
+	return int(n.Int64()), nil
+}
@@ -0,0 +1,92 @@
+package random
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourcePassword(t *testing.T) {
+	r.UnitTest(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "random_password" "auth_key" {
+	length  = 24
+	special = false
+}
+`,
+				Check: testResourcePasswordCheck("random_password.auth_key", 24),
+			},
+		},
+	})
+}
+
+func testResourcePasswordCheck(n string, length int) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		result := rs.Primary.Attributes["result"]
+		if len(result) != length {
+			return fmt.Errorf("bad length of %q: %d", result, len(result))
+		}
+		if strings.ContainsAny(result, passwordSpecialChars) {
+			return fmt.Errorf("should not contain special characters: %q", result)
+		}
+
+		return nil
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	classes := []passwordCharClass{
+		{Chars: passwordLowerChars, Min: 2},
+		{Chars: passwordNumberChars, Min: 3},
+		{Chars: "!", Min: 1},
+	}
+
+	for i := 0; i < 20; i++ {
+		result, err := generatePassword(6, classes)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		var lower, number, special int
+		for _, c := range result {
+			switch {
+			case strings.ContainsRune(passwordLowerChars, c):
+				lower++
+			case strings.ContainsRune(passwordNumberChars, c):
+				number++
+			case c == '!':
+				special++
+			default:
+				t.Fatalf("bad character %q in %q", c, result)
+			}
+		}
+		if lower != 2 || number != 3 || special != 1 {
+			t.Fatalf("bad: %q", result)
+		}
+	}
+}
+
+func TestGeneratePassword_errors(t *testing.T) {
+	if _, err := generatePassword(8, nil); err == nil {
+		t.Fatal("should error without character classes")
+	}
+
+	classes := []passwordCharClass{
+		{Chars: passwordLowerChars, Min: 5},
+		{Chars: passwordUpperChars, Min: 5},
+	}
+	if _, err := generatePassword(8, classes); err == nil {
+		t.Fatal("should error when the minimums exceed the length")
+	}
+}
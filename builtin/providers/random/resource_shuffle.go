@@ -0,0 +1,90 @@
+package random
+
+import (
+	"hash/crc64"
+	"math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceShuffle() *schema.Resource {
+	return &schema.Resource{
+		Create: createShuffle,
+		Read:   readNil,
+		Delete: removeResource,
+
+		Schema: map[string]*schema.Schema{
+			"keepers": keepersSchema(),
+
+			"seed": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"input": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"result_count": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePositiveInt,
+			},
+
+			"result": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func createShuffle(d *schema.ResourceData, meta interface{}) error {
+	input := d.Get("input").([]interface{})
+	seed := time.Now().UnixNano()
+	if v, ok := d.GetOk("seed"); ok {
+		seed = int64(crc64.Checksum([]byte(v.(string)), crc64.MakeTable(crc64.ECMA)))
+	}
+
+	resultCount := len(input)
+	if v, ok := d.GetOk("result_count"); ok {
+		resultCount = v.(int)
+	}
+
+	d.Set("result", shuffle(input, resultCount, seed))
+	d.SetId("-")
+	return nil
+}
+
+// shuffle returns count elements of the input in a random order, which is
+// the same for the same seed. If count is larger than the number of
+// elements of the input, the shuffled elements are repeated.
+func shuffle(input []interface{}, count int, seed int64) []interface{} {
+	result := make([]interface{}, 0, count)
+	if len(input) == 0 {
+		return result
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	for len(result) < count {
+		for _, i := range r.Perm(len(input)) {
+			if len(result) == count {
+				break
+			}
+			result = append(result, input[i])
+		}
+	}
+
+	return result
+}
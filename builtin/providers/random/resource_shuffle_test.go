@@ -0,0 +1,55 @@
+package random
+
+import (
+	"reflect"
+	"testing"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestResourceShuffle(t *testing.T) {
+	r.UnitTest(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "random_shuffle" "az" {
+	input        = ["us-west-2a", "us-west-2b", "us-west-2c"]
+	result_count = 5
+	seed         = "dx"
+}
+`,
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("random_shuffle.az", "result.#", "5"),
+				),
+			},
+		},
+	})
+}
+
+func TestShuffle(t *testing.T) {
+	input := []interface{}{"a", "b", "c", "d"}
+
+	// The same seed always gives the same order
+	first := shuffle(input, 4, 42)
+	if second := shuffle(input, 4, 42); !reflect.DeepEqual(first, second) {
+		t.Fatalf("bad: %#v != %#v", first, second)
+	}
+
+	seen := make(map[interface{}]bool)
+	for _, v := range first {
+		seen[v] = true
+	}
+	if len(seen) != 4 {
+		t.Fatalf("bad: %#v", first)
+	}
+
+	// Larger counts repeat the elements
+	if result := shuffle(input, 10, 42); len(result) != 10 {
+		t.Fatalf("bad: %#v", result)
+	}
+
+	if result := shuffle(nil, 3, 42); len(result) != 0 {
+		t.Fatalf("bad: %#v", result)
+	}
+}
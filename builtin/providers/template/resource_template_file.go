@@ -132,9 +132,10 @@ func renderFile(d *schema.ResourceData) (string, error) {
 	return rendered, nil
 }
 
-// execute parses and executes a template using vars.
+// execute parses and executes a template using vars. Templates support the
+// same interpolation syntax as the configuration, operators included.
 func execute(s string, vars map[string]interface{}) (string, error) {
-	root, err := hil.Parse(s)
+	root, err := config.ParseInterpolations(s)
 	if err != nil {
 		return "", err
 	}
@@ -160,7 +161,7 @@ func execute(s string, vars map[string]interface{}) (string, error) {
 		},
 	}
 
-	result, err := hil.Eval(root, &cfg)
+	result, err := config.EvalInterpolations(root, &cfg)
 	if err != nil {
 		return "", err
 	}
@@ -26,6 +26,7 @@ func TestTemplateRendering(t *testing.T) {
 		{`{a="foo"}`, `${a}`, `foo`},
 		{`{a="hello"}`, `${replace(a, "ello", "i")}`, `hi`},
 		{`{}`, `${1+2+3}`, `6`},
+		{`{a="prod"}`, `${a == "prod" ? "big" : "small"}`, `big`},
 	}
 
 	for _, tt := range cases {
@@ -1,11 +1,14 @@
 package terraform
 
 import (
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform/backend"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/state/remote"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 func dataSourceRemoteState() *schema.Resource {
@@ -14,8 +17,9 @@ func dataSourceRemoteState() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"backend": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateRemoteStateBackend,
 			},
 
 			"config": &schema.Schema{
@@ -32,32 +36,39 @@ func dataSourceRemoteState() *schema.Resource {
 }
 
 func dataSourceRemoteStateRead(d *schema.ResourceData, meta interface{}) error {
-	backend := d.Get("backend").(string)
+	backendType := d.Get("backend").(string)
 	config := make(map[string]string)
 	for k, v := range d.Get("config").(map[string]interface{}) {
 		config[k] = v.(string)
 	}
 
-	// Create the client to access our remote state
-	log.Printf("[DEBUG] Initializing remote state client: %s", backend)
-	client, err := remote.NewClient(backend, config)
+	// Initialize the backend to access our remote state
+	log.Printf("[DEBUG] Initializing remote state backend: %s", backendType)
+	b, err := backend.Init(backendType, config)
 	if err != nil {
 		return err
 	}
 
-	// Create the remote state itself and refresh it in order to load the state
+	// Refresh the state in order to load it
 	log.Printf("[DEBUG] Loading remote state...")
-	state := &remote.State{Client: client}
+	state, err := b.State()
+	if err != nil {
+		return err
+	}
 	if err := state.RefreshState(); err != nil {
 		return err
 	}
 
-	var outputs map[string]interface{}
+	outputs := make(map[string]interface{})
 	if !state.State().Empty() {
-		outputValueMap := make(map[string]string)
 		for key, output := range state.State().RootModule().Outputs {
-			//This is ok for 0.6.17 as outputs will have been strings
-			outputValueMap[key] = output.Value.(string)
+			v, err := remoteStateOutputValue(output)
+			if err != nil {
+				log.Printf("[WARN] Skipping remote state output %q: %s", key, err)
+				continue
+			}
+
+			outputs[key] = v
 		}
 	}
 
@@ -65,3 +76,35 @@ func dataSourceRemoteStateRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("output", outputs)
 	return nil
 }
+
+// remoteStateOutputValue returns the value of an output of the remote
+// state as a string, as the values of the output map are strings. Lists
+// are joined with commas, as with outputs before Terraform 0.7, so that
+// they can be split again with split().
+func remoteStateOutputValue(output *terraform.OutputState) (string, error) {
+	switch v := output.Value.(type) {
+	case string:
+		return v, nil
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return "", fmt.Errorf("list element %d is not a string", i)
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, ","), nil
+	default:
+		return "", fmt.Errorf("outputs of type %s are not supported", output.Type)
+	}
+}
+
+func validateRemoteStateBackend(v interface{}, k string) (ws []string, es []error) {
+	backendType := v.(string)
+	if _, ok := backend.Backends[backendType]; !ok {
+		es = append(es, fmt.Errorf(
+			"%s: unknown remote state backend %q", k, backendType))
+	}
+	return
+}
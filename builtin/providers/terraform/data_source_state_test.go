@@ -24,6 +24,39 @@ func TestAccState_basic(t *testing.T) {
 	})
 }
 
+func TestAccState_dataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                  func() { testAccPreCheck(t) },
+		Providers:                 testAccProviders,
+		PreventPostDestroyRefresh: true,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccState_dataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStateValue(
+						"data.terraform_remote_state.foo", "foo", "bar"),
+					testAccCheckStateValue(
+						"data.terraform_remote_state.foo", "subnets", "subnet-a,subnet-b"),
+					resource.TestCheckResourceAttr(
+						"data.terraform_remote_state.foo", "output.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateRemoteStateBackend(t *testing.T) {
+	for _, b := range []string{"s3", "consul", "http"} {
+		if _, es := validateRemoteStateBackend(b, "backend"); len(es) > 0 {
+			t.Fatalf("%s: %v", b, es)
+		}
+	}
+
+	if _, es := validateRemoteStateBackend("nope", "backend"); len(es) == 0 {
+		t.Fatal("should error")
+	}
+}
+
 func testAccCheckStateValue(id, name, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[id]
@@ -52,3 +85,12 @@ resource "terraform_remote_state" "foo" {
 		path = "./test-fixtures/basic.tfstate"
 	}
 }`
+
+const testAccState_dataSource = `
+data "terraform_remote_state" "foo" {
+	backend = "_local"
+
+	config {
+		path = "./test-fixtures/basic.tfstate"
+	}
+}`
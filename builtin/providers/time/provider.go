@@ -0,0 +1,104 @@
+package time
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"time_rotating": resourceTimeRotating(),
+			"time_static":   resourceTimeStatic(),
+		},
+	}
+}
+
+// timeSchema returns the attributes shared by the time resources, which
+// expose the parts of their timestamp.
+func timeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"rfc3339": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validateRFC3339,
+		},
+
+		"triggers": &schema.Schema{
+			Type:     schema.TypeMap,
+			Optional: true,
+			ForceNew: true,
+		},
+
+		"year": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+
+		"month": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+
+		"day": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+
+		"hour": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+
+		"minute": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+
+		"second": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+
+		"unix": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+// timestamp returns the configured timestamp of a time resource being
+// created, or the current time if none is configured.
+func timestamp(d *schema.ResourceData) (time.Time, error) {
+	if v, ok := d.GetOk("rfc3339"); ok {
+		return time.Parse(time.RFC3339, v.(string))
+	}
+
+	return time.Now().UTC().Truncate(time.Second), nil
+}
+
+// setTimeAttributes sets the attributes of the given timestamp.
+func setTimeAttributes(d *schema.ResourceData, t time.Time) {
+	d.Set("rfc3339", t.Format(time.RFC3339))
+	d.Set("year", t.Year())
+	d.Set("month", int(t.Month()))
+	d.Set("day", t.Day())
+	d.Set("hour", t.Hour())
+	d.Set("minute", t.Minute())
+	d.Set("second", t.Second())
+	d.Set("unix", t.Unix())
+}
+
+func validateRFC3339(v interface{}, k string) (ws []string, es []error) {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		es = append(es, fmt.Errorf(
+			"%s: must be an RFC3339 timestamp, such as 2016-09-01T10:00:00Z: %s", k, err))
+	}
+	return
+}
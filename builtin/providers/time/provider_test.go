@@ -0,0 +1,31 @@
+package time
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+var testProviders = map[string]terraform.ResourceProvider{
+	"time": Provider(),
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProvider_impl(t *testing.T) {
+	var _ terraform.ResourceProvider = Provider()
+}
+
+func TestValidateRFC3339(t *testing.T) {
+	if _, es := validateRFC3339("2016-09-01T10:00:00Z", "rfc3339"); len(es) > 0 {
+		t.Fatalf("bad: %v", es)
+	}
+	if _, es := validateRFC3339("2016-09-01", "rfc3339"); len(es) == 0 {
+		t.Fatal("should error")
+	}
+}
@@ -0,0 +1,110 @@
+package time
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// rotationAttributes are the attributes that configure the rotation of
+// time_rotating, at least one of which must be set.
+var rotationAttributes = []string{
+	"rotation_years",
+	"rotation_months",
+	"rotation_days",
+	"rotation_hours",
+	"rotation_minutes",
+}
+
+func resourceTimeRotating() *schema.Resource {
+	s := timeSchema()
+	for _, k := range rotationAttributes {
+		s[k] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validatePositiveInt,
+		}
+	}
+	s["rotation_rfc3339"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return &schema.Resource{
+		Create: resourceTimeRotatingCreate,
+		Read:   resourceTimeRotatingRead,
+		Delete: resourceTimeRotatingDelete,
+
+		Schema: s,
+	}
+}
+
+func resourceTimeRotatingCreate(d *schema.ResourceData, meta interface{}) error {
+	var set bool
+	for _, k := range rotationAttributes {
+		if _, ok := d.GetOk(k); ok {
+			set = true
+		}
+	}
+	if !set {
+		return fmt.Errorf("One of %v must be set", rotationAttributes)
+	}
+
+	t, err := timestamp(d)
+	if err != nil {
+		return err
+	}
+
+	rotation := t.AddDate(
+		d.Get("rotation_years").(int),
+		d.Get("rotation_months").(int),
+		d.Get("rotation_days").(int))
+	rotation = rotation.Add(
+		time.Duration(d.Get("rotation_hours").(int))*time.Hour +
+			time.Duration(d.Get("rotation_minutes").(int))*time.Minute)
+
+	d.SetId(t.Format(time.RFC3339))
+	d.Set("rotation_rfc3339", rotation.Format(time.RFC3339))
+
+	return resourceTimeRotatingRead(d, meta)
+}
+
+func resourceTimeRotatingRead(d *schema.ResourceData, meta interface{}) error {
+	t, err := time.Parse(time.RFC3339, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing time_rotating ID %q: %s", d.Id(), err)
+	}
+
+	rotation, err := time.Parse(time.RFC3339, d.Get("rotation_rfc3339").(string))
+	if err != nil {
+		return fmt.Errorf("Error parsing time_rotating rotation_rfc3339: %s", err)
+	}
+
+	// Once the rotation time has passed, the resource is removed from the
+	// state so that it's created again with a new timestamp, which in turn
+	// replaces the resources that depend on it.
+	if !time.Now().Before(rotation) {
+		log.Printf("[INFO] time_rotating %s passed its rotation time %s, removing from state",
+			d.Id(), rotation.Format(time.RFC3339))
+		d.SetId("")
+		return nil
+	}
+
+	setTimeAttributes(d, t)
+	return nil
+}
+
+func resourceTimeRotatingDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func validatePositiveInt(v interface{}, k string) (ws []string, es []error) {
+	if v.(int) < 1 {
+		es = append(es, fmt.Errorf("%s: must be at least 1", k))
+	}
+	return
+}
@@ -0,0 +1,82 @@
+package time
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestTimeRotating_basic(t *testing.T) {
+	base := time.Now().UTC().Truncate(time.Second)
+	rotation := base.AddDate(0, 1, 2).Add(3*time.Hour + 4*time.Minute)
+
+	r.UnitTest(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "time_rotating" "test" {
+	rfc3339          = "` + base.Format(time.RFC3339) + `"
+	rotation_months  = 1
+	rotation_days    = 2
+	rotation_hours   = 3
+	rotation_minutes = 4
+}
+`,
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr(
+						"time_rotating.test", "id", base.Format(time.RFC3339)),
+					r.TestCheckResourceAttr(
+						"time_rotating.test", "rotation_rfc3339", rotation.Format(time.RFC3339)),
+				),
+			},
+		},
+	})
+}
+
+func TestTimeRotatingCreate_noRotation(t *testing.T) {
+	d := resourceTimeRotating().Data(nil)
+
+	err := resourceTimeRotatingCreate(d, nil)
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !strings.Contains(err.Error(), "must be set") {
+		t.Fatalf("bad error: %s", err)
+	}
+}
+
+func TestTimeRotatingRead_expired(t *testing.T) {
+	cases := map[string]struct {
+		Rotation time.Time
+		Expired  bool
+	}{
+		"Expired": {
+			Rotation: time.Now().Add(-time.Minute),
+			Expired:  true,
+		},
+		"NotExpired": {
+			Rotation: time.Now().Add(time.Hour),
+			Expired:  false,
+		},
+	}
+
+	for k, tc := range cases {
+		d := resourceTimeRotating().Data(&terraform.InstanceState{
+			ID: "2016-09-01T10:00:00Z",
+			Attributes: map[string]string{
+				"rotation_rfc3339": tc.Rotation.UTC().Format(time.RFC3339),
+			},
+		})
+
+		if err := resourceTimeRotatingRead(d, nil); err != nil {
+			t.Fatalf("%s: err: %s", k, err)
+		}
+		if expired := d.Id() == ""; expired != tc.Expired {
+			t.Fatalf("%s: expected expired to be %t", k, tc.Expired)
+		}
+	}
+}
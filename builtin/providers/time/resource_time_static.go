@@ -0,0 +1,47 @@
+package time
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceTimeStatic() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTimeStaticCreate,
+		Read:   resourceTimeStaticRead,
+		Delete: resourceTimeStaticDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: timeSchema(),
+	}
+}
+
+func resourceTimeStaticCreate(d *schema.ResourceData, meta interface{}) error {
+	t, err := timestamp(d)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(t.Format(time.RFC3339))
+
+	return resourceTimeStaticRead(d, meta)
+}
+
+func resourceTimeStaticRead(d *schema.ResourceData, meta interface{}) error {
+	t, err := time.Parse(time.RFC3339, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing time_static ID %q: %s", d.Id(), err)
+	}
+
+	setTimeAttributes(d, t)
+	return nil
+}
+
+func resourceTimeStaticDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
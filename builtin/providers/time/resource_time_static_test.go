@@ -0,0 +1,61 @@
+package time
+
+import (
+	"regexp"
+	"testing"
+
+	r "github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestTimeStatic_basic(t *testing.T) {
+	r.UnitTest(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "time_static" "test" {
+	rfc3339 = "2016-09-01T10:30:15Z"
+}
+`,
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("time_static.test", "id", "2016-09-01T10:30:15Z"),
+					r.TestCheckResourceAttr("time_static.test", "year", "2016"),
+					r.TestCheckResourceAttr("time_static.test", "month", "9"),
+					r.TestCheckResourceAttr("time_static.test", "day", "1"),
+					r.TestCheckResourceAttr("time_static.test", "hour", "10"),
+					r.TestCheckResourceAttr("time_static.test", "minute", "30"),
+					r.TestCheckResourceAttr("time_static.test", "second", "15"),
+					r.TestCheckResourceAttr("time_static.test", "unix", "1472725815"),
+				),
+			},
+
+			r.TestStep{
+				ResourceName:      "time_static.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestTimeStatic_now(t *testing.T) {
+	r.UnitTest(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+resource "time_static" "test" {
+	triggers {
+		auth_key = "secret"
+	}
+}
+`,
+				Check: r.ComposeTestCheckFunc(
+					r.TestMatchResourceAttr("time_static.test", "rfc3339",
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)),
+					r.TestCheckResourceAttr("time_static.test", "triggers.auth_key", "secret"),
+				),
+			},
+		},
+	})
+}
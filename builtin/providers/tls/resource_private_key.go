@@ -3,11 +3,13 @@ package tls
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/md5"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/ssh"
 
@@ -79,8 +81,9 @@ func resourcePrivateKey() *schema.Resource {
 			},
 
 			"private_key_pem": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 
 			"public_key_pem": &schema.Schema{
@@ -92,6 +95,11 @@ func resourcePrivateKey() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"public_key_fingerprint_md5": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -150,8 +158,10 @@ func CreatePrivateKey(d *schema.ResourceData, meta interface{}) error {
 		// if an appropriate type was selected.
 		sshPubKeyBytes := ssh.MarshalAuthorizedKey(sshPubKey)
 		d.Set("public_key_openssh", string(sshPubKeyBytes))
+		d.Set("public_key_fingerprint_md5", sshFingerprintMD5(sshPubKey))
 	} else {
 		d.Set("public_key_openssh", "")
+		d.Set("public_key_fingerprint_md5", "")
 	}
 
 	return nil
@@ -176,3 +186,15 @@ func publicKey(priv interface{}) interface{} {
 		return nil
 	}
 }
+
+// sshFingerprintMD5 returns the MD5 fingerprint of an SSH public key in
+// the colon-separated hex format printed by `ssh-keygen -l -E md5`.
+func sshFingerprintMD5(key ssh.PublicKey) string {
+	sum := md5.Sum(key.Marshal())
+
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":")
+}
@@ -7,6 +7,7 @@ import (
 
 	r "github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestPrivateKeyRSA(t *testing.T) {
@@ -190,3 +191,17 @@ func TestPrivateKeyECDSA(t *testing.T) {
 		},
 	})
 }
+
+func TestSSHFingerprintMD5(t *testing.T) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(testSSHPublicKey))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := "d2:ca:14:77:a6:bd:f3:39:96:2f:40:26:8e:4e:fc:65"
+	if actual := sshFingerprintMD5(key); actual != expected {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+}
+
+const testSSHPublicKey = `ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQDBCjuUQvQRQDeBN0DIs6LmDfKVwQtTOJm2/soEJ5PmuwI3ShRrEiRDja/CcG/35HZfV1FymAJGl1t+EaK1BcvGhV8zjzbG3xPxbO5eIijSJs2bqudOrhI5aRT9/3HDfSKRv0Tw84HeQ38Eioy665guBmRdhjnbD3LIqCAezo3Dnw==`
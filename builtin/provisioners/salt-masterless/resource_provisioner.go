@@ -0,0 +1,417 @@
+package saltmasterless
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/terraform/communicator"
+	"github.com/hashicorp/terraform/communicator/remote"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/go-homedir"
+	"github.com/mitchellh/go-linereader"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	bootstrapURL            = "https://bootstrap.saltstack.com"
+	bootstrapScript         = "install_salt.sh"
+	defaultLogLevel         = "info"
+	defaultRemotePillarRoot = "/srv/pillar"
+	defaultRemoteStateTree  = "/srv/salt"
+	defaultTempConfigDir    = "/tmp/salt"
+	minionConfDir           = "/etc/salt"
+	minionConfFile          = "minion"
+)
+
+const minionConf = `
+file_client: local
+file_roots:
+  base:
+    - {{ .RemoteStateTree }}
+pillar_roots:
+  base:
+    - {{ .RemotePillarRoots }}
+`
+
+// Provisioner represents a specificly configured salt-masterless provisioner
+type Provisioner struct {
+	BootstrapArgs     string `mapstructure:"bootstrap_args"`
+	DisableSudo       bool   `mapstructure:"disable_sudo"`
+	LocalPillarRoots  string `mapstructure:"local_pillar_roots"`
+	LocalStateTree    string `mapstructure:"local_state_tree"`
+	LogLevel          string `mapstructure:"log_level"`
+	MinionConfig      string `mapstructure:"minion_config_file"`
+	NoExitOnFailure   bool   `mapstructure:"no_exit_on_failure"`
+	RemotePillarRoots string `mapstructure:"remote_pillar_roots"`
+	RemoteStateTree   string `mapstructure:"remote_state_tree"`
+	SkipBootstrap     bool   `mapstructure:"skip_bootstrap"`
+	TempConfigDir     string `mapstructure:"temp_config_dir"`
+
+	useSudo bool
+}
+
+// ResourceProvisioner represents a generic salt-masterless provisioner
+type ResourceProvisioner struct{}
+
+// Apply executes the salt-masterless provisioner
+func (r *ResourceProvisioner) Apply(
+	o terraform.UIOutput,
+	s *terraform.InstanceState,
+	c *terraform.ResourceConfig) error {
+	// Decode the raw config for this provisioner
+	p, err := r.decodeConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Salt is bootstrapped with a shell script, so only ssh is supported
+	switch s.Ephemeral.ConnInfo["type"] {
+	case "ssh", "": // The default connection type is ssh, so if the type is empty assume ssh
+	default:
+		return fmt.Errorf("Unsupported connection type: %s", s.Ephemeral.ConnInfo["type"])
+	}
+
+	p.useSudo = !p.DisableSudo && s.Ephemeral.ConnInfo["user"] != "root"
+
+	// Get a new communicator
+	comm, err := communicator.New(s)
+	if err != nil {
+		return err
+	}
+
+	// Wait and retry until we establish the connection
+	err = retryFunc(comm.Timeout(), func() error {
+		err := comm.Connect(o)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	defer comm.Disconnect()
+
+	if !p.SkipBootstrap {
+		o.Output("Bootstrapping Salt...")
+		if err := p.bootstrapSalt(o, comm); err != nil {
+			return err
+		}
+	}
+
+	o.Output("Uploading minion config and state tree...")
+	if err := p.uploadStateTree(o, comm); err != nil {
+		return err
+	}
+
+	o.Output("Running Salt...")
+	if err := p.runSalt(o, comm); err != nil {
+		return err
+	}
+
+	// Remove the uploaded files again, the states were copied into place
+	return p.runCommand(o, comm, "rm -rf "+p.TempConfigDir, true)
+}
+
+// Validate checks if the required arguments are configured
+func (r *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string, es []error) {
+	p, err := r.decodeConfig(c)
+	if err != nil {
+		es = append(es, err)
+		return ws, es
+	}
+
+	if p.LocalStateTree == "" {
+		es = append(es, fmt.Errorf("Key not found: local_state_tree"))
+	} else if !c.IsComputed("local_state_tree") {
+		if err := validateDir(p.LocalStateTree); err != nil {
+			es = append(es, fmt.Errorf("Bad local_state_tree: %v", err))
+		}
+	}
+
+	if p.LocalPillarRoots != "" && !c.IsComputed("local_pillar_roots") {
+		if err := validateDir(p.LocalPillarRoots); err != nil {
+			es = append(es, fmt.Errorf("Bad local_pillar_roots: %v", err))
+		}
+	}
+
+	if p.MinionConfig != "" && !c.IsComputed("minion_config_file") {
+		if _, err := os.Stat(p.MinionConfig); err != nil {
+			es = append(es, fmt.Errorf("Bad minion_config_file: %v", err))
+		}
+		if _, ok := c.Raw["remote_state_tree"]; ok {
+			es = append(es, fmt.Errorf(
+				"remote_state_tree can't be used with minion_config_file, "+
+					"configure the file_roots in the minion config instead"))
+		}
+		if _, ok := c.Raw["remote_pillar_roots"]; ok {
+			es = append(es, fmt.Errorf(
+				"remote_pillar_roots can't be used with minion_config_file, "+
+					"configure the pillar_roots in the minion config instead"))
+		}
+	}
+
+	return ws, es
+}
+
+func (r *ResourceProvisioner) decodeConfig(c *terraform.ResourceConfig) (*Provisioner, error) {
+	p := new(Provisioner)
+
+	decConf := &mapstructure.DecoderConfig{
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		Result:           p,
+	}
+	dec, err := mapstructure.NewDecoder(decConf)
+	if err != nil {
+		return nil, err
+	}
+
+	// We need to merge both configs into a single map first. Order is
+	// important as we need to make sure interpolated values are used
+	// over raw values.
+	m := make(map[string]interface{})
+
+	for k, v := range c.Raw {
+		m[k] = v
+	}
+
+	for k, v := range c.Config {
+		m[k] = v
+	}
+
+	if err := dec.Decode(m); err != nil {
+		return nil, err
+	}
+
+	if p.LogLevel == "" {
+		p.LogLevel = defaultLogLevel
+	}
+	if p.RemotePillarRoots == "" {
+		p.RemotePillarRoots = defaultRemotePillarRoot
+	}
+	if p.RemoteStateTree == "" {
+		p.RemoteStateTree = defaultRemoteStateTree
+	}
+	if p.TempConfigDir == "" {
+		p.TempConfigDir = defaultTempConfigDir
+	}
+
+	for _, local := range []*string{&p.LocalStateTree, &p.LocalPillarRoots, &p.MinionConfig} {
+		if *local == "" {
+			continue
+		}
+
+		expanded, err := homedir.Expand(*local)
+		if err != nil {
+			return nil, fmt.Errorf("Error expanding the path %s: %v", *local, err)
+		}
+		*local = expanded
+	}
+
+	return p, nil
+}
+
+// validateDir makes sure the given path exists and is a directory
+func validateDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+// retryFunc is used to retry a function for a given duration
+func retryFunc(timeout time.Duration, f func() error) error {
+	finish := time.After(timeout)
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		log.Printf("Retryable error: %v", err)
+
+		select {
+		case <-finish:
+			return err
+		case <-time.After(3 * time.Second):
+		}
+	}
+}
+
+func (p *Provisioner) bootstrapSalt(
+	o terraform.UIOutput,
+	comm communicator.Communicator) error {
+	script := path.Join(p.TempConfigDir, bootstrapScript)
+
+	if err := p.runCommand(o, comm, "mkdir -p "+p.TempConfigDir, false); err != nil {
+		return err
+	}
+
+	// First download the bootstrap script from SaltStack
+	err := p.runCommand(o, comm, fmt.Sprintf("curl -L %s -o %s", bootstrapURL, script), false)
+	if err != nil {
+		return err
+	}
+
+	// Then execute it to install Salt
+	cmd := strings.TrimSpace(fmt.Sprintf("sh %s %s", script, p.BootstrapArgs))
+	if err := p.runCommand(o, comm, cmd, true); err != nil {
+		return err
+	}
+
+	// And finally cleanup the bootstrap script again
+	return p.runCommand(o, comm, "rm -f "+script, false)
+}
+
+func (p *Provisioner) uploadStateTree(
+	o terraform.UIOutput,
+	comm communicator.Communicator) error {
+	if err := p.runCommand(o, comm, "mkdir -p "+p.TempConfigDir, false); err != nil {
+		return err
+	}
+
+	// Upload the minion config, either the given one or one that points
+	// Salt at the remote state tree and pillar roots
+	var conf io.Reader
+	if p.MinionConfig != "" {
+		f, err := os.Open(p.MinionConfig)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		conf = f
+	} else {
+		t := template.Must(template.New(minionConfFile).Parse(minionConf))
+
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, p); err != nil {
+			return fmt.Errorf("Error executing %s template: %s", minionConfFile, err)
+		}
+		conf = &buf
+	}
+
+	tmpConf := path.Join(p.TempConfigDir, minionConfFile)
+	if err := comm.Upload(tmpConf, conf); err != nil {
+		return fmt.Errorf("Uploading %s failed: %v", minionConfFile, err)
+	}
+	if err := p.runCommand(o, comm, "mkdir -p "+minionConfDir, true); err != nil {
+		return err
+	}
+	err := p.runCommand(o, comm, fmt.Sprintf(
+		"mv %s %s", tmpConf, path.Join(minionConfDir, minionConfFile)), true)
+	if err != nil {
+		return err
+	}
+
+	if err := p.uploadDir(o, comm, p.LocalStateTree, "states", p.RemoteStateTree); err != nil {
+		return err
+	}
+
+	if p.LocalPillarRoots != "" {
+		if err := p.uploadDir(o, comm, p.LocalPillarRoots, "pillar", p.RemotePillarRoots); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uploadDir uploads the contents of a local directory to the temporary
+// directory first, and then moves them into place as the remote directory
+// is usually only writable by root.
+func (p *Provisioner) uploadDir(
+	o terraform.UIOutput,
+	comm communicator.Communicator,
+	src string,
+	name string,
+	dst string) error {
+	tmpDir := path.Join(p.TempConfigDir, name)
+	if err := p.runCommand(o, comm, "mkdir -p "+tmpDir, false); err != nil {
+		return err
+	}
+
+	// The trailing slash makes sure the contents of the directory are
+	// uploaded, and not the directory itself
+	src = strings.TrimSuffix(src, "/") + "/"
+	if err := comm.UploadDir(tmpDir, src); err != nil {
+		return fmt.Errorf("Uploading %s failed: %v", src, err)
+	}
+
+	if err := p.runCommand(o, comm, "rm -rf "+dst, true); err != nil {
+		return err
+	}
+	return p.runCommand(o, comm, fmt.Sprintf("mv %s %s", tmpDir, dst), true)
+}
+
+func (p *Provisioner) runSalt(
+	o terraform.UIOutput,
+	comm communicator.Communicator) error {
+	cmd := "salt-call --local state.apply"
+	if !p.NoExitOnFailure {
+		cmd += " --retcode-passthrough"
+	}
+	cmd += " -l " + p.LogLevel
+
+	return p.runCommand(o, comm, cmd, true)
+}
+
+// runCommand is used to run already prepared commands, prefixed with
+// sudo if needed and not prevented
+func (p *Provisioner) runCommand(
+	o terraform.UIOutput,
+	comm communicator.Communicator,
+	command string,
+	sudo bool) error {
+	var err error
+
+	if sudo && p.useSudo {
+		command = "sudo " + command
+	}
+
+	outR, outW := io.Pipe()
+	errR, errW := io.Pipe()
+	outDoneCh := make(chan struct{})
+	errDoneCh := make(chan struct{})
+	go p.copyOutput(o, outR, outDoneCh)
+	go p.copyOutput(o, errR, errDoneCh)
+
+	cmd := &remote.Cmd{
+		Command: command,
+		Stdout:  outW,
+		Stderr:  errW,
+	}
+
+	if err := comm.Start(cmd); err != nil {
+		return fmt.Errorf("Error executing command %q: %v", cmd.Command, err)
+	}
+
+	cmd.Wait()
+	if cmd.ExitStatus != 0 {
+		err = fmt.Errorf(
+			"Command %q exited with non-zero exit status: %d", cmd.Command, cmd.ExitStatus)
+	}
+
+	// Wait for output to clean up
+	outW.Close()
+	errW.Close()
+	<-outDoneCh
+	<-errDoneCh
+
+	return err
+}
+
+func (p *Provisioner) copyOutput(o terraform.UIOutput, r io.Reader, doneCh chan<- struct{}) {
+	defer close(doneCh)
+	lr := linereader.New(r)
+	for line := range lr.Ch {
+		o.Output(line)
+	}
+}
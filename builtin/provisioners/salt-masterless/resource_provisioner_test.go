@@ -0,0 +1,301 @@
+package saltmasterless
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/communicator"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceProvisioner_impl(t *testing.T) {
+	var _ terraform.ResourceProvisioner = new(ResourceProvisioner)
+}
+
+func TestResourceProvider_Validate_good(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"local_state_tree":   "test-fixtures/states",
+		"local_pillar_roots": "test-fixtures/pillar",
+	})
+	r := new(ResourceProvisioner)
+	warn, errs := r.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("Errors: %v", errs)
+	}
+}
+
+func TestResourceProvider_Validate_bad(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"Unknown": {
+			"invalid": "nope",
+		},
+		"NoStateTree": {
+			"local_pillar_roots": "test-fixtures/pillar",
+		},
+		"MissingStateTree": {
+			"local_state_tree": "test-fixtures/missing",
+		},
+		"StateTreeNotDir": {
+			"local_state_tree": "test-fixtures/minion",
+		},
+		"MinionConfigAndRemoteStateTree": {
+			"local_state_tree":   "test-fixtures/states",
+			"minion_config_file": "test-fixtures/minion",
+			"remote_state_tree":  "/srv/custom",
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	for k, raw := range cases {
+		warn, errs := r.Validate(testConfig(t, raw))
+		if len(warn) > 0 {
+			t.Fatalf("%s: Warnings: %v", k, warn)
+		}
+		if len(errs) == 0 {
+			t.Fatalf("%s: Should have errors", k)
+		}
+	}
+}
+
+func TestResourceProvider_bootstrapSalt(t *testing.T) {
+	cases := map[string]struct {
+		Config   *terraform.ResourceConfig
+		Commands map[string]bool
+	}{
+		"Sudo": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "test-fixtures/states",
+			}),
+
+			Commands: map[string]bool{
+				"mkdir -p /tmp/salt": true,
+				"curl -L https://bootstrap.saltstack.com -o /tmp/salt/install_salt.sh": true,
+				"sudo sh /tmp/salt/install_salt.sh":                                    true,
+				"rm -f /tmp/salt/install_salt.sh":                                      true,
+			},
+		},
+
+		"BootstrapArgs": {
+			Config: testConfig(t, map[string]interface{}{
+				"bootstrap_args":   "-P git v2016.3.1",
+				"disable_sudo":     true,
+				"local_state_tree": "test-fixtures/states",
+			}),
+
+			Commands: map[string]bool{
+				"mkdir -p /tmp/salt": true,
+				"curl -L https://bootstrap.saltstack.com -o /tmp/salt/install_salt.sh": true,
+				"sh /tmp/salt/install_salt.sh -P git v2016.3.1":                        true,
+				"rm -f /tmp/salt/install_salt.sh":                                      true,
+			},
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	o := new(terraform.MockUIOutput)
+	c := new(communicator.MockCommunicator)
+
+	for k, tc := range cases {
+		c.Commands = tc.Commands
+
+		p, err := r.decodeConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		p.useSudo = !p.DisableSudo
+
+		err = p.bootstrapSalt(o, c)
+		if err != nil {
+			t.Fatalf("Test %q failed: %v", k, err)
+		}
+	}
+}
+
+func TestResourceProvider_uploadStateTree(t *testing.T) {
+	cases := map[string]struct {
+		Config     *terraform.ResourceConfig
+		Commands   map[string]bool
+		Uploads    map[string]string
+		UploadDirs map[string]string
+	}{
+		"Default": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "test-fixtures/states",
+			}),
+
+			Commands: map[string]bool{
+				"mkdir -p /tmp/salt":                        true,
+				"sudo mkdir -p /etc/salt":                   true,
+				"sudo mv /tmp/salt/minion /etc/salt/minion": true,
+				"mkdir -p /tmp/salt/states":                 true,
+				"sudo rm -rf /srv/salt":                     true,
+				"sudo mv /tmp/salt/states /srv/salt":        true,
+			},
+
+			Uploads: map[string]string{
+				"/tmp/salt/minion": defaultMinionConf,
+			},
+
+			UploadDirs: map[string]string{
+				"test-fixtures/states/": "/tmp/salt/states",
+			},
+		},
+
+		"PillarRoots": {
+			Config: testConfig(t, map[string]interface{}{
+				"disable_sudo":        true,
+				"local_pillar_roots":  "test-fixtures/pillar/",
+				"local_state_tree":    "test-fixtures/states",
+				"remote_pillar_roots": "/srv/custom-pillar",
+				"remote_state_tree":   "/srv/custom-salt",
+				"temp_config_dir":     "/tmp/custom",
+			}),
+
+			Commands: map[string]bool{
+				"mkdir -p /tmp/custom":                     true,
+				"mkdir -p /etc/salt":                       true,
+				"mv /tmp/custom/minion /etc/salt/minion":   true,
+				"mkdir -p /tmp/custom/states":              true,
+				"rm -rf /srv/custom-salt":                  true,
+				"mv /tmp/custom/states /srv/custom-salt":   true,
+				"mkdir -p /tmp/custom/pillar":              true,
+				"rm -rf /srv/custom-pillar":                true,
+				"mv /tmp/custom/pillar /srv/custom-pillar": true,
+			},
+
+			Uploads: map[string]string{
+				"/tmp/custom/minion": customMinionConf,
+			},
+
+			UploadDirs: map[string]string{
+				"test-fixtures/states/": "/tmp/custom/states",
+				"test-fixtures/pillar/": "/tmp/custom/pillar",
+			},
+		},
+
+		"MinionConfig": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree":   "test-fixtures/states",
+				"minion_config_file": "test-fixtures/minion",
+			}),
+
+			Commands: map[string]bool{
+				"mkdir -p /tmp/salt":                        true,
+				"sudo mkdir -p /etc/salt":                   true,
+				"sudo mv /tmp/salt/minion /etc/salt/minion": true,
+				"mkdir -p /tmp/salt/states":                 true,
+				"sudo rm -rf /srv/salt":                     true,
+				"sudo mv /tmp/salt/states /srv/salt":        true,
+			},
+
+			Uploads: map[string]string{
+				"/tmp/salt/minion": "file_client: local\nfile_roots:\n  base:\n    - /srv/custom",
+			},
+
+			UploadDirs: map[string]string{
+				"test-fixtures/states/": "/tmp/salt/states",
+			},
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	o := new(terraform.MockUIOutput)
+	c := new(communicator.MockCommunicator)
+
+	for k, tc := range cases {
+		c.Commands = tc.Commands
+		c.Uploads = tc.Uploads
+		c.UploadDirs = tc.UploadDirs
+
+		p, err := r.decodeConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		p.useSudo = !p.DisableSudo
+
+		err = p.uploadStateTree(o, c)
+		if err != nil {
+			t.Fatalf("Test %q failed: %v", k, err)
+		}
+	}
+}
+
+func TestResourceProvider_runSalt(t *testing.T) {
+	cases := map[string]struct {
+		Config   *terraform.ResourceConfig
+		Commands map[string]bool
+	}{
+		"Sudo": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "test-fixtures/states",
+			}),
+
+			Commands: map[string]bool{
+				"sudo salt-call --local state.apply --retcode-passthrough -l info": true,
+			},
+		},
+
+		"NoExitOnFailure": {
+			Config: testConfig(t, map[string]interface{}{
+				"disable_sudo":       true,
+				"local_state_tree":   "test-fixtures/states",
+				"log_level":          "debug",
+				"no_exit_on_failure": true,
+			}),
+
+			Commands: map[string]bool{
+				"salt-call --local state.apply -l debug": true,
+			},
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	o := new(terraform.MockUIOutput)
+	c := new(communicator.MockCommunicator)
+
+	for k, tc := range cases {
+		c.Commands = tc.Commands
+
+		p, err := r.decodeConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		p.useSudo = !p.DisableSudo
+
+		err = p.runSalt(o, c)
+		if err != nil {
+			t.Fatalf("Test %q failed: %v", k, err)
+		}
+	}
+}
+
+func testConfig(t *testing.T, c map[string]interface{}) *terraform.ResourceConfig {
+	r, err := config.NewRawConfig(c)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	return terraform.NewResourceConfig(r)
+}
+
+const defaultMinionConf = `file_client: local
+file_roots:
+  base:
+    - /srv/salt
+pillar_roots:
+  base:
+    - /srv/pillar`
+
+const customMinionConf = `file_client: local
+file_roots:
+  base:
+    - /srv/custom-salt
+pillar_roots:
+  base:
+    - /srv/custom-pillar`
@@ -27,7 +27,9 @@ type ApplyCommand struct {
 }
 
 func (c *ApplyCommand) Run(args []string) int {
-	var destroyForce, refresh bool
+	var destroyForce, refresh, jsonOutput bool
+	var approvalPatterns []string
+	var approvalWebhook string
 	args = c.Meta.process(args, true)
 
 	cmdName := "apply"
@@ -42,14 +44,27 @@ func (c *ApplyCommand) Run(args []string) int {
 	cmdFlags.BoolVar(&refresh, "refresh", true, "refresh")
 	cmdFlags.IntVar(
 		&c.Meta.parallelism, "parallelism", DefaultParallelism, "parallelism")
+	c.Meta.refreshFlags(cmdFlags)
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.Var((*FlagStringSlice)(&approvalPatterns), "require-approval", "pattern")
+	cmdFlags.StringVar(&approvalWebhook, "approval-webhook", "", "url")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
 	}
 
+	if jsonOutput {
+		if c.Destroy && !destroyForce {
+			c.Ui.Error("The -json flag requires -force, since the destroy can't be confirmed.")
+			return 1
+		}
+
+		c.Meta.streamJSON()
+	}
+
 	pwd, err := os.Getwd()
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
@@ -70,10 +85,23 @@ func (c *ApplyCommand) Run(args []string) int {
 		maybeInit = false
 	}
 
-	// Prepare the extra hooks to count resources
+	// Prepare the extra hooks to count and time resources
 	countHook := new(CountHook)
+	durationHook := new(DurationHook)
 	stateHook := new(StateHook)
-	c.Meta.extraHooks = []terraform.Hook{countHook, stateHook}
+	c.Meta.extraHooks = []terraform.Hook{countHook, durationHook, stateHook}
+
+	// The approvals are asked before the other hooks see the changes, so
+	// that the time waiting for them isn't counted as applying.
+	if len(approvalPatterns) > 0 {
+		approvalHook := &ApprovalHook{Patterns: approvalPatterns}
+		if approvalWebhook != "" {
+			approvalHook.Approver = &WebhookApprover{URL: approvalWebhook}
+		} else if c.Meta.input {
+			approvalHook.Approver = &UIApprover{Input: c.UIInput()}
+		}
+		c.Meta.extraHooks = append([]terraform.Hook{approvalHook}, c.Meta.extraHooks...)
+	}
 
 	if !c.Destroy && maybeInit {
 		// Do a detect to determine if we need to do an init + apply.
@@ -96,11 +124,13 @@ func (c *ApplyCommand) Run(args []string) int {
 	}
 
 	// Build the context based on the arguments given
+	defer c.Meta.unlockState()
 	ctx, planned, err := c.Context(contextOpts{
-		Destroy:     c.Destroy,
-		Path:        configPath,
-		StatePath:   c.Meta.statePath,
-		Parallelism: c.Meta.parallelism,
+		Destroy:       c.Destroy,
+		Path:          configPath,
+		StatePath:     c.Meta.statePath,
+		Parallelism:   c.Meta.parallelism,
+		LockOperation: cmdName,
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
@@ -232,6 +262,27 @@ func (c *ApplyCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.Meta.jsonUi != nil {
+		c.Meta.jsonUi.Event(&jsonEvent{
+			Type: jsonEventChangeSummary,
+			Changes: &jsonChanges{
+				Add:    countHook.Added,
+				Change: countHook.Changed,
+				Remove: countHook.Removed,
+			},
+		})
+		if !c.Destroy {
+			if outputs := jsonOutputs(state); len(outputs) > 0 {
+				c.Meta.jsonUi.Event(&jsonEvent{
+					Type:    jsonEventOutputs,
+					Outputs: outputs,
+				})
+			}
+		}
+
+		return 0
+	}
+
 	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
 		"[reset][bold][green]\n"+
 			"Apply complete! Resources: %d added, %d changed, %d destroyed.",
@@ -239,6 +290,11 @@ func (c *ApplyCommand) Run(args []string) int {
 		countHook.Changed,
 		countHook.Removed)))
 
+	if summary := durationHook.Summary(); summary != "" {
+		c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+			"[reset]\nResource durations:\n\n%s", summary)))
+	}
+
 	if countHook.Added > 0 || countHook.Changed > 0 {
 		c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
 			"[reset]\n"+
@@ -295,12 +351,27 @@ Usage: terraform apply [options] [DIR-OR-PLAN]
 
 Options:
 
+  -approval-webhook=url  URL that the approvals required by -require-approval
+                         are asked from, instead of asking interactively.
+                         It receives the change as JSON in a POST request,
+                         and approves it with a 2xx response or rejects it
+                         with a 403 response.
+
   -backup=path           Path to backup the existing state file before
                          modifying. Defaults to the "-state-out" path with
                          ".backup" extension. Set to "-" to disable backup.
 
   -input=true            Ask for input for variables if not directly set.
 
+  -json                  Write the output as newline-delimited JSON events,
+                         such as the start, progress and completion of the
+                         changes to each resource, instead of text. This
+                         disables input.
+
+  -lock=true             Lock the state file when locking is supported.
+
+  -lock-timeout=0s       Duration to retry a state lock.
+
   -no-color              If specified, output won't contain any color.
 
   -parallelism=n         Limit the number of concurrent operations.
@@ -309,6 +380,20 @@ Options:
   -refresh=true          Update state prior to checking for differences. This
                          has no effect if a plan file is given to apply.
 
+  -refresh-parallelism=n Limit the number of concurrent operations while
+                         refreshing. Defaults to the -parallelism value.
+
+  -refresh-rate-limit=provider=n
+                         Refresh at most n resources of the provider per
+                         second, e.g. "aws=20". This flag can be set
+                         multiple times.
+
+  -require-approval=pattern
+                         Ask for an approval before changing a resource
+                         whose type or address matches the pattern, such as
+                         "aws_directconnect_*". Changes that aren't approved
+                         fail. This flag can be set multiple times.
+
   -state=path            Path to read and save state (unless state-out
                          is specified). Defaults to "terraform.tfstate".
 
@@ -340,12 +425,27 @@ Usage: terraform destroy [options] [DIR]
 
 Options:
 
+  -approval-webhook=url  URL that the approvals required by -require-approval
+                         are asked from, instead of asking interactively.
+                         It receives the change as JSON in a POST request,
+                         and approves it with a 2xx response or rejects it
+                         with a 403 response.
+
   -backup=path           Path to backup the existing state file before
                          modifying. Defaults to the "-state-out" path with
                          ".backup" extension. Set to "-" to disable backup.
 
   -force                 Don't ask for input for destroy confirmation.
 
+  -json                  Write the output as newline-delimited JSON events,
+                         such as the start, progress and completion of the
+                         destruction of each resource, instead of text.
+                         Requires -force.
+
+  -lock=true             Lock the state file when locking is supported.
+
+  -lock-timeout=0s       Duration to retry a state lock.
+
   -no-color              If specified, output won't contain any color.
 
   -parallelism=n         Limit the number of concurrent operations.
@@ -354,6 +454,20 @@ Options:
   -refresh=true          Update state prior to checking for differences. This
                          has no effect if a plan file is given to apply.
 
+  -refresh-parallelism=n Limit the number of concurrent operations while
+                         refreshing. Defaults to the -parallelism value.
+
+  -refresh-rate-limit=provider=n
+                         Refresh at most n resources of the provider per
+                         second, e.g. "aws=20". This flag can be set
+                         multiple times.
+
+  -require-approval=pattern
+                         Ask for an approval before changing a resource
+                         whose type or address matches the pattern, such as
+                         "aws_directconnect_*". Changes that aren't approved
+                         fail. This flag can be set multiple times.
+
   -state=path            Path to read and save state (unless state-out
                          is specified). Defaults to "terraform.tfstate".
 
@@ -382,7 +496,13 @@ func outputsAsString(state *terraform.State, schema []*config.Output, includeHea
 		return ""
 	}
 
-	outputs := state.RootModule().Outputs
+	return outputValuesAsString(state.RootModule().Outputs, schema, includeHeader, false)
+}
+
+// outputValuesAsString formats the given outputs for the UI. The values of
+// outputs that are sensitive, either in the given configuration or in the
+// state, are masked unless showSensitive is true.
+func outputValuesAsString(outputs map[string]*terraform.OutputState, schema []*config.Output, includeHeader, showSensitive bool) string {
 	outputBuf := new(bytes.Buffer)
 	if len(outputs) > 0 {
 		schemaMap := make(map[string]*config.Output)
@@ -408,13 +528,13 @@ func outputsAsString(state *terraform.State, schema []*config.Output, includeHea
 		sort.Strings(ks)
 
 		for _, k := range ks {
+			v := outputs[k]
 			schema, ok := schemaMap[k]
-			if ok && schema.Sensitive {
+			if !showSensitive && (v.Sensitive || ok && schema.Sensitive) {
 				outputBuf.WriteString(fmt.Sprintf("%s = <sensitive>\n", k))
 				continue
 			}
 
-			v := outputs[k]
 			switch typedV := v.Value.(type) {
 			case string:
 				outputBuf.WriteString(fmt.Sprintf("%s = %s\n", k, typedV))
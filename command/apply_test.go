@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -59,6 +60,181 @@ func TestApply(t *testing.T) {
 	}
 }
 
+func TestApply_requireApproval(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	p.DiffReturn = &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"ami": &terraform.ResourceAttrDiff{
+				New: "bar",
+			},
+		},
+	}
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-require-approval", "test_*",
+		"-approval-webhook", ts.URL,
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	if requests != 1 {
+		t.Fatalf("bad: %d", requests)
+	}
+	if p.ApplyCalled {
+		t.Fatal("apply should not be called")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "not approved") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestApply_json(t *testing.T) {
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	p.DiffReturn = &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"ami": &terraform.ResourceAttrDiff{
+				New: "bar",
+			},
+		},
+	}
+	p.ApplyReturn = &terraform.InstanceState{ID: "foo"}
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-json",
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if ui.ErrorWriter != nil && ui.ErrorWriter.Len() > 0 {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	expected := []string{
+		jsonEventApplyStart,
+		jsonEventApplyComplete,
+		jsonEventChangeSummary,
+	}
+	if !reflect.DeepEqual(types, expected) {
+		t.Fatalf("bad: %#v", types)
+	}
+
+	complete := events[1]
+	if complete.Address != "test_instance.foo" || complete.Action != planJSONActionCreate || complete.ID != "foo" {
+		t.Fatalf("bad: %#v", complete)
+	}
+	if events[2].Changes == nil || events[2].Changes.Add != 1 {
+		t.Fatalf("bad: %#v", events[2])
+	}
+}
+
+func TestApply_jsonError(t *testing.T) {
+	statePath := testTempFile(t)
+
+	p := testProvider()
+	p.DiffReturn = &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"ami": &terraform.ResourceAttrDiff{
+				New: "bar",
+			},
+		},
+	}
+	p.ApplyReturnError = fmt.Errorf("quota exceeded")
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-json",
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	var errored, diagnostic *jsonEvent
+	for _, e := range events {
+		switch e.Type {
+		case jsonEventApplyErrored:
+			errored = e
+		case jsonEventDiagnostic:
+			diagnostic = e
+		}
+	}
+	if errored == nil || !strings.Contains(errored.Diagnostic.Detail, "quota exceeded") {
+		t.Fatalf("bad: %#v", errored)
+	}
+	if diagnostic == nil || diagnostic.Diagnostic.Summary != "Error applying plan" {
+		t.Fatalf("bad: %#v", diagnostic)
+	}
+	if diagnostic.Diagnostic.Severity != jsonSeverityError {
+		t.Fatalf("bad: %#v", diagnostic.Diagnostic)
+	}
+}
+
+func TestApply_jsonDestroyNoForce(t *testing.T) {
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Destroy: true,
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-json",
+		testFixturePath("apply"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-force") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+}
+
 func TestApply_parallelism(t *testing.T) {
 	provider := testProvider()
 	statePath := testTempFile(t)
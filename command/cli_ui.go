@@ -49,3 +49,30 @@ func (u *ColorizeUi) colorize(message string, color string) string {
 
 	return u.Colorize.Color(fmt.Sprintf("%s%s[reset]", color, message))
 }
+
+// QuietUi is a Ui implementation that only shows errors and warnings. It is
+// used by commands whose output must be machine-readable, so that progress
+// messages don't end up mixed in with it.
+type QuietUi struct {
+	Ui cli.Ui
+}
+
+func (u *QuietUi) Ask(query string) (string, error) {
+	return u.Ui.Ask(query)
+}
+
+func (u *QuietUi) AskSecret(query string) (string, error) {
+	return u.Ui.AskSecret(query)
+}
+
+func (u *QuietUi) Output(message string) {}
+
+func (u *QuietUi) Info(message string) {}
+
+func (u *QuietUi) Error(message string) {
+	u.Ui.Error(message)
+}
+
+func (u *QuietUi) Warn(message string) {
+	u.Ui.Warn(message)
+}
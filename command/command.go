@@ -22,10 +22,26 @@ const DefaultVarsFilename = "terraform.tfvars"
 // DefaultBackupExtension is added to the state file to form the path
 const DefaultBackupExtension = ".backup"
 
+// DefaultStateHistoryExtension is added to the state file to form the path
+// of the directory holding the history of the state.
+const DefaultStateHistoryExtension = ".history"
+
+// DefaultStateHistory is the number of serials of the state that are kept
+// in its history by default.
+const DefaultStateHistory = 10
+
 // DefaultDataDirectory is the directory where local state is stored
 // by default.
 const DefaultDataDirectory = ".terraform"
 
+// DefaultWorkspace is the name of the workspace whose state is stored at
+// the default paths.
+const DefaultWorkspace = "default"
+
+// WorkspaceEnvVar is the environment variable that, if set, overrides the
+// workspace selected with "terraform workspace select".
+const WorkspaceEnvVar = "TF_WORKSPACE"
+
 // DefaultParallelism is the limit Terraform places on total parallel
 // operations as it walks the dependency graph.
 const DefaultParallelism = 10
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform/repl"
+)
+
+// ConsoleCommand is a Command implementation that starts an interactive
+// console for evaluating interpolations against the state.
+type ConsoleCommand struct {
+	Meta
+
+	// input is the reader the expressions are read from, STDIN if nil.
+	// The prompt is only shown when reading from STDIN.
+	input io.Reader
+}
+
+func (c *ConsoleCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	cmdFlags := c.Meta.flagSet("console")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	var path string
+	args = cmdFlags.Args()
+	if len(args) > 1 {
+		c.Ui.Error("The console command expects at most one argument.")
+		cmdFlags.Usage()
+		return 1
+	} else if len(args) == 1 {
+		path = args[0]
+	} else {
+		var err error
+		path, err = os.Getwd()
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+		}
+	}
+
+	ctx, _, err := c.Context(contextOpts{
+		Path:      path,
+		StatePath: c.Meta.statePath,
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading Terraform: %s", err))
+		return 1
+	}
+
+	session := &repl.Session{
+		Interpolater: ctx.Interpolater(),
+	}
+
+	input := c.input
+	prompt := false
+	if input == nil {
+		input = os.Stdin
+		prompt = true
+	}
+
+	scanner := bufio.NewScanner(input)
+	for {
+		if prompt {
+			fmt.Fprint(os.Stdout, "> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+
+		out, err := session.Handle(scanner.Text())
+		if err == repl.ErrSessionExit {
+			return 0
+		}
+		if err != nil {
+			c.Ui.Error(err.Error())
+			continue
+		}
+
+		c.Ui.Output(out)
+	}
+	if prompt {
+		fmt.Fprintln(os.Stdout)
+	}
+
+	if err := scanner.Err(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading input: %s", err))
+		return 1
+	}
+
+	return 0
+}
+
+func (c *ConsoleCommand) Help() string {
+	helpText := `
+Usage: terraform console [options] [DIR]
+
+  Starts an interactive console for experimenting with Terraform
+  interpolations.
+
+  This will open an interactive console that you can use to type
+  interpolations into and inspect their values. This command loads the
+  current state. This lets you explore and test interpolations before
+  using them in future configurations.
+
+  This command will never modify your state.
+
+  DIR can be set to a directory with a Terraform configuration whose
+  variables should be available. It defaults to the current directory.
+
+Options:
+
+  -state=path       Path to read state. Defaults to "terraform.tfstate"
+
+  -var 'foo=bar'    Set a variable in the Terraform configuration. This
+                    flag can be set multiple times.
+
+  -var-file=foo     Set variables in the Terraform configuration from
+                    a file. If "terraform.tfvars" is present, it will be
+                    automatically loaded if this flag is not specified.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ConsoleCommand) Synopsis() string {
+	return "Interactive console for Terraform interpolations"
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestConsole(t *testing.T) {
+	state := testState()
+	state.RootModule().Resources["test_instance.foo"].Primary.Attributes = map[string]string{
+		"id": "bar",
+	}
+	statePath := testStateFile(t, state)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ConsoleCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+		input: strings.NewReader(
+			"var.foo\ntest_instance.foo.id\nvar.list\nexit\nvar.foo\n"),
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-var", "foo=baz",
+		testFixturePath("console"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	actual := strings.TrimSpace(ui.OutputWriter.String())
+	expected := "baz\nbar\n[\n  a,\n  b\n]"
+	if actual != expected {
+		t.Fatalf("bad:\n%s\n\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestConsole_error(t *testing.T) {
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &ConsoleCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+		input: strings.NewReader("var.nope\nvar.foo\n"),
+	}
+
+	args := []string{
+		"-state", testTempFile(t),
+		testFixturePath("console"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if actual := strings.TrimSpace(ui.OutputWriter.String()); actual != "bar" {
+		t.Fatalf("bad: %q", actual)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "nope") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+}
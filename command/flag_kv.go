@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"io/ioutil"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/hcl"
@@ -32,6 +33,35 @@ func (v *FlagKV) Set(raw string) error {
 	return nil
 }
 
+// FlagKVFloat is a flag.Value implementation for parsing positive numbers
+// keyed by name from the command-line in the format of '-flag key=number',
+// e.g. '-refresh-rate-limit aws=20'.
+type FlagKVFloat map[string]float64
+
+func (v *FlagKVFloat) String() string {
+	return ""
+}
+
+func (v *FlagKVFloat) Set(raw string) error {
+	idx := strings.Index(raw, "=")
+	if idx == -1 {
+		return fmt.Errorf("No '=' value in arg: %s", raw)
+	}
+
+	key, value := raw[0:idx], raw[idx+1:]
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("Value of %s must be a positive number: %s", key, value)
+	}
+
+	if *v == nil {
+		*v = make(map[string]float64)
+	}
+
+	(*v)[key] = n
+	return nil
+}
+
 // FlagKVFile is a flag.Value implementation for parsing user variables
 // from the command line in the form of files. i.e. '-var-file=foo'
 type FlagKVFile map[string]string
@@ -62,6 +62,61 @@ func TestFlagKV(t *testing.T) {
 	}
 }
 
+func TestFlagKVFloat_impl(t *testing.T) {
+	var _ flag.Value = new(FlagKVFloat)
+}
+
+func TestFlagKVFloat(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Output map[string]float64
+		Error  bool
+	}{
+		{
+			"aws=20",
+			map[string]float64{"aws": 20},
+			false,
+		},
+
+		{
+			"aws=0.5",
+			map[string]float64{"aws": 0.5},
+			false,
+		},
+
+		{
+			"aws=0",
+			nil,
+			true,
+		},
+
+		{
+			"aws=foo",
+			nil,
+			true,
+		},
+
+		{
+			"aws",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		f := new(FlagKVFloat)
+		err := f.Set(tc.Input)
+		if err != nil != tc.Error {
+			t.Fatalf("bad error. Input: %#v", tc.Input)
+		}
+
+		actual := map[string]float64(*f)
+		if !reflect.DeepEqual(actual, tc.Output) {
+			t.Fatalf("bad: %#v", actual)
+		}
+	}
+}
+
 func TestFlagKVFile_impl(t *testing.T) {
 	var _ flag.Value = new(FlagKVFile)
 }
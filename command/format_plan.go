@@ -112,9 +112,16 @@ func formatPlanModuleExpand(
 			symbol = "-"
 		}
 
+		// Tainted resources, including those replaced with -replace, are
+		// replaced even though none of their attributes forces it.
+		taintStr := ""
+		if rdiff.DestroyTainted {
+			taintStr = " (tainted)"
+		}
+
 		buf.WriteString(opts.Color.Color(fmt.Sprintf(
-			"[%s]%s %s\n",
-			color, symbol, name)))
+			"[%s]%s %s%s\n",
+			color, symbol, name, taintStr)))
 
 		// Get all the attributes that are changing, and sort them. Also
 		// determine the longest key so that we can align them all.
@@ -138,10 +145,16 @@ func formatPlanModuleExpand(
 			attrDiff := rdiff.Attributes[attrK]
 
 			v := attrDiff.New
+			u := attrDiff.Old
 			if attrDiff.NewComputed {
 				v = "<computed>"
 			}
 
+			if attrDiff.Sensitive {
+				u = "<sensitive>"
+				v = "<sensitive>"
+			}
+
 			newResource := ""
 			if attrDiff.RequiresNew && rdiff.Destroy {
 				newResource = opts.Color.Color(" [red](forces new resource)")
@@ -152,7 +165,7 @@ func formatPlanModuleExpand(
 					"    %s:%s %#v => %#v%s\n",
 					attrK,
 					strings.Repeat(" ", keyLen-len(attrK)),
-					attrDiff.Old,
+					u,
 					v,
 					newResource))
 			} else {
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/ryanuber/columnize"
+)
+
+// PlanCounts are the numbers of resources that a plan adds, changes and
+// destroys. Replaced resources are both added and destroyed.
+type PlanCounts struct {
+	ToAdd    int
+	ToChange int
+	ToRemove int
+}
+
+// PlanCountsByType returns the counts of the changes of a plan by resource
+// type. The types of data sources are prefixed with "data.".
+func PlanCountsByType(p *terraform.Plan) map[string]*PlanCounts {
+	result := make(map[string]*PlanCounts)
+	if p == nil || p.Diff == nil {
+		return result
+	}
+
+	for _, m := range p.Diff.Modules {
+		for name, rdiff := range m.Resources {
+			key, err := terraform.ParseResourceStateKey(name)
+			if err != nil {
+				continue
+			}
+
+			t := key.Type
+			if key.Mode == config.DataResourceMode {
+				t = "data." + t
+			}
+
+			counts := result[t]
+			if counts == nil {
+				counts = new(PlanCounts)
+			}
+
+			switch rdiff.ChangeType() {
+			case terraform.DiffDestroyCreate:
+				counts.ToAdd++
+				counts.ToRemove++
+			case terraform.DiffCreate:
+				counts.ToAdd++
+			case terraform.DiffDestroy:
+				counts.ToRemove++
+			case terraform.DiffUpdate:
+				counts.ToChange++
+			default:
+				continue
+			}
+
+			result[t] = counts
+		}
+	}
+
+	return result
+}
+
+// FormatPlanCounts returns the counts of the changes of a plan by resource
+// type as aligned lines, sorted by type.
+func FormatPlanCounts(counts map[string]*PlanCounts) string {
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	if len(types) == 0 {
+		return ""
+	}
+
+	lines := make([]string, len(types))
+	for i, t := range types {
+		c := counts[t]
+		lines[i] = fmt.Sprintf(
+			"%s|%d to add, %d to change, %d to destroy",
+			t, c.ToAdd, c.ToChange, c.ToRemove)
+	}
+
+	// Columnize trims leading whitespace, so indent the lines afterwards
+	lines = strings.Split(strings.TrimRight(columnize.SimpleFormat(lines), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "  " + line
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,83 @@
+package command
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestPlanCountsByType(t *testing.T) {
+	actual := PlanCountsByType(testPlanCountsPlan())
+	expected := map[string]*PlanCounts{
+		"aws_instance":      &PlanCounts{ToAdd: 2, ToRemove: 1},
+		"aws_dx_connection": &PlanCounts{ToChange: 1},
+		"data.aws_ami":      &PlanCounts{ToAdd: 1},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %s", FormatPlanCounts(actual))
+	}
+
+	actualStr := FormatPlanCounts(actual)
+	expectedStr := strings.Join([]string{
+		"  aws_dx_connection  0 to add, 1 to change, 0 to destroy",
+		"  aws_instance       2 to add, 0 to change, 1 to destroy",
+		"  data.aws_ami       1 to add, 0 to change, 0 to destroy",
+	}, "\n")
+	if actualStr != expectedStr {
+		t.Fatalf("bad:\n\n%s", actualStr)
+	}
+}
+
+func testPlanCountsPlan() *terraform.Plan {
+	return &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.web": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									New:         "ami-123",
+									RequiresNew: true,
+								},
+							},
+							Destroy: true,
+						},
+						"aws_dx_connection.main": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"bandwidth": &terraform.ResourceAttrDiff{
+									Old: "1Gbps",
+									New: "10Gbps",
+								},
+							},
+						},
+						"data.aws_ami.ubuntu": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"id": &terraform.ResourceAttrDiff{
+									NewComputed: true,
+									RequiresNew: true,
+								},
+							},
+						},
+					},
+				},
+				&terraform.ModuleDiff{
+					Path: []string{"root", "child"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.app": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									New:         "ami-456",
+									RequiresNew: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
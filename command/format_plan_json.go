@@ -0,0 +1,208 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// planJSONFormatVersion is the version of the JSON representation of plans.
+// It must be bumped whenever the representation changes in a way that is
+// not backwards compatible.
+const planJSONFormatVersion = "1"
+
+// The actions that a resource change in the JSON representation of a plan
+// can have.
+const (
+	planJSONActionCreate  = "create"
+	planJSONActionRead    = "read"
+	planJSONActionUpdate  = "update"
+	planJSONActionReplace = "replace"
+	planJSONActionDestroy = "destroy"
+)
+
+// planJSONSensitiveValue replaces the values of sensitive attributes.
+const planJSONSensitiveValue = "<sensitive>"
+
+// planJSON is the machine-readable representation of a plan.
+type planJSON struct {
+	FormatVersion   string                    `json:"format_version"`
+	ResourceChanges []*planJSONResourceChange `json:"resource_changes"`
+}
+
+// planJSONResourceChange is a single resource change of a plan.
+//
+// Before holds the attributes of the resource in the state the plan was
+// built from, and is nil for resources that don't exist yet. After holds
+// the planned attributes, and is nil for resources that will be destroyed.
+// Attributes whose value will only be known after apply are listed in
+// AfterUnknown instead, and the attributes that force the resource to be
+// replaced are listed in RequiresReplace.
+type planJSONResourceChange struct {
+	Address         string            `json:"address"`
+	Module          string            `json:"module,omitempty"`
+	Action          string            `json:"action"`
+	Before          map[string]string `json:"before"`
+	After           map[string]string `json:"after"`
+	AfterUnknown    []string          `json:"after_unknown,omitempty"`
+	RequiresReplace []string          `json:"requires_replace,omitempty"`
+}
+
+// FormatPlanJSON returns the machine-readable JSON representation of the
+// given plan, meant to be consumed by tools that inspect plans.
+func FormatPlanJSON(p *terraform.Plan) (string, error) {
+	result := &planJSON{
+		FormatVersion:   planJSONFormatVersion,
+		ResourceChanges: make([]*planJSONResourceChange, 0),
+	}
+
+	if p.Diff != nil {
+		for _, m := range p.Diff.Modules {
+			var ms *terraform.ModuleState
+			if p.State != nil {
+				ms = p.State.ModuleByPath(m.Path)
+			}
+
+			changes := formatPlanJSONModule(m, ms)
+			result.ResourceChanges = append(result.ResourceChanges, changes...)
+		}
+	}
+
+	sort.Sort(planJSONResourceChangesByAddress(result.ResourceChanges))
+
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("Error encoding plan as JSON: %s", err)
+	}
+
+	return string(out), nil
+}
+
+func formatPlanJSONModule(
+	m *terraform.ModuleDiff, ms *terraform.ModuleState) []*planJSONResourceChange {
+	var moduleName string
+	if !m.IsRoot() {
+		moduleName = fmt.Sprintf("module.%s", strings.Join(m.Path[1:], "."))
+	}
+
+	var result []*planJSONResourceChange
+	for name, rdiff := range m.Resources {
+		var before *terraform.InstanceState
+		if ms != nil {
+			if rs, ok := ms.Resources[name]; ok {
+				before = rs.Primary
+			}
+		}
+
+		if change := formatPlanJSONResourceChange(moduleName, name, rdiff, before); change != nil {
+			result = append(result, change)
+		}
+	}
+
+	return result
+}
+
+// formatPlanJSONResourceChange returns the JSON representation of the
+// change of a single resource, from its state before the change and its
+// diff. It returns nil if the resource doesn't change.
+func formatPlanJSONResourceChange(
+	moduleName, name string,
+	rdiff *terraform.InstanceDiff,
+	before *terraform.InstanceState) *planJSONResourceChange {
+	if rdiff.Empty() {
+		return nil
+	}
+
+	change := &planJSONResourceChange{
+		Address: name,
+		Module:  moduleName,
+	}
+	if moduleName != "" {
+		change.Address = moduleName + "." + name
+	}
+
+	switch rdiff.ChangeType() {
+	case terraform.DiffCreate:
+		change.Action = planJSONActionCreate
+
+		// Data resources are "created" in the diff, but are only read,
+		// like in the human-readable output of the plan.
+		if strings.HasPrefix(name, "data.") {
+			change.Action = planJSONActionRead
+		}
+	case terraform.DiffUpdate:
+		change.Action = planJSONActionUpdate
+	case terraform.DiffDestroyCreate:
+		change.Action = planJSONActionReplace
+	case terraform.DiffDestroy:
+		change.Action = planJSONActionDestroy
+	default:
+		return nil
+	}
+
+	if before != nil {
+		change.Before = make(map[string]string, len(before.Attributes))
+		for k, v := range before.Attributes {
+			change.Before[k] = v
+		}
+	}
+
+	var sensitive []string
+	if change.Action != planJSONActionDestroy {
+		change.After = make(map[string]string)
+
+		// Updates keep the attributes that aren't changing, while
+		// replaced resources are diffed from scratch.
+		if change.Action == planJSONActionUpdate {
+			for k, v := range change.Before {
+				change.After[k] = v
+			}
+		}
+
+		for k, attrDiff := range rdiff.Attributes {
+			if attrDiff.Sensitive {
+				sensitive = append(sensitive, k)
+			}
+
+			switch {
+			case attrDiff.NewRemoved:
+				delete(change.After, k)
+			case attrDiff.NewComputed:
+				delete(change.After, k)
+				change.AfterUnknown = append(change.AfterUnknown, k)
+			default:
+				change.After[k] = attrDiff.New
+			}
+
+			if attrDiff.RequiresNew && change.Action == planJSONActionReplace {
+				change.RequiresReplace = append(change.RequiresReplace, k)
+			}
+		}
+
+		sort.Strings(change.AfterUnknown)
+		sort.Strings(change.RequiresReplace)
+	}
+
+	// Sensitive values are redacted, as in the human-readable output.
+	for _, k := range sensitive {
+		if _, ok := change.Before[k]; ok {
+			change.Before[k] = planJSONSensitiveValue
+		}
+		if _, ok := change.After[k]; ok {
+			change.After[k] = planJSONSensitiveValue
+		}
+	}
+
+	return change
+}
+
+type planJSONResourceChangesByAddress []*planJSONResourceChange
+
+func (s planJSONResourceChangesByAddress) Len() int      { return len(s) }
+func (s planJSONResourceChangesByAddress) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s planJSONResourceChangesByAddress) Less(i, j int) bool {
+	return s[i].Address < s[j].Address
+}
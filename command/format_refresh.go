@@ -0,0 +1,213 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/colorstring"
+)
+
+// FormatRefreshOpts are the options for formatting the changes that a
+// refresh made to the state.
+type FormatRefreshOpts struct {
+	// Before and After are the states before and after the refresh. These
+	// are required.
+	Before *terraform.State
+	After  *terraform.State
+
+	// Color is the colorizer. This is optional.
+	Color *colorstring.Colorize
+}
+
+// FormatRefresh returns the resources whose state was changed by a refresh,
+// along with the attributes that changed, or an empty string if the refresh
+// didn't change anything.
+func FormatRefresh(opts *FormatRefreshOpts) string {
+	if opts.Color == nil {
+		opts.Color = &colorstring.Colorize{
+			Colors: colorstring.DefaultColors,
+			Reset:  false,
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	for _, c := range refreshChanges(opts.Before, opts.After) {
+		// Resources that were deleted outside of Terraform are removed
+		// from the state by the refresh.
+		if c.After == nil {
+			buf.WriteString(opts.Color.Color(fmt.Sprintf(
+				"[red]- %s (no longer exists)[reset]\n", c.Address)))
+			continue
+		}
+
+		keyLen := 0
+		for _, k := range c.Changed {
+			if len(k) > keyLen {
+				keyLen = len(k)
+			}
+		}
+
+		buf.WriteString(opts.Color.Color(fmt.Sprintf(
+			"[yellow]~ %s\n", c.Address)))
+		for _, k := range c.Changed {
+			buf.WriteString(fmt.Sprintf(
+				"    %s:%s %#v => %#v\n",
+				k,
+				strings.Repeat(" ", keyLen-len(k)),
+				c.Before[k],
+				c.After[k]))
+		}
+		buf.WriteString(opts.Color.Color("[reset]\n"))
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+// The actions that a resource drift in the JSON representation of a
+// refresh can have.
+const (
+	refreshJSONActionUpdate = "update"
+	refreshJSONActionDelete = "delete"
+)
+
+// refreshJSON is the machine-readable representation of the changes a
+// refresh made to the state. It shares its format version with plans.
+type refreshJSON struct {
+	FormatVersion string           `json:"format_version"`
+	ResourceDrift []*refreshChange `json:"resource_drift"`
+}
+
+// FormatRefreshJSON returns the machine-readable JSON representation of the
+// changes that a refresh made to the state, meant to be consumed by tools
+// that alert on drift.
+func FormatRefreshJSON(opts *FormatRefreshOpts) (string, error) {
+	result := &refreshJSON{
+		FormatVersion: planJSONFormatVersion,
+		ResourceDrift: refreshChanges(opts.Before, opts.After),
+	}
+
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("Error encoding refresh as JSON: %s", err)
+	}
+
+	return string(out), nil
+}
+
+// refreshChange is a resource whose state was changed by a refresh.
+//
+// Before and After hold the attributes of the resource before and after
+// the refresh, and After is nil for resources that no longer exist.
+// Changed lists the attributes whose value changed.
+type refreshChange struct {
+	Address string            `json:"address"`
+	Module  string            `json:"module,omitempty"`
+	Action  string            `json:"action"`
+	Changed []string          `json:"changed_attributes,omitempty"`
+	Before  map[string]string `json:"before"`
+	After   map[string]string `json:"after"`
+}
+
+// refreshChanges returns the resources whose state was changed by a refresh,
+// by module and then by name.
+func refreshChanges(before, after *terraform.State) []*refreshChange {
+	result := make([]*refreshChange, 0)
+	if before == nil {
+		return result
+	}
+
+	for _, m := range before.Modules {
+		var ms *terraform.ModuleState
+		if after != nil {
+			ms = after.ModuleByPath(m.Path)
+		}
+
+		result = append(result, refreshChangesModule(m, ms)...)
+	}
+
+	return result
+}
+
+func refreshChangesModule(before, after *terraform.ModuleState) []*refreshChange {
+	var moduleName string
+	if !before.IsRoot() {
+		moduleName = fmt.Sprintf("module.%s", strings.Join(before.Path[1:], "."))
+	}
+
+	names := make([]string, 0, len(before.Resources))
+	for name, _ := range before.Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []*refreshChange
+	for _, name := range names {
+		oldPrimary := before.Resources[name].Primary
+		if oldPrimary == nil {
+			continue
+		}
+
+		var newPrimary *terraform.InstanceState
+		if after != nil {
+			if rs, ok := after.Resources[name]; ok {
+				newPrimary = rs.Primary
+			}
+		}
+
+		change := &refreshChange{
+			Address: name,
+			Module:  moduleName,
+			Before:  formatRefreshAttributes(oldPrimary),
+		}
+		if moduleName != "" {
+			change.Address = moduleName + "." + name
+		}
+
+		if newPrimary == nil {
+			change.Action = refreshJSONActionDelete
+			result = append(result, change)
+			continue
+		}
+
+		change.Action = refreshJSONActionUpdate
+		change.After = formatRefreshAttributes(newPrimary)
+
+		keySet := make(map[string]struct{})
+		for k, _ := range change.Before {
+			keySet[k] = struct{}{}
+		}
+		for k, _ := range change.After {
+			keySet[k] = struct{}{}
+		}
+		for k, _ := range keySet {
+			if change.Before[k] != change.After[k] {
+				change.Changed = append(change.Changed, k)
+			}
+		}
+		if len(change.Changed) == 0 {
+			continue
+		}
+		sort.Strings(change.Changed)
+
+		result = append(result, change)
+	}
+
+	return result
+}
+
+// formatRefreshAttributes returns the attributes of the given instance,
+// including its ID, which isn't always stored as an attribute.
+func formatRefreshAttributes(is *terraform.InstanceState) map[string]string {
+	attrs := make(map[string]string, len(is.Attributes)+1)
+	for k, v := range is.Attributes {
+		attrs[k] = v
+	}
+	if _, ok := attrs["id"]; !ok {
+		attrs["id"] = is.ID
+	}
+	return attrs
+}
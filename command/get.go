@@ -75,7 +75,9 @@ Usage: terraform get [options] PATH
 Options:
 
   -update=false       If true, modules already downloaded will be checked
-                      for updates and updated if necessary.
+                      for updates and updated if necessary. Modules from
+                      a registry are updated to the newest version that
+                      matches their version constraint.
 
   -no-color           If specified, output won't contain any color.
 
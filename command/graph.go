@@ -19,6 +19,7 @@ func (c *GraphCommand) Run(args []string) int {
 	var moduleDepth int
 	var verbose bool
 	var drawCycles bool
+	var jsonOutput bool
 
 	args = c.Meta.process(args, false)
 
@@ -26,6 +27,7 @@ func (c *GraphCommand) Run(args []string) int {
 	c.addModuleDepthFlag(cmdFlags, &moduleDepth)
 	cmdFlags.BoolVar(&verbose, "verbose", false, "verbose")
 	cmdFlags.BoolVar(&drawCycles, "draw-cycles", false, "draw-cycles")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -47,7 +49,7 @@ func (c *GraphCommand) Run(args []string) int {
 		}
 	}
 
-	ctx, _, err := c.Context(contextOpts{
+	ctx, planFile, err := c.Context(contextOpts{
 		Path:      path,
 		StatePath: "",
 	})
@@ -56,6 +58,19 @@ func (c *GraphCommand) Run(args []string) int {
 		return 1
 	}
 
+	// If we're graphing a plan, annotate the resources with the action
+	// planned for them.
+	var diff *terraform.Diff
+	if planFile {
+		plan, err := c.readPlan(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error loading plan: %s", err))
+			return 1
+		}
+
+		diff = plan.Diff
+	}
+
 	// Skip validation during graph generation - we want to see the graph even if
 	// it is invalid for some reason.
 	g, err := ctx.Graph(&terraform.ContextGraphOpts{
@@ -67,11 +82,19 @@ func (c *GraphCommand) Run(args []string) int {
 		return 1
 	}
 
-	graphStr, err := terraform.GraphDot(g, &terraform.GraphDotOpts{
+	opts := &terraform.GraphDotOpts{
 		DrawCycles: drawCycles,
 		MaxDepth:   moduleDepth,
 		Verbose:    verbose,
-	})
+		Diff:       diff,
+	}
+
+	var graphStr string
+	if jsonOutput {
+		graphStr, err = terraform.GraphJSON(g, opts)
+	} else {
+		graphStr, err = terraform.GraphDot(g, opts)
+	}
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error converting graph: %s", err))
 		return 1
@@ -82,13 +105,27 @@ func (c *GraphCommand) Run(args []string) int {
 	return 0
 }
 
+// readPlan reads the plan file at the given path.
+func (c *GraphCommand) readPlan(path string) (*terraform.Plan, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return terraform.ReadPlan(f)
+}
+
 func (c *GraphCommand) Help() string {
 	helpText := `
-Usage: terraform graph [options] [DIR]
+Usage: terraform graph [options] [DIR|PLAN]
 
   Outputs the visual dependency graph of Terraform resources according to
   configuration files in DIR (or the current directory if omitted).
 
+  If a plan file is given instead, the resources are annotated with the
+  action planned for them: create, update, replace or destroy.
+
   The graph is outputted in DOT format. The typical program that can
   read this format is GraphViz, but many web services are also available
   to read this format.
@@ -98,6 +135,10 @@ Options:
   -draw-cycles         Highlight any cycles in the graph with colored edges.
                        This helps when diagnosing cycle errors.
 
+  -json                Output the graph as JSON instead of DOT, for use by
+                       other tools. The cycles of the graph are always
+                       included.
+
   -module-depth=n      The maximum depth to expand modules. By default this is
                        -1, which will expand resources within all modules.
 
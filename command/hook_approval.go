@@ -0,0 +1,160 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// ApprovalHook is a hook that asks for an approval before applying the
+// changes to the resources matching one of its patterns, so that changes to
+// critical infrastructure get a human check even when Terraform runs inside
+// automation. A change that isn't approved fails to apply.
+type ApprovalHook struct {
+	terraform.NilHook
+	sync.Mutex
+
+	// Patterns are matched against the type and the address of the
+	// resources, such as "aws_directconnect_*" or "module.network.*",
+	// with the syntax of filepath.Match.
+	Patterns []string
+
+	// Approver asks for the approvals. If it is nil, the changes to the
+	// resources matching Patterns are never approved.
+	Approver Approver
+
+	// approved are the addresses of the resources whose changes were
+	// approved, so that replacing a resource is only approved once.
+	approved map[string]struct{}
+}
+
+// Approver asks for the approval of the change of a single resource.
+type Approver interface {
+	Approve(*planJSONResourceChange) (bool, error)
+}
+
+func (h *ApprovalHook) PreApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff) (terraform.HookAction, error) {
+	id := n.HumanId()
+	if !h.matches(n.Type, id) {
+		return terraform.HookActionContinue, nil
+	}
+
+	var moduleName string
+	if len(n.ModulePath) > 1 {
+		moduleName = fmt.Sprintf("module.%s", strings.Join(n.ModulePath[1:], "."))
+	}
+	change := formatPlanJSONResourceChange(moduleName, n.Id, d, s)
+	if change == nil {
+		return terraform.HookActionContinue, nil
+	}
+
+	// Approvals are asked one at a time, so that prompts don't overlap.
+	h.Lock()
+	defer h.Unlock()
+
+	if _, ok := h.approved[id]; ok {
+		return terraform.HookActionContinue, nil
+	}
+
+	if h.Approver == nil {
+		return terraform.HookActionHalt, fmt.Errorf(
+			"%s: the change requires an approval, but input is disabled and "+
+				"no approval webhook is set", id)
+	}
+
+	log.Printf("[INFO] Asking for the approval to %s %s", change.Action, id)
+	ok, err := h.Approver.Approve(change)
+	if err != nil {
+		return terraform.HookActionHalt, fmt.Errorf(
+			"%s: error asking for approval: %s", id, err)
+	}
+	if !ok {
+		return terraform.HookActionHalt, fmt.Errorf(
+			"%s: the change to %s the resource was not approved", id, change.Action)
+	}
+
+	if h.approved == nil {
+		h.approved = make(map[string]struct{})
+	}
+	h.approved[id] = struct{}{}
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *ApprovalHook) matches(typ, id string) bool {
+	for _, pattern := range h.Patterns {
+		for _, v := range []string{typ, id} {
+			if ok, _ := filepath.Match(pattern, v); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// UIApprover is an Approver that asks for approvals with a UIInput.
+type UIApprover struct {
+	Input terraform.UIInput
+}
+
+func (a *UIApprover) Approve(change *planJSONResourceChange) (bool, error) {
+	v, err := a.Input.Input(&terraform.InputOpts{
+		Id:    "approve-" + change.Address,
+		Query: fmt.Sprintf("Do you approve the change to %s %s?", change.Action, change.Address),
+		Description: "This resource requires an approval before it is changed.\n" +
+			"Only 'yes' will be accepted to approve.",
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return v == "yes", nil
+}
+
+// WebhookApprover is an Approver that asks for approvals with an HTTP
+// POST request to URL, whose body is the change in the same JSON
+// representation as the resource changes of "terraform show -json".
+//
+// A 2xx response approves the change and a 403 response rejects it. The
+// webhook can hold the request until someone has made the decision.
+type WebhookApprover struct {
+	URL string
+}
+
+// webhookApproverClient is the HTTP client used by WebhookApprover. It has
+// no timeout, since approvals can take a while.
+var webhookApproverClient = cleanhttp.DefaultClient()
+
+func (a *WebhookApprover) Approve(change *planJSONResourceChange) (bool, error) {
+	body, err := json.Marshal(change)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := webhookApproverClient.Post(a.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return true, nil
+	case resp.StatusCode == http.StatusForbidden:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected response from %s: %s", a.URL, resp.Status)
+	}
+}
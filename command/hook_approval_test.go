@@ -0,0 +1,224 @@
+package command
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestApprovalHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(ApprovalHook)
+	var _ Approver = new(UIApprover)
+	var _ Approver = new(WebhookApprover)
+}
+
+func TestApprovalHook(t *testing.T) {
+	input := &terraform.MockUIInput{InputReturnString: "yes"}
+	h := &ApprovalHook{
+		Patterns: []string{"aws_directconnect_*"},
+		Approver: &UIApprover{Input: input},
+	}
+
+	// Resources that don't match aren't asked about
+	action, err := h.PreApply(
+		&terraform.InstanceInfo{Id: "aws_instance.foo", Type: "aws_instance"},
+		new(terraform.InstanceState), testApprovalHookDiff())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if action != terraform.HookActionContinue {
+		t.Fatalf("bad: %#v", action)
+	}
+	if input.InputCalled {
+		t.Fatal("input should not be called")
+	}
+
+	action, err = h.PreApply(
+		&terraform.InstanceInfo{
+			Id:   "aws_directconnect_connection.main",
+			Type: "aws_directconnect_connection",
+		},
+		new(terraform.InstanceState), testApprovalHookDiff())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if action != terraform.HookActionContinue {
+		t.Fatalf("bad: %#v", action)
+	}
+	if !input.InputCalled {
+		t.Fatal("input should be called")
+	}
+	if !strings.Contains(input.InputOpts.Query, "create aws_directconnect_connection.main") {
+		t.Fatalf("bad: %#v", input.InputOpts)
+	}
+}
+
+func TestApprovalHook_address(t *testing.T) {
+	input := &terraform.MockUIInput{InputReturnString: "yes"}
+	h := &ApprovalHook{
+		Patterns: []string{"module.network.*"},
+		Approver: &UIApprover{Input: input},
+	}
+
+	_, err := h.PreApply(
+		&terraform.InstanceInfo{
+			Id:         "aws_vpc.main",
+			ModulePath: []string{"root", "network"},
+			Type:       "aws_vpc",
+		},
+		new(terraform.InstanceState), testApprovalHookDiff())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !input.InputCalled {
+		t.Fatal("input should be called")
+	}
+	if !strings.Contains(input.InputOpts.Query, "module.network.aws_vpc.main") {
+		t.Fatalf("bad: %#v", input.InputOpts)
+	}
+}
+
+func TestApprovalHook_rejected(t *testing.T) {
+	h := &ApprovalHook{
+		Patterns: []string{"aws_directconnect_*"},
+		Approver: &UIApprover{Input: &terraform.MockUIInput{InputReturnString: "no"}},
+	}
+
+	action, err := h.PreApply(
+		&terraform.InstanceInfo{
+			Id:   "aws_directconnect_connection.main",
+			Type: "aws_directconnect_connection",
+		},
+		new(terraform.InstanceState), testApprovalHookDiff())
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if action != terraform.HookActionHalt {
+		t.Fatalf("bad: %#v", action)
+	}
+	if !strings.Contains(err.Error(), "not approved") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestApprovalHook_noApprover(t *testing.T) {
+	h := &ApprovalHook{Patterns: []string{"aws_directconnect_*"}}
+
+	_, err := h.PreApply(
+		&terraform.InstanceInfo{
+			Id:   "aws_directconnect_connection.main",
+			Type: "aws_directconnect_connection",
+		},
+		new(terraform.InstanceState), testApprovalHookDiff())
+	if err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestApprovalHook_replace(t *testing.T) {
+	// A replaced resource is destroyed and created with two separate
+	// applies, but is only approved once.
+	var calls int
+	input := &terraform.MockUIInput{
+		InputFn: func(*terraform.InputOpts) (string, error) {
+			calls++
+			return "yes", nil
+		},
+	}
+	h := &ApprovalHook{
+		Patterns: []string{"aws_directconnect_*"},
+		Approver: &UIApprover{Input: input},
+	}
+
+	info := &terraform.InstanceInfo{
+		Id:   "aws_directconnect_connection.main",
+		Type: "aws_directconnect_connection",
+	}
+	state := &terraform.InstanceState{
+		ID:         "dxcon-abc123",
+		Attributes: map[string]string{"bandwidth": "1Gbps"},
+	}
+	destroy := &terraform.InstanceDiff{Destroy: true}
+	if _, err := h.PreApply(info, state, destroy); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := h.PreApply(info, new(terraform.InstanceState), testApprovalHookDiff()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("bad: %d", calls)
+	}
+}
+
+func TestWebhookApprover(t *testing.T) {
+	var change planJSONResourceChange
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("bad method: %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+			t.Errorf("err: %s", err)
+		}
+
+		if change.Action == planJSONActionDestroy {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer ts.Close()
+
+	a := &WebhookApprover{URL: ts.URL}
+	ok, err := a.Approve(&planJSONResourceChange{
+		Address: "aws_directconnect_connection.main",
+		Action:  planJSONActionCreate,
+		After:   map[string]string{"bandwidth": "1Gbps"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !ok {
+		t.Fatal("should be approved")
+	}
+	if change.Address != "aws_directconnect_connection.main" || change.After["bandwidth"] != "1Gbps" {
+		t.Fatalf("bad: %#v", change)
+	}
+
+	ok, err = a.Approve(&planJSONResourceChange{
+		Address: "aws_directconnect_connection.main",
+		Action:  planJSONActionDestroy,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ok {
+		t.Fatal("should not be approved")
+	}
+}
+
+func TestWebhookApprover_error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	a := &WebhookApprover{URL: ts.URL}
+	if _, err := a.Approve(&planJSONResourceChange{Action: planJSONActionCreate}); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func testApprovalHookDiff() *terraform.InstanceDiff {
+	return &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"bandwidth": &terraform.ResourceAttrDiff{
+				Old:         "",
+				New:         "1Gbps",
+				RequiresNew: true,
+			},
+		},
+	}
+}
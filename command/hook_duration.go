@@ -0,0 +1,117 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/ryanuber/columnize"
+)
+
+// DurationHook is a hook that records how long each resource took to
+// apply, so that the slowest resources can be summarized at the end of
+// an apply.
+type DurationHook struct {
+	// Durations are the resources that were applied successfully.
+	Durations []ResourceDuration
+
+	pending map[string]ResourceDuration
+
+	sync.Mutex
+	terraform.NilHook
+}
+
+// ResourceDuration is how long the operation on a single resource took.
+type ResourceDuration struct {
+	Id       string
+	Action   string
+	Start    time.Time
+	Duration time.Duration
+}
+
+func (h *DurationHook) PreApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff) (terraform.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.pending == nil {
+		h.pending = make(map[string]ResourceDuration)
+	}
+
+	action := "modify"
+	if d.Destroy {
+		action = "destroy"
+	} else if s.ID == "" {
+		action = "create"
+	}
+
+	h.pending[n.HumanId()] = ResourceDuration{
+		Id:     n.HumanId(),
+		Action: action,
+		Start:  time.Now().Round(time.Second),
+	}
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *DurationHook) PostApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	e error) (terraform.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	d, ok := h.pending[n.HumanId()]
+	if !ok {
+		return terraform.HookActionContinue, nil
+	}
+	delete(h.pending, n.HumanId())
+
+	if e == nil {
+		d.Duration = time.Now().Round(time.Second).Sub(d.Start)
+		h.Durations = append(h.Durations, d)
+	}
+
+	return terraform.HookActionContinue, nil
+}
+
+// Summary returns a table of the applied resources and how long each of
+// them took, slowest first. It is empty if no resources were applied.
+func (h *DurationHook) Summary() string {
+	h.Lock()
+	defer h.Unlock()
+
+	if len(h.Durations) == 0 {
+		return ""
+	}
+
+	ds := make([]ResourceDuration, len(h.Durations))
+	copy(ds, h.Durations)
+	sort.Sort(resourceDurationSort(ds))
+
+	output := make([]string, 0, len(ds)+1)
+	output = append(output, "Resource | Action | Duration")
+	for _, d := range ds {
+		output = append(output, fmt.Sprintf(
+			"%s | %s | %s", d.Id, d.Action, d.Duration))
+	}
+
+	return columnize.Format(output, columnize.DefaultConfig())
+}
+
+// resourceDurationSort sorts resources by duration, slowest first.
+type resourceDurationSort []ResourceDuration
+
+func (s resourceDurationSort) Len() int      { return len(s) }
+func (s resourceDurationSort) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s resourceDurationSort) Less(i, j int) bool {
+	if s[i].Duration != s[j].Duration {
+		return s[i].Duration > s[j].Duration
+	}
+
+	return s[i].Id < s[j].Id
+}
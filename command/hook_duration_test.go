@@ -0,0 +1,66 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestDurationHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(DurationHook)
+}
+
+func TestDurationHookApply(t *testing.T) {
+	h := new(DurationHook)
+
+	resources := map[string]*terraform.InstanceState{
+		"foo": &terraform.InstanceState{},
+		"bar": &terraform.InstanceState{ID: "bar"},
+		"baz": &terraform.InstanceState{},
+	}
+
+	for id, s := range resources {
+		n := &terraform.InstanceInfo{Id: id}
+		h.PreApply(n, s, &terraform.InstanceDiff{})
+	}
+
+	h.PostApply(&terraform.InstanceInfo{Id: "foo"}, nil, nil)
+	h.PostApply(&terraform.InstanceInfo{Id: "bar"}, nil, nil)
+	h.PostApply(&terraform.InstanceInfo{Id: "baz"}, nil, errors.New("failed"))
+
+	if len(h.Durations) != 2 {
+		t.Fatalf("bad: %#v", h.Durations)
+	}
+	if d := h.Durations[0]; d.Id != "foo" || d.Action != "create" {
+		t.Fatalf("bad: %#v", d)
+	}
+	if d := h.Durations[1]; d.Id != "bar" || d.Action != "modify" {
+		t.Fatalf("bad: %#v", d)
+	}
+}
+
+func TestDurationHookSummary(t *testing.T) {
+	h := new(DurationHook)
+	if s := h.Summary(); s != "" {
+		t.Fatalf("bad: %q", s)
+	}
+
+	h.Durations = []ResourceDuration{
+		{Id: "aws_instance.web", Action: "create", Duration: 40 * time.Second},
+		{Id: "aws_dx_connection.main", Action: "create", Duration: 150 * time.Second},
+		{Id: "aws_eip.web", Action: "destroy", Duration: 40 * time.Second},
+	}
+
+	expected := strings.TrimSpace(`
+Resource                Action   Duration
+aws_dx_connection.main  create   2m30s
+aws_eip.web             destroy  40s
+aws_instance.web        create   40s
+`)
+	if actual := h.Summary(); actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
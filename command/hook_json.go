@@ -0,0 +1,331 @@
+package command
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+// The types of the events written by JSONUi and JSONHook.
+const (
+	jsonEventLog             = "log"
+	jsonEventDiagnostic      = "diagnostic"
+	jsonEventApplyStart      = "apply_start"
+	jsonEventApplyProgress   = "apply_progress"
+	jsonEventApplyComplete   = "apply_complete"
+	jsonEventApplyErrored    = "apply_errored"
+	jsonEventProvisionStart  = "provision_start"
+	jsonEventProvisionOutput = "provision_output"
+	jsonEventChangeSummary   = "change_summary"
+	jsonEventOutputs         = "outputs"
+)
+
+// The severities of the diagnostics of the events.
+const (
+	jsonSeverityError   = "error"
+	jsonSeverityWarning = "warning"
+)
+
+// jsonEvent is a single event of the machine-readable output of a command
+// run with -json. The events are written one per line.
+//
+// Only the fields relevant to the type of the event are set. The address,
+// module and action of the resource events are the same as the ones of
+// the resource changes of "terraform show -json".
+type jsonEvent struct {
+	Type        string                 `json:"type"`
+	Timestamp   string                 `json:"@timestamp"`
+	Message     string                 `json:"message,omitempty"`
+	Address     string                 `json:"address,omitempty"`
+	Module      string                 `json:"module,omitempty"`
+	Action      string                 `json:"action,omitempty"`
+	ID          string                 `json:"id,omitempty"`
+	Elapsed     float64                `json:"elapsed_seconds,omitempty"`
+	Provisioner string                 `json:"provisioner,omitempty"`
+	Diagnostic  *jsonDiagnostic        `json:"diagnostic,omitempty"`
+	Changes     *jsonChanges           `json:"changes,omitempty"`
+	Outputs     map[string]*jsonOutput `json:"outputs,omitempty"`
+}
+
+// jsonDiagnostic is an error or a warning. Summary is a short description
+// of the problem, and Detail the full message.
+type jsonDiagnostic struct {
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// jsonChanges counts the resources changed by an apply.
+type jsonChanges struct {
+	Add    int `json:"add"`
+	Change int `json:"change"`
+	Remove int `json:"remove"`
+}
+
+// jsonOutput is an output of the root module. The value of sensitive
+// outputs is masked.
+type jsonOutput struct {
+	Sensitive bool        `json:"sensitive"`
+	Type      string      `json:"type"`
+	Value     interface{} `json:"value"`
+}
+
+// JSONUi is a cli.Ui that writes everything as JSON events to the standard
+// output of Ui, so that the whole output of a command is machine-readable.
+// Messages are "log" events, and errors and warnings are "diagnostic"
+// events.
+type JSONUi struct {
+	Ui cli.Ui
+
+	l sync.Mutex
+}
+
+func (u *JSONUi) Ask(string) (string, error) {
+	return "", fmt.Errorf("input is disabled with -json")
+}
+
+func (u *JSONUi) AskSecret(string) (string, error) {
+	return "", fmt.Errorf("input is disabled with -json")
+}
+
+func (u *JSONUi) Output(msg string) {
+	u.Event(&jsonEvent{Type: jsonEventLog, Message: strings.TrimSpace(msg)})
+}
+
+func (u *JSONUi) Info(msg string) {
+	u.Output(msg)
+}
+
+func (u *JSONUi) Error(msg string) {
+	u.Event(&jsonEvent{
+		Type:       jsonEventDiagnostic,
+		Diagnostic: newJSONDiagnostic(jsonSeverityError, msg),
+	})
+}
+
+func (u *JSONUi) Warn(msg string) {
+	u.Event(&jsonEvent{
+		Type:       jsonEventDiagnostic,
+		Diagnostic: newJSONDiagnostic(jsonSeverityWarning, msg),
+	})
+}
+
+// Event writes the event on its own line, setting its timestamp.
+func (u *JSONUi) Event(e *jsonEvent) {
+	e.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+
+	out, err := json.Marshal(e)
+	if err != nil {
+		// Events only contain values that can be encoded
+		panic(fmt.Sprintf("Error encoding event as JSON: %s", err))
+	}
+
+	u.l.Lock()
+	defer u.l.Unlock()
+	u.Ui.Output(string(out))
+}
+
+// newJSONDiagnostic builds a diagnostic from a message of the UI. Its first
+// line is the summary and the rest is the detail.
+func newJSONDiagnostic(severity, msg string) *jsonDiagnostic {
+	msg = strings.TrimSpace(msg)
+	summary, detail := msg, ""
+	if idx := strings.Index(msg, "\n"); idx != -1 {
+		summary, detail = msg[:idx], strings.TrimSpace(msg[idx+1:])
+	}
+
+	return &jsonDiagnostic{
+		Severity: severity,
+		Summary:  strings.TrimSuffix(strings.TrimSpace(summary), ":"),
+		Detail:   detail,
+	}
+}
+
+// JSONHook is the hook used instead of UiHook with -json. It writes an
+// event when a resource starts being applied, every Interval while it is
+// still being applied, and when it is complete or fails.
+type JSONHook struct {
+	terraform.NilHook
+
+	Ui *JSONUi
+
+	// Interval is the time between the progress events of a resource.
+	// It defaults to the interval of the "Still creating..." messages.
+	Interval time.Duration
+
+	l         sync.Mutex
+	resources map[string]jsonResourceState
+}
+
+// jsonResourceState tracks a resource being applied.
+type jsonResourceState struct {
+	Action string
+	Start  time.Time
+}
+
+func (h *JSONHook) PreApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff) (terraform.HookAction, error) {
+	id := n.HumanId()
+
+	action := planJSONActionUpdate
+	if d.Destroy {
+		action = planJSONActionDestroy
+	} else if s.ID == "" {
+		action = planJSONActionCreate
+	}
+
+	h.l.Lock()
+	if h.resources == nil {
+		h.resources = make(map[string]jsonResourceState)
+	}
+	h.resources[id] = jsonResourceState{
+		Action: action,
+		Start:  time.Now(),
+	}
+	h.l.Unlock()
+
+	h.Ui.Event(h.resourceEvent(jsonEventApplyStart, n, action))
+
+	time.AfterFunc(h.interval(), func() { h.stillApplying(n) })
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) stillApplying(n *terraform.InstanceInfo) {
+	// Hold the lock while writing, so that the progress event can't come
+	// after the completion event.
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	state, ok := h.resources[n.HumanId()]
+	if !ok {
+		return
+	}
+
+	e := h.resourceEvent(jsonEventApplyProgress, n, state.Action)
+	e.Elapsed = time.Since(state.Start).Seconds()
+	h.Ui.Event(e)
+
+	time.AfterFunc(h.interval(), func() { h.stillApplying(n) })
+}
+
+func (h *JSONHook) PostApply(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState,
+	applyerr error) (terraform.HookAction, error) {
+	id := n.HumanId()
+
+	h.l.Lock()
+	state, ok := h.resources[id]
+	delete(h.resources, id)
+	h.l.Unlock()
+
+	if !ok {
+		return terraform.HookActionContinue, nil
+	}
+
+	var e *jsonEvent
+	if applyerr != nil {
+		e = h.resourceEvent(jsonEventApplyErrored, n, state.Action)
+		e.Diagnostic = &jsonDiagnostic{
+			Severity: jsonSeverityError,
+			Summary:  fmt.Sprintf("Error applying %s", id),
+			Detail:   applyerr.Error(),
+		}
+	} else {
+		e = h.resourceEvent(jsonEventApplyComplete, n, state.Action)
+		if s != nil {
+			e.ID = s.ID
+		}
+	}
+	e.Elapsed = time.Since(state.Start).Seconds()
+	h.Ui.Event(e)
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) PreProvision(
+	n *terraform.InstanceInfo,
+	provId string) (terraform.HookAction, error) {
+	e := h.resourceEvent(jsonEventProvisionStart, n, "")
+	e.Provisioner = provId
+	h.Ui.Event(e)
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *JSONHook) ProvisionOutput(
+	n *terraform.InstanceInfo,
+	provId string,
+	msg string) {
+	s := bufio.NewScanner(strings.NewReader(msg))
+	s.Split(scanLines)
+	for s.Scan() {
+		line := strings.TrimRightFunc(s.Text(), unicode.IsSpace)
+		if line == "" {
+			continue
+		}
+
+		e := h.resourceEvent(jsonEventProvisionOutput, n, "")
+		e.Provisioner = provId
+		e.Message = line
+		h.Ui.Event(e)
+	}
+}
+
+// resourceEvent returns an event of the given type about the resource.
+func (h *JSONHook) resourceEvent(
+	typ string, n *terraform.InstanceInfo, action string) *jsonEvent {
+	var module string
+	if len(n.ModulePath) > 1 {
+		module = fmt.Sprintf("module.%s", strings.Join(n.ModulePath[1:], "."))
+	}
+
+	return &jsonEvent{
+		Type:    typ,
+		Address: n.HumanId(),
+		Module:  module,
+		Action:  action,
+	}
+}
+
+func (h *JSONHook) interval() time.Duration {
+	if h.Interval > 0 {
+		return h.Interval
+	}
+
+	return periodicUiTimer
+}
+
+// jsonOutputs returns the outputs of the root module of the state for the
+// "outputs" event.
+func jsonOutputs(s *terraform.State) map[string]*jsonOutput {
+	if s == nil {
+		return nil
+	}
+
+	result := make(map[string]*jsonOutput)
+	for k, v := range s.RootModule().Outputs {
+		o := &jsonOutput{
+			Sensitive: v.Sensitive,
+			Type:      v.Type,
+			Value:     v.Value,
+		}
+		if o.Sensitive {
+			o.Value = planJSONSensitiveValue
+		}
+
+		result[k] = o
+	}
+
+	return result
+}
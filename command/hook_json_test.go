@@ -0,0 +1,242 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestJSONUi_impl(t *testing.T) {
+	var _ cli.Ui = new(JSONUi)
+}
+
+func TestJSONHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(JSONHook)
+}
+
+func TestJSONUi(t *testing.T) {
+	ui := new(cli.MockUi)
+	u := &JSONUi{Ui: ui}
+
+	u.Output("Refreshed 3 resources...")
+	u.Error("Error applying plan:\n\n1 error(s) occurred:\n\n* quota exceeded")
+	u.Warn("Deprecated attribute")
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	if len(events) != 3 {
+		t.Fatalf("bad: %#v", events)
+	}
+
+	if events[0].Type != jsonEventLog || events[0].Message != "Refreshed 3 resources..." {
+		t.Fatalf("bad: %#v", events[0])
+	}
+	if events[0].Timestamp == "" {
+		t.Fatal("timestamp should be set")
+	}
+
+	expected := &jsonDiagnostic{
+		Severity: jsonSeverityError,
+		Summary:  "Error applying plan",
+		Detail:   "1 error(s) occurred:\n\n* quota exceeded",
+	}
+	if !reflect.DeepEqual(events[1].Diagnostic, expected) {
+		t.Fatalf("bad: %#v", events[1].Diagnostic)
+	}
+
+	expected = &jsonDiagnostic{
+		Severity: jsonSeverityWarning,
+		Summary:  "Deprecated attribute",
+	}
+	if !reflect.DeepEqual(events[2].Diagnostic, expected) {
+		t.Fatalf("bad: %#v", events[2].Diagnostic)
+	}
+}
+
+func TestJSONHook(t *testing.T) {
+	ui := new(cli.MockUi)
+	h := &JSONHook{Ui: &JSONUi{Ui: ui}}
+
+	info := &terraform.InstanceInfo{
+		Id:         "aws_instance.foo",
+		ModulePath: []string{"root", "child"},
+		Type:       "aws_instance",
+	}
+	if _, err := h.PreApply(info, new(terraform.InstanceState), new(terraform.InstanceDiff)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	_, err := h.PostApply(info, &terraform.InstanceState{ID: "i-abc123"}, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	if len(events) != 2 {
+		t.Fatalf("bad: %#v", events)
+	}
+
+	start := events[0]
+	if start.Type != jsonEventApplyStart || start.Action != planJSONActionCreate {
+		t.Fatalf("bad: %#v", start)
+	}
+	if start.Address != "module.child.aws_instance.foo" || start.Module != "module.child" {
+		t.Fatalf("bad: %#v", start)
+	}
+
+	complete := events[1]
+	if complete.Type != jsonEventApplyComplete || complete.ID != "i-abc123" {
+		t.Fatalf("bad: %#v", complete)
+	}
+}
+
+func TestJSONHook_error(t *testing.T) {
+	ui := new(cli.MockUi)
+	h := &JSONHook{Ui: &JSONUi{Ui: ui}}
+
+	info := &terraform.InstanceInfo{Id: "aws_instance.foo", Type: "aws_instance"}
+	state := &terraform.InstanceState{ID: "i-abc123"}
+	if _, err := h.PreApply(info, state, &terraform.InstanceDiff{Destroy: true}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	_, err := h.PostApply(info, state, fmt.Errorf("DependencyViolation"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	if len(events) != 2 {
+		t.Fatalf("bad: %#v", events)
+	}
+
+	errored := events[1]
+	if errored.Type != jsonEventApplyErrored || errored.Action != planJSONActionDestroy {
+		t.Fatalf("bad: %#v", errored)
+	}
+	expected := &jsonDiagnostic{
+		Severity: jsonSeverityError,
+		Summary:  "Error applying aws_instance.foo",
+		Detail:   "DependencyViolation",
+	}
+	if !reflect.DeepEqual(errored.Diagnostic, expected) {
+		t.Fatalf("bad: %#v", errored.Diagnostic)
+	}
+}
+
+func TestJSONHook_progress(t *testing.T) {
+	ui := new(cli.MockUi)
+	h := &JSONHook{
+		Ui:       &JSONUi{Ui: ui},
+		Interval: 10 * time.Millisecond,
+	}
+
+	info := &terraform.InstanceInfo{Id: "aws_instance.foo", Type: "aws_instance"}
+	state := &terraform.InstanceState{ID: "i-abc123"}
+	if _, err := h.PreApply(info, state, new(terraform.InstanceDiff)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	time.Sleep(35 * time.Millisecond)
+	if _, err := h.PostApply(info, state, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// No progress is reported once the resource is complete
+	time.Sleep(20 * time.Millisecond)
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	if len(events) < 3 {
+		t.Fatalf("bad: %#v", events)
+	}
+	for i, e := range events[1 : len(events)-1] {
+		if e.Type != jsonEventApplyProgress || e.Action != planJSONActionUpdate {
+			t.Fatalf("bad %d: %#v", i, e)
+		}
+		if e.Elapsed <= 0 {
+			t.Fatalf("bad %d: %#v", i, e)
+		}
+	}
+	if e := events[len(events)-1]; e.Type != jsonEventApplyComplete {
+		t.Fatalf("bad: %#v", e)
+	}
+}
+
+func TestJSONHook_provision(t *testing.T) {
+	ui := new(cli.MockUi)
+	h := &JSONHook{Ui: &JSONUi{Ui: ui}}
+
+	info := &terraform.InstanceInfo{Id: "aws_instance.foo", Type: "aws_instance"}
+	if _, err := h.PreProvision(info, "local-exec"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	h.ProvisionOutput(info, "local-exec", "foo\n\nbar\r\n")
+
+	events := testJSONEvents(t, ui.OutputWriter.String())
+	if len(events) != 3 {
+		t.Fatalf("bad: %#v", events)
+	}
+	if events[0].Type != jsonEventProvisionStart || events[0].Provisioner != "local-exec" {
+		t.Fatalf("bad: %#v", events[0])
+	}
+	for i, msg := range []string{"foo", "bar"} {
+		e := events[i+1]
+		if e.Type != jsonEventProvisionOutput || e.Message != msg {
+			t.Fatalf("bad: %#v", e)
+		}
+	}
+}
+
+func TestJSONOutputs(t *testing.T) {
+	s := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"address": &terraform.OutputState{
+						Type:  "string",
+						Value: "169.254.0.2/30",
+					},
+					"auth_key": &terraform.OutputState{
+						Sensitive: true,
+						Type:      "string",
+						Value:     "secret",
+					},
+				},
+			},
+		},
+	}
+
+	actual := jsonOutputs(s)
+	expected := map[string]*jsonOutput{
+		"address": &jsonOutput{
+			Type:  "string",
+			Value: "169.254.0.2/30",
+		},
+		"auth_key": &jsonOutput{
+			Sensitive: true,
+			Type:      "string",
+			Value:     planJSONSensitiveValue,
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+// testJSONEvents decodes the events written by a JSONUi, failing if any
+// line isn't an event.
+func testJSONEvents(t *testing.T, out string) []*jsonEvent {
+	var result []*jsonEvent
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e jsonEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("bad line %q: %s", line, err)
+		}
+		result = append(result, &e)
+	}
+
+	return result
+}
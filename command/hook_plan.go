@@ -0,0 +1,57 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// PlanHook is called by the plan command with the plan it shows, and
+// returns annotations to show below the plan, such as cost estimates for
+// the resources it adds.
+type PlanHook interface {
+	PostPlan(*terraform.Plan) ([]string, error)
+}
+
+// ProgramPlanHook is a PlanHook that runs an external program. The program
+// reads the plan on stdin, in the same JSON representation as
+// "terraform show -json", and every non-empty line it writes to stdout is
+// an annotation.
+type ProgramPlanHook struct {
+	Program string
+}
+
+func (h *ProgramPlanHook) PostPlan(p *terraform.Plan) ([]string, error) {
+	planJSON, err := FormatPlanJSON(p)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Running plan hook: %s", h.Program)
+	cmd := exec.Command(h.Program)
+	cmd.Stdin = strings.NewReader(planJSON)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("Error running %q: %s: %s", h.Program, err, msg)
+		}
+		return nil, fmt.Errorf("Error running %q: %s", h.Program, err)
+	}
+
+	var result []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimRight(line, "\r\t "); line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return result, nil
+}
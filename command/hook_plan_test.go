@@ -0,0 +1,63 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProgramPlanHook_impl(t *testing.T) {
+	var _ PlanHook = new(ProgramPlanHook)
+}
+
+func TestProgramPlanHook(t *testing.T) {
+	// The hook counts the resources it reads on stdin
+	program := testPlanHookProgram(t, `#!/bin/sh
+echo "resources: $(grep -c '"address"')"
+echo
+echo "done"
+`)
+	defer os.RemoveAll(filepath.Dir(program))
+
+	h := &ProgramPlanHook{Program: program}
+	actual, err := h.PostPlan(testPlanCountsPlan())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []string{"resources: 4", "done"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestProgramPlanHook_error(t *testing.T) {
+	program := testPlanHookProgram(t, `#!/bin/sh
+echo "cost API unavailable" >&2
+exit 1
+`)
+	defer os.RemoveAll(filepath.Dir(program))
+
+	h := &ProgramPlanHook{Program: program}
+	_, err := h.PostPlan(testPlanCountsPlan())
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !strings.Contains(err.Error(), "cost API unavailable") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+// testPlanHookProgram writes an executable script to a temporary directory
+// and returns its path.
+func testPlanHookProgram(t *testing.T, script string) string {
+	path := filepath.Join(testTempDir(t), "hook")
+	if err := ioutil.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return path
+}
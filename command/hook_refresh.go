@@ -0,0 +1,139 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+// RefreshRateHook is a hook that limits how many resources of each
+// provider are refreshed per second, to stay below the rate limits of
+// their APIs when refreshing a large state.
+type RefreshRateHook struct {
+	// Limits are the refreshes per second allowed for each provider,
+	// keyed by provider name, e.g. "aws". Providers that aren't in Limits
+	// aren't limited.
+	Limits map[string]float64
+
+	// next is the time the next resource of each provider can be
+	// refreshed at.
+	next map[string]time.Time
+
+	sync.Mutex
+	terraform.NilHook
+}
+
+func (h *RefreshRateHook) PreRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	provider := refreshHookProvider(n.Type)
+	limit := h.Limits[provider]
+	if limit <= 0 {
+		return terraform.HookActionContinue, nil
+	}
+
+	// Reserve the next slot for this provider, then wait for it without
+	// holding the lock, so that other providers aren't held up.
+	h.Lock()
+	if h.next == nil {
+		h.next = make(map[string]time.Time)
+	}
+	now := time.Now()
+	next := h.next[provider]
+	if next.Before(now) {
+		next = now
+	}
+	h.next[provider] = next.Add(time.Duration(float64(time.Second) / limit))
+	h.Unlock()
+
+	time.Sleep(next.Sub(now))
+	return terraform.HookActionContinue, nil
+}
+
+// refreshHookProvider returns the name of the provider of a resource type,
+// the same way Terraform finds the provider of a resource that doesn't set
+// one.
+func refreshHookProvider(t string) string {
+	return strings.SplitN(t, "_", 2)[0]
+}
+
+// RefreshProgressHook is a hook that periodically reports how many
+// resources were refreshed, so that refreshing a large state doesn't look
+// stuck.
+type RefreshProgressHook struct {
+	Ui cli.Ui
+
+	// Total is the number of resources expected to be refreshed. If it is
+	// zero, the progress is reported without it.
+	Total int
+
+	// Interval is the minimum time between two reports.
+	Interval time.Duration
+
+	// Refreshed is the number of resources refreshed so far.
+	Refreshed int
+
+	lastReport time.Time
+
+	sync.Mutex
+	terraform.NilHook
+}
+
+func (h *RefreshProgressHook) PreRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.lastReport.IsZero() {
+		h.lastReport = time.Now()
+	}
+
+	return terraform.HookActionContinue, nil
+}
+
+func (h *RefreshProgressHook) PostRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.Refreshed++
+	if time.Since(h.lastReport) < h.Interval {
+		return terraform.HookActionContinue, nil
+	}
+	h.lastReport = time.Now()
+
+	// Data sources that aren't in the state yet are refreshed too, so the
+	// total can be exceeded.
+	if h.Total > 0 && h.Refreshed <= h.Total {
+		h.Ui.Output(fmt.Sprintf("Refreshed %d/%d resources...", h.Refreshed, h.Total))
+	} else {
+		h.Ui.Output(fmt.Sprintf("Refreshed %d resources...", h.Refreshed))
+	}
+
+	return terraform.HookActionContinue, nil
+}
+
+// refreshTotal returns the number of resources in the state that are
+// refreshed, which are the ones with an ID.
+func refreshTotal(s *terraform.State) int {
+	if s == nil {
+		return 0
+	}
+
+	var total int
+	for _, m := range s.Modules {
+		for _, r := range m.Resources {
+			if r.Primary != nil && r.Primary.ID != "" {
+				total++
+			}
+		}
+	}
+
+	return total
+}
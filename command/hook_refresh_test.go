@@ -0,0 +1,156 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestRefreshRateHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(RefreshRateHook)
+}
+
+func TestRefreshRateHook(t *testing.T) {
+	h := &RefreshRateHook{
+		Limits: map[string]float64{"aws": 100},
+	}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		action, err := h.PreRefresh(
+			&terraform.InstanceInfo{Id: "aws_instance.foo", Type: "aws_instance"},
+			&terraform.InstanceState{ID: "i-abc123"})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if action != terraform.HookActionContinue {
+			t.Fatalf("bad: %#v", action)
+		}
+	}
+
+	// The first refresh doesn't wait, and each of the others waits 10ms.
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected the refreshes to be limited, took %s", elapsed)
+	}
+}
+
+func TestRefreshRateHook_otherProvider(t *testing.T) {
+	h := &RefreshRateHook{
+		Limits: map[string]float64{"aws": 0.001},
+	}
+
+	// Only the first refresh of a provider doesn't wait, so a provider
+	// without a limit would block here for a long time.
+	for i := 0; i < 5; i++ {
+		_, err := h.PreRefresh(
+			&terraform.InstanceInfo{Id: "google_compute_instance.foo", Type: "google_compute_instance"},
+			&terraform.InstanceState{ID: "foo"})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+}
+
+func TestRefreshRateHook_provider(t *testing.T) {
+	cases := map[string]string{
+		"aws_instance":            "aws",
+		"google_compute_instance": "google",
+		"null":                    "null",
+	}
+
+	for input, expected := range cases {
+		if actual := refreshHookProvider(input); actual != expected {
+			t.Fatalf("%s: expected %q, got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestRefreshProgressHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(RefreshProgressHook)
+}
+
+func TestRefreshProgressHook(t *testing.T) {
+	ui := new(cli.MockUi)
+	h := &RefreshProgressHook{Ui: ui, Total: 2}
+
+	info := &terraform.InstanceInfo{Id: "aws_instance.foo", Type: "aws_instance"}
+	state := &terraform.InstanceState{ID: "i-abc123"}
+	for i := 0; i < 3; i++ {
+		if _, err := h.PreRefresh(info, state); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if _, err := h.PostRefresh(info, state); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	if h.Refreshed != 3 {
+		t.Fatalf("bad: %d", h.Refreshed)
+	}
+
+	expected := strings.TrimSpace(`
+Refreshed 1/2 resources...
+Refreshed 2/2 resources...
+Refreshed 3 resources...
+`)
+	if actual := strings.TrimSpace(ui.OutputWriter.String()); actual != expected {
+		t.Fatalf("expected:\n%s\n\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestRefreshProgressHook_interval(t *testing.T) {
+	ui := new(cli.MockUi)
+	h := &RefreshProgressHook{Ui: ui, Total: 2, Interval: time.Hour}
+
+	info := &terraform.InstanceInfo{Id: "aws_instance.foo", Type: "aws_instance"}
+	state := &terraform.InstanceState{ID: "i-abc123"}
+	for i := 0; i < 2; i++ {
+		h.PreRefresh(info, state)
+		h.PostRefresh(info, state)
+	}
+
+	if h.Refreshed != 2 {
+		t.Fatalf("bad: %d", h.Refreshed)
+	}
+	if ui.OutputWriter != nil && ui.OutputWriter.Len() > 0 {
+		t.Fatalf("expected no report, got: %s", ui.OutputWriter.String())
+	}
+}
+
+func TestRefreshTotal(t *testing.T) {
+	state := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"aws_instance.foo": &terraform.ResourceState{
+						Type:    "aws_instance",
+						Primary: &terraform.InstanceState{ID: "i-abc123"},
+					},
+					"aws_instance.empty": &terraform.ResourceState{
+						Type: "aws_instance",
+					},
+				},
+			},
+			&terraform.ModuleState{
+				Path: []string{"root", "child"},
+				Resources: map[string]*terraform.ResourceState{
+					"aws_instance.bar": &terraform.ResourceState{
+						Type:    "aws_instance",
+						Primary: &terraform.InstanceState{ID: "i-bcd345"},
+					},
+				},
+			},
+		},
+	}
+
+	if total := refreshTotal(state); total != 2 {
+		t.Fatalf("bad: %d", total)
+	}
+	if total := refreshTotal(nil); total != 0 {
+		t.Fatalf("bad: %d", total)
+	}
+}
@@ -103,15 +103,21 @@ func (h *UiHook) PreApply(
 		attrDiff := d.Attributes[attrK]
 
 		v := attrDiff.New
+		u := attrDiff.Old
 		if attrDiff.NewComputed {
 			v = "<computed>"
 		}
 
+		if attrDiff.Sensitive {
+			u = "<sensitive>"
+			v = "<sensitive>"
+		}
+
 		attrBuf.WriteString(fmt.Sprintf(
 			"  %s:%s %#v => %#v\n",
 			attrK,
 			strings.Repeat(" ", keyLen-len(attrK)),
-			attrDiff.Old,
+			u,
 			v))
 	}
 
@@ -208,6 +214,18 @@ func (h *UiHook) PreDiff(
 	return terraform.HookActionContinue, nil
 }
 
+func (h *UiHook) PostIgnoreChanges(
+	n *terraform.InstanceInfo,
+	attrs []string) (terraform.HookAction, error) {
+	h.once.Do(h.init)
+
+	id := n.HumanId()
+	h.ui.Output(h.Colorize.Color(fmt.Sprintf(
+		"[reset][yellow]%s: Ignoring changes to %s (lifecycle.ignore_changes)",
+		id, strings.Join(attrs, ", "))))
+	return terraform.HookActionContinue, nil
+}
+
 func (h *UiHook) PreProvision(
 	n *terraform.InstanceInfo,
 	provId string) (terraform.HookAction, error) {
@@ -3,8 +3,10 @@ package command
 import (
 	"fmt"
 	"log"
+	"os"
 	"strings"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -15,6 +17,14 @@ type ImportCommand struct {
 }
 
 func (c *ImportCommand) Run(args []string) int {
+	// Get the pwd since its our default -config flag value
+	pwd, err := os.Getwd()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+		return 1
+	}
+
+	var configPath string
 	args = c.Meta.process(args, true)
 
 	cmdFlags := c.Meta.flagSet("import")
@@ -22,6 +32,7 @@ func (c *ImportCommand) Run(args []string) int {
 	cmdFlags.IntVar(&c.Meta.parallelism, "parallelism", 0, "parallelism")
 	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.StringVar(&configPath, "config", pwd, "path")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -34,16 +45,38 @@ func (c *ImportCommand) Run(args []string) int {
 		return 1
 	}
 
+	// The configuration is only used to configure the provider, so it is
+	// fine for the directory not to have any. An explicit -config must
+	// have some, though.
+	if configPath == pwd {
+		empty, err := config.IsEmptyDir(configPath)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error loading config: %s", err))
+			return 1
+		}
+		if empty {
+			configPath = ""
+		}
+	}
+
 	// Build the context based on the arguments given
+	defer c.Meta.unlockState()
 	ctx, _, err := c.Context(contextOpts{
-		StatePath:   c.Meta.statePath,
-		Parallelism: c.Meta.parallelism,
+		Path:          configPath,
+		StatePath:     c.Meta.statePath,
+		Parallelism:   c.Meta.parallelism,
+		LockOperation: "import",
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
 		return 1
 	}
 
+	if err := ctx.Input(c.InputMode()); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error configuring: %s", err))
+		return 1
+	}
+
 	// Perform the import. Note that as you can see it is possible for this
 	// API to import more than one resource at once. For now, we only allow
 	// one while we stabilize this feature.
@@ -54,6 +87,7 @@ func (c *ImportCommand) Run(args []string) int {
 				ID:   args[1],
 			},
 		},
+		Module: ctx.Module(),
 	})
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error importing: %s", err))
@@ -111,8 +145,17 @@ Options:
                       modifying. Defaults to the "-state-out" path with
                       ".backup" extension. Set to "-" to disable backup.
 
+  -config=path        Path to a directory of Terraform configuration files
+                      to use to configure the provider. Defaults to pwd.
+                      Only the provider configuration is used; resources
+                      in the configuration are not imported or changed.
+
   -input=true         Ask for input for variables if not directly set.
 
+  -lock=true          Lock the state file when locking is supported.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
   -no-color           If specified, output won't contain any color.
 
   -state=path         Path to read and save state (unless state-out
@@ -121,6 +164,13 @@ Options:
   -state-out=path     Path to write updated state file. By default, the
                       "-state" path will be used.
 
+  -var 'foo=bar'      Set a variable in the Terraform configuration. This
+                      flag can be set multiple times.
+
+  -var-file=foo       Set variables in the Terraform configuration from
+                      a file. If "terraform.tfvars" is present, it will be
+                      automatically loaded if this flag is not specified.
+
 `
 	return strings.TrimSpace(helpText)
 }
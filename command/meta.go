@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/go-getter"
 	"github.com/hashicorp/terraform/config/module"
@@ -23,6 +24,20 @@ type Meta struct {
 	ContextOpts *terraform.ContextOpts
 	Ui          cli.Ui
 
+	// ProviderPlugins are the provider plugins found, with their versions.
+	// They are used to pick the plugins matching the version constraints
+	// of the configuration.
+	ProviderPlugins ProviderPlugins
+
+	// PlanHooks are called with the plans that the plan command shows,
+	// and return annotations to show below them.
+	PlanHooks []PlanHook
+
+	// StateHistory is the number of serials of the state kept in its
+	// history. Zero keeps DefaultStateHistory serials, and a negative
+	// number disables the history.
+	StateHistory int
+
 	// State read when calling `Context`. This is available after calling
 	// `Context`.
 	state       state.State
@@ -43,6 +58,16 @@ type Meta struct {
 	// Targets for this context (private)
 	targets []string
 
+	// stateLock is set by the -lock flag, and is false to disable locking
+	// the state. stateLockTimeout is how long to retry to lock a state that
+	// is already locked.
+	stateLock        bool
+	stateLockTimeout time.Duration
+
+	// The locker and ID of the lock held on the state, if any.
+	stateLocker state.Locker
+	stateLockID string
+
 	color bool
 	oldUi cli.Ui
 
@@ -67,6 +92,16 @@ type Meta struct {
 	stateOutPath string
 	backupPath   string
 	parallelism  int
+
+	// refreshParallelism limits the concurrent operations while
+	// refreshing, and refreshRateLimits the refreshes per second of each
+	// provider. They are set by the flags added with refreshFlags.
+	refreshParallelism int
+	refreshRateLimits  map[string]float64
+
+	// jsonUi is set by streamJSON when the output of the command is a
+	// stream of JSON events.
+	jsonUi *JSONUi
 }
 
 // initStatePaths is used to initialize the default values for
@@ -109,7 +144,10 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 		f.Close()
 		if err == nil {
 			// Setup our state
-			state, statePath, err := StateFromPlan(m.statePath, plan)
+			stateOpts := m.StateOpts()
+			state, statePath, err := StateFromPlan(
+				stateOpts.LocalPath, stateOpts.RemotePath,
+				stateOpts.HistoryKeep, plan)
 			if err != nil {
 				return nil, false, fmt.Errorf("Error loading plan: %s", err)
 			}
@@ -118,6 +156,14 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 			m.state = state
 			m.stateOutPath = statePath
 
+			// The state of the plan must not be replaced by refreshing
+			// it once locked.
+			if copts.LockOperation != "" {
+				if err := m.lockState(state, copts.LockOperation, false); err != nil {
+					return nil, false, err
+				}
+			}
+
 			if len(m.variables) > 0 {
 				return nil, false, fmt.Errorf(
 					"You can't set variables with the '-var' or '-var-file' flag\n" +
@@ -126,6 +172,10 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 						"variable values, create a new plan file.")
 			}
 
+			if err := m.selectProviders(opts, plan.Module); err != nil {
+				return nil, false, err
+			}
+
 			ctx, err := plan.Context(opts)
 			return ctx, true, err
 		}
@@ -145,6 +195,12 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 		return nil, false, err
 	}
 
+	if copts.LockOperation != "" {
+		if err := m.lockState(state, copts.LockOperation, true); err != nil {
+			return nil, false, err
+		}
+	}
+
 	// Load the root module
 	var mod *module.Tree
 	if copts.Path != "" {
@@ -161,9 +217,15 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 		return nil, false, fmt.Errorf("Error downloading modules: %s", err)
 	}
 
+	if err := m.selectProviders(opts, mod); err != nil {
+		return nil, false, err
+	}
+
 	opts.Module = mod
 	opts.Parallelism = copts.Parallelism
+	opts.Replace = copts.Replace
 	opts.State = state.State()
+	opts.Hooks = append(opts.Hooks, m.refreshHooks(opts.State)...)
 	ctx, err := terraform.NewContext(opts)
 	return ctx, false, err
 }
@@ -216,6 +278,10 @@ func (m *Meta) State() (state.State, error) {
 		return m.state, nil
 	}
 
+	if err := validateWorkspaceName(m.Workspace()); err != nil {
+		return nil, err
+	}
+
 	result, err := State(m.StateOpts())
 	if err != nil {
 		return nil, err
@@ -242,18 +308,35 @@ func (m *Meta) StateRaw(opts *StateOpts) (*StateResult, error) {
 
 // StateOpts returns the default state options
 func (m *Meta) StateOpts() *StateOpts {
+	workspace := m.Workspace()
+
+	// Only the default state paths depend on the workspace, paths given
+	// with flags are used as they are.
 	localPath := m.statePath
-	if localPath == "" {
-		localPath = DefaultStateFilename
+	if localPath == "" || localPath == DefaultStateFilename {
+		localPath = workspacePath(DefaultStateFilename, workspace)
+	}
+	localPathOut := m.stateOutPath
+	if localPathOut == DefaultStateFilename {
+		localPathOut = localPath
+	}
+	remotePath := workspacePath(
+		filepath.Join(m.DataDir(), DefaultStateFilename), workspace)
+
+	// Tests keep no history unless they ask for it, as many of them save
+	// the state next to their fixtures.
+	historyKeep := m.StateHistory
+	if historyKeep == 0 && !test {
+		historyKeep = DefaultStateHistory
 	}
-	remotePath := filepath.Join(m.DataDir(), DefaultStateFilename)
 
 	return &StateOpts{
 		LocalPath:     localPath,
-		LocalPathOut:  m.stateOutPath,
+		LocalPathOut:  localPathOut,
 		RemotePath:    remotePath,
 		RemoteRefresh: true,
 		BackupPath:    m.backupPath,
+		HistoryKeep:   historyKeep,
 	}
 }
 
@@ -274,6 +357,69 @@ func (m *Meta) PersistState(s *terraform.State) error {
 	return m.state.PersistState()
 }
 
+// lockState locks the given state for the given operation, unless locking
+// was disabled with -lock=false or the state can't be locked. The lock is
+// released by unlockState, which callers should defer before calling this.
+//
+// If refresh is true, the state is refreshed once locked, as it may have
+// been changed by someone else since it was read.
+func (m *Meta) lockState(s state.State, operation string, refresh bool) error {
+	if !m.stateLock || m.stateLocker != nil {
+		return nil
+	}
+
+	l, ok := s.(state.Locker)
+	if !ok {
+		return nil
+	}
+
+	info := state.NewLockInfo(operation)
+	id, err := state.LockWithTimeout(l, info, m.stateLockTimeout)
+	if err != nil {
+		return fmt.Errorf(
+			"Error locking state: %s\n\n"+
+				"Terraform locks the state to prevent concurrent operations from\n"+
+				"corrupting it. Use -lock-timeout to wait for the lock to be released.\n"+
+				"If the lock was left behind by an operation that didn't exit cleanly,\n"+
+				"it can be released with the 'terraform force-unlock' command.",
+			err)
+	}
+
+	// States that wrap a state that can't be locked return no ID.
+	if id == "" {
+		return nil
+	}
+
+	m.stateLocker = l
+	m.stateLockID = id
+
+	if refresh {
+		if err := s.RefreshState(); err != nil {
+			return fmt.Errorf("Error refreshing locked state: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// unlockState releases the lock acquired by lockState, if any. Errors are
+// output to the UI, as the operation itself is done by then.
+func (m *Meta) unlockState() {
+	if m.stateLocker == nil {
+		return
+	}
+
+	if err := m.stateLocker.Unlock(m.stateLockID); err != nil {
+		m.Ui.Error(fmt.Sprintf(
+			"Error releasing the state lock: %s\n\n"+
+				"The lock must be released with 'terraform force-unlock %s'.",
+			err, m.stateLockID))
+	}
+
+	m.stateLocker = nil
+	m.stateLockID = ""
+}
+
 // Input returns true if we should ask for input for context.
 func (m *Meta) Input() bool {
 	return !test && m.input && len(m.variables) == 0
@@ -302,7 +448,9 @@ func (m *Meta) contextOpts() *terraform.ContextOpts {
 	}
 	opts.Variables = vs
 	opts.Targets = m.targets
+	opts.RefreshParallelism = m.refreshParallelism
 	opts.UIInput = m.UIInput()
+	opts.Workspace = m.Workspace()
 
 	return &opts
 }
@@ -314,6 +462,8 @@ func (m *Meta) flagSet(n string) *flag.FlagSet {
 	f.Var((*FlagKV)(&m.variables), "var", "variables")
 	f.Var((*FlagKVFile)(&m.variables), "var-file", "variable file")
 	f.Var((*FlagStringSlice)(&m.targets), "target", "resource to target")
+	f.BoolVar(&m.stateLock, "lock", true, "lock state")
+	f.DurationVar(&m.stateLockTimeout, "lock-timeout", 0, "duration")
 
 	if m.autoKey != "" {
 		f.Var((*FlagKVFile)(&m.autoVariables), m.autoKey, "variable file")
@@ -407,8 +557,50 @@ func (m *Meta) process(args []string, vars bool) []string {
 	return args
 }
 
-// uiHook returns the UiHook to use with the context.
-func (m *Meta) uiHook() *UiHook {
+// refreshFlags adds the flags of the commands that refresh the state to
+// the given FlagSet.
+func (m *Meta) refreshFlags(f *flag.FlagSet) {
+	f.IntVar(&m.refreshParallelism, "refresh-parallelism", 0, "parallelism")
+	f.Var((*FlagKVFloat)(&m.refreshRateLimits), "refresh-rate-limit", "rate limit")
+}
+
+// refreshHooks returns the hooks that limit the rate of the refresh of the
+// given state and report its progress.
+func (m *Meta) refreshHooks(s *terraform.State) []terraform.Hook {
+	progressHook := &RefreshProgressHook{
+		Ui:       m.Ui,
+		Interval: periodicUiTimer,
+	}
+
+	// The total is unknown when only some resources are refreshed.
+	if len(m.targets) == 0 {
+		progressHook.Total = refreshTotal(s)
+	}
+
+	hooks := []terraform.Hook{progressHook}
+	if len(m.refreshRateLimits) > 0 {
+		hooks = append(hooks, &RefreshRateHook{Limits: m.refreshRateLimits})
+	}
+
+	return hooks
+}
+
+// streamJSON switches the output of the command to a stream of JSON
+// events, for the -json flag. Input and colors are disabled.
+func (m *Meta) streamJSON() {
+	m.color = false
+	m.input = false
+	m.jsonUi = &JSONUi{Ui: m.oldUi}
+	m.Ui = m.jsonUi
+}
+
+// uiHook returns the hook that reports the progress of the operations to
+// the UI.
+func (m *Meta) uiHook() terraform.Hook {
+	if m.jsonUi != nil {
+		return &JSONHook{Ui: m.jsonUi}
+	}
+
 	return &UiHook{
 		Colorize: m.Colorize(),
 		Ui:       m.Ui,
@@ -453,4 +645,11 @@ type contextOpts struct {
 
 	// Number of concurrent operations allowed
 	Parallelism int
+
+	// LockOperation is the operation recorded in the lock of the state,
+	// e.g. "apply". If empty, the state isn't locked.
+	LockOperation string
+
+	// Replace are the addresses of the resources to replace.
+	Replace []string
 }
@@ -8,7 +8,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform/state/remote"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
 )
 
 func TestMetaColorize(t *testing.T) {
@@ -268,3 +270,47 @@ func TestMeta_addModuleDepthFlag(t *testing.T) {
 		}
 	}
 }
+
+func TestMeta_lockState(t *testing.T) {
+	client := new(remote.InmemClient)
+	s := &remote.State{Client: client}
+
+	m := new(Meta)
+	m.Ui = new(cli.MockUi)
+	fs := m.flagSet("foo")
+	if err := fs.Parse(nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := m.lockState(s, "apply", false); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.LockInfo == nil || client.LockInfo.Operation != "apply" {
+		t.Fatalf("expected the state to be locked for apply, got: %#v", client.LockInfo)
+	}
+
+	// A second command can't lock the state
+	m2 := new(Meta)
+	m2.stateLock = true
+	if err := m2.lockState(s, "plan", false); err == nil {
+		t.Fatal("expected an error locking a locked state")
+	}
+
+	m.unlockState()
+	if client.LockInfo != nil {
+		t.Fatal("expected the state to be unlocked")
+	}
+
+	// Locking can be disabled
+	m = new(Meta)
+	fs = m.flagSet("foo")
+	if err := fs.Parse([]string{"-lock=false"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := m.lockState(s, "apply", false); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.LockInfo != nil {
+		t.Fatal("expected the state not to be locked")
+	}
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"sort"
@@ -19,9 +20,12 @@ func (c *OutputCommand) Run(args []string) int {
 	args = c.Meta.process(args, false)
 
 	var module string
+	var jsonOutput, showSensitive bool
 	cmdFlags := flag.NewFlagSet("output", flag.ContinueOnError)
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.StringVar(&module, "module", "", "module")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
+	cmdFlags.BoolVar(&showSensitive, "show-sensitive", false, "show-sensitive")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 
 	if err := cmdFlags.Parse(args); err != nil {
@@ -47,6 +51,12 @@ func (c *OutputCommand) Run(args []string) int {
 		index = args[1]
 	}
 
+	if jsonOutput && index != "" {
+		c.Ui.Error("The -json flag can't be used with an index.\n")
+		cmdFlags.Usage()
+		return 1
+	}
+
 	stateStore, err := c.Meta.State()
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error reading state: %s", err))
@@ -81,7 +91,11 @@ func (c *OutputCommand) Run(args []string) int {
 	}
 
 	if name == "" {
-		c.Ui.Output(outputsAsString(state, nil, false))
+		if jsonOutput {
+			return c.outputJSON(mod.Outputs)
+		}
+
+		c.Ui.Output(outputValuesAsString(mod.Outputs, nil, false, showSensitive))
 		return 0
 	}
 
@@ -95,6 +109,10 @@ func (c *OutputCommand) Run(args []string) int {
 		return 1
 	}
 
+	if jsonOutput {
+		return c.outputJSON(v)
+	}
+
 	switch output := v.Value.(type) {
 	case string:
 		c.Ui.Output(output)
@@ -122,7 +140,7 @@ func (c *OutputCommand) Run(args []string) int {
 			break
 		}
 
-		c.Ui.Output(fmt.Sprintf("%s", output[indexInt]))
+		c.Ui.Output(formatNestedOutput("", output[indexInt]))
 		return 0
 	case map[string]interface{}:
 		if index == "" {
@@ -131,7 +149,7 @@ func (c *OutputCommand) Run(args []string) int {
 		}
 
 		if value, ok := output[index]; ok {
-			c.Ui.Output(fmt.Sprintf("%s", value))
+			c.Ui.Output(formatNestedOutput("", value))
 			return 0
 		} else {
 			return 1
@@ -144,6 +162,20 @@ func (c *OutputCommand) Run(args []string) int {
 	return 0
 }
 
+// outputJSON prints the given outputs, or output, as JSON. Sensitive values
+// are included, along with whether they are sensitive, since the JSON is
+// meant to be consumed by other programs.
+func (c *OutputCommand) outputJSON(v interface{}) int {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error encoding outputs as JSON: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(string(data))
+	return 0
+}
+
 func formatListOutput(indent, outputName string, outputList []interface{}) string {
 	keyIndent := ""
 
@@ -154,7 +186,8 @@ func formatListOutput(indent, outputName string, outputList []interface{}) strin
 	}
 
 	for _, value := range outputList {
-		outputBuf.WriteString(fmt.Sprintf("\n%s%s%s", indent, keyIndent, value))
+		outputBuf.WriteString(fmt.Sprintf("\n%s%s%s", indent, keyIndent,
+			formatNestedOutput(indent+keyIndent, value)))
 	}
 
 	if outputName != "" {
@@ -181,7 +214,8 @@ func formatMapOutput(indent, outputName string, outputMap map[string]interface{}
 
 	for _, k := range ks {
 		v := outputMap[k]
-		outputBuf.WriteString(fmt.Sprintf("\n%s%s%s = %v", indent, keyIndent, k, v))
+		outputBuf.WriteString(fmt.Sprintf("\n%s%s%s = %s", indent, keyIndent, k,
+			formatNestedOutput(indent+keyIndent, v)))
 	}
 
 	if outputName != "" {
@@ -196,7 +230,8 @@ func (c *OutputCommand) Help() string {
 Usage: terraform output [options] [NAME]
 
   Reads an output variable from a Terraform state file and prints
-  the value.  If NAME is not specified, all outputs are printed.
+  the value.  If NAME is not specified, all outputs are printed, with
+  the values of sensitive outputs masked.
 
 Options:
 
@@ -208,6 +243,13 @@ Options:
   -module=name     If specified, returns the outputs for a
                    specific module
 
+  -json            If specified, the outputs are printed as JSON, along
+                   with their type and whether they are sensitive.
+                   Sensitive values are not masked.
+
+  -show-sensitive  If specified, the values of sensitive outputs are
+                   printed when printing all outputs.
+
 `
 	return strings.TrimSpace(helpText)
 }
@@ -215,3 +257,37 @@ Options:
 func (c *OutputCommand) Synopsis() string {
 	return "Read an output from a state file"
 }
+
+// formatNestedOutput formats an element of a list or map output. Lists and
+// maps nested within outputs are printed like the outputs themselves,
+// indented one level further.
+func formatNestedOutput(indent string, v interface{}) string {
+	switch v := v.(type) {
+	case []interface{}:
+		outputBuf := new(bytes.Buffer)
+		outputBuf.WriteString("[")
+		for _, value := range v {
+			outputBuf.WriteString(fmt.Sprintf("\n%s  %s", indent,
+				formatNestedOutput(indent+"  ", value)))
+		}
+		outputBuf.WriteString(fmt.Sprintf("\n%s]", indent))
+		return outputBuf.String()
+	case map[string]interface{}:
+		ks := make([]string, 0, len(v))
+		for k, _ := range v {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks)
+
+		outputBuf := new(bytes.Buffer)
+		outputBuf.WriteString("{")
+		for _, k := range ks {
+			outputBuf.WriteString(fmt.Sprintf("\n%s  %s = %s", indent, k,
+				formatNestedOutput(indent+"  ", v[k])))
+		}
+		outputBuf.WriteString(fmt.Sprintf("\n%s}", indent))
+		return outputBuf.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
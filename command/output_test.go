@@ -100,6 +100,55 @@ func TestModuleOutput(t *testing.T) {
 	}
 }
 
+func TestModuleOutput_all(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Value: "bar",
+						Type:  "string",
+					},
+				},
+			},
+			&terraform.ModuleState{
+				Path: []string{"root", "my_module"},
+				Outputs: map[string]*terraform.OutputState{
+					"blah": &terraform.OutputState{
+						Value: "tastatur",
+						Type:  "string",
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-module", "my_module",
+	}
+
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	actual := strings.TrimSpace(ui.OutputWriter.String())
+	if actual != "blah = tastatur" {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestMissingModuleOutput(t *testing.T) {
 	originalState := &terraform.State{
 		Modules: []*terraform.ModuleState{
@@ -215,6 +264,70 @@ func TestOutput_blank(t *testing.T) {
 	}
 }
 
+func TestOutput_nested(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"vifs": &terraform.OutputState{
+						Value: []interface{}{
+							map[string]interface{}{"asn": "65000", "vlan": "100"},
+							map[string]interface{}{"asn": "65001", "vlan": "200"},
+						},
+						Type: "list",
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"vifs",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	expected := "{\n  asn = 65000\n  vlan = 100\n}\n{\n  asn = 65001\n  vlan = 200\n}"
+	actual := strings.TrimSpace(ui.OutputWriter.String())
+	if actual != expected {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	ui = new(cli.MockUi)
+	c = &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+	args = []string{
+		"-state", statePath,
+		"vifs", "1",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	expected = "{\n  asn = 65001\n  vlan = 200\n}"
+	actual = strings.TrimSpace(ui.OutputWriter.String())
+	if actual != expected {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestOutput_manyArgs(t *testing.T) {
 	ui := new(cli.MockUi)
 	c := &OutputCommand{
@@ -361,3 +474,111 @@ func TestOutput_stateDefault(t *testing.T) {
 		t.Fatalf("bad: %#v", actual)
 	}
 }
+
+func TestOutput_sensitive(t *testing.T) {
+	statePath := testStateFile(t, testOutputSensitiveState())
+
+	cases := []struct {
+		Args     []string
+		Expected string
+	}{
+		{
+			nil,
+			"foo = bar\npassword = <sensitive>",
+		},
+		{
+			[]string{"-show-sensitive"},
+			"foo = bar\npassword = hunter2",
+		},
+		{
+			// Outputs asked for by name are shown
+			[]string{"password"},
+			"hunter2",
+		},
+		{
+			[]string{"-json", "password"},
+			`{
+  "sensitive": true,
+  "type": "string",
+  "value": "hunter2"
+}`,
+		},
+		{
+			[]string{"-json"},
+			`{
+  "foo": {
+    "sensitive": false,
+    "type": "string",
+    "value": "bar"
+  },
+  "password": {
+    "sensitive": true,
+    "type": "string",
+    "value": "hunter2"
+  }
+}`,
+		},
+	}
+
+	for i, tc := range cases {
+		ui := new(cli.MockUi)
+		c := &OutputCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		args := append([]string{"-state", statePath}, tc.Args...)
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("%d: bad: \n%s", i, ui.ErrorWriter.String())
+		}
+
+		actual := strings.TrimSpace(ui.OutputWriter.String())
+		if actual != tc.Expected {
+			t.Fatalf("%d: bad: %s", i, actual)
+		}
+	}
+}
+
+func TestOutput_jsonIndex(t *testing.T) {
+	statePath := testStateFile(t, testOutputSensitiveState())
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-json",
+		"foo", "0",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+}
+
+func testOutputSensitiveState() *terraform.State {
+	return &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Value: "bar",
+						Type:  "string",
+					},
+					"password": &terraform.OutputState{
+						Sensitive: true,
+						Value:     "hunter2",
+						Type:      "string",
+					},
+				},
+			},
+		},
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
 )
 
 // PlanCommand is a Command implementation that compares a Terraform
@@ -16,22 +17,27 @@ type PlanCommand struct {
 }
 
 func (c *PlanCommand) Run(args []string) int {
-	var destroy, refresh, detailed bool
+	var destroy, refresh, refreshOnly, detailed, jsonOutput bool
 	var outPath string
 	var moduleDepth int
+	var replace []string
 
 	args = c.Meta.process(args, true)
 
 	cmdFlags := c.Meta.flagSet("plan")
 	cmdFlags.BoolVar(&destroy, "destroy", false, "destroy")
 	cmdFlags.BoolVar(&refresh, "refresh", true, "refresh")
+	cmdFlags.BoolVar(&refreshOnly, "refresh-only", false, "refresh-only")
+	cmdFlags.Var((*FlagStringSlice)(&replace), "replace", "resource")
 	c.addModuleDepthFlag(cmdFlags, &moduleDepth)
 	cmdFlags.StringVar(&outPath, "out", "", "path")
 	cmdFlags.IntVar(
 		&c.Meta.parallelism, "parallelism", DefaultParallelism, "parallelism")
+	c.Meta.refreshFlags(cmdFlags)
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
 	cmdFlags.BoolVar(&detailed, "detailed-exitcode", false, "detailed-exitcode")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -52,17 +58,51 @@ func (c *PlanCommand) Run(args []string) int {
 		path, err = os.Getwd()
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+			return 1
 		}
 	}
 
+	if refreshOnly && (!refresh || destroy || outPath != "") {
+		c.Ui.Error(
+			"The -refresh-only flag can't be used with -refresh=false, -destroy\n" +
+				"or -out.\n")
+		cmdFlags.Usage()
+		return 1
+	}
+
+	if jsonOutput && !refreshOnly {
+		c.Ui.Error(
+			"The -json flag can only be used with -refresh-only. To inspect a plan\n" +
+				"as JSON, save it with -out and use \"terraform show -json\".\n")
+		cmdFlags.Usage()
+		return 1
+	}
+
+	if len(replace) > 0 && (destroy || refreshOnly) {
+		c.Ui.Error("The -replace flag can't be used with -destroy or -refresh-only.\n")
+		cmdFlags.Usage()
+		return 1
+	}
+
+	// The JSON must be the only output, so only errors and warnings are
+	// shown while refreshing.
+	ui := c.Ui
+	if jsonOutput {
+		c.Ui = &QuietUi{Ui: ui}
+		defer func() { c.Ui = ui }()
+	}
+
 	countHook := new(CountHook)
 	c.Meta.extraHooks = []terraform.Hook{countHook}
 
+	defer c.Meta.unlockState()
 	ctx, _, err := c.Context(contextOpts{
-		Destroy:     destroy,
-		Path:        path,
-		StatePath:   c.Meta.statePath,
-		Parallelism: c.Meta.parallelism,
+		Destroy:       destroy,
+		Path:          path,
+		StatePath:     c.Meta.statePath,
+		Parallelism:   c.Meta.parallelism,
+		LockOperation: "plan",
+		Replace:       replace,
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
@@ -79,6 +119,18 @@ func (c *PlanCommand) Run(args []string) int {
 	}
 
 	if refresh {
+		// Keep the state from before the refresh, so that a refresh-only
+		// plan can show what the refresh changed.
+		var before *terraform.State
+		if refreshOnly {
+			s, err := c.State()
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+				return 1
+			}
+			before = s.State().DeepCopy()
+		}
+
 		c.Ui.Output("Refreshing Terraform state prior to plan...\n")
 		state, err := ctx.Refresh()
 		if err != nil {
@@ -94,6 +146,13 @@ func (c *PlanCommand) Run(args []string) int {
 				return 1
 			}
 		}
+
+		if refreshOnly {
+			if jsonOutput {
+				return c.outputRefreshOnlyJSON(ui, before, state, detailed)
+			}
+			return c.outputRefreshOnly(before, state, detailed)
+		}
 	}
 
 	plan, err := ctx.Plan()
@@ -145,12 +204,81 @@ func (c *PlanCommand) Run(args []string) int {
 		countHook.ToChange,
 		countHook.ToRemove+countHook.ToRemoveAndAdd)))
 
+	if counts := FormatPlanCounts(PlanCountsByType(plan)); counts != "" {
+		c.Ui.Output("\n" + counts)
+	}
+
+	c.outputPlanHooks(plan)
+
+	if detailed {
+		return 2
+	}
+	return 0
+}
+
+// outputPlanHooks shows the annotations that the plan hooks return for the
+// plan. A failing hook only causes a warning, since the plan itself is fine.
+func (c *PlanCommand) outputPlanHooks(plan *terraform.Plan) {
+	for _, h := range c.Meta.PlanHooks {
+		annotations, err := h.PostPlan(plan)
+		if err != nil {
+			c.Ui.Warn(fmt.Sprintf("Error running plan hook: %s", err))
+			continue
+		}
+
+		if len(annotations) > 0 {
+			c.Ui.Output("\n" + strings.Join(annotations, "\n"))
+		}
+	}
+}
+
+// outputRefreshOnly shows the changes that the refresh of a refresh-only
+// plan made to the state, and returns the exit code of the command.
+func (c *PlanCommand) outputRefreshOnly(before, after *terraform.State, detailed bool) int {
+	out := FormatRefresh(&FormatRefreshOpts{
+		Before: before,
+		After:  after,
+		Color:  c.Colorize(),
+	})
+	if out == "" {
+		c.Ui.Output(
+			"No changes. The refresh didn't detect any differences between the\n" +
+				"state and the real physical resources.")
+		return 0
+	}
+
+	c.Ui.Output(strings.TrimSpace(planHeaderRefreshOnly) + "\n")
+	c.Ui.Output(out)
+
 	if detailed {
 		return 2
 	}
 	return 0
 }
 
+// outputRefreshOnlyJSON prints the changes that the refresh of a
+// refresh-only plan made to the state as JSON, and returns the exit code of
+// the command.
+func (c *PlanCommand) outputRefreshOnlyJSON(
+	ui cli.Ui, before, after *terraform.State, detailed bool) int {
+	opts := &FormatRefreshOpts{
+		Before: before,
+		After:  after,
+	}
+	out, err := FormatRefreshJSON(opts)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	ui.Output(out)
+
+	if detailed && len(refreshChanges(before, after)) > 0 {
+		return 2
+	}
+	return 0
+}
+
 func (c *PlanCommand) Help() string {
 	helpText := `
 Usage: terraform plan [options] [dir]
@@ -179,6 +307,13 @@ Options:
 
   -input=true         Ask for input for variables if not directly set.
 
+  -json               Print the changes of a -refresh-only plan as JSON, for
+                      use by other programs such as drift alerting.
+
+  -lock=true          Lock the state file when locking is supported.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
   -module-depth=n     Specifies the depth of modules to show in the output.
                       This does not affect the plan itself, only the output
                       shown. By default, this is -1, which will expand all.
@@ -192,6 +327,23 @@ Options:
 
   -refresh=true       Update state prior to checking for differences.
 
+  -refresh-only       Only refresh the state, and show the changes the refresh
+                      made to it instead of computing the execution plan.
+                      Combine with -target to refresh only some resources.
+
+  -refresh-parallelism=n
+                      Limit the number of concurrent operations while
+                      refreshing. Defaults to the -parallelism value.
+
+  -refresh-rate-limit=provider=n
+                      Refresh at most n resources of the provider per
+                      second, e.g. "aws=20". This flag can be set multiple
+                      times.
+
+  -replace=resource   Resource to replace. The plan destroys and recreates
+                      this resource as if it was tainted, without marking
+                      it in the state. This flag can be used multiple times.
+
   -state=statefile    Path to a Terraform state file to use to look
                       up Terraform-managed resources. By default it will
                       use the state "terraform.tfstate" if it exists.
@@ -238,3 +390,9 @@ plan.
 
 Path: %s
 `
+
+const planHeaderRefreshOnly = `
+The state has been refreshed, and the changes the refresh made to it are
+shown below. Yellow resources were changed outside of Terraform, and red
+resources no longer exist. No execution plan was generated.
+`
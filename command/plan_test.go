@@ -2,9 +2,12 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -458,8 +461,9 @@ func TestPlan_varsUnset(t *testing.T) {
 	ui := new(cli.MockUi)
 	c := &PlanCommand{
 		Meta: Meta{
-			ContextOpts: testCtxConfig(p),
-			Ui:          ui,
+			ContextOpts:  testCtxConfig(p),
+			Ui:           ui,
+			StateHistory: -1,
 		},
 	}
 
@@ -758,6 +762,40 @@ func TestPlan_detailedExitcode_emptyDiff(t *testing.T) {
 	}
 }
 
+func TestPlan_detailedExitcode_error(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := os.Chdir(testFixturePath("plan")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Chdir(cwd)
+
+	p := testProvider()
+	p.DiffFn = func(
+		*terraform.InstanceInfo,
+		*terraform.InstanceState,
+		*terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
+		return nil, fmt.Errorf("diff error")
+	}
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{"-detailed-exitcode"}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "diff error") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+}
+
 const planVarFile = `
 foo = "bar"
 `
@@ -781,3 +819,376 @@ ID = bar
 const testPlanStateDefaultStr = `
 ID = bar
 `
+
+func TestPlan_refreshOnly(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	p.RefreshFn = nil
+	p.RefreshReturn = &terraform.InstanceState{ID: "yes"}
+
+	args := []string{
+		"-refresh-only",
+		"-detailed-exitcode",
+		"-state", statePath,
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 2 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if !p.RefreshCalled {
+		t.Fatal("refresh should be called")
+	}
+	if p.DiffCalled {
+		t.Fatal("diff should not be called")
+	}
+
+	output := ui.OutputWriter.String()
+	for _, expected := range []string{"~ test_instance.foo", `id: "bar" => "yes"`} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected output to contain %q:\n\n%s", expected, output)
+		}
+	}
+}
+
+func TestPlan_refreshOnlyNoChanges(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	p.RefreshFn = nil
+	p.RefreshReturn = &terraform.InstanceState{ID: "bar"}
+
+	args := []string{
+		"-refresh-only",
+		"-detailed-exitcode",
+		"-state", statePath,
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if p.DiffCalled {
+		t.Fatal("diff should not be called")
+	}
+}
+
+func TestPlan_refreshOnlyJSON(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	p.RefreshFn = nil
+	p.RefreshReturn = &terraform.InstanceState{ID: "yes"}
+
+	args := []string{
+		"-refresh-only",
+		"-json",
+		"-detailed-exitcode",
+		"-state", statePath,
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 2 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	// The output must be nothing but the JSON
+	var result struct {
+		FormatVersion string `json:"format_version"`
+		ResourceDrift []struct {
+			Address string            `json:"address"`
+			Action  string            `json:"action"`
+			Changed []string          `json:"changed_attributes"`
+			Before  map[string]string `json:"before"`
+			After   map[string]string `json:"after"`
+		} `json:"resource_drift"`
+	}
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &result); err != nil {
+		t.Fatalf("err: %s\n\n%s", err, ui.OutputWriter.String())
+	}
+
+	if len(result.ResourceDrift) != 1 {
+		t.Fatalf("bad: %#v", result)
+	}
+	drift := result.ResourceDrift[0]
+	if drift.Address != "test_instance.foo" || drift.Action != "update" {
+		t.Fatalf("bad: %#v", drift)
+	}
+	if !reflect.DeepEqual(drift.Changed, []string{"id"}) {
+		t.Fatalf("bad: %#v", drift.Changed)
+	}
+	if drift.Before["id"] != "bar" || drift.After["id"] != "yes" {
+		t.Fatalf("bad: %#v", drift)
+	}
+}
+
+func TestPlan_refreshOnlyJSONDeleted(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	p.RefreshFn = nil
+	p.RefreshReturn = nil
+
+	args := []string{
+		"-refresh-only",
+		"-json",
+		"-state", statePath,
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &result); err != nil {
+		t.Fatalf("err: %s\n\n%s", err, ui.OutputWriter.String())
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"address": "test_instance.foo",
+			"action":  "delete",
+			"before":  map[string]interface{}{"id": "bar"},
+			"after":   nil,
+		},
+	}
+	if !reflect.DeepEqual(result["resource_drift"], expected) {
+		t.Fatalf("bad: %#v", result["resource_drift"])
+	}
+}
+
+func TestPlan_refreshOnlyInvalidFlags(t *testing.T) {
+	cases := [][]string{
+		[]string{"-refresh-only", "-refresh=false"},
+		[]string{"-refresh-only", "-destroy"},
+		[]string{"-refresh-only", "-out", "foo.tfplan"},
+		[]string{"-json"},
+	}
+
+	for _, args := range cases {
+		ui := new(cli.MockUi)
+		c := &PlanCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		args = append(args, testFixturePath("refresh"))
+		if code := c.Run(args); code != 1 {
+			t.Fatalf("%v: bad: %d", args, code)
+		}
+	}
+}
+
+func TestPlan_replace(t *testing.T) {
+	originalState := testState()
+	statePath := testStateFile(t, originalState)
+	outPath := testTempFile(t)
+
+	p := testProvider()
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-replace", "test_instance.foo",
+		"-out", outPath,
+		"-state", statePath,
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "-/+ test_instance.foo (tainted)") {
+		t.Fatalf("bad:\n\n%s", output)
+	}
+
+	plan := testReadPlan(t, outPath)
+	rs := plan.State.RootModule().Resources["test_instance.foo"]
+	if rs.Primary != nil || len(rs.Tainted) != 1 {
+		t.Fatalf("bad: %#v", rs)
+	}
+
+	// The state isn't tainted
+	testStateOutput(t, statePath, originalState.String())
+}
+
+func TestPlan_replaceMissing(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-replace", "test_instance.bar",
+		"-state", statePath,
+		testFixturePath("refresh"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	if !strings.Contains(ui.ErrorWriter.String(), "isn't in the state") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestPlan_replaceInvalidFlags(t *testing.T) {
+	cases := [][]string{
+		[]string{"-replace", "test_instance.foo", "-destroy"},
+		[]string{"-replace", "test_instance.foo", "-refresh-only"},
+	}
+
+	for _, args := range cases {
+		ui := new(cli.MockUi)
+		c := &PlanCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		args = append(args, testFixturePath("refresh"))
+		if code := c.Run(args); code != 1 {
+			t.Fatalf("%v: bad: %d", args, code)
+		}
+	}
+}
+
+func TestPlan_providerVersion(t *testing.T) {
+	plugins, _ := testProviderPlugins("1.0.0")
+
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts:     testCtxConfig(testProvider()),
+			Ui:              ui,
+			ProviderPlugins: plugins,
+		},
+	}
+
+	args := []string{
+		"-state", testTempFile(t),
+		testFixturePath("plan-provider-version"),
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	if !strings.Contains(ui.ErrorWriter.String(), "no plugin matches version constraint") {
+		t.Fatalf("bad: %s", ui.ErrorWriter.String())
+	}
+}
+
+func TestPlan_planHooks(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := os.Chdir(testFixturePath("plan")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Chdir(cwd)
+
+	failing := &testPlanHook{Err: fmt.Errorf("cost API unavailable")}
+	hook := &testPlanHook{Annotations: []string{"Estimated cost: $1.00/hr"}}
+
+	p := testProvider()
+	p.DiffReturn = &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"ami": &terraform.ResourceAttrDiff{
+				New:         "bar",
+				RequiresNew: true,
+			},
+		},
+	}
+	ui := new(cli.MockUi)
+	c := &PlanCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+			PlanHooks:   []PlanHook{failing, hook},
+		},
+	}
+
+	args := []string{}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if !failing.Called || !hook.Called {
+		t.Fatal("plan hooks should be called")
+	}
+	if hook.Plan == nil || hook.Plan.Diff.Empty() {
+		t.Fatalf("bad: %#v", hook.Plan)
+	}
+
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "test_instance  1 to add, 0 to change, 0 to destroy") {
+		t.Fatalf("bad:\n\n%s", output)
+	}
+	if !strings.Contains(output, "Estimated cost: $1.00/hr") {
+		t.Fatalf("bad:\n\n%s", output)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "cost API unavailable") {
+		t.Fatalf("bad:\n\n%s", ui.ErrorWriter.String())
+	}
+}
+
+// testPlanHook is a PlanHook that records the plan it is called with.
+type testPlanHook struct {
+	Called      bool
+	Plan        *terraform.Plan
+	Annotations []string
+	Err         error
+}
+
+func (h *testPlanHook) PostPlan(p *terraform.Plan) ([]string, error) {
+	h.Called = true
+	h.Plan = p
+	return h.Annotations, h.Err
+}
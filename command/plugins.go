@@ -0,0 +1,153 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform/config/module"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// ProviderPlugin is a provider plugin available to Terraform, either as a
+// binary found on disk or compiled into Terraform.
+type ProviderPlugin struct {
+	// Version is the version of the plugin. It is nil for plugin binaries
+	// that don't have a version in their file name, which can't be used
+	// with version constraints.
+	Version *version.Version
+
+	// Path is the path to the plugin binary, or the command running an
+	// internal plugin.
+	Path string
+
+	Factory terraform.ResourceProviderFactory
+}
+
+// ProviderPlugins are the provider plugins available to Terraform, by
+// provider name.
+type ProviderPlugins map[string][]*ProviderPlugin
+
+// Newest returns the plugin with the highest version of the given provider
+// that matches the given constraints, or nil if there is none.
+func (p ProviderPlugins) Newest(name string, cs version.Constraints) *ProviderPlugin {
+	var result *ProviderPlugin
+	for _, plugin := range p[name] {
+		if plugin.Version == nil || !cs.Check(plugin.Version) {
+			continue
+		}
+
+		if result == nil || plugin.Version.GreaterThan(result.Version) {
+			result = plugin
+		}
+	}
+
+	return result
+}
+
+// providerVersionConstraints returns the version constraints set on the
+// providers in the given module tree, by provider name. A provider may be
+// configured with several constraints across modules and aliases, all of
+// which must be met.
+func providerVersionConstraints(mod *module.Tree) map[string][]string {
+	result := make(map[string][]string)
+
+	var walk func(*module.Tree)
+	walk = func(t *module.Tree) {
+		if c := t.Config(); c != nil {
+			for _, pc := range c.ProviderConfigs {
+				if pc.Version != "" {
+					result[pc.Name] = append(result[pc.Name], pc.Version)
+				}
+			}
+		}
+
+		for _, child := range t.Children() {
+			walk(child)
+		}
+	}
+	walk(mod)
+
+	return result
+}
+
+// selectProviders replaces the providers in opts with the plugins that
+// match the version constraints set in the given module tree. It fails
+// if there is no matching plugin for a provider, rather than use whichever
+// plugin was found first.
+func (m *Meta) selectProviders(opts *terraform.ContextOpts, mod *module.Tree) error {
+	if mod == nil {
+		return nil
+	}
+
+	constraints := providerVersionConstraints(mod)
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	providers := make(map[string]terraform.ResourceProviderFactory)
+	for k, v := range opts.Providers {
+		providers[k] = v
+	}
+
+	names := make([]string, 0, len(constraints))
+	for name, _ := range constraints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []string
+	for _, name := range names {
+		raw := strings.Join(constraints[name], ", ")
+		cs, err := version.NewConstraint(raw)
+		if err != nil {
+			return fmt.Errorf(
+				"provider.%s: invalid version constraint %q: %s", name, raw, err)
+		}
+
+		plugin := m.ProviderPlugins.Newest(name, cs)
+		if plugin == nil {
+			errs = append(errs, m.providerVersionError(name, raw))
+			continue
+		}
+
+		providers[name] = plugin.Factory
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s\n\n%s", strings.Join(errs, "\n\n"), providerVersionHelp)
+	}
+
+	opts.Providers = providers
+	return nil
+}
+
+// providerVersionError returns the message for a provider that has no
+// plugin matching its version constraints, listing the plugins found.
+func (m *Meta) providerVersionError(name, constraint string) string {
+	plugins := m.ProviderPlugins[name]
+	if len(plugins) == 0 {
+		return fmt.Sprintf(
+			"provider.%s: no plugin found for version constraint %q.", name, constraint)
+	}
+
+	found := make([]string, 0, len(plugins))
+	for _, p := range plugins {
+		v := "unknown version"
+		if p.Version != nil {
+			v = p.Version.String()
+		}
+		found = append(found, fmt.Sprintf("  %s (%s)", v, p.Path))
+	}
+
+	return fmt.Sprintf(
+		"provider.%s: no plugin matches version constraint %q. Found:\n\n%s",
+		name, constraint, strings.Join(found, "\n"))
+}
+
+const providerVersionHelp = `Provider plugins used with a version constraint must have their version
+in their file name, as in "terraform-provider-NAME_vX.Y.Z". Plugins compiled
+into Terraform have the version of Terraform. Install a matching plugin in
+the directory of the Terraform binary, in the plugins directory of the
+Terraform configuration directory, or in the current directory.`
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testProviderPlugins(versions ...string) (ProviderPlugins, map[string]terraform.ResourceProvider) {
+	plugins := make(ProviderPlugins)
+	providers := make(map[string]terraform.ResourceProvider)
+	for _, v := range versions {
+		p := testProvider()
+		providers[v] = p
+		plugins["test"] = append(plugins["test"], &ProviderPlugin{
+			Version: version.Must(version.NewVersion(v)),
+			Path:    "terraform-provider-test_v" + v,
+			Factory: func() (terraform.ResourceProvider, error) {
+				return p, nil
+			},
+		})
+	}
+
+	return plugins, providers
+}
+
+func TestMetaSelectProviders(t *testing.T) {
+	plugins, providers := testProviderPlugins("1.1.0", "1.2.0", "1.2.5", "1.3.0", "2.0.0")
+	m := &Meta{ProviderPlugins: plugins}
+
+	opts := testCtxConfig(testProvider())
+	if err := m.selectProviders(opts, testModule(t, "provider-version")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p, err := opts.Providers["test"]()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if p != providers["1.2.5"] {
+		t.Fatalf("expected the provider v1.2.5")
+	}
+}
+
+func TestMetaSelectProviders_noConstraint(t *testing.T) {
+	plugins, _ := testProviderPlugins("1.2.0")
+	m := &Meta{ProviderPlugins: plugins}
+
+	provider := testProvider()
+	opts := testCtxConfig(provider)
+	if err := m.selectProviders(opts, testModule(t, "apply")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p, err := opts.Providers["test"]()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if p != provider {
+		t.Fatalf("the provider should not have been replaced")
+	}
+}
+
+func TestMetaSelectProviders_noMatch(t *testing.T) {
+	plugins, _ := testProviderPlugins("1.1.0", "2.0.0")
+	m := &Meta{ProviderPlugins: plugins}
+
+	opts := testCtxConfig(testProvider())
+	err := m.selectProviders(opts, testModule(t, "provider-version"))
+	if err == nil {
+		t.Fatal("should error")
+	}
+
+	for _, s := range []string{`"~> 1.2, < 1.3.0"`, "1.1.0", "2.0.0"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expected %q in error: %s", s, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+// ProvidersSchemaCommand is a Command implementation that outputs the
+// schemas of the available providers, for tooling.
+type ProvidersSchemaCommand struct {
+	Meta
+}
+
+// providerSchemas is the JSON output of ProvidersSchemaCommand.
+type providerSchemas struct {
+	ProviderSchemas map[string]*terraform.ProviderSchema `json:"provider_schemas"`
+}
+
+func (c *ProvidersSchemaCommand) Run(args []string) int {
+	var jsonOutput bool
+
+	args = c.Meta.process(args, false)
+
+	cmdFlags := flag.NewFlagSet("providers schema", flag.ContinueOnError)
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return cli.RunResultHelp
+	}
+	args = cmdFlags.Args()
+
+	// JSON is the only format for now, but require it to be asked for so
+	// that a human-readable format can be the default later.
+	if !jsonOutput {
+		c.Ui.Error("The -json flag is required.\n")
+		return cli.RunResultHelp
+	}
+
+	names := args
+	if len(names) == 0 {
+		for name, _ := range c.ContextOpts.Providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	result := providerSchemas{
+		ProviderSchemas: make(map[string]*terraform.ProviderSchema, len(names)),
+	}
+	for _, name := range names {
+		schema, err := c.providerSchema(name)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("provider.%s: %s", name, err))
+			return 1
+		}
+
+		result.ProviderSchemas[name] = schema
+	}
+
+	data, err := json.MarshalIndent(&result, "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error encoding the schemas: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(string(data))
+	return 0
+}
+
+// providerSchema returns the schema of the provider with the given name.
+func (c *ProvidersSchemaCommand) providerSchema(name string) (*terraform.ProviderSchema, error) {
+	factory, ok := c.ContextOpts.Providers[name]
+	if !ok {
+		return nil, fmt.Errorf("provider not found")
+	}
+
+	provider, err := factory()
+	if err != nil {
+		return nil, fmt.Errorf("Error loading provider: %s", err)
+	}
+	if closer, ok := provider.(terraform.ResourceProviderCloser); ok {
+		defer closer.Close()
+	}
+
+	schema, err := provider.GetSchema()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Error getting schema: %s\n\n"+
+				"The provider may be too old to export its schema.", err)
+	}
+
+	return schema, nil
+}
+
+func (c *ProvidersSchemaCommand) Help() string {
+	helpText := `
+Usage: terraform providers schema -json [name...]
+
+  Outputs the schemas of the available providers, and of their resources
+  and data sources, as JSON.
+
+  This is meant for tooling, such as editors and linters validating
+  Terraform configurations without running Terraform. If names are given,
+  only the schemas of these providers are output.
+
+Options:
+
+  -json               Output the schemas as JSON. This is required.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ProvidersSchemaCommand) Synopsis() string {
+	return "Output the schemas of the providers"
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestProvidersSchema(t *testing.T) {
+	p := testProvider()
+	p.GetSchemaReturn = &terraform.ProviderSchema{
+		Resources: map[string]*terraform.ResourceSchema{
+			"test_instance": &terraform.ResourceSchema{
+				Attributes: map[string]*terraform.AttributeSchema{
+					"ami": &terraform.AttributeSchema{
+						Type:     "string",
+						Required: true,
+						ForceNew: true,
+					},
+				},
+			},
+		},
+	}
+
+	ui := new(cli.MockUi)
+	c := &ProvidersSchemaCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{"-json"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var actual providerSchemas
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &actual); err != nil {
+		t.Fatalf("err: %s\n\n%s", err, ui.OutputWriter.String())
+	}
+
+	expected := providerSchemas{
+		ProviderSchemas: map[string]*terraform.ProviderSchema{
+			"test": p.GetSchemaReturn,
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad:\n\n%s", ui.OutputWriter.String())
+	}
+}
+
+func TestProvidersSchema_noJSON(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &ProvidersSchemaCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run(nil); code != cli.RunResultHelp {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
+
+func TestProvidersSchema_notFound(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &ProvidersSchemaCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{"-json", "nope"}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
+
+func TestProvidersSchema_error(t *testing.T) {
+	p := testProvider()
+	p.GetSchemaReturnError = errors.New("unsupported")
+
+	ui := new(cli.MockUi)
+	c := &ProvidersSchemaCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{"-json"}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
@@ -19,6 +19,7 @@ func (c *RefreshCommand) Run(args []string) int {
 	cmdFlags := c.Meta.flagSet("refresh")
 	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
 	cmdFlags.IntVar(&c.Meta.parallelism, "parallelism", 0, "parallelism")
+	c.Meta.refreshFlags(cmdFlags)
 	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
@@ -78,10 +79,12 @@ func (c *RefreshCommand) Run(args []string) int {
 	}
 
 	// Build the context based on the arguments given
+	defer c.Meta.unlockState()
 	ctx, _, err := c.Context(contextOpts{
-		Path:        configPath,
-		StatePath:   c.Meta.statePath,
-		Parallelism: c.Meta.parallelism,
+		Path:          configPath,
+		StatePath:     c.Meta.statePath,
+		Parallelism:   c.Meta.parallelism,
+		LockOperation: "refresh",
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
@@ -135,8 +138,24 @@ Options:
 
   -input=true         Ask for input for variables if not directly set.
 
+  -lock=true          Lock the state file when locking is supported.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
   -no-color           If specified, output won't contain any color.
 
+  -parallelism=n      Limit the number of concurrent operations.
+                      Defaults to 10.
+
+  -refresh-parallelism=n
+                      Limit the number of concurrent operations while
+                      refreshing. Defaults to the -parallelism value.
+
+  -refresh-rate-limit=provider=n
+                      Refresh at most n resources of the provider per
+                      second, e.g. "aws=20". This flag can be set multiple
+                      times.
+
   -state=path         Path to read and save state (unless state-out
                       is specified). Defaults to "terraform.tfstate".
 
@@ -7,8 +7,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/hashicorp/terraform/backend"
 	"github.com/hashicorp/terraform/state"
-	"github.com/hashicorp/terraform/state/remote"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -48,8 +48,10 @@ func (c *RemoteConfigCommand) Run(args []string) int {
 	// Lowercase the type
 	c.remoteConf.Type = strings.ToLower(c.remoteConf.Type)
 
-	// Set the local state path
+	// Set the local state path, which depends on the workspace if it's
+	// the default path
 	c.statePath = c.conf.statePath
+	c.conf.statePath = c.StateOpts().LocalPath
 
 	// Populate the various configurations
 	c.remoteConf.Config = config
@@ -207,7 +209,7 @@ func (c *RemoteConfigCommand) disableRemoteState() int {
 // we have is valid
 func (c *RemoteConfigCommand) validateRemoteConfig() error {
 	conf := c.remoteConf
-	_, err := remote.NewClient(conf.Type, conf.Config)
+	_, err := backend.Init(conf.Type, conf.Config)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf(
 			"%s\n\n"+
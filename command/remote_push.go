@@ -38,6 +38,9 @@ func (c *RemotePushCommand) Run(args []string) int {
 
 	// We need the CacheState structure in order to do anything
 	var cache *state.CacheState
+	if hs, ok := s.(*state.HistoryState); ok {
+		s = hs.Real
+	}
 	if bs, ok := s.(*state.BackupState); ok {
 		if cs, ok := bs.Real.(*state.CacheState); ok {
 			cache = cs
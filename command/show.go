@@ -17,11 +17,13 @@ type ShowCommand struct {
 
 func (c *ShowCommand) Run(args []string) int {
 	var moduleDepth int
+	var jsonOutput bool
 
 	args = c.Meta.process(args, false)
 
 	cmdFlags := flag.NewFlagSet("show", flag.ContinueOnError)
 	c.addModuleDepthFlag(cmdFlags, &moduleDepth)
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -36,6 +38,13 @@ func (c *ShowCommand) Run(args []string) int {
 		return 1
 	}
 
+	if jsonOutput && len(args) == 0 {
+		c.Ui.Error(
+			"The -json flag requires the path to a Terraform plan file.\n")
+		cmdFlags.Usage()
+		return 1
+	}
+
 	var planErr, stateErr error
 	var path string
 	var plan *terraform.Plan
@@ -60,7 +69,7 @@ func (c *ShowCommand) Run(args []string) int {
 			planErr = err
 		}
 		if plan == nil {
-			state, err = terraform.ReadState(f)
+			state, err = readStateFile(f)
 			if err != nil {
 				stateErr = err
 			}
@@ -91,6 +100,23 @@ func (c *ShowCommand) Run(args []string) int {
 		return 1
 	}
 
+	if jsonOutput {
+		if plan == nil {
+			c.Ui.Error(fmt.Sprintf(
+				"The -json flag only supports plan files, but %s is a state file.", path))
+			return 1
+		}
+
+		out, err := FormatPlanJSON(plan)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
 	if plan != nil {
 		c.Ui.Output(FormatPlan(&FormatPlanOpts{
 			Plan:        plan,
@@ -117,6 +143,12 @@ Usage: terraform show [options] [path]
 
 Options:
 
+  -json               If specified, output the plan file at the given path
+                      as JSON, for consumption by other tools. The resource
+                      changes of the plan are listed with their action
+                      (create, read, update, replace or destroy) and their
+                      attributes before and after the change.
+
   -module-depth=n     Specifies the depth of modules to show in the output.
                       By default this is -1, which will expand all.
 
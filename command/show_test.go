@@ -1,9 +1,11 @@
 package command
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -183,3 +185,169 @@ func TestShow_state(t *testing.T) {
 		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
 	}
 }
+
+func TestShow_planJSON(t *testing.T) {
+	planPath := testPlanFile(t, &terraform.Plan{
+		Module: new(module.Tree),
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"test_instance.foo": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": &terraform.ResourceAttrDiff{
+									Old:         "bar",
+									New:         "baz",
+									RequiresNew: true,
+								},
+								"id": &terraform.ResourceAttrDiff{
+									Old:         "foo",
+									NewComputed: true,
+								},
+							},
+							Destroy: true,
+						},
+						"test_instance.bar": &terraform.InstanceDiff{
+							Destroy: true,
+						},
+					},
+				},
+			},
+		},
+		State: &terraform.State{
+			Modules: []*terraform.ModuleState{
+				&terraform.ModuleState{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.ResourceState{
+						"test_instance.foo": &terraform.ResourceState{
+							Type: "test_instance",
+							Primary: &terraform.InstanceState{
+								ID: "foo",
+								Attributes: map[string]string{
+									"ami": "bar",
+									"id":  "foo",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	ui := new(cli.MockUi)
+	c := &ShowCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-json",
+		planPath,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	var actual map[string]interface{}
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &actual); err != nil {
+		t.Fatalf("err: %s\n\n%s", err, ui.OutputWriter.String())
+	}
+
+	expected := map[string]interface{}{
+		"format_version": "1",
+		"resource_changes": []interface{}{
+			map[string]interface{}{
+				"address": "test_instance.bar",
+				"action":  "destroy",
+				"before":  nil,
+				"after":   nil,
+			},
+			map[string]interface{}{
+				"address": "test_instance.foo",
+				"action":  "replace",
+				"before": map[string]interface{}{
+					"ami": "bar",
+					"id":  "foo",
+				},
+				"after": map[string]interface{}{
+					"ami": "baz",
+				},
+				"after_unknown":    []interface{}{"id"},
+				"requires_replace": []interface{}{"ami"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad:\n\n%s", ui.OutputWriter.String())
+	}
+}
+
+func TestShow_planSensitive(t *testing.T) {
+	planPath := testPlanFile(t, &terraform.Plan{
+		Module: new(module.Tree),
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				&terraform.ModuleDiff{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"test_instance.foo": &terraform.InstanceDiff{
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"password": &terraform.ResourceAttrDiff{
+									Old:       "hunter1",
+									New:       "hunter2",
+									Sensitive: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	for _, args := range [][]string{{planPath}, {"-json", planPath}} {
+		ui := new(cli.MockUi)
+		c := &ShowCommand{
+			Meta: Meta{
+				ContextOpts: testCtxConfig(testProvider()),
+				Ui:          ui,
+			},
+		}
+
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+		}
+
+		output := ui.OutputWriter.String()
+		if strings.Contains(output, "hunter") {
+			t.Fatalf("sensitive value in output:\n\n%s", output)
+		}
+		if !strings.Contains(output, "sensitive") {
+			t.Fatalf("bad:\n\n%s", output)
+		}
+	}
+}
+
+func TestShow_stateJSON(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	ui := new(cli.MockUi)
+	c := &ShowCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-json",
+		statePath,
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: \n%s", ui.OutputWriter.String())
+	}
+}
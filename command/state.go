@@ -2,13 +2,13 @@ package command
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/terraform/backend"
 	"github.com/hashicorp/terraform/state"
-	"github.com/hashicorp/terraform/state/remote"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -33,6 +33,11 @@ type StateOpts struct {
 	// it is assumed to be the path where the state is stored locally
 	// plus the DefaultBackupExtension.
 	BackupPath string
+
+	// HistoryKeep is the number of serials of the state that are kept in
+	// its history, which is the path where the state is stored locally
+	// plus the DefaultStateHistoryExtension. If zero, no history is kept.
+	HistoryKeep int
 }
 
 // StateResult is the result of calling State and holds various different
@@ -46,6 +51,10 @@ type StateResult struct {
 	State     state.State
 	StatePath string
 
+	// HistoryDir is the directory where the history of the state is
+	// kept, if it is kept.
+	HistoryDir string
+
 	// Local and Remote are the local/remote state implementations, raw
 	// and unwrapped by any backups. The paths here are the paths where
 	// these state files would be saved.
@@ -161,6 +170,15 @@ func State(opts *StateOpts) (*StateResult, error) {
 				Path: backupPath,
 			}
 		}
+
+		result.HistoryDir = result.StatePath + DefaultStateHistoryExtension
+		if opts.HistoryKeep > 0 {
+			result.State = &state.HistoryState{
+				Real: result.State,
+				Dir:  result.HistoryDir,
+				Keep: opts.HistoryKeep,
+			}
+		}
 	}
 
 	// Return whatever state we have
@@ -168,8 +186,14 @@ func State(opts *StateOpts) (*StateResult, error) {
 }
 
 // StateFromPlan gets our state from the plan.
+//
+// localPath and remotePath are the paths where the state is stored locally
+// and where the remote state cache is stored, if the plan has remote state.
+// historyKeep is the number of serials kept in the history of the state, as
+// in StateOpts.
 func StateFromPlan(
-	localPath string, plan *terraform.Plan) (state.State, string, error) {
+	localPath, remotePath string, historyKeep int,
+	plan *terraform.Plan) (state.State, string, error) {
 	var result state.State
 	resultPath := localPath
 	if plan != nil && plan.State != nil &&
@@ -178,7 +202,7 @@ func StateFromPlan(
 
 		// It looks like we have a remote state in the plan, so
 		// we have to initialize that.
-		resultPath = filepath.Join(DefaultDataDir, DefaultStateFilename)
+		resultPath = remotePath
 		result, err = remoteState(plan.State, resultPath, false)
 		if err != nil {
 			return nil, "", err
@@ -197,6 +221,14 @@ func StateFromPlan(
 		Path: resultPath + DefaultBackupExtension,
 	}
 
+	if historyKeep > 0 {
+		result = &state.HistoryState{
+			Real: result,
+			Dir:  resultPath + DefaultStateHistoryExtension,
+			Keep: historyKeep,
+		}
+	}
+
 	return result, resultPath, nil
 }
 
@@ -208,16 +240,18 @@ func remoteState(
 		return nil, fmt.Errorf("Remote state cache has no remote info")
 	}
 
-	// Initialize the remote client based on the local state
-	client, err := remote.NewClient(strings.ToLower(local.Remote.Type), local.Remote.Config)
+	// Initialize the backend based on the local state
+	b, err := backend.Init(strings.ToLower(local.Remote.Type), local.Remote.Config)
 	if err != nil {
 		return nil, errwrap.Wrapf(fmt.Sprintf(
 			"Error initializing remote driver '%s': {{err}}",
 			local.Remote.Type), err)
 	}
 
-	// Create the remote client
-	durable := &remote.State{Client: client}
+	durable, err := b.State()
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the cached client
 	cache := &state.CacheState{
@@ -274,3 +308,18 @@ func remoteStateFromPath(path string, refresh bool) (*state.CacheState, error) {
 
 	return remoteState(localState, path, refresh)
 }
+
+// readStateFile reads a state file given as an argument, decrypting its
+// sensitive attributes as the state storage does.
+func readStateFile(r io.Reader) (*terraform.State, error) {
+	result, err := terraform.ReadState(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := state.DecryptState(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -16,6 +16,13 @@ type StateMeta struct{}
 // in the way that backups are done. This configures backups to be timestamped
 // rather than just the original state path plus a backup path.
 func (c *StateMeta) State(m *Meta) (state.State, error) {
+	// Store the backup path given with -backup, if any, since backups
+	// can't be disabled for state subcommands.
+	backupPath := m.backupPath
+	if backupPath == "-" {
+		backupPath = ""
+	}
+
 	// Disable backups since we wrap it manually below
 	m.backupPath = "-"
 
@@ -27,11 +34,13 @@ func (c *StateMeta) State(m *Meta) (state.State, error) {
 
 	// Determine the backup path. stateOutPath is set to the resulting
 	// file where state is written (cached in the case of remote state)
-	backupPath := fmt.Sprintf(
-		"%s.%d.%s",
-		m.stateOutPath,
-		time.Now().UTC().Unix(),
-		DefaultBackupExtension)
+	if backupPath == "" {
+		backupPath = fmt.Sprintf(
+			"%s.%d.%s",
+			m.stateOutPath,
+			time.Now().UTC().Unix(),
+			DefaultBackupExtension)
+	}
 
 	// Wrap it for backups
 	s = &state.BackupState{
@@ -8,7 +8,8 @@ import (
 	"github.com/mitchellh/cli"
 )
 
-// StateMvCommand is a Command implementation that shows a single resource.
+// StateMvCommand is a Command implementation that moves an item in the
+// state, or to another state.
 type StateMvCommand struct {
 	Meta
 	StateMeta
@@ -19,10 +20,10 @@ func (c *StateMvCommand) Run(args []string) int {
 
 	// We create two metas to track the two states
 	var meta1, meta2 Meta
-	cmdFlags := c.Meta.flagSet("state show")
-	cmdFlags.StringVar(&meta1.stateOutPath, "backup", "", "backup")
+	cmdFlags := c.Meta.flagSet("state mv")
+	cmdFlags.StringVar(&meta1.backupPath, "backup", "", "backup")
 	cmdFlags.StringVar(&meta1.statePath, "state", DefaultStateFilename, "path")
-	cmdFlags.StringVar(&meta2.stateOutPath, "backup-out", "", "backup")
+	cmdFlags.StringVar(&meta2.backupPath, "backup-out", "", "backup")
 	cmdFlags.StringVar(&meta2.statePath, "state-out", "", "path")
 	if err := cmdFlags.Parse(args); err != nil {
 		return cli.RunResultHelp
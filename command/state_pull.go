@@ -0,0 +1,107 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/state/remote"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+// StatePullCommand is a Command implementation that writes the state, or
+// a prior version of the remote state, to stdout.
+type StatePullCommand struct {
+	Meta
+}
+
+func (c *StatePullCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	var version string
+	cmdFlags := c.Meta.flagSet("state pull")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.StringVar(&version, "version", "", "version")
+	if err := cmdFlags.Parse(args); err != nil {
+		return cli.RunResultHelp
+	}
+
+	st, err := c.Meta.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return cli.RunResultHelp
+	}
+
+	stateReal := st.State()
+	if version != "" {
+		stateReal, err = c.stateVersion(version)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf(errStatePullVersion, version, err))
+			return 1
+		}
+	}
+	if stateReal == nil {
+		c.Ui.Error(fmt.Sprintf(errStateNotFound))
+		return 1
+	}
+
+	var buf bytes.Buffer
+	if err := terraform.WriteState(stateReal, &buf); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing the state: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(strings.TrimSpace(buf.String()))
+	return 0
+}
+
+// stateVersion returns a prior version of the remote state.
+func (c *StatePullCommand) stateVersion(version string) (*terraform.State, error) {
+	var rs *remote.State
+	if c.Meta.stateResult != nil && c.Meta.stateResult.Remote != nil {
+		rs, _ = c.Meta.stateResult.Remote.Durable.(*remote.State)
+	}
+	if rs == nil {
+		return nil, fmt.Errorf("Only the remote state has versions")
+	}
+
+	return rs.StateVersion(version)
+}
+
+func (c *StatePullCommand) Help() string {
+	helpText := `
+Usage: terraform state pull [options]
+
+  Write the Terraform state to stdout.
+
+  This command writes the state in its JSON format, so it can be inspected
+  or processed with other tools. With remote state, the state is read from
+  the remote storage first.
+
+  The -version flag writes a prior version of the remote state instead,
+  to recover the state from before a bad apply. This requires a remote
+  state backend that keeps versions of the state, such as S3 with
+  versioning enabled on the bucket.
+
+Options:
+
+  -state=statefile    Path to a Terraform state file to use to look
+                      up Terraform-managed resources. By default it will
+                      use the state "terraform.tfstate" if it exists.
+
+  -version=id         ID of a prior version of the remote state to write
+                      instead of the current state.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StatePullCommand) Synopsis() string {
+	return "Write the state to stdout"
+}
+
+const errStatePullVersion = `Error reading version %[1]q of the state: %[2]s
+
+Please ensure that remote state is configured with a backend that keeps
+versions of the state, such as S3 with versioning enabled on the bucket.`
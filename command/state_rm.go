@@ -0,0 +1,131 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+// StateRmCommand is a Command implementation that removes items from
+// the state.
+type StateRmCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateRmCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	cmdFlags := c.Meta.flagSet("state rm")
+	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "backup")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	if err := cmdFlags.Parse(args); err != nil {
+		return cli.RunResultHelp
+	}
+	args = cmdFlags.Args()
+	if len(args) == 0 {
+		c.Ui.Error("At least one address is required.\n")
+		return cli.RunResultHelp
+	}
+
+	state, err := c.StateMeta.State(&c.Meta)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return cli.RunResultHelp
+	}
+
+	stateReal := state.State()
+	if stateReal == nil {
+		c.Ui.Error(fmt.Sprintf(errStateNotFound))
+		return 1
+	}
+
+	// Filter what we're removing, so that we can report it
+	filter := &terraform.StateFilter{State: stateReal}
+	results, err := filter.Filter(args...)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateFilter, err))
+		return cli.RunResultHelp
+	}
+
+	var removed []string
+	for _, result := range results {
+		if _, ok := result.Value.(*terraform.InstanceState); ok {
+			removed = append(removed, result.Address)
+		}
+	}
+	if len(removed) == 0 {
+		c.Ui.Output(fmt.Sprintf("No matching items found: %s", strings.Join(args, " ")))
+		return 1
+	}
+
+	if err := stateReal.Remove(args...); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRm, err))
+		return 1
+	}
+
+	if err := state.WriteState(stateReal); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRmPersist, err))
+		return 1
+	}
+
+	if err := state.PersistState(); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRmPersist, err))
+		return 1
+	}
+
+	for _, addr := range removed {
+		c.Ui.Output(fmt.Sprintf("Removed %s", addr))
+	}
+	return 0
+}
+
+func (c *StateRmCommand) Help() string {
+	helpText := `
+Usage: terraform state rm [options] ADDRESS...
+
+  Remove one or more items from the Terraform state.
+
+  This command removes one or more items from the Terraform state based
+  on the address given. You can view and list the available resources
+  with "terraform state list".
+
+  Removing an item from the state doesn't destroy the real infrastructure
+  it represents. Terraform simply stops managing it, and the next plan will
+  propose to create it again if it is still in the configuration.
+
+  This command creates a timestamped backup of the state on every invocation.
+  This can't be disabled. Due to the destructive nature of this command,
+  the backup is ensured by Terraform for safety reasons.
+
+Options:
+
+  -backup=PATH        Path where Terraform should write the backup
+                      state. This can't be disabled. If not set, Terraform
+                      will write it to the same path as the statefile with
+                      a backup extension.
+
+  -state=statefile    Path to a Terraform state file to use to look
+                      up Terraform-managed resources. By default it will
+                      use the state "terraform.tfstate" if it exists.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateRmCommand) Synopsis() string {
+	return "Remove an item from the state"
+}
+
+const errStateRm = `Error removing items from the state: %[1]s
+
+Please ensure your addresses and state paths are valid. No
+state was persisted. Your existing state is untouched.`
+
+const errStateRmPersist = `Error saving the state: %s
+
+The state wasn't saved properly. If the error happening after a partial
+write occurred, a backup file will have been created. Otherwise, the state
+is in the same state it was when the operation started.`
@@ -0,0 +1,174 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/mitchellh/cli"
+)
+
+// StateRollbackCommand is a Command implementation that restores the
+// state from its history.
+type StateRollbackCommand struct {
+	Meta
+	StateMeta
+}
+
+func (c *StateRollbackCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	var list bool
+	cmdFlags := c.Meta.flagSet("state rollback")
+	cmdFlags.BoolVar(&list, "list", false, "list")
+	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "backup")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	if err := cmdFlags.Parse(args); err != nil {
+		return cli.RunResultHelp
+	}
+	args = cmdFlags.Args()
+	if (list && len(args) != 0) || (!list && len(args) != 1) {
+		c.Ui.Error("Exactly one serial, or the -list flag, is required.\n")
+		return cli.RunResultHelp
+	}
+
+	s, err := c.StateMeta.State(&c.Meta)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return cli.RunResultHelp
+	}
+
+	snapshots, err := state.History(c.Meta.stateResult.HistoryDir)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	if list {
+		if len(snapshots) == 0 {
+			c.Ui.Output(fmt.Sprintf(
+				"The history of the state is empty: %s", c.Meta.stateResult.HistoryDir))
+			return 0
+		}
+
+		for _, snapshot := range snapshots {
+			c.Ui.Output(fmt.Sprintf(
+				"%d\t%s", snapshot.Serial, snapshot.ModTime.UTC().Format(time.RFC3339)))
+		}
+		return 0
+	}
+
+	serial, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid serial %q: %s", args[0], err))
+		return 1
+	}
+
+	var snapshot *state.HistorySnapshot
+	for _, snap := range snapshots {
+		if snap.Serial == serial {
+			snapshot = snap
+			break
+		}
+	}
+	if snapshot == nil {
+		c.Ui.Error(fmt.Sprintf(errStateRollbackNotFound, serial))
+		return 1
+	}
+
+	defer c.Meta.unlockState()
+	if err := c.Meta.lockState(s, "state rollback", true); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	stateReal := s.State()
+	if stateReal == nil {
+		c.Ui.Error(fmt.Sprintf(errStateNotFound))
+		return 1
+	}
+
+	restored, err := snapshot.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading serial %d of the state: %s", serial, err))
+		return 1
+	}
+
+	// The restored state gets a serial newer than the current one once
+	// written, so that it replaces it wherever the state is stored.
+	restored.Serial = stateReal.Serial
+
+	if err := s.WriteState(restored); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRollbackPersist, err))
+		return 1
+	}
+	if err := s.PersistState(); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRollbackPersist, err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf(
+		"Rolled back the state to serial %d. It is now serial %d.",
+		serial, s.State().Serial))
+	return 0
+}
+
+func (c *StateRollbackCommand) Help() string {
+	helpText := `
+Usage: terraform state rollback [options] SERIAL
+
+  Restore the state as it was at the given serial.
+
+  Every time the state is saved, Terraform keeps a copy of it in the state
+  history, which is the directory next to the state file (or next to the
+  local cache of a remote state) with a ".history" extension. Use -list to
+  list the serials in the history.
+
+  The restored state is saved with a new serial, so it replaces the state
+  wherever it is stored, including remote state. Rolling back doesn't
+  change the real infrastructure: run "terraform plan" afterwards to see
+  how it differs from the restored state.
+
+  This command creates a timestamped backup of the state on every invocation.
+  This can't be disabled. Due to the destructive nature of this command,
+  the backup is ensured by Terraform for safety reasons.
+
+Options:
+
+  -backup=PATH        Path where Terraform should write the backup
+                      state. This can't be disabled. If not set, Terraform
+                      will write it to the same path as the statefile with
+                      a backup extension.
+
+  -list               List the serials in the history, newest first, with
+                      the time they were saved, instead of rolling back.
+
+  -lock=true          Lock the state file when locking is supported.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
+  -state=statefile    Path to a Terraform state file to use to look
+                      up Terraform-managed resources. By default it will
+                      use the state "terraform.tfstate" if it exists.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateRollbackCommand) Synopsis() string {
+	return "Restore the state from its history"
+}
+
+const errStateRollbackNotFound = `Serial %d isn't in the history of the state.
+
+Use "terraform state rollback -list" to list the serials that can be
+restored. Only the last serials are kept, as set by state_history in the
+Terraform CLI configuration.`
+
+const errStateRollbackPersist = `Error saving the state: %s
+
+The state wasn't saved properly. If the error happening after a partial
+write occurred, a backup file will have been created. Otherwise, the state
+is in the same state it was when the operation started.`
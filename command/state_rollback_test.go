@@ -0,0 +1,135 @@
+package command
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestStateRollback(t *testing.T) {
+	state := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_instance.foo": &terraform.ResourceState{
+						Type: "test_instance",
+						Primary: &terraform.InstanceState{
+							ID: "bar",
+						},
+					},
+
+					"test_instance.bar": &terraform.ResourceState{
+						Type: "test_instance",
+						Primary: &terraform.InstanceState{
+							ID: "foo",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, state)
+	defer os.RemoveAll(statePath + DefaultStateHistoryExtension)
+
+	// Remove the resources one at a time, so that the state history has
+	// a serial with only test_instance.bar.
+	for _, addr := range []string{"test_instance.foo", "test_instance.bar"} {
+		ui := new(cli.MockUi)
+		c := &StateRmCommand{
+			Meta: Meta{
+				ContextOpts:  testCtxConfig(testProvider()),
+				Ui:           ui,
+				StateHistory: DefaultStateHistory,
+			},
+		}
+
+		args := []string{
+			"-state", statePath,
+			addr,
+		}
+		if code := c.Run(args); code != 0 {
+			t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+		}
+	}
+
+	ui := new(cli.MockUi)
+	c := &StateRollbackCommand{
+		Meta: Meta{
+			ContextOpts:  testCtxConfig(testProvider()),
+			Ui:           ui,
+			StateHistory: DefaultStateHistory,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"1",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if actual := ui.OutputWriter.String(); !strings.Contains(actual, "It is now serial 3") {
+		t.Fatalf("bad: %s", actual)
+	}
+
+	testStateOutput(t, statePath, testStateRollbackOutput)
+
+	// The rollback is in the history as well
+	ui = new(cli.MockUi)
+	c = &StateRollbackCommand{
+		Meta: Meta{
+			ContextOpts:  testCtxConfig(testProvider()),
+			Ui:           ui,
+			StateHistory: DefaultStateHistory,
+		},
+	}
+
+	args = []string{
+		"-state", statePath,
+		"-list",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var serials []string
+	for _, line := range strings.Split(strings.TrimSpace(ui.OutputWriter.String()), "\n") {
+		serials = append(serials, strings.Fields(line)[0])
+	}
+	if actual := strings.Join(serials, " "); actual != "3 2 1" {
+		t.Fatalf("bad: %s", actual)
+	}
+}
+
+func TestStateRollback_notFound(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	ui := new(cli.MockUi)
+	c := &StateRollbackCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"5",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if actual := ui.ErrorWriter.String(); !strings.Contains(actual, "isn't in the history") {
+		t.Fatalf("bad: %s", actual)
+	}
+}
+
+const testStateRollbackOutput = `
+test_instance.bar:
+  ID = foo
+`
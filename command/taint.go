@@ -63,6 +63,12 @@ func (c *TaintCommand) Run(args []string) int {
 		return 1
 	}
 
+	defer c.Meta.unlockState()
+	if err := c.Meta.lockState(state, "taint", true); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
 	// Get the actual state structure
 	s := state.State()
 	if s.Empty() {
@@ -159,6 +165,10 @@ Options:
                       default this will be root. Child modules can be specified
                       by names. Ex. "consul" or "consul.vpc" (nested modules).
 
+  -lock=true          Lock the state file when locking is supported.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
   -no-color           If specified, output won't contain any color.
 
   -state=path         Path to read and save state (unless state-out
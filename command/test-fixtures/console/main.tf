@@ -0,0 +1,9 @@
+variable "foo" {
+    default = "bar"
+}
+
+variable "list" {
+    default = ["a", "b"]
+}
+
+resource "test_instance" "foo" {}
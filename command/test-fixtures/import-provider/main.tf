@@ -0,0 +1,5 @@
+variable "foo" {}
+
+provider "test" {
+  foo = "${var.foo}"
+}
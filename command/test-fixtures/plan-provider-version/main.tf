@@ -0,0 +1,5 @@
+provider "test" {
+    version = "~> 1.2"
+}
+
+resource "test_instance" "foo" {}
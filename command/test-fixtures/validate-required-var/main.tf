@@ -0,0 +1,8 @@
+variable "ami" {}
+
+variable "count" {}
+
+resource "test_instance" "foo" {
+    ami   = "${var.ami}"
+    count = "${var.count}"
+}
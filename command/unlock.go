@@ -0,0 +1,109 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// UnlockCommand is a cli.Command implementation that manually releases
+// the lock of a state.
+type UnlockCommand struct {
+	Meta
+}
+
+func (c *UnlockCommand) Run(args []string) int {
+	args = c.Meta.process(args, false)
+
+	var force bool
+	cmdFlags := c.Meta.flagSet("force-unlock")
+	cmdFlags.BoolVar(&force, "force", false, "force")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", DefaultStateFilename, "path")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Require the one argument for the lock ID
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("The force-unlock command expects exactly one argument.")
+		cmdFlags.Usage()
+		return 1
+	}
+	lockID := args[0]
+
+	s, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	// Only remote states can be locked.
+	l, ok := s.(state.Locker)
+	if !ok || c.stateResult == nil || c.stateResult.Remote == nil {
+		c.Ui.Error("Local state files can't be locked, so there is nothing to unlock.")
+		return 1
+	}
+
+	if !force {
+		v, err := c.UIInput().Input(&terraform.InputOpts{
+			Id:    "force-unlock",
+			Query: "Do you really want to force-unlock?",
+			Description: "Terraform will remove the lock on the state.\n" +
+				"This will allow local Terraform commands to modify this state, even though it\n" +
+				"may still be in use. Only 'yes' will be accepted to confirm.",
+		})
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error asking for confirmation: %s", err))
+			return 1
+		}
+		if v != "yes" {
+			c.Ui.Output("force-unlock cancelled.")
+			return 1
+		}
+	}
+
+	if err := l.Unlock(lockID); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to unlock state: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(strings.TrimSpace(outputUnlockSuccess)))
+	return 0
+}
+
+func (c *UnlockCommand) Help() string {
+	helpText := `
+Usage: terraform force-unlock [options] LOCK_ID
+
+  Manually unlock the state for the defined configuration.
+
+  This will not modify your infrastructure. This command removes the lock on
+  the state for the current configuration. The behavior of this lock is
+  dependent on the backend being used. Local state files cannot be unlocked
+  by another process.
+
+Options:
+
+  -force              Don't ask for input for unlock confirmation.
+
+  -state=path         Path to the state file. Defaults to
+                      "terraform.tfstate". Ignored when remote state is
+                      used.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *UnlockCommand) Synopsis() string {
+	return "Manually unlock the terraform state"
+}
+
+const outputUnlockSuccess = `
+[reset][bold][green]Terraform state has been successfully unlocked![reset][green]
+
+The state has been unlocked, and Terraform commands should now be able to
+obtain a new lock on the remote state.
+`
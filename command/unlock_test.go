@@ -0,0 +1,42 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestUnlock_localState(t *testing.T) {
+	statePath := testStateFile(t, testState())
+
+	ui := new(cli.MockUi)
+	c := &UnlockCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-force",
+		"-state", statePath,
+		"LOCK_ID",
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
+
+func TestUnlock_noArgs(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &UnlockCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	if code := c.Run([]string{"-force"}); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
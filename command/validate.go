@@ -2,9 +2,13 @@ package command
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/config/module"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 // ValidateCommand is a Command implementation that validates the terraform files
@@ -15,17 +19,54 @@ type ValidateCommand struct {
 const defaultPath = "."
 
 func (c *ValidateCommand) Help() string {
-	return ""
+	helpText := `
+Usage: terraform validate [options] [dir]
+
+  Validate the Terraform files in a directory. Besides the syntax of the
+  files, the configuration of each provider and resource is checked
+  against the provider's schema: unknown attributes, missing required
+  attributes and values of the wrong type are reported.
+
+  No cloud APIs are called and no state is read, so this can be run
+  without credentials, for example from a pre-commit hook. Modules must
+  have been downloaded with "terraform get" first.
+
+Options:
+
+  -check-variables=true  If set to false, required variables that are not
+                         set are treated as unknown values instead of
+                         being reported as errors.
+
+  -no-color              If specified, output won't contain any color.
+
+  -var 'foo=bar'         Set a variable in the Terraform configuration. This
+                         flag can be set multiple times.
+
+  -var-file=foo          Set variables in the Terraform configuration from
+                         a file. If "terraform.tfvars" is present, it will be
+                         automatically loaded if this flag is not specified.
+
+`
+	return strings.TrimSpace(helpText)
 }
 
 func (c *ValidateCommand) Run(args []string) int {
-	args = c.Meta.process(args, false)
-	var dirPath string
+	args = c.Meta.process(args, true)
+
+	var checkVars bool
+	cmdFlags := c.Meta.flagSet("validate")
+	cmdFlags.BoolVar(&checkVars, "check-variables", true, "check-variables")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
 
+	var dirPath string
+	args = cmdFlags.Args()
 	if len(args) == 1 {
 		dirPath = args[0]
 	} else {
-		dirPath = "."
+		dirPath = defaultPath
 	}
 	dir, err := filepath.Abs(dirPath)
 	if err != nil {
@@ -33,7 +74,7 @@ func (c *ValidateCommand) Run(args []string) int {
 			"Unable to locate directory %v\n", err.Error()))
 	}
 
-	rtnCode := c.validate(dir)
+	rtnCode := c.validate(dir, checkVars)
 
 	return rtnCode
 }
@@ -42,7 +83,7 @@ func (c *ValidateCommand) Synopsis() string {
 	return "Validates the Terraform files"
 }
 
-func (c *ValidateCommand) validate(dir string) int {
+func (c *ValidateCommand) validate(dir string, checkVars bool) int {
 	cfg, err := config.LoadDir(dir)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf(
@@ -55,5 +96,47 @@ func (c *ValidateCommand) validate(dir string) int {
 			"Error validating: %v\n", err.Error()))
 		return 1
 	}
+
+	// Check the configuration against the provider schemas. Validating
+	// only asks the providers to validate their configuration, so this
+	// doesn't need credentials or call any APIs.
+	mod, err := module.NewTreeModule("", dir)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading config: %s", err))
+		return 1
+	}
+	err = mod.Load(c.moduleStorage(c.DataDir()), module.GetModeNone)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading modules: %s", err))
+		return 1
+	}
+
+	opts := c.contextOpts()
+	opts.Module = mod
+	if !checkVars {
+		for _, v := range cfg.Variables {
+			if !v.Required() {
+				continue
+			}
+			if _, ok := opts.Variables[v.Name]; ok {
+				continue
+			}
+			if _, ok := os.LookupEnv(terraform.VarEnvPrefix + v.Name); ok {
+				continue
+			}
+
+			opts.Variables[v.Name] = config.UnknownVariableValue
+		}
+	}
+
+	ctx, err := terraform.NewContext(opts)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error validating: %s", err))
+		return 1
+	}
+	if !validateContext(ctx, c.Ui) {
+		return 1
+	}
+
 	return 0
 }
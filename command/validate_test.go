@@ -1,23 +1,28 @@
 package command
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/cli"
 )
 
-func setupTest(fixturepath string) (*cli.MockUi, int) {
+func setupTest(fixturepath string, args ...string) (*cli.MockUi, int) {
+	return setupTestProvider(testProvider(), fixturepath, args...)
+}
+
+func setupTestProvider(p *terraform.MockResourceProvider, fixturepath string, args ...string) (*cli.MockUi, int) {
 	ui := new(cli.MockUi)
 	c := &ValidateCommand{
 		Meta: Meta{
-			Ui: ui,
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
 		},
 	}
 
-	args := []string{
-		testFixturePath(fixturepath),
-	}
+	args = append(args, testFixturePath(fixturepath))
 
 	code := c.Run(args)
 	return ui, code
@@ -122,3 +127,45 @@ func TestWronglyUsedInterpolationShouldFail(t *testing.T) {
 		t.Fatalf("Should have failed: %d\n\n'%s'", code, ui.ErrorWriter.String())
 	}
 }
+
+func TestValidateCommand_providerSchema(t *testing.T) {
+	p := testProvider()
+	p.ValidateResourceReturnErrors = []error{
+		errors.New("\"virtual_gateway_id\": required field is not set"),
+	}
+
+	ui, code := setupTestProvider(p, "validate-valid")
+	if code != 1 {
+		t.Fatalf("Should have failed: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if !p.ValidateResourceCalled {
+		t.Fatal("ValidateResource should be called")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "virtual_gateway_id") {
+		t.Fatalf("Should have failed: %d\n\n'%s'", code, ui.ErrorWriter.String())
+	}
+}
+
+func TestValidateCommand_missingRequiredVariable(t *testing.T) {
+	ui, code := setupTest("validate-required-var")
+	if code != 1 {
+		t.Fatalf("Should have failed: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "Required variable not set: ami") {
+		t.Fatalf("Should have failed: %d\n\n'%s'", code, ui.ErrorWriter.String())
+	}
+}
+
+func TestValidateCommand_noCheckVariables(t *testing.T) {
+	ui, code := setupTest("validate-required-var", "-check-variables=false")
+	if code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+}
+
+func TestValidateCommand_vars(t *testing.T) {
+	ui, code := setupTest("validate-required-var", "-var", "ami=bar", "-var", "count=2")
+	if code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+}
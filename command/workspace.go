@@ -0,0 +1,143 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// workspaceFilename is the file in the data directory that holds the name
+// of the selected workspace.
+const workspaceFilename = "workspace"
+
+// workspaceDirSuffix is added to the path of a state file in the default
+// workspace to form the directory of the state files in other workspaces.
+const workspaceDirSuffix = ".d"
+
+var workspaceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Workspace returns the name of the current workspace.
+func (m *Meta) Workspace() string {
+	if v := os.Getenv(WorkspaceEnvVar); v != "" {
+		return v
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(m.DataDir(), workspaceFilename))
+	if err != nil {
+		return DefaultWorkspace
+	}
+
+	if name := strings.TrimSpace(string(data)); name != "" {
+		return name
+	}
+	return DefaultWorkspace
+}
+
+// SetWorkspace selects the workspace for future commands.
+func (m *Meta) SetWorkspace(name string) error {
+	path := filepath.Join(m.DataDir(), workspaceFilename)
+	if name == DefaultWorkspace {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(m.DataDir(), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(name+"\n"), 0644)
+}
+
+// Workspaces returns the names of all workspaces, sorted, with the default
+// workspace first.
+func (m *Meta) Workspaces() ([]string, error) {
+	set := make(map[string]struct{})
+	for _, path := range m.workspaceDirs() {
+		infos, err := ioutil.ReadDir(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, info := range infos {
+			if info.IsDir() && info.Name() != DefaultWorkspace {
+				set[info.Name()] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return append([]string{DefaultWorkspace}, names...), nil
+}
+
+// workspaceExists returns whether the workspace with the given name exists.
+func (m *Meta) workspaceExists(name string) (bool, error) {
+	names, err := m.Workspaces()
+	if err != nil {
+		return false, err
+	}
+
+	for _, n := range names {
+		if n == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// workspaceDirs returns the directories that hold the state files of the
+// workspaces other than the default workspace: the local state and the
+// remote state cache.
+func (m *Meta) workspaceDirs() []string {
+	return []string{
+		DefaultStateFilename + workspaceDirSuffix,
+		filepath.Join(m.DataDir(), DefaultStateFilename+workspaceDirSuffix),
+	}
+}
+
+// workspaceStateOpts returns the options to get the state of the given
+// workspace.
+func (m *Meta) workspaceStateOpts(name string) *StateOpts {
+	opts := m.StateOpts()
+	opts.LocalPath = workspacePath(DefaultStateFilename, name)
+	opts.LocalPathOut = ""
+	opts.RemotePath = workspacePath(
+		filepath.Join(m.DataDir(), DefaultStateFilename), name)
+	opts.BackupPath = ""
+	return opts
+}
+
+// workspacePath returns the path of a state file in the given workspace,
+// given its path in the default workspace.
+func workspacePath(path, workspace string) string {
+	if workspace == "" || workspace == DefaultWorkspace {
+		return path
+	}
+
+	dir, file := filepath.Split(path)
+	return filepath.Join(dir, file+workspaceDirSuffix, workspace, file)
+}
+
+// validateWorkspaceName returns an error if the name can't be used for a
+// workspace.
+func validateWorkspaceName(name string) error {
+	if !workspaceNameRegexp.MatchString(name) {
+		return fmt.Errorf(
+			"Invalid workspace name %q. Workspace names may only contain letters,\n"+
+				"digits, underscores, dashes and dots, and must start with a letter or\n"+
+				"a digit.", name)
+	}
+	return nil
+}
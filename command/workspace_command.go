@@ -0,0 +1,40 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// WorkspaceCommand is a Command implementation that just shows help for
+// the subcommands nested below it.
+type WorkspaceCommand struct {
+	Meta
+}
+
+func (c *WorkspaceCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *WorkspaceCommand) Help() string {
+	helpText := `
+Usage: terraform workspace <subcommand> [options] [args]
+
+  This command has subcommands for workspace management.
+
+  Workspaces keep separate states for a single configuration, such as
+  for the development, staging and production environments. The current
+  workspace is available to the configuration as "${terraform.workspace}".
+
+  The "default" workspace always exists and uses the state at the usual
+  paths. The states of other workspaces are stored in the directory
+  "terraform.tfstate.d", or "terraform.tfstate.d" within the data
+  directory for remote state, which is configured per workspace.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WorkspaceCommand) Synopsis() string {
+	return "Workspace management"
+}
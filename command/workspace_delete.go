@@ -0,0 +1,127 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// WorkspaceDeleteCommand is a Command implementation that deletes a
+// workspace and its state.
+type WorkspaceDeleteCommand struct {
+	Meta
+}
+
+func (c *WorkspaceDeleteCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	var force bool
+	cmdFlags := c.Meta.flagSet("workspace delete")
+	cmdFlags.BoolVar(&force, "force", false, "force")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("The workspace delete command expects exactly one argument.")
+		cmdFlags.Usage()
+		return 1
+	}
+	name := args[0]
+
+	if name == DefaultWorkspace {
+		c.Ui.Error("The default workspace can't be deleted.")
+		return 1
+	}
+	if name == c.Meta.Workspace() {
+		c.Ui.Error(fmt.Sprintf(
+			"Workspace %q is the current workspace. Select another workspace\n"+
+				"before deleting it.", name))
+		return 1
+	}
+
+	exists, err := c.Meta.workspaceExists(name)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+	if !exists {
+		c.Ui.Error(fmt.Sprintf("Workspace %q doesn't exist.", name))
+		return 1
+	}
+
+	// Refuse to forget about resources that are still managed in the
+	// workspace, unless forced to
+	if !force {
+		result, err := State(c.Meta.workspaceStateOpts(name))
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+			return 1
+		}
+		if result.State != nil && stateHasResources(result.State.State()) {
+			c.Ui.Error(fmt.Sprintf(errWorkspaceNotEmpty, name))
+			return 1
+		}
+	}
+
+	for _, dir := range c.Meta.workspaceDirs() {
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error deleting workspace: %s", err))
+			return 1
+		}
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][green]Deleted workspace %q.", name)))
+	return 0
+}
+
+// stateHasResources returns whether the state has any resources.
+func stateHasResources(s *terraform.State) bool {
+	if s == nil {
+		return false
+	}
+
+	for _, m := range s.Modules {
+		if len(m.Resources) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *WorkspaceDeleteCommand) Help() string {
+	helpText := `
+Usage: terraform workspace delete [options] NAME
+
+  Delete a workspace and its local state.
+
+  The current workspace and the default workspace can't be deleted. A
+  workspace whose state still has resources can't be deleted either,
+  since Terraform would stop managing them, unless -force is given.
+
+  For a workspace with remote state, only the local cache and its remote
+  state configuration are deleted. The state remains in the remote storage.
+
+Options:
+
+  -force              Delete the workspace even if its state still has
+                      resources.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WorkspaceDeleteCommand) Synopsis() string {
+	return "Delete a workspace"
+}
+
+const errWorkspaceNotEmpty = `The state of workspace %[1]q still has resources.
+
+Deleting the workspace would make Terraform stop managing them. Destroy
+the resources in the workspace first, or use -force to delete it anyway.`
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkspaceListCommand is a Command implementation that lists the
+// workspaces.
+type WorkspaceListCommand struct {
+	Meta
+}
+
+func (c *WorkspaceListCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	cmdFlags := c.Meta.flagSet("workspace list")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	names, err := c.Meta.Workspaces()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+
+	current := c.Meta.Workspace()
+	for _, name := range names {
+		if name == current {
+			c.Ui.Output("* " + name)
+		} else {
+			c.Ui.Output("  " + name)
+		}
+	}
+
+	return 0
+}
+
+func (c *WorkspaceListCommand) Help() string {
+	helpText := `
+Usage: terraform workspace list
+
+  List the workspaces. The current workspace is marked with an asterisk.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WorkspaceListCommand) Synopsis() string {
+	return "List workspaces"
+}
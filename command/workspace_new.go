@@ -0,0 +1,90 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WorkspaceNewCommand is a Command implementation that creates a new
+// workspace and selects it.
+type WorkspaceNewCommand struct {
+	Meta
+}
+
+func (c *WorkspaceNewCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	cmdFlags := c.Meta.flagSet("workspace new")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("The workspace new command expects exactly one argument.")
+		cmdFlags.Usage()
+		return 1
+	}
+	name := args[0]
+
+	if err := validateWorkspaceName(name); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	exists, err := c.Meta.workspaceExists(name)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+	if exists {
+		c.Ui.Error(fmt.Sprintf("Workspace %q already exists.", name))
+		return 1
+	}
+
+	// The workspace exists once the directory for its state does
+	dir := filepath.Dir(workspacePath(DefaultStateFilename, name))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating workspace: %s", err))
+		return 1
+	}
+
+	if os.Getenv(WorkspaceEnvVar) != "" {
+		c.Ui.Output(fmt.Sprintf(
+			"Created workspace %q. It wasn't selected, since the workspace is\n"+
+				"set with the %s environment variable.", name, WorkspaceEnvVar))
+		return 0
+	}
+
+	if err := c.Meta.SetWorkspace(name); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error selecting workspace: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][green]Created and switched to workspace %q.\n\n"+
+			"[reset]The workspace starts with an empty state. Remote state is\n"+
+			"configured per workspace with \"terraform remote config\".", name)))
+	return 0
+}
+
+func (c *WorkspaceNewCommand) Help() string {
+	helpText := `
+Usage: terraform workspace new NAME
+
+  Create a new workspace and select it.
+
+  The new workspace starts with an empty state. Remote state is configured
+  per workspace with "terraform remote config", so that each workspace can
+  store its state with its own key.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WorkspaceNewCommand) Synopsis() string {
+	return "Create a new workspace"
+}
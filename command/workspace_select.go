@@ -0,0 +1,75 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WorkspaceSelectCommand is a Command implementation that selects the
+// workspace for future commands.
+type WorkspaceSelectCommand struct {
+	Meta
+}
+
+func (c *WorkspaceSelectCommand) Run(args []string) int {
+	args = c.Meta.process(args, true)
+
+	cmdFlags := c.Meta.flagSet("workspace select")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("The workspace select command expects exactly one argument.")
+		cmdFlags.Usage()
+		return 1
+	}
+	name := args[0]
+
+	if os.Getenv(WorkspaceEnvVar) != "" {
+		c.Ui.Error(fmt.Sprintf(errWorkspaceEnvVar, WorkspaceEnvVar))
+		return 1
+	}
+
+	exists, err := c.Meta.workspaceExists(name)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+	if !exists {
+		c.Ui.Error(fmt.Sprintf(
+			"Workspace %q doesn't exist. You can create it with\n"+
+				"\"terraform workspace new %s\".", name, name))
+		return 1
+	}
+
+	if err := c.Meta.SetWorkspace(name); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error selecting workspace: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][green]Switched to workspace %q.", name)))
+	return 0
+}
+
+func (c *WorkspaceSelectCommand) Help() string {
+	helpText := `
+Usage: terraform workspace select NAME
+
+  Select the workspace that future commands operate in.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *WorkspaceSelectCommand) Synopsis() string {
+	return "Select a workspace"
+}
+
+const errWorkspaceEnvVar = `The workspace is set with the %[1]s environment variable.
+
+Unset %[1]s to select a workspace with this command.`
@@ -0,0 +1,223 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+)
+
+func TestWorkspace_newSelectListShow(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	testWorkspaceShow(t, DefaultWorkspace)
+
+	ui := new(cli.MockUi)
+	newCmd := &WorkspaceNewCommand{Meta: Meta{Ui: ui}}
+	if code := newCmd.Run([]string{"prod"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	testWorkspaceShow(t, "prod")
+
+	// The workspace can't be created twice
+	ui = new(cli.MockUi)
+	newCmd = &WorkspaceNewCommand{Meta: Meta{Ui: ui}}
+	if code := newCmd.Run([]string{"prod"}); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	newCmd = &WorkspaceNewCommand{Meta: Meta{Ui: ui}}
+	if code := newCmd.Run([]string{"dev"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	listCmd := &WorkspaceListCommand{Meta: Meta{Ui: ui}}
+	if code := listCmd.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	expected := "  default\n* dev\n  prod\n"
+	if actual := ui.OutputWriter.String(); actual != expected {
+		t.Fatalf("bad:\n%s\n\nexpected:\n%s", actual, expected)
+	}
+
+	for _, name := range []string{"prod", DefaultWorkspace} {
+		ui = new(cli.MockUi)
+		selectCmd := &WorkspaceSelectCommand{Meta: Meta{Ui: ui}}
+		if code := selectCmd.Run([]string{name}); code != 0 {
+			t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+		}
+		testWorkspaceShow(t, name)
+	}
+
+	ui = new(cli.MockUi)
+	selectCmd := &WorkspaceSelectCommand{Meta: Meta{Ui: ui}}
+	if code := selectCmd.Run([]string{"nope"}); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	testWorkspaceShow(t, DefaultWorkspace)
+}
+
+func TestWorkspaceNew_invalidName(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	for _, name := range []string{"../prod", "prod/eu", ".prod", ""} {
+		ui := new(cli.MockUi)
+		c := &WorkspaceNewCommand{Meta: Meta{Ui: ui}}
+		if code := c.Run([]string{name}); code != 1 {
+			t.Fatalf("%q: bad: %d\n\n%s", name, code, ui.OutputWriter.String())
+		}
+	}
+
+	testWorkspaceShow(t, DefaultWorkspace)
+}
+
+func TestWorkspace_envVar(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	defer os.Setenv(WorkspaceEnvVar, os.Getenv(WorkspaceEnvVar))
+	os.Setenv(WorkspaceEnvVar, "staging")
+
+	testWorkspaceShow(t, "staging")
+
+	ui := new(cli.MockUi)
+	c := &WorkspaceSelectCommand{Meta: Meta{Ui: ui}}
+	if code := c.Run([]string{DefaultWorkspace}); code != 1 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.OutputWriter.String())
+	}
+}
+
+func TestWorkspace_apply(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	ui := new(cli.MockUi)
+	newCmd := &WorkspaceNewCommand{Meta: Meta{Ui: ui}}
+	if code := newCmd.Run([]string{"prod"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	p := testProvider()
+	ui = new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(p),
+			Ui:          ui,
+		},
+	}
+	if code := c.Run([]string{testFixturePath("apply")}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	// The state is stored in the workspace
+	statePath := filepath.Join(DefaultStateFilename+".d", "prod", DefaultStateFilename)
+	if _, err := os.Stat(statePath); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := os.Stat(DefaultStateFilename); !os.IsNotExist(err) {
+		t.Fatalf("default state should not exist: %v", err)
+	}
+
+	if opts := c.Meta.contextOpts(); opts.Workspace != "prod" {
+		t.Fatalf("bad workspace: %q", opts.Workspace)
+	}
+}
+
+func TestWorkspaceDelete(t *testing.T) {
+	tmp, cwd := testCwd(t)
+	defer testFixCwd(t, tmp, cwd)
+
+	for _, name := range []string{"prod", "dev"} {
+		ui := new(cli.MockUi)
+		c := &WorkspaceNewCommand{Meta: Meta{Ui: ui}}
+		if code := c.Run([]string{name}); code != 0 {
+			t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+		}
+	}
+
+	// Give the prod workspace a state with resources
+	statePath := filepath.Join(DefaultStateFilename+".d", "prod", DefaultStateFilename)
+	f, err := os.Create(statePath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	err = terraform.WriteState(testState(), f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := []struct {
+		Args []string
+		Code int
+	}{
+		// The current and the default workspaces can't be deleted
+		{[]string{"dev"}, 1},
+		{[]string{DefaultWorkspace}, 1},
+		{[]string{"nope"}, 1},
+
+		// The state still has resources
+		{[]string{"prod"}, 1},
+		{[]string{"-force", "prod"}, 0},
+	}
+
+	for _, tc := range cases {
+		ui := new(cli.MockUi)
+		c := &WorkspaceDeleteCommand{Meta: Meta{Ui: ui}}
+		if code := c.Run(tc.Args); code != tc.Code {
+			t.Fatalf("%v: bad: %d\n\n%s%s", tc.Args, code,
+				ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+
+	if _, err := os.Stat(filepath.Dir(statePath)); !os.IsNotExist(err) {
+		t.Fatalf("workspace directory should be deleted: %v", err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &WorkspaceListCommand{Meta: Meta{Ui: ui}}
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if actual := ui.OutputWriter.String(); strings.Contains(actual, "prod") {
+		t.Fatalf("bad: %s", actual)
+	}
+}
+
+func TestWorkspacePath(t *testing.T) {
+	cases := []struct {
+		Path, Workspace, Expected string
+	}{
+		{"terraform.tfstate", "", "terraform.tfstate"},
+		{"terraform.tfstate", DefaultWorkspace, "terraform.tfstate"},
+		{"terraform.tfstate", "prod", "terraform.tfstate.d/prod/terraform.tfstate"},
+		{".terraform/terraform.tfstate", "prod", ".terraform/terraform.tfstate.d/prod/terraform.tfstate"},
+	}
+
+	for _, tc := range cases {
+		actual := workspacePath(tc.Path, tc.Workspace)
+		if actual != filepath.FromSlash(tc.Expected) {
+			t.Fatalf("%s in %q: bad: %s", tc.Path, tc.Workspace, actual)
+		}
+	}
+}
+
+// testWorkspaceShow checks that the current workspace is the given one.
+func testWorkspaceShow(t *testing.T, expected string) {
+	ui := new(cli.MockUi)
+	c := &WorkspaceShowCommand{Meta: Meta{Ui: ui}}
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	if actual := strings.TrimSpace(ui.OutputWriter.String()); actual != expected {
+		t.Fatalf("bad workspace: %q, expected %q", actual, expected)
+	}
+}
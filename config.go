@@ -8,9 +8,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl"
 	"github.com/hashicorp/terraform/command"
 	tfplugin "github.com/hashicorp/terraform/plugin"
@@ -29,6 +31,25 @@ type Config struct {
 
 	DisableCheckpoint          bool `hcl:"disable_checkpoint"`
 	DisableCheckpointSignature bool `hcl:"disable_checkpoint_signature"`
+
+	// PlanHooks are the paths to programs that are run with every plan
+	// shown by the plan command. See command.ProgramPlanHook.
+	PlanHooks []string `hcl:"plan_hooks"`
+
+	// ModuleRegistry is the host of the module registry used for module
+	// sources that don't include one, such as "corp/network/aws".
+	ModuleRegistry string `hcl:"module_registry"`
+
+	// StateHistory is the number of serials of the state kept in its
+	// history. See command.Meta.
+	StateHistory int `hcl:"state_history"`
+
+	// providerVersions are the paths to the provider plugins discovered
+	// with a version in their file name, by name and version.
+	providerVersions map[string]map[string]string
+
+	// pluginClients are the plugin clients by plugin path.
+	pluginClients map[string]*plugin.Client
 }
 
 // BuiltinConfig is the built-in defaults for the configuration. These
@@ -38,6 +59,10 @@ var BuiltinConfig Config
 // ContextOpts are the global ContextOpts we use to initialize the CLI.
 var ContextOpts terraform.ContextOpts
 
+// ProviderPlugins are the provider plugins available to the CLI, with their
+// versions. They are filled in once the plugins are discovered.
+var ProviderPlugins = make(command.ProviderPlugins)
+
 // ConfigFile returns the default path to the configuration file.
 //
 // On Unix-like systems this is the ".terraformrc" file in the home directory.
@@ -88,6 +113,12 @@ func LoadConfig(path string) (*Config, error) {
 //
 // Whichever file is discoverd LAST wins.
 //
+// Provider plugins may have a version in their file name, as in
+// "terraform-provider-aws_v1.2.3". All the versions found are kept so that
+// they can be chosen from with the version constraints of a configuration.
+// If a provider was only found with versions, the highest version is used
+// by default.
+//
 // Finally, we look at the list of plugins compiled into Terraform. If any of
 // them has not been found on disk we use the internal version. This allows
 // users to add / replace plugins without recompiling the main binary.
@@ -119,6 +150,22 @@ func (c *Config) Discover(ui cli.Ui) error {
 		return err
 	}
 
+	// Use the highest version of the providers only found with versions.
+	for name, versions := range c.providerVersions {
+		if _, found := c.Providers[name]; found {
+			continue
+		}
+
+		var newest *version.Version
+		for raw, path := range versions {
+			v := version.Must(version.NewVersion(raw))
+			if newest == nil || v.GreaterThan(newest) {
+				newest = v
+				c.Providers[name] = path
+			}
+		}
+	}
+
 	// Finally, if we have a plugin compiled into Terraform and we didn't find
 	// a replacement on disk, we'll just use the internal version.
 	for name, _ := range command.InternalProviders {
@@ -170,6 +217,29 @@ func (c1 *Config) Merge(c2 *Config) *Config {
 	for k, v := range c2.Provisioners {
 		result.Provisioners[k] = v
 	}
+	result.PlanHooks = append(result.PlanHooks, c1.PlanHooks...)
+	result.PlanHooks = append(result.PlanHooks, c2.PlanHooks...)
+	result.ModuleRegistry = c1.ModuleRegistry
+	if c2.ModuleRegistry != "" {
+		result.ModuleRegistry = c2.ModuleRegistry
+	}
+	result.StateHistory = c1.StateHistory
+	if c2.StateHistory != 0 {
+		result.StateHistory = c2.StateHistory
+	}
+	for _, c := range []*Config{c1, c2} {
+		for name, versions := range c.providerVersions {
+			if result.providerVersions == nil {
+				result.providerVersions = make(map[string]map[string]string)
+			}
+			if result.providerVersions[name] == nil {
+				result.providerVersions[name] = make(map[string]string)
+			}
+			for v, path := range versions {
+				result.providerVersions[name][v] = path
+			}
+		}
+	}
 
 	return &result
 }
@@ -185,13 +255,13 @@ func (c *Config) discover(path string) error {
 	}
 
 	err = c.discoverSingle(
-		filepath.Join(path, "terraform-provider-*"), &c.Providers)
+		filepath.Join(path, "terraform-provider-*"), &c.Providers, &c.providerVersions)
 	if err != nil {
 		return err
 	}
 
 	err = c.discoverSingle(
-		filepath.Join(path, "terraform-provisioner-*"), &c.Provisioners)
+		filepath.Join(path, "terraform-provisioner-*"), &c.Provisioners, nil)
 	if err != nil {
 		return err
 	}
@@ -199,7 +269,11 @@ func (c *Config) discover(path string) error {
 	return nil
 }
 
-func (c *Config) discoverSingle(glob string, m *map[string]string) error {
+// discoverSingle discovers the plugins matching glob. The plugins with a
+// version in their file name are added to versions, if it isn't nil, and
+// the others to m.
+func (c *Config) discoverSingle(
+	glob string, m *map[string]string, versions *map[string]map[string]string) error {
 	matches, err := filepath.Glob(glob)
 	if err != nil {
 		return err
@@ -212,6 +286,17 @@ func (c *Config) discoverSingle(glob string, m *map[string]string) error {
 	for _, match := range matches {
 		file := filepath.Base(match)
 
+		// Look for foo-bar-baz_vX.Y.Z. The version is "X.Y.Z"
+		var v string
+		if sm := pluginVersionRegexp.FindStringSubmatch(file); sm != nil {
+			if _, err := version.NewVersion(sm[2]); err != nil {
+				log.Printf("[WARN] Ignoring plugin with invalid version: %s", match)
+				continue
+			}
+
+			file, v = sm[1], sm[2]
+		}
+
 		// If the filename has a ".", trim up to there
 		if idx := strings.Index(file, "."); idx >= 0 {
 			file = file[:idx]
@@ -223,6 +308,18 @@ func (c *Config) discoverSingle(glob string, m *map[string]string) error {
 			continue
 		}
 
+		if v != "" && versions != nil {
+			log.Printf("[DEBUG] Discovered plugin: %s v%s = %s", parts[2], v, match)
+			if *versions == nil {
+				*versions = make(map[string]map[string]string)
+			}
+			if (*versions)[parts[2]] == nil {
+				(*versions)[parts[2]] = make(map[string]string)
+			}
+			(*versions)[parts[2]][v] = match
+			continue
+		}
+
 		log.Printf("[DEBUG] Discovered plugin: %s = %s", parts[2], match)
 		(*m)[parts[2]] = match
 	}
@@ -230,6 +327,11 @@ func (c *Config) discoverSingle(glob string, m *map[string]string) error {
 	return nil
 }
 
+// pluginVersionRegexp matches the file name of a plugin with a version,
+// as in "terraform-provider-aws_v1.2.3", or "terraform-provider-aws_v1.2.3.exe"
+// on Windows.
+var pluginVersionRegexp = regexp.MustCompile(`^([^_]+)_v([0-9][0-9A-Za-z.+-]*?)(?:\.exe)?$`)
+
 // ProviderFactories returns the mapping of prefixes to
 // ResourceProviderFactory that can be used to instantiate a
 // binary-based plugin.
@@ -242,14 +344,44 @@ func (c *Config) ProviderFactories() map[string]terraform.ResourceProviderFactor
 	return result
 }
 
+// ProviderPlugins returns the provider plugins with their versions. The
+// plugins without a version in their file name have no version, except
+// for the plugins compiled into Terraform which have the version of
+// Terraform.
+func (c *Config) ProviderPlugins() command.ProviderPlugins {
+	result := make(command.ProviderPlugins)
+	versioned := make(map[string]struct{})
+	for name, versions := range c.providerVersions {
+		for raw, path := range versions {
+			result[name] = append(result[name], &command.ProviderPlugin{
+				Version: version.Must(version.NewVersion(raw)),
+				Path:    path,
+				Factory: c.providerFactory(path),
+			})
+			versioned[path] = struct{}{}
+		}
+	}
+
+	for name, path := range c.Providers {
+		if _, ok := versioned[path]; ok {
+			continue
+		}
+
+		plugin := &command.ProviderPlugin{
+			Path:    path,
+			Factory: c.providerFactory(path),
+		}
+		if strings.Contains(path, command.TFSPACE) {
+			plugin.Version = terraform.SemVersion
+		}
+		result[name] = append(result[name], plugin)
+	}
+
+	return result
+}
+
 func (c *Config) providerFactory(path string) terraform.ResourceProviderFactory {
-	// Build the plugin client configuration and init the plugin
-	var config plugin.ClientConfig
-	config.Cmd = pluginCmd(path)
-	config.HandshakeConfig = tfplugin.Handshake
-	config.Managed = true
-	config.Plugins = tfplugin.PluginMap
-	client := plugin.NewClient(&config)
+	client := c.pluginClient(path)
 
 	return func() (terraform.ResourceProvider, error) {
 		// Request the RPC client so we can get the provider
@@ -268,6 +400,17 @@ func (c *Config) providerFactory(path string) terraform.ResourceProviderFactory
 	}
 }
 
+// ProgramPlanHooks returns the plan hooks that run the programs configured
+// in plan_hooks.
+func (c *Config) ProgramPlanHooks() []command.PlanHook {
+	result := make([]command.PlanHook, len(c.PlanHooks))
+	for i, path := range c.PlanHooks {
+		result[i] = &command.ProgramPlanHook{Program: path}
+	}
+
+	return result
+}
+
 // ProvisionerFactories returns the mapping of prefixes to
 // ResourceProvisionerFactory that can be used to instantiate a
 // binary-based plugin.
@@ -281,13 +424,7 @@ func (c *Config) ProvisionerFactories() map[string]terraform.ResourceProvisioner
 }
 
 func (c *Config) provisionerFactory(path string) terraform.ResourceProvisionerFactory {
-	// Build the plugin client configuration and init the plugin
-	var config plugin.ClientConfig
-	config.HandshakeConfig = tfplugin.Handshake
-	config.Cmd = pluginCmd(path)
-	config.Managed = true
-	config.Plugins = tfplugin.PluginMap
-	client := plugin.NewClient(&config)
+	client := c.pluginClient(path)
 
 	return func() (terraform.ResourceProvisioner, error) {
 		rpcClient, err := client.Client()
@@ -304,6 +441,30 @@ func (c *Config) provisionerFactory(path string) terraform.ResourceProvisionerFa
 	}
 }
 
+// pluginClient returns the client for the plugin at the given path. The
+// clients are shared, so that each plugin runs in a single process, which
+// is started when the plugin is first used and reused by all the factories
+// for it and all the graph walks of a command.
+func (c *Config) pluginClient(path string) *plugin.Client {
+	if client, ok := c.pluginClients[path]; ok {
+		return client
+	}
+
+	// Build the plugin client configuration and init the plugin
+	var config plugin.ClientConfig
+	config.Cmd = pluginCmd(path)
+	config.HandshakeConfig = tfplugin.Handshake
+	config.Managed = true
+	config.Plugins = tfplugin.PluginMap
+	client := plugin.NewClient(&config)
+
+	if c.pluginClients == nil {
+		c.pluginClients = make(map[string]*plugin.Client)
+	}
+	c.pluginClients[path] = client
+	return client
+}
+
 func pluginCmd(path string) *exec.Cmd {
 	cmdPath := ""
 
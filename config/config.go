@@ -5,10 +5,12 @@ package config
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hil"
 	"github.com/hashicorp/hil/ast"
 	"github.com/mitchellh/mapstructure"
@@ -32,6 +34,7 @@ type Config struct {
 	ProviderConfigs []*ProviderConfig
 	Resources       []*Resource
 	Variables       []*Variable
+	Locals          []*Local
 	Outputs         []*Output
 
 	// The fields below can be filled in by loaders for validation
@@ -54,6 +57,14 @@ type Module struct {
 	Name      string
 	Source    string
 	RawConfig *RawConfig
+
+	// Version is the constraint on the version of a module from a
+	// registry, such as "~> 2.1". It is empty to allow any version.
+	Version string
+
+	// DependsOn are the resources and modules, as "module.NAME", that
+	// all the resources of the module depend on.
+	DependsOn []string
 }
 
 // ProviderConfig is the configuration for a resource provider.
@@ -63,6 +74,7 @@ type Module struct {
 type ProviderConfig struct {
 	Name      string
 	Alias     string
+	Version   string
 	RawConfig *RawConfig
 }
 
@@ -75,6 +87,7 @@ type Resource struct {
 	Name         string
 	Type         string
 	RawCount     *RawConfig
+	RawForEach   *RawConfig // nil if the resource doesn't have for_each
 	RawConfig    *RawConfig
 	Provisioners []*Provisioner
 	Provider     string
@@ -97,6 +110,9 @@ func (r *Resource) Copy() *Resource {
 		DependsOn:    make([]string, len(r.DependsOn)),
 		Lifecycle:    *r.Lifecycle.Copy(),
 	}
+	if r.RawForEach != nil {
+		n.RawForEach = r.RawForEach.Copy()
+	}
 	for _, p := range r.Provisioners {
 		n.Provisioners = append(n.Provisioners, p.Copy())
 	}
@@ -128,6 +144,7 @@ type Provisioner struct {
 	Type      string
 	RawConfig *RawConfig
 	ConnInfo  *RawConfig
+	When      ProvisionerWhen
 }
 
 // Copy returns a copy of this Provisioner
@@ -136,6 +153,7 @@ func (p *Provisioner) Copy() *Provisioner {
 		Type:      p.Type,
 		RawConfig: p.RawConfig.Copy(),
 		ConnInfo:  p.ConnInfo.Copy(),
+		When:      p.When,
 	}
 }
 
@@ -147,6 +165,13 @@ type Variable struct {
 	Description  string
 }
 
+// Local is a local value defined within the configuration, in a "locals"
+// block. Its value is under the "value" key of RawConfig.
+type Local struct {
+	Name      string
+	RawConfig *RawConfig
+}
+
 // Output is an output defined within the configuration. An output is
 // resulting data that is highlighted by Terraform when finished. An
 // output marked Sensitive will be output in a masked form following
@@ -211,6 +236,30 @@ func (r *Resource) Count() (int, error) {
 	return int(v), nil
 }
 
+// ForEach returns the map that the instances of this resource are created
+// for, one per key. It is nil if the resource doesn't have for_each.
+func (r *Resource) ForEach() (map[string]interface{}, error) {
+	if r.RawForEach == nil {
+		return nil, nil
+	}
+
+	switch v := r.RawForEach.Value().(type) {
+	case map[string]interface{}:
+		return v, nil
+	case []map[string]interface{}:
+		// Maps written in the configuration are decoded as a list of maps
+		result := make(map[string]interface{})
+		for _, m := range v {
+			for k, v := range m {
+				result[k] = v
+			}
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("for_each must be a map, got %#v", v)
+	}
+}
+
 // A unique identifier for this resource.
 func (r *Resource) Id() string {
 	switch r.Mode {
@@ -223,6 +272,25 @@ func (r *Resource) Id() string {
 	}
 }
 
+// variables returns the variables of the configuration and provisioners
+// of this resource.
+func (r *Resource) variables() []InterpolatedVariable {
+	result := make([]InterpolatedVariable, 0, len(r.RawConfig.Variables))
+	for _, v := range r.RawConfig.Variables {
+		result = append(result, v)
+	}
+	for _, p := range r.Provisioners {
+		for _, v := range p.ConnInfo.Variables {
+			result = append(result, v)
+		}
+		for _, v := range p.RawConfig.Variables {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
 // Validate does some basic semantic checking of the configuration.
 func (c *Config) Validate() error {
 	if c == nil {
@@ -286,6 +354,36 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Check that local values aren't declared multiple times, and that
+	// all references to local values are valid.
+	locals := make(map[string]*Local)
+	for _, l := range c.Locals {
+		if _, ok := locals[l.Name]; ok {
+			errs = append(errs, fmt.Errorf(
+				"local.%s: declared multiple times, you can only declare a local value once",
+				l.Name))
+			continue
+		}
+
+		locals[l.Name] = l
+	}
+
+	for source, vs := range vars {
+		for _, v := range vs {
+			lv, ok := v.(*LocalVariable)
+			if !ok {
+				continue
+			}
+
+			if _, ok := locals[lv.Name]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s: unknown local value referenced: '%s'. define it with 'locals' blocks",
+					source,
+					lv.Name))
+			}
+		}
+	}
+
 	// Check that all count variables are valid.
 	for source, vs := range vars {
 		for _, rawV := range vs {
@@ -297,6 +395,13 @@ func (c *Config) Validate() error {
 						source,
 						v.FullKey()))
 				}
+			case *EachVariable:
+				if v.Type == EachValueInvalid {
+					errs = append(errs, fmt.Errorf(
+						"%s: invalid each variable: %s",
+						source,
+						v.FullKey()))
+				}
 			case *PathVariable:
 				if v.Type == PathValueInvalid {
 					errs = append(errs, fmt.Errorf(
@@ -304,6 +409,13 @@ func (c *Config) Validate() error {
 						source,
 						v.FullKey()))
 				}
+			case *TerraformVariable:
+				if v.Type == TerraformValueInvalid {
+					errs = append(errs, fmt.Errorf(
+						"%s: invalid terraform variable: %s",
+						source,
+						v.FullKey()))
+				}
 			}
 		}
 	}
@@ -320,6 +432,14 @@ func (c *Config) Validate() error {
 		}
 
 		providerSet[name] = struct{}{}
+
+		if p.Version != "" {
+			if _, err := version.NewConstraint(p.Version); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"provider.%s: invalid version constraint %q: %s",
+					name, p.Version, err))
+			}
+		}
 	}
 
 	// Check that all references to modules are valid
@@ -356,6 +476,14 @@ func (c *Config) Validate() error {
 				m.Id()))
 		}
 
+		if m.Version != "" {
+			if _, err := version.NewConstraint(m.Version); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"%s: invalid version constraint %q: %s",
+					m.Id(), m.Version, err))
+			}
+		}
+
 		// Check that the name matches our regexp
 		if !NameRegexp.Match([]byte(m.Name)) {
 			errs = append(errs, fmt.Errorf(
@@ -396,6 +524,9 @@ func (c *Config) Validate() error {
 			case *CountVariable:
 				errs = append(errs, fmt.Errorf(
 					"%s: count variables are only valid within resources", m.Name))
+			case *EachVariable:
+				errs = append(errs, fmt.Errorf(
+					"%s: each variables are only valid within resources", m.Name))
 			case *SelfVariable:
 				errs = append(errs, fmt.Errorf(
 					"%s: self variables are only valid within resources", m.Name))
@@ -458,6 +589,11 @@ func (c *Config) Validate() error {
 					"%s: resource count can't reference count variable: %s",
 					n,
 					v.FullKey()))
+			case *LocalVariable:
+				errs = append(errs, fmt.Errorf(
+					"%s: resource count can't reference local value: %s",
+					n,
+					v.FullKey()))
 			case *ModuleVariable:
 				errs = append(errs, fmt.Errorf(
 					"%s: resource count can't reference module variable: %s",
@@ -497,6 +633,45 @@ func (c *Config) Validate() error {
 		}
 		r.RawCount.init()
 
+		if r.RawForEach != nil {
+			if r.RawCount.Value() != "1" {
+				errs = append(errs, fmt.Errorf(
+					"%s: resource can't have both count and for_each",
+					n))
+			}
+
+			// Verify for_each variables
+			for _, v := range r.RawForEach.Variables {
+				if _, ok := v.(*UserVariable); !ok {
+					errs = append(errs, fmt.Errorf(
+						"%s: resource for_each can only reference variables, not: %s",
+						n,
+						v.FullKey()))
+				}
+			}
+		}
+
+		// Verify that count and each variables are used with count and
+		// for_each respectively.
+		for _, v := range r.variables() {
+			switch v.(type) {
+			case *CountVariable:
+				if r.RawForEach != nil {
+					errs = append(errs, fmt.Errorf(
+						"%s: count variables can't be used with for_each, use each.key: %s",
+						n,
+						v.FullKey()))
+				}
+			case *EachVariable:
+				if r.RawForEach == nil {
+					errs = append(errs, fmt.Errorf(
+						"%s: each variables are only valid within resources with for_each: %s",
+						n,
+						v.FullKey()))
+				}
+			}
+		}
+
 		// Verify depends on points to resources that all exist
 		for _, d := range r.DependsOn {
 			// Check if we contain interpolations
@@ -521,8 +696,8 @@ func (c *Config) Validate() error {
 		if r.Provider != "" {
 			if _, ok := providerSet[r.Provider]; !ok {
 				errs = append(errs, fmt.Errorf(
-					"%s: resource depends on non-configured provider '%s'",
-					n, r.Provider))
+					"%s: resource depends on non-configured provider '%s'%s",
+					n, r.Provider, c.providerAliasHint(r.Provider)))
 			}
 		}
 
@@ -561,6 +736,41 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Verify module depends on points to resources and modules that exist
+	for _, m := range c.Modules {
+		for _, d := range m.DependsOn {
+			// Check if we contain interpolations
+			rc, err := NewRawConfig(map[string]interface{}{
+				"value": d,
+			})
+			if err == nil && len(rc.Variables) > 0 {
+				errs = append(errs, fmt.Errorf(
+					"%s: depends on value cannot contain interpolations: %s",
+					m.Id(), d))
+				continue
+			}
+
+			if strings.HasPrefix(d, "module.") {
+				name := d[len("module."):]
+				if name == m.Name {
+					errs = append(errs, fmt.Errorf(
+						"%s: module can't depend on itself", m.Id()))
+				} else if _, ok := modules[name]; !ok {
+					errs = append(errs, fmt.Errorf(
+						"%s: module depends on non-existent module '%s'",
+						m.Id(), name))
+				}
+				continue
+			}
+
+			if _, ok := resources[d]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s: module depends on non-existent resource '%s'",
+					m.Id(), d))
+			}
+		}
+	}
+
 	for source, vs := range vars {
 		for _, v := range vs {
 			rv, ok := v.(*ResourceVariable)
@@ -615,9 +825,32 @@ func (c *Config) Validate() error {
 		}
 
 		for _, v := range o.RawConfig.Variables {
-			if _, ok := v.(*CountVariable); ok {
+			switch v.(type) {
+			case *CountVariable:
 				errs = append(errs, fmt.Errorf(
 					"%s: count variables are only valid within resources", o.Name))
+			case *EachVariable:
+				errs = append(errs, fmt.Errorf(
+					"%s: each variables are only valid within resources", o.Name))
+			}
+		}
+	}
+
+	// Check that all local values are valid
+	for _, l := range c.Locals {
+		for _, v := range l.RawConfig.Variables {
+			switch v := v.(type) {
+			case *CountVariable:
+				errs = append(errs, fmt.Errorf(
+					"local.%s: count variables are only valid within resources", l.Name))
+			case *EachVariable:
+				errs = append(errs, fmt.Errorf(
+					"local.%s: each variables are only valid within resources", l.Name))
+			case *LocalVariable:
+				if v.Name == l.Name {
+					errs = append(errs, fmt.Errorf(
+						"local.%s: local value can't reference itself", l.Name))
+				}
 			}
 		}
 	}
@@ -656,6 +889,31 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// providerAliasHint returns a hint listing the configured providers of the
+// same type as the given missing provider, to help spot a misspelled or
+// undeclared alias.
+func (c *Config) providerAliasHint(name string) string {
+	typ := name
+	if idx := strings.Index(name, "."); idx != -1 {
+		typ = name[:idx]
+	}
+
+	var names []string
+	for _, p := range c.ProviderConfigs {
+		if p.Name == typ {
+			names = append(names, p.FullName())
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	return fmt.Sprintf(
+		". Configured %q providers in this module: %s",
+		typ, strings.Join(names, ", "))
+}
+
 // InterpolatedVariables is a helper that returns a mapping of all the interpolated
 // variables within the configuration. This is used to verify references
 // are valid in the Validate step.
@@ -686,6 +944,9 @@ func (c *Config) rawConfigs() map[string]*RawConfig {
 	for _, rc := range c.Resources {
 		source := fmt.Sprintf("resource '%s'", rc.Id())
 		result[source+" count"] = rc.RawCount
+		if rc.RawForEach != nil {
+			result[source+" for_each"] = rc.RawForEach
+		}
 		result[source+" config"] = rc.RawConfig
 
 		for i, p := range rc.Provisioners {
@@ -696,6 +957,11 @@ func (c *Config) rawConfigs() map[string]*RawConfig {
 		}
 	}
 
+	for _, l := range c.Locals {
+		source := fmt.Sprintf("local '%s'", l.Name)
+		result[source] = l.RawConfig
+	}
+
 	for _, o := range c.Outputs {
 		source := fmt.Sprintf("output '%s'", o.Name)
 		result[source] = o.RawConfig
@@ -769,6 +1035,26 @@ func (m *Module) mergerMerge(other merger) merger {
 	if m2.Source != "" {
 		result.Source = m2.Source
 	}
+	if m2.Version != "" {
+		result.Version = m2.Version
+	}
+	if len(m2.DependsOn) > 0 {
+		result.DependsOn = m2.DependsOn
+	}
+
+	return &result
+}
+
+func (l *Local) mergerName() string {
+	return l.Name
+}
+
+func (l *Local) mergerMerge(m merger) merger {
+	l2 := m.(*Local)
+
+	result := *l
+	result.Name = l2.Name
+	result.RawConfig = result.RawConfig.merge(l2.RawConfig)
 
 	return &result
 }
@@ -810,6 +1096,10 @@ func (c *ProviderConfig) mergerMerge(m merger) merger {
 	result.Name = c2.Name
 	result.RawConfig = result.RawConfig.merge(c2.RawConfig)
 
+	if c2.Version != "" {
+		result.Version = c2.Version
+	}
+
 	return &result
 }
 
@@ -829,6 +1119,9 @@ func (r *Resource) mergerMerge(m merger) merger {
 	if r2.RawCount.Value() != "1" {
 		result.RawCount = r2.RawCount
 	}
+	if r2.RawForEach != nil {
+		result.RawForEach = r2.RawForEach
+	}
 
 	if len(r2.Provisioners) > 0 {
 		result.Provisioners = r2.Provisioners
@@ -882,7 +1175,7 @@ func (v *Variable) ValidateTypeAndDefault() error {
 	// If an explicit type is declared, ensure it is valid
 	if v.DeclaredType != "" {
 		if _, ok := typeStringMap[v.DeclaredType]; !ok {
-			return fmt.Errorf("Variable '%s' must be of type string or map - '%s' is not a valid type", v.Name, v.DeclaredType)
+			return fmt.Errorf("Variable '%s' must be of type string, map or list - '%s' is not a valid type", v.Name, v.DeclaredType)
 		}
 	}
 
@@ -936,6 +1229,15 @@ func (v *Variable) inferTypeFromDefault() VariableType {
 		return VariableTypeList
 	}
 
+	// Maps and lists may also contain other maps and lists, such as a
+	// list of maps.
+	switch v.Default.(type) {
+	case map[string]interface{}:
+		return VariableTypeMap
+	case []interface{}:
+		return VariableTypeList
+	}
+
 	return VariableTypeUnknown
 }
 
@@ -41,6 +41,12 @@ func (c *Config) TestString() string {
 		buf.WriteString("\n\n")
 	}
 
+	if len(c.Locals) > 0 {
+		buf.WriteString("Locals:\n\n")
+		buf.WriteString(localsStr(c.Locals))
+		buf.WriteString("\n\n")
+	}
+
 	if len(c.Outputs) > 0 {
 		buf.WriteString("Outputs:\n\n")
 		buf.WriteString(outputsStr(c.Outputs))
@@ -76,10 +82,58 @@ func modulesStr(ms []*Module) string {
 		sort.Strings(ks)
 
 		result += fmt.Sprintf("  source = %s\n", m.Source)
+		if m.Version != "" {
+			result += fmt.Sprintf("  version = %s\n", m.Version)
+		}
 
 		for _, k := range ks {
 			result += fmt.Sprintf("  %s\n", k)
 		}
+
+		if len(m.DependsOn) > 0 {
+			result += fmt.Sprintf("  dependsOn\n")
+			for _, d := range m.DependsOn {
+				result += fmt.Sprintf("    %s\n", d)
+			}
+		}
+	}
+
+	return strings.TrimSpace(result)
+}
+
+func localsStr(ls []*Local) string {
+	ns := make([]string, 0, len(ls))
+	m := make(map[string]*Local)
+	for _, l := range ls {
+		ns = append(ns, l.Name)
+		m[l.Name] = l
+	}
+	sort.Strings(ns)
+
+	result := ""
+	for _, n := range ns {
+		l := m[n]
+
+		result += fmt.Sprintf("%s\n", n)
+
+		if len(l.RawConfig.Variables) > 0 {
+			result += fmt.Sprintf("  vars\n")
+			for _, rawV := range l.RawConfig.Variables {
+				kind := "unknown"
+				str := rawV.FullKey()
+
+				switch rawV.(type) {
+				case *LocalVariable:
+					kind = "local"
+				case *ResourceVariable:
+					kind = "resource"
+				case *UserVariable:
+					kind = "user"
+				}
+
+				result += fmt.Sprintf("    %s: %s\n", kind, str)
+			}
+		}
 	}
 
 	return strings.TrimSpace(result)
@@ -107,6 +161,8 @@ func outputsStr(os []*Output) string {
 				str := rawV.FullKey()
 
 				switch rawV.(type) {
+				case *LocalVariable:
+					kind = "local"
 				case *ResourceVariable:
 					kind = "resource"
 				case *UserVariable:
@@ -156,6 +212,8 @@ func providerConfigsStr(pcs []*ProviderConfig) string {
 				str := rawV.FullKey()
 
 				switch rawV.(type) {
+				case *LocalVariable:
+					kind = "local"
 				case *ResourceVariable:
 					kind = "resource"
 				case *UserVariable:
@@ -207,7 +265,11 @@ func resourcesStr(rs []*Resource) string {
 		if len(r.Provisioners) > 0 {
 			result += fmt.Sprintf("  provisioners\n")
 			for _, p := range r.Provisioners {
-				result += fmt.Sprintf("    %s\n", p.Type)
+				if p.When == ProvisionerWhenDestroy {
+					result += fmt.Sprintf("    %s (destroy)\n", p.Type)
+				} else {
+					result += fmt.Sprintf("    %s\n", p.Type)
+				}
 
 				ks := make([]string, 0, len(p.RawConfig.Raw))
 				for k, _ := range p.RawConfig.Raw {
@@ -243,6 +305,8 @@ func resourcesStr(rs []*Resource) string {
 				str := rawV.FullKey()
 
 				switch rawV.(type) {
+				case *LocalVariable:
+					kind = "local"
 				case *ResourceVariable:
 					kind = "resource"
 				case *UserVariable:
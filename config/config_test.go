@@ -178,6 +178,20 @@ func TestConfigValidate_dependsOnVar(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_eachVarInvalid(t *testing.T) {
+	c := testConfig(t, "validate-each-var-invalid")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_eachVarNoForEach(t *testing.T) {
+	c := testConfig(t, "validate-each-var-no-for-each")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_dupModule(t *testing.T) {
 	c := testConfig(t, "validate-dup-module")
 	if err := c.Validate(); err == nil {
@@ -192,6 +206,62 @@ func TestConfigValidate_dupResource(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_forEach(t *testing.T) {
+	c := testConfig(t, "validate-for-each")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_forEachCount(t *testing.T) {
+	c := testConfig(t, "validate-for-each-count")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_forEachCountVar(t *testing.T) {
+	c := testConfig(t, "validate-for-each-count-var")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_forEachResourceVar(t *testing.T) {
+	c := testConfig(t, "validate-for-each-resource-var")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_moduleDependsOn(t *testing.T) {
+	c := testConfig(t, "validate-module-depends-on")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_moduleDependsOnBadModule(t *testing.T) {
+	c := testConfig(t, "validate-module-depends-on-bad-module")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_moduleDependsOnBadResource(t *testing.T) {
+	c := testConfig(t, "validate-module-depends-on-bad-resource")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_moduleDependsOnSelf(t *testing.T) {
+	c := testConfig(t, "validate-module-depends-on-self")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_moduleNameBad(t *testing.T) {
 	c := testConfig(t, "validate-module-name-bad")
 	if err := c.Validate(); err == nil {
@@ -206,6 +276,13 @@ func TestConfigValidate_moduleSourceVar(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_moduleVersionBad(t *testing.T) {
+	c := testConfig(t, "validate-module-version-bad")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_moduleVarInt(t *testing.T) {
 	c := testConfig(t, "validate-module-var-int")
 	if err := c.Validate(); err != nil {
@@ -241,6 +318,41 @@ func TestConfigValidate_nil(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_local(t *testing.T) {
+	c := testConfig(t, "validate-local-value")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_localCount(t *testing.T) {
+	c := testConfig(t, "validate-local-count")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_localDup(t *testing.T) {
+	c := testConfig(t, "validate-local-dup")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_localSelf(t *testing.T) {
+	c := testConfig(t, "validate-local-self")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_localUnknown(t *testing.T) {
+	c := testConfig(t, "validate-local-unknown")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_outputBadField(t *testing.T) {
 	c := testConfig(t, "validate-output-bad-field")
 	if err := c.Validate(); err == nil {
@@ -262,6 +374,20 @@ func TestConfigValidate_pathVarInvalid(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_terraformVar(t *testing.T) {
+	c := testConfig(t, "validate-terraform-var")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_terraformVarInvalid(t *testing.T) {
+	c := testConfig(t, "validate-terraform-var-invalid")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_providerMulti(t *testing.T) {
 	c := testConfig(t, "validate-provider-multi")
 	if err := c.Validate(); err == nil {
@@ -285,11 +411,29 @@ func TestConfigValidate_providerMultiRefGood(t *testing.T) {
 
 func TestConfigValidate_providerMultiRefBad(t *testing.T) {
 	c := testConfig(t, "validate-provider-multi-ref-bad")
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("should not be valid")
+	}
+	if !strings.Contains(err.Error(), `Configured "aws" providers in this module: aws.bar`) {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestConfigValidate_providerVersionBad(t *testing.T) {
+	c := testConfig(t, "validate-provider-version-bad")
 	if err := c.Validate(); err == nil {
 		t.Fatal("should not be valid")
 	}
 }
 
+func TestConfigValidate_providerVersionGood(t *testing.T) {
+	c := testConfig(t, "validate-provider-version-good")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("should be valid: %s", err)
+	}
+}
+
 func TestConfigValidate_provConnSplatOther(t *testing.T) {
 	c := testConfig(t, "validate-prov-conn-splat-other")
 	if err := c.Validate(); err != nil {
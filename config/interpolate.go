@@ -31,6 +31,30 @@ const (
 	CountValueIndex
 )
 
+// EachVariable is a variable for referencing the key or the value of the
+// instance of a resource with for_each: "${each.key}", "${each.value}".
+type EachVariable struct {
+	Type EachValueType
+	key  string
+}
+
+// EachValueType is the type of the each variable that is referenced.
+type EachValueType byte
+
+const (
+	EachValueInvalid EachValueType = iota
+	EachValueKey
+	EachValueValue
+)
+
+// A LocalVariable is a variable that references a local value defined
+// within the current module, via a "locals" block. This looks like
+// "${local.foo}".
+type LocalVariable struct {
+	Name string
+	key  string
+}
+
 // A ModuleVariable is a variable that is referencing the output
 // of a module, such as "${module.foo.bar}"
 type ModuleVariable struct {
@@ -66,6 +90,10 @@ type ResourceVariable struct {
 	Multi bool // True if multi-variable: aws_instance.foo.*.id
 	Index int  // Index for multi-variable: aws_instance.foo.1.id == 1
 
+	// Key is the key of an instance of a resource with for_each. It isn't
+	// set from the configuration, but for self variables.
+	Key string
+
 	key string
 }
 
@@ -77,6 +105,20 @@ type SelfVariable struct {
 	key string
 }
 
+// A TerraformVariable is a variable that references information about
+// Terraform itself, such as "${terraform.workspace}".
+type TerraformVariable struct {
+	Type TerraformValueType
+	key  string
+}
+
+type TerraformValueType byte
+
+const (
+	TerraformValueInvalid TerraformValueType = iota
+	TerraformValueWorkspace
+)
+
 // SimpleVariable is an unprefixed variable, which can show up when users have
 // strings they are passing down to resources that use interpolation
 // internally. The template_file resource is an example of this.
@@ -97,12 +139,18 @@ type UserVariable struct {
 func NewInterpolatedVariable(v string) (InterpolatedVariable, error) {
 	if strings.HasPrefix(v, "count.") {
 		return NewCountVariable(v)
+	} else if strings.HasPrefix(v, "each.") {
+		return NewEachVariable(v)
 	} else if strings.HasPrefix(v, "path.") {
 		return NewPathVariable(v)
 	} else if strings.HasPrefix(v, "self.") {
 		return NewSelfVariable(v)
+	} else if strings.HasPrefix(v, "terraform.") {
+		return NewTerraformVariable(v)
 	} else if strings.HasPrefix(v, "var.") {
 		return NewUserVariable(v)
+	} else if strings.HasPrefix(v, "local.") {
+		return NewLocalVariable(v)
 	} else if strings.HasPrefix(v, "module.") {
 		return NewModuleVariable(v)
 	} else if !strings.ContainsRune(v, '.') {
@@ -130,6 +178,47 @@ func (c *CountVariable) FullKey() string {
 	return c.key
 }
 
+func NewEachVariable(key string) (*EachVariable, error) {
+	var fieldType EachValueType
+	parts := strings.SplitN(key, ".", 2)
+	switch parts[1] {
+	case "key":
+		fieldType = EachValueKey
+	case "value":
+		fieldType = EachValueValue
+	}
+
+	return &EachVariable{
+		Type: fieldType,
+		key:  key,
+	}, nil
+}
+
+func (c *EachVariable) FullKey() string {
+	return c.key
+}
+
+func NewLocalVariable(key string) (*LocalVariable, error) {
+	name := key[len("local."):]
+	if idx := strings.Index(name, "."); idx > -1 {
+		return nil, fmt.Errorf(
+			"%s: local value names must not contain dots", key)
+	}
+
+	return &LocalVariable{
+		Name: name,
+		key:  key,
+	}, nil
+}
+
+func (v *LocalVariable) FullKey() string {
+	return v.key
+}
+
+func (v *LocalVariable) GoString() string {
+	return fmt.Sprintf("*%#v", *v)
+}
+
 func NewModuleVariable(key string) (*ModuleVariable, error) {
 	parts := strings.SplitN(key, ".", 3)
 	if len(parts) < 3 {
@@ -171,6 +260,24 @@ func (v *PathVariable) FullKey() string {
 	return v.key
 }
 
+func NewTerraformVariable(key string) (*TerraformVariable, error) {
+	var fieldType TerraformValueType
+	parts := strings.SplitN(key, ".", 2)
+	switch parts[1] {
+	case "workspace":
+		fieldType = TerraformValueWorkspace
+	}
+
+	return &TerraformVariable{
+		Type: fieldType,
+		key:  key,
+	}, nil
+}
+
+func (v *TerraformVariable) FullKey() string {
+	return v.key
+}
+
 func NewResourceVariable(key string) (*ResourceVariable, error) {
 	var mode ResourceMode
 	var parts []string
@@ -48,9 +48,10 @@ func listVariableValueToStringSlice(values []ast.Variable) ([]string, error) {
 	return output, nil
 }
 
-// Funcs is the mapping of built-in functions for configuration.
+// Funcs is the mapping of built-in functions for configuration, including
+// the ones implementing the operators, see ParseInterpolations.
 func Funcs() map[string]ast.Function {
-	return map[string]ast.Function{
+	funcs := map[string]ast.Function{
 		"base64decode": interpolationFuncBase64Decode(),
 		"base64encode": interpolationFuncBase64Encode(),
 		"base64sha256": interpolationFuncBase64Sha256(),
@@ -79,6 +80,11 @@ func Funcs() map[string]ast.Function {
 		"trimspace":    interpolationFuncTrimSpace(),
 		"upper":        interpolationFuncUpper(),
 	}
+	for k, v := range operatorFuncs() {
+		funcs[k] = v
+	}
+
+	return funcs
 }
 
 // interpolationFuncCompact strips a list of multi-variable values
@@ -46,6 +46,17 @@ func TestInterpolateFuncCidrHost(t *testing.T) {
 				"192.168.1.5",
 				false,
 			},
+			{
+				// Both ends of a point-to-point link, e.g. a VIF
+				`${cidrhost(cidrsubnet("169.254.0.0/16", 14, 5), 1)}`,
+				"169.254.0.21",
+				false,
+			},
+			{
+				`${cidrhost(cidrsubnet("169.254.0.0/16", 14, 5), 2)}`,
+				"169.254.0.22",
+				false,
+			},
 			{
 				`${cidrhost("192.168.1.0/30", 255)}`,
 				nil,
@@ -1007,6 +1018,151 @@ func TestInterpolateFuncUUID(t *testing.T) {
 	}
 }
 
+func TestInterpolateConditional(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Vars: map[string]ast.Variable{
+			"var.env": ast.Variable{
+				Value: "prod",
+				Type:  ast.TypeString,
+			},
+			"var.count": ast.Variable{
+				Value: "3",
+				Type:  ast.TypeString,
+			},
+			"var.enabled": ast.Variable{
+				Value: "true",
+				Type:  ast.TypeString,
+			},
+			"var.legacy": ast.Variable{
+				Value: "1",
+				Type:  ast.TypeString,
+			},
+			"var.version": ast.Variable{
+				Value: "2.0",
+				Type:  ast.TypeString,
+			},
+			"var.empty": ast.Variable{
+				Value: []ast.Variable{},
+				Type:  ast.TypeList,
+			},
+			"var.zones": ast.Variable{
+				Value: []ast.Variable{
+					{Value: "a", Type: ast.TypeString},
+					{Value: "b", Type: ast.TypeString},
+				},
+				Type: ast.TypeList,
+			},
+		},
+		Cases: []testFunctionCase{
+			{
+				`${var.env == "prod" ? 10 : 1}`,
+				"10",
+				false,
+			},
+			{
+				`${var.env != "prod" ? 10 : 1}`,
+				"1",
+				false,
+			},
+			{
+				`${var.count > 2 ? "many" : "few"}`,
+				"many",
+				false,
+			},
+			{
+				`${var.count <= 2.5 ? "few" : "many"}`,
+				"many",
+				false,
+			},
+			{
+				`${var.enabled ? "on" : "off"}`,
+				"on",
+				false,
+			},
+			{
+				`${!var.enabled ? "on" : "off"}`,
+				"off",
+				false,
+			},
+			{
+				`${var.enabled && var.env == "dev" || var.count == 3}`,
+				"true",
+				false,
+			},
+			{
+				`${var.env == "dev" ? "d" : var.env == "prod" ? "p" : "x"}`,
+				"p",
+				false,
+			},
+			{
+				`${"true" ? var.count : upper(var.env)}`,
+				"3",
+				false,
+			},
+
+			// Numbers are compared as numbers whichever side they're on
+			{
+				`${var.version == 2}`,
+				"true",
+				false,
+			},
+			{
+				`${2 == var.version}`,
+				"true",
+				false,
+			},
+			{
+				`${var.version != "2"}`,
+				"false",
+				false,
+			},
+			{
+				`${var.env == 2}`,
+				"false",
+				false,
+			},
+
+			// The result has the type of the selected branch
+			{
+				`${var.env == "prod" ? var.zones : var.empty}`,
+				[]interface{}{"a", "b"},
+				false,
+			},
+
+			// Only the selected branch is evaluated
+			{
+				`${length(var.empty) > 0 ? element(var.empty, 0) : "none"}`,
+				"none",
+				false,
+			},
+
+			// The condition must be a bool
+			{
+				`${var.env ? 1 : 0}`,
+				nil,
+				true,
+			},
+			{
+				`${var.legacy ? 1 : 0}`,
+				nil,
+				true,
+			},
+			{
+				`${true ? 1 : 0}`,
+				nil,
+				true,
+			},
+
+			// Only numbers can be ordered
+			{
+				`${var.env < "prod"}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
 type testFunctionConfig struct {
 	Cases []testFunctionCase
 	Vars  map[string]ast.Variable
@@ -1020,12 +1176,12 @@ type testFunctionCase struct {
 
 func testFunction(t *testing.T, config testFunctionConfig) {
 	for i, tc := range config.Cases {
-		ast, err := hil.Parse(tc.Input)
+		ast, err := ParseInterpolations(tc.Input)
 		if err != nil {
 			t.Fatalf("Case #%d: input: %#v\nerr: %s", i, tc.Input, err)
 		}
 
-		result, err := hil.Eval(ast, langEvalConfig(config.Vars))
+		result, err := EvalInterpolations(ast, langEvalConfig(config.Vars))
 		if err != nil != tc.Error {
 			t.Fatalf("Case #%d:\ninput: %#v\nerr: %s", i, tc.Input, err)
 		}
@@ -0,0 +1,754 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hil"
+	"github.com/hashicorp/hil/ast"
+)
+
+// HIL has no syntax for conditionals, comparisons or boolean logic, so
+// interpolations using them are rewritten before they're parsed: each
+// operator becomes a call to the function implementing it. For example
+//
+//	${var.env == "prod" ? 2 : 1}
+//
+// is parsed as
+//
+//	${__builtin_Conditional(__builtin_Equal(var.env, "prod"), 2, 1)}
+//
+// Everything else is copied as is, so that HIL parses it exactly as
+// written, and strings without any of these operators are left untouched.
+//
+// Conditionals are the exception: there's no function for them, as HIL
+// evaluates all the arguments of a call. EvalInterpolations replaces each
+// of them with the branch selected by its condition before evaluating the
+// result, so that only that branch is evaluated, and the conditional has
+// the type of that branch.
+const (
+	operatorFuncConditional   = "__builtin_Conditional"
+	operatorFuncEqual         = "__builtin_Equal"
+	operatorFuncNotEqual      = "__builtin_NotEqual"
+	operatorFuncLessThan      = "__builtin_LessThan"
+	operatorFuncLessThanEq    = "__builtin_LessThanOrEqual"
+	operatorFuncGreaterThan   = "__builtin_GreaterThan"
+	operatorFuncGreaterThanEq = "__builtin_GreaterThanOrEqual"
+	operatorFuncLogicalAnd    = "__builtin_LogicalAnd"
+	operatorFuncLogicalOr     = "__builtin_LogicalOr"
+	operatorFuncLogicalNot    = "__builtin_LogicalNot"
+)
+
+// operatorLexEOF is returned by the lexer at the end of the input.
+const operatorLexEOF rune = 0
+
+// operatorFuncs returns the functions implementing the operators, to be
+// added to Funcs.
+func operatorFuncs() map[string]ast.Function {
+	return map[string]ast.Function{
+		operatorFuncEqual:         interpolationFuncEqual(false),
+		operatorFuncNotEqual:      interpolationFuncEqual(true),
+		operatorFuncLessThan:      interpolationFuncCompare(func(a, b float64) bool { return a < b }),
+		operatorFuncLessThanEq:    interpolationFuncCompare(func(a, b float64) bool { return a <= b }),
+		operatorFuncGreaterThan:   interpolationFuncCompare(func(a, b float64) bool { return a > b }),
+		operatorFuncGreaterThanEq: interpolationFuncCompare(func(a, b float64) bool { return a >= b }),
+		operatorFuncLogicalAnd:    interpolationFuncLogical(func(a, b bool) bool { return a && b }),
+		operatorFuncLogicalOr:     interpolationFuncLogical(func(a, b bool) bool { return a || b }),
+		operatorFuncLogicalNot:    interpolationFuncLogicalNot(),
+	}
+}
+
+// ParseInterpolations parses a string that may contain interpolations with
+// HIL, including the conditionals, comparisons and boolean operators HIL
+// doesn't have syntax for. The result must be evaluated with
+// EvalInterpolations, with Funcs in the scope.
+func ParseInterpolations(s string) (ast.Node, error) {
+	expanded, err := expandInterpolationOperators(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return hil.Parse(expanded)
+}
+
+// EvalInterpolations evaluates a string parsed with ParseInterpolations.
+func EvalInterpolations(root ast.Node, config *hil.EvalConfig) (hil.EvaluationResult, error) {
+	root, err := expandConditionals(root, config)
+	if err != nil {
+		return hil.InvalidResult, err
+	}
+
+	return hil.Eval(root, config)
+}
+
+// expandConditionals returns root with its conditionals replaced by the
+// branch selected by their condition. The conditions are evaluated, but
+// nothing else is.
+func expandConditionals(root ast.Node, config *hil.EvalConfig) (ast.Node, error) {
+	var err error
+	switch n := root.(type) {
+	case *ast.Call:
+		if n.Func == operatorFuncConditional && len(n.Args) == 3 {
+			cond, err := expandConditionals(n.Args[0], config)
+			if err != nil {
+				return nil, err
+			}
+			result, err := hil.Eval(cond, config)
+			if err != nil {
+				return nil, err
+			}
+			v, err := interpolationBool(result.Value)
+			if err != nil {
+				return nil, fmt.Errorf("condition: %s", err)
+			}
+
+			if v {
+				return expandConditionals(n.Args[1], config)
+			}
+			return expandConditionals(n.Args[2], config)
+		}
+
+		args := make([]ast.Node, len(n.Args))
+		for i, arg := range n.Args {
+			if args[i], err = expandConditionals(arg, config); err != nil {
+				return nil, err
+			}
+		}
+		return &ast.Call{Func: n.Func, Args: args, Posx: n.Posx}, nil
+	case *ast.Output:
+		exprs := make([]ast.Node, len(n.Exprs))
+		for i, expr := range n.Exprs {
+			if exprs[i], err = expandConditionals(expr, config); err != nil {
+				return nil, err
+			}
+		}
+		return &ast.Output{Exprs: exprs, Posx: n.Posx}, nil
+	case *ast.Arithmetic:
+		exprs := make([]ast.Node, len(n.Exprs))
+		for i, expr := range n.Exprs {
+			if exprs[i], err = expandConditionals(expr, config); err != nil {
+				return nil, err
+			}
+		}
+		return &ast.Arithmetic{Op: n.Op, Exprs: exprs, Posx: n.Posx}, nil
+	case *ast.Index:
+		key, err := expandConditionals(n.Key, config)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Index{Target: n.Target, Key: key, Posx: n.Posx}, nil
+	default:
+		return root, nil
+	}
+}
+
+// expandInterpolationOperators rewrites the conditionals, comparisons and
+// boolean operators in the interpolations of s into calls to the functions
+// implementing them, so that the result can be parsed by HIL.
+func expandInterpolationOperators(s string) (string, error) {
+	p := &operatorParser{input: s}
+	return p.text(false)
+}
+
+// operatorTokenType is the type of a token within an interpolation.
+type operatorTokenType int
+
+const (
+	operatorTokenEOF operatorTokenType = iota
+	operatorTokenRBrace
+	operatorTokenLParen
+	operatorTokenRParen
+	operatorTokenLBracket
+	operatorTokenRBracket
+	operatorTokenComma
+	operatorTokenArith
+	operatorTokenQuestion
+	operatorTokenColon
+	operatorTokenNot
+	operatorTokenEqual
+	operatorTokenNotEqual
+	operatorTokenLessThan
+	operatorTokenLessThanEq
+	operatorTokenGreaterThan
+	operatorTokenGreaterThanEq
+	operatorTokenAnd
+	operatorTokenOr
+	operatorTokenNumber
+	operatorTokenString
+	operatorTokenIdent
+)
+
+// operatorBinaryFuncs are the functions of the binary operators, by
+// precedence, loosest first.
+var operatorBinaryFuncs = []map[operatorTokenType]string{
+	{operatorTokenOr: operatorFuncLogicalOr},
+	{operatorTokenAnd: operatorFuncLogicalAnd},
+	{
+		operatorTokenEqual:    operatorFuncEqual,
+		operatorTokenNotEqual: operatorFuncNotEqual,
+	},
+	{
+		operatorTokenLessThan:      operatorFuncLessThan,
+		operatorTokenLessThanEq:    operatorFuncLessThanEq,
+		operatorTokenGreaterThan:   operatorFuncGreaterThan,
+		operatorTokenGreaterThanEq: operatorFuncGreaterThanEq,
+	},
+}
+
+// operatorToken is a token within an interpolation. Raw is its text as
+// written, including the whitespace before it.
+type operatorToken struct {
+	Type  operatorTokenType
+	Raw   string
+	Value string
+}
+
+// operatorParser rewrites the operators of a string. It lexes the string
+// the same way as HIL, and copies what it reads to its output, except for
+// the operators it handles.
+type operatorParser struct {
+	input string
+	pos   int
+	width int
+	tok   operatorToken
+}
+
+// text reads literal text up to the end of the input or, if quoted, up to
+// the closing quote of a string within an interpolation, and returns it
+// with the operators in its interpolations rewritten.
+func (p *operatorParser) text(quoted bool) (string, error) {
+	var buf bytes.Buffer
+	for {
+		c := p.next()
+		switch {
+		case c == operatorLexEOF:
+			if quoted {
+				return "", fmt.Errorf("parse error: unterminated string")
+			}
+			return buf.String(), nil
+		case quoted && c == '"':
+			buf.WriteRune(c)
+			return buf.String(), nil
+		case quoted && c == '\\':
+			buf.WriteRune(c)
+			switch n := p.next(); n {
+			case '\\', '"', 'n':
+				buf.WriteRune(n)
+			default:
+				p.backup()
+			}
+		case c == '$' && p.peek() == '$':
+			p.next()
+			buf.WriteString("$$")
+		case c == '$' && p.peek() == '{':
+			p.next()
+			expr, err := p.interpolation()
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString("${")
+			buf.WriteString(expr)
+		default:
+			buf.WriteRune(c)
+		}
+	}
+}
+
+// interpolation parses an interpolation, after its "${", and returns it
+// rewritten, including its closing "}".
+func (p *operatorParser) interpolation() (string, error) {
+	if err := p.advance(); err != nil {
+		return "", err
+	}
+	expr, err := p.conditional()
+	if err != nil {
+		return "", err
+	}
+	if p.tok.Type != operatorTokenRBrace {
+		return "", p.unexpected()
+	}
+
+	// The closing brace was read by the lexer already, so the caller goes
+	// on from right after it.
+	return expr + p.tok.Raw, nil
+}
+
+func (p *operatorParser) conditional() (string, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return "", err
+	}
+	if p.tok.Type != operatorTokenQuestion {
+		return cond, nil
+	}
+
+	if err := p.advance(); err != nil {
+		return "", err
+	}
+	trueExpr, err := p.conditional()
+	if err != nil {
+		return "", err
+	}
+	if p.tok.Type != operatorTokenColon {
+		return "", p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return "", err
+	}
+	falseExpr, err := p.conditional()
+	if err != nil {
+		return "", err
+	}
+
+	return operatorCall(operatorFuncConditional, cond, trueExpr, falseExpr), nil
+}
+
+// binary parses the binary operators of the given precedence, and those
+// binding tighter.
+func (p *operatorParser) binary(precedence int) (string, error) {
+	operand := func() (string, error) {
+		if precedence+1 < len(operatorBinaryFuncs) {
+			return p.binary(precedence + 1)
+		}
+		return p.arithmetic()
+	}
+
+	left, err := operand()
+	if err != nil {
+		return "", err
+	}
+	for {
+		name, ok := operatorBinaryFuncs[precedence][p.tok.Type]
+		if !ok {
+			return left, nil
+		}
+
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		right, err := operand()
+		if err != nil {
+			return "", err
+		}
+		left = operatorCall(name, left, right)
+	}
+}
+
+// arithmetic parses arithmetic, which HIL handles itself, so it is copied
+// as is.
+func (p *operatorParser) arithmetic() (string, error) {
+	result, err := p.unary()
+	if err != nil {
+		return "", err
+	}
+	for p.tok.Type == operatorTokenArith {
+		result += p.tok.Raw
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		operand, err := p.unary()
+		if err != nil {
+			return "", err
+		}
+		result += operand
+	}
+
+	return result, nil
+}
+
+func (p *operatorParser) unary() (string, error) {
+	switch p.tok.Type {
+	case operatorTokenNot:
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		operand, err := p.unary()
+		if err != nil {
+			return "", err
+		}
+		return operatorCall(operatorFuncLogicalNot, operand), nil
+	case operatorTokenArith:
+		op := p.tok.Raw
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		operand, err := p.unary()
+		if err != nil {
+			return "", err
+		}
+		return op + operand, nil
+	default:
+		return p.primary()
+	}
+}
+
+func (p *operatorParser) primary() (string, error) {
+	tok := p.tok
+	switch tok.Type {
+	case operatorTokenLParen:
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		expr, err := p.conditional()
+		if err != nil {
+			return "", err
+		}
+		closing, err := p.closing(operatorTokenRParen)
+		return tok.Raw + expr + closing, err
+	case operatorTokenNumber, operatorTokenString:
+		return tok.Raw, p.advance()
+	case operatorTokenIdent:
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+		switch p.tok.Type {
+		case operatorTokenLParen:
+			return p.call(tok.Raw)
+		case operatorTokenLBracket:
+			open := p.tok.Raw
+			if err := p.advance(); err != nil {
+				return "", err
+			}
+			key, err := p.conditional()
+			if err != nil {
+				return "", err
+			}
+			closing, err := p.closing(operatorTokenRBracket)
+			return tok.Raw + open + key + closing, err
+		}
+
+		return tok.Raw, nil
+	default:
+		return "", p.unexpected()
+	}
+}
+
+// call parses the arguments of a call to function name, from their opening
+// parenthesis.
+func (p *operatorParser) call(name string) (string, error) {
+	result := name + p.tok.Raw
+	if err := p.advance(); err != nil {
+		return "", err
+	}
+	if p.tok.Type == operatorTokenRParen {
+		result += p.tok.Raw
+		return result, p.advance()
+	}
+
+	for {
+		arg, err := p.conditional()
+		if err != nil {
+			return "", err
+		}
+		result += arg
+		if p.tok.Type != operatorTokenComma {
+			break
+		}
+		result += p.tok.Raw
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+	}
+
+	closing, err := p.closing(operatorTokenRParen)
+	return result + closing, err
+}
+
+// closing returns the closing parenthesis or bracket t, and moves past it.
+func (p *operatorParser) closing(t operatorTokenType) (string, error) {
+	if p.tok.Type != t {
+		return "", p.unexpected()
+	}
+	raw := p.tok.Raw
+	return raw, p.advance()
+}
+
+func (p *operatorParser) unexpected() error {
+	if p.tok.Type == operatorTokenEOF {
+		return fmt.Errorf("parse error: unexpected end of interpolation")
+	}
+	return fmt.Errorf("parse error: unexpected %q", p.tok.Value)
+}
+
+// advance lexes the next token of an interpolation into p.tok.
+func (p *operatorParser) advance() error {
+	start := p.pos
+	c := p.next()
+	for unicode.IsSpace(c) {
+		c = p.next()
+	}
+	valueStart := p.pos - p.width
+
+	token := func(t operatorTokenType) error {
+		p.tok = operatorToken{
+			Type:  t,
+			Raw:   p.input[start:p.pos],
+			Value: p.input[valueStart:p.pos],
+		}
+		return nil
+	}
+	// twoChar lexes an operator that is either c alone, or c followed by
+	// second.
+	twoChar := func(single, double operatorTokenType, second rune) error {
+		if p.peek() == second {
+			p.next()
+			return token(double)
+		}
+		return token(single)
+	}
+
+	switch {
+	case c == operatorLexEOF:
+		return token(operatorTokenEOF)
+	case c == '"':
+		// Strings may contain interpolations of their own
+		rest, err := p.text(true)
+		if err != nil {
+			return err
+		}
+		p.tok = operatorToken{
+			Type:  operatorTokenString,
+			Raw:   p.input[start:valueStart] + `"` + rest,
+			Value: `"` + rest,
+		}
+		return nil
+	case c >= '0' && c <= '9':
+		for c := p.peek(); c == '.' || (c >= '0' && c <= '9'); c = p.peek() {
+			p.next()
+		}
+		return token(operatorTokenNumber)
+	}
+
+	switch c {
+	case '}':
+		return token(operatorTokenRBrace)
+	case '(':
+		return token(operatorTokenLParen)
+	case ')':
+		return token(operatorTokenRParen)
+	case '[':
+		return token(operatorTokenLBracket)
+	case ']':
+		return token(operatorTokenRBracket)
+	case ',':
+		return token(operatorTokenComma)
+	case '+', '-', '*', '/', '%':
+		return token(operatorTokenArith)
+	case '?':
+		return token(operatorTokenQuestion)
+	case ':':
+		return token(operatorTokenColon)
+	case '!':
+		return twoChar(operatorTokenNot, operatorTokenNotEqual, '=')
+	case '<':
+		return twoChar(operatorTokenLessThan, operatorTokenLessThanEq, '=')
+	case '>':
+		return twoChar(operatorTokenGreaterThan, operatorTokenGreaterThanEq, '=')
+	case '=', '&', '|':
+		if p.next() != c {
+			return fmt.Errorf("parse error: expected '%c%c'", c, c)
+		}
+		switch c {
+		case '=':
+			return token(operatorTokenEqual)
+		case '&':
+			return token(operatorTokenAnd)
+		default:
+			return token(operatorTokenOr)
+		}
+	}
+
+	// Anything else is an identifier, with the same characters HIL allows:
+	// * is only part of one after a '.', as in type.name.*.id, otherwise
+	// it's a multiplication.
+	last := c
+	for {
+		c := p.next()
+		if c == operatorLexEOF {
+			break
+		}
+		if (c == '*' && last != '.') ||
+			(c != '_' && c != '-' && c != '.' && c != '*' &&
+				!unicode.IsLetter(c) && !unicode.IsNumber(c)) {
+			p.backup()
+			break
+		}
+		last = c
+	}
+	return token(operatorTokenIdent)
+}
+
+func (p *operatorParser) next() rune {
+	if p.pos >= len(p.input) {
+		p.width = 0
+		return operatorLexEOF
+	}
+
+	r, w := utf8.DecodeRuneInString(p.input[p.pos:])
+	p.width = w
+	p.pos += w
+	return r
+}
+
+func (p *operatorParser) peek() rune {
+	r := p.next()
+	p.backup()
+	return r
+}
+
+// backup steps back one rune. Can only be called once per next.
+func (p *operatorParser) backup() {
+	p.pos -= p.width
+}
+
+// operatorCall returns a call to function name with the given arguments,
+// dropping the whitespace they were written with.
+func operatorCall(name string, args ...string) string {
+	var buf bytes.Buffer
+	buf.WriteString(name)
+	buf.WriteString("(")
+	for i, arg := range args {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strings.TrimSpace(arg))
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// interpolationFuncEqual implements "==", or "!=" if negated. Operands
+// that are both numbers are compared as numbers, so that "2" equals 2.0,
+// and anything else as strings.
+func interpolationFuncEqual(negated bool) ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeAny, ast.TypeAny},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			a, err := interpolationPrimitiveString(args[0])
+			if err != nil {
+				return nil, err
+			}
+			b, err := interpolationPrimitiveString(args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			equal := a == b
+			if aNum, err := strconv.ParseFloat(a, 64); err == nil {
+				if bNum, err := strconv.ParseFloat(b, 64); err == nil {
+					equal = aNum == bNum
+				}
+			}
+
+			return strconv.FormatBool(equal != negated), nil
+		},
+	}
+}
+
+// interpolationFuncCompare implements the ordering operators, which only
+// apply to numbers.
+func interpolationFuncCompare(f func(a, b float64) bool) ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeAny, ast.TypeAny},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			a, err := interpolationNumber(args[0])
+			if err != nil {
+				return nil, err
+			}
+			b, err := interpolationNumber(args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return strconv.FormatBool(f(a, b)), nil
+		},
+	}
+}
+
+// interpolationFuncLogical implements "&&" and "||". Both operands are
+// always evaluated.
+func interpolationFuncLogical(f func(a, b bool) bool) ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeAny, ast.TypeAny},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			a, err := interpolationBool(args[0])
+			if err != nil {
+				return nil, err
+			}
+			b, err := interpolationBool(args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return strconv.FormatBool(f(a, b)), nil
+		},
+	}
+}
+
+// interpolationFuncLogicalNot implements the unary "!".
+func interpolationFuncLogicalNot() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeAny},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			v, err := interpolationBool(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			return strconv.FormatBool(!v), nil
+		},
+	}
+}
+
+// interpolationPrimitiveString returns a string, int or float value as a
+// string.
+func interpolationPrimitiveString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("lists and maps can't be used with this operator")
+	}
+}
+
+// interpolationNumber returns a value as a number, for the operators that
+// only apply to numbers.
+func interpolationNumber(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("only numbers can be ordered, got %q", v)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("only numbers can be ordered")
+	}
+}
+
+// interpolationBool returns a value as a bool. Only "true" and "false",
+// the results of the comparisons, are bools.
+func interpolationBool(v interface{}) (bool, error) {
+	s, ok := v.(string)
+	if !ok || (s != "true" && s != "false") {
+		return false, fmt.Errorf(`expected "true" or "false", got %#v`, v)
+	}
+
+	return s == "true", nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestExpandInterpolationOperators(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Output string
+		Error  bool
+	}{
+		{
+			"foo",
+			"foo",
+			false,
+		},
+
+		{
+			`foo-$${bar == 1}`,
+			`foo-$${bar == 1}`,
+			false,
+		},
+
+		{
+			`${ aws_instance.foo.*.id[count.index] }-${format("%d", 1 + 2 * -3)}`,
+			`${ aws_instance.foo.*.id[count.index] }-${format("%d", 1 + 2 * -3)}`,
+			false,
+		},
+
+		{
+			`${var.env == "prod" ? 2 : 1}`,
+			`${__builtin_Conditional(__builtin_Equal(var.env, "prod"), 2, 1)}`,
+			false,
+		},
+
+		{
+			`${!var.a && var.b || var.c != 1}`,
+			`${__builtin_LogicalOr(__builtin_LogicalAnd(__builtin_LogicalNot(var.a), var.b), __builtin_NotEqual(var.c, 1))}`,
+			false,
+		},
+
+		{
+			`${var.count*2 >= 4 ? (var.a < 1) : false}`,
+			`${__builtin_Conditional(__builtin_GreaterThanOrEqual(var.count*2, 4), (__builtin_LessThan(var.a, 1)), false)}`,
+			false,
+		},
+
+		{
+			`${upper("a${var.b <= 1}\"b")} ${var.c > 2}`,
+			`${upper("a${__builtin_LessThanOrEqual(var.b, 1)}\"b")} ${__builtin_GreaterThan(var.c, 2)}`,
+			false,
+		},
+
+		{
+			`${var.a ? 1 : var.b ? 2 : 3}`,
+			`${__builtin_Conditional(var.a, 1, __builtin_Conditional(var.b, 2, 3))}`,
+			false,
+		},
+
+		{
+			`${var.a = 1}`,
+			"",
+			true,
+		},
+
+		{
+			`${var.a & var.b}`,
+			"",
+			true,
+		},
+
+		{
+			`${var.a ? 1}`,
+			"",
+			true,
+		},
+
+		{
+			`${var.a == }`,
+			"",
+			true,
+		},
+
+		{
+			`${"foo}`,
+			"",
+			true,
+		},
+	}
+
+	for i, tc := range cases {
+		actual, err := expandInterpolationOperators(tc.Input)
+		if err != nil != tc.Error {
+			t.Fatalf("%d: input: %s\nerr: %s", i, tc.Input, err)
+		}
+		if actual != tc.Output {
+			t.Fatalf("%d: input: %s\n\nOutput: %s\nExpected: %s",
+				i, tc.Input, actual, tc.Output)
+		}
+	}
+}
@@ -46,6 +46,22 @@ func TestNewInterpolatedVariable(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"each.key",
+			&EachVariable{
+				Type: EachValueKey,
+				key:  "each.key",
+			},
+			false,
+		},
+		{
+			"each.nope",
+			&EachVariable{
+				Type: EachValueInvalid,
+				key:  "each.nope",
+			},
+			false,
+		},
 		{
 			"path.module",
 			&PathVariable{
@@ -54,6 +70,22 @@ func TestNewInterpolatedVariable(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"terraform.workspace",
+			&TerraformVariable{
+				Type: TerraformValueWorkspace,
+				key:  "terraform.workspace",
+			},
+			false,
+		},
+		{
+			"terraform.nope",
+			&TerraformVariable{
+				Type: TerraformValueInvalid,
+				key:  "terraform.nope",
+			},
+			false,
+		},
 		{
 			"self.address",
 			&SelfVariable{
@@ -129,6 +161,24 @@ func TestNewResourceVariableData(t *testing.T) {
 	}
 }
 
+func TestNewLocalVariable(t *testing.T) {
+	v, err := NewLocalVariable("local.foo")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if v.Name != "foo" {
+		t.Fatalf("bad: %#v", v.Name)
+	}
+	if v.FullKey() != "local.foo" {
+		t.Fatalf("bad: %#v", v)
+	}
+
+	if _, err := NewLocalVariable("local.foo.bar"); err == nil {
+		t.Fatal("should error")
+	}
+}
+
 func TestNewUserVariable(t *testing.T) {
 	v, err := NewUserVariable("var.bar")
 	if err != nil {
@@ -5,7 +5,6 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/hashicorp/hil"
 	"github.com/hashicorp/hil/ast"
 	"github.com/mitchellh/reflectwalk"
 )
@@ -113,7 +112,7 @@ func (w *interpolationWalker) Primitive(v reflect.Value) error {
 		return nil
 	}
 
-	astRoot, err := hil.Parse(v.String())
+	astRoot, err := ParseInterpolations(v.String())
 	if err != nil {
 		return err
 	}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
+	"sort"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl"
@@ -21,6 +22,7 @@ func (t *hclConfigurable) Config() (*Config, error) {
 	validKeys := map[string]struct{}{
 		"atlas":    struct{}{},
 		"data":     struct{}{},
+		"locals":   struct{}{},
 		"module":   struct{}{},
 		"output":   struct{}{},
 		"provider": struct{}{},
@@ -57,24 +59,21 @@ func (t *hclConfigurable) Config() (*Config, error) {
 	if len(rawConfig.Variable) > 0 {
 		config.Variables = make([]*Variable, 0, len(rawConfig.Variable))
 		for k, v := range rawConfig.Variable {
-			// Defaults turn into a slice of map[string]interface{} and
-			// we need to make sure to convert that down into the
-			// proper type for Config.
-			if ms, ok := v.Default.([]map[string]interface{}); ok {
-				def := make(map[string]interface{})
-				for _, m := range ms {
-					for k, v := range m {
-						def[k] = v
-					}
+			def := v.Default
+			if v.DeclaredType == "list" {
+				maps, err := loadListOfMapsDefaultHcl(list, k)
+				if err != nil {
+					return nil, err
+				}
+				if maps != nil {
+					def = maps
 				}
-
-				v.Default = def
 			}
 
 			newVar := &Variable{
 				Name:         k,
 				DeclaredType: v.DeclaredType,
-				Default:      v.Default,
+				Default:      hclDefaultValue(def),
 				Description:  v.Description,
 			}
 
@@ -137,6 +136,15 @@ func (t *hclConfigurable) Config() (*Config, error) {
 		config.Resources = append(config.Resources, managedResources...)
 	}
 
+	// Build the local values
+	if locals := list.Filter("locals"); len(locals.Items) > 0 {
+		var err error
+		config.Locals, err = loadLocalsHcl(locals)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Build the outputs
 	if outputs := list.Filter("output"); len(outputs.Items) > 0 {
 		var err error
@@ -287,6 +295,8 @@ func loadModulesHcl(list *ast.ObjectList) ([]*Module, error) {
 
 		// Remove the fields we handle specially
 		delete(config, "source")
+		delete(config, "version")
+		delete(config, "depends_on")
 
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
@@ -308,16 +318,84 @@ func loadModulesHcl(list *ast.ObjectList) ([]*Module, error) {
 			}
 		}
 
+		var version string
+		if o := listVal.Filter("version"); len(o.Items) > 0 {
+			err = hcl.DecodeObject(&version, o.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error parsing version for %s: %s",
+					k,
+					err)
+			}
+		}
+
+		// If we have depends fields, then add those in
+		var dependsOn []string
+		if o := listVal.Filter("depends_on"); len(o.Items) > 0 {
+			err := hcl.DecodeObject(&dependsOn, o.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error reading depends_on for %s: %s",
+					k,
+					err)
+			}
+		}
+
 		result = append(result, &Module{
 			Name:      k,
 			Source:    source,
+			Version:   version,
 			RawConfig: rawConfig,
+			DependsOn: dependsOn,
 		})
 	}
 
 	return result, nil
 }
 
+// loadLocalsHcl recurses into the given HCL object and turns it into
+// a list of local values. A file can have several "locals" blocks.
+func loadLocalsHcl(list *ast.ObjectList) ([]*Local, error) {
+	var result []*Local
+	for _, item := range list.Items {
+		if len(item.Keys) > 0 {
+			return nil, fmt.Errorf(
+				"locals: blocks can't have a name, at %s", item.Pos())
+		}
+
+		var config map[string]interface{}
+		if err := hcl.DecodeObject(&config, item.Val); err != nil {
+			return nil, fmt.Errorf("Error reading locals: %s", err)
+		}
+
+		// Sort the names, so the local values are in a stable order
+		names := make([]string, 0, len(config))
+		for n := range config {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+
+		for _, n := range names {
+			rawConfig, err := NewRawConfig(map[string]interface{}{
+				"value": hclDefaultValue(config[n]),
+			})
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error reading config for local %s: %s",
+					n,
+					err)
+			}
+
+			result = append(result, &Local{
+				Name:      n,
+				RawConfig: rawConfig,
+			})
+		}
+	}
+
+	return result, nil
+}
+
 // LoadOutputsHcl recurses into the given HCL object and turns
 // it into a mapping of outputs.
 func loadOutputsHcl(list *ast.ObjectList) ([]*Output, error) {
@@ -379,6 +457,7 @@ func loadProvidersHcl(list *ast.ObjectList) ([]*ProviderConfig, error) {
 		}
 
 		delete(config, "alias")
+		delete(config, "version")
 
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
@@ -400,9 +479,22 @@ func loadProvidersHcl(list *ast.ObjectList) ([]*ProviderConfig, error) {
 			}
 		}
 
+		// If we have a version field, then add that in
+		var version string
+		if a := listVal.Filter("version"); len(a.Items) > 0 {
+			err := hcl.DecodeObject(&version, a.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error reading version for provider[%s]: %s",
+					n,
+					err)
+			}
+		}
+
 		result = append(result, &ProviderConfig{
 			Name:      n,
 			Alias:     alias,
+			Version:   version,
 			RawConfig: rawConfig,
 		})
 	}
@@ -583,6 +675,7 @@ func loadManagedResourcesHcl(list *ast.ObjectList) ([]*Resource, error) {
 		// Remove the fields we handle specially
 		delete(config, "connection")
 		delete(config, "count")
+		delete(config, "for_each")
 		delete(config, "depends_on")
 		delete(config, "provisioner")
 		delete(config, "provider")
@@ -617,6 +710,28 @@ func loadManagedResourcesHcl(list *ast.ObjectList) ([]*Resource, error) {
 		}
 		countConfig.Key = "count"
 
+		// If we have a for_each, then figure it out
+		var forEachConfig *RawConfig
+		if o := listVal.Filter("for_each"); len(o.Items) > 0 {
+			var forEach interface{}
+			err = hcl.DecodeObject(&forEach, o.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error parsing for_each for %s[%s]: %s",
+					t,
+					k,
+					err)
+			}
+
+			forEachConfig, err = NewRawConfig(map[string]interface{}{
+				"for_each": forEach,
+			})
+			if err != nil {
+				return nil, err
+			}
+			forEachConfig.Key = "for_each"
+		}
+
 		// If we have depends fields, then add those in
 		var dependsOn []string
 		if o := listVal.Filter("depends_on"); len(o.Items) > 0 {
@@ -704,6 +819,7 @@ func loadManagedResourcesHcl(list *ast.ObjectList) ([]*Resource, error) {
 			Name:         k,
 			Type:         t,
 			RawCount:     countConfig,
+			RawForEach:   forEachConfig,
 			RawConfig:    rawConfig,
 			Provisioners: provisioners,
 			Provider:     provider,
@@ -741,6 +857,22 @@ func loadProvisionersHcl(list *ast.ObjectList, connInfo map[string]interface{})
 		// Delete the "connection" section, handle separately
 		delete(config, "connection")
 
+		// Delete the "when" field, which configures the provisioner
+		// itself rather than being passed to it
+		when := ProvisionerWhenCreate
+		if raw, ok := config["when"]; ok {
+			switch raw {
+			case "create":
+			case "destroy":
+				when = ProvisionerWhenDestroy
+			default:
+				return nil, fmt.Errorf(
+					"provisioner '%s': 'when' must be \"create\" or \"destroy\", got %#v",
+					n, raw)
+			}
+			delete(config, "when")
+		}
+
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
 			return nil, err
@@ -778,12 +910,97 @@ func loadProvisionersHcl(list *ast.ObjectList, connInfo map[string]interface{})
 			Type:      n,
 			RawConfig: rawConfig,
 			ConnInfo:  connRaw,
+			When:      when,
 		})
 	}
 
 	return result, nil
 }
 
+// loadListOfMapsDefaultHcl loads the default of a list variable if it is a
+// list of maps, returning nil otherwise.
+//
+// The HCL parser doesn't accept objects within lists, so a list of maps can
+// only be given as a list of objects in JSON. Those are flattened into one
+// default item per map, which decode into a single slice of maps with one
+// map per key, so the elements are decoded one by one instead.
+func loadListOfMapsDefaultHcl(list *ast.ObjectList, name string) ([]interface{}, error) {
+	var defaults []*ast.ObjectItem
+	json := true
+	for _, item := range list.Filter("variable", name).Items {
+		ot, ok := item.Val.(*ast.ObjectType)
+		if !ok {
+			continue
+		}
+		for _, field := range ot.List.Items {
+			if !field.Keys[0].Token.JSON {
+				json = false
+			}
+		}
+		defaults = append(defaults, ot.List.Filter("default").Items...)
+	}
+	if len(defaults) == 0 {
+		return nil, nil
+	}
+	for _, item := range defaults {
+		if _, ok := item.Val.(*ast.ObjectType); !ok {
+			return nil, nil
+		}
+	}
+
+	// A default block on a list variable would otherwise silently become
+	// a list of one map, so only accept the JSON form.
+	if !json {
+		return nil, fmt.Errorf(
+			"Error reading default of variable %s: the default of a list "+
+				"variable can't be a block or map. A list of maps can only "+
+				"be given as a list of objects in JSON configuration.",
+			name)
+	}
+
+	result := make([]interface{}, len(defaults))
+	for i, item := range defaults {
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, item.Val); err != nil {
+			return nil, fmt.Errorf(
+				"Error reading default of variable %s: %s", name, err)
+		}
+		result[i] = m
+	}
+
+	return result, nil
+}
+
+// hclDefaultValue converts a variable default decoded from HCL into the
+// proper type for Config. Maps turn into a slice of map[string]interface{}
+// when decoded, at any depth, so they are converted back down into maps.
+func hclDefaultValue(raw interface{}) interface{} {
+	switch v := raw.(type) {
+	case []map[string]interface{}:
+		result := make(map[string]interface{})
+		for _, m := range v {
+			for k, v := range m {
+				result[k] = hclDefaultValue(v)
+			}
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, v := range v {
+			result[k] = hclDefaultValue(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, v := range v {
+			result[i] = hclDefaultValue(v)
+		}
+		return result
+	default:
+		return raw
+	}
+}
+
 /*
 func hclObjectMap(os *hclobj.Object) map[string]ast.ListNode {
 	objects := make(map[string][]*hclobj.Object)
@@ -291,6 +291,30 @@ func TestLoadFileBasic_modules(t *testing.T) {
 	}
 }
 
+func TestLoadFile_moduleDependsOn(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "module-depends-on.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := modulesStr(c.Modules)
+	if actual != strings.TrimSpace(moduleDependsOnModulesStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestLoadFile_moduleVersion(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "module-version.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := modulesStr(c.Modules)
+	if actual != strings.TrimSpace(moduleVersionModulesStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestLoadJSONBasic(t *testing.T) {
 	raw, err := ioutil.ReadFile(filepath.Join(fixtureDir, "basic.tf.json"))
 	if err != nil {
@@ -455,6 +479,18 @@ func TestLoadDir_override(t *testing.T) {
 	}
 }
 
+func TestLoadDir_overrideLocals(t *testing.T) {
+	c, err := LoadDir(filepath.Join(fixtureDir, "dir-override-locals"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := localsStr(c.Locals)
+	if actual != strings.TrimSpace(dirOverrideLocalsStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestLoadFile_mismatchedVariableTypes(t *testing.T) {
 	_, err := LoadFile(filepath.Join(fixtureDir, "variable-mismatched-type.tf"))
 	if err == nil {
@@ -479,6 +515,142 @@ func TestLoadFile_badVariableTypes(t *testing.T) {
 	}
 }
 
+func TestLoadFile_nestedVariables(t *testing.T) {
+	expected := map[string]struct {
+		Type    VariableType
+		Default interface{}
+	}{
+		"vifs": {
+			VariableTypeList,
+			[]interface{}{
+				map[string]interface{}{"vlan": 100, "prefix": "10.0.0.0/30"},
+				map[string]interface{}{"vlan": 200, "prefix": "10.0.0.4/30"},
+			},
+		},
+		"peers": {
+			VariableTypeMap,
+			map[string]interface{}{
+				"primary": map[string]interface{}{
+					"asn":      "64512",
+					"prefixes": []interface{}{"10.0.0.0/30"},
+				},
+			},
+		},
+	}
+
+	c, err := LoadFile(filepath.Join(fixtureDir, "variables-nested.tf.json"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(c.Variables) != len(expected) {
+		t.Fatalf("bad: %#v", c.Variables)
+	}
+	for _, v := range c.Variables {
+		e := expected[v.Name]
+		if v.Type() != e.Type {
+			t.Fatalf("bad type for %s: %s", v.Name, v.Type().Printable())
+		}
+		if !reflect.DeepEqual(v.Default, e.Default) {
+			t.Fatalf("bad default for %s: %#v", v.Name, v.Default)
+		}
+	}
+}
+
+func TestLoadFile_listVariableDefaultBlock(t *testing.T) {
+	_, err := LoadFile(filepath.Join(fixtureDir, "variables-list-default-block.tf"))
+	if err == nil {
+		t.Fatal("should have error")
+	}
+	if !strings.Contains(err.Error(), "list of objects in JSON") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestLoadFile_providerVersion(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "provider-version.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(c.ProviderConfigs) != 2 {
+		t.Fatalf("bad: %#v", c.ProviderConfigs)
+	}
+
+	pc := c.ProviderConfigs[0]
+	if pc.Version != "~> 1.2" {
+		t.Fatalf("bad: %#v", pc)
+	}
+	if _, ok := pc.RawConfig.Raw["version"]; ok {
+		t.Fatalf("version should not be in the raw config: %#v", pc.RawConfig.Raw)
+	}
+
+	if v := c.ProviderConfigs[1].Version; v != "" {
+		t.Fatalf("bad: %q", v)
+	}
+}
+
+func TestLoadFile_forEach(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "for-each.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(c.Resources) != 2 {
+		t.Fatalf("bad: %#v", c.Resources)
+	}
+
+	r := c.Resources[0]
+	if r.RawForEach == nil {
+		t.Fatalf("bad: %#v", r)
+	}
+	if r.RawForEach.Key != "for_each" {
+		t.Fatalf("bad: %#v", r.RawForEach)
+	}
+	if _, ok := r.RawConfig.Raw["for_each"]; ok {
+		t.Fatalf("for_each should not be in the raw config: %#v", r.RawConfig.Raw)
+	}
+
+	if c.Resources[1].RawForEach != nil {
+		t.Fatalf("bad: %#v", c.Resources[1].RawForEach)
+	}
+}
+
+func TestLoadFile_locals(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "locals.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := localsStr(c.Locals)
+	if actual != strings.TrimSpace(localsLocalsStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	for _, l := range c.Locals {
+		if l.Name != "map" {
+			continue
+		}
+
+		expected := map[string]interface{}{"key": "value"}
+		if v := l.RawConfig.Config()["value"]; !reflect.DeepEqual(v, expected) {
+			t.Fatalf("bad: %#v", v)
+		}
+	}
+}
+
+func TestLoadFile_localsJSON(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "locals.tf.json"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := localsStr(c.Locals)
+	if actual != strings.TrimSpace(localsJSONLocalsStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestLoadFile_provisioners(t *testing.T) {
 	c, err := LoadFile(filepath.Join(fixtureDir, "provisioners.tf"))
 	if err != nil {
@@ -495,6 +667,39 @@ func TestLoadFile_provisioners(t *testing.T) {
 	}
 }
 
+func TestLoadFile_provisionersDestroy(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "provisioners-destroy.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if c == nil {
+		t.Fatal("config should not be nil")
+	}
+
+	actual := resourcesStr(c.Resources)
+	if actual != strings.TrimSpace(provisionerDestroyResourcesStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	// The "when" field must not be passed to the provisioner
+	for _, p := range c.Resources[0].Provisioners {
+		if _, ok := p.RawConfig.Raw["when"]; ok {
+			t.Fatalf("bad: %#v", p.RawConfig.Raw)
+		}
+	}
+}
+
+func TestLoadFile_provisionersWhenInvalid(t *testing.T) {
+	_, err := LoadFile(filepath.Join(fixtureDir, "provisioners-when-invalid.tf"))
+	if err == nil {
+		t.Fatal("should have error")
+	}
+	if !strings.Contains(err.Error(), "'when' must be") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
 func TestLoadFile_connections(t *testing.T) {
 	c, err := LoadFile(filepath.Join(fixtureDir, "connection.tf"))
 	if err != nil {
@@ -901,6 +1106,34 @@ web_ip
     resource: aws_instance.web.private_ip
 `
 
+const dirOverrideLocalsStr = `
+ami
+  vars
+    user: var.ami
+name
+  vars
+    user: var.name
+`
+
+const localsLocalsStr = `
+bar
+baz
+  vars
+    local: local.foo
+    resource: aws_instance.web.id
+foo
+  vars
+    user: var.foo
+map
+`
+
+const localsJSONLocalsStr = `
+bar
+foo
+  vars
+    user: var.foo
+`
+
 const dirOverrideProvidersStr = `
 aws
   access_key
@@ -964,6 +1197,24 @@ bar
   memory
 `
 
+const moduleVersionModulesStr = `
+network
+  source = corp/network/aws
+  version = ~> 2.1
+  cidr_block
+`
+
+const moduleDependsOnModulesStr = `
+compute
+  source = ./compute
+  memory
+  dependsOn
+    module.network
+    aws_instance.web
+network
+  source = ./network
+`
+
 const provisionerResourcesStr = `
 aws_instance.web (x1)
   ami
@@ -976,6 +1227,18 @@ aws_instance.web (x1)
     user: var.foo
 `
 
+const provisionerDestroyResourcesStr = `
+aws_instance.web (x1)
+  ami
+  provisioners
+    shell
+      path
+    shell
+      path
+    shell (destroy)
+      path
+`
+
 const connectionResourcesStr = `
 aws_instance.web (x1)
   ami
@@ -2,6 +2,7 @@ package module
 
 import (
 	"io/ioutil"
+	"log"
 	"os"
 
 	"github.com/hashicorp/go-getter"
@@ -67,3 +68,26 @@ func getStorage(s getter.Storage, key string, src string, mode GetMode) (string,
 	// Get the directory where the module is.
 	return s.Dir(key)
 }
+
+// getRegistryStorage is like getStorage for a module from a registry. The
+// registry is only asked for the version to get when the module is
+// downloaded: when it isn't in the storage yet, or when updating.
+func getRegistryStorage(s getter.Storage, key string, m *registryModule, constraint string, mode GetMode) (string, bool, error) {
+	if mode == GetModeNone {
+		return s.Dir(key)
+	}
+
+	if mode == GetModeGet {
+		if dir, ok, err := s.Dir(key); err != nil || ok {
+			return dir, ok, err
+		}
+	}
+
+	v, source, err := registryResolve(m, constraint)
+	if err != nil {
+		return "", false, err
+	}
+
+	log.Printf("[INFO] Getting module %s %s from %s", m, v, source)
+	return getStorage(s, key, source, GetModeUpdate)
+}
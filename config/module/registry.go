@@ -0,0 +1,235 @@
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-version"
+)
+
+// DefaultRegistryHost is the host of the module registry used for
+// registry sources that don't include a host, such as "corp/network/aws".
+// If it is empty, such sources must include the host.
+var DefaultRegistryHost string
+
+// registryClient is the HTTP client used to talk to module registries.
+var registryClient = cleanhttp.DefaultClient()
+
+// registrySourceRegexp matches the module sources of a registry, in the
+// format "[HOST/]NAMESPACE/NAME/PROVIDER".
+//
+// The namespace can't contain dots, so sources such as
+// "github.com/hashicorp/example" aren't mistaken for registry sources.
+var registrySourceRegexp = regexp.MustCompile(
+	`^(?:([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)+(?::[0-9]+)?)/)?` +
+		`([0-9A-Za-z][0-9A-Za-z_-]*)/([0-9A-Za-z][0-9A-Za-z_-]*)/([0-9A-Za-z]+)$`)
+
+// registryHostsExcluded are the hosts that are handled by the detectors
+// of go-getter, so their sources are never registry sources.
+var registryHostsExcluded = map[string]struct{}{
+	"github.com":    struct{}{},
+	"bitbucket.org": struct{}{},
+}
+
+// registryModule is a module in a module registry.
+type registryModule struct {
+	Host      string
+	Namespace string
+	Name      string
+	Provider  string
+}
+
+// parseRegistrySource parses a module source in the registry format. The
+// second return value is false if the source isn't a registry source.
+func parseRegistrySource(source string) (*registryModule, bool) {
+	match := registrySourceRegexp.FindStringSubmatch(source)
+	if match == nil {
+		return nil, false
+	}
+	if _, ok := registryHostsExcluded[match[1]]; ok {
+		return nil, false
+	}
+
+	return &registryModule{
+		Host:      match[1],
+		Namespace: match[2],
+		Name:      match[3],
+		Provider:  match[4],
+	}, true
+}
+
+func (m *registryModule) String() string {
+	return fmt.Sprintf("%s/%s/%s", m.Namespace, m.Name, m.Provider)
+}
+
+// url returns the URL of the module in the registry API, followed by
+// the given path elements.
+func (m *registryModule) url(path ...string) (string, error) {
+	host := m.Host
+	if host == "" {
+		host = DefaultRegistryHost
+	}
+	if host == "" {
+		return "", fmt.Errorf(
+			"no module registry is configured for %q. Either include the "+
+				"registry host in the source, or set module_registry in the "+
+				"Terraform CLI configuration", m.String())
+	}
+
+	elems := append([]string{m.Namespace, m.Name, m.Provider}, path...)
+	return fmt.Sprintf("https://%s/v1/modules/%s", host, strings.Join(elems, "/")), nil
+}
+
+// registryVersionsResponse is the response of the registry listing the
+// versions of a module.
+type registryVersionsResponse struct {
+	Modules []struct {
+		Versions []struct {
+			Version string `json:"version"`
+		} `json:"versions"`
+	} `json:"modules"`
+}
+
+// registryVersions returns the versions of the module available in the
+// registry.
+func registryVersions(m *registryModule) ([]*version.Version, error) {
+	u, err := m.url("versions")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Listing the versions of module %s: %s", m, u)
+	resp, err := registryClient.Get(u)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing the versions of module %s: %s", m, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("module %s not found in the registry", m)
+	default:
+		return nil, fmt.Errorf(
+			"Error listing the versions of module %s: %s", m, resp.Status)
+	}
+
+	var body registryVersionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf(
+			"Error decoding the versions of module %s: %s", m, err)
+	}
+
+	var result []*version.Version
+	for _, mod := range body.Modules {
+		for _, v := range mod.Versions {
+			parsed, err := version.NewVersion(v.Version)
+			if err != nil {
+				log.Printf("[WARN] Ignoring invalid version %q of module %s", v.Version, m)
+				continue
+			}
+			result = append(result, parsed)
+		}
+	}
+
+	return result, nil
+}
+
+// registryLocation returns the go-getter source that the given version of
+// the module is downloaded from. The registry returns it in the
+// X-Terraform-Get header, possibly relative to the download URL.
+func registryLocation(m *registryModule, v *version.Version) (string, error) {
+	u, err := m.url(v.String(), "download")
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("[DEBUG] Looking up the location of module %s %s: %s", m, v, u)
+	resp, err := registryClient.Get(u)
+	if err != nil {
+		return "", fmt.Errorf("Error looking up module %s %s: %s", m, v, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return "", fmt.Errorf("Error looking up module %s %s: %s", m, v, resp.Status)
+	}
+
+	location := resp.Header.Get("X-Terraform-Get")
+	if location == "" {
+		return "", fmt.Errorf(
+			"Error looking up module %s %s: the registry didn't return a location", m, v)
+	}
+
+	// Relative locations are relative to the download URL. Anything else,
+	// including the forced getters such as "git::", is used as is.
+	if strings.HasPrefix(location, "/") || strings.HasPrefix(location, "./") ||
+		strings.HasPrefix(location, "../") {
+		base, err := url.Parse(u)
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(location)
+		if err != nil {
+			return "", fmt.Errorf(
+				"Error looking up module %s %s: invalid location %q: %s",
+				m, v, location, err)
+		}
+		location = base.ResolveReference(ref).String()
+	}
+
+	return location, nil
+}
+
+// registryResolve returns the newest version of the module matching the
+// version constraint, which may be empty to match any version, and the
+// source it is downloaded from.
+//
+// Pre-release versions are only selected if the constraint names them.
+func registryResolve(m *registryModule, constraint string) (*version.Version, string, error) {
+	var constraints version.Constraints
+	if constraint != "" {
+		var err error
+		constraints, err = version.NewConstraint(constraint)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid version constraint %q: %s", constraint, err)
+		}
+	}
+
+	versions, err := registryVersions(m)
+	if err != nil {
+		return nil, "", err
+	}
+	sort.Sort(sort.Reverse(version.Collection(versions)))
+
+	var selected *version.Version
+	for _, v := range versions {
+		if v.Prerelease() != "" && !strings.Contains(constraint, v.String()) {
+			continue
+		}
+		if constraints != nil && !constraints.Check(v) {
+			continue
+		}
+
+		selected = v
+		break
+	}
+	if selected == nil {
+		return nil, "", fmt.Errorf(
+			"no version of module %s matches the constraint %q", m, constraint)
+	}
+
+	location, err := registryLocation(m, selected)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return selected, location, nil
+}
@@ -0,0 +1,230 @@
+package module
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+)
+
+func TestParseRegistrySource(t *testing.T) {
+	cases := []struct {
+		Source string
+		Result *registryModule
+	}{
+		{
+			"corp/network/aws",
+			&registryModule{Namespace: "corp", Name: "network", Provider: "aws"},
+		},
+		{
+			"registry.example.com/corp/network/aws",
+			&registryModule{
+				Host:      "registry.example.com",
+				Namespace: "corp",
+				Name:      "network",
+				Provider:  "aws",
+			},
+		},
+		{
+			"registry.example.com:8443/corp/network/aws",
+			&registryModule{
+				Host:      "registry.example.com:8443",
+				Namespace: "corp",
+				Name:      "network",
+				Provider:  "aws",
+			},
+		},
+		{"./network", nil},
+		{"../corp/network/aws", nil},
+		{"/corp/network/aws", nil},
+		{"corp/network", nil},
+		{"github.com/hashicorp/example", nil},
+		{"github.com/hashicorp/example/modules", nil},
+		{"git::https://example.com/network.git", nil},
+		{"https://example.com/network.zip", nil},
+	}
+
+	for _, tc := range cases {
+		actual, ok := parseRegistrySource(tc.Source)
+		if ok != (tc.Result != nil) {
+			t.Fatalf("%s: bad: %t", tc.Source, ok)
+		}
+		if !reflect.DeepEqual(actual, tc.Result) {
+			t.Fatalf("%s: bad: %#v", tc.Source, actual)
+		}
+	}
+}
+
+func TestTreeLoad_registry(t *testing.T) {
+	versions := []string{"2.0.0", "2.1.0", "3.0.0"}
+	host, closeFn := testRegistry(t, &versions)
+	defer closeFn()
+
+	storage := testStorage(t)
+	tree := NewTree("", testRegistryConfig(t, host+"/corp/network/aws", "~> 2.1"))
+
+	// This should error because we haven't gotten things yet
+	if err := tree.Load(storage, GetModeNone); err == nil {
+		t.Fatal("should error")
+	}
+
+	if err := tree.Load(storage, GetModeGet); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testTreeModuleVersion(t, tree, "2.1.0")
+
+	// A newer version is only picked up when updating
+	versions = append(versions, "2.2.0", "2.3.0-beta")
+	if err := tree.Load(storage, GetModeGet); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testTreeModuleVersion(t, tree, "2.1.0")
+
+	if err := tree.Load(storage, GetModeUpdate); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testTreeModuleVersion(t, tree, "2.2.0")
+
+	if err := tree.Load(storage, GetModeNone); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testTreeModuleVersion(t, tree, "2.2.0")
+}
+
+func TestTreeLoad_registryDefaultHost(t *testing.T) {
+	versions := []string{"2.1.0"}
+	host, closeFn := testRegistry(t, &versions)
+	defer closeFn()
+
+	tree := NewTree("", testRegistryConfig(t, "corp/network/aws", ""))
+	if err := tree.Load(testStorage(t), GetModeGet); err == nil {
+		t.Fatal("should error without a default registry")
+	}
+
+	defer func(old string) { DefaultRegistryHost = old }(DefaultRegistryHost)
+	DefaultRegistryHost = host
+
+	if err := tree.Load(testStorage(t), GetModeGet); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testTreeModuleVersion(t, tree, "2.1.0")
+}
+
+func TestTreeLoad_registryNoMatch(t *testing.T) {
+	versions := []string{"2.1.0"}
+	host, closeFn := testRegistry(t, &versions)
+	defer closeFn()
+
+	tree := NewTree("", testRegistryConfig(t, host+"/corp/network/aws", "~> 3.0"))
+	err := tree.Load(testStorage(t), GetModeGet)
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !strings.Contains(err.Error(), "no version of module corp/network/aws") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestTreeLoad_registryNotFound(t *testing.T) {
+	versions := []string{"2.1.0"}
+	host, closeFn := testRegistry(t, &versions)
+	defer closeFn()
+
+	tree := NewTree("", testRegistryConfig(t, host+"/corp/storage/aws", ""))
+	err := tree.Load(testStorage(t), GetModeGet)
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestTreeLoad_versionNotRegistry(t *testing.T) {
+	tree := NewTree("", testRegistryConfig(t, "./foo", "~> 2.1"))
+	if err := tree.Load(testStorage(t), GetModeGet); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+// testRegistry starts a module registry serving the given versions of the
+// module corp/network/aws, which are downloaded from the fixtures in
+// test-fixtures/registry. It returns the host of the registry and a func
+// to stop it.
+func testRegistry(t *testing.T, versions *[]string) (string, func()) {
+	fixtures, err := filepath.Abs(filepath.Join(fixtureDir, "registry"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/modules/corp/network/aws/", func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v1/modules/corp/network/aws/")
+		if path == "versions" {
+			var vs []string
+			for _, v := range *versions {
+				vs = append(vs, fmt.Sprintf(`{"version": %q}`, v))
+			}
+			fmt.Fprintf(w, `{"modules": [{"versions": [%s]}]}`, strings.Join(vs, ","))
+			return
+		}
+
+		v := strings.TrimSuffix(path, "/download")
+		w.Header().Set("X-Terraform-Get", "file://"+filepath.Join(fixtures, v))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := httptest.NewTLSServer(mux)
+	oldClient := registryClient
+	registryClient = &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	closeFn := func() {
+		srv.Close()
+		registryClient = oldClient
+	}
+	return strings.TrimPrefix(srv.URL, "https://"), closeFn
+}
+
+func testRegistryConfig(t *testing.T, source, version string) *config.Config {
+	rc, err := config.NewRawConfig(nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return &config.Config{
+		Dir: fixtureDir,
+		Modules: []*config.Module{
+			&config.Module{
+				Name:      "network",
+				Source:    source,
+				Version:   version,
+				RawConfig: rc,
+			},
+		},
+	}
+}
+
+func testTreeModuleVersion(t *testing.T, tree *Tree, expected string) {
+	child := tree.Children()["network"]
+	if child == nil {
+		t.Fatal("module not loaded")
+	}
+
+	outputs := child.Config().Outputs
+	if len(outputs) != 1 {
+		t.Fatalf("bad: %#v", outputs)
+	}
+	if actual := outputs[0].RawConfig.Raw["value"]; actual != expected {
+		t.Fatalf("bad version: %v, expected %s", actual, expected)
+	}
+}
@@ -0,0 +1,3 @@
+output "version" {
+    value = "2.1.0"
+}
@@ -103,8 +103,9 @@ func (t *Tree) Modules() []*Module {
 	result := make([]*Module, len(t.config.Modules))
 	for i, m := range t.config.Modules {
 		result[i] = &Module{
-			Name:   m.Name,
-			Source: m.Source,
+			Name:    m.Name,
+			Source:  m.Source,
+			Version: m.Version,
 		}
 	}
 
@@ -157,23 +158,44 @@ func (t *Tree) Load(s getter.Storage, mode GetMode) error {
 		// Split out the subdir if we have one
 		source, subDir := getter.SourceDirSubdir(m.Source)
 
-		source, err := getter.Detect(source, t.config.Dir, getter.Detectors)
-		if err != nil {
-			return fmt.Errorf("module %s: %s", m.Name, err)
-		}
-
-		// Check if the detector introduced something new.
-		source, subDir2 := getter.SourceDirSubdir(source)
-		if subDir2 != "" {
-			subDir = filepath.Join(subDir2, subDir)
-		}
-
 		// Get the directory where this module is so we can load it
 		key := strings.Join(path, ".")
 		key = "root." + key
-		dir, ok, err := getStorage(s, key, source, mode)
-		if err != nil {
-			return err
+
+		var dir string
+		var ok bool
+		var err error
+		if rm, isRegistry := parseRegistrySource(source); isRegistry {
+			// The source and version constraint are part of the key, so
+			// that changing them gets the module again.
+			key = fmt.Sprintf("%s;%s;%s", key, source, m.Version)
+
+			dir, ok, err = getRegistryStorage(s, key, rm, m.Version, mode)
+			if err != nil {
+				return fmt.Errorf("module %s: %s", m.Name, err)
+			}
+		} else {
+			if m.Version != "" {
+				return fmt.Errorf(
+					"module %s: version can only be set for modules from a registry", m.Name)
+			}
+
+			source, err = getter.Detect(source, t.config.Dir, getter.Detectors)
+			if err != nil {
+				return fmt.Errorf("module %s: %s", m.Name, err)
+			}
+
+			// Check if the detector introduced something new.
+			var subDir2 string
+			source, subDir2 = getter.SourceDirSubdir(source)
+			if subDir2 != "" {
+				subDir = filepath.Join(subDir2, subDir)
+			}
+
+			dir, ok, err = getStorage(s, key, source, mode)
+			if err != nil {
+				return err
+			}
 		}
 		if !ok {
 			return fmt.Errorf(
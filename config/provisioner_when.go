@@ -0,0 +1,16 @@
+package config
+
+//go:generate stringer -type=ProvisionerWhen -output=provisioner_when_string.go provisioner_when.go
+
+// ProvisionerWhen is when a provisioner runs, set with its "when" field.
+type ProvisionerWhen int
+
+const (
+	// ProvisionerWhenCreate provisioners run after their resource is
+	// created. This is the default.
+	ProvisionerWhenCreate ProvisionerWhen = iota
+
+	// ProvisionerWhenDestroy provisioners run before their resource is
+	// destroyed.
+	ProvisionerWhenDestroy
+)
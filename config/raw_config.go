@@ -132,12 +132,12 @@ func (r *RawConfig) Interpolate(vs map[string]ast.Variable) error {
 
 		// None of the variables we need are computed, meaning we should
 		// be able to properly evaluate.
-		result, err := hil.Eval(root, config)
+		result, err := EvalInterpolations(root, config)
 		if err != nil {
 			return "", err
 		}
 
-		return result.Value, nil
+		return hilValueToGo(result.Value), nil
 	})
 }
 
@@ -319,3 +319,38 @@ func langEvalConfig(vs map[string]ast.Variable) *hil.EvalConfig {
 		},
 	}
 }
+
+// hilValueToGo converts the lists and maps nested in the result of an
+// evaluation to Go values. HIL only converts the outer list or map of a
+// result, leaving the values of its elements as []ast.Variable or
+// map[string]ast.Variable.
+func hilValueToGo(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			result[i] = hilValueToGo(e)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			result[k] = hilValueToGo(e)
+		}
+		return result
+	case []ast.Variable:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			result[i] = hilValueToGo(e.Value)
+		}
+		return result
+	case map[string]ast.Variable:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			result[k] = hilValueToGo(e.Value)
+		}
+		return result
+	default:
+		return v
+	}
+}
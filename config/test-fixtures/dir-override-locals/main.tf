@@ -0,0 +1,4 @@
+locals {
+  ami  = "foo"
+  name = "${var.name}"
+}
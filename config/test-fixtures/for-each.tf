@@ -0,0 +1,14 @@
+variable "vlans" {
+    default = {
+        "100" = "vgw-a"
+    }
+}
+
+resource "aws_instance" "web" {
+    for_each = "${var.vlans}"
+    vlan = "${each.key}"
+}
+
+resource "aws_instance" "db" {
+    count = 2
+}
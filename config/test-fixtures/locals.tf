@@ -0,0 +1,11 @@
+locals {
+  foo = "${var.foo}"
+  bar = "bar"
+}
+
+locals {
+  baz = "${local.foo}-${aws_instance.web.id}"
+  map = {
+    key = "value"
+  }
+}
@@ -0,0 +1,11 @@
+resource "aws_instance" "web" {}
+
+module "network" {
+    source = "./network"
+}
+
+module "compute" {
+    source = "./compute"
+    memory = "1G"
+    depends_on = ["module.network", "aws_instance.web"]
+}
@@ -0,0 +1,6 @@
+module "network" {
+    source  = "corp/network/aws"
+    version = "~> 2.1"
+
+    cidr_block = "10.0.0.0/16"
+}
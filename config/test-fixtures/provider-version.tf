@@ -0,0 +1,9 @@
+provider "aws" {
+    version = "~> 1.2"
+    region = "us-west-2"
+}
+
+provider "aws" {
+    alias = "east"
+    region = "us-east-1"
+}
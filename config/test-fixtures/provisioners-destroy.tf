@@ -0,0 +1,17 @@
+resource "aws_instance" "web" {
+    ami = "foo"
+
+    provisioner "shell" {
+        path = "foo"
+    }
+
+    provisioner "shell" {
+        path = "bar"
+        when = "create"
+    }
+
+    provisioner "shell" {
+        path = "baz"
+        when = "destroy"
+    }
+}
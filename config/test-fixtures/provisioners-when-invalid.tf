@@ -0,0 +1,8 @@
+resource "aws_instance" "web" {
+    ami = "foo"
+
+    provisioner "shell" {
+        path = "foo"
+        when = "update"
+    }
+}
@@ -0,0 +1,8 @@
+variable "vlans" {
+    default = {}
+}
+
+resource "aws_instance" "web" {
+    for_each = "${var.vlans}"
+    vlan     = "${count.index}"
+}
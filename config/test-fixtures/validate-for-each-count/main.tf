@@ -0,0 +1,8 @@
+variable "vlans" {
+    default = {}
+}
+
+resource "aws_instance" "web" {
+    count    = 2
+    for_each = "${var.vlans}"
+}
@@ -0,0 +1,5 @@
+resource "aws_instance" "foo" {}
+
+resource "aws_instance" "web" {
+    for_each = "${aws_instance.foo.tags}"
+}
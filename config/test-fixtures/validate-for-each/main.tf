@@ -0,0 +1,12 @@
+variable "vlans" {
+    default = {
+        "100" = "vgw-a"
+        "200" = "vgw-b"
+    }
+}
+
+resource "aws_instance" "web" {
+    for_each = "${var.vlans}"
+    vlan     = "${each.key}"
+    gateway  = "${each.value}"
+}
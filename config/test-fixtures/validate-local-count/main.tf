@@ -0,0 +1,7 @@
+locals {
+  count = 2
+}
+
+resource "aws_instance" "web" {
+  count = "${local.count}"
+}
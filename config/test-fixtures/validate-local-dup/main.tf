@@ -0,0 +1,7 @@
+locals {
+  ami = "foo"
+}
+
+locals {
+  ami = "bar"
+}
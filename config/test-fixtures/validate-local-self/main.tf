@@ -0,0 +1,3 @@
+locals {
+  ami = "${local.ami}-foo"
+}
@@ -0,0 +1,20 @@
+variable "prefix" {}
+
+locals {
+  amazon_address   = "${cidrhost(var.prefix, 1)}"
+  customer_address = "${cidrhost(var.prefix, 2)}"
+}
+
+locals {
+  addresses = ["${local.amazon_address}", "${local.customer_address}"]
+}
+
+resource "aws_instance" "web" {
+  tags {
+    Address = "${local.amazon_address}"
+  }
+}
+
+output "addresses" {
+  value = ["${local.addresses}"]
+}
@@ -0,0 +1,4 @@
+module "compute" {
+    source = "./compute"
+    depends_on = ["module.compute"]
+}
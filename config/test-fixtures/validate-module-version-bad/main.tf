@@ -0,0 +1,4 @@
+module "network" {
+    source  = "corp/network/aws"
+    version = "not a version"
+}
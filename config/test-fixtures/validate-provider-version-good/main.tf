@@ -0,0 +1,3 @@
+provider "aws" {
+    version = ">= 1.0, < 2.0"
+}
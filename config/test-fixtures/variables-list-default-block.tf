@@ -0,0 +1,8 @@
+variable "vifs" {
+    type = "list"
+
+    default {
+        vlan = 100
+        prefix = "10.0.0.0/30"
+    }
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -47,6 +49,8 @@ func TestConfig_Merge(t *testing.T) {
 		Provisioners: map[string]string{
 			"remote": "remote",
 		},
+		ModuleRegistry: "registry.example.com",
+		StateHistory:   20,
 	}
 
 	expected := &Config{
@@ -59,6 +63,8 @@ func TestConfig_Merge(t *testing.T) {
 			"local":  "local",
 			"remote": "remote",
 		},
+		ModuleRegistry: "registry.example.com",
+		StateHistory:   20,
 	}
 
 	actual := c1.Merge(c2)
@@ -66,3 +72,98 @@ func TestConfig_Merge(t *testing.T) {
 		t.Fatalf("bad: %#v", actual)
 	}
 }
+
+func TestConfig_discoverVersions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := []string{
+		"terraform-provider-aws_v1.2.0",
+		"terraform-provider-aws_v1.10.0",
+		"terraform-provider-aws_vbad",
+		"terraform-provider-null",
+		"terraform-provider-null_v0.1.0.exe",
+		"terraform-provider-test_v1.0.0",
+		"terraform-provisioner-file_v1.0.0",
+	}
+	for _, f := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, f), nil, 0755); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	var c Config
+	if err := c.discover(dir); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]map[string]string{
+		"aws": map[string]string{
+			"1.2.0":  filepath.Join(dir, files[0]),
+			"1.10.0": filepath.Join(dir, files[1]),
+		},
+		"null": map[string]string{
+			"0.1.0": filepath.Join(dir, files[4]),
+		},
+		"test": map[string]string{
+			"1.0.0": filepath.Join(dir, files[5]),
+		},
+	}
+	if !reflect.DeepEqual(c.providerVersions, expected) {
+		t.Fatalf("bad: %#v", c.providerVersions)
+	}
+
+	if c.Providers["null"] != filepath.Join(dir, files[3]) {
+		t.Fatalf("bad: %#v", c.Providers)
+	}
+	if c.Provisioners["file"] != filepath.Join(dir, files[6]) {
+		t.Fatalf("bad: %#v", c.Provisioners)
+	}
+
+	plugins := c.ProviderPlugins()
+	if len(plugins["aws"]) != 2 {
+		t.Fatalf("bad: %#v", plugins["aws"])
+	}
+	if len(plugins["null"]) != 2 {
+		t.Fatalf("bad: %#v", plugins["null"])
+	}
+	for _, p := range plugins["null"] {
+		if (p.Version == nil) != (p.Path == filepath.Join(dir, files[3])) {
+			t.Fatalf("bad: %#v", p)
+		}
+	}
+}
+
+func TestConfig_pluginClientShared(t *testing.T) {
+	c := &Config{
+		Providers: map[string]string{
+			"aws":  "terraform-provider-aws",
+			"null": "terraform-provider-null",
+		},
+		Provisioners: map[string]string{
+			"file": "terraform-provisioner-file",
+		},
+		providerVersions: map[string]map[string]string{
+			"aws": map[string]string{
+				"1.0.0": "terraform-provider-aws_v1.0.0",
+			},
+		},
+	}
+
+	// The factories created for the same plugin share its client, so
+	// the plugin only runs in a single process. No process is started
+	// before a factory is called.
+	c.ProviderFactories()
+	c.ProviderPlugins()
+	c.ProvisionerFactories()
+
+	if len(c.pluginClients) != 4 {
+		t.Fatalf("bad: %#v", c.pluginClients)
+	}
+	if c.pluginClient("terraform-provider-null") != c.pluginClients["terraform-provider-null"] {
+		t.Fatal("client should be shared")
+	}
+}
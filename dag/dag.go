@@ -131,8 +131,19 @@ func (g *AcyclicGraph) Validate() error {
 				cycleStr[j] = VertexName(vertex)
 			}
 
+			// The vertices of the cycle alone don't say which edges
+			// form it, which is hard to work out on large graphs, so
+			// trace a path around it too.
+			path := g.CyclePath(cycle)
+			pathStr := make([]string, len(path))
+			for j, vertex := range path {
+				pathStr[j] = VertexName(vertex)
+			}
+
 			err = multierror.Append(err, fmt.Errorf(
-				"Cycle: %s", strings.Join(cycleStr, ", ")))
+				"Cycle: %s\n  Trace: %s",
+				strings.Join(cycleStr, ", "),
+				strings.Join(pathStr, " -> ")))
 		}
 	}
 
@@ -157,6 +168,60 @@ func (g *AcyclicGraph) Cycles() [][]Vertex {
 	return cycles
 }
 
+// CyclePath returns a path along the edges of the graph that goes around
+// the given cycle, as returned by Cycles, starting and ending with its first
+// vertex. It is the shortest such path, so it may not go through all the
+// vertices of the cycle. It returns nil if there is no such path.
+func (g *AcyclicGraph) CyclePath(cycle []Vertex) []Vertex {
+	if len(cycle) == 0 {
+		return nil
+	}
+
+	inCycle := make(map[Vertex]struct{}, len(cycle))
+	for _, v := range cycle {
+		inCycle[v] = struct{}{}
+	}
+
+	// Breadth-first search from the first vertex back to itself, staying
+	// within the cycle, recording where each vertex was reached from.
+	start := cycle[0]
+	from := make(map[Vertex]Vertex)
+	queue := []Vertex{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+
+		for _, raw := range g.DownEdges(v).List() {
+			next := raw.(Vertex)
+			if _, ok := inCycle[next]; !ok {
+				continue
+			}
+
+			if next == start {
+				path := []Vertex{start}
+				for cur := v; cur != start; cur = from[cur] {
+					path = append(path, cur)
+				}
+				path = append(path, start)
+
+				// The path was built backwards
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+
+			if _, ok := from[next]; ok {
+				continue
+			}
+			from[next] = v
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
 // Walk walks the graph, calling your callback as each node is visited.
 // This will walk nodes in parallel if it can. Because the walk is done
 // in parallel, the error returned will be a multierror.
@@ -115,6 +115,54 @@ func TestAcyclicGraphValidate_cycle(t *testing.T) {
 	}
 }
 
+func TestAcyclicGraphValidate_cycleTrace(t *testing.T) {
+	var g AcyclicGraph
+	g.Add(0)
+	g.Add(1)
+	g.Add(2)
+	g.Add(3)
+	g.Connect(BasicEdge(0, 1))
+	g.Connect(BasicEdge(1, 2))
+	g.Connect(BasicEdge(2, 3))
+	g.Connect(BasicEdge(3, 1))
+
+	err := g.Validate()
+	if err == nil {
+		t.Fatal("should error")
+	}
+
+	// The trace depends on the vertex the cycle starts with
+	for _, expected := range []string{"1 -> 2 -> 3 -> 1", "2 -> 3 -> 1 -> 2", "3 -> 1 -> 2 -> 3"} {
+		if strings.Contains(err.Error(), "Trace: "+expected) {
+			return
+		}
+	}
+	t.Fatalf("bad: %s", err)
+}
+
+func TestAcyclicGraphCyclePath(t *testing.T) {
+	var g AcyclicGraph
+	g.Add(1)
+	g.Add(2)
+	g.Add(3)
+	g.Add(4)
+	g.Connect(BasicEdge(1, 2))
+	g.Connect(BasicEdge(2, 3))
+	g.Connect(BasicEdge(2, 4))
+	g.Connect(BasicEdge(3, 1))
+	g.Connect(BasicEdge(4, 1))
+
+	actual := g.CyclePath([]Vertex{1, 2, 3})
+	expected := []Vertex{1, 2, 3, 1}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	if actual := g.CyclePath([]Vertex{1, 3}); actual != nil {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestAcyclicGraphValidate_cycleSelf(t *testing.T) {
 	var g AcyclicGraph
 	g.Add(1)
@@ -0,0 +1,155 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/logutils"
+)
+
+// logLineRegexp matches a line written by the log package with the standard
+// flags, with the level in brackets, e.g.
+// "2016/10/15 01:31:02 [DEBUG] aws.directconnect: Creating connection".
+var logLineRegexp = regexp.MustCompile(
+	`^(?:\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}(?:\.\d+)? )?\[([A-Z]+)\] ?(.*)$`)
+
+// pluginLineRegexp matches the message of a line logged by a plugin, as
+// relayed by Terraform core, e.g. "plugin: terraform-provider-aws: ...".
+var pluginLineRegexp = regexp.MustCompile(`^plugin: ([^:\s]+): (.*)$`)
+
+// moduleRegexp matches the name a scoped Logger prefixes messages with.
+var moduleRegexp = regexp.MustCompile(`^([a-z0-9_-]+(?:\.[a-z0-9_-]+)+): (.*)$`)
+
+// LogFilter is an io.Writer that filters log lines by level, with separate
+// levels for the lines logged by Terraform core and those logged by plugins.
+// Lines without a level continue the line before them, as with values
+// logged over several lines, and are filtered the same way.
+type LogFilter struct {
+	// CoreLevel and ProviderLevel are the minimum levels of the lines of
+	// core and of plugins to write. If empty, no lines are written.
+	CoreLevel     logutils.LogLevel
+	ProviderLevel logutils.LogLevel
+
+	// JSON writes each line as a JSON object rather than as is.
+	JSON bool
+
+	// Writer is where the lines that pass the filter are written.
+	Writer io.Writer
+
+	buf      []byte
+	last     logLine // The level and module of the last line written
+	skipping bool    // Whether the last line was filtered out
+	l        sync.Mutex
+}
+
+// logLine is a single line of log, as written in JSON.
+type logLine struct {
+	Timestamp string `json:"@timestamp"`
+	Level     string `json:"@level,omitempty"`
+	Module    string `json:"@module,omitempty"`
+	Message   string `json:"@message"`
+}
+
+// Write buffers p and writes the complete lines in it that pass the filter.
+// Unlike logutils.LevelFilter, lines may be split across writes.
+func (f *LogFilter) Write(p []byte) (int, error) {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	f.buf = append(f.buf, p...)
+	for {
+		i := bytes.IndexByte(f.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		line := f.buf[:i+1]
+		if err := f.writeLine(line); err != nil {
+			return 0, err
+		}
+		f.buf = f.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+func (f *LogFilter) writeLine(line []byte) error {
+	text := string(bytes.TrimRight(line, "\r\n"))
+
+	m := logLineRegexp.FindStringSubmatch(text)
+	if m == nil {
+		if f.skipping {
+			return nil
+		}
+		return f.write(line, f.last.Level, f.last.Module, text)
+	}
+
+	level, module, message := m[1], "terraform", m[2]
+	minLevel := f.CoreLevel
+
+	// Lines relayed from plugins carry the level they were logged with by
+	// the plugin, if any.
+	if pm := pluginLineRegexp.FindStringSubmatch(message); pm != nil {
+		module, message = pm[1], pm[2]
+		minLevel = f.ProviderLevel
+		if im := logLineRegexp.FindStringSubmatch(message); im != nil {
+			level, message = im[1], im[2]
+		}
+	}
+
+	if mm := moduleRegexp.FindStringSubmatch(message); mm != nil {
+		module, message = mm[1], mm[2]
+	}
+
+	f.skipping = !levelAllowed(logutils.LogLevel(level), minLevel)
+	if f.skipping {
+		return nil
+	}
+
+	f.last = logLine{Level: level, Module: module}
+	return f.write(line, level, module, message)
+}
+
+// write writes a line that passed the filter.
+func (f *LogFilter) write(line []byte, level, module, message string) error {
+	if !f.JSON {
+		_, err := f.Writer.Write(line)
+		return err
+	}
+
+	out, err := json.Marshal(&logLine{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     level,
+		Module:    module,
+		Message:   message,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = f.Writer.Write(append(out, '\n'))
+	return err
+}
+
+// levelAllowed returns whether a line of the given level passes a filter
+// with the given minimum level. Unknown levels always pass, as in
+// logutils.LevelFilter.
+func levelAllowed(level, min logutils.LogLevel) bool {
+	if min == "" {
+		return false
+	}
+
+	for _, l := range validLevels {
+		if l == min {
+			return true
+		}
+		if l == level {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger is a logger scoped to a part of Terraform or of a provider, such
+// as "aws.directconnect". It logs through the standard log package, with
+// the scope after the level, so that the lines can be told apart in the
+// logs and in the "@module" of JSON logs.
+//
+// It is a drop-in replacement for log.Printf:
+//
+//	var dxLog = logging.NewLogger("aws.directconnect")
+//
+//	dxLog.Printf("[DEBUG] Creating connection: %#v", req)
+//
+// logs "[DEBUG] aws.directconnect: Creating connection: ...".
+type Logger struct {
+	name string
+}
+
+// NewLogger returns a Logger with the given scope. Scopes are made of
+// dot-separated lower case names, starting with the name of the provider.
+func NewLogger(name string) *Logger {
+	return &Logger{name: name}
+}
+
+// Printf logs a message like log.Printf. The format should start with the
+// level in brackets, which is kept in front.
+func (l *Logger) Printf(format string, v ...interface{}) {
+	log.Print(l.format(fmt.Sprintf(format, v...)))
+}
+
+// Println logs a message like log.Println. The first operand should be
+// the level in brackets, if any.
+func (l *Logger) Println(v ...interface{}) {
+	log.Print(l.format(strings.TrimSuffix(fmt.Sprintln(v...), "\n")))
+}
+
+// format inserts the scope of the logger in the message, after its level.
+func (l *Logger) format(msg string) string {
+	if strings.HasPrefix(msg, "[") {
+		if i := strings.Index(msg, "] "); i > 0 {
+			return fmt.Sprintf("%s %s: %s", msg[:i+1], l.name, msg[i+2:])
+		}
+	}
+
+	return fmt.Sprintf("%s: %s", l.name, msg)
+}
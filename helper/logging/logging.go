@@ -15,6 +15,16 @@ import (
 const (
 	EnvLog     = "TF_LOG"      // Set to True
 	EnvLogFile = "TF_LOG_PATH" // Set to a file
+
+	// These override the level of TF_LOG for Terraform core and for
+	// plugins (providers and provisioners) respectively, so that one can
+	// be logged without the other.
+	EnvLogCore     = "TF_LOG_CORE"
+	EnvLogProvider = "TF_LOG_PROVIDER"
+
+	// EnvLogFormat can be set to "json" to write each log line as a JSON
+	// object, for ingestion into log aggregation systems.
+	EnvLogFormat = "TF_LOG_FORMAT"
 )
 
 var validLevels = []logutils.LogLevel{"TRACE", "DEBUG", "INFO", "WARN", "ERROR"}
@@ -23,8 +33,8 @@ var validLevels = []logutils.LogLevel{"TRACE", "DEBUG", "INFO", "WARN", "ERROR"}
 func LogOutput() (logOutput io.Writer, err error) {
 	logOutput = ioutil.Discard
 
-	logLevel := LogLevel()
-	if logLevel == "" {
+	coreLevel, providerLevel := CoreLogLevel(), ProviderLogLevel()
+	if coreLevel == "" && providerLevel == "" {
 		return
 	}
 
@@ -37,11 +47,11 @@ func LogOutput() (logOutput io.Writer, err error) {
 		}
 	}
 
-	// This was the default since the beginning
-	logOutput = &logutils.LevelFilter{
-		Levels:   validLevels,
-		MinLevel: logutils.LogLevel(logLevel),
-		Writer:   logOutput,
+	logOutput = &LogFilter{
+		CoreLevel:     logutils.LogLevel(coreLevel),
+		ProviderLevel: logutils.LogLevel(providerLevel),
+		JSON:          strings.ToLower(os.Getenv(EnvLogFormat)) == "json",
+		Writer:        logOutput,
 	}
 
 	return
@@ -49,7 +59,30 @@ func LogOutput() (logOutput io.Writer, err error) {
 
 // LogLevel returns the current log level string based the environment vars
 func LogLevel() string {
-	envLevel := os.Getenv(EnvLog)
+	return logLevel(EnvLog)
+}
+
+// CoreLogLevel returns the log level of Terraform core, which is that of
+// TF_LOG unless TF_LOG_CORE is set.
+func CoreLogLevel() string {
+	if os.Getenv(EnvLogCore) != "" {
+		return logLevel(EnvLogCore)
+	}
+	return LogLevel()
+}
+
+// ProviderLogLevel returns the log level of plugins, which is that of
+// TF_LOG unless TF_LOG_PROVIDER is set.
+func ProviderLogLevel() string {
+	if os.Getenv(EnvLogProvider) != "" {
+		return logLevel(EnvLogProvider)
+	}
+	return LogLevel()
+}
+
+// logLevel returns the log level set by the given environment variable.
+func logLevel(env string) string {
+	envLevel := os.Getenv(env)
 	if envLevel == "" {
 		return ""
 	}
@@ -59,16 +92,18 @@ func LogLevel() string {
 		// allow following for better ux: info, Info or INFO
 		logLevel = strings.ToUpper(envLevel)
 	} else {
-		log.Printf("[WARN] Invalid log level: %q. Defaulting to level: TRACE. Valid levels are: %+v",
-			envLevel, validLevels)
+		log.Printf("[WARN] Invalid log level for %s: %q. Defaulting to level: TRACE. Valid levels are: %+v",
+			env, envLevel, validLevels)
 	}
 
 	return logLevel
 }
 
-// IsDebugOrHigher returns whether or not the current log level is debug or trace
+// IsDebugOrHigher returns whether or not the current log level of plugins
+// is debug or trace. Providers use it to enable the debug logging of the
+// libraries they are built on.
 func IsDebugOrHigher() bool {
-	level := string(LogLevel())
+	level := ProviderLogLevel()
 	return level == "DEBUG" || level == "TRACE"
 }
 
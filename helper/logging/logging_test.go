@@ -0,0 +1,164 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogFilter(t *testing.T) {
+	var buf bytes.Buffer
+	f := &LogFilter{
+		CoreLevel:     "WARN",
+		ProviderLevel: "DEBUG",
+		Writer:        &buf,
+	}
+
+	lines := []string{
+		"2016/10/15 01:31:02 [DEBUG] core debug\n",
+		"2016/10/15 01:31:02 [WARN] core warning\n",
+		"2016/10/15 01:31:02 [DEBUG] plugin: terraform-provider-aws: 2016/10/15 01:31:02 [TRACE] aws trace\n",
+		"2016/10/15 01:31:02 [DEBUG] plugin: terraform-provider-aws: 2016/10/15 01:31:02 [DEBUG] aws.directconnect: aws debug\n",
+		"2016/10/15 01:31:02 [DEBUG] plugin: terraform-provider-aws: 2016/10/15 01:31:02 [INFO] aws info\n",
+		"2016/10/15 01:31:02 [INFO] core info\n",
+		"{\n",
+		"}\n",
+	}
+	for _, l := range lines {
+		if _, err := f.Write([]byte(l)); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	expected := strings.Join([]string{
+		lines[1],
+		lines[3],
+		lines[4],
+	}, "")
+	if buf.String() != expected {
+		t.Fatalf("bad:\n%s\n\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestLogFilter_continuation(t *testing.T) {
+	var buf bytes.Buffer
+	f := &LogFilter{
+		CoreLevel:     "INFO",
+		ProviderLevel: "INFO",
+		Writer:        &buf,
+	}
+
+	// Lines may be split across writes, and values logged over several
+	// lines are filtered with the line they start on.
+	writes := []string{
+		"2016/10/15 01:31:02 [DEBUG] request: {\n  Name: \"foo\"\n}\n2016/10/15",
+		" 01:31:02 [INFO] response: {\n",
+		"  Name: \"foo\"\n}\n",
+	}
+	for _, w := range writes {
+		if _, err := f.Write([]byte(w)); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	expected := "2016/10/15 01:31:02 [INFO] response: {\n  Name: \"foo\"\n}\n"
+	if buf.String() != expected {
+		t.Fatalf("bad:\n%s\n\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestLogFilter_json(t *testing.T) {
+	var buf bytes.Buffer
+	f := &LogFilter{
+		CoreLevel:     "TRACE",
+		ProviderLevel: "TRACE",
+		JSON:          true,
+		Writer:        &buf,
+	}
+
+	lines := []string{
+		"2016/10/15 01:31:02 [INFO] Terraform version: 0.7.0\n",
+		"2016/10/15 01:31:02 [DEBUG] plugin: terraform-provider-aws: 2016/10/15 01:31:02 [DEBUG] aws.directconnect: Creating connection\n",
+		"2016/10/15 01:31:02 [DEBUG] plugin: terraform-provider-aws: 2016/10/15 01:31:02 [WARN] Unscoped\n",
+	}
+	for _, l := range lines {
+		if _, err := f.Write([]byte(l)); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	expected := []logLine{
+		{Level: "INFO", Module: "terraform", Message: "Terraform version: 0.7.0"},
+		{Level: "DEBUG", Module: "aws.directconnect", Message: "Creating connection"},
+		{Level: "WARN", Module: "terraform-provider-aws", Message: "Unscoped"},
+	}
+
+	dec := json.NewDecoder(&buf)
+	for i, e := range expected {
+		var actual logLine
+		if err := dec.Decode(&actual); err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if actual.Timestamp == "" {
+			t.Fatalf("%d: no timestamp", i)
+		}
+		actual.Timestamp = ""
+		if actual != e {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestLogLevels(t *testing.T) {
+	defer os.Setenv(EnvLog, os.Getenv(EnvLog))
+	defer os.Setenv(EnvLogCore, os.Getenv(EnvLogCore))
+	defer os.Setenv(EnvLogProvider, os.Getenv(EnvLogProvider))
+
+	cases := []struct {
+		Log, Core, Provider      string
+		CoreLevel, ProviderLevel string
+	}{
+		{"", "", "", "", ""},
+		{"debug", "", "", "DEBUG", "DEBUG"},
+		{"1", "", "", "TRACE", "TRACE"},
+		{"DEBUG", "warn", "", "WARN", "DEBUG"},
+		{"", "", "info", "", "INFO"},
+		{"info", "error", "trace", "ERROR", "TRACE"},
+	}
+
+	for i, tc := range cases {
+		os.Setenv(EnvLog, tc.Log)
+		os.Setenv(EnvLogCore, tc.Core)
+		os.Setenv(EnvLogProvider, tc.Provider)
+
+		if level := CoreLogLevel(); level != tc.CoreLevel {
+			t.Fatalf("%d: expected core level %q, got %q", i, tc.CoreLevel, level)
+		}
+		if level := ProviderLogLevel(); level != tc.ProviderLevel {
+			t.Fatalf("%d: expected provider level %q, got %q", i, tc.ProviderLevel, level)
+		}
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	defer log.SetOutput(os.Stderr)
+	defer log.SetFlags(log.Flags())
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+
+	l := NewLogger("aws.directconnect")
+	l.Printf("[DEBUG] Creating %s", "connection")
+	l.Println("[INFO]", "Created")
+	l.Printf("No level")
+
+	expected := "[DEBUG] aws.directconnect: Creating connection\n" +
+		"[INFO] aws.directconnect: Created\n" +
+		"aws.directconnect: No level\n"
+	if buf.String() != expected {
+		t.Fatalf("bad:\n%s", buf.String())
+	}
+}
@@ -15,3 +15,11 @@ func (e *NotFoundError) Error() string {
 
 	return "couldn't find resource"
 }
+
+// IsNotFound returns true if err is a *NotFoundError. Providers return
+// *NotFoundError for objects their API doesn't know about, so that waiting
+// and retrying can treat them uniformly, such as with RetryNotFound.
+func IsNotFound(err error) bool {
+	_, ok := err.(*NotFoundError)
+	return ok
+}
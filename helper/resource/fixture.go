@@ -0,0 +1,293 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// FixtureEnvVar is the name of the environment variable that sets the mode
+// of the HTTP fixtures of acceptance tests, see FixtureTransport.
+const FixtureEnvVar = "TF_ACC_FIXTURE"
+
+const (
+	// FixtureModeRecord records the HTTP requests of the test, and the
+	// responses to them, to the fixture file.
+	FixtureModeRecord = "record"
+
+	// FixtureModeReplay answers the HTTP requests of the test with the
+	// responses recorded in the fixture file, without making any request.
+	FixtureModeReplay = "replay"
+)
+
+// FixtureTransport is an http.RoundTripper that records or replays the HTTP
+// requests of an acceptance test, so that tests of resources that can't be
+// created on demand, like physical network connections, can run in CI.
+//
+// The mode is taken from the TF_ACC_FIXTURE environment variable. If it
+// isn't set, requests are passed through to the underlying transport and
+// nothing is recorded.
+//
+// Requests are matched on their method, URL, body and MatchHeaders.
+// Identical requests, e.g. when polling for a state change, are answered
+// in the order they were recorded in. Values the requests depend on that
+// aren't part of the test, like the IDs of existing resources, are recorded
+// along with them, see Getenv.
+type FixtureTransport struct {
+	// Path is the path of the fixture file.
+	Path string
+
+	// Mode is either FixtureModeRecord, FixtureModeReplay or empty.
+	Mode string
+
+	// MatchHeaders are the names of the request headers that are recorded
+	// and matched on, in addition to the method, URL and body. Headers
+	// that vary from one run to the next, e.g. signatures, must not be
+	// listed.
+	MatchHeaders []string
+
+	// Transport is the transport requests are passed through to when not
+	// replaying. It defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	fixture *fixtureFile
+	used    []bool
+	l       sync.Mutex
+}
+
+// fixtureFile is the content of a fixture file.
+type fixtureFile struct {
+	Variables    map[string]string     `json:"variables,omitempty"`
+	Interactions []*FixtureInteraction `json:"interactions"`
+}
+
+// FixtureInteraction is a request and its response, as recorded in a
+// fixture file.
+type FixtureInteraction struct {
+	Request  *FixtureRequest  `json:"request"`
+	Response *FixtureResponse `json:"response"`
+}
+
+// FixtureRequest is a recorded HTTP request.
+type FixtureRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// FixtureResponse is a recorded HTTP response.
+type FixtureResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// NewFixtureTransport returns a FixtureTransport for the fixture file at
+// the given path, in the mode set by TF_ACC_FIXTURE. The fixture file is
+// read when replaying, and it is an error if it doesn't exist.
+func NewFixtureTransport(path string, matchHeaders ...string) (*FixtureTransport, error) {
+	t := &FixtureTransport{
+		Path:         path,
+		Mode:         os.Getenv(FixtureEnvVar),
+		MatchHeaders: matchHeaders,
+		fixture:      new(fixtureFile),
+	}
+
+	switch t.Mode {
+	case "", FixtureModeRecord:
+	case FixtureModeReplay:
+		if err := t.load(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf(
+			"%s must be %q or %q, got %q",
+			FixtureEnvVar, FixtureModeRecord, FixtureModeReplay, t.Mode)
+	}
+
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Mode == "" {
+		return t.transport().RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	fr := &FixtureRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Body:   string(body),
+	}
+	for _, h := range t.MatchHeaders {
+		if v := req.Header.Get(h); v != "" {
+			if fr.Headers == nil {
+				fr.Headers = make(map[string]string)
+			}
+			fr.Headers[http.CanonicalHeaderKey(h)] = v
+		}
+	}
+
+	if t.Mode == FixtureModeReplay {
+		return t.replay(req, fr)
+	}
+
+	return t.record(req, fr)
+}
+
+// Getenv returns the value of the environment variable with the given
+// name, as os.Getenv. When recording, the value is saved to the fixture
+// file, and when replaying, the saved value is returned instead.
+func (t *FixtureTransport) Getenv(name string) string {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if t.Mode == FixtureModeReplay {
+		return t.fixture.Variables[name]
+	}
+
+	v := os.Getenv(name)
+	if t.Mode == FixtureModeRecord {
+		if t.fixture.Variables == nil {
+			t.fixture.Variables = make(map[string]string)
+		}
+		t.fixture.Variables[name] = v
+	}
+	return v
+}
+
+// Save writes the recorded interactions to the fixture file. It does
+// nothing unless recording.
+func (t *FixtureTransport) Save() error {
+	if t.Mode != FixtureModeRecord {
+		return nil
+	}
+
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	data, err := json.MarshalIndent(t.fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Test: Saving %d HTTP interactions to %s", len(t.fixture.Interactions), t.Path)
+	return ioutil.WriteFile(t.Path, append(data, '\n'), 0644)
+}
+
+func (t *FixtureTransport) record(req *http.Request, fr *FixtureRequest) (*http.Response, error) {
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	t.l.Lock()
+	defer t.l.Unlock()
+	t.fixture.Interactions = append(t.fixture.Interactions, &FixtureInteraction{
+		Request: fr,
+		Response: &FixtureResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header,
+			Body:       string(body),
+		},
+	})
+
+	return resp, nil
+}
+
+func (t *FixtureTransport) replay(req *http.Request, fr *FixtureRequest) (*http.Response, error) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	for i, interaction := range t.fixture.Interactions {
+		if t.used[i] || !fixtureRequestsMatch(interaction.Request, fr) {
+			continue
+		}
+		t.used[i] = true
+
+		r := interaction.Response
+		header := r.Headers
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+			StatusCode:    r.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader([]byte(r.Body))),
+			ContentLength: int64(len(r.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf(
+		"no interaction recorded in %s for %s %s with body %q",
+		t.Path, fr.Method, fr.URL, fr.Body)
+}
+
+func (t *FixtureTransport) load() error {
+	data, err := ioutil.ReadFile(t.Path)
+	if err != nil {
+		return fmt.Errorf("Error reading fixture: %s", err)
+	}
+
+	var f fixtureFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("Error parsing fixture %s: %s", t.Path, err)
+	}
+
+	t.fixture = &f
+	t.used = make([]bool, len(f.Interactions))
+	return nil
+}
+
+func (t *FixtureTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+func fixtureRequestsMatch(a, b *FixtureRequest) bool {
+	if a.Method != b.Method || a.URL != b.URL || a.Body != b.Body {
+		return false
+	}
+	if len(a.Headers) != len(b.Headers) {
+		return false
+	}
+	for k, v := range a.Headers {
+		if b.Headers[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,124 @@
+package resource
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFixtureTransport(t *testing.T) {
+	defer os.Setenv(FixtureEnvVar, os.Getenv(FixtureEnvVar))
+	defer os.Unsetenv("TF_TEST_FIXTURE_ID")
+
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fixture.json")
+
+	var count int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&count, 1)
+		body, _ := ioutil.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s %d", r.Header.Get("X-Op"), body, n)
+	}))
+	defer ts.Close()
+
+	requests := []struct {
+		Op, Body string
+	}{
+		{"Describe", "foo"},
+		{"Describe", "foo"},
+		{"Create", "foo"},
+	}
+	expected := []string{"Describe foo 1", "Describe foo 2", "Create foo 3"}
+
+	// Record
+	os.Setenv(FixtureEnvVar, FixtureModeRecord)
+	ft, err := NewFixtureTransport(path, "X-Op")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	os.Setenv("TF_TEST_FIXTURE_ID", "foo")
+	if v := ft.Getenv("TF_TEST_FIXTURE_ID"); v != "foo" {
+		t.Fatalf("bad: %q", v)
+	}
+	for i, r := range requests {
+		if actual := testFixtureRequest(t, ft, ts.URL, r.Op, r.Body); actual != expected[i] {
+			t.Fatalf("%d: bad: %q", i, actual)
+		}
+	}
+	if err := ft.Save(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Replay, without the server
+	ts.Close()
+	os.Setenv(FixtureEnvVar, FixtureModeReplay)
+	ft, err = NewFixtureTransport(path, "X-Op")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	os.Setenv("TF_TEST_FIXTURE_ID", "bar")
+	if v := ft.Getenv("TF_TEST_FIXTURE_ID"); v != "foo" {
+		t.Fatalf("bad: %q", v)
+	}
+	for _, i := range []int{2, 0, 1} {
+		r := requests[i]
+		if actual := testFixtureRequest(t, ft, ts.URL, r.Op, r.Body); actual != expected[i] {
+			t.Fatalf("%d: bad: %q", i, actual)
+		}
+	}
+
+	// All the interactions have been used up
+	req, _ := http.NewRequest("POST", ts.URL, strings.NewReader("foo"))
+	req.Header.Set("X-Op", "Describe")
+	if _, err := ft.RoundTrip(req); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestNewFixtureTransport_badMode(t *testing.T) {
+	defer os.Setenv(FixtureEnvVar, os.Getenv(FixtureEnvVar))
+	os.Setenv(FixtureEnvVar, "nope")
+
+	if _, err := NewFixtureTransport("fixture.json"); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestNewFixtureTransport_replayMissing(t *testing.T) {
+	defer os.Setenv(FixtureEnvVar, os.Getenv(FixtureEnvVar))
+	os.Setenv(FixtureEnvVar, FixtureModeReplay)
+
+	if _, err := NewFixtureTransport("test-fixtures/nope.json"); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func testFixtureRequest(t *testing.T, rt http.RoundTripper, url, op, body string) string {
+	req, err := http.NewRequest("POST", url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	req.Header.Set("X-Op", op)
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return string(data)
+}
@@ -1,14 +1,18 @@
 package resource
 
 import (
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
 )
 
+var allHex = regexp.MustCompile(`^[a-f0-9]+$`)
+
 func TestUniqueId(t *testing.T) {
 	iterations := 10000
 	ids := make(map[string]struct{})
-	var id string
+	var id, lastId string
 	for i := 0; i < iterations; i++ {
 		id = UniqueId()
 
@@ -20,6 +24,54 @@ func TestUniqueId(t *testing.T) {
 			t.Fatalf("Unique ID didn't have terraform- prefix! %s", id)
 		}
 
+		rest := strings.TrimPrefix(id, "terraform-")
+		if len(rest) != UniqueIDSuffixLength {
+			t.Fatalf("Post-prefix part has wrong length! %s", rest)
+		}
+
+		if !allHex.MatchString(rest) {
+			t.Fatalf("Post-prefix part has non-hex characters! %s", rest)
+		}
+
+		if lastId != "" && lastId >= id {
+			t.Fatalf("IDs not ordered! %s vs %s", lastId, id)
+		}
+
 		ids[id] = struct{}{}
+		lastId = id
+	}
+}
+
+func TestPrefixedUniqueId(t *testing.T) {
+	id := PrefixedUniqueId("tf-test-")
+	if !strings.HasPrefix(id, "tf-test-") {
+		t.Fatalf("Unique ID didn't have tf-test- prefix! %s", id)
+	}
+	if len(id) != len("tf-test-")+UniqueIDSuffixLength {
+		t.Fatalf("Unique ID has wrong length! %s", id)
+	}
+}
+
+func TestUniqueId_concurrent(t *testing.T) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ids := make(map[string]struct{})
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				id := UniqueId()
+
+				mu.Lock()
+				if _, ok := ids[id]; ok {
+					t.Errorf("Got duplicated id! %s", id)
+				}
+				ids[id] = struct{}{}
+				mu.Unlock()
+			}
+		}()
 	}
+	wg.Wait()
 }
@@ -5,9 +5,15 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/rand"
+	"strings"
 	"time"
 )
 
+// maxBackoff is the longest wait between refreshes when backing off
+// exponentially.
+const maxBackoff = 10 * time.Second
+
 // StateRefreshFunc is a function type used for StateChangeConf that is
 // responsible for refreshing the item being watched for a state change.
 //
@@ -20,6 +26,38 @@ import (
 // may have happened while refreshing the state.
 type StateRefreshFunc func() (result interface{}, state string, err error)
 
+// ComposeStateRefreshFunc lets you compose multiple StateRefreshFuncs into a
+// single StateRefreshFunc, to wait for several conditions at once.
+//
+// The composed state is the states of all the funcs joined by "/", in order,
+// so waiting for an instance to be "running" while its volume is "attached"
+// means targeting "running/attached". The result is that of the first func.
+//
+// Refreshing stops at the first func that returns an error, which is
+// returned. If any func doesn't find its object, neither does the composed
+// one.
+func ComposeStateRefreshFunc(fs ...StateRefreshFunc) StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var first interface{}
+		states := make([]string, len(fs))
+		for i, f := range fs {
+			result, state, err := f()
+			if err != nil {
+				return nil, "", fmt.Errorf("Refresh %d/%d error: %s", i+1, len(fs), err)
+			}
+			if result == nil {
+				return nil, "", nil
+			}
+			if i == 0 {
+				first = result
+			}
+			states[i] = state
+		}
+
+		return first, strings.Join(states, "/"), nil
+	}
+}
+
 // StateChangeConf is the configuration struct used for `WaitForState`.
 type StateChangeConf struct {
 	Delay          time.Duration    // Wait this time before starting checks
@@ -28,6 +66,7 @@ type StateChangeConf struct {
 	Target         []string         // Target state
 	Timeout        time.Duration    // The amount of time to wait before timeout
 	MinTimeout     time.Duration    // Smallest time to wait before refreshes
+	PollInterval   time.Duration    // Fixed time to wait between refreshes, instead of backing off
 	NotFoundChecks int              // Number of times to allow not found
 
 	// This is to work around inconsistent APIs
@@ -67,25 +106,29 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 	var resulterr error
 
 	doneCh := make(chan struct{})
+	cancelCh := make(chan struct{})
+	defer close(cancelCh)
 	go func() {
 		defer close(doneCh)
 
 		// Wait for the delay
-		time.Sleep(conf.Delay)
+		select {
+		case <-time.After(conf.Delay):
+		case <-cancelCh:
+			return
+		}
 
 		var err error
 		for tries := 0; ; tries++ {
-			// Wait between refreshes using an exponential backoff
-			wait := time.Duration(math.Pow(2, float64(tries))) *
-				100 * time.Millisecond
-			if wait < conf.MinTimeout {
-				wait = conf.MinTimeout
-			} else if wait > 10*time.Second {
-				wait = 10 * time.Second
-			}
-
+			wait := conf.wait(tries)
 			log.Printf("[TRACE] Waiting %s before next try", wait)
-			time.Sleep(wait)
+
+			// Stop refreshing once we've given up waiting
+			select {
+			case <-time.After(wait):
+			case <-cancelCh:
+				return
+			}
 
 			var currentState string
 			result, currentState, err = conf.Refresh()
@@ -157,3 +200,25 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 			conf.Target)
 	}
 }
+
+// wait returns how long to wait before the given try at refreshing the
+// state. Unless PollInterval is set, this backs off exponentially from
+// 100ms up to 10s, and never less than MinTimeout. Up to 10% jitter is
+// added, so that many resources waiting at once don't refresh in lockstep.
+func (conf *StateChangeConf) wait(tries int) time.Duration {
+	if conf.PollInterval > 0 {
+		return conf.PollInterval
+	}
+
+	wait := maxBackoff
+	if tries < 10 {
+		wait = time.Duration(math.Pow(2, float64(tries))) * 100 * time.Millisecond
+	}
+	if wait < conf.MinTimeout {
+		wait = conf.MinTimeout
+	} else if wait > maxBackoff {
+		wait = maxBackoff
+	}
+
+	return wait + time.Duration(rand.Int63n(int64(wait)/10+1))
+}
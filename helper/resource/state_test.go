@@ -178,3 +178,184 @@ func TestWaitForState_failure(t *testing.T) {
 		t.Fatalf("should not return obj")
 	}
 }
+
+func TestComposeStateRefreshFunc_success(t *testing.T) {
+	first := struct{ name string }{"first"}
+	f := ComposeStateRefreshFunc(
+		func() (interface{}, string, error) {
+			return first, "available", nil
+		},
+		func() (interface{}, string, error) {
+			return struct{}{}, "up", nil
+		},
+	)
+
+	obj, state, err := f()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if state != "available/up" {
+		t.Fatalf("bad state: %s", state)
+	}
+	if obj != first {
+		t.Fatalf("bad obj: %#v", obj)
+	}
+}
+
+func TestComposeStateRefreshFunc_failure(t *testing.T) {
+	called := false
+	f := ComposeStateRefreshFunc(
+		SuccessfulStateRefreshFunc(),
+		FailedStateRefreshFunc(),
+		func() (interface{}, string, error) {
+			called = true
+			return struct{}{}, "up", nil
+		},
+	)
+
+	obj, _, err := f()
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if err.Error() != "Refresh 2/3 error: failed" {
+		t.Fatalf("bad err: %s", err)
+	}
+	if obj != nil {
+		t.Fatalf("should not return obj")
+	}
+	if called {
+		t.Fatal("should not refresh after an error")
+	}
+}
+
+func TestComposeStateRefreshFunc_notFound(t *testing.T) {
+	f := ComposeStateRefreshFunc(
+		SuccessfulStateRefreshFunc(),
+		func() (interface{}, string, error) {
+			return nil, "", nil
+		},
+	)
+
+	obj, state, err := f()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if obj != nil || state != "" {
+		t.Fatalf("should not find obj, got %#v in state %q", obj, state)
+	}
+}
+
+func TestWaitForState_composed(t *testing.T) {
+	bgp := NewStateGenerator([]string{"down", "down", "up"})
+	conf := &StateChangeConf{
+		Pending: []string{"running/down"},
+		Target:  []string{"running/up"},
+		Refresh: ComposeStateRefreshFunc(
+			SuccessfulStateRefreshFunc(),
+			func() (interface{}, string, error) {
+				_, state, err := bgp.NextState()
+				return struct{}{}, state, err
+			},
+		),
+		Timeout: 200 * time.Second,
+	}
+
+	if _, err := conf.WaitForState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestStateChangeConf_wait(t *testing.T) {
+	cases := map[string]struct {
+		Conf     StateChangeConf
+		Tries    int
+		Min, Max time.Duration
+	}{
+		"first try": {
+			Tries: 0,
+			Min:   100 * time.Millisecond,
+			Max:   110 * time.Millisecond,
+		},
+		"backing off": {
+			Tries: 3,
+			Min:   800 * time.Millisecond,
+			Max:   880 * time.Millisecond,
+		},
+		"capped": {
+			Tries: 50,
+			Min:   10 * time.Second,
+			Max:   11 * time.Second,
+		},
+		"min timeout": {
+			Conf:  StateChangeConf{MinTimeout: 5 * time.Second},
+			Tries: 0,
+			Min:   5 * time.Second,
+			Max:   5500 * time.Millisecond,
+		},
+		"poll interval": {
+			Conf:  StateChangeConf{PollInterval: 30 * time.Second, MinTimeout: 5 * time.Second},
+			Tries: 50,
+			Min:   30 * time.Second,
+			Max:   30 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		for i := 0; i < 100; i++ {
+			wait := tc.Conf.wait(tc.Tries)
+			if wait < tc.Min || wait > tc.Max {
+				t.Fatalf("%s: expected a wait in [%s, %s], got %s", name, tc.Min, tc.Max, wait)
+			}
+		}
+	}
+}
+
+func TestWaitForState_pollInterval(t *testing.T) {
+	refreshes := 0
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"running"},
+		Refresh: func() (interface{}, string, error) {
+			refreshes++
+			if refreshes < 3 {
+				return struct{}{}, "pending", nil
+			}
+			return struct{}{}, "running", nil
+		},
+		PollInterval: time.Millisecond,
+		Timeout:      time.Second,
+	}
+
+	if _, err := conf.WaitForState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if refreshes != 3 {
+		t.Fatalf("expected 3 refreshes, got %d", refreshes)
+	}
+}
+
+func TestWaitForState_stopsOnTimeout(t *testing.T) {
+	refreshes := make(chan struct{}, 100)
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"running"},
+		Refresh: func() (interface{}, string, error) {
+			refreshes <- struct{}{}
+			return struct{}{}, "pending", nil
+		},
+		PollInterval: 5 * time.Millisecond,
+		Timeout:      20 * time.Millisecond,
+	}
+
+	if _, err := conf.WaitForState(); err == nil {
+		t.Fatal("expected a timeout")
+	}
+
+	// Let any refresh already in progress finish
+	time.Sleep(10 * time.Millisecond)
+	n := len(refreshes)
+	time.Sleep(50 * time.Millisecond)
+	if len(refreshes) != n {
+		t.Fatalf("still refreshing after the timeout")
+	}
+}
@@ -64,6 +64,12 @@ type TestCase struct {
 	// same state. Each step can have its own check to verify correctness.
 	Steps []TestStep
 
+	// Fixture, if non-nil, records the HTTP requests of the test to a
+	// fixture file, or replays them from it, depending on TF_ACC_FIXTURE.
+	// The providers must send their requests through it. Tests replaying
+	// a fixture run without TF_ACC, as they don't touch real resources.
+	Fixture *FixtureTransport
+
 	// The settings below control the "ID-only refresh test." This is
 	// an enabled-by-default test that tests that a refresh can be
 	// refreshed with only an ID to result in the same attributes.
@@ -75,6 +81,11 @@ type TestCase struct {
 	// IDRefreshIgnore is a list of configuration keys that will be ignored.
 	IDRefreshName   string
 	IDRefreshIgnore []string
+
+	// PreventPostDestroyRefresh can be set to true for cases where data
+	// sources are tested, since a refresh after the destroy would read
+	// them again and leave them in the state.
+	PreventPostDestroyRefresh bool
 }
 
 // TestStep is a single apply sequence of a test, done within the
@@ -131,6 +142,10 @@ type TestStep struct {
 	// looking to verify that a diff occurs
 	ExpectNonEmptyPlan bool
 
+	// PreventPostDestroyRefresh skips the refresh and second plan after
+	// a destroy step. See TestCase.PreventPostDestroyRefresh.
+	PreventPostDestroyRefresh bool
+
 	//---------------------------------------------------------------
 	// ImportState testing
 	//---------------------------------------------------------------
@@ -172,16 +187,27 @@ type TestStep struct {
 // long, we require the verbose flag so users are able to see progress
 // output.
 func Test(t TestT, c TestCase) {
+	replaying := c.Fixture != nil && c.Fixture.Mode == FixtureModeReplay
+
 	// We only run acceptance tests if an env var is set because they're
 	// slow and generally require some outside configuration.
-	if os.Getenv(TestEnvVar) == "" {
+	if os.Getenv(TestEnvVar) == "" && !replaying {
 		t.Skip(fmt.Sprintf(
 			"Acceptance tests skipped unless env '%s' set",
 			TestEnvVar))
 		return
 	}
 
-	isUnitTest := (os.Getenv(TestEnvVar) == UnitTestOverride)
+	isUnitTest := (os.Getenv(TestEnvVar) == UnitTestOverride) || replaying
+
+	// Save the requests made once the test is done, if recording
+	if c.Fixture != nil {
+		defer func() {
+			if err := c.Fixture.Save(); err != nil {
+				t.Error(fmt.Sprintf("Error saving fixture: %s", err))
+			}
+		}()
+	}
 
 	logWriter, err := logging.LogOutput()
 	if err != nil {
@@ -287,6 +313,8 @@ func Test(t TestT, c TestCase) {
 			Config:  c.Steps[len(c.Steps)-1].Config,
 			Check:   c.CheckDestroy,
 			Destroy: true,
+
+			PreventPostDestroyRefresh: c.PreventPostDestroyRefresh,
 		}
 
 		log.Printf("[WARN] Test: Executing destroy step")
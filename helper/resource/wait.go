@@ -45,6 +45,32 @@ func Retry(timeout time.Duration, f RetryFunc) error {
 	return resultErr
 }
 
+// RetryClassified retries f until it succeeds or returns an error that the
+// retryable classifier doesn't consider retryable, so that a provider can
+// classify the errors of its API once instead of in every RetryFunc.
+func RetryClassified(timeout time.Duration, retryable RetryErrorClassifier, f func() error) error {
+	return Retry(timeout, func() *RetryError {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if retryable(err) {
+			return RetryableError(err)
+		}
+		return NonRetryableError(err)
+	})
+}
+
+// RetryNotFound retries f while it returns a *NotFoundError. This is how to
+// read an object that was just created from an eventually consistent API,
+// which may not return it yet.
+func RetryNotFound(timeout time.Duration, f func() error) error {
+	return RetryClassified(timeout, IsNotFound, f)
+}
+
+// RetryErrorClassifier returns true if err is retryable.
+type RetryErrorClassifier func(err error) bool
+
 // RetryFunc is the function retried until it succeeds.
 type RetryFunc func() *RetryError
 
@@ -60,3 +60,61 @@ func TestRetry_error(t *testing.T) {
 		t.Fatal("timeout")
 	}
 }
+
+func TestRetryClassified(t *testing.T) {
+	t.Parallel()
+
+	retryable := fmt.Errorf("retryable")
+	expected := fmt.Errorf("nope")
+
+	tries := 0
+	f := func() error {
+		tries++
+		if tries == 3 {
+			return expected
+		}
+
+		return retryable
+	}
+
+	err := RetryClassified(10*time.Second, func(err error) bool {
+		return err == retryable
+	}, f)
+	if err != expected {
+		t.Fatalf("bad: %#v", err)
+	}
+	if tries != 3 {
+		t.Fatalf("bad: %d", tries)
+	}
+}
+
+func TestRetryNotFound(t *testing.T) {
+	t.Parallel()
+
+	tries := 0
+	f := func() error {
+		tries++
+		if tries == 3 {
+			return nil
+		}
+
+		return &NotFoundError{}
+	}
+
+	err := RetryNotFound(10*time.Second, f)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestRetryNotFound_error(t *testing.T) {
+	t.Parallel()
+
+	expected := fmt.Errorf("nope")
+	err := RetryNotFound(10*time.Second, func() error {
+		return expected
+	})
+	if err != expected {
+		t.Fatalf("bad: %#v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// coreSchemaTypes are the names of the value types in a
+// terraform.AttributeSchema.
+var coreSchemaTypes = map[ValueType]string{
+	TypeBool:              "bool",
+	TypeInt:               "int",
+	TypeFloat:             "float",
+	TypeString:            "string",
+	TypeList:              "list",
+	TypeMap:               "map",
+	TypeSet:               "set",
+	TypeSingleNestedBlock: "object",
+}
+
+// coreSchema returns the schema map as a terraform.ResourceSchema, which
+// is what the schemas of providers are exported as.
+func (m schemaMap) coreSchema() *terraform.ResourceSchema {
+	result := &terraform.ResourceSchema{
+		Attributes: make(map[string]*terraform.AttributeSchema, len(m)),
+	}
+	for k, s := range m {
+		result.Attributes[k] = s.coreSchema()
+	}
+
+	return result
+}
+
+// coreSchema returns the schema as a terraform.AttributeSchema.
+func (s *Schema) coreSchema() *terraform.AttributeSchema {
+	result := &terraform.AttributeSchema{
+		Type:          coreSchemaTypes[s.Type],
+		Description:   s.Description,
+		Required:      s.Required,
+		Optional:      s.Optional,
+		Computed:      s.Computed,
+		ForceNew:      s.ForceNew,
+		Sensitive:     s.Sensitive,
+		Deprecated:    s.Deprecated,
+		Removed:       s.Removed,
+		ConflictsWith: s.ConflictsWith,
+		MaxItems:      s.MaxItems,
+	}
+
+	switch e := s.Elem.(type) {
+	case *Schema:
+		result.Elem = e.coreSchema()
+	case *Resource:
+		result.Block = schemaMap(e.Schema).coreSchema()
+	}
+
+	return result
+}
@@ -98,6 +98,27 @@ func addrToSchema(addr []string, schemaMap map[string]*Schema) []*Schema {
 			if len(addr) > 0 {
 				current = &Schema{Type: TypeString}
 			}
+		case TypeSingleNestedBlock:
+			// The fields of a nested object are addressed right after
+			// it, without an index: "foo.bar", not "foo.0.bar".
+			if len(addr) == 0 {
+				break
+			}
+
+			k = addr[0]
+			addr = addr[1:]
+
+			r, ok := current.Elem.(*Resource)
+			if !ok {
+				return nil
+			}
+			val, ok := r.Schema[k]
+			if !ok {
+				return nil
+			}
+
+			current = val
+			goto REPEAT
 		case typeObject:
 			// If we're already in the object, then we want to handle Sets
 			// and Lists specially. Basically, their next key is the lookup
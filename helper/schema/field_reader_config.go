@@ -77,7 +77,27 @@ func (r *ConfigFieldReader) readField(
 		}
 	}
 
+	// Nested objects are blocks in the config, so their fields are in
+	// the first and only element of a list there.
 	k := strings.Join(address, ".")
+	if len(schemaList) > 1 {
+		parts := make([]string, 0, len(address)+1)
+		for i, v := range schemaList[:len(schemaList)-1] {
+			parts = append(parts, address[i])
+			if v.Type != TypeSingleNestedBlock {
+				continue
+			}
+
+			// The fields of an object that isn't in the config at all
+			// don't exist, so they don't get their defaults either.
+			if _, ok := r.Config.GetRaw(strings.Join(parts, ".")); !ok {
+				return FieldReadResult{}, nil
+			}
+			parts = append(parts, "0")
+		}
+		k = strings.Join(append(parts, address[len(address)-1]), ".")
+	}
+
 	schema := schemaList[len(schemaList)-1]
 	switch schema.Type {
 	case TypeBool, TypeFloat, TypeInt, TypeString:
@@ -88,6 +108,10 @@ func (r *ConfigFieldReader) readField(
 		return r.readMap(k)
 	case TypeSet:
 		return r.readSet(address, schema)
+	case TypeSingleNestedBlock:
+		return readObjectField(
+			&nestedConfigFieldReader{r},
+			address, schema.Elem.(*Resource).Schema)
 	case typeObject:
 		return readObjectField(
 			&nestedConfigFieldReader{r},
@@ -257,10 +281,26 @@ func (r *ConfigFieldReader) readSet(
 	}, nil
 }
 
+// aliasSetCode makes the element of the set at the given address with the
+// hash code to addressable by the hash code from as well.
+func (r *ConfigFieldReader) aliasSetCode(address, from, to string) {
+	r.once.Do(func() { r.indexMaps = make(map[string]map[string]int) })
+	indexMap, ok := r.indexMaps[address]
+	if !ok {
+		return
+	}
+	if index, ok := indexMap[to]; ok {
+		indexMap[from] = index
+	}
+}
+
 // hasComputedSubKeys walks through a schema and returns whether or not the
 // given key contains any subkeys that are computed.
 func (r *ConfigFieldReader) hasComputedSubKeys(key string, schema *Schema) bool {
 	prefix := key + "."
+	if schema.Type == TypeSingleNestedBlock {
+		prefix = key + ".0."
+	}
 
 	switch t := schema.Elem.(type) {
 	case *Resource:
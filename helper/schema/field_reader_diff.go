@@ -47,6 +47,8 @@ func (r *DiffFieldReader) ReadField(address []string) (FieldReadResult, error) {
 		return r.readMap(address, schema)
 	case TypeSet:
 		return r.readSet(address, schema)
+	case TypeSingleNestedBlock:
+		return readObjectField(r, address, schema.Elem.(*Resource).Schema)
 	case typeObject:
 		return readObjectField(r, address, schema.Elem.(map[string]*Schema))
 	default:
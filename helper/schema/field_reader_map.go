@@ -29,6 +29,8 @@ func (r *MapFieldReader) ReadField(address []string) (FieldReadResult, error) {
 		return r.readMap(k)
 	case TypeSet:
 		return r.readSet(address, schema)
+	case TypeSingleNestedBlock:
+		return readObjectField(r, address, schema.Elem.(*Resource).Schema)
 	case typeObject:
 		return readObjectField(r, address, schema.Elem.(map[string]*Schema))
 	default:
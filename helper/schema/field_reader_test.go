@@ -168,6 +168,51 @@ func TestAddrToSchema(t *testing.T) {
 			},
 			[]ValueType{TypeSet, typeObject, TypeInt},
 		},
+
+		"object": {
+			[]string{"object"},
+			map[string]*Schema{
+				"object": &Schema{
+					Type: TypeSingleNestedBlock,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"value": &Schema{Type: TypeString},
+						},
+					},
+				},
+			},
+			[]ValueType{TypeSingleNestedBlock},
+		},
+
+		"object.value": {
+			[]string{"object", "value"},
+			map[string]*Schema{
+				"object": &Schema{
+					Type: TypeSingleNestedBlock,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"value": &Schema{Type: TypeString},
+						},
+					},
+				},
+			},
+			[]ValueType{TypeSingleNestedBlock, TypeString},
+		},
+
+		"object.0.value": {
+			[]string{"object", "0", "value"},
+			map[string]*Schema{
+				"object": &Schema{
+					Type: TypeSingleNestedBlock,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"value": &Schema{Type: TypeString},
+						},
+					},
+				},
+			},
+			[]ValueType{},
+		},
 	}
 
 	for name, tc := range cases {
@@ -82,6 +82,8 @@ func (w *MapFieldWriter) set(addr []string, value interface{}) error {
 		return w.setMap(addr, value, schema)
 	case TypeSet:
 		return w.setSet(addr, value, schema)
+	case TypeSingleNestedBlock:
+		return w.setNestedObject(addr, value, schema)
 	case typeObject:
 		return w.setObject(addr, value, schema)
 	default:
@@ -200,6 +202,36 @@ func (w *MapFieldWriter) setObject(
 	return err
 }
 
+// setNestedObject sets all the fields of a nested object. The fields
+// missing from the value, or all of them if the value is nil, are removed.
+func (w *MapFieldWriter) setNestedObject(
+	addr []string,
+	value interface{},
+	schema *Schema) error {
+	var v map[string]interface{}
+	if err := mapstructure.Decode(value, &v); err != nil {
+		return fmt.Errorf("%s: %s", strings.Join(addr, "."), err)
+	}
+
+	addrCopy := make([]string, len(addr), len(addr)+1)
+	copy(addrCopy, addr)
+
+	var err error
+	fields := schema.Elem.(*Resource).Schema
+	for k1, _ := range fields {
+		if err = w.set(append(addrCopy, k1), v[k1]); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		for k1, _ := range fields {
+			w.set(append(addrCopy, k1), nil)
+		}
+	}
+
+	return err
+}
+
 func (w *MapFieldWriter) setPrimitive(
 	addr []string,
 	v interface{},
@@ -48,8 +48,19 @@ type Provider struct {
 	ConfigureFunc ConfigureFunc
 
 	meta interface{}
+
+	// sem limits the number of concurrent operations, if the provider is
+	// configured with MaxConcurrentOperationsKey.
+	sem terraform.Semaphore
 }
 
+// MaxConcurrentOperationsKey is the name of the provider configuration
+// field that limits how many Apply and Refresh operations the provider runs
+// at once, on top of the parallelism of the graph walk. Providers opt in by
+// adding a TypeInt field with this name to their Schema. Zero, the default,
+// means no limit.
+const MaxConcurrentOperationsKey = "max_concurrent_operations"
+
 // ConfigureFunc is the function used to configure a Provider.
 //
 // The interface{} value returned by this function is stored and passed into
@@ -74,6 +85,10 @@ func (p *Provider) InternalValidate() error {
 		return err
 	}
 
+	if s, ok := p.Schema[MaxConcurrentOperationsKey]; ok && s.Type != TypeInt {
+		return fmt.Errorf("%s must be a TypeInt", MaxConcurrentOperationsKey)
+	}
+
 	for k, r := range p.ResourcesMap {
 		if err := r.InternalValidate(nil, true); err != nil {
 			return fmt.Errorf("resource %s: %s", k, err)
@@ -135,8 +150,10 @@ func (p *Provider) ValidateResource(
 
 // Configure implementation of terraform.ResourceProvider interface.
 func (p *Provider) Configure(c *terraform.ResourceConfig) error {
+	_, limited := p.Schema[MaxConcurrentOperationsKey]
+
 	// No configuration
-	if p.ConfigureFunc == nil {
+	if p.ConfigureFunc == nil && !limited {
 		return nil
 	}
 
@@ -144,7 +161,7 @@ func (p *Provider) Configure(c *terraform.ResourceConfig) error {
 
 	// Get a ResourceData for this configuration. To do this, we actually
 	// generate an intermediary "diff" although that is never exposed.
-	diff, err := sm.Diff(nil, c)
+	diff, err := sm.Diff(nil, c, nil)
 	if err != nil {
 		return err
 	}
@@ -154,6 +171,17 @@ func (p *Provider) Configure(c *terraform.ResourceConfig) error {
 		return err
 	}
 
+	p.sem = nil
+	if limited {
+		if n := data.Get(MaxConcurrentOperationsKey).(int); n > 0 {
+			p.sem = terraform.NewSemaphore(n)
+		}
+	}
+
+	if p.ConfigureFunc == nil {
+		return nil
+	}
+
 	meta, err := p.ConfigureFunc(data)
 	if err != nil {
 		return err
@@ -173,6 +201,9 @@ func (p *Provider) Apply(
 		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
 	}
 
+	p.acquire()
+	defer p.release()
+
 	return r.Apply(s, d, p.meta)
 }
 
@@ -198,9 +229,27 @@ func (p *Provider) Refresh(
 		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
 	}
 
+	p.acquire()
+	defer p.release()
+
 	return r.Refresh(s, p.meta)
 }
 
+// acquire blocks until the provider can start another operation, if its
+// concurrent operations are limited.
+func (p *Provider) acquire() {
+	if p.sem != nil {
+		p.sem.Acquire()
+	}
+}
+
+// release ends an operation started with acquire.
+func (p *Provider) release() {
+	if p.sem != nil {
+		p.sem.Release()
+	}
+}
+
 // Resources implementation of terraform.ResourceProvider interface.
 func (p *Provider) Resources() []terraform.ResourceType {
 	keys := make([]string, 0, len(p.ResourcesMap))
@@ -312,10 +361,31 @@ func (p *Provider) ReadDataApply(
 		return nil, fmt.Errorf("unknown data source: %s", info.Type)
 	}
 
+	p.acquire()
+	defer p.release()
+
 	return r.ReadDataApply(d, p.meta)
 }
 
 // DataSources implementation of terraform.ResourceProvider interface.
+// GetSchema implementation of terraform.ResourceProvider interface.
+func (p *Provider) GetSchema() (*terraform.ProviderSchema, error) {
+	result := &terraform.ProviderSchema{
+		Provider:    schemaMap(p.Schema).coreSchema(),
+		Resources:   make(map[string]*terraform.ResourceSchema, len(p.ResourcesMap)),
+		DataSources: make(map[string]*terraform.ResourceSchema, len(p.DataSourcesMap)),
+	}
+
+	for k, r := range p.ResourcesMap {
+		result.Resources[k] = schemaMap(r.Schema).coreSchema()
+	}
+	for k, r := range p.DataSourcesMap {
+		result.DataSources[k] = schemaMap(r.Schema).coreSchema()
+	}
+
+	return result, nil
+}
+
 func (p *Provider) DataSources() []terraform.DataSource {
 	keys := make([]string, 0, len(p.DataSourcesMap))
 	for k, _ := range p.DataSourcesMap {
@@ -3,7 +3,9 @@ package schema
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/terraform"
@@ -85,6 +87,204 @@ func TestProviderConfigure(t *testing.T) {
 	}
 }
 
+func TestProviderApply_maxConcurrentOperations(t *testing.T) {
+	var lock sync.Mutex
+	var running, maxRunning int
+
+	p := &Provider{
+		Schema: map[string]*Schema{
+			MaxConcurrentOperationsKey: &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+
+		ResourcesMap: map[string]*Resource{
+			"foo": &Resource{
+				Create: func(d *ResourceData, m interface{}) error {
+					lock.Lock()
+					running++
+					if running > maxRunning {
+						maxRunning = running
+					}
+					lock.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					lock.Lock()
+					running--
+					lock.Unlock()
+
+					d.SetId("foo")
+					return nil
+				},
+			},
+		},
+	}
+
+	c, err := config.NewRawConfig(map[string]interface{}{
+		MaxConcurrentOperationsKey: 2,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := p.Configure(terraform.NewResourceConfig(c)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			info := &terraform.InstanceInfo{Type: "foo"}
+			d := &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{},
+			}
+			if _, err := p.Apply(info, nil, d); err != nil {
+				t.Errorf("err: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning != 2 {
+		t.Fatalf("expected at most 2 concurrent operations, got %d", maxRunning)
+	}
+}
+
+func TestProviderInternalValidate_maxConcurrentOperations(t *testing.T) {
+	p := &Provider{
+		Schema: map[string]*Schema{
+			MaxConcurrentOperationsKey: &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+	}
+
+	if err := p.InternalValidate(); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestProviderGetSchema(t *testing.T) {
+	p := &Provider{
+		Schema: map[string]*Schema{
+			"region": &Schema{
+				Type:        TypeString,
+				Required:    true,
+				Description: "The region",
+			},
+		},
+		ResourcesMap: map[string]*Resource{
+			"foo": &Resource{
+				Schema: map[string]*Schema{
+					"name": &Schema{
+						Type:     TypeString,
+						Required: true,
+						ForceNew: true,
+					},
+					"tags": &Schema{
+						Type:     TypeMap,
+						Optional: true,
+					},
+					"rule": &Schema{
+						Type:     TypeSet,
+						Optional: true,
+						Computed: true,
+						MaxItems: 2,
+						Elem: &Resource{
+							Schema: map[string]*Schema{
+								"port": &Schema{
+									Type:     TypeInt,
+									Required: true,
+								},
+							},
+						},
+						Set: func(interface{}) int { return 0 },
+					},
+				},
+			},
+		},
+		DataSourcesMap: map[string]*Resource{
+			"bar": &Resource{
+				Schema: map[string]*Schema{
+					"ids": &Schema{
+						Type:     TypeList,
+						Computed: true,
+						Elem:     &Schema{Type: TypeString},
+					},
+				},
+			},
+		},
+	}
+
+	actual, err := p.GetSchema()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := &terraform.ProviderSchema{
+		Provider: &terraform.ResourceSchema{
+			Attributes: map[string]*terraform.AttributeSchema{
+				"region": &terraform.AttributeSchema{
+					Type:        "string",
+					Required:    true,
+					Description: "The region",
+				},
+			},
+		},
+		Resources: map[string]*terraform.ResourceSchema{
+			"foo": &terraform.ResourceSchema{
+				Attributes: map[string]*terraform.AttributeSchema{
+					"name": &terraform.AttributeSchema{
+						Type:     "string",
+						Required: true,
+						ForceNew: true,
+					},
+					"tags": &terraform.AttributeSchema{
+						Type:     "map",
+						Optional: true,
+					},
+					"rule": &terraform.AttributeSchema{
+						Type:     "set",
+						Optional: true,
+						Computed: true,
+						MaxItems: 2,
+						Block: &terraform.ResourceSchema{
+							Attributes: map[string]*terraform.AttributeSchema{
+								"port": &terraform.AttributeSchema{
+									Type:     "int",
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSources: map[string]*terraform.ResourceSchema{
+			"bar": &terraform.ResourceSchema{
+				Attributes: map[string]*terraform.AttributeSchema{
+					"ids": &terraform.AttributeSchema{
+						Type:     "list",
+						Computed: true,
+						Elem: &terraform.AttributeSchema{
+							Type: "string",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestProviderResources(t *testing.T) {
 	cases := []struct {
 		P      *Provider
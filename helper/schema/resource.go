@@ -3,7 +3,9 @@ package schema
 import (
 	"errors"
 	"fmt"
+	"log"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -72,6 +74,12 @@ type Resource struct {
 	// returned. If a resource was partially updated, be careful to enable
 	// partial state mode for ResourceData and use it accordingly.
 	//
+	// Create should call SetId as soon as the remote object exists, before
+	// waiting for it to become available. If Create then returns an error,
+	// the ID is still recorded in the state and the resource is marked as
+	// tainted, so that it is destroyed and created again on the next apply
+	// rather than being left behind.
+	//
 	// Exists is a function that is called to check if a resource still
 	// exists. If this returns false, then this will affect the diff
 	// accordingly. If this function isn't set, it will not be called. It
@@ -90,12 +98,39 @@ type Resource struct {
 	// by InternalValidate on Resource.
 	Importer *ResourceImporter
 
+	// CustomizeDiff is an optional function that is called with the diff
+	// of this resource after it has been computed from the schema. This
+	// can be used to require a new resource based on a combination of
+	// attributes, which ForceNew alone can't express, to set the planned
+	// values of Computed attributes derived from the others, or to reject
+	// invalid combinations of attributes at plan time rather than when
+	// applying. If it returns an error, planning the resource fails with
+	// that error.
+	CustomizeDiff CustomizeDiffFunc
+
+	// Timeouts are the default timeouts of the operations of this resource.
+	// Practitioners can override the operations that have a timeout set
+	// here with a "timeouts" block in the resource configuration, and the
+	// CRUD functions read the effective value with ResourceData.Timeout.
+	Timeouts *ResourceTimeout
+
 	// If non-empty, this string is emitted as a warning during Validate.
-	// This is a private interface for now, for use by DataSourceResourceShim,
-	// and not for general use. (But maybe later...)
+	// This is a private interface for now, for use by DataSourceResourceShim
+	// and DeprecatedResourceAlias, and not for general use. (But maybe
+	// later...)
 	deprecationMessage string
 }
 
+// DeprecatedResourceAlias returns a Resource for registering r under an
+// additional, deprecated, name. The alias shares the schema and operations
+// of r, so both names behave the same; only validating a configuration of
+// the alias emits the given deprecation message as a warning.
+func DeprecatedResourceAlias(r *Resource, message string) *Resource {
+	alias := *r
+	alias.deprecationMessage = message
+	return &alias
+}
+
 // See Resource documentation.
 type CreateFunc func(*ResourceData, interface{}) error
 
@@ -111,6 +146,9 @@ type DeleteFunc func(*ResourceData, interface{}) error
 // See Resource documentation.
 type ExistsFunc func(*ResourceData, interface{}) (bool, error)
 
+// See Resource documentation.
+type CustomizeDiffFunc func(*ResourceDiff) error
+
 // See Resource documentation.
 type StateMigrateFunc func(
 	int, *terraform.InstanceState, interface{}) (*terraform.InstanceState, error)
@@ -131,11 +169,23 @@ func (r *Resource) Apply(
 		s = new(terraform.InstanceState)
 	}
 
+	// The diff carries the timeouts from the configuration. Diffs that
+	// weren't built from it, such as for a destroy, fall back to the ones
+	// recorded in the state.
+	if d != nil && hasTimeoutMeta(d.Meta) {
+		data.timeouts, err = r.diffTimeouts(d)
+	} else {
+		data.timeouts, err = r.stateTimeouts(s)
+	}
+	if err != nil {
+		return s, err
+	}
+
 	if d.Destroy || d.RequiresNew() {
 		if s.ID != "" {
 			// Destroy the resource since it is created
 			if err := r.Delete(data, meta); err != nil {
-				return r.recordCurrentSchemaVersion(data.State()), err
+				return r.recordMeta(data.State()), err
 			}
 
 			// Make sure the ID is gone.
@@ -149,10 +199,12 @@ func (r *Resource) Apply(
 		}
 
 		// Reset the data to be stateless since we just destroyed
+		timeouts := data.timeouts
 		data, err = schemaMap(r.Schema).Data(nil, d)
 		if err != nil {
 			return nil, err
 		}
+		data.timeouts = timeouts
 	}
 
 	err = nil
@@ -168,7 +220,7 @@ func (r *Resource) Apply(
 		err = r.Update(data, meta)
 	}
 
-	return r.recordCurrentSchemaVersion(data.State()), err
+	return r.recordMeta(data.State()), err
 }
 
 // Diff returns a diff of this resource and is API compatible with the
@@ -176,13 +228,31 @@ func (r *Resource) Apply(
 func (r *Resource) Diff(
 	s *terraform.InstanceState,
 	c *terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
-	return schemaMap(r.Schema).Diff(s, c)
+	instanceDiff, err := schemaMap(r.Schema).Diff(s, c, r.CustomizeDiff)
+	if err != nil || instanceDiff == nil || r.Timeouts == nil {
+		return instanceDiff, err
+	}
+
+	var t ResourceTimeout
+	if err := t.ConfigDecode(r, c); err != nil {
+		return nil, err
+	}
+	t.DiffEncode(instanceDiff)
+
+	return instanceDiff, nil
 }
 
 // Validate validates the resource configuration against the schema.
 func (r *Resource) Validate(c *terraform.ResourceConfig) ([]string, []error) {
 	warns, errs := schemaMap(r.Schema).Validate(c)
 
+	if _, ok := c.Config[TimeoutsConfigKey]; ok {
+		var t ResourceTimeout
+		if err := t.ConfigDecode(r, c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if r.deprecationMessage != "" {
 		warns = append(warns, r.deprecationMessage)
 	}
@@ -214,7 +284,7 @@ func (r *Resource) ReadDataApply(
 		state.ID = "-"
 	}
 
-	return r.recordCurrentSchemaVersion(state), err
+	return r.recordMeta(state), err
 }
 
 // Refresh refreshes the state of the resource.
@@ -256,13 +326,18 @@ func (r *Resource) Refresh(
 		return s, err
 	}
 
+	data.timeouts, err = r.stateTimeouts(s)
+	if err != nil {
+		return s, err
+	}
+
 	err = r.Read(data, meta)
 	state := data.State()
 	if state != nil && state.ID == "" {
 		state = nil
 	}
 
-	return r.recordCurrentSchemaVersion(state), err
+	return r.recordMeta(state), err
 }
 
 // InternalValidate should be called to validate the structure
@@ -286,6 +361,12 @@ func (r *Resource) InternalValidate(topSchemaMap schemaMap, writable bool) error
 		}
 	}
 
+	if r.Timeouts != nil {
+		if err := r.validateTimeouts(); err != nil {
+			return err
+		}
+	}
+
 	tsm := topSchemaMap
 
 	if r.isTopLevel() && writable {
@@ -349,6 +430,15 @@ func (r *Resource) Data(s *terraform.InstanceState) *ResourceData {
 		"schema_version": strconv.Itoa(r.SchemaVersion),
 	}
 
+	result.timeouts, err = r.stateTimeouts(s)
+	if err != nil {
+		// The timeouts in the state are only there to carry the configured
+		// values to later operations, so a malformed entry isn't worth
+		// failing over.
+		log.Printf("[WARN] Error reading timeouts from state, using the defaults: %s", err)
+		result.timeouts = r.defaultTimeouts()
+	}
+
 	return result
 }
 
@@ -361,6 +451,78 @@ func (r *Resource) TestResourceData() *ResourceData {
 	}
 }
 
+// diffTimeouts returns the timeouts of this resource, as recorded in the
+// given diff.
+func (r *Resource) diffTimeouts(d *terraform.InstanceDiff) (*ResourceTimeout, error) {
+	t := r.defaultTimeouts()
+	if err := t.DiffDecode(d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// stateTimeouts returns the timeouts of this resource, as recorded in the
+// given state.
+func (r *Resource) stateTimeouts(s *terraform.InstanceState) (*ResourceTimeout, error) {
+	t := r.defaultTimeouts()
+	if s == nil {
+		return t, nil
+	}
+	if err := t.StateDecode(s); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *Resource) defaultTimeouts() *ResourceTimeout {
+	var t ResourceTimeout
+	if r.Timeouts != nil {
+		t = *r.Timeouts
+	}
+	return &t
+}
+
+// supportsTimeout reports whether the given timeout can be set in the
+// configuration of this resource.
+func (r *Resource) supportsTimeout(key string) bool {
+	if r.Timeouts == nil {
+		return false
+	}
+	return r.Timeouts.get(key) != nil || (r.Timeouts.Default != nil && r.hasOperation(key))
+}
+
+// hasOperation reports whether this resource implements the operation that
+// the given timeout applies to.
+func (r *Resource) hasOperation(key string) bool {
+	switch key {
+	case TimeoutCreate:
+		return r.Create != nil
+	case TimeoutRead:
+		return r.Read != nil
+	case TimeoutUpdate:
+		return r.Update != nil
+	case TimeoutDelete:
+		return r.Delete != nil
+	case TimeoutDefault:
+		return true
+	}
+	return false
+}
+
+func (r *Resource) validateTimeouts() error {
+	if _, ok := r.Schema[TimeoutsConfigKey]; ok {
+		return fmt.Errorf("%s is a reserved field name when Timeouts is set", TimeoutsConfigKey)
+	}
+
+	for _, k := range timeoutKeys {
+		if r.Timeouts.get(k) != nil && !r.hasOperation(k) {
+			return fmt.Errorf("%s timeout is set but the resource doesn't implement %s", k, k)
+		}
+	}
+
+	return nil
+}
+
 // Returns true if the resource is "top level" i.e. not a sub-resource.
 func (r *Resource) isTopLevel() bool {
 	// TODO: This is a heuristic; replace with a definitive attribute?
@@ -374,6 +536,39 @@ func (r *Resource) checkSchemaVersion(is *terraform.InstanceState) (bool, int) {
 	return stateSchemaVersion < r.SchemaVersion, stateSchemaVersion
 }
 
+// recordMeta records the schema version and the sensitive attributes of
+// the given state in its Meta.
+func (r *Resource) recordMeta(
+	state *terraform.InstanceState) *terraform.InstanceState {
+	return r.recordSensitiveAttributes(r.recordCurrentSchemaVersion(state))
+}
+
+// recordSensitiveAttributes lists the attributes of the given state that
+// belong to a Sensitive schema in its Meta. The counts of lists, sets and
+// maps aren't sensitive, as core reads them.
+func (r *Resource) recordSensitiveAttributes(
+	state *terraform.InstanceState) *terraform.InstanceState {
+	if state == nil {
+		return nil
+	}
+
+	var keys []string
+	for k := range state.Attributes {
+		if strings.HasSuffix(k, ".#") || strings.HasSuffix(k, ".%") {
+			continue
+		}
+		for _, s := range addrToSchema(strings.Split(k, "."), r.Schema) {
+			if s.Sensitive {
+				keys = append(keys, k)
+				break
+			}
+		}
+	}
+
+	state.SetSensitiveAttributes(keys)
+	return state
+}
+
 func (r *Resource) recordCurrentSchemaVersion(
 	state *terraform.InstanceState) *terraform.InstanceState {
 	if state != nil && r.SchemaVersion > 0 {
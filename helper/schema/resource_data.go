@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -18,11 +19,12 @@ import (
 // The most relevant methods to take a look at are Get, Set, and Partial.
 type ResourceData struct {
 	// Settable (internally)
-	schema map[string]*Schema
-	config *terraform.ResourceConfig
-	state  *terraform.InstanceState
-	diff   *terraform.InstanceDiff
-	meta   map[string]string
+	schema   map[string]*Schema
+	config   *terraform.ResourceConfig
+	state    *terraform.InstanceState
+	diff     *terraform.InstanceDiff
+	meta     map[string]string
+	timeouts *ResourceTimeout
 
 	// Don't set
 	multiReader *MultiLevelFieldReader
@@ -94,6 +96,16 @@ func (d *ResourceData) GetOk(key string) (interface{}, bool) {
 	return r.Value, exists
 }
 
+// GetOkExists returns the data for a given key and whether or not the key
+// has been set to a value, including the zero value, at some point.
+//
+// This is useful for primitives such as booleans where an explicit zero
+// value has a different meaning than not setting the key at all.
+func (d *ResourceData) GetOkExists(key string) (interface{}, bool) {
+	r := d.getRaw(key, getSourceSet)
+	return r.Value, r.Exists && !r.Computed
+}
+
 func (d *ResourceData) getRaw(key string, level getSource) getResult {
 	var parts []string
 	if key != "" {
@@ -290,9 +302,38 @@ func (d *ResourceData) State() *terraform.InstanceState {
 		result.Attributes["id"] = d.Id()
 	}
 
+	if d.timeouts != nil && d.timeouts.isSet() {
+		// Copy the meta so that recording the timeouts doesn't modify the
+		// map shared with this ResourceData.
+		meta := make(map[string]string, len(d.meta))
+		for k, v := range d.meta {
+			meta[k] = v
+		}
+		result.Meta = meta
+		d.timeouts.StateEncode(&result)
+	}
+
 	return &result
 }
 
+// Timeout returns the timeout of the given operation: the one set in the
+// configuration if any, else the resource's default for that operation,
+// else its default timeout, else 20 minutes.
+func (d *ResourceData) Timeout(key string) time.Duration {
+	key = strings.ToLower(key)
+
+	if d.timeouts != nil {
+		if t := d.timeouts.get(key); t != nil {
+			return *t
+		}
+		if d.timeouts.Default != nil {
+			return *d.timeouts.Default
+		}
+	}
+
+	return defaultTimeout
+}
+
 func (d *ResourceData) init() {
 	// Initialize the field that will store our new state
 	var copyState terraform.InstanceState
@@ -732,6 +732,85 @@ func TestResourceDataGet(t *testing.T) {
 
 			Value: []interface{}{80},
 		},
+
+		// #24 Objects
+		{
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+							"delete": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"timeouts.create": "5m",
+					"timeouts.delete": "10m",
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"timeouts.delete": &terraform.ResourceAttrDiff{
+						Old: "10m",
+						New: "20m",
+					},
+				},
+			},
+
+			Key: "timeouts",
+
+			Value: map[string]interface{}{
+				"create": "5m",
+				"delete": "20m",
+			},
+		},
+
+		// #25 Object fields
+		{
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+							"delete": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"timeouts.create": "5m",
+				},
+			},
+
+			Diff: nil,
+
+			Key: "timeouts.create",
+
+			Value: "5m",
+		},
 	}
 
 	for i, tc := range cases {
@@ -1080,6 +1159,84 @@ func TestResourceDataGetOk(t *testing.T) {
 	}
 }
 
+func TestResourceDataGetOkExists(t *testing.T) {
+	cases := []struct {
+		Diff  *terraform.InstanceDiff
+		Value interface{}
+		Ok    bool
+	}{
+		// Explicitly set to the zero value
+		{
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"enabled": &terraform.ResourceAttrDiff{
+						Old: "",
+						New: "false",
+					},
+				},
+			},
+			Value: false,
+			Ok:    true,
+		},
+
+		// Set to a non-zero value
+		{
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"enabled": &terraform.ResourceAttrDiff{
+						Old: "",
+						New: "true",
+					},
+				},
+			},
+			Value: true,
+			Ok:    true,
+		},
+
+		// Computed
+		{
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"enabled": &terraform.ResourceAttrDiff{
+						Old:         "",
+						NewComputed: true,
+					},
+				},
+			},
+			Value: false,
+			Ok:    false,
+		},
+
+		// Not set
+		{
+			Diff:  nil,
+			Value: false,
+			Ok:    false,
+		},
+	}
+
+	for i, tc := range cases {
+		d, err := schemaMap(map[string]*Schema{
+			"enabled": &Schema{
+				Type:     TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+		}).Data(nil, tc.Diff)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		v, ok := d.GetOkExists("enabled")
+		if !reflect.DeepEqual(v, tc.Value) {
+			t.Fatalf("Bad: %d\n\n%#v", i, v)
+		}
+		if ok != tc.Ok {
+			t.Fatalf("%d: expected ok: %t, got: %t", i, tc.Ok, ok)
+		}
+	}
+}
+
 func TestResourceDataHasChange(t *testing.T) {
 	cases := []struct {
 		Schema map[string]*Schema
@@ -2836,6 +2993,48 @@ func TestResourceDataState(t *testing.T) {
 				},
 			},
 		},
+
+		// #28 Set objects
+		{
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+							"delete": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"timeouts.create": "5m",
+					"timeouts.delete": "10m",
+				},
+			},
+
+			Set: map[string]interface{}{
+				"timeouts": map[string]interface{}{
+					"create": "15m",
+				},
+			},
+
+			Result: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"timeouts.create": "15m",
+					"timeouts.delete": "",
+				},
+			},
+		},
 	}
 
 	for i, tc := range cases {
@@ -0,0 +1,218 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// ResourceDiff is used to query the planned changes of a resource from a
+// CustomizeDiffFunc, and to adjust them.
+//
+// Values are read the same way as from ResourceData during an apply: the
+// old value comes from the state and the new value from the diff.
+type ResourceDiff struct {
+	data *ResourceData
+	diff *terraform.InstanceDiff
+
+	// updatedKeys are the keys whose planned values were set with SetNew
+	// or SetNewComputed. They are kept when the diff is recomputed for a
+	// new resource.
+	updatedKeys map[string]bool
+}
+
+func newResourceDiff(
+	schema schemaMap,
+	config *terraform.ResourceConfig,
+	state *terraform.InstanceState,
+	diff *terraform.InstanceDiff) *ResourceDiff {
+	return &ResourceDiff{
+		data: &ResourceData{
+			schema: schema,
+			config: config,
+			state:  state,
+			diff:   diff,
+		},
+		diff:        diff,
+		updatedKeys: make(map[string]bool),
+	}
+}
+
+// Get returns the planned value for the given key. See ResourceData.Get.
+func (d *ResourceDiff) Get(key string) interface{} {
+	return d.data.Get(key)
+}
+
+// GetOk returns the planned value for the given key and whether it is set
+// to a non-zero value. See ResourceData.GetOk.
+func (d *ResourceDiff) GetOk(key string) (interface{}, bool) {
+	return d.data.GetOk(key)
+}
+
+// GetChange returns the old and the planned value for the given key.
+func (d *ResourceDiff) GetChange(key string) (interface{}, interface{}) {
+	return d.data.GetChange(key)
+}
+
+// HasChange returns whether or not the given key is planned to change.
+func (d *ResourceDiff) HasChange(key string) bool {
+	return d.data.HasChange(key)
+}
+
+// ForceNew marks the planned change of the given key, and of all of the
+// keys nested below it, as requiring a new resource. It is an error to call
+// ForceNew for a key that has no planned change.
+func (d *ResourceDiff) ForceNew(key string) error {
+	if !d.HasChange(key) {
+		return fmt.Errorf("ForceNew: No changes for %s", key)
+	}
+
+	found := false
+	for k, attr := range d.diff.Attributes {
+		if attr == nil {
+			continue
+		}
+		if k == key || strings.HasPrefix(k, key+".") {
+			attr.RequiresNew = true
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("ForceNew: No diff for %s", key)
+	}
+
+	return nil
+}
+
+// NewValueKnown returns whether the planned value for the given key is
+// known. It isn't when the value is interpolated from attributes that are
+// only known after apply, or when it is computed by the provider, in which
+// case Get returns the zero value. Checks of the value should be skipped
+// until it is known, they are made again when applying.
+func (d *ResourceDiff) NewValueKnown(key string) bool {
+	if d.data.config != nil && d.data.config.IsComputed(key) {
+		return false
+	}
+
+	for k, attr := range d.diff.Attributes {
+		if attr == nil || !attr.NewComputed {
+			continue
+		}
+		if k == key || strings.HasPrefix(k, key+".") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetNew sets the planned value of the given key, for attributes whose
+// value can be derived from the others at plan time. The key must be a
+// top-level Computed attribute of a primitive type.
+func (d *ResourceDiff) SetNew(key string, value interface{}) error {
+	schema, err := d.computedSchema(key)
+	if err != nil {
+		return err
+	}
+
+	switch schema.Type {
+	case TypeBool, TypeInt, TypeFloat, TypeString:
+	default:
+		return fmt.Errorf("SetNew: %s is not of a primitive type", key)
+	}
+
+	w := &MapFieldWriter{Schema: d.data.schema}
+	if err := w.WriteField([]string{key}, value); err != nil {
+		return fmt.Errorf("SetNew: %s", err)
+	}
+	n := w.Map()[key]
+
+	d.clearDiff(key)
+	if o := d.oldAttribute(key); o != n {
+		d.diff.Attributes[key] = &terraform.ResourceAttrDiff{
+			Old:       o,
+			New:       n,
+			Sensitive: schema.Sensitive,
+		}
+	}
+	d.updatedKeys[key] = true
+
+	return nil
+}
+
+// SetNewComputed marks the planned value of the given key as only known
+// after apply, e.g. because it changes along with other attributes. The
+// key must be a top-level Computed attribute.
+func (d *ResourceDiff) SetNewComputed(key string) error {
+	schema, err := d.computedSchema(key)
+	if err != nil {
+		return err
+	}
+
+	k := key
+	switch schema.Type {
+	case TypeList, TypeSet:
+		k = key + ".#"
+	case TypeMap:
+		k = key + ".%"
+	}
+
+	d.clearDiff(key)
+	d.diff.Attributes[k] = &terraform.ResourceAttrDiff{
+		Old:         d.oldAttribute(k),
+		NewComputed: true,
+		Sensitive:   schema.Sensitive,
+	}
+	d.updatedKeys[key] = true
+
+	return nil
+}
+
+// computedSchema returns the schema of the given key, which must be a
+// top-level Computed attribute for its planned value to be set.
+func (d *ResourceDiff) computedSchema(key string) (*Schema, error) {
+	schema, ok := d.data.schema[key]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a top-level attribute", key)
+	}
+	if !schema.Computed {
+		return nil, fmt.Errorf("%s is not Computed, only Computed attributes can be set", key)
+	}
+	return schema, nil
+}
+
+// clearDiff removes the planned changes of the given key, and of all of the
+// keys nested below it.
+func (d *ResourceDiff) clearDiff(key string) {
+	for k, _ := range d.diff.Attributes {
+		if k == key || strings.HasPrefix(k, key+".") {
+			delete(d.diff.Attributes, k)
+		}
+	}
+}
+
+// oldAttribute returns the value of the given attribute in the state.
+func (d *ResourceDiff) oldAttribute(k string) string {
+	if d.data.state == nil {
+		return ""
+	}
+	return d.data.state.Attributes[k]
+}
+
+// keepUpdatedKeys copies the planned changes of the keys that were set with
+// SetNew or SetNewComputed to the given diff, replacing its own.
+func (d *ResourceDiff) keepUpdatedKeys(diff *terraform.InstanceDiff) {
+	for key, _ := range d.updatedKeys {
+		for k, _ := range diff.Attributes {
+			if k == key || strings.HasPrefix(k, key+".") {
+				delete(diff.Attributes, k)
+			}
+		}
+		for k, attr := range d.diff.Attributes {
+			if k == key || strings.HasPrefix(k, key+".") {
+				diff.Attributes[k] = attr
+			}
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hil/ast"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceDiff_customizeDiff(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+			"replace": &Schema{
+				Type:     TypeBool,
+				Optional: true,
+			},
+			"computed": &Schema{
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: func(d *ResourceDiff) error {
+			if d.Get("foo").(string) == "invalid" {
+				return fmt.Errorf("foo is invalid")
+			}
+			if d.HasChange("foo") && d.Get("replace").(bool) {
+				return d.ForceNew("foo")
+			}
+			return nil
+		},
+	}
+
+	state := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"id":       "bar",
+			"foo":      "old",
+			"computed": "value",
+		},
+	}
+
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		RequiresNew bool
+		Err         bool
+	}{
+		"in place": {
+			Config: map[string]interface{}{
+				"foo": "new",
+			},
+		},
+
+		"replace": {
+			Config: map[string]interface{}{
+				"foo":     "new",
+				"replace": true,
+			},
+			RequiresNew: true,
+		},
+
+		"error": {
+			Config: map[string]interface{}{
+				"foo": "invalid",
+			},
+			Err: true,
+		},
+	}
+
+	for name, tc := range cases {
+		c, err := config.NewRawConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+
+		diff, err := r.Diff(state, terraform.NewResourceConfig(c))
+		if err != nil != tc.Err {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+		if tc.Err {
+			continue
+		}
+
+		if diff.RequiresNew() != tc.RequiresNew {
+			t.Fatalf("%s: expected RequiresNew %t, got diff: %#v", name, tc.RequiresNew, diff)
+		}
+		if diff.Attributes["foo"].RequiresNew != tc.RequiresNew {
+			t.Fatalf("%s: bad foo diff: %#v", name, diff.Attributes["foo"])
+		}
+
+		// A replacement has to recompute the computed attributes.
+		if tc.RequiresNew && !diff.Attributes["computed"].NewComputed {
+			t.Fatalf("%s: expected computed to be recomputed: %#v", name, diff.Attributes["computed"])
+		}
+	}
+}
+
+func TestResourceDiff_ForceNewNoChange(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+			"bar": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+		CustomizeDiff: func(d *ResourceDiff) error {
+			return d.ForceNew("bar")
+		},
+	}
+
+	state := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"id":  "bar",
+			"foo": "old",
+		},
+	}
+
+	c, err := config.NewRawConfig(map[string]interface{}{
+		"foo": "new",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := r.Diff(state, terraform.NewResourceConfig(c)); err == nil {
+		t.Fatal("expected ForceNew of an unchanged key to fail")
+	}
+}
+
+func TestResourceDiff_SetNew(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+			"replace": &Schema{
+				Type:     TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"upper": &Schema{
+				Type:     TypeString,
+				Computed: true,
+			},
+			"length": &Schema{
+				Type:     TypeInt,
+				Computed: true,
+			},
+			"optional": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+		CustomizeDiff: func(d *ResourceDiff) error {
+			foo := d.Get("foo").(string)
+			if err := d.SetNew("upper", strings.ToUpper(foo)); err != nil {
+				return err
+			}
+			return d.SetNew("length", len(foo))
+		},
+	}
+
+	state := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"id":      "bar",
+			"foo":     "old",
+			"replace": "a",
+			"upper":   "OLD",
+			"length":  "3",
+		},
+	}
+
+	cases := map[string]struct {
+		Config map[string]interface{}
+		Upper  *terraform.ResourceAttrDiff
+		Length *terraform.ResourceAttrDiff
+	}{
+		"unchanged": {
+			Config: map[string]interface{}{
+				"foo":     "old",
+				"replace": "a",
+			},
+		},
+
+		"in place": {
+			Config: map[string]interface{}{
+				"foo":     "newer",
+				"replace": "a",
+			},
+			Upper:  &terraform.ResourceAttrDiff{Old: "OLD", New: "NEWER"},
+			Length: &terraform.ResourceAttrDiff{Old: "3", New: "5"},
+		},
+
+		"replace": {
+			Config: map[string]interface{}{
+				"foo":     "new",
+				"replace": "b",
+			},
+			Upper: &terraform.ResourceAttrDiff{Old: "OLD", New: "NEW"},
+		},
+	}
+
+	for name, tc := range cases {
+		c, err := config.NewRawConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+
+		diff, err := r.Diff(state, terraform.NewResourceConfig(c))
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+
+		var upper, length *terraform.ResourceAttrDiff
+		if diff != nil {
+			upper = diff.Attributes["upper"]
+			length = diff.Attributes["length"]
+		}
+		if !reflect.DeepEqual(upper, tc.Upper) {
+			t.Fatalf("%s: bad upper diff: %#v", name, upper)
+		}
+		if !reflect.DeepEqual(length, tc.Length) {
+			t.Fatalf("%s: bad length diff: %#v", name, length)
+		}
+	}
+
+	// Only computed attributes can be set.
+	rd := newResourceDiff(schemaMap(r.Schema), nil, state, &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{},
+	})
+	if err := rd.SetNew("optional", "value"); err == nil {
+		t.Fatal("expected SetNew of an attribute that isn't computed to fail")
+	}
+	if err := rd.SetNew("unknown", "value"); err == nil {
+		t.Fatal("expected SetNew of an unknown attribute to fail")
+	}
+}
+
+func TestResourceDiff_SetNewComputed(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+			"version": &Schema{
+				Type:     TypeString,
+				Computed: true,
+			},
+			"addresses": &Schema{
+				Type:     TypeList,
+				Computed: true,
+				Elem:     &Schema{Type: TypeString},
+			},
+		},
+		CustomizeDiff: func(d *ResourceDiff) error {
+			if !d.HasChange("foo") {
+				return nil
+			}
+			if err := d.SetNewComputed("version"); err != nil {
+				return err
+			}
+			return d.SetNewComputed("addresses")
+		},
+	}
+
+	state := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"id":          "bar",
+			"foo":         "old",
+			"version":     "1",
+			"addresses.#": "1",
+			"addresses.0": "10.0.0.1",
+		},
+	}
+
+	c, err := config.NewRawConfig(map[string]interface{}{
+		"foo": "new",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	diff, err := r.Diff(state, terraform.NewResourceConfig(c))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]*terraform.ResourceAttrDiff{
+		"foo":         &terraform.ResourceAttrDiff{Old: "old", New: "new"},
+		"version":     &terraform.ResourceAttrDiff{Old: "1", NewComputed: true},
+		"addresses.#": &terraform.ResourceAttrDiff{Old: "1", NewComputed: true},
+	}
+	if !reflect.DeepEqual(diff.Attributes, expected) {
+		t.Fatalf("bad: %#v", diff.Attributes)
+	}
+}
+
+func TestResourceDiff_NewValueKnown(t *testing.T) {
+	var known map[string]bool
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+			"bar": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+			"computed": &Schema{
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: func(d *ResourceDiff) error {
+			for k, _ := range known {
+				known[k] = d.NewValueKnown(k)
+			}
+			return nil
+		},
+	}
+
+	c, err := config.NewRawConfig(map[string]interface{}{
+		"foo": "value",
+		"bar": "${var.bar}",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	err = c.Interpolate(map[string]ast.Variable{
+		"var.bar": interfaceToVariableSwallowError(config.UnknownVariableValue),
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	known = map[string]bool{"foo": false, "bar": true, "computed": true}
+	if _, err := r.Diff(nil, terraform.NewResourceConfig(c)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]bool{"foo": true, "bar": false, "computed": false}
+	if !reflect.DeepEqual(known, expected) {
+		t.Fatalf("bad: %#v", known)
+	}
+}
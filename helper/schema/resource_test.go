@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -62,6 +63,149 @@ func TestResourceApply_create(t *testing.T) {
 	}
 }
 
+func TestResourceApply_createSensitive(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"name": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+			"password": &Schema{
+				Type:      TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"keys": &Schema{
+				Type:      TypeList,
+				Optional:  true,
+				Sensitive: true,
+				Elem:      &Schema{Type: TypeString},
+			},
+		},
+	}
+
+	r.Create = func(d *ResourceData, m interface{}) error {
+		d.SetId("foo")
+		return nil
+	}
+
+	d := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"name":     &terraform.ResourceAttrDiff{New: "foo"},
+			"password": &terraform.ResourceAttrDiff{New: "hunter2"},
+			"keys.#":   &terraform.ResourceAttrDiff{New: "1"},
+			"keys.0":   &terraform.ResourceAttrDiff{New: "secret"},
+		},
+	}
+
+	actual, err := r.Apply(nil, d, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The counts of lists aren't sensitive
+	expected := []string{"keys.0", "password"}
+	if keys := actual.SensitiveAttributes(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("bad: %#v", keys)
+	}
+
+	if actual.Attributes["password"] != "hunter2" {
+		t.Fatalf("bad: %#v", actual.Attributes)
+	}
+}
+
+func TestResourceApply_createError(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	r.Create = func(d *ResourceData, m interface{}) error {
+		d.SetId("foo")
+		return fmt.Errorf("timeout while waiting for state to become 'available'")
+	}
+
+	d := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"foo": &terraform.ResourceAttrDiff{
+				New: "42",
+			},
+		},
+	}
+
+	actual, err := r.Apply(nil, d, nil)
+	if err == nil {
+		t.Fatal("should error")
+	}
+
+	// The ID must be kept so that the resource isn't lost
+	expected := &terraform.InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"id":  "foo",
+			"foo": "42",
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected:\n%#v\n\ngot:\n%#v", expected, actual)
+	}
+}
+
+func TestResourceApply_createPartial(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+			"bar": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	r.Create = func(d *ResourceData, m interface{}) error {
+		d.Partial(true)
+		d.SetId("foo")
+		d.SetPartial("foo")
+		return fmt.Errorf("error setting bar")
+	}
+
+	d := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"foo": &terraform.ResourceAttrDiff{
+				New: "42",
+			},
+			"bar": &terraform.ResourceAttrDiff{
+				New: "12",
+			},
+		},
+	}
+
+	actual, err := r.Apply(nil, d, nil)
+	if err == nil {
+		t.Fatal("should error")
+	}
+
+	expected := &terraform.InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"id":  "foo",
+			"foo": "42",
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected:\n%#v\n\ngot:\n%#v", expected, actual)
+	}
+}
+
 func TestResourceApply_destroy(t *testing.T) {
 	r := &Resource{
 		Schema: map[string]*Schema{
@@ -881,6 +1025,36 @@ func TestResourceRefresh_migrateStateErr(t *testing.T) {
 	}
 }
 
+func TestDeprecatedResourceAlias(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	alias := DeprecatedResourceAlias(r, "use foo instead")
+	if !reflect.DeepEqual(alias.Schema, r.Schema) {
+		t.Fatalf("bad: %#v", alias.Schema)
+	}
+
+	c := terraform.NewResourceConfig(nil)
+	ws, es := alias.Validate(c)
+	if len(es) > 0 {
+		t.Fatalf("err: %#v", es)
+	}
+	if !reflect.DeepEqual(ws, []string{"use foo instead"}) {
+		t.Fatalf("bad: %#v", ws)
+	}
+
+	ws, _ = r.Validate(c)
+	if len(ws) > 0 {
+		t.Fatalf("bad: %#v", ws)
+	}
+}
+
 func TestResourceData(t *testing.T) {
 	r := &Resource{
 		SchemaVersion: 2,
@@ -919,6 +1093,32 @@ func TestResourceData(t *testing.T) {
 	}
 }
 
+func TestResourceData_badTimeouts(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+		Timeouts: &ResourceTimeout{
+			Create: DefaultTimeout(5 * time.Minute),
+		},
+	}
+
+	state := &terraform.InstanceState{
+		ID: "foo",
+		Meta: map[string]string{
+			timeoutMetaPrefix + TimeoutCreate: "bad",
+		},
+	}
+
+	data := r.Data(state)
+	if v := data.Timeout(TimeoutCreate); v != 5*time.Minute {
+		t.Fatalf("bad: %s", v)
+	}
+}
+
 func TestResourceData_blank(t *testing.T) {
 	r := &Resource{
 		SchemaVersion: 2,
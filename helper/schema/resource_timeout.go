@@ -0,0 +1,235 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const (
+	TimeoutCreate  = "create"
+	TimeoutRead    = "read"
+	TimeoutUpdate  = "update"
+	TimeoutDelete  = "delete"
+	TimeoutDefault = "default"
+)
+
+// TimeoutsConfigKey is the reserved configuration key that practitioners use
+// to override the timeouts of a resource:
+//
+//	timeouts {
+//	  create = "60m"
+//	  delete = "2h"
+//	}
+const TimeoutsConfigKey = "timeouts"
+
+// timeoutMetaPrefix prefixes the keys under which configured timeouts are
+// carried in the Meta of diffs and states, e.g. "timeouts.create".
+const timeoutMetaPrefix = TimeoutsConfigKey + "."
+
+// timeoutKeys are the operations, in order, that a timeout can be set for.
+var timeoutKeys = []string{
+	TimeoutCreate,
+	TimeoutRead,
+	TimeoutUpdate,
+	TimeoutDelete,
+	TimeoutDefault,
+}
+
+// defaultTimeout is the timeout used for an operation when neither the
+// resource nor the configuration specify one.
+const defaultTimeout = 20 * time.Minute
+
+// ResourceTimeout holds the timeouts of the operations of a resource. A nil
+// value means that no timeout was set for that operation.
+//
+// When set on a Resource, the values are the defaults used when the
+// configuration doesn't override them. Only the operations that have a
+// timeout set here can be overridden in the configuration.
+type ResourceTimeout struct {
+	Create, Read, Update, Delete, Default *time.Duration
+}
+
+// DefaultTimeout is a helper for building a ResourceTimeout. It accepts a
+// time.Duration, or a number of nanoseconds, and returns a pointer to it.
+func DefaultTimeout(tx interface{}) *time.Duration {
+	var td time.Duration
+	switch raw := tx.(type) {
+	case time.Duration:
+		return &raw
+	case int64:
+		td = time.Duration(raw)
+	case float64:
+		td = time.Duration(int64(raw))
+	default:
+		panic(fmt.Sprintf("unknown type for DefaultTimeout: %#v", tx))
+	}
+	return &td
+}
+
+func (t *ResourceTimeout) get(key string) *time.Duration {
+	switch key {
+	case TimeoutCreate:
+		return t.Create
+	case TimeoutRead:
+		return t.Read
+	case TimeoutUpdate:
+		return t.Update
+	case TimeoutDelete:
+		return t.Delete
+	case TimeoutDefault:
+		return t.Default
+	}
+	return nil
+}
+
+func (t *ResourceTimeout) set(key string, v *time.Duration) {
+	switch key {
+	case TimeoutCreate:
+		t.Create = v
+	case TimeoutRead:
+		t.Read = v
+	case TimeoutUpdate:
+		t.Update = v
+	case TimeoutDelete:
+		t.Delete = v
+	case TimeoutDefault:
+		t.Default = v
+	}
+}
+
+// ConfigDecode starts from the defaults declared on the Resource and
+// applies any timeouts overridden in the "timeouts" block of the given
+// configuration. It returns an error if the block sets a timeout the
+// resource doesn't support, or a value that isn't a valid duration.
+func (t *ResourceTimeout) ConfigDecode(r *Resource, c *terraform.ResourceConfig) error {
+	if r.Timeouts != nil {
+		*t = *r.Timeouts
+	}
+
+	if c == nil {
+		return nil
+	}
+
+	raw, ok := c.Config[TimeoutsConfigKey]
+	if !ok {
+		return nil
+	}
+
+	var blocks []map[string]interface{}
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		blocks = append(blocks, v)
+	case []map[string]interface{}:
+		blocks = v
+	case []interface{}:
+		for _, b := range v {
+			m, ok := b.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: expected object, got %T", TimeoutsConfigKey, b)
+			}
+			blocks = append(blocks, m)
+		}
+	default:
+		return fmt.Errorf("%s: expected object, got %T", TimeoutsConfigKey, raw)
+	}
+
+	for _, block := range blocks {
+		for k, v := range block {
+			key := strings.ToLower(k)
+			if !r.supportsTimeout(key) {
+				return fmt.Errorf("%s: timeout %q is not supported by this resource", TimeoutsConfigKey, k)
+			}
+
+			s, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("%s.%s: expected a duration string, got %T", TimeoutsConfigKey, k, v)
+			}
+
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return fmt.Errorf("%s.%s: %s", TimeoutsConfigKey, k, err)
+			}
+
+			t.set(key, &d)
+		}
+	}
+
+	return nil
+}
+
+// DiffEncode records the timeouts in the Meta of the diff, so that they
+// are available to the resource when the diff is applied.
+func (t *ResourceTimeout) DiffEncode(id *terraform.InstanceDiff) {
+	if id.Meta == nil {
+		id.Meta = make(map[string]string)
+	}
+	t.metaEncode(id.Meta)
+}
+
+// DiffDecode reads the timeouts recorded by DiffEncode.
+func (t *ResourceTimeout) DiffDecode(id *terraform.InstanceDiff) error {
+	return t.metaDecode(id.Meta)
+}
+
+// StateEncode records the timeouts in the Meta of the state, so that they
+// are available to later refreshes and to destroys, which don't get a diff
+// built from the configuration.
+func (t *ResourceTimeout) StateEncode(is *terraform.InstanceState) {
+	if is.Meta == nil {
+		is.Meta = make(map[string]string)
+	}
+	t.metaEncode(is.Meta)
+}
+
+// StateDecode reads the timeouts recorded by StateEncode.
+func (t *ResourceTimeout) StateDecode(is *terraform.InstanceState) error {
+	return t.metaDecode(is.Meta)
+}
+
+func (t *ResourceTimeout) metaEncode(meta map[string]string) {
+	for _, k := range timeoutKeys {
+		if v := t.get(k); v != nil {
+			meta[timeoutMetaPrefix+k] = v.String()
+		}
+	}
+}
+
+func (t *ResourceTimeout) metaDecode(meta map[string]string) error {
+	for _, k := range timeoutKeys {
+		raw, ok := meta[timeoutMetaPrefix+k]
+		if !ok {
+			continue
+		}
+
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("Error decoding %s timeout: %s", k, err)
+		}
+		t.set(k, &d)
+	}
+
+	return nil
+}
+
+// isSet reports whether any timeout is set.
+func (t *ResourceTimeout) isSet() bool {
+	for _, k := range timeoutKeys {
+		if t.get(k) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTimeoutMeta reports whether any timeouts were encoded in meta.
+func hasTimeoutMeta(meta map[string]string) bool {
+	for k := range meta {
+		if strings.HasPrefix(k, timeoutMetaPrefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testTimeoutResource() *Resource {
+	return &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+		Create: func(d *ResourceData, m interface{}) error { return nil },
+		Read:   func(d *ResourceData, m interface{}) error { return nil },
+		Update: func(d *ResourceData, m interface{}) error { return nil },
+		Delete: func(d *ResourceData, m interface{}) error { return nil },
+		Timeouts: &ResourceTimeout{
+			Create: DefaultTimeout(10 * time.Minute),
+			Delete: DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func TestResourceTimeout_ConfigDecode(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   map[string]interface{}
+		Expected *ResourceTimeout
+		Err      bool
+	}{
+		{
+			Name:   "no timeouts block",
+			Config: map[string]interface{}{},
+			Expected: &ResourceTimeout{
+				Create: DefaultTimeout(10 * time.Minute),
+				Delete: DefaultTimeout(5 * time.Minute),
+			},
+		},
+		{
+			Name: "override",
+			Config: map[string]interface{}{
+				"timeouts": []map[string]interface{}{
+					map[string]interface{}{
+						"create": "1h",
+					},
+				},
+			},
+			Expected: &ResourceTimeout{
+				Create: DefaultTimeout(1 * time.Hour),
+				Delete: DefaultTimeout(5 * time.Minute),
+			},
+		},
+		{
+			Name: "unsupported timeout",
+			Config: map[string]interface{}{
+				"timeouts": []map[string]interface{}{
+					map[string]interface{}{
+						"update": "1h",
+					},
+				},
+			},
+			Err: true,
+		},
+		{
+			Name: "invalid duration",
+			Config: map[string]interface{}{
+				"timeouts": []map[string]interface{}{
+					map[string]interface{}{
+						"delete": "forever",
+					},
+				},
+			},
+			Err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		var actual ResourceTimeout
+		err := actual.ConfigDecode(testTimeoutResource(), testConfig(t, tc.Config))
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+		if tc.Err {
+			continue
+		}
+		if !reflect.DeepEqual(&actual, tc.Expected) {
+			t.Fatalf("%s: bad: %#v", tc.Name, actual)
+		}
+	}
+}
+
+func TestResourceTimeout_Validate(t *testing.T) {
+	r := testTimeoutResource()
+
+	c := testConfig(t, map[string]interface{}{
+		"foo": 42,
+		"timeouts": []map[string]interface{}{
+			map[string]interface{}{
+				"create": "1h",
+			},
+		},
+	})
+	if _, es := r.Validate(c); len(es) > 0 {
+		t.Fatalf("unexpected errors: %s", es)
+	}
+
+	r.Timeouts = nil
+	if _, es := r.Validate(c); len(es) == 0 {
+		t.Fatal("expected an error when the resource doesn't support timeouts")
+	}
+}
+
+func TestResourceTimeout_Apply(t *testing.T) {
+	r := testTimeoutResource()
+
+	var createTimeout time.Duration
+	r.Create = func(d *ResourceData, m interface{}) error {
+		createTimeout = d.Timeout(TimeoutCreate)
+		d.SetId("foo")
+		return nil
+	}
+
+	c := testConfig(t, map[string]interface{}{
+		"foo": 42,
+		"timeouts": []map[string]interface{}{
+			map[string]interface{}{
+				"create": "1h",
+			},
+		},
+	})
+
+	d, err := r.Diff(nil, c)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expectedMeta := map[string]string{
+		"timeouts.create": "1h0m0s",
+		"timeouts.delete": "5m0s",
+	}
+	if !reflect.DeepEqual(d.Meta, expectedMeta) {
+		t.Fatalf("bad diff meta: %#v", d.Meta)
+	}
+
+	s, err := r.Apply(nil, d, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if createTimeout != time.Hour {
+		t.Fatalf("bad create timeout: %s", createTimeout)
+	}
+	if !reflect.DeepEqual(s.Meta, expectedMeta) {
+		t.Fatalf("bad state meta: %#v", s.Meta)
+	}
+
+	// Destroy diffs aren't built from the configuration, so the timeouts
+	// are read from the state.
+	var deleteTimeout time.Duration
+	r.Delete = func(d *ResourceData, m interface{}) error {
+		deleteTimeout = d.Timeout(TimeoutDelete)
+		return nil
+	}
+	s.Meta["timeouts.delete"] = "30m0s"
+
+	if _, err := r.Apply(s, &terraform.InstanceDiff{Destroy: true}, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if deleteTimeout != 30*time.Minute {
+		t.Fatalf("bad delete timeout: %s", deleteTimeout)
+	}
+}
+
+func TestResourceTimeout_InternalValidate(t *testing.T) {
+	r := testTimeoutResource()
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	r.Update = nil
+	r.Schema["foo"].ForceNew = true
+	r.Timeouts.Update = DefaultTimeout(time.Minute)
+	if err := r.InternalValidate(nil, true); err == nil {
+		t.Fatal("expected an error for an update timeout without Update")
+	}
+
+	r = testTimeoutResource()
+	r.Schema["timeouts"] = &Schema{
+		Type:     TypeString,
+		Optional: true,
+	}
+	if err := r.InternalValidate(nil, true); err == nil {
+		t.Fatal("expected an error for a reserved timeouts field")
+	}
+}
+
+func TestResourceDataTimeout(t *testing.T) {
+	r := testTimeoutResource()
+	d := r.Data(nil)
+
+	cases := map[string]time.Duration{
+		TimeoutCreate: 10 * time.Minute,
+		TimeoutDelete: 5 * time.Minute,
+		TimeoutUpdate: 20 * time.Minute,
+	}
+	for k, expected := range cases {
+		if actual := d.Timeout(k); actual != expected {
+			t.Fatalf("%s: expected %s, got %s", k, expected, actual)
+		}
+	}
+
+	r.Timeouts.Default = DefaultTimeout(time.Minute)
+	d = r.Data(nil)
+	if actual := d.Timeout(TimeoutUpdate); actual != time.Minute {
+		t.Fatalf("expected the resource default timeout, got %s", actual)
+	}
+}
@@ -42,6 +42,7 @@ type Schema struct {
 	//   TypeList - []interface{}
 	//   TypeMap - map[string]interface{}
 	//   TypeSet - *schema.Set
+	//   TypeSingleNestedBlock - map[string]interface{}
 	//
 	Type ValueType
 
@@ -106,6 +107,12 @@ type Schema struct {
 	// TypeSet or TypeList. Specific use cases would be if a TypeSet is being
 	// used to wrap a complex structure, however more than one instance would
 	// cause instability.
+	//
+	// For a TypeSingleNestedBlock, Elem must be a *Resource: the value is
+	// a single nested block with those fields. Unlike a TypeList with a
+	// MaxItems of 1, its fields are addressed without an index, such as
+	// "foo.bar" instead of "foo.0.bar", in the state, in diffs and in
+	// interpolations.
 	Elem     interface{}
 	MaxItems int
 
@@ -147,8 +154,31 @@ type Schema struct {
 	//
 	// ValidateFunc currently only works for primitive types.
 	ValidateFunc SchemaValidateFunc
+
+	// DiffSuppressFunc allows a field to declare that an old and a new value
+	// are semantically equal even though they differ as strings, e.g. an
+	// address the API returns in another case, or a JSON document with its
+	// keys reordered. If it returns true, the attribute is left out of the
+	// diff, and so doesn't cause an update or force a new resource.
+	//
+	// It is called with the key of the attribute, its old and new values
+	// as they would appear in the diff, and the ResourceData being diffed.
+	// It isn't called for values that are computed or being removed.
+	DiffSuppressFunc SchemaDiffSuppressFunc
+
+	// Sensitive marks the value of the field as a secret, such as a
+	// password or a key. Its diffs are redacted from the output of the
+	// plan and apply commands, and its keys are listed in the Meta of the
+	// state so that it is encrypted when the state is persisted, if
+	// TF_STATE_ENCRYPTION_KEY is set. It applies to all the elements of
+	// lists, sets and maps.
+	Sensitive bool
 }
 
+// SchemaDiffSuppressFunc is a function used to suppress the diff of an
+// attribute whose old and new values are semantically equal.
+type SchemaDiffSuppressFunc func(k, old, new string, d *ResourceData) bool
+
 // SchemaDefaultFunc is a function called to return a default value for
 // a field.
 type SchemaDefaultFunc func() (interface{}, error)
@@ -301,10 +331,12 @@ func (m schemaMap) Data(
 }
 
 // Diff returns the diff for a resource given the schema map,
-// state, and configuration.
+// state, and configuration. If customizeDiff is non-nil, it is called
+// with the computed diff before the diff is finalized.
 func (m schemaMap) Diff(
 	s *terraform.InstanceState,
-	c *terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
+	c *terraform.ResourceConfig,
+	customizeDiff CustomizeDiffFunc) (*terraform.InstanceDiff, error) {
 	result := new(terraform.InstanceDiff)
 	result.Attributes = make(map[string]*terraform.ResourceAttrDiff)
 
@@ -321,6 +353,16 @@ func (m schemaMap) Diff(
 		}
 	}
 
+	// Give the resource a chance to adjust the diff before we decide
+	// whether it requires a new resource.
+	var rd *ResourceDiff
+	if customizeDiff != nil {
+		rd = newResourceDiff(m, c, s, result)
+		if err := customizeDiff(rd); err != nil {
+			return nil, err
+		}
+	}
+
 	// If the diff requires a new resource, then we recompute the diff
 	// so we have the complete new resource diff, and preserve the
 	// RequiresNew fields where necessary so the user knows exactly what
@@ -377,6 +419,11 @@ func (m schemaMap) Diff(
 			result2.Attributes[k] = newAttr
 		}
 
+		// The values set by the customizer are kept as well.
+		if rd != nil {
+			rd.keepUpdatedKeys(result2)
+		}
+
 		// And set the diff!
 		result = result2
 	}
@@ -569,10 +616,27 @@ func (m schemaMap) InternalValidate(topSchemaMap schemaMap) error {
 			}
 		}
 
+		if v.Type == TypeSingleNestedBlock {
+			r, ok := v.Elem.(*Resource)
+			if !ok {
+				return fmt.Errorf("%s: Elem must be a *Resource for objects", k)
+			}
+
+			if v.Default != nil {
+				return fmt.Errorf("%s: Default is not valid for objects", k)
+			}
+
+			if err := r.InternalValidate(topSchemaMap, true); err != nil {
+				return err
+			}
+		}
+
 		if v.ValidateFunc != nil {
 			switch v.Type {
 			case TypeList, TypeSet:
 				return fmt.Errorf("ValidateFunc is not yet supported on lists or sets.")
+			case TypeSingleNestedBlock:
+				return fmt.Errorf("ValidateFunc is not yet supported on objects.")
 			}
 		}
 	}
@@ -586,20 +650,43 @@ func (m schemaMap) diff(
 	diff *terraform.InstanceDiff,
 	d *ResourceData,
 	all bool) error {
+	// Diff into a scratch diff first, so that the attributes the schema
+	// considers semantically unchanged can be left out.
+	unsuppressedDiff := new(terraform.InstanceDiff)
+	unsuppressedDiff.Attributes = make(map[string]*terraform.ResourceAttrDiff)
+
 	var err error
 	switch schema.Type {
 	case TypeBool, TypeInt, TypeFloat, TypeString:
-		err = m.diffString(k, schema, diff, d, all)
+		err = m.diffString(k, schema, unsuppressedDiff, d, all)
 	case TypeList:
-		err = m.diffList(k, schema, diff, d, all)
+		err = m.diffList(k, schema, unsuppressedDiff, d, all)
 	case TypeMap:
-		err = m.diffMap(k, schema, diff, d, all)
+		err = m.diffMap(k, schema, unsuppressedDiff, d, all)
 	case TypeSet:
-		err = m.diffSet(k, schema, diff, d, all)
+		err = m.diffSet(k, schema, unsuppressedDiff, d, all)
+	case TypeSingleNestedBlock:
+		err = m.diffObject(k, schema, unsuppressedDiff, d, all)
 	default:
 		err = fmt.Errorf("%s: unknown type %#v", k, schema.Type)
 	}
 
+	for attrK, attrV := range unsuppressedDiff.Attributes {
+		if schema.DiffSuppressFunc != nil &&
+			attrV != nil &&
+			!attrV.NewComputed &&
+			!attrV.NewRemoved &&
+			schema.DiffSuppressFunc(attrK, attrV.Old, attrV.New, d) {
+			continue
+		}
+
+		if schema.Sensitive {
+			attrV.Sensitive = true
+		}
+
+		diff.Attributes[attrK] = attrV
+	}
+
 	return err
 }
 
@@ -721,6 +808,36 @@ func (m schemaMap) diffList(
 	return nil
 }
 
+// diffObject diffs each field of a nested object. The fields of an object
+// that forces a new resource force one as well.
+func (m schemaMap) diffObject(
+	k string,
+	schema *Schema,
+	diff *terraform.InstanceDiff,
+	d *ResourceData,
+	all bool) error {
+	o, n, _, computed := d.diffChange(k)
+
+	// If we have an old value and no new value is set or will be
+	// computed once all variables can be interpolated and we're
+	// computed, then nothing has changed.
+	if o != nil && n == nil && !computed && schema.Computed {
+		return nil
+	}
+
+	for k2, s := range schema.Elem.(*Resource).Schema {
+		s2 := *s
+		s2.ForceNew = s.ForceNew || schema.ForceNew
+
+		subK := fmt.Sprintf("%s.%s", k, k2)
+		if err := m.diff(subK, &s2, diff, d, all); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m schemaMap) diffMap(
 	k string,
 	schema *Schema,
@@ -843,6 +960,21 @@ func (m schemaMap) diffSet(
 	os := o.(*Set)
 	ns := n.(*Set)
 
+	// Elements of a set of resources which leave optional computed
+	// attributes unset hash differently than the same elements in the
+	// state, match them up so they don't show as removed and re-added.
+	if r, ok := schema.Elem.(*Resource); ok && nSet {
+		var adopted map[string]string
+		ns, adopted = setAdoptComputed(os, ns, r)
+
+		// The config addresses the adopted elements by their new codes
+		if cr, ok := d.multiReader.Readers["config"].(*ConfigFieldReader); ok {
+			for oldCode, newCode := range adopted {
+				cr.aliasSetCode(k, oldCode, newCode)
+			}
+		}
+	}
+
 	// If the new value was set, compare the listCode's to determine if
 	// the two are equal. Comparing listCode's instead of the actual values
 	// is needed because there could be computed values in the set which
@@ -934,6 +1066,83 @@ func (m schemaMap) diffSet(
 	return nil
 }
 
+// setAdoptComputed returns the new set of resources ns where each element
+// which only differs from an old element of os by optional computed
+// attributes it leaves unset is replaced by that old element, along with
+// the new codes of the replaced elements by old code. The zero value of an
+// optional computed attribute means it is left for the provider to compute,
+// so the value computed before is kept.
+func setAdoptComputed(os, ns *Set, r *Resource) (*Set, map[string]string) {
+	var computed []string
+	for k, s := range r.Schema {
+		if !s.Optional || !s.Computed {
+			continue
+		}
+		switch s.Type {
+		case TypeBool, TypeInt, TypeFloat, TypeString:
+			computed = append(computed, k)
+		}
+	}
+	if len(computed) == 0 {
+		return ns, nil
+	}
+
+	removed := os.Difference(ns)
+	if removed.Len() == 0 {
+		return ns, nil
+	}
+
+	adopted := make(map[string]string)
+	result := &Set{F: ns.F}
+	result.once.Do(result.init)
+	for code, v := range ns.m {
+		// Computed elements are unknown until apply
+		if _, ok := os.m[code]; ok || strings.HasPrefix(code, "~") {
+			result.m[code] = v
+			continue
+		}
+
+		elem, ok := v.(map[string]interface{})
+		if !ok {
+			result.m[code] = v
+			continue
+		}
+
+		found := false
+		for oldCode, oldV := range removed.m {
+			oldElem, ok := oldV.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			candidate := make(map[string]interface{}, len(elem))
+			for k, v := range elem {
+				candidate[k] = v
+			}
+			for _, k := range computed {
+				if v, ok := candidate[k]; !ok || v == nil ||
+					reflect.DeepEqual(v, r.Schema[k].ZeroValue()) {
+					candidate[k] = oldElem[k]
+				}
+			}
+
+			if result.hash(candidate) == oldCode {
+				result.m[oldCode] = oldV
+				removed.remove(oldV)
+				adopted[oldCode] = code
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			result.m[code] = v
+		}
+	}
+
+	return result, adopted
+}
+
 func (m schemaMap) diffString(
 	k string,
 	schema *Schema,
@@ -1204,6 +1413,12 @@ func (m schemaMap) validateObject(
 	if m, ok := raw.(map[string]interface{}); ok {
 		for subk, _ := range m {
 			if _, ok := schema[subk]; !ok {
+				// The timeouts block of a resource is validated by the
+				// Resource itself.
+				if k == "" && subk == TimeoutsConfigKey {
+					continue
+				}
+
 				es = append(es, fmt.Errorf(
 					"%s: invalid or unknown key: %s", k, subk))
 			}
@@ -1213,6 +1428,31 @@ func (m schemaMap) validateObject(
 	return ws, es
 }
 
+// validateNestedObject validates a nested object, which is configured as a
+// single block.
+func (m schemaMap) validateNestedObject(
+	k string,
+	raw interface{},
+	schema *Schema,
+	c *terraform.ResourceConfig) ([]string, []error) {
+	rawV := reflect.ValueOf(raw)
+	if rawV.Kind() != reflect.Slice {
+		return nil, []error{fmt.Errorf(
+			"%s: should be a block", k)}
+	}
+
+	switch rawV.Len() {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, []error{fmt.Errorf(
+			"%s: only one block is allowed, config has %d declared", k, rawV.Len())}
+	}
+
+	return m.validateObject(k+".0", schema.Elem.(*Resource).Schema, c)
+}
+
 func (m schemaMap) validatePrimitive(
 	k string,
 	raw interface{},
@@ -1293,6 +1533,8 @@ func (m schemaMap) validateType(
 		ws, es = m.validateList(k, raw, schema, c)
 	case TypeMap:
 		ws, es = m.validateMap(k, raw, schema, c)
+	case TypeSingleNestedBlock:
+		ws, es = m.validateNestedObject(k, raw, schema, c)
 	default:
 		ws, es = m.validatePrimitive(k, raw, schema, c)
 	}
@@ -1329,7 +1571,7 @@ func (t ValueType) Zero() interface{} {
 		return map[string]interface{}{}
 	case TypeSet:
 		return new(Set)
-	case typeObject:
+	case TypeSingleNestedBlock, typeObject:
 		return map[string]interface{}{}
 	default:
 		panic(fmt.Sprintf("unknown type %s", t))
@@ -6,6 +6,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/hil"
@@ -2400,6 +2401,432 @@ func TestSchemaMap_Diff(t *testing.T) {
 
 			Err: false,
 		},
+
+		"DiffSuppressFunc suppresses a semantically equal value": {
+			Schema: map[string]*Schema{
+				"address": &Schema{
+					Type:     TypeString,
+					Required: true,
+					ForceNew: true,
+					DiffSuppressFunc: func(k, old, new string, d *ResourceData) bool {
+						return strings.ToLower(old) == strings.ToLower(new)
+					},
+				},
+				"name": &Schema{
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"address": "2001:db8::1/125",
+					"name":    "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"address": "2001:DB8::1/125",
+				"name":    "bar",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": &terraform.ResourceAttrDiff{
+						Old: "foo",
+						New: "bar",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"DiffSuppressFunc keeps a different value": {
+			Schema: map[string]*Schema{
+				"address": &Schema{
+					Type:     TypeString,
+					Required: true,
+					ForceNew: true,
+					DiffSuppressFunc: func(k, old, new string, d *ResourceData) bool {
+						return strings.ToLower(old) == strings.ToLower(new)
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"address": "2001:db8::1/125",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"address": "2001:DB8::2/125",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"address": &terraform.ResourceAttrDiff{
+						Old:         "2001:db8::1/125",
+						New:         "2001:DB8::2/125",
+						RequiresNew: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"DiffSuppressFunc on a nested attribute": {
+			Schema: map[string]*Schema{
+				"peer": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"address": &Schema{
+								Type:     TypeString,
+								Optional: true,
+								DiffSuppressFunc: func(k, old, new string, d *ResourceData) bool {
+									return strings.ToLower(old) == strings.ToLower(new)
+								},
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"peer.#":         "1",
+					"peer.0.address": "2001:db8::1/125",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"peer": []map[string]interface{}{
+					map[string]interface{}{
+						"address": "2001:DB8::1/125",
+					},
+				},
+			},
+
+			Diff: nil,
+
+			Err: false,
+		},
+
+		"Set of resources with unset optional computed attributes": {
+			Schema: map[string]*Schema{
+				"rule": &Schema{
+					Type:     TypeSet,
+					Optional: true,
+					ForceNew: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": &Schema{
+								Type:     TypeString,
+								Required: true,
+							},
+							"port": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+								Computed: true,
+							},
+						},
+					},
+					Set: func(v interface{}) int {
+						m := v.(map[string]interface{})
+						return m["port"].(int)*100 + int(m["name"].(string)[0])
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"rule.#":         "2",
+					"rule.8097.name": "a",
+					"rule.8097.port": "80",
+					"rule.8198.name": "b",
+					"rule.8198.port": "81",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"rule": []map[string]interface{}{
+					map[string]interface{}{
+						"name": "b",
+					},
+					map[string]interface{}{
+						"name": "a",
+					},
+				},
+			},
+
+			Diff: nil,
+
+			Err: false,
+		},
+
+		"Set of resources with unset optional computed attributes, adding an element": {
+			Schema: map[string]*Schema{
+				"rule": &Schema{
+					Type:     TypeSet,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": &Schema{
+								Type:     TypeString,
+								Required: true,
+							},
+							"port": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+								Computed: true,
+							},
+						},
+					},
+					Set: func(v interface{}) int {
+						m := v.(map[string]interface{})
+						return m["port"].(int)*100 + int(m["name"].(string)[0])
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"rule.#":         "2",
+					"rule.8097.name": "a",
+					"rule.8097.port": "80",
+					"rule.8198.name": "b",
+					"rule.8198.port": "81",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"rule": []map[string]interface{}{
+					map[string]interface{}{
+						"name": "a",
+					},
+					map[string]interface{}{
+						"name": "b",
+						"port": 81,
+					},
+					map[string]interface{}{
+						"name": "c",
+					},
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"rule.#": &terraform.ResourceAttrDiff{
+						Old: "2",
+						New: "3",
+					},
+					"rule.8097.name": &terraform.ResourceAttrDiff{
+						Old: "a",
+						New: "a",
+					},
+					"rule.8198.name": &terraform.ResourceAttrDiff{
+						Old: "b",
+						New: "b",
+					},
+					"rule.8198.port": &terraform.ResourceAttrDiff{
+						Old: "81",
+						New: "81",
+					},
+					"rule.99.name": &terraform.ResourceAttrDiff{
+						Old: "",
+						New: "c",
+					},
+					"rule.99.port": &terraform.ResourceAttrDiff{
+						NewComputed: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"Object": {
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					ForceNew: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+							"delete": &Schema{
+								Type:     TypeString,
+								Optional: true,
+								Default:  "10m",
+							},
+						},
+					},
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"timeouts": []map[string]interface{}{
+					map[string]interface{}{
+						"create": "5m",
+					},
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"timeouts.create": &terraform.ResourceAttrDiff{
+						Old:         "",
+						New:         "5m",
+						RequiresNew: true,
+					},
+					"timeouts.delete": &terraform.ResourceAttrDiff{
+						Old:         "",
+						New:         "10m",
+						RequiresNew: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"Object unchanged": {
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					ForceNew: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+							"delete": &Schema{
+								Type:     TypeString,
+								Optional: true,
+								Default:  "10m",
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"timeouts.create": "5m",
+					"timeouts.delete": "10m",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"timeouts": []map[string]interface{}{
+					map[string]interface{}{
+						"create": "5m",
+					},
+				},
+			},
+
+			Diff: nil,
+
+			Err: false,
+		},
+
+		"Object not set": {
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					ForceNew: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+							"delete": &Schema{
+								Type:     TypeString,
+								Optional: true,
+								Default:  "10m",
+							},
+						},
+					},
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{},
+
+			Diff: nil,
+
+			Err: false,
+		},
+
+		"Object removed": {
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					ForceNew: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeString,
+								Optional: true,
+							},
+							"delete": &Schema{
+								Type:     TypeString,
+								Optional: true,
+								Default:  "10m",
+							},
+						},
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				ID: "foo",
+				Attributes: map[string]string{
+					"timeouts.create": "5m",
+					"timeouts.delete": "10m",
+				},
+			},
+
+			Config: map[string]interface{}{},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"timeouts.create": &terraform.ResourceAttrDiff{
+						Old:        "5m",
+						New:        "",
+						NewRemoved: true,
+					},
+					"timeouts.delete": &terraform.ResourceAttrDiff{
+						Old:        "10m",
+						New:        "",
+						NewRemoved: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -2415,7 +2842,7 @@ func TestSchemaMap_Diff(t *testing.T) {
 		}
 
 		d, err := schemaMap(tc.Schema).Diff(
-			tc.State, terraform.NewResourceConfig(c))
+			tc.State, terraform.NewResourceConfig(c), nil)
 		if err != nil != tc.Err {
 			t.Fatalf("#%q err: %s", tn, err)
 		}
@@ -2905,6 +3332,54 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			},
 			true,
 		},
+
+		"Object valid": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"foo": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		"Object with a schema Elem": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+			true,
+		},
+
+		"Object with MaxItems": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"foo": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+			true,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -3656,6 +4131,117 @@ func TestSchemaMap_Validate(t *testing.T) {
 
 			Err: false,
 		},
+
+		"Object": {
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"timeouts": []map[string]interface{}{
+					map[string]interface{}{
+						"create": 5,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"Object with several blocks": {
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"timeouts": []map[string]interface{}{
+					map[string]interface{}{
+						"create": 5,
+					},
+					map[string]interface{}{
+						"create": 10,
+					},
+				},
+			},
+
+			Err: true,
+		},
+
+		"Object with an unknown key": {
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"timeouts": []map[string]interface{}{
+					map[string]interface{}{
+						"update": 5,
+					},
+				},
+			},
+
+			Err: true,
+		},
+
+		"Object with a bad type": {
+			Schema: map[string]*Schema{
+				"timeouts": &Schema{
+					Type:     TypeSingleNestedBlock,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"create": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"timeouts": []map[string]interface{}{
+					map[string]interface{}{
+						"create": "soon",
+					},
+				},
+			},
+
+			Err: true,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -3789,3 +4375,30 @@ func TestSchemaSet_ValidateMaxItems(t *testing.T) {
 		}
 	}
 }
+
+func TestSchemaMap_diffSensitive(t *testing.T) {
+	m := schemaMap{
+		"password": &Schema{
+			Type:      TypeString,
+			Optional:  true,
+			Sensitive: true,
+		},
+	}
+
+	c, err := config.NewRawConfig(map[string]interface{}{
+		"password": "hunter2",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d, err := m.Diff(nil, terraform.NewResourceConfig(c), nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	attr, ok := d.Attributes["password"]
+	if !ok || !attr.Sensitive {
+		t.Fatalf("bad: %#v", d.Attributes)
+	}
+}
@@ -54,6 +54,10 @@ func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
 			serializeCollectionMemberForHash(buf, innerVal, schema.Elem)
 		}
 		buf.WriteRune('}')
+	case TypeSingleNestedBlock:
+		buf.WriteRune('<')
+		SerializeResourceForHash(buf, val, schema.Elem.(*Resource))
+		buf.WriteRune('>')
 	default:
 		panic("unknown schema type to serialize")
 	}
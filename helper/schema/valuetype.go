@@ -14,6 +14,7 @@ const (
 	TypeList
 	TypeMap
 	TypeSet
+	TypeSingleNestedBlock
 	typeObject
 )
 
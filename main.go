@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/terraform/config/module"
 	"github.com/hashicorp/terraform/helper/logging"
 	"github.com/mattn/go-colorable"
 	"github.com/mitchellh/cli"
@@ -140,6 +141,12 @@ func wrappedMain() int {
 	// Initialize the TFConfig settings for the commands...
 	ContextOpts.Providers = config.ProviderFactories()
 	ContextOpts.Provisioners = config.ProvisionerFactories()
+	for name, plugins := range config.ProviderPlugins() {
+		ProviderPlugins[name] = plugins
+	}
+	meta.PlanHooks = config.ProgramPlanHooks()
+	meta.StateHistory = config.StateHistory
+	module.DefaultRegistryHost = config.ModuleRegistry
 
 	exitCode, err := cli.Run()
 	if err != nil {
@@ -232,6 +232,19 @@ func (p *ResourceProvider) Resources() []terraform.ResourceType {
 	return result
 }
 
+func (p *ResourceProvider) GetSchema() (*terraform.ProviderSchema, error) {
+	var resp ResourceProviderGetSchemaResponse
+	err := p.Client.Call("Plugin.GetSchema", new(interface{}), &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		err = resp.Error
+	}
+
+	return resp.Schema, err
+}
+
 func (p *ResourceProvider) ReadDataDiff(
 	info *terraform.InstanceInfo,
 	c *terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
@@ -299,6 +312,11 @@ type ResourceProviderConfigureResponse struct {
 	Error *plugin.BasicError
 }
 
+type ResourceProviderGetSchemaResponse struct {
+	Schema *terraform.ProviderSchema
+	Error  *plugin.BasicError
+}
+
 type ResourceProviderInputArgs struct {
 	InputId uint32
 	Config  *terraform.ResourceConfig
@@ -505,6 +523,17 @@ func (s *ResourceProviderServer) Resources(
 	return nil
 }
 
+func (s *ResourceProviderServer) GetSchema(
+	nothing interface{},
+	result *ResourceProviderGetSchemaResponse) error {
+	schema, err := s.Provider.GetSchema()
+	*result = ResourceProviderGetSchemaResponse{
+		Schema: schema,
+		Error:  plugin.NewBasicError(err),
+	}
+	return nil
+}
+
 func (s *ResourceProviderServer) ValidateDataSource(
 	args *ResourceProviderValidateResourceArgs,
 	reply *ResourceProviderValidateResourceResponse) error {
@@ -356,6 +356,77 @@ func TestResourceProvider_importState(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_getSchema(t *testing.T) {
+	p := new(terraform.MockResourceProvider)
+
+	// Create a mock provider
+	client, _ := plugin.TestPluginRPCConn(t, pluginMap(&ServeOpts{
+		ProviderFunc: testProviderFixed(p),
+	}))
+	defer client.Close()
+
+	// Request the provider
+	raw, err := client.Dispense(ProviderPluginName)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	provider := raw.(terraform.ResourceProvider)
+
+	expected := &terraform.ProviderSchema{
+		Resources: map[string]*terraform.ResourceSchema{
+			"foo": &terraform.ResourceSchema{
+				Attributes: map[string]*terraform.AttributeSchema{
+					"bar": &terraform.AttributeSchema{
+						Type:     "list",
+						Required: true,
+						ForceNew: true,
+						Elem: &terraform.AttributeSchema{
+							Type: "string",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	p.GetSchemaReturn = expected
+
+	// GetSchema
+	result, err := provider.GetSchema()
+	if !p.GetSchemaCalled {
+		t.Fatal("get schema should be called")
+	}
+	if err != nil {
+		t.Fatalf("bad: %#v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("bad: %#v", result)
+	}
+}
+
+func TestResourceProvider_getSchema_error(t *testing.T) {
+	p := new(terraform.MockResourceProvider)
+
+	// Create a mock provider
+	client, _ := plugin.TestPluginRPCConn(t, pluginMap(&ServeOpts{
+		ProviderFunc: testProviderFixed(p),
+	}))
+	defer client.Close()
+
+	// Request the provider
+	raw, err := client.Dispense(ProviderPluginName)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	provider := raw.(terraform.ResourceProvider)
+
+	p.GetSchemaReturnError = errors.New("foo")
+
+	if _, err := provider.GetSchema(); err == nil {
+		t.Fatal("should have error")
+	}
+}
+
 func TestResourceProvider_resources(t *testing.T) {
 	p := new(terraform.MockResourceProvider)
 
@@ -13,6 +13,9 @@ const (
 )
 
 // Handshake is the HandshakeConfig used to configure clients and servers.
+// The plugins are served over net/rpc; ProtocolVersion must be incremented
+// if that changes, as older plugins and Terraform couldn't talk to each
+// other.
 var Handshake = plugin.HandshakeConfig{
 	ProtocolVersion:  1,
 	MagicCookieKey:   "TF_PLUGIN_MAGIC_COOKIE",
@@ -0,0 +1,91 @@
+package repl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FormatResult formats the given result value for human-readable output.
+//
+// The value must currently be a string, list, map, and any nested values
+// with those same types.
+func FormatResult(value interface{}) string {
+	return formatResult(value)
+}
+
+func formatResult(value interface{}) string {
+	switch output := value.(type) {
+	case string:
+		return output
+	case []interface{}:
+		return formatListResult(output)
+	case map[string]interface{}:
+		return formatMapResult(output)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+func formatListResult(value []interface{}) string {
+	var outputBuf bytes.Buffer
+	outputBuf.WriteString("[")
+	if len(value) > 0 {
+		outputBuf.WriteString("\n")
+	}
+
+	for i, v := range value {
+		raw := formatResult(v)
+		outputBuf.WriteString(indent(raw))
+		if i < len(value)-1 {
+			outputBuf.WriteString(",")
+		}
+		outputBuf.WriteString("\n")
+	}
+
+	outputBuf.WriteString("]")
+	return outputBuf.String()
+}
+
+func formatMapResult(value map[string]interface{}) string {
+	ks := make([]string, 0, len(value))
+	for k, _ := range value {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+
+	var outputBuf bytes.Buffer
+	outputBuf.WriteString("{")
+	if len(value) > 0 {
+		outputBuf.WriteString("\n")
+	}
+
+	for _, k := range ks {
+		v := value[k]
+		rawK := formatResult(k)
+		rawV := formatResult(v)
+
+		outputBuf.WriteString(indent(fmt.Sprintf("%s = %s", rawK, rawV)))
+		outputBuf.WriteString("\n")
+	}
+
+	outputBuf.WriteString("}")
+	return outputBuf.String()
+}
+
+func indent(value string) string {
+	var outputBuf bytes.Buffer
+	s := bufio.NewScanner(strings.NewReader(value))
+	newline := false
+	for s.Scan() {
+		if newline {
+			outputBuf.WriteString("\n")
+		}
+		outputBuf.WriteString("  " + s.Text())
+		newline = true
+	}
+
+	return outputBuf.String()
+}
@@ -0,0 +1,205 @@
+package repl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/terraform/config/module"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestSession_basicState(t *testing.T) {
+	state := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_instance.foo": &terraform.ResourceState{
+						Type: "test_instance",
+						Primary: &terraform.InstanceState{
+							ID: "bar",
+							Attributes: map[string]string{
+								"id":     "bar",
+								"list.#": "2",
+								"list.0": "a",
+								"list.1": "b",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testSession(t, testSessionTest{
+		State:  state,
+		Module: "basic",
+		Inputs: []testSessionInput{
+			{
+				Input:  "test_instance.foo.id",
+				Output: "bar",
+			},
+			{
+				Input:  "test_instance.foo.list",
+				Output: "[\n  a,\n  b\n]",
+			},
+			{
+				Input:         "test_instance.bar.id",
+				Error:         true,
+				ErrorContains: "'test_instance.bar' not found",
+			},
+		},
+	})
+}
+
+func TestSession_stateless(t *testing.T) {
+	testSession(t, testSessionTest{
+		Inputs: []testSessionInput{
+			{
+				Input:  "",
+				Output: "",
+			},
+			{
+				Input:          "help",
+				OutputContains: "allows you to",
+			},
+			{
+				Input:  "upper(\"foo\")",
+				Output: "FOO",
+			},
+			{
+				Input:  "split(\",\", \"a,b\")",
+				Output: "[\n  a,\n  b\n]",
+			},
+			{
+				Input: "upper(",
+				Error: true,
+			},
+			{
+				Input: "exit",
+				Error: true,
+				Exit:  true,
+			},
+		},
+	})
+}
+
+func TestFormatResult(t *testing.T) {
+	cases := []struct {
+		Input  interface{}
+		Output string
+	}{
+		{"foo", "foo"},
+		{[]interface{}{}, "[]"},
+		{[]interface{}{"a", "b"}, "[\n  a,\n  b\n]"},
+		{map[string]interface{}{}, "{}"},
+		{
+			map[string]interface{}{"b": "2", "a": []interface{}{"1"}},
+			"{\n  a = [\n    1\n  ]\n  b = 2\n}",
+		},
+	}
+
+	for i, tc := range cases {
+		if actual := FormatResult(tc.Input); actual != tc.Output {
+			t.Fatalf("%d: expected:\n\n%s\n\ngot:\n\n%s", i, tc.Output, actual)
+		}
+	}
+}
+
+func testSession(t *testing.T, test testSessionTest) {
+	// Load the module, if any
+	mod := module.NewEmptyTree()
+	if test.Module != "" {
+		mod = testModule(t, test.Module)
+	}
+
+	// Build the TF context
+	ctx, err := terraform.NewContext(&terraform.ContextOpts{
+		State:  test.State,
+		Module: mod,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Build the session
+	s := &Session{
+		Interpolater: ctx.Interpolater(),
+	}
+
+	// Test the inputs. They are run in order in the same session, as a
+	// user would type them.
+	for _, input := range test.Inputs {
+		result, err := s.Handle(input.Input)
+		if (err != nil) != input.Error {
+			t.Fatalf("%q: err: %s", input.Input, err)
+		}
+		if err != nil {
+			if input.ErrorContains != "" {
+				if !strings.Contains(err.Error(), input.ErrorContains) {
+					t.Fatalf(
+						"%q: err should contain: %q\n\n%s",
+						input.Input, input.ErrorContains, err)
+				}
+			}
+
+			if input.Exit && err != ErrSessionExit {
+				t.Fatalf("%q: should exit: %s", input.Input, err)
+			}
+
+			continue
+		}
+
+		if input.Output != "" && result != input.Output {
+			t.Fatalf("%q: expected:\n\n%s\n\ngot:\n\n%s", input.Input, input.Output, result)
+		}
+
+		if input.OutputContains != "" && !strings.Contains(result, input.OutputContains) {
+			t.Fatalf("%q: expected contains:\n\n%s\n\ngot:\n\n%s",
+				input.Input, input.OutputContains, result)
+		}
+	}
+}
+
+func testModule(t *testing.T, name string) *module.Tree {
+	mod, err := module.NewTreeModule("", filepath.Join("test-fixtures", name))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &getter.FolderStorage{StorageDir: dir}
+	if err := mod.Load(s, module.GetModeGet); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return mod
+}
+
+type testSessionTest struct {
+	State  *terraform.State // State to use
+	Module string           // Module name in test-fixtures to load
+
+	// Inputs are the list of test inputs that are run in order.
+	// Each input can test the output of each step.
+	Inputs []testSessionInput
+}
+
+// testSessionInput is a single input to test for a session.
+type testSessionInput struct {
+	Input          string // Input string
+	Output         string // Exact output string to check
+	OutputContains string
+	Error          bool // Error is true if error is expected
+	Exit           bool // Exit is true if exiting is expected
+	ErrorContains  string
+}
@@ -0,0 +1 @@
+resource "test_instance" "foo" {}
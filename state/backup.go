@@ -43,6 +43,24 @@ func (s *BackupState) PersistState() error {
 	return s.Real.PersistState()
 }
 
+// Lock locks the real state, if it supports locking.
+//
+// Locker impl.
+func (s *BackupState) Lock(info *LockInfo) (string, error) {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Lock(info)
+	}
+	return "", nil
+}
+
+// Locker impl.
+func (s *BackupState) Unlock(id string) error {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Unlock(id)
+	}
+	return nil
+}
+
 func (s *BackupState) backup() error {
 	state := s.Real.State()
 	if state == nil {
@@ -131,6 +131,25 @@ func (s *CacheState) PersistState() error {
 	return s.Durable.PersistState()
 }
 
+// Lock locks the durable state, if it supports locking. The local cache
+// is never locked, as it is only used by the current process.
+//
+// Locker impl.
+func (s *CacheState) Lock(info *LockInfo) (string, error) {
+	if l, ok := s.Durable.(Locker); ok {
+		return l.Lock(info)
+	}
+	return "", nil
+}
+
+// Locker impl.
+func (s *CacheState) Unlock(id string) error {
+	if l, ok := s.Durable.(Locker); ok {
+		return l.Unlock(id)
+	}
+	return nil
+}
+
 // CacheStateCache is the meta-interface that must be implemented for
 // the cache for the CacheState.
 type CacheStateCache interface {
@@ -0,0 +1,203 @@
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// EncryptionKeyEnvVar is the environment variable holding the key used to
+// encrypt the sensitive attributes of the state when it is persisted: 32
+// bytes, base64-encoded. If it isn't set, the state is stored as is.
+const EncryptionKeyEnvVar = "TF_STATE_ENCRYPTION_KEY"
+
+// encryptedValuePrefix prefixes the encrypted values in the persisted
+// state, so that they can be told apart from plaintext ones.
+const encryptedValuePrefix = "tfenc:v1:"
+
+// stateCipher encrypts and decrypts attribute values with AES-GCM.
+//
+// Encryption is deterministic so that persisting an unchanged state gives
+// the same result: the nonce is an HMAC of the value, with a key separate
+// from the encryption key. The address of the attribute is authenticated
+// along with the value, so encrypted values can't be moved to another
+// attribute.
+type stateCipher struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// newStateCipher returns the cipher for the key set in EncryptionKeyEnvVar,
+// or nil if it isn't set.
+func newStateCipher() (*stateCipher, error) {
+	raw := os.Getenv(EncryptionKeyEnvVar)
+	if raw == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be base64-encoded: %s", EncryptionKeyEnvVar, err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf(
+			"%s must be 32 bytes long, got %d", EncryptionKeyEnvVar, len(key))
+	}
+
+	block, err := aes.NewCipher(deriveKey(key, "terraform state encryption"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateCipher{
+		aead:     aead,
+		nonceKey: deriveKey(key, "terraform state nonce"),
+	}, nil
+}
+
+// deriveKey derives a 32 byte subkey for the given purpose from the key
+// with HKDF-SHA256 (RFC 5869), with no salt.
+func deriveKey(key []byte, info string) []byte {
+	extract := hmac.New(sha256.New, make([]byte, sha256.Size))
+	extract.Write(key)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	expand.Write([]byte(info))
+	expand.Write([]byte{1})
+	return expand.Sum(nil)
+}
+
+func (c *stateCipher) encrypt(addr, v string) string {
+	mac := hmac.New(sha256.New, c.nonceKey)
+	mac.Write([]byte(addr))
+	mac.Write([]byte{0})
+	mac.Write([]byte(v))
+	nonce := mac.Sum(nil)[:c.aead.NonceSize()]
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(v), []byte(addr))
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed)
+}
+
+func (c *stateCipher) decrypt(addr, v string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(
+		strings.TrimPrefix(v, encryptedValuePrefix))
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < c.aead.NonceSize() {
+		return "", fmt.Errorf("encrypted value is too short")
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, []byte(addr))
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// attributeAddr is the address of an attribute authenticated along with its
+// encrypted value.
+func attributeAddr(mod *terraform.ModuleState, name, k string) string {
+	return strings.Join(mod.Path, ".") + "|" + name + "|" + k
+}
+
+// walkSensitive calls f with the address and value of each of the
+// sensitive attributes of the given state, and sets them to the values f
+// returns.
+func walkSensitive(s *terraform.State, f func(addr, v string) (string, error)) error {
+	for _, mod := range s.Modules {
+		for name, rs := range mod.Resources {
+			instances := append([]*terraform.InstanceState{rs.Primary}, rs.Deposed...)
+			for _, is := range instances {
+				if is == nil {
+					continue
+				}
+
+				for _, k := range is.SensitiveAttributes() {
+					v, ok := is.Attributes[k]
+					if !ok || v == "" {
+						continue
+					}
+
+					result, err := f(attributeAddr(mod, name, k), v)
+					if err != nil {
+						return fmt.Errorf("%s: %s: %s", name, k, err)
+					}
+					is.Attributes[k] = result
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// EncryptState returns the state to persist for the given state: a copy
+// with the values of its sensitive attributes encrypted if
+// EncryptionKeyEnvVar is set, or the state itself otherwise.
+func EncryptState(s *terraform.State) (*terraform.State, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	c, err := newStateCipher()
+	if err != nil || c == nil {
+		return s, err
+	}
+
+	result := s.DeepCopy()
+	err = walkSensitive(result, func(addr, v string) (string, error) {
+		if strings.HasPrefix(v, encryptedValuePrefix) {
+			return v, nil
+		}
+		return c.encrypt(addr, v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DecryptState decrypts, in place, the sensitive attributes of a state read
+// from storage. It is an error for the state to have encrypted values if
+// EncryptionKeyEnvVar isn't set.
+func DecryptState(s *terraform.State) error {
+	if s == nil {
+		return nil
+	}
+
+	c, err := newStateCipher()
+	if err != nil {
+		return err
+	}
+
+	return walkSensitive(s, func(addr, v string) (string, error) {
+		if !strings.HasPrefix(v, encryptedValuePrefix) {
+			return v, nil
+		}
+		if c == nil {
+			return "", fmt.Errorf(
+				"value is encrypted, but %s isn't set", EncryptionKeyEnvVar)
+		}
+		v, err := c.decrypt(addr, v)
+		if err != nil {
+			return "", fmt.Errorf("error decrypting value: %s", err)
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,200 @@
+package state
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testEncryptionKey(t *testing.T) func() {
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	if err := os.Setenv(EncryptionKeyEnvVar, key); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return func() { os.Unsetenv(EncryptionKeyEnvVar) }
+}
+
+func testSensitiveState() *terraform.State {
+	primary := &terraform.InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"id":       "foo",
+			"name":     "foo",
+			"password": "hunter2",
+			"keys.#":   "1",
+			"keys.0":   "secret",
+		},
+	}
+	primary.SetSensitiveAttributes([]string{"password", "keys.0"})
+
+	return &terraform.State{
+		Version: terraform.StateVersion,
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"test_instance.foo": &terraform.ResourceState{
+						Type:    "test_instance",
+						Primary: primary,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEncryptState(t *testing.T) {
+	defer testEncryptionKey(t)()
+
+	plain := testSensitiveState()
+	encrypted, err := EncryptState(plain)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	attrs := encrypted.RootModule().Resources["test_instance.foo"].Primary.Attributes
+	for _, k := range []string{"password", "keys.0"} {
+		if !strings.HasPrefix(attrs[k], encryptedValuePrefix) {
+			t.Fatalf("%s not encrypted: %s", k, attrs[k])
+		}
+	}
+	if attrs["name"] != "foo" || attrs["keys.#"] != "1" {
+		t.Fatalf("bad: %#v", attrs)
+	}
+
+	// The given state isn't modified
+	if !plain.Equal(testSensitiveState()) {
+		t.Fatalf("state modified: %#v", plain)
+	}
+
+	// Encrypting the same state again gives the same result
+	again, err := EncryptState(plain)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !again.Equal(encrypted) {
+		t.Fatalf("encryption isn't deterministic:\n\n%s\n\n%s", again, encrypted)
+	}
+
+	if err := DecryptState(encrypted); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !encrypted.Equal(plain) {
+		t.Fatalf("bad:\n\n%s\n\n%s", encrypted, plain)
+	}
+}
+
+func TestEncryptState_noKey(t *testing.T) {
+	os.Unsetenv(EncryptionKeyEnvVar)
+
+	plain := testSensitiveState()
+	actual, err := EncryptState(plain)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != plain {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestEncryptState_badKey(t *testing.T) {
+	defer os.Unsetenv(EncryptionKeyEnvVar)
+
+	cases := []string{
+		"not base64!",
+		base64.StdEncoding.EncodeToString([]byte("short")),
+	}
+
+	for _, tc := range cases {
+		os.Setenv(EncryptionKeyEnvVar, tc)
+		if _, err := EncryptState(testSensitiveState()); err == nil {
+			t.Fatalf("%q: should error", tc)
+		}
+	}
+}
+
+func TestDecryptState_noKey(t *testing.T) {
+	unset := testEncryptionKey(t)
+	encrypted, err := EncryptState(testSensitiveState())
+	unset()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	err = DecryptState(encrypted)
+	if err == nil || !strings.Contains(err.Error(), EncryptionKeyEnvVar) {
+		t.Fatalf("bad: %v", err)
+	}
+}
+
+func TestDecryptState_wrongKey(t *testing.T) {
+	unset := testEncryptionKey(t)
+	encrypted, err := EncryptState(testSensitiveState())
+	unset()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("x", 32)))
+	os.Setenv(EncryptionKeyEnvVar, key)
+	defer os.Unsetenv(EncryptionKeyEnvVar)
+
+	if err := DecryptState(encrypted); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestDecryptState_movedValue(t *testing.T) {
+	defer testEncryptionKey(t)()
+
+	encrypted, err := EncryptState(testSensitiveState())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// An encrypted value only decrypts as the attribute it was encrypted as
+	attrs := encrypted.RootModule().Resources["test_instance.foo"].Primary.Attributes
+	attrs["keys.0"] = attrs["password"]
+
+	if err := DecryptState(encrypted); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestLocalState_encrypted(t *testing.T) {
+	defer testEncryptionKey(t)()
+
+	f, err := ioutil.TempFile("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	ls := &LocalState{Path: f.Name()}
+	if err := ls.WriteState(testSensitiveState()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	raw, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if strings.Contains(string(raw), "hunter2") {
+		t.Fatalf("sensitive value stored in plaintext:\n%s", raw)
+	}
+
+	ls = &LocalState{Path: f.Name()}
+	if err := ls.RefreshState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	attrs := ls.State().RootModule().Resources["test_instance.foo"].Primary.Attributes
+	if attrs["password"] != "hunter2" {
+		t.Fatalf("bad: %#v", attrs)
+	}
+}
@@ -0,0 +1,157 @@
+package state
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// HistoryExtension is the extension of the state snapshots in a history
+// directory. The snapshots are named after the serial of their state.
+const HistoryExtension = ".tfstate"
+
+// HistoryState wraps a State that keeps a snapshot of the state in Dir
+// every time it is persisted, one per serial.
+//
+// If Keep is more than zero, only the snapshots of the Keep newest serials
+// are kept.
+type HistoryState struct {
+	Real State
+	Dir  string
+	Keep int
+}
+
+func (s *HistoryState) State() *terraform.State {
+	return s.Real.State()
+}
+
+func (s *HistoryState) RefreshState() error {
+	return s.Real.RefreshState()
+}
+
+func (s *HistoryState) WriteState(state *terraform.State) error {
+	return s.Real.WriteState(state)
+}
+
+func (s *HistoryState) PersistState() error {
+	if err := s.Real.PersistState(); err != nil {
+		return err
+	}
+
+	return s.snapshot()
+}
+
+// Lock locks the real state, if it supports locking.
+//
+// Locker impl.
+func (s *HistoryState) Lock(info *LockInfo) (string, error) {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Lock(info)
+	}
+	return "", nil
+}
+
+// Locker impl.
+func (s *HistoryState) Unlock(id string) error {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Unlock(id)
+	}
+	return nil
+}
+
+// snapshot writes the persisted state to the history, and removes the
+// snapshots of the serials that are no longer kept.
+func (s *HistoryState) snapshot() error {
+	state := s.Real.State()
+	if state == nil {
+		return nil
+	}
+
+	path := filepath.Join(s.Dir, fmt.Sprintf("%d%s", state.Serial, HistoryExtension))
+	ls := &LocalState{Path: path}
+	if err := ls.WriteState(state); err != nil {
+		return fmt.Errorf("Error writing the state history: %s", err)
+	}
+
+	if s.Keep <= 0 {
+		return nil
+	}
+
+	snapshots, err := History(s.Dir)
+	if err != nil {
+		return err
+	}
+	for i := s.Keep; i < len(snapshots); i++ {
+		if err := os.Remove(snapshots[i].Path); err != nil {
+			return fmt.Errorf("Error removing from the state history: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// HistorySnapshot is a snapshot of a state in a history directory.
+type HistorySnapshot struct {
+	Serial  int64
+	Path    string
+	ModTime time.Time
+}
+
+// State reads the state of the snapshot.
+func (s *HistorySnapshot) State() (*terraform.State, error) {
+	ls := &LocalState{Path: s.Path}
+	if err := ls.RefreshState(); err != nil {
+		return nil, err
+	}
+
+	return ls.State(), nil
+}
+
+// History returns the snapshots in the history directory dir, newest
+// serial first. A directory that doesn't exist has no snapshots.
+func History(dir string) ([]*HistorySnapshot, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("Error reading the state history: %s", err)
+	}
+
+	var result []*HistorySnapshot
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, HistoryExtension) {
+			continue
+		}
+
+		serial, err := strconv.ParseInt(strings.TrimSuffix(name, HistoryExtension), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, &HistorySnapshot{
+			Serial:  serial,
+			Path:    filepath.Join(dir, name),
+			ModTime: info.ModTime(),
+		})
+	}
+
+	sort.Sort(historySnapshots(result))
+	return result, nil
+}
+
+// historySnapshots sorts snapshots by serial, newest first.
+type historySnapshots []*HistorySnapshot
+
+func (s historySnapshots) Len() int           { return len(s) }
+func (s historySnapshots) Less(i, j int) bool { return s[i].Serial > s[j].Serial }
+func (s historySnapshots) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,102 @@
+package state
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestHistoryState_impl(t *testing.T) {
+	var _ StateReader = new(HistoryState)
+	var _ StateWriter = new(HistoryState)
+	var _ StatePersister = new(HistoryState)
+	var _ StateRefresher = new(HistoryState)
+	var _ Locker = new(HistoryState)
+}
+
+func TestHistoryState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ls := testLocalState(t)
+	defer os.Remove(ls.Path)
+	TestState(t, &HistoryState{
+		Real: ls,
+		Dir:  dir,
+	})
+
+	snapshots, err := History(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(snapshots) == 0 {
+		t.Fatal("should have snapshots")
+	}
+}
+
+func TestHistoryState_keep(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ls := testLocalState(t)
+	defer os.Remove(ls.Path)
+	hs := &HistoryState{
+		Real: ls,
+		Dir:  dir,
+		Keep: 2,
+	}
+
+	// Persist a new serial each time
+	for _, v := range []string{"a", "b", "c", "d"} {
+		state := hs.State()
+		state.Modules[0].Outputs["foo"].Value = v
+		if err := hs.WriteState(state); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if err := hs.PersistState(); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	snapshots, err := History(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var serials []int64
+	for _, s := range snapshots {
+		serials = append(serials, s.Serial)
+	}
+	if expected := []int64{4, 3}; !reflect.DeepEqual(serials, expected) {
+		t.Fatalf("bad: %#v", serials)
+	}
+
+	// The snapshot holds the state of its serial
+	state, err := snapshots[1].State()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if state.Serial != 3 {
+		t.Fatalf("bad: %d", state.Serial)
+	}
+	if v := state.Modules[0].Outputs["foo"].Value; v != "c" {
+		t.Fatalf("bad: %#v", v)
+	}
+}
+
+func TestHistory_notExist(t *testing.T) {
+	snapshots, err := History("does-not-exist")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(snapshots) != 0 {
+		t.Fatalf("bad: %#v", snapshots)
+	}
+}
@@ -57,16 +57,23 @@ func (s *LocalState) WriteState(state *terraform.State) error {
 		return err
 	}
 
+	s.state.IncrementSerialMaybe(s.readState)
+	s.readState = s.state
+
+	// Encrypt before creating the file, so that a bad key doesn't
+	// truncate the existing state
+	persisted, err := EncryptState(s.state)
+	if err != nil {
+		return err
+	}
+
 	f, err := os.Create(path)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
-	s.state.IncrementSerialMaybe(s.readState)
-	s.readState = s.state
-
-	if err := terraform.WriteState(s.state, f); err != nil {
+	if err := terraform.WriteState(persisted, f); err != nil {
 		return err
 	}
 
@@ -107,6 +114,9 @@ func (s *LocalState) RefreshState() error {
 		if err != nil {
 			return err
 		}
+		if err := DecryptState(state); err != nil {
+			return err
+		}
 	}
 
 	s.state = state
@@ -0,0 +1,153 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Locker is implemented by states that can be locked, to prevent concurrent
+// operations from reading and writing the same state.
+type Locker interface {
+	// Lock locks the state, recording the given info along with the lock,
+	// and returns the ID of the lock. If the state is already locked, the
+	// error must be a *LockError holding the info of the existing lock.
+	//
+	// States that wrap a state that can't be locked return an empty ID
+	// and no error.
+	Lock(info *LockInfo) (string, error)
+
+	// Unlock releases the lock with the given ID.
+	Unlock(id string) error
+}
+
+// lockRetryInterval is how often LockWithTimeout retries to lock a state
+// that is already locked.
+var lockRetryInterval = time.Second
+
+// LockInfo is the information stored along with a lock. It is shown to
+// users who try to lock a state that is already locked.
+type LockInfo struct {
+	// ID is the unique ID of the lock, which is required to unlock it.
+	ID string
+
+	// Operation is the Terraform operation that holds the lock, e.g.
+	// "apply".
+	Operation string
+
+	// Who holds the lock, as user@hostname.
+	Who string
+
+	// Version of Terraform that holds the lock.
+	Version string
+
+	// Created is the time the lock was acquired.
+	Created time.Time
+
+	// Path to the locked state, if any.
+	Path string
+}
+
+// NewLockInfo returns a LockInfo for the given operation, with a new ID and
+// the information about the current user and process filled in.
+func NewLockInfo(operation string) *LockInfo {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+
+	who := "unknown"
+	if u, err := user.Current(); err == nil {
+		who = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		who = who + "@" + host
+	}
+
+	version := terraform.Version
+	if terraform.VersionPrerelease != "" {
+		version += "-" + terraform.VersionPrerelease
+	}
+
+	return &LockInfo{
+		ID:        id,
+		Operation: operation,
+		Who:       who,
+		Version:   version,
+		Created:   time.Now().UTC(),
+	}
+}
+
+// Marshal returns the JSON encoding of the lock info.
+func (l *LockInfo) Marshal() []byte {
+	js, err := json.Marshal(l)
+	if err != nil {
+		panic(err)
+	}
+	return js
+}
+
+// String returns a multi-line description of the lock, for users.
+func (l *LockInfo) String() string {
+	lines := []string{
+		fmt.Sprintf("ID:        %s", l.ID),
+		fmt.Sprintf("Path:      %s", l.Path),
+		fmt.Sprintf("Operation: %s", l.Operation),
+		fmt.Sprintf("Who:       %s", l.Who),
+		fmt.Sprintf("Version:   %s", l.Version),
+		fmt.Sprintf("Created:   %s", l.Created),
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LockError is returned by Locker.Lock when the state is already locked.
+type LockError struct {
+	// Info is the info of the existing lock, if it could be read.
+	Info *LockInfo
+	Err  error
+}
+
+func (e *LockError) Error() string {
+	var out []string
+	if e.Err != nil {
+		out = append(out, e.Err.Error())
+	}
+	if e.Info != nil {
+		out = append(out, "Lock Info:\n"+e.Info.String())
+	}
+	return strings.Join(out, "\n")
+}
+
+// LockWithTimeout locks the given state, retrying for as long as timeout
+// while it is locked by someone else. Errors other than a *LockError are
+// returned immediately.
+func LockWithTimeout(l Locker, info *LockInfo, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		id, err := l.Lock(info)
+		if err == nil {
+			return id, nil
+		}
+
+		if _, ok := err.(*LockError); !ok {
+			return "", err
+		}
+
+		remaining := deadline.Sub(time.Now())
+		if remaining <= 0 {
+			return "", err
+		}
+
+		wait := lockRetryInterval
+		if remaining < wait {
+			wait = remaining
+		}
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,94 @@
+package state
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testLocker is a Locker that is locked by someone else for the given
+// number of attempts.
+type testLocker struct {
+	lockedAttempts int
+	attempts       int
+	err            error
+}
+
+func (l *testLocker) Lock(info *LockInfo) (string, error) {
+	l.attempts++
+	if l.err != nil {
+		return "", l.err
+	}
+	if l.attempts <= l.lockedAttempts {
+		return "", &LockError{
+			Info: &LockInfo{ID: "other"},
+			Err:  fmt.Errorf("state locked"),
+		}
+	}
+	return info.ID, nil
+}
+
+func (l *testLocker) Unlock(id string) error {
+	return nil
+}
+
+func TestLockWithTimeout(t *testing.T) {
+	old := lockRetryInterval
+	lockRetryInterval = time.Millisecond
+	defer func() { lockRetryInterval = old }()
+
+	info := NewLockInfo("test")
+
+	// Not locked
+	l := &testLocker{}
+	id, err := LockWithTimeout(l, info, 0)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if id != info.ID {
+		t.Fatalf("bad lock ID: %s", id)
+	}
+
+	// Locked, without a timeout
+	l = &testLocker{lockedAttempts: 2}
+	_, err = LockWithTimeout(l, info, 0)
+	if _, ok := err.(*LockError); !ok {
+		t.Fatalf("expected a *LockError, got: %#v", err)
+	}
+	if l.attempts != 1 {
+		t.Fatalf("expected a single attempt, got: %d", l.attempts)
+	}
+
+	// Locked until a retry
+	l = &testLocker{lockedAttempts: 2}
+	if _, err := LockWithTimeout(l, info, time.Minute); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if l.attempts != 3 {
+		t.Fatalf("expected 3 attempts, got: %d", l.attempts)
+	}
+
+	// Other errors aren't retried
+	l = &testLocker{err: fmt.Errorf("boom")}
+	if _, err := LockWithTimeout(l, info, time.Minute); err == nil {
+		t.Fatal("expected an error")
+	}
+	if l.attempts != 1 {
+		t.Fatalf("expected a single attempt, got: %d", l.attempts)
+	}
+}
+
+func TestNewLockInfo(t *testing.T) {
+	a := NewLockInfo("apply")
+	b := NewLockInfo("apply")
+
+	if a.ID == "" || a.ID == b.ID {
+		t.Fatalf("expected unique lock IDs, got %q and %q", a.ID, b.ID)
+	}
+	if a.Operation != "apply" {
+		t.Fatalf("bad operation: %s", a.Operation)
+	}
+	if a.Who == "" || a.Version == "" || a.Created.IsZero() {
+		t.Fatalf("expected lock info to be filled in: %#v", a)
+	}
+}
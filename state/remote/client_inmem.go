@@ -2,12 +2,18 @@ package remote
 
 import (
 	"crypto/md5"
+	"fmt"
+
+	"github.com/hashicorp/terraform/state"
 )
 
 // InmemClient is a Client implementation that stores data in memory.
 type InmemClient struct {
 	Data []byte
 	MD5  []byte
+
+	// LockInfo is the info of the current lock, if the state is locked.
+	LockInfo *state.LockInfo
 }
 
 func (c *InmemClient) Get() (*Payload, error) {
@@ -30,3 +36,30 @@ func (c *InmemClient) Delete() error {
 	c.MD5 = nil
 	return nil
 }
+
+func (c *InmemClient) Lock(info *state.LockInfo) (string, error) {
+	if c.LockInfo != nil {
+		return "", &state.LockError{
+			Info: c.LockInfo,
+			Err:  fmt.Errorf("state locked"),
+		}
+	}
+
+	c.LockInfo = info
+	return info.ID, nil
+}
+
+func (c *InmemClient) Unlock(id string) error {
+	if c.LockInfo == nil {
+		return fmt.Errorf("state not locked")
+	}
+	if c.LockInfo.ID != id {
+		return &state.LockError{
+			Info: c.LockInfo,
+			Err:  fmt.Errorf("lock ID %q does not match the existing lock", id),
+		}
+	}
+
+	c.LockInfo = nil
+	return nil
+}
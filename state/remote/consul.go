@@ -2,18 +2,31 @@ package remote
 
 import (
 	"crypto/md5"
+	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	consulapi "github.com/hashicorp/consul/api"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/state"
 )
 
+// consulLockWaitTime is how long Lock waits for a lock held by someone
+// else to be released before it gives up. Waiting longer is left to
+// state.LockWithTimeout.
+var consulLockWaitTime = time.Second
+
 func consulFactory(conf map[string]string) (Client, error) {
 	path, ok := conf["path"]
 	if !ok {
 		return nil, fmt.Errorf("missing 'path' configuration")
 	}
 
+	// The default config reads the address, the ACL token and the
+	// credentials from the CONSUL_HTTP_* environment variables, which the
+	// configuration below overrides.
 	config := consulapi.DefaultConfig()
 	if token, ok := conf["access_token"]; ok && token != "" {
 		config.Token = token
@@ -21,6 +34,9 @@ func consulFactory(conf map[string]string) (Client, error) {
 	if addr, ok := conf["address"]; ok && addr != "" {
 		config.Address = addr
 	}
+	if datacenter, ok := conf["datacenter"]; ok && datacenter != "" {
+		config.Datacenter = datacenter
+	}
 	if scheme, ok := conf["scheme"]; ok && scheme != "" {
 		config.Scheme = scheme
 	}
@@ -54,6 +70,12 @@ func consulFactory(conf map[string]string) (Client, error) {
 type ConsulClient struct {
 	Client *consulapi.Client
 	Path   string
+
+	// consulLock is the lock held by this client, if any, and lockInfo
+	// its info. lockDone is closed when the lock is released on purpose.
+	consulLock *consulapi.Lock
+	lockInfo   *state.LockInfo
+	lockDone   chan struct{}
 }
 
 func (c *ConsulClient) Get() (*Payload, error) {
@@ -86,3 +108,152 @@ func (c *ConsulClient) Delete() error {
 	_, err := kv.Delete(c.Path, nil)
 	return err
 }
+
+// Lock locks the state with a Consul session, at the path of the state
+// followed by "/.lock". The session is renewed for as long as the lock is
+// held, so that the lock of a process that crashed is released once the
+// TTL of its session expires.
+func (c *ConsulClient) Lock(info *state.LockInfo) (string, error) {
+	if c.consulLock != nil {
+		return "", &state.LockError{
+			Info: c.lockInfo,
+			Err:  fmt.Errorf("state already locked by this process"),
+		}
+	}
+
+	info.Path = c.Path
+
+	lock, err := c.Client.LockOpts(&consulapi.LockOptions{
+		Key:          c.lockPath(),
+		Value:        info.Marshal(),
+		SessionName:  fmt.Sprintf("terraform %s", info.Operation),
+		LockTryOnce:  true,
+		LockWaitTime: consulLockWaitTime,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("[DEBUG] Locking remote state in Consul: %s", c.lockPath())
+
+	lockCh, err := lock.Lock(nil)
+	if err != nil {
+		return "", fmt.Errorf("Failed to lock remote state: %s", err)
+	}
+	if lockCh == nil {
+		err = fmt.Errorf("state locked by another session")
+
+		lockInfo, infoErr := c.getLockInfo()
+		if infoErr != nil {
+			err = multierror.Append(err, infoErr)
+		}
+
+		return "", &state.LockError{
+			Info: lockInfo,
+			Err:  err,
+		}
+	}
+
+	c.consulLock = lock
+	c.lockInfo = info
+	c.lockDone = make(chan struct{})
+
+	// The lock is lost if the session can't be renewed or is destroyed,
+	// for example by a force-unlock. There is no way to stop the running
+	// operation from here, but it is worth knowing about.
+	go func(lockCh <-chan struct{}, done <-chan struct{}) {
+		select {
+		case <-lockCh:
+			log.Printf("[ERROR] Lost the lock on the remote state in Consul: %s", c.Path)
+		case <-done:
+		}
+	}(lockCh, c.lockDone)
+
+	return info.ID, nil
+}
+
+// Unlock releases the lock with the given ID. If the lock isn't held by
+// this client, the session that holds it is destroyed instead.
+func (c *ConsulClient) Unlock(id string) error {
+	if c.consulLock == nil {
+		return c.forceUnlock(id)
+	}
+
+	if c.lockInfo.ID != id {
+		return &state.LockError{
+			Info: c.lockInfo,
+			Err:  fmt.Errorf("lock ID %q does not match the existing lock", id),
+		}
+	}
+
+	log.Printf("[DEBUG] Unlocking remote state in Consul: %s", c.lockPath())
+
+	close(c.lockDone)
+	lock := c.consulLock
+	c.consulLock, c.lockInfo, c.lockDone = nil, nil, nil
+
+	if err := lock.Unlock(); err != nil {
+		return fmt.Errorf("Failed to unlock remote state: %s", err)
+	}
+
+	// Removing the lock entry is only cleanup, and fails if someone else
+	// locked the state in between.
+	if err := lock.Destroy(); err != nil && err != consulapi.ErrLockInUse {
+		log.Printf("[WARN] Error removing the lock of the remote state in Consul: %s", err)
+	}
+
+	return nil
+}
+
+// forceUnlock releases a lock held by another client, by destroying the
+// session that holds it.
+func (c *ConsulClient) forceUnlock(id string) error {
+	pair, _, err := c.Client.KV().Get(c.lockPath(), nil)
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve lock info: %s", err)
+	}
+	if pair == nil || pair.Session == "" {
+		return fmt.Errorf("state not locked")
+	}
+
+	lockInfo := &state.LockInfo{}
+	if err := json.Unmarshal(pair.Value, lockInfo); err != nil {
+		return fmt.Errorf("Failed to decode lock info: %s", err)
+	}
+	if lockInfo.ID != id {
+		return &state.LockError{
+			Info: lockInfo,
+			Err:  fmt.Errorf("lock ID %q does not match the existing lock", id),
+		}
+	}
+
+	log.Printf("[DEBUG] Destroying the session %s locking remote state in Consul: %s",
+		pair.Session, c.lockPath())
+
+	if _, err := c.Client.Session().Destroy(pair.Session, nil); err != nil {
+		return fmt.Errorf("Failed to unlock remote state: %s", err)
+	}
+
+	return nil
+}
+
+func (c *ConsulClient) getLockInfo() (*state.LockInfo, error) {
+	pair, _, err := c.Client.KV().Get(c.lockPath(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if pair == nil {
+		return nil, fmt.Errorf("no lock info found for %s", c.lockPath())
+	}
+
+	lockInfo := &state.LockInfo{}
+	if err := json.Unmarshal(pair.Value, lockInfo); err != nil {
+		return nil, fmt.Errorf("Failed to decode lock info: %s", err)
+	}
+
+	return lockInfo, nil
+}
+
+func (c *ConsulClient) lockPath() string {
+	return c.Path + "/.lock"
+}
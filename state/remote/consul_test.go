@@ -2,10 +2,16 @@ package remote
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/state"
 )
 
 func TestConsulClient_impl(t *testing.T) {
@@ -25,3 +31,98 @@ func TestConsulClient(t *testing.T) {
 
 	testClient(t, client)
 }
+
+func TestConsulClient_lockerImpl(t *testing.T) {
+	var _ ClientLocker = new(ConsulClient)
+}
+
+func TestConsulFactory_datacenter(t *testing.T) {
+	var query url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	defer os.Setenv("CONSUL_HTTP_TOKEN", os.Getenv("CONSUL_HTTP_TOKEN"))
+	os.Setenv("CONSUL_HTTP_TOKEN", "env-token")
+
+	client, err := consulFactory(map[string]string{
+		"address":    strings.TrimPrefix(ts.URL, "http://"),
+		"datacenter": "dc2",
+		"path":       "tf-unit/foo",
+	})
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	payload, err := client.Get()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if payload != nil {
+		t.Fatalf("bad: %#v", payload)
+	}
+	if query.Get("dc") != "dc2" {
+		t.Fatalf("bad datacenter: %#v", query)
+	}
+	if query.Get("token") != "env-token" {
+		t.Fatalf("bad token: %#v", query)
+	}
+}
+
+func TestConsulClient_locks(t *testing.T) {
+	acctest.RemoteTestPrecheck(t)
+
+	path := fmt.Sprintf("tf-unit/%s", time.Now().String())
+	conf := map[string]string{
+		"address": "demo.consul.io:80",
+		"path":    path,
+	}
+
+	a, err := consulFactory(conf)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	b, err := consulFactory(conf)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	lockerA := a.(ClientLocker)
+	lockerB := b.(ClientLocker)
+
+	info := state.NewLockInfo("test")
+	id, err := lockerA.Lock(info)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, err = lockerB.Lock(state.NewLockInfo("test"))
+	lockErr, ok := err.(*state.LockError)
+	if !ok {
+		t.Fatalf("expected a *state.LockError, got: %#v", err)
+	}
+	if lockErr.Info == nil || lockErr.Info.ID != id {
+		t.Fatalf("bad lock info: %#v", lockErr.Info)
+	}
+
+	if err := lockerA.Unlock(id); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// A lock held by another client is released by destroying its session
+	id, err = lockerB.Lock(state.NewLockInfo("test"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := lockerA.Unlock("bad-id"); err == nil {
+		t.Fatal("should error")
+	}
+	if err := lockerA.Unlock(id); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := state.LockWithTimeout(lockerA, state.NewLockInfo("test"), 30*time.Second); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
@@ -2,6 +2,8 @@ package remote
 
 import (
 	"fmt"
+
+	"github.com/hashicorp/terraform/state"
 )
 
 // Client is the interface that must be implemented for a remote state
@@ -13,6 +15,20 @@ type Client interface {
 	Delete() error
 }
 
+// ClientLocker is implemented by the clients of remote state backends that
+// support locking the state.
+type ClientLocker interface {
+	Client
+	state.Locker
+}
+
+// ClientVersioner is implemented by the clients of remote state backends
+// that keep prior versions of the state, so they can be recovered.
+type ClientVersioner interface {
+	Client
+	GetVersion(id string) (*Payload, error)
+}
+
 // Payload is the return value from the remote state storage.
 type Payload struct {
 	MD5  []byte
@@ -2,6 +2,7 @@ package remote
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -11,10 +12,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-multierror"
 	terraformAws "github.com/hashicorp/terraform/builtin/providers/aws"
+	"github.com/hashicorp/terraform/state"
 )
 
 func s3Factory(conf map[string]string) (Client, error) {
@@ -58,6 +61,7 @@ func s3Factory(conf map[string]string) (Client, error) {
 		acl = raw
 	}
 	kmsKeyID := conf["kms_key_id"]
+	lockTable := conf["lock_table"]
 
 	var errs []error
 	creds := terraformAws.GetCredentials(conf["access_key"], conf["secret_key"], conf["token"], conf["profile"], conf["shared_credentials_file"])
@@ -83,64 +87,114 @@ providing credentials for the AWS S3 remote`))
 	}
 	sess := session.New(awsConfig)
 	nativeClient := s3.New(sess)
+	dynClient := dynamodb.New(sess)
 
 	return &S3Client{
 		nativeClient:         nativeClient,
+		dynClient:            dynClient,
 		bucketName:           bucketName,
 		keyName:              keyName,
 		serverSideEncryption: serverSideEncryption,
 		acl:                  acl,
 		kmsKeyID:             kmsKeyID,
+		lockTable:            lockTable,
 	}, nil
 }
 
 type S3Client struct {
 	nativeClient         *s3.S3
+	dynClient            *dynamodb.DynamoDB
 	bucketName           string
 	keyName              string
 	serverSideEncryption bool
 	acl                  string
 	kmsKeyID             string
+	lockTable            string
+
+	// read is set once the state was read or written, and etag and
+	// versionID then identify the object this client last saw. They're
+	// used to detect that someone else modified the state in between.
+	read      bool
+	etag      string
+	versionID string
 }
 
 func (c *S3Client) Get() (*Payload, error) {
-	output, err := c.nativeClient.GetObject(&s3.GetObjectInput{
+	payload, output, err := c.getObject("")
+	if err != nil {
+		return nil, err
+	}
+
+	c.read = true
+	c.etag, c.versionID = "", ""
+	if output != nil {
+		c.etag = aws.StringValue(output.ETag)
+		c.versionID = aws.StringValue(output.VersionId)
+		log.Printf("[DEBUG] Read remote state from S3: %s", s3ObjectDesc(c.etag, c.versionID))
+	}
+
+	return payload, nil
+}
+
+// GetVersion returns a prior version of the state. This requires
+// versioning to be enabled on the bucket.
+//
+// ClientVersioner impl.
+func (c *S3Client) GetVersion(versionID string) (*Payload, error) {
+	if versionID == "" {
+		return nil, fmt.Errorf("version ID must not be empty")
+	}
+
+	payload, output, err := c.getObject(versionID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read version %q of remote state: %s", versionID, err)
+	}
+	if output == nil || payload == nil {
+		return nil, fmt.Errorf("Version %q of remote state has no data", versionID)
+	}
+
+	return payload, nil
+}
+
+// getObject reads the state object, or the given version of it. If the
+// object doesn't exist, the output is nil. If it's empty, the payload is.
+func (c *S3Client) getObject(versionID string) (*Payload, *s3.GetObjectOutput, error) {
+	input := &s3.GetObjectInput{
 		Bucket: &c.bucketName,
 		Key:    &c.keyName,
-	})
+	}
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
 
+	output, err := c.nativeClient.GetObject(input)
 	if err != nil {
-		if awserr := err.(awserr.Error); awserr != nil {
-			if awserr.Code() == "NoSuchKey" {
-				return nil, nil
-			} else {
-				return nil, err
-			}
-		} else {
-			return nil, err
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchKey" {
+			return nil, nil, nil
 		}
+		return nil, nil, err
 	}
 
 	defer output.Body.Close()
 
 	buf := bytes.NewBuffer(nil)
 	if _, err := io.Copy(buf, output.Body); err != nil {
-		return nil, fmt.Errorf("Failed to read remote state: %s", err)
-	}
-
-	payload := &Payload{
-		Data: buf.Bytes(),
+		return nil, nil, fmt.Errorf("Failed to read remote state: %s", err)
 	}
 
-	// If there was no data, then return nil
-	if len(payload.Data) == 0 {
-		return nil, nil
+	// If there was no data, then return no payload
+	if buf.Len() == 0 {
+		return nil, output, nil
 	}
 
-	return payload, nil
+	return &Payload{Data: buf.Bytes()}, output, nil
 }
 
 func (c *S3Client) Put(data []byte) error {
+	if err := c.checkUnmodified(); err != nil {
+		return err
+	}
+
 	contentType := "application/json"
 	contentLength := int64(len(data))
 
@@ -167,10 +221,58 @@ func (c *S3Client) Put(data []byte) error {
 
 	log.Printf("[DEBUG] Uploading remote state to S3: %#v", i)
 
-	if _, err := c.nativeClient.PutObject(i); err == nil {
+	output, err := c.nativeClient.PutObject(i)
+	if err != nil {
+		return fmt.Errorf("Failed to upload state: %v", err)
+	}
+
+	c.read = true
+	c.etag = aws.StringValue(output.ETag)
+	c.versionID = aws.StringValue(output.VersionId)
+	return nil
+}
+
+// checkUnmodified returns an error if the state object changed since this
+// client last read or wrote it, for example because of a concurrent run
+// without a lock table. S3 can't make the upload itself conditional, so
+// this narrows the window for lost updates but doesn't close it.
+func (c *S3Client) checkUnmodified() error {
+	if !c.read {
 		return nil
+	}
+
+	var etag, versionID string
+	output, err := c.nativeClient.HeadObject(&s3.HeadObjectInput{
+		Bucket: &c.bucketName,
+		Key:    &c.keyName,
+	})
+	if err != nil {
+		awsErr, ok := err.(awserr.Error)
+		if !ok || (awsErr.Code() != "NotFound" && awsErr.Code() != "NoSuchKey") {
+			return fmt.Errorf("Failed to check remote state for changes: %s", err)
+		}
 	} else {
-		return fmt.Errorf("Failed to upload state: %v", err)
+		etag = aws.StringValue(output.ETag)
+		versionID = aws.StringValue(output.VersionId)
+	}
+
+	if etag == c.etag && versionID == c.versionID {
+		return nil
+	}
+
+	return fmt.Errorf(errS3StateModified,
+		s3ObjectDesc(c.etag, c.versionID), s3ObjectDesc(etag, versionID))
+}
+
+// s3ObjectDesc describes a state object for messages.
+func s3ObjectDesc(etag, versionID string) string {
+	switch {
+	case versionID != "":
+		return fmt.Sprintf("version %s", versionID)
+	case etag != "":
+		return fmt.Sprintf("ETag %s", etag)
+	default:
+		return "no state"
 	}
 }
 
@@ -179,6 +281,124 @@ func (c *S3Client) Delete() error {
 		Bucket: &c.bucketName,
 		Key:    &c.keyName,
 	})
+	if err != nil {
+		return err
+	}
+
+	c.read = false
+	c.etag, c.versionID = "", ""
+	return nil
+}
+
+// Lock locks the state with an item in the DynamoDB table set in
+// lock_table. The table must have a string hash key named LockID. Without
+// a lock table, the state isn't locked.
+func (c *S3Client) Lock(info *state.LockInfo) (string, error) {
+	if c.lockTable == "" {
+		return "", nil
+	}
+
+	info.Path = c.lockPath()
+
+	i := &dynamodb.PutItemInput{
+		Item: map[string]*dynamodb.AttributeValue{
+			"LockID": {S: aws.String(c.lockPath())},
+			"Info":   {S: aws.String(string(info.Marshal()))},
+		},
+		TableName:           aws.String(c.lockTable),
+		ConditionExpression: aws.String("attribute_not_exists(LockID)"),
+	}
+
+	log.Printf("[DEBUG] Locking remote state in DynamoDB table %s: %s", c.lockTable, c.lockPath())
+
+	if _, err := c.dynClient.PutItem(i); err != nil {
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "ConditionalCheckFailedException" {
+			return "", fmt.Errorf("Failed to lock remote state: %s", err)
+		}
+
+		lockInfo, infoErr := c.getLockInfo()
+		if infoErr != nil {
+			err = multierror.Append(err, infoErr)
+		}
+
+		return "", &state.LockError{
+			Info: lockInfo,
+			Err:  err,
+		}
+	}
+
+	return info.ID, nil
+}
+
+// Unlock releases the lock with the given ID.
+func (c *S3Client) Unlock(id string) error {
+	if c.lockTable == "" {
+		return nil
+	}
+
+	lockInfo, err := c.getLockInfo()
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve lock info: %s", err)
+	}
+
+	if lockInfo.ID != id {
+		return &state.LockError{
+			Info: lockInfo,
+			Err:  fmt.Errorf("lock ID %q does not match the existing lock", id),
+		}
+	}
+
+	log.Printf("[DEBUG] Unlocking remote state in DynamoDB table %s: %s", c.lockTable, c.lockPath())
+
+	_, err = c.dynClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockID": {S: aws.String(c.lockPath())},
+		},
+		TableName: aws.String(c.lockTable),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to unlock remote state: %s", err)
+	}
+
+	return nil
+}
+
+func (c *S3Client) getLockInfo() (*state.LockInfo, error) {
+	resp, err := c.dynClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockID": {S: aws.String(c.lockPath())},
+		},
+		ProjectionExpression: aws.String("LockID, Info"),
+		TableName:            aws.String(c.lockTable),
+		ConsistentRead:       aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := resp.Item["Info"]
+	if !ok || raw.S == nil {
+		return nil, fmt.Errorf("no lock info found for %s", c.lockPath())
+	}
+
+	lockInfo := &state.LockInfo{}
+	if err := json.Unmarshal([]byte(aws.StringValue(raw.S)), lockInfo); err != nil {
+		return nil, fmt.Errorf("Failed to decode lock info: %s", err)
+	}
+
+	return lockInfo, nil
+}
 
-	return err
+func (c *S3Client) lockPath() string {
+	return fmt.Sprintf("%s/%s", c.bucketName, c.keyName)
 }
+
+const errS3StateModified = `The remote state in S3 was modified since it was last read.
+
+Expected %s, but found %s. Another Terraform run probably
+wrote the state concurrently, and uploading the state now would overwrite
+its changes. Run the command again to work with the latest state.
+
+If versioning is enabled on the bucket, prior versions of the state can be
+recovered with "terraform state pull -version=ID". Set lock_table to
+prevent concurrent runs.`
@@ -1,12 +1,22 @@
 package remote
 
 import (
+	"crypto/md5"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform/state"
 )
 
 func TestS3Client_impl(t *testing.T) {
@@ -129,3 +139,168 @@ func TestS3Client(t *testing.T) {
 
 	testClient(t, client)
 }
+
+func TestS3Client_versionerImpl(t *testing.T) {
+	var _ ClientVersioner = new(S3Client)
+}
+
+func TestS3Client_concurrentModification(t *testing.T) {
+	srv := httptest.NewServer(new(testS3Server))
+	defer srv.Close()
+
+	c1 := testS3ClientServer(srv)
+	c2 := testS3ClientServer(srv)
+
+	// Both clients see that there is no state yet
+	for _, c := range []*S3Client{c1, c2} {
+		if p, err := c.Get(); err != nil || p != nil {
+			t.Fatalf("expected no state, got: %#v, %v", p, err)
+		}
+	}
+
+	if err := c1.Put([]byte("first")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The second client would overwrite the state written by the first
+	err := c2.Put([]byte("second"))
+	if err == nil || !strings.Contains(err.Error(), "modified since it was last read") {
+		t.Fatalf("expected modification error, got: %v", err)
+	}
+
+	// Once it read the latest state, it can write
+	p, err := c2.Get()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(p.Data) != "first" {
+		t.Fatalf("bad: %s", p.Data)
+	}
+	if err := c2.Put([]byte("second")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Consecutive writes by the same client are fine
+	if err := c2.Put([]byte("third")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := c1.Put([]byte("fourth")); err == nil {
+		t.Fatal("expected modification error")
+	}
+}
+
+func TestS3Client_getVersion(t *testing.T) {
+	srv := httptest.NewServer(new(testS3Server))
+	defer srv.Close()
+
+	c := testS3ClientServer(srv)
+	for _, data := range []string{"first", "second"} {
+		if _, err := c.Get(); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if err := c.Put([]byte(data)); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	p, err := c.GetVersion("1")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(p.Data) != "first" {
+		t.Fatalf("bad: %s", p.Data)
+	}
+
+	if _, err := c.GetVersion("3"); err == nil {
+		t.Fatal("expected error for unknown version")
+	}
+
+	// Reading a version doesn't change the version a write is checked
+	// against
+	if err := c.Put([]byte("third")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testS3ClientServer returns a client for a bucket on the given server.
+func testS3ClientServer(srv *httptest.Server) *S3Client {
+	sess := session.New(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials("key", "secret", ""),
+		Endpoint:         aws.String(srv.URL),
+		Region:           aws.String("us-east-1"),
+		S3ForcePathStyle: aws.Bool(true),
+		MaxRetries:       aws.Int(0),
+	})
+
+	return &S3Client{
+		nativeClient: s3.New(sess),
+		bucketName:   "bucket",
+		keyName:      "state",
+	}
+}
+
+// testS3Server is a minimal S3 with a single versioned object. The
+// version IDs count up from 1.
+type testS3Server struct {
+	versions [][]byte
+}
+
+func (s *testS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "PUT":
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s.versions = append(s.versions, data)
+		s.writeHeader(w, len(s.versions))
+		w.WriteHeader(http.StatusOK)
+
+	case "GET", "HEAD":
+		version := len(s.versions)
+		if raw := r.URL.Query().Get("versionId"); raw != "" {
+			version, _ = strconv.Atoi(raw)
+		}
+		if version < 1 || version > len(s.versions) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchKey</Code></Error>")
+			return
+		}
+
+		s.writeHeader(w, version)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == "GET" {
+			w.Write(s.versions[version-1])
+		}
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *testS3Server) writeHeader(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, md5.Sum(s.versions[version-1])))
+	w.Header().Set("x-amz-version-id", strconv.Itoa(version))
+}
+
+func TestS3Client_lockerImpl(t *testing.T) {
+	var _ ClientLocker = new(S3Client)
+}
+
+func TestS3Client_noLockTable(t *testing.T) {
+	// Without a lock table, locking is a noop and makes no requests.
+	c := &S3Client{bucketName: "foo", keyName: "bar"}
+
+	id, err := c.Lock(state.NewLockInfo("test"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if id != "" {
+		t.Fatalf("expected no lock ID, got: %s", id)
+	}
+	if err := c.Unlock(id); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
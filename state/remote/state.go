@@ -2,7 +2,9 @@ package remote
 
 import (
 	"bytes"
+	"fmt"
 
+	"github.com/hashicorp/terraform/state"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -34,16 +36,19 @@ func (s *State) RefreshState() error {
 		return err
 	}
 
-	var state *terraform.State
+	var result *terraform.State
 	if payload != nil {
-		state, err = terraform.ReadState(bytes.NewReader(payload.Data))
+		result, err = terraform.ReadState(bytes.NewReader(payload.Data))
 		if err != nil {
 			return err
 		}
+		if err := state.DecryptState(result); err != nil {
+			return err
+		}
 	}
 
-	s.state = state
-	s.readState = state
+	s.state = result
+	s.readState = result
 	return nil
 }
 
@@ -51,10 +56,61 @@ func (s *State) RefreshState() error {
 func (s *State) PersistState() error {
 	s.state.IncrementSerialMaybe(s.readState)
 
+	persisted, err := state.EncryptState(s.state)
+	if err != nil {
+		return err
+	}
+
 	var buf bytes.Buffer
-	if err := terraform.WriteState(s.state, &buf); err != nil {
+	if err := terraform.WriteState(persisted, &buf); err != nil {
 		return err
 	}
 
 	return s.Client.Put(buf.Bytes())
 }
+
+// StateVersion returns a prior version of the state, if the client keeps
+// versions of the state. The current state isn't changed.
+func (s *State) StateVersion(id string) (*terraform.State, error) {
+	c, ok := s.Client.(ClientVersioner)
+	if !ok {
+		return nil, fmt.Errorf("The remote state backend doesn't keep versions of the state")
+	}
+
+	payload, err := c.GetVersion(id)
+	if err != nil {
+		return nil, err
+	}
+	if payload == nil {
+		return nil, fmt.Errorf("Version %q of remote state has no data", id)
+	}
+
+	result, err := terraform.ReadState(bytes.NewReader(payload.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := state.DecryptState(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Lock locks the remote state, if the client supports locking.
+//
+// Locker impl.
+func (s *State) Lock(info *state.LockInfo) (string, error) {
+	if c, ok := s.Client.(ClientLocker); ok {
+		return c.Lock(info)
+	}
+	return "", nil
+}
+
+// Locker impl.
+func (s *State) Unlock(id string) error {
+	if c, ok := s.Client.(ClientLocker); ok {
+		return c.Unlock(id)
+	}
+	return nil
+}
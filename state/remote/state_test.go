@@ -1,9 +1,15 @@
 package remote
 
 import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestState(t *testing.T) {
@@ -19,9 +25,132 @@ func TestState(t *testing.T) {
 	state.TestState(t, s)
 }
 
+func TestState_encrypted(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	os.Setenv(state.EncryptionKeyEnvVar, key)
+	defer os.Unsetenv(state.EncryptionKeyEnvVar)
+
+	primary := &terraform.InstanceState{
+		ID:         "foo",
+		Attributes: map[string]string{"id": "foo", "password": "hunter2"},
+	}
+	primary.SetSensitiveAttributes([]string{"password"})
+
+	current := terraform.NewState()
+	current.RootModule().Resources["test_instance.foo"] = &terraform.ResourceState{
+		Type:    "test_instance",
+		Primary: primary,
+	}
+
+	client := new(InmemClient)
+	s := &State{Client: client}
+	if err := s.WriteState(current); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.PersistState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if bytes.Contains(client.Data, []byte("hunter2")) {
+		t.Fatalf("sensitive value stored in plaintext:\n%s", client.Data)
+	}
+
+	s = &State{Client: client}
+	if err := s.RefreshState(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !s.State().Equal(current) {
+		t.Fatalf("bad: %s", s.State())
+	}
+}
+
 func TestState_impl(t *testing.T) {
 	var _ state.StateReader = new(State)
 	var _ state.StateWriter = new(State)
 	var _ state.StatePersister = new(State)
 	var _ state.StateRefresher = new(State)
 }
+
+func TestState_lock(t *testing.T) {
+	client := new(InmemClient)
+	s := &State{Client: client}
+
+	var _ state.Locker = s
+
+	info := state.NewLockInfo("test")
+	id, err := s.Lock(info)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if id != info.ID {
+		t.Fatalf("bad lock ID: %s", id)
+	}
+
+	// A second lock must fail with the info of the first one
+	_, err = s.Lock(state.NewLockInfo("test"))
+	lockErr, ok := err.(*state.LockError)
+	if !ok {
+		t.Fatalf("expected a *state.LockError, got: %#v", err)
+	}
+	if lockErr.Info.ID != id {
+		t.Fatalf("expected the info of the existing lock, got: %#v", lockErr.Info)
+	}
+
+	if err := s.Unlock("wrong"); err == nil {
+		t.Fatal("expected an error unlocking with the wrong ID")
+	}
+	if err := s.Unlock(id); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.LockInfo != nil {
+		t.Fatal("expected the state to be unlocked")
+	}
+}
+
+func TestState_StateVersion(t *testing.T) {
+	var buf bytes.Buffer
+	if err := terraform.WriteState(state.TestStateInitial(), &buf); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client := &testVersionedClient{
+		Versions: map[string][]byte{"v1": buf.Bytes()},
+	}
+	s := &State{Client: client}
+
+	actual, err := s.StateVersion("v1")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !actual.Equal(state.TestStateInitial()) {
+		t.Fatalf("bad: %s", actual)
+	}
+	if s.State() != nil {
+		t.Fatalf("the current state should not change: %s", s.State())
+	}
+
+	if _, err := s.StateVersion("v2"); err == nil {
+		t.Fatal("expected error for unknown version")
+	}
+
+	// Clients without versions can't return one
+	s = &State{Client: new(InmemClient)}
+	if _, err := s.StateVersion("v1"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// testVersionedClient is an InmemClient that keeps prior versions.
+type testVersionedClient struct {
+	InmemClient
+
+	Versions map[string][]byte
+}
+
+func (c *testVersionedClient) GetVersion(id string) (*Payload, error) {
+	data, ok := c.Versions[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown version: %s", id)
+	}
+
+	return &Payload{Data: data}, nil
+}
@@ -47,6 +47,20 @@ type ContextOpts struct {
 	Targets            []string
 	Variables          map[string]string
 
+	// Workspace is the name of the workspace the context operates in,
+	// available to the configuration as "${terraform.workspace}". If it
+	// isn't set, it is "default".
+	Workspace string
+
+	// Replace are the addresses of the resources that a plan replaces,
+	// as if they were tainted.
+	Replace []string
+
+	// RefreshParallelism limits the number of concurrent operations while
+	// refreshing, independently from Parallelism. If it is zero, refreshing
+	// is limited by Parallelism as well.
+	RefreshParallelism int
+
 	UIInput UIInput
 }
 
@@ -63,15 +77,18 @@ type Context struct {
 	module       *module.Tree
 	providers    map[string]ResourceProviderFactory
 	provisioners map[string]ResourceProvisionerFactory
+	replace      []string
 	sh           *stopHook
 	state        *State
 	stateLock    sync.RWMutex
 	targets      []string
 	uiInput      UIInput
 	variables    map[string]string
+	workspace    string
 
 	l                   sync.Mutex // Lock acquired during any task
 	parallelSem         Semaphore
+	refreshSem          Semaphore
 	providerInputConfig map[string]map[string]interface{}
 	runCh               <-chan struct{}
 }
@@ -118,6 +135,10 @@ func NewContext(opts *ContextOpts) (*Context, error) {
 	if par == 0 {
 		par = 10
 	}
+	refreshPar := opts.RefreshParallelism
+	if refreshPar == 0 {
+		refreshPar = par
+	}
 
 	// Setup the variables. We first take the variables given to us.
 	// We then merge in the variables set in the environment.
@@ -146,12 +167,15 @@ func NewContext(opts *ContextOpts) (*Context, error) {
 		module:       opts.Module,
 		providers:    opts.Providers,
 		provisioners: opts.Provisioners,
+		replace:      opts.Replace,
 		state:        state,
 		targets:      opts.Targets,
 		uiInput:      opts.UIInput,
 		variables:    variables,
+		workspace:    opts.Workspace,
 
 		parallelSem:         NewSemaphore(par),
+		refreshSem:          NewSemaphore(refreshPar),
 		providerInputConfig: make(map[string]map[string]interface{}),
 		sh:                  sh,
 	}, nil
@@ -336,6 +360,21 @@ func (c *Context) Plan() (*Plan, error) {
 	v := c.acquireRun()
 	defer c.releaseRun(v)
 
+	// Taint the resources to replace in a copy of the state, so that they
+	// are only replaced by this plan.
+	if len(c.replace) > 0 {
+		state, err := replaceState(c.state, c.replace)
+		if err != nil {
+			return nil, err
+		}
+
+		old := c.state
+		c.state = state
+		defer func() {
+			c.state = old
+		}()
+	}
+
 	p := &Plan{
 		Module:  c.module,
 		Vars:    c.variables,
@@ -494,6 +533,29 @@ func (c *Context) Module() *module.Tree {
 	return c.module
 }
 
+// Interpolater returns an Interpolater built on a copy of the state of
+// the context, that can be used to evaluate interpolations against it
+// outside of a graph walk, e.g. by the console.
+func (c *Context) Interpolater() *Interpolater {
+	var varLock sync.Mutex
+	var stateLock sync.RWMutex
+
+	variables := make(map[string]interface{}, len(c.variables))
+	for k, v := range c.variables {
+		variables[k] = v
+	}
+
+	return &Interpolater{
+		Operation:          walkApply,
+		Module:             c.module,
+		State:              c.state.DeepCopy(),
+		StateLock:          &stateLock,
+		VariableValues:     variables,
+		VariableValuesLock: &varLock,
+		Workspace:          c.workspace,
+	}
+}
+
 // Variables will return the mapping of variables that were defined
 // for this Context. If Input was called, this mapping may be different
 // than what was given.
@@ -539,3 +601,47 @@ func (c *Context) walk(
 	walker := &ContextGraphWalker{Context: c, Operation: operation}
 	return walker, graph.Walk(walker)
 }
+
+// replaceState returns a copy of the state where the resources at the given
+// addresses are tainted.
+func replaceState(state *State, addrs []string) (*State, error) {
+	if state == nil {
+		state = &State{}
+		state.init()
+	}
+	state = state.DeepCopy()
+
+	filter := &StateFilter{State: state}
+	for _, addr := range addrs {
+		a, err := ParseResourceAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing address '%s': %s", addr, err)
+		}
+		if a.Type == "" || !a.Mode.Taintable() {
+			return nil, fmt.Errorf("Resource '%s' cannot be replaced", addr)
+		}
+
+		results, err := filter.Filter(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, r := range results {
+			rs, ok := r.Value.(*ResourceState)
+			if !ok || rs.Primary == nil {
+				continue
+			}
+
+			log.Printf("[INFO] Replacing %s", r.Address)
+			rs.Taint()
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf(
+				"Resource '%s' can't be replaced, it isn't in the state", addr)
+		}
+	}
+
+	return state, nil
+}
@@ -770,6 +770,61 @@ func TestContext2Plan_preventDestroy_bad(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_preventDestroy_ignoreChanges(t *testing.T) {
+	m := testModule(t, "plan-prevent-destroy-ignore-changes")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	h := new(MockHook)
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Hooks:  []Hook{h},
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		State: &State{
+			Modules: []*ModuleState{
+				&ModuleState{
+					Path: rootModulePath,
+					Resources: map[string]*ResourceState{
+						"aws_instance.foo": &ResourceState{
+							Type: "aws_instance",
+							Primary: &InstanceState{
+								ID: "i-abc123",
+								Attributes: map[string]string{
+									"require_new": "no",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	rd := plan.Diff.RootModule().Resources["aws_instance.foo"]
+	if rd == nil || rd.Destroy || rd.RequiresNew() {
+		t.Fatalf("bad: %s", plan)
+	}
+	if _, ok := rd.Attributes["require_new"]; ok {
+		t.Fatalf("bad: %s", plan)
+	}
+
+	if !h.PostIgnoreChangesCalled {
+		t.Fatal("should be called")
+	}
+	if h.PostIgnoreChangesInfo.HumanId() != "aws_instance.foo" {
+		t.Fatalf("bad: %#v", h.PostIgnoreChangesInfo)
+	}
+	if !reflect.DeepEqual(h.PostIgnoreChangesAttrs, []string{"require_new"}) {
+		t.Fatalf("bad: %#v", h.PostIgnoreChangesAttrs)
+	}
+}
+
 func TestContext2Plan_preventDestroy_good(t *testing.T) {
 	m := testModule(t, "plan-prevent-destroy-good")
 	p := testProvider("aws")
@@ -1003,6 +1058,32 @@ func TestContext2Plan_countVar(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_countConditional(t *testing.T) {
+	m := testModule(t, "plan-count-conditional")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Variables: map[string]string{
+			"env": "prod",
+		},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(plan.String())
+	expected := strings.TrimSpace(testTerraformPlanCountConditionalStr)
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestContext2Plan_countZero(t *testing.T) {
 	m := testModule(t, "plan-count-zero")
 	p := testProvider("aws")
@@ -1104,6 +1185,91 @@ func TestContext2Plan_countDecreaseToOne(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_forEach(t *testing.T) {
+	m := testModule(t, "plan-for-each")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	s := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Resources: map[string]*ResourceState{
+					`aws_instance.foo["200"]`: &ResourceState{
+						Type: "aws_instance",
+						Primary: &InstanceState{
+							ID: "bar",
+							Attributes: map[string]string{
+								"foo":  "vgw-b",
+								"num":  "200",
+								"type": "aws_instance",
+							},
+						},
+					},
+					`aws_instance.foo["300"]`: &ResourceState{
+						Type: "aws_instance",
+						Primary: &InstanceState{
+							ID: "baz",
+						},
+					},
+				},
+			},
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		State: s,
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(plan.String())
+	expected := strings.TrimSpace(testTerraformPlanForEachStr)
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestContext2Plan_forEachTargeted(t *testing.T) {
+	m := testModule(t, "plan-for-each")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Targets: []string{`aws_instance.foo["200"]`},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(plan.String())
+	expected := strings.TrimSpace(`
+DIFF:
+
+CREATE: aws_instance.foo["200"]
+  foo:  "" => "vgw-b"
+  num:  "" => "200"
+  type: "" => "aws_instance"
+
+STATE:
+
+<no state>
+`)
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestContext2Plan_countIncreaseFromNotSet(t *testing.T) {
 	m := testModule(t, "plan-count-inc")
 	p := testProvider("aws")
@@ -1488,6 +1654,41 @@ func TestContext2Plan_pathVar(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_terraformWorkspace(t *testing.T) {
+	cases := map[string]string{
+		"":     "default",
+		"prod": "prod",
+	}
+
+	for workspace, expected := range cases {
+		m := testModule(t, "plan-terraform-workspace")
+		p := testProvider("aws")
+		p.DiffFn = testDiffFn
+		ctx := testContext2(t, &ContextOpts{
+			Module: m,
+			Providers: map[string]ResourceProviderFactory{
+				"aws": testProviderFuncFixed(p),
+			},
+			Workspace: workspace,
+		})
+
+		plan, err := ctx.Plan()
+		if err != nil {
+			t.Fatalf("%q: err: %s", workspace, err)
+		}
+
+		for _, path := range [][]string{rootModulePath, []string{"root", "child"}} {
+			rd := plan.Diff.ModuleByPath(path).Resources["aws_instance.foo"]
+			if rd == nil {
+				t.Fatalf("%q: no diff for aws_instance.foo in %v", workspace, path)
+			}
+			if actual := rd.Attributes["workspace"].New; actual != expected {
+				t.Fatalf("%q: bad workspace in %v: %q", workspace, path, actual)
+			}
+		}
+	}
+}
+
 func TestContext2Plan_diffVar(t *testing.T) {
 	m := testModule(t, "plan-diffvar")
 	p := testProvider("aws")
@@ -1701,6 +1902,77 @@ func TestContext2Plan_taint(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_replace(t *testing.T) {
+	m := testModule(t, "plan-taint")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	s := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Resources: map[string]*ResourceState{
+					"aws_instance.foo": &ResourceState{
+						Type: "aws_instance",
+						Primary: &InstanceState{
+							ID:         "bar",
+							Attributes: map[string]string{"num": "2"},
+						},
+					},
+					"aws_instance.bar": &ResourceState{
+						Type: "aws_instance",
+						Primary: &InstanceState{
+							ID:         "baz",
+							Attributes: map[string]string{"foo": "2"},
+						},
+					},
+				},
+			},
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		State:   s,
+		Replace: []string{"aws_instance.bar"},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(plan.String())
+	expected := strings.TrimSpace(testTerraformPlanTaintStr)
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	// The resource is only replaced by the plan
+	rs := s.RootModule().Resources["aws_instance.bar"]
+	if rs.Primary == nil || len(rs.Tainted) > 0 {
+		t.Fatalf("bad: %#v", rs)
+	}
+}
+
+func TestContext2Plan_replaceMissing(t *testing.T) {
+	m := testModule(t, "plan-taint")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Replace: []string{"aws_instance.bar"},
+	})
+
+	if _, err := ctx.Plan(); err == nil {
+		t.Fatal("should error")
+	}
+}
+
 func TestContext2Plan_multiple_taint(t *testing.T) {
 	m := testModule(t, "plan-taint")
 	p := testProvider("aws")
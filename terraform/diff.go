@@ -273,6 +273,12 @@ type InstanceDiff struct {
 	Attributes     map[string]*ResourceAttrDiff
 	Destroy        bool
 	DestroyTainted bool
+
+	// Meta is a simple K/V map that is carried along with the diff to the
+	// provider's Apply but is otherwise ignored by Terraform. Providers use
+	// it to pass information from the configuration, such as operation
+	// timeouts, that isn't part of the resource's attributes.
+	Meta map[string]string
 }
 
 // ResourceAttrDiff is the diff of a single attribute of a resource.
@@ -283,6 +289,7 @@ type ResourceAttrDiff struct {
 	NewRemoved  bool        // True if this attribute is being removed
 	NewExtra    interface{} // Extra information for the provider
 	RequiresNew bool        // True if change requires new resource
+	Sensitive   bool        // True if the data should not be displayed in UI output
 	Type        DiffAttrType
 }
 
@@ -19,6 +19,12 @@ type EvalApply struct {
 	Output    **InstanceState
 	CreateNew *bool
 	Error     *error
+
+	// Tainted, if set, is set to true if creating a new instance failed
+	// after the provider obtained an ID for it, so that the instance is
+	// recorded in the state as tainted: it exists, and must be replaced
+	// rather than taken as fully created.
+	Tainted *bool
 }
 
 // TODO: test
@@ -85,6 +91,17 @@ func (n *EvalApply) Eval(ctx EvalContext) (interface{}, error) {
 		}
 	}
 
+	// An instance that was created, even partially, must stay in the
+	// state so that it isn't lost, but it can't be trusted either.
+	if err != nil && state.ID != "" && n.CreateNew != nil && *n.CreateNew {
+		log.Printf(
+			"[WARN] apply: %s: create failed after obtaining ID %q, marking as tainted",
+			n.Info.Id, state.ID)
+		if n.Tainted != nil {
+			*n.Tainted = true
+		}
+	}
+
 	// Write the final state
 	if n.Output != nil {
 		*n.Output = state
@@ -141,18 +158,24 @@ type EvalApplyProvisioners struct {
 	CreateNew      *bool
 	Tainted        *bool
 	Error          *error
+
+	// When is when the provisioners run: only those configured to run then
+	// are applied. Destroy provisioners run whenever they are evaluated,
+	// before the resource is destroyed.
+	When config.ProvisionerWhen
 }
 
 // TODO: test
 func (n *EvalApplyProvisioners) Eval(ctx EvalContext) (interface{}, error) {
 	state := *n.State
 
-	if !*n.CreateNew {
+	if n.When == config.ProvisionerWhenCreate && !*n.CreateNew {
 		// If we're not creating a new resource, then don't run provisioners
 		return nil, nil
 	}
 
-	if len(n.Resource.Provisioners) == 0 {
+	provs := n.filterProvisioners()
+	if len(provs) == 0 {
 		// We have no provisioners, so don't do anything
 		return nil, nil
 	}
@@ -179,7 +202,7 @@ func (n *EvalApplyProvisioners) Eval(ctx EvalContext) (interface{}, error) {
 
 	// If there are no errors, then we append it to our output error
 	// if we have one, otherwise we just output it.
-	err := n.apply(ctx)
+	err := n.apply(ctx, provs)
 	if n.Tainted != nil {
 		*n.Tainted = err != nil
 	}
@@ -204,7 +227,20 @@ func (n *EvalApplyProvisioners) Eval(ctx EvalContext) (interface{}, error) {
 	return nil, nil
 }
 
-func (n *EvalApplyProvisioners) apply(ctx EvalContext) error {
+// filterProvisioners returns the provisioners of the resource that run
+// at n.When.
+func (n *EvalApplyProvisioners) filterProvisioners() []*config.Provisioner {
+	var result []*config.Provisioner
+	for _, p := range n.Resource.Provisioners {
+		if p.When == n.When {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+func (n *EvalApplyProvisioners) apply(ctx EvalContext, provs []*config.Provisioner) error {
 	state := *n.State
 
 	// Store the original connection info, restore later
@@ -213,7 +249,7 @@ func (n *EvalApplyProvisioners) apply(ctx EvalContext) error {
 		state.Ephemeral.ConnInfo = origConnInfo
 	}()
 
-	for _, prov := range n.Resource.Provisioners {
+	for _, prov := range provs {
 		// Get the provisioner
 		provisioner := ctx.Provisioner(prov.Type)
 
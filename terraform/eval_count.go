@@ -13,6 +13,11 @@ type EvalCountFixZeroOneBoundary struct {
 
 // TODO: test
 func (n *EvalCountFixZeroOneBoundary) Eval(ctx EvalContext) (interface{}, error) {
+	// Instances of resources with for_each are always named by their key.
+	if n.Resource.RawForEach != nil {
+		return nil, nil
+	}
+
 	// Get the count, important for knowing whether we're supposed to
 	// be adding the zero, or trimming it.
 	count, err := n.Resource.Count()
@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform/config"
@@ -9,8 +10,10 @@ import (
 
 // EvalIgnoreChanges is an EvalNode implementation that removes diff
 // attributes if their name matches names provided by the resource's
-// IgnoreChanges lifecycle.
+// IgnoreChanges lifecycle. If Info is set, the PostIgnoreChanges hook is
+// called with the attributes whose changes were removed.
 type EvalIgnoreChanges struct {
+	Info          *InstanceInfo
 	Resource      *config.Resource
 	Diff          **InstanceDiff
 	WasChangeType *DiffChangeType
@@ -40,9 +43,13 @@ func (n *EvalIgnoreChanges) Eval(ctx EvalContext) (interface{}, error) {
 		return nil, nil
 	}
 
+	var ignored []string
 	for _, ignoredName := range ignoreChanges {
-		for name := range diff.Attributes {
+		for name, attr := range diff.Attributes {
 			if strings.HasPrefix(name, ignoredName) {
+				if !attr.Empty() {
+					ignored = append(ignored, name)
+				}
 				delete(diff.Attributes, name)
 			}
 		}
@@ -83,5 +90,15 @@ func (n *EvalIgnoreChanges) Eval(ctx EvalContext) (interface{}, error) {
 		}
 	}
 
+	if n.Info != nil && len(ignored) > 0 {
+		sort.Strings(ignored)
+		err := ctx.Hook(func(h Hook) (HookAction, error) {
+			return h.PostIgnoreChanges(n.Info, ignored)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, nil
 }
@@ -0,0 +1,48 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/config"
+)
+
+// EvalLocal is an EvalNode implementation that evaluates the value of
+// a local value and sets it in the state of the current module, so that
+// it can be interpolated.
+type EvalLocal struct {
+	Name  string
+	Value *config.RawConfig
+}
+
+func (n *EvalLocal) Eval(ctx EvalContext) (interface{}, error) {
+	cfg, err := ctx.Interpolate(n.Value, nil)
+	if err != nil {
+		return nil, fmt.Errorf("local.%s: %s", n.Name, err)
+	}
+
+	state, lock := ctx.State()
+	if state == nil {
+		return nil, fmt.Errorf("cannot write local value to nil state")
+	}
+
+	// Get a write lock so we can access the module state
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Look for the module state. If we don't have one, create it.
+	mod := state.ModuleByPath(ctx.Path())
+	if mod == nil {
+		mod = state.AddModule(ctx.Path())
+	}
+	if mod.Locals == nil {
+		mod.Locals = make(map[string]interface{})
+	}
+
+	var value interface{} = config.UnknownVariableValue
+	if !cfg.IsComputed("value") {
+		value, _ = cfg.Get("value")
+	}
+	mod.Locals[n.Name] = value
+
+	return nil, nil
+}
@@ -18,7 +18,7 @@ func (e *EvalValidateError) Error() string {
 }
 
 // EvalValidateCount is an EvalNode implementation that validates
-// the count of a resource.
+// the count, or the for_each, of a resource.
 type EvalValidateCount struct {
 	Resource *config.Resource
 }
@@ -41,6 +41,7 @@ func (n *EvalValidateCount) Eval(ctx EvalContext) (interface{}, error) {
 		c := n.Resource.RawCount.Config()
 		c[n.Resource.RawCount.Key] = "1"
 		count = 1
+		err = nil
 	}
 
 	if count < 0 {
@@ -48,6 +49,23 @@ func (n *EvalValidateCount) Eval(ctx EvalContext) (interface{}, error) {
 			"Count is less than zero: %d", count))
 	}
 
+	if n.Resource.RawForEach != nil {
+		if _, err := ctx.Interpolate(n.Resource.RawForEach, nil); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"Failed to interpolate for_each: %s", err))
+			goto RETURN
+		}
+
+		if _, err := n.Resource.ForEach(); err != nil {
+			// If we can't get the map during validation, such as when it
+			// is computed, then replace it with a single unknown entry.
+			c := n.Resource.RawForEach.Config()
+			c[n.Resource.RawForEach.Key] = map[string]interface{}{
+				config.UnknownVariableValue: config.UnknownVariableValue,
+			}
+		}
+	}
+
 RETURN:
 	if len(errs) != 0 {
 		err = &EvalValidateError{
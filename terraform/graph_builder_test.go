@@ -204,6 +204,25 @@ func TestBuiltinGraphBuilder_multiLevelModule(t *testing.T) {
 	}
 }
 
+func TestBuiltinGraphBuilder_moduleDependsOn(t *testing.T) {
+	b := &BuiltinGraphBuilder{
+		Root:      testModule(t, "graph-builder-module-depends-on"),
+		Providers: []string{"aws"},
+		Validate:  true,
+	}
+
+	g, err := b.Build(RootModulePath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(g.String())
+	expected := strings.TrimSpace(testBuiltinGraphBuilderModuleDependsOnStr)
+	if actual != expected {
+		t.Fatalf("bad: %s", actual)
+	}
+}
+
 func TestBuiltinGraphBuilder_orphanDeps(t *testing.T) {
 	state := &State{
 		Modules: []*ModuleState{
@@ -329,6 +348,53 @@ root
   module.foo.var.foo
 `
 
+const testBuiltinGraphBuilderModuleDependsOnStr = `
+aws_instance.web
+  provider.aws
+module.compute.aws_instance.app
+  aws_instance.web
+  module.compute.provider.aws
+  module.network.aws_instance.vgw
+module.compute.module.disk.aws_instance.disk
+  aws_instance.web
+  module.compute.module.disk.provider.aws
+  module.network.aws_instance.vgw
+module.compute.module.disk.plan-destroy
+module.compute.module.disk.provider.aws
+  module.compute.provider.aws
+module.compute.plan-destroy
+module.compute.provider.aws
+  provider.aws
+module.network.aws_instance.vgw
+  module.network.provider.aws
+module.network.plan-destroy
+module.network.provider.aws
+  provider.aws
+provider.aws
+provider.aws (close)
+  aws_instance.web
+  provider.aws
+provider.module.compute.aws (close)
+  module.compute.aws_instance.app
+  module.compute.provider.aws
+provider.module.compute.module.disk.aws (close)
+  module.compute.module.disk.aws_instance.disk
+  module.compute.module.disk.provider.aws
+provider.module.network.aws (close)
+  module.network.aws_instance.vgw
+  module.network.provider.aws
+root
+  module.compute.aws_instance.app
+  module.compute.module.disk.aws_instance.disk
+  module.compute.module.disk.plan-destroy
+  module.compute.plan-destroy
+  module.network.plan-destroy
+  provider.aws (close)
+  provider.module.compute.aws (close)
+  provider.module.compute.module.disk.aws (close)
+  provider.module.network.aws (close)
+`
+
 const testBuiltinGraphBuilderOrphanDepsStr = `
 aws_instance.bar (orphan)
   provider.aws
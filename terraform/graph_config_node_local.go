@@ -0,0 +1,97 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/dag"
+)
+
+// GraphNodeConfigLocal represents a local value configured within the
+// configuration.
+type GraphNodeConfigLocal struct {
+	Local *config.Local
+}
+
+func (n *GraphNodeConfigLocal) Name() string {
+	return fmt.Sprintf("local.%s", n.Local.Name)
+}
+
+func (n *GraphNodeConfigLocal) ConfigType() GraphNodeConfigType {
+	return GraphNodeConfigTypeLocal
+}
+
+func (n *GraphNodeConfigLocal) DependableName() []string {
+	return []string{n.Name()}
+}
+
+func (n *GraphNodeConfigLocal) DependentOn() []string {
+	vars := n.Local.RawConfig.Variables
+	result := make([]string, 0, len(vars))
+	for _, v := range vars {
+		if vn := varNameForVar(v); vn != "" {
+			result = append(result, vn)
+		}
+	}
+
+	return result
+}
+
+// GraphNodeEvalable impl.
+func (n *GraphNodeConfigLocal) EvalTree() EvalNode {
+	return &EvalOpFilter{
+		Ops: []walkOperation{
+			walkRefresh, walkPlan, walkPlanDestroy, walkApply, walkDestroy, walkImport},
+		Node: &EvalLocal{
+			Name:  n.Local.Name,
+			Value: n.Local.RawConfig,
+		},
+	}
+}
+
+// GraphNodeProxy impl.
+func (n *GraphNodeConfigLocal) Proxy() bool {
+	return true
+}
+
+// GraphNodeDestroyEdgeInclude impl.
+func (n *GraphNodeConfigLocal) DestroyEdgeInclude(dag.Vertex) bool {
+	return false
+}
+
+// GraphNodeFlattenable impl.
+func (n *GraphNodeConfigLocal) Flatten(p []string) (dag.Vertex, error) {
+	return &GraphNodeConfigLocalFlat{
+		GraphNodeConfigLocal: n,
+		PathValue:            p,
+	}, nil
+}
+
+// Same as GraphNodeConfigLocal, but for flattening
+type GraphNodeConfigLocalFlat struct {
+	*GraphNodeConfigLocal
+
+	PathValue []string
+}
+
+func (n *GraphNodeConfigLocalFlat) Name() string {
+	return fmt.Sprintf(
+		"%s.%s", modulePrefixStr(n.PathValue), n.GraphNodeConfigLocal.Name())
+}
+
+func (n *GraphNodeConfigLocalFlat) Path() []string {
+	return n.PathValue
+}
+
+func (n *GraphNodeConfigLocalFlat) DependableName() []string {
+	return modulePrefixList(
+		n.GraphNodeConfigLocal.DependableName(),
+		modulePrefixStr(n.PathValue))
+}
+
+func (n *GraphNodeConfigLocalFlat) DependentOn() []string {
+	prefix := modulePrefixStr(n.PathValue)
+	return modulePrefixList(
+		n.GraphNodeConfigLocal.DependentOn(),
+		prefix)
+}
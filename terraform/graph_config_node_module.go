@@ -15,6 +15,12 @@ type GraphNodeConfigModule struct {
 	Path   []string
 	Module *config.Module
 	Tree   *module.Tree
+
+	// DependsOn are the names of the resources that all the resources
+	// of the module depend on, from the depends_on of the module. A module
+	// in depends_on is replaced with the names of its resources, since the
+	// module isn't in the graph anymore once flattened.
+	DependsOn []string
 }
 
 func (n *GraphNodeConfigModule) ConfigType() GraphNodeConfigType {
@@ -35,7 +41,8 @@ func (n *GraphNodeConfigModule) DependableName() []string {
 
 func (n *GraphNodeConfigModule) DependentOn() []string {
 	vars := n.Module.RawConfig.Variables
-	result := make([]string, 0, len(vars))
+	result := make([]string, len(n.Module.DependsOn), len(vars)+len(n.Module.DependsOn))
+	copy(result, n.Module.DependsOn)
 	for _, v := range vars {
 		if vn := varNameForVar(v); vn != "" {
 			result = append(result, vn)
@@ -159,10 +166,29 @@ func (n *graphNodeModuleExpanded) FlattenGraph() *Graph {
 	graph := n.Subgraph()
 	input := n.Original.Module.RawConfig
 
+	// The dependencies of the module are in the module that contains it,
+	// so they are prefixed with its path rather than with ours.
+	var dependsOn []string
+	if len(n.Original.DependsOn) > 0 {
+		dependsOn = make([]string, len(n.Original.DependsOn))
+		copy(dependsOn, n.Original.DependsOn)
+		dependsOn = modulePrefixList(
+			dependsOn, modulePrefixStr(n.Original.Path[:len(n.Original.Path)-1]))
+	}
+
 	// Go over each vertex and do some modifications to the graph for
 	// flattening. We have to skip some nodes (graphNodeModuleSkippable)
 	// as well as setup the variable values.
 	for _, v := range graph.Vertices() {
+		// Make every resource in the module, including those of the modules
+		// within it, depend on the dependencies of the module.
+		switch rn := v.(type) {
+		case *GraphNodeConfigResource:
+			rn.ModuleDependsOn = append(rn.ModuleDependsOn, dependsOn...)
+		case *GraphNodeConfigResourceFlat:
+			rn.ModuleDependsOn = append(rn.ModuleDependsOn, dependsOn...)
+		}
+
 		// If this is a variable, then look it up in the raw configuration.
 		// If it exists in the raw configuration, set the value of it.
 		if vn, ok := v.(*GraphNodeConfigVariable); ok && input != nil {
@@ -193,6 +219,28 @@ func (n *graphNodeModuleExpanded) Subgraph() *Graph {
 	return n.Graph
 }
 
+// moduleResourceNames returns the names of all the resources of the given
+// module and of the modules within it, as they are named in the graph once
+// flattened, relative to the given prefix.
+func moduleResourceNames(prefix string, t *module.Tree) []string {
+	if t == nil {
+		return nil
+	}
+
+	var result []string
+	if c := t.Config(); c != nil {
+		for _, r := range c.Resources {
+			result = append(result, fmt.Sprintf("%s.%s", prefix, r.Id()))
+		}
+	}
+	for name, child := range t.Children() {
+		result = append(result, moduleResourceNames(
+			fmt.Sprintf("%s.module.%s", prefix, name), child)...)
+	}
+
+	return result
+}
+
 func modulePrefixStr(p []string) string {
 	parts := make([]string, 0, len(p)*2)
 	for _, p := range p[1:] {
@@ -27,6 +27,11 @@ type GraphNodeConfigResource struct {
 	// Used during DynamicExpand to target indexes
 	Targets []ResourceAddress
 
+	// ModuleDependsOn are the dependencies of the modules the resource is
+	// in, from their depends_on, prefixed with the path of the module they
+	// are in. They are only used once the resource is flattened.
+	ModuleDependsOn []string
+
 	Path []string
 }
 
@@ -62,10 +67,25 @@ func (n *GraphNodeConfigResource) CountDependentOn() []string {
 			result = append(result, vn)
 		}
 	}
+	for _, v := range n.forEachVariables() {
+		if vn := varNameForVar(v); vn != "" {
+			result = append(result, vn)
+		}
+	}
 
 	return result
 }
 
+// forEachVariables returns the variables of the for_each of the resource,
+// if any.
+func (n *GraphNodeConfigResource) forEachVariables() map[string]config.InterpolatedVariable {
+	if n.Resource.RawForEach == nil {
+		return nil
+	}
+
+	return n.Resource.RawForEach.Variables
+}
+
 // GraphNodeDependent impl.
 func (n *GraphNodeConfigResource) DependentOn() []string {
 	result := make([]string, len(n.Resource.DependsOn),
@@ -79,6 +99,11 @@ func (n *GraphNodeConfigResource) DependentOn() []string {
 			result = append(result, vn)
 		}
 	}
+	for _, v := range n.forEachVariables() {
+		if vn := varNameForVar(v); vn != "" {
+			result = append(result, vn)
+		}
+	}
 	for _, v := range n.Resource.RawConfig.Variables {
 		if vn := varNameForVar(v); vn != "" {
 			result = append(result, vn)
@@ -106,6 +131,9 @@ func (n *GraphNodeConfigResource) VarWalk(fn func(config.InterpolatedVariable))
 	for _, v := range n.Resource.RawCount.Variables {
 		fn(v)
 	}
+	for _, v := range n.forEachVariables() {
+		fn(v)
+	}
 	for _, v := range n.Resource.RawConfig.Variables {
 		fn(v)
 	}
@@ -230,16 +258,24 @@ func (n *GraphNodeConfigResource) SetTargets(targets []ResourceAddress) {
 
 // GraphNodeEvalable impl.
 func (n *GraphNodeConfigResource) EvalTree() EvalNode {
-	return &EvalSequence{
+	seq := &EvalSequence{
 		Nodes: []EvalNode{
 			&EvalInterpolate{Config: n.Resource.RawCount},
-			&EvalOpFilter{
-				Ops:  []walkOperation{walkValidate},
-				Node: &EvalValidateCount{Resource: n.Resource},
-			},
-			&EvalCountFixZeroOneBoundary{Resource: n.Resource},
 		},
 	}
+	if n.Resource.RawForEach != nil {
+		seq.Nodes = append(seq.Nodes, &EvalInterpolate{Config: n.Resource.RawForEach})
+	}
+
+	seq.Nodes = append(seq.Nodes,
+		&EvalOpFilter{
+			Ops:  []walkOperation{walkValidate},
+			Node: &EvalValidateCount{Resource: n.Resource},
+		},
+		&EvalCountFixZeroOneBoundary{Resource: n.Resource},
+	)
+
+	return seq
 }
 
 // GraphNodeProviderConsumer
@@ -295,6 +331,10 @@ func (n *GraphNodeConfigResource) Noop(opts *NoopOpts) bool {
 		log.Printf("[DEBUG] Count has interpolations, not a noop")
 		return false
 	}
+	if len(n.forEachVariables()) > 0 {
+		log.Printf("[DEBUG] for_each has interpolations, not a noop")
+		return false
+	}
 
 	// If we have no module diff, we're certainly a noop. This is because
 	// it means there is a diff, and that the module we're in just isn't
@@ -345,9 +385,12 @@ func (n *GraphNodeConfigResourceFlat) DependableName() []string {
 
 func (n *GraphNodeConfigResourceFlat) DependentOn() []string {
 	prefix := modulePrefixStr(n.PathValue)
-	return modulePrefixList(
+	result := modulePrefixList(
 		n.GraphNodeConfigResource.DependentOn(),
 		prefix)
+
+	// The dependencies of the modules we're in are already prefixed
+	return append(result, n.ModuleDependsOn...)
 }
 
 func (n *GraphNodeConfigResourceFlat) ProvidedBy() []string {
@@ -527,6 +570,11 @@ func (n *graphNodeResourceDestroy) destroyIncludePrimary(
 		return true
 	}
 
+	// The same goes for for_each, which always has dynamic instances.
+	if n.Original.Resource.RawForEach != nil {
+		return true
+	}
+
 	// Okay, we're dealing with a static count. There are a few ways
 	// to include this resource.
 	prefix := n.Original.Resource.Id()
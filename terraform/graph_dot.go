@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform/dag"
 	"github.com/hashicorp/terraform/dot"
@@ -33,6 +34,10 @@ type GraphDotOpts struct {
 
 	// How many levels to expand modules as we draw
 	MaxDepth int
+
+	// Diff, if set, is the planned diff. The nodes of resources are
+	// annotated with the action planned for them.
+	Diff *Diff
 }
 
 // GraphDot returns the dot formatting of a visual representation of
@@ -69,40 +74,32 @@ func graphDotSubgraph(
 		sg.AddAttr("label", modName)
 	}
 
-	origins, err := graphDotFindOrigins(g)
+	toDraw, drawableVertices, subgraphVertices, err := graphDotVertices(g, opts)
 	if err != nil {
 		return err
 	}
 
-	drawableVertices := make(map[dag.Vertex]struct{})
-	toDraw := make([]dag.Vertex, 0, len(g.Vertices()))
-	subgraphVertices := make(map[dag.Vertex]*Graph)
-
-	walk := func(v dag.Vertex, depth int) error {
-		// We only care about nodes that yield non-empty Dot strings.
-		if dn, ok := v.(GraphNodeDotter); !ok {
-			return nil
-		} else if dn.DotNode("fake", opts) == nil {
-			return nil
-		}
-
-		drawableVertices[v] = struct{}{}
-		toDraw = append(toDraw, v)
-
-		if sn, ok := v.(GraphNodeSubgraph); ok {
-			subgraphVertices[v] = sn.Subgraph()
+	// Find the edges of the cycles to highlight them, rather than drawing
+	// them again next to the edges of the graph.
+	cycleEdges := make(map[[2]dag.Vertex]string)
+	if opts.DrawCycles {
+		colors := []string{"red", "green", "blue"}
+		for ci, cycle := range g.Cycles() {
+			path := g.CyclePath(cycle)
+			for i := 0; i+1 < len(path); i++ {
+				cycleEdges[[2]dag.Vertex{path[i], path[i+1]}] = colors[ci%len(colors)]
+			}
 		}
-		return nil
-	}
-
-	if err := g.ReverseDepthFirstWalk(origins, walk); err != nil {
-		return err
 	}
 
 	for _, v := range toDraw {
 		dn := v.(GraphNodeDotter)
 		nodeName := graphDotNodeName(modName, v)
-		sg.AddNode(dn.DotNode(nodeName, opts))
+		node := dn.DotNode(nodeName, opts)
+		if action := graphNodeAction(g, v, opts.Diff); action != "" {
+			graphDotAnnotate(node, action)
+		}
+		sg.AddNode(node)
 
 		// Draw all the edges from this vertex to other nodes
 		targets := dag.AsVertexList(g.DownEdges(v))
@@ -113,15 +110,39 @@ func graphDotSubgraph(
 				continue
 			}
 
+			edgeAttrs := map[string]string{}
+			key := [2]dag.Vertex{v, target}
+			if color, ok := cycleEdges[key]; ok {
+				edgeAttrs["color"] = color
+				edgeAttrs["penwidth"] = "2.0"
+				delete(cycleEdges, key)
+			}
+
 			if err := sg.AddEdgeBetween(
 				graphDotNodeName(modName, v),
 				graphDotNodeName(modName, target),
-				map[string]string{}); err != nil {
+				edgeAttrs); err != nil {
 				return err
 			}
 		}
 	}
 
+	// Draw the edges of cycles that go through nodes that aren't drawn,
+	// so that the whole cycle shows.
+	for _, e := range graphDotSortedEdges(cycleEdges) {
+		edgeAttrs := map[string]string{
+			"color":    cycleEdges[e],
+			"penwidth": "2.0",
+		}
+
+		if err := sg.AddEdgeBetween(
+			graphDotNodeName(modName, e[0]),
+			graphDotNodeName(modName, e[1]),
+			edgeAttrs); err != nil {
+			return err
+		}
+	}
+
 	// Recurse into any subgraphs
 	for _, v := range toDraw {
 		subgraph, ok := subgraphVertices[v]
@@ -135,31 +156,147 @@ func graphDotSubgraph(
 		}
 	}
 
-	if opts.DrawCycles {
-		colors := []string{"red", "green", "blue"}
-		for ci, cycle := range g.Cycles() {
-			for i, c := range cycle {
-				// Catch the last wrapping edge of the cycle
-				if i+1 >= len(cycle) {
-					i = -1
-				}
-				edgeAttrs := map[string]string{
-					"color":    colors[ci%len(colors)],
-					"penwidth": "2.0",
-				}
-
-				if err := sg.AddEdgeBetween(
-					graphDotNodeName(modName, c),
-					graphDotNodeName(modName, cycle[i+1]),
-					edgeAttrs); err != nil {
-					return err
-				}
+	return nil
+}
 
-			}
+// graphDotVertices returns the vertices of the graph that are drawn, in
+// the order they are drawn in, along with the subgraphs of those that have
+// one.
+func graphDotVertices(g *Graph, opts *GraphDotOpts) (
+	[]dag.Vertex, map[dag.Vertex]struct{}, map[dag.Vertex]*Graph, error) {
+	origins, err := graphDotFindOrigins(g)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	drawableVertices := make(map[dag.Vertex]struct{})
+	toDraw := make([]dag.Vertex, 0, len(g.Vertices()))
+	subgraphVertices := make(map[dag.Vertex]*Graph)
+
+	walk := func(v dag.Vertex, depth int) error {
+		// We only care about nodes that yield non-empty Dot strings.
+		if dn, ok := v.(GraphNodeDotter); !ok {
+			return nil
+		} else if dn.DotNode("fake", opts) == nil {
+			return nil
 		}
+
+		drawableVertices[v] = struct{}{}
+		toDraw = append(toDraw, v)
+
+		if sn, ok := v.(GraphNodeSubgraph); ok {
+			subgraphVertices[v] = sn.Subgraph()
+		}
+		return nil
 	}
 
-	return nil
+	if err := g.ReverseDepthFirstWalk(origins, walk); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return toDraw, drawableVertices, subgraphVertices, nil
+}
+
+// graphNodeAction returns the action planned in the given diff for the
+// resource of the given vertex of the graph: "create", "update", "replace"
+// or "destroy". It returns "" if the vertex isn't a resource or if there is
+// no change planned for it.
+//
+// If several instances of the resource change, the most disruptive action
+// is returned.
+func graphNodeAction(g *Graph, v dag.Vertex, d *Diff) string {
+	if d == nil {
+		return ""
+	}
+
+	an, ok := v.(GraphNodeAddressable)
+	if !ok {
+		return ""
+	}
+
+	path := g.Path
+	if len(path) == 0 {
+		path = rootModulePath
+	}
+	md := d.ModuleByPath(path)
+	if md == nil {
+		return ""
+	}
+
+	addr := an.ResourceAddress()
+	key := &ResourceStateKey{
+		Name:  addr.Name,
+		Type:  addr.Type,
+		Mode:  addr.Mode,
+		Index: addr.Index,
+		Key:   addr.Key,
+	}
+
+	result := DiffNone
+	for _, id := range md.Instances(key.String()) {
+		if ct := id.ChangeType(); graphDiffChangeRank[ct] > graphDiffChangeRank[result] {
+			result = ct
+		}
+	}
+
+	return graphDiffChangeActions[result]
+}
+
+// graphDiffChangeRank ranks change types from the least to the most
+// disruptive.
+var graphDiffChangeRank = map[DiffChangeType]int{
+	DiffNone:          0,
+	DiffUpdate:        1,
+	DiffCreate:        2,
+	DiffDestroy:       3,
+	DiffDestroyCreate: 4,
+}
+
+var graphDiffChangeActions = map[DiffChangeType]string{
+	DiffCreate:        "create",
+	DiffUpdate:        "update",
+	DiffDestroy:       "destroy",
+	DiffDestroyCreate: "replace",
+}
+
+var graphDotActionColors = map[string]string{
+	"create":  "green",
+	"update":  "orange",
+	"destroy": "red",
+	"replace": "red",
+}
+
+// graphDotAnnotate adds the given planned action to the label of a node,
+// and colors it.
+func graphDotAnnotate(n *dot.Node, action string) {
+	label := n.Attrs["label"]
+	if label == "" {
+		label = n.Name
+	}
+
+	n.Attrs["label"] = fmt.Sprintf("%s (%s)", label, action)
+	n.Attrs["color"] = graphDotActionColors[action]
+	n.Attrs["fontcolor"] = graphDotActionColors[action]
+}
+
+// graphDotSortedEdges returns the given edges sorted by the names of their
+// vertices, so that the output is stable.
+func graphDotSortedEdges(edges map[[2]dag.Vertex]string) [][2]dag.Vertex {
+	byName := make(map[string][2]dag.Vertex, len(edges))
+	names := make([]string, 0, len(edges))
+	for e, _ := range edges {
+		name := dag.VertexName(e[0]) + " -> " + dag.VertexName(e[1])
+		byName[name] = e
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([][2]dag.Vertex, len(names))
+	for i, name := range names {
+		result[i] = byName[name]
+	}
+
+	return result
 }
 
 func graphDotNodeName(modName, v dag.Vertex) string {
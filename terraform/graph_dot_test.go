@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/dag"
 	"github.com/hashicorp/terraform/dot"
 )
 
@@ -97,13 +99,48 @@ digraph {
 		"[root] B"
 		"[root] C"
 		"[root] root"
-		"[root] A" -> "[root] B" [color = "red", penwidth = "2.0"]
-		"[root] A" -> "[root] C"
+		"[root] A" -> "[root] C" [color = "red", penwidth = "2.0"]
 		"[root] A" -> "[root] root"
-		"[root] B" -> "[root] A"
-		"[root] B" -> "[root] C" [color = "red", penwidth = "2.0"]
-		"[root] C" -> "[root] A" [color = "red", penwidth = "2.0"]
-		"[root] C" -> "[root] B"
+		"[root] B" -> "[root] A" [color = "red", penwidth = "2.0"]
+		"[root] C" -> "[root] B" [color = "red", penwidth = "2.0"]
+	}
+}
+			`,
+		},
+		"diff": {
+			Opts: GraphDotOpts{
+				Diff: &Diff{
+					Modules: []*ModuleDiff{
+						&ModuleDiff{
+							Path: rootModulePath,
+							Resources: map[string]*InstanceDiff{
+								"aws_instance.foo.0": &InstanceDiff{
+									Attributes: map[string]*ResourceAttrDiff{
+										"ami": &ResourceAttrDiff{Old: "a", New: "b"},
+									},
+								},
+								"aws_instance.foo.1": &InstanceDiff{
+									Destroy: true,
+									Attributes: map[string]*ResourceAttrDiff{
+										"ami": &ResourceAttrDiff{Old: "a", New: "b", RequiresNew: true},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Graph: testGraphDiff,
+			Expect: `
+digraph {
+	compound = "true"
+	newrank = "true"
+	subgraph "root" {
+		"[root] aws_instance.bar" [label = "aws_instance.bar", shape = "box"]
+		"[root] aws_instance.foo" [color = "red", fontcolor = "red", label = "aws_instance.foo (replace)", shape = "box"]
+		"[root] root"
+		"[root] aws_instance.bar" -> "[root] aws_instance.foo"
+		"[root] aws_instance.foo" -> "[root] root"
 	}
 }
 			`,
@@ -232,6 +269,38 @@ digraph {
 
 type testGraphFunc func() *Graph
 
+// testGraphDiff returns a graph with two resources, for testing the
+// annotation of resources with their planned action.
+func testGraphDiff() *Graph {
+	g := &Graph{Path: rootModulePath}
+	root := &testDrawableOrigin{"root"}
+	g.Add(root)
+
+	foo := &GraphNodeConfigResource{
+		Resource: &config.Resource{
+			Mode: config.ManagedResourceMode,
+			Type: "aws_instance",
+			Name: "foo",
+		},
+		Path: rootModulePath,
+	}
+	g.Add(foo)
+	g.Connect(dag.BasicEdge(foo, root))
+
+	bar := &GraphNodeConfigResource{
+		Resource: &config.Resource{
+			Mode: config.ManagedResourceMode,
+			Type: "aws_instance",
+			Name: "bar",
+		},
+		Path: rootModulePath,
+	}
+	g.Add(bar)
+	g.Connect(dag.BasicEdge(bar, foo))
+
+	return g
+}
+
 type testDrawable struct {
 	VertexName      string
 	DependentOnMock []string
@@ -0,0 +1,131 @@
+package terraform
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform/dag"
+)
+
+// graphJSON is the JSON representation of a graph.
+type graphJSON struct {
+	Nodes []*graphJSONNode `json:"nodes"`
+
+	// Edges go from a node to a node it depends on.
+	Edges []*graphJSONEdge `json:"edges"`
+
+	// Cycles are the paths around the cycles of the graph, from a node
+	// back to itself.
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+type graphJSONNode struct {
+	// ID is the name of the node in the DOT graph, unique across modules.
+	ID     string `json:"id"`
+	Module string `json:"module"`
+	Name   string `json:"name"`
+
+	// Action is the action planned for the resource of the node, if any.
+	Action string `json:"action,omitempty"`
+}
+
+type graphJSONEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// GraphJSON returns a JSON representation of the given Terraform graph,
+// with the same nodes and edges as GraphDot, for use by other tools.
+// DrawCycles is ignored: the cycles of the graph are always included.
+func GraphJSON(g *Graph, opts *GraphDotOpts) (string, error) {
+	var result graphJSON
+	if err := graphJSONSubgraph(&result, "root", g, opts, 0); err != nil {
+		return "", err
+	}
+
+	sort.Sort(graphJSONNodes(result.Nodes))
+	sort.Sort(graphJSONEdges(result.Edges))
+
+	data, err := json.MarshalIndent(&result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func graphJSONSubgraph(
+	result *graphJSON, modName string, g *Graph, opts *GraphDotOpts, modDepth int) error {
+	// Respect user-specified module depth
+	if opts.MaxDepth >= 0 && modDepth > opts.MaxDepth {
+		return nil
+	}
+
+	toDraw, drawableVertices, subgraphVertices, err := graphDotVertices(g, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range toDraw {
+		result.Nodes = append(result.Nodes, &graphJSONNode{
+			ID:     graphDotNodeName(modName, v),
+			Module: modName,
+			Name:   dag.VertexName(v),
+			Action: graphNodeAction(g, v, opts.Diff),
+		})
+
+		for _, t := range g.DownEdges(v).List() {
+			target := t.(dag.Vertex)
+			if _, ok := drawableVertices[target]; !ok {
+				continue
+			}
+
+			result.Edges = append(result.Edges, &graphJSONEdge{
+				Source: graphDotNodeName(modName, v),
+				Target: graphDotNodeName(modName, target),
+			})
+		}
+	}
+
+	for _, cycle := range g.Cycles() {
+		path := g.CyclePath(cycle)
+		ids := make([]string, len(path))
+		for i, v := range path {
+			ids[i] = graphDotNodeName(modName, v)
+		}
+		result.Cycles = append(result.Cycles, ids)
+	}
+
+	// Recurse into any subgraphs
+	for _, v := range toDraw {
+		subgraph, ok := subgraphVertices[v]
+		if !ok {
+			continue
+		}
+
+		err := graphJSONSubgraph(result, dag.VertexName(v), subgraph, opts, modDepth+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type graphJSONNodes []*graphJSONNode
+
+func (s graphJSONNodes) Len() int           { return len(s) }
+func (s graphJSONNodes) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s graphJSONNodes) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+type graphJSONEdges []*graphJSONEdge
+
+func (s graphJSONEdges) Len() int      { return len(s) }
+func (s graphJSONEdges) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s graphJSONEdges) Less(i, j int) bool {
+	if s[i].Source != s[j].Source {
+		return s[i].Source < s[j].Source
+	}
+
+	return s[i].Target < s[j].Target
+}
@@ -0,0 +1,100 @@
+package terraform
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGraphJSON(t *testing.T) {
+	var g Graph
+	g.Add(&testDrawableOrigin{"root"})
+	g.Add(&testDrawable{
+		VertexName:      "A",
+		DependentOnMock: []string{"root", "C"},
+	})
+	g.Add(&testDrawable{
+		VertexName:      "B",
+		DependentOnMock: []string{"A"},
+	})
+	g.Add(&testDrawable{
+		VertexName:      "C",
+		DependentOnMock: []string{"B"},
+	})
+	g.ConnectDependents()
+
+	raw, err := GraphJSON(&g, &GraphDotOpts{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var actual graphJSON
+	if err := json.Unmarshal([]byte(raw), &actual); err != nil {
+		t.Fatalf("err: %s\n\n%s", err, raw)
+	}
+
+	expectedNodes := []*graphJSONNode{
+		{ID: "[root] A", Module: "root", Name: "A"},
+		{ID: "[root] B", Module: "root", Name: "B"},
+		{ID: "[root] C", Module: "root", Name: "C"},
+		{ID: "[root] root", Module: "root", Name: "root"},
+	}
+	if !reflect.DeepEqual(actual.Nodes, expectedNodes) {
+		t.Fatalf("bad nodes:\n\n%s", raw)
+	}
+
+	expectedEdges := []*graphJSONEdge{
+		{Source: "[root] A", Target: "[root] C"},
+		{Source: "[root] A", Target: "[root] root"},
+		{Source: "[root] B", Target: "[root] A"},
+		{Source: "[root] C", Target: "[root] B"},
+	}
+	if !reflect.DeepEqual(actual.Edges, expectedEdges) {
+		t.Fatalf("bad edges:\n\n%s", raw)
+	}
+
+	if len(actual.Cycles) != 1 || len(actual.Cycles[0]) != 4 {
+		t.Fatalf("bad cycles:\n\n%s", raw)
+	}
+}
+
+func TestGraphJSON_diff(t *testing.T) {
+	d := &Diff{
+		Modules: []*ModuleDiff{
+			&ModuleDiff{
+				Path: rootModulePath,
+				Resources: map[string]*InstanceDiff{
+					"aws_instance.bar": &InstanceDiff{
+						Attributes: map[string]*ResourceAttrDiff{
+							"id": &ResourceAttrDiff{NewComputed: true, RequiresNew: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	raw, err := GraphJSON(testGraphDiff(), &GraphDotOpts{Diff: d})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var actual graphJSON
+	if err := json.Unmarshal([]byte(raw), &actual); err != nil {
+		t.Fatalf("err: %s\n\n%s", err, raw)
+	}
+
+	actions := make(map[string]string)
+	for _, n := range actual.Nodes {
+		actions[n.Name] = n.Action
+	}
+
+	expected := map[string]string{
+		"aws_instance.bar": "create",
+		"aws_instance.foo": "",
+		"root":             "",
+	}
+	if !reflect.DeepEqual(actions, expected) {
+		t.Fatalf("bad: %#v\n\n%s", actions, raw)
+	}
+}
@@ -87,6 +87,7 @@ func (w *ContextGraphWalker) EnterPath(path []string) EvalContext {
 			StateLock:          &w.Context.stateLock,
 			VariableValues:     variables,
 			VariableValuesLock: &w.interpolaterVarLock,
+			Workspace:          w.Context.workspace,
 		},
 		InterpolaterVars:    w.interpolaterVars,
 		InterpolaterVarLock: &w.interpolaterVarLock,
@@ -101,20 +102,30 @@ func (w *ContextGraphWalker) EnterEvalTree(v dag.Vertex, n EvalNode) EvalNode {
 		w.Operation, dag.VertexName(v))
 
 	// Acquire a lock on the semaphore
-	w.Context.parallelSem.Acquire()
+	w.sem().Acquire()
 
 	// We want to filter the evaluation tree to only include operations
 	// that belong in this operation.
 	return EvalFilter(n, EvalNodeFilterOp(w.Operation))
 }
 
+// sem returns the semaphore limiting the concurrent operations of the
+// walk. Refreshing has its own, so that it can be sized independently.
+func (w *ContextGraphWalker) sem() Semaphore {
+	if w.Operation == walkRefresh {
+		return w.Context.refreshSem
+	}
+
+	return w.Context.parallelSem
+}
+
 func (w *ContextGraphWalker) ExitEvalTree(
 	v dag.Vertex, output interface{}, err error) error {
 	log.Printf("[TRACE] [%s] Exiting eval tree: %s",
 		w.Operation, dag.VertexName(v))
 
 	// Release the semaphore
-	w.Context.parallelSem.Release()
+	w.sem().Release()
 
 	if err == nil {
 		return nil
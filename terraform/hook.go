@@ -32,6 +32,11 @@ type Hook interface {
 	PreDiff(*InstanceInfo, *InstanceState) (HookAction, error)
 	PostDiff(*InstanceInfo, *InstanceDiff) (HookAction, error)
 
+	// PostIgnoreChanges is called after changes to the given attributes
+	// of a single resource were removed from its diff because of its
+	// lifecycle.ignore_changes.
+	PostIgnoreChanges(*InstanceInfo, []string) (HookAction, error)
+
 	// Provisioning hooks
 	//
 	// All should be self-explanatory. ProvisionOutput is called with
@@ -80,6 +85,10 @@ func (*NilHook) PostDiff(*InstanceInfo, *InstanceDiff) (HookAction, error) {
 	return HookActionContinue, nil
 }
 
+func (*NilHook) PostIgnoreChanges(*InstanceInfo, []string) (HookAction, error) {
+	return HookActionContinue, nil
+}
+
 func (*NilHook) PreProvisionResource(*InstanceInfo, *InstanceState) (HookAction, error) {
 	return HookActionContinue, nil
 }
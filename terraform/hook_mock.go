@@ -29,6 +29,12 @@ type MockHook struct {
 	PostDiffReturn HookAction
 	PostDiffError  error
 
+	PostIgnoreChangesCalled bool
+	PostIgnoreChangesInfo   *InstanceInfo
+	PostIgnoreChangesAttrs  []string
+	PostIgnoreChangesReturn HookAction
+	PostIgnoreChangesError  error
+
 	PreProvisionResourceCalled bool
 	PreProvisionResourceInfo   *InstanceInfo
 	PreProvisionInstanceState  *InstanceState
@@ -118,6 +124,13 @@ func (h *MockHook) PostDiff(n *InstanceInfo, d *InstanceDiff) (HookAction, error
 	return h.PostDiffReturn, h.PostDiffError
 }
 
+func (h *MockHook) PostIgnoreChanges(n *InstanceInfo, attrs []string) (HookAction, error) {
+	h.PostIgnoreChangesCalled = true
+	h.PostIgnoreChangesInfo = n
+	h.PostIgnoreChangesAttrs = attrs
+	return h.PostIgnoreChangesReturn, h.PostIgnoreChangesError
+}
+
 func (h *MockHook) PreProvisionResource(n *InstanceInfo, s *InstanceState) (HookAction, error) {
 	h.PreProvisionResourceCalled = true
 	h.PreProvisionResourceInfo = n
@@ -26,6 +26,10 @@ func (h *stopHook) PostDiff(*InstanceInfo, *InstanceDiff) (HookAction, error) {
 	return h.hook()
 }
 
+func (h *stopHook) PostIgnoreChanges(*InstanceInfo, []string) (HookAction, error) {
+	return h.hook()
+}
+
 func (h *stopHook) PreProvisionResource(*InstanceInfo, *InstanceState) (HookAction, error) {
 	return h.hook()
 }
@@ -30,6 +30,10 @@ type Interpolater struct {
 	StateLock          *sync.RWMutex
 	VariableValues     map[string]interface{}
 	VariableValuesLock *sync.Mutex
+
+	// Workspace is the name of the current workspace, or empty for the
+	// default workspace.
+	Workspace string
 }
 
 // InterpolationScope is the current scope of execution. This is required
@@ -73,6 +77,10 @@ func (i *Interpolater) Values(
 		switch v := rawV.(type) {
 		case *config.CountVariable:
 			err = i.valueCountVar(scope, n, v, result)
+		case *config.EachVariable:
+			err = i.valueEachVar(scope, n, v, result)
+		case *config.LocalVariable:
+			err = i.valueLocalVar(scope, n, v, result)
 		case *config.ModuleVariable:
 			err = i.valueModuleVar(scope, n, v, result)
 		case *config.PathVariable:
@@ -83,6 +91,8 @@ func (i *Interpolater) Values(
 			err = i.valueSelfVar(scope, n, v, result)
 		case *config.SimpleVariable:
 			err = i.valueSimpleVar(scope, n, v, result)
+		case *config.TerraformVariable:
+			err = i.valueTerraformVar(scope, n, v, result)
 		case *config.UserVariable:
 			err = i.valueUserVar(scope, n, v, result)
 		default:
@@ -117,6 +127,34 @@ func (i *Interpolater) valueCountVar(
 	}
 }
 
+func (i *Interpolater) valueEachVar(
+	scope *InterpolationScope,
+	n string,
+	v *config.EachVariable,
+	result map[string]ast.Variable) error {
+	if scope.Resource == nil || scope.Resource.EachKey == "" {
+		return fmt.Errorf("%s: each variables are only valid within resources with for_each", n)
+	}
+
+	switch v.Type {
+	case config.EachValueKey:
+		result[n] = ast.Variable{
+			Value: scope.Resource.EachKey,
+			Type:  ast.TypeString,
+		}
+		return nil
+	case config.EachValueValue:
+		variable, err := hil.InterfaceToVariable(scope.Resource.EachValue)
+		if err != nil {
+			return fmt.Errorf("%s: %s", n, err)
+		}
+		result[n] = variable
+		return nil
+	default:
+		return fmt.Errorf("%s: unknown each type: %#v", n, v.Type)
+	}
+}
+
 func unknownVariable() ast.Variable {
 	return ast.Variable{
 		Type:  ast.TypeString,
@@ -124,6 +162,42 @@ func unknownVariable() ast.Variable {
 	}
 }
 
+func (i *Interpolater) valueLocalVar(
+	scope *InterpolationScope,
+	n string,
+	v *config.LocalVariable,
+	result map[string]ast.Variable) error {
+	// Local values aren't computed while validating
+	if i.Operation == walkValidate || i.State == nil || scope == nil {
+		result[n] = unknownVariable()
+		return nil
+	}
+
+	i.StateLock.RLock()
+	defer i.StateLock.RUnlock()
+
+	// The graph ordering ensures that the local value is set before it
+	// is used, unless it was pruned from the graph, such as with targets.
+	mod := i.State.ModuleByPath(scope.Path)
+	if mod == nil {
+		result[n] = unknownVariable()
+		return nil
+	}
+	val, ok := mod.Locals[v.Name]
+	if !ok {
+		result[n] = unknownVariable()
+		return nil
+	}
+
+	variable, err := hil.InterfaceToVariable(val)
+	if err != nil {
+		return fmt.Errorf("%s: %s", n, err)
+	}
+
+	result[n] = variable
+	return nil
+}
+
 func (i *Interpolater) valueModuleVar(
 	scope *InterpolationScope,
 	n string,
@@ -215,6 +289,28 @@ func (i *Interpolater) valuePathVar(
 
 }
 
+func (i *Interpolater) valueTerraformVar(
+	scope *InterpolationScope,
+	n string,
+	v *config.TerraformVariable,
+	result map[string]ast.Variable) error {
+	switch v.Type {
+	case config.TerraformValueWorkspace:
+		workspace := i.Workspace
+		if workspace == "" {
+			workspace = "default"
+		}
+
+		result[n] = ast.Variable{
+			Value: workspace,
+			Type:  ast.TypeString,
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s: unknown terraform variable: %s", n, v.FullKey())
+	}
+}
+
 func (i *Interpolater) valueResourceVar(
 	scope *InterpolationScope,
 	n string,
@@ -283,6 +379,7 @@ func (i *Interpolater) valueSelfVar(
 	if err != nil {
 		return err
 	}
+	rv.Key = scope.Resource.EachKey
 
 	return i.valueResourceVar(scope, n, rv, result)
 }
@@ -353,7 +450,9 @@ func (i *Interpolater) computeResourceVariable(
 	scope *InterpolationScope,
 	v *config.ResourceVariable) (*ast.Variable, error) {
 	id := v.ResourceId()
-	if v.Multi {
+	if v.Key != "" {
+		id = forEachStateId(id, v.Key)
+	} else if v.Multi {
 		id = fmt.Sprintf("%s.%d", id, v.Index)
 	}
 
@@ -478,8 +577,8 @@ func (i *Interpolater) computeResourceMultiVariable(
 		return nil, err
 	}
 
-	// Get the count so we know how many to iterate over
-	count, err := cr.Count()
+	// Get the state keys of the instances to iterate over
+	ids, err := resourceInstanceIds(cr)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"Error reading %s count: %s",
@@ -488,20 +587,12 @@ func (i *Interpolater) computeResourceMultiVariable(
 	}
 
 	// If we have no module in the state yet or count, return empty
-	if module == nil || len(module.Resources) == 0 || count == 0 {
+	if module == nil || len(module.Resources) == 0 || len(ids) == 0 {
 		return &ast.Variable{Type: ast.TypeString, Value: ""}, nil
 	}
 
 	var values []string
-	for j := 0; j < count; j++ {
-		id := fmt.Sprintf("%s.%d", v.ResourceId(), j)
-
-		// If we're dealing with only a single resource, then the
-		// ID doesn't have a trailing index.
-		if count == 1 {
-			id = v.ResourceId()
-		}
-
+	for _, id := range ids {
 		r, ok := module.Resources[id]
 		if !ok {
 			continue
@@ -571,6 +662,45 @@ func (i *Interpolater) computeResourceMultiVariable(
 	return &variable, err
 }
 
+// resourceInstanceIds returns the state keys of the instances of the given
+// resource, in order: by count index, or by key for resources with for_each.
+func resourceInstanceIds(r *config.Resource) ([]string, error) {
+	forEach, err := r.ForEach()
+	if err != nil {
+		return nil, err
+	}
+	if forEach != nil {
+		keys := make([]string, 0, len(forEach))
+		for k, _ := range forEach {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		ids := make([]string, len(keys))
+		for i, k := range keys {
+			ids[i] = forEachStateId(r.Id(), k)
+		}
+		return ids, nil
+	}
+
+	count, err := r.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	// If we're dealing with only a single resource, then the
+	// ID doesn't have a trailing index.
+	if count == 1 {
+		return []string{r.Id()}, nil
+	}
+
+	ids := make([]string, 0, count)
+	for j := 0; j < count; j++ {
+		ids = append(ids, fmt.Sprintf("%s.%d", r.Id(), j))
+	}
+	return ids, nil
+}
+
 func (i *Interpolater) interpolateComplexTypeAttribute(
 	resourceID string,
 	attributes map[string]string) (ast.Variable, error) {
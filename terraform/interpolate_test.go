@@ -93,6 +93,46 @@ func TestInterpolater_moduleVariable(t *testing.T) {
 	})
 }
 
+func TestInterpolater_localVal(t *testing.T) {
+	lock := new(sync.RWMutex)
+	state := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Locals: map[string]interface{}{
+					"foo": "bar",
+					"baz": []interface{}{"a", "b"},
+				},
+			},
+		},
+	}
+
+	i := &Interpolater{
+		Operation: walkApply,
+		State:     state,
+		StateLock: lock,
+	}
+
+	scope := &InterpolationScope{
+		Path: rootModulePath,
+	}
+
+	testInterpolate(t, i, scope, "local.foo", ast.Variable{
+		Value: "bar",
+		Type:  ast.TypeString,
+	})
+	testInterpolate(t, i, scope, "local.baz", ast.Variable{
+		Value: []ast.Variable{
+			{Value: "a", Type: ast.TypeString},
+			{Value: "b", Type: ast.TypeString},
+		},
+		Type: ast.TypeList,
+	})
+
+	// Local values that aren't set yet are unknown
+	testInterpolate(t, i, scope, "local.missing", unknownVariable())
+}
+
 func TestInterpolater_pathCwd(t *testing.T) {
 	i := &Interpolater{}
 	scope := &InterpolationScope{}
@@ -140,6 +180,21 @@ func TestInterpolater_pathRoot(t *testing.T) {
 	})
 }
 
+func TestInterpolater_terraformWorkspace(t *testing.T) {
+	scope := &InterpolationScope{}
+
+	testInterpolate(t, &Interpolater{}, scope, "terraform.workspace", ast.Variable{
+		Value: "default",
+		Type:  ast.TypeString,
+	})
+
+	i := &Interpolater{Workspace: "prod"}
+	testInterpolate(t, i, scope, "terraform.workspace", ast.Variable{
+		Value: "prod",
+		Type:  ast.TypeString,
+	})
+}
+
 func TestInterpolater_resourceVariable(t *testing.T) {
 	lock := new(sync.RWMutex)
 	state := &State{
@@ -0,0 +1,46 @@
+package terraform
+
+// ProviderSchema is the schema of a provider: of its own configuration and
+// of the configuration of its resources and data sources. It is exported
+// for tooling, such as editors validating configurations offline, and is
+// not used by Terraform itself.
+type ProviderSchema struct {
+	Provider    *ResourceSchema            `json:"provider"`
+	Resources   map[string]*ResourceSchema `json:"resources"`
+	DataSources map[string]*ResourceSchema `json:"data_sources"`
+}
+
+// ResourceSchema is the schema of the configuration of a provider, of a
+// resource or of a data source, or of a nested block within one.
+type ResourceSchema struct {
+	Attributes map[string]*AttributeSchema `json:"attributes"`
+}
+
+// AttributeSchema is the schema of a single attribute.
+type AttributeSchema struct {
+	// Type is one of "string", "int", "float", "bool", "list", "set",
+	// "map" or "object".
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+
+	Required  bool `json:"required,omitempty"`
+	Optional  bool `json:"optional,omitempty"`
+	Computed  bool `json:"computed,omitempty"`
+	ForceNew  bool `json:"force_new,omitempty"`
+	Sensitive bool `json:"sensitive,omitempty"`
+
+	// Deprecated and Removed are the messages shown when the attribute
+	// is set, if it's deprecated or has been removed.
+	Deprecated string `json:"deprecated,omitempty"`
+	Removed    string `json:"removed,omitempty"`
+
+	ConflictsWith []string `json:"conflicts_with,omitempty"`
+	MaxItems      int      `json:"max_items,omitempty"`
+
+	// Elem is the schema of the elements of a list, set or map of
+	// primitive values, and Block that of the elements of a list or set
+	// of nested blocks, or of the single nested block of an object. At
+	// most one of them is set.
+	Elem  *AttributeSchema `json:"elem,omitempty"`
+	Block *ResourceSchema  `json:"block,omitempty"`
+}
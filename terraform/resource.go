@@ -31,6 +31,11 @@ type Resource struct {
 	Type       string
 	CountIndex int
 
+	// EachKey and EachValue are the key and value of the for_each entry
+	// of the instance, for resources with for_each.
+	EachKey   string
+	EachValue interface{}
+
 	// These aren't really used anymore anywhere, but we keep them around
 	// since we haven't done a proper cleanup yet.
 	Id           string
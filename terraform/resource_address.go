@@ -20,6 +20,10 @@ type ResourceAddress struct {
 	// Addresses a specific resource that occurs in a list
 	Index int
 
+	// Addresses a specific instance of a resource with for_each. If it is
+	// set, Index is -1.
+	Key string
+
 	InstanceType    InstanceType
 	InstanceTypeSet bool
 	Name            string
@@ -32,6 +36,7 @@ func (r *ResourceAddress) Copy() *ResourceAddress {
 	n := &ResourceAddress{
 		Path:         make([]string, 0, len(r.Path)),
 		Index:        r.Index,
+		Key:          r.Key,
 		InstanceType: r.InstanceType,
 		Name:         r.Name,
 		Type:         r.Type,
@@ -76,7 +81,9 @@ func (r *ResourceAddress) String() string {
 			}
 		}
 
-		if r.Index >= 0 {
+		if r.Key != "" {
+			name += fmt.Sprintf("[%s]", strconv.Quote(r.Key))
+		} else if r.Index >= 0 {
 			name += fmt.Sprintf("[%d]", r.Index)
 		}
 		result = append(result, name)
@@ -98,6 +105,13 @@ func ParseResourceAddress(s string) (*ResourceAddress, error) {
 	if err != nil {
 		return nil, err
 	}
+	var resourceKey string
+	if matches["key"] != "" {
+		resourceKey, err = strconv.Unquote(matches["key"])
+		if err != nil {
+			return nil, fmt.Errorf("Problem parsing address key: %q", s)
+		}
+	}
 	instanceType, err := ParseInstanceType(matches["instance_type"])
 	if err != nil {
 		return nil, err
@@ -112,6 +126,7 @@ func ParseResourceAddress(s string) (*ResourceAddress, error) {
 	return &ResourceAddress{
 		Path:            path,
 		Index:           resourceIndex,
+		Key:             resourceKey,
 		InstanceType:    instanceType,
 		InstanceTypeSet: matches["instance_type"] != "",
 		Name:            matches["name"],
@@ -129,9 +144,9 @@ func (addr *ResourceAddress) Equals(raw interface{}) bool {
 	pathMatch := len(addr.Path) == 0 && len(other.Path) == 0 ||
 		reflect.DeepEqual(addr.Path, other.Path)
 
-	indexMatch := addr.Index == -1 ||
-		other.Index == -1 ||
-		addr.Index == other.Index
+	indexMatch := addr.Index == -1 && addr.Key == "" ||
+		other.Index == -1 && other.Key == "" ||
+		addr.Index == other.Index && addr.Key == other.Key
 
 	nameMatch := addr.Name == "" ||
 		other.Name == "" ||
@@ -203,8 +218,8 @@ func tokenizeResourceAddress(s string) (map[string]string, error) {
 		`(?:(?P<type>[^.]+)\.(?P<name>[^.[]+))?` +
 		// "tainted" (optional, omission implies: "primary")
 		`(?:\.(?P<instance_type>\w+))?` +
-		// "1" (optional, omission implies: "0")
-		`(?:\[(?P<index>\d+)\])?` +
+		// "1" or `"key"` (optional, omission implies: "0")
+		`(?:\[(?:(?P<index>\d+)|(?P<key>"(?:[^"\\]|\\.)*"))\])?` +
 		`\z`)
 	groupNames := re.SubexpNames()
 	rawMatches := re.FindAllStringSubmatch(s, -1)
@@ -46,6 +46,18 @@ func TestParseResourceAddress(t *testing.T) {
 			},
 			"",
 		},
+		"implicit primary, explicit key": {
+			`aws_instance.foo["vlan.100"]`,
+			&ResourceAddress{
+				Mode:         config.ManagedResourceMode,
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+				Key:          "vlan.100",
+			},
+			"",
+		},
 		"implicit primary, explicit index over ten": {
 			"aws_instance.foo[12]",
 			&ResourceAddress{
@@ -190,6 +202,80 @@ func TestResourceAddressEquals(t *testing.T) {
 		Other   interface{}
 		Expect  bool
 	}{
+		"key match": {
+			Address: &ResourceAddress{
+				Mode:         config.ManagedResourceMode,
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+				Key:          "bar",
+			},
+			Other: &ResourceAddress{
+				Mode:         config.ManagedResourceMode,
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+				Key:          "bar",
+			},
+			Expect: true,
+		},
+		"key mismatch": {
+			Address: &ResourceAddress{
+				Mode:         config.ManagedResourceMode,
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+				Key:          "bar",
+			},
+			Other: &ResourceAddress{
+				Mode:         config.ManagedResourceMode,
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+				Key:          "baz",
+			},
+			Expect: false,
+		},
+		"key and index mismatch": {
+			Address: &ResourceAddress{
+				Mode:         config.ManagedResourceMode,
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+				Key:          "bar",
+			},
+			Other: &ResourceAddress{
+				Mode:         config.ManagedResourceMode,
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        0,
+			},
+			Expect: false,
+		},
+		"key wildcard": {
+			Address: &ResourceAddress{
+				Mode:         config.ManagedResourceMode,
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+			},
+			Other: &ResourceAddress{
+				Mode:         config.ManagedResourceMode,
+				Type:         "aws_instance",
+				Name:         "foo",
+				InstanceType: TypePrimary,
+				Index:        -1,
+				Key:          "bar",
+			},
+			Expect: true,
+		},
 		"basic match": {
 			Address: &ResourceAddress{
 				Mode:         config.ManagedResourceMode,
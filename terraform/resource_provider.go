@@ -41,6 +41,10 @@ type ResourceProvider interface {
 	// knows how to manage.
 	Resources() []ResourceType
 
+	// GetSchema returns the schema of the provider, its resources and its
+	// data sources, for tooling. See ProviderSchema.
+	GetSchema() (*ProviderSchema, error)
+
 	/*********************************************************************
 	* Functions related to individual resources
 	*********************************************************************/
@@ -44,6 +44,9 @@ type MockResourceProvider struct {
 	RefreshReturnError             error
 	ResourcesCalled                bool
 	ResourcesReturn                []ResourceType
+	GetSchemaCalled                bool
+	GetSchemaReturn                *ProviderSchema
+	GetSchemaReturnError           error
 	ReadDataApplyCalled            bool
 	ReadDataApplyInfo              *InstanceInfo
 	ReadDataApplyDiff              *InstanceDiff
@@ -203,6 +206,14 @@ func (p *MockResourceProvider) Resources() []ResourceType {
 	return p.ResourcesReturn
 }
 
+func (p *MockResourceProvider) GetSchema() (*ProviderSchema, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.GetSchemaCalled = true
+	return p.GetSchemaReturn, p.GetSchemaReturnError
+}
+
 func (p *MockResourceProvider) ImportState(info *InstanceInfo, id string) ([]*InstanceState, error) {
 	p.Lock()
 	defer p.Unlock()
@@ -624,6 +624,11 @@ type ModuleState struct {
 	// This allows operators to inspect values at the boundaries.
 	Outputs map[string]*OutputState `json:"outputs"`
 
+	// Locals are the local values of the module, set while walking the
+	// graph so that they can be interpolated. They're recomputed on every
+	// walk, so they aren't saved with the state.
+	Locals map[string]interface{} `json:"-"`
+
 	// Resources is a mapping of the logically named resource to
 	// the state of the resource. Each resource may actually have
 	// N instances underneath, although a user only needs to think
@@ -712,7 +717,7 @@ func (m *ModuleState) Orphans(c *config.Config) []string {
 			delete(keys, r.Id())
 
 			for k, _ := range keys {
-				if strings.HasPrefix(k, r.Id()+".") {
+				if strings.HasPrefix(k, r.Id()+".") || strings.HasPrefix(k, r.Id()+"[") {
 					delete(keys, k)
 				}
 			}
@@ -735,7 +740,7 @@ func (m *ModuleState) View(id string) *ModuleState {
 
 	r := m.deepcopy()
 	for k, _ := range r.Resources {
-		if id == k || strings.HasPrefix(k, id+".") {
+		if id == k || strings.HasPrefix(k, id+".") || strings.HasPrefix(k, id+"[") {
 			continue
 		}
 
@@ -772,6 +777,12 @@ func (m *ModuleState) deepcopy() *ModuleState {
 	for k, v := range m.Resources {
 		n.Resources[k] = v.deepcopy()
 	}
+	if m.Locals != nil {
+		n.Locals = make(map[string]interface{}, len(m.Locals))
+		for k, v := range m.Locals {
+			n.Locals[k] = v
+		}
+	}
 	return n
 }
 
@@ -921,6 +932,10 @@ type ResourceStateKey struct {
 	Type  string
 	Mode  config.ResourceMode
 	Index int
+
+	// Key is the key of an instance of a resource with for_each. If it is
+	// set, Index is -1.
+	Key string
 }
 
 // Equal determines whether two ResourceStateKeys are the same
@@ -940,6 +955,9 @@ func (rsk *ResourceStateKey) Equal(other *ResourceStateKey) bool {
 	if rsk.Index != other.Index {
 		return false
 	}
+	if rsk.Key != other.Key {
+		return false
+	}
 	return true
 }
 
@@ -956,18 +974,39 @@ func (rsk *ResourceStateKey) String() string {
 	default:
 		panic(fmt.Errorf("unknown resource mode %s", rsk.Mode))
 	}
+	if rsk.Key != "" {
+		return forEachStateId(fmt.Sprintf("%s%s.%s", prefix, rsk.Type, rsk.Name), rsk.Key)
+	}
 	if rsk.Index == -1 {
 		return fmt.Sprintf("%s%s.%s", prefix, rsk.Type, rsk.Name)
 	}
 	return fmt.Sprintf("%s%s.%s.%d", prefix, rsk.Type, rsk.Name, rsk.Index)
 }
 
+// forEachStateId returns the state key of the instance with the given key
+// of a resource with for_each, such as `aws_instance.foo["bar"]`.
+func forEachStateId(id, key string) string {
+	return fmt.Sprintf("%s[%s]", id, strconv.Quote(key))
+}
+
 // ParseResourceStateKey accepts a key in the format used by
 // ModuleState.Resources and returns a resource name and resource index. In the
 // state, a resource has the format "type.name.index" or "type.name". In the
-// latter case, the index is returned as -1.
+// latter case, the index is returned as -1. Instances of resources with
+// for_each have the format `type.name["key"]`, and are returned with their
+// key and an index of -1.
 func ParseResourceStateKey(k string) (*ResourceStateKey, error) {
-	parts := strings.Split(k, ".")
+	id, key := k, ""
+	if idx := strings.Index(k, "["); idx >= 0 && strings.HasSuffix(k, "]") {
+		var err error
+		key, err = strconv.Unquote(k[idx+1 : len(k)-1])
+		if err != nil {
+			return nil, fmt.Errorf("Malformed resource state key: %s", k)
+		}
+		id = k[:idx]
+	}
+
+	parts := strings.Split(id, ".")
 	mode := config.ManagedResourceMode
 	if len(parts) > 0 && parts[0] == "data" {
 		mode = config.DataResourceMode
@@ -983,8 +1022,13 @@ func ParseResourceStateKey(k string) (*ResourceStateKey, error) {
 		Type:  parts[0],
 		Name:  parts[1],
 		Index: -1,
+		Key:   key,
 	}
 	if len(parts) == 3 {
+		if key != "" {
+			return nil, fmt.Errorf("Malformed resource state key: %s", k)
+		}
+
 		index, err := strconv.Atoi(parts[2])
 		if err != nil {
 			return nil, fmt.Errorf("Malformed resource state key index: %s", k)
@@ -1261,7 +1305,8 @@ type InstanceState struct {
 
 	// Meta is a simple K/V map that is persisted to the State but otherwise
 	// ignored by Terraform core. It's meant to be used for accounting by
-	// external client code.
+	// external client code. The state storage reads the
+	// SensitiveAttributesMetaKey entry to encrypt secrets.
 	Meta map[string]string `json:"meta,omitempty"`
 }
 
@@ -1384,6 +1429,46 @@ func (s *InstanceState) MergeDiff(d *InstanceDiff) *InstanceState {
 	return result
 }
 
+// SensitiveAttributesMetaKey is the Meta key under which providers list the
+// attributes of an instance that hold secrets, so that they can be
+// encrypted when the state is persisted.
+const SensitiveAttributesMetaKey = "sensitive_attributes"
+
+// SensitiveAttributes returns the keys of the attributes listed as
+// sensitive in the Meta of the instance.
+func (s *InstanceState) SensitiveAttributes() []string {
+	if s == nil || s.Meta[SensitiveAttributesMetaKey] == "" {
+		return nil
+	}
+
+	var keys []string
+	if err := json.Unmarshal([]byte(s.Meta[SensitiveAttributesMetaKey]), &keys); err != nil {
+		return nil
+	}
+	return keys
+}
+
+// SetSensitiveAttributes lists the given attribute keys as sensitive in the
+// Meta of the instance, replacing any keys listed before.
+func (s *InstanceState) SetSensitiveAttributes(keys []string) {
+	if len(keys) == 0 {
+		delete(s.Meta, SensitiveAttributesMetaKey)
+		return
+	}
+
+	sorted := make([]string, len(keys))
+	copy(sorted, keys)
+	sort.Strings(sorted)
+
+	// A slice of strings always encodes
+	raw, _ := json.Marshal(sorted)
+
+	if s.Meta == nil {
+		s.Meta = make(map[string]string)
+	}
+	s.Meta[SensitiveAttributesMetaKey] = string(raw)
+}
+
 func (i *InstanceState) GoString() string {
 	return fmt.Sprintf("*%#v", *i)
 }
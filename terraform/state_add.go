@@ -90,6 +90,7 @@ func stateAddFunc_Module_Module(s *State, fromAddr, addr *ResourceAddress, raw i
 		addrCopy.Type = resourceKey.Type
 		addrCopy.Name = resourceKey.Name
 		addrCopy.Index = resourceKey.Index
+		addrCopy.Key = resourceKey.Key
 
 		// Perform an add
 		if err := s.Add(fromAddr.String(), addrCopy.String(), v); err != nil {
@@ -264,6 +265,7 @@ func stateAddInitAddr(s *State, addr *ResourceAddress) (interface{}, bool) {
 		Name:  addr.Name,
 		Type:  addr.Type,
 		Index: addr.Index,
+		Key:   addr.Key,
 	}).String()
 	exists = true
 	resource, ok := mod.Resources[resourceKey]
@@ -1566,6 +1566,24 @@ func TestParseResourceStateKey(t *testing.T) {
 				Index: -1,
 			},
 		},
+		{
+			Input: `aws_instance.foo["vlan.100"]`,
+			Expected: &ResourceStateKey{
+				Mode:  config.ManagedResourceMode,
+				Type:  "aws_instance",
+				Name:  "foo",
+				Index: -1,
+				Key:   "vlan.100",
+			},
+		},
+		{
+			Input:       `aws_instance.foo.0["bar"]`,
+			ExpectedErr: true,
+		},
+		{
+			Input:       `aws_instance.foo[bar]`,
+			ExpectedErr: true,
+		},
 		{
 			Input:       "aws_instance.foo.malformed",
 			ExpectedErr: true,
@@ -1584,6 +1602,9 @@ func TestParseResourceStateKey(t *testing.T) {
 		if rsk != nil && tc.Expected != nil && !rsk.Equal(tc.Expected) {
 			t.Fatalf("%s: expected %s, got %s", tc.Input, tc.Expected, rsk)
 		}
+		if rsk != nil && rsk.String() != tc.Input {
+			t.Fatalf("%s: bad string: %s", tc.Input, rsk)
+		}
 		if (err != nil) != tc.ExpectedErr {
 			t.Fatalf("%s: expected err: %t, got %s", tc.Input, tc.ExpectedErr, err)
 		}
@@ -233,6 +233,26 @@ aws_instance.foo:
   num = 2
 `
 
+const testTerraformApplyForEachStr = `
+aws_instance.bar:
+  ID = foo
+  foo = 100,200
+  type = aws_instance
+
+  Dependencies:
+    aws_instance.foo
+aws_instance.foo["100"]:
+  ID = foo
+  foo = vgw-a
+  num = 100
+  type = aws_instance
+aws_instance.foo["200"]:
+  ID = bar
+  foo = vgw-b
+  num = 200
+  type = aws_instance
+`
+
 const testTerraformApplyComputeStr = `
 aws_instance.bar:
   ID = foo
@@ -408,6 +428,12 @@ aws_instance.foo:
   type = aws_instance
 `
 
+const testTerraformApplyFailCreateStr = `
+aws_instance.bar: (1 tainted)
+  ID = <not created>
+  Tainted ID 1 = foo
+`
+
 const testTerraformApplyProvisionerFailCreateStr = `
 aws_instance.bar: (1 tainted)
   ID = <not created>
@@ -471,8 +497,9 @@ module.child.subchild:
 `
 
 const testTerraformApplyErrorStr = `
-aws_instance.bar:
-  ID = bar
+aws_instance.bar: (1 tainted)
+  ID = <not created>
+  Tainted ID 1 = bar
 
   Dependencies:
     aws_instance.foo
@@ -572,6 +599,28 @@ firstOutput = foo0
 secondOutput = foo1
 `
 
+const testTerraformApplyLocalsStr = `
+aws_instance.bar:
+  ID = foo
+  foo = foo-bar
+  type = aws_instance
+aws_instance.foo:
+  ID = foo
+  foo = 169.254.0.1/30,169.254.0.2/30
+  type = aws_instance
+
+Outputs:
+
+amazon_address = 169.254.0.1/30
+child_name = child
+
+module.child:
+  <no state>
+  Outputs:
+
+  name = child
+`
+
 const testTerraformApplyOutputListStr = `
 aws_instance.bar.0:
   ID = foo
@@ -642,10 +691,9 @@ foo_num = bar
 `
 
 const testTerraformApplyUnknownAttrStr = `
-aws_instance.foo:
-  ID = foo
-  num = 2
-  type = aws_instance
+aws_instance.foo: (1 tainted)
+  ID = <not created>
+  Tainted ID 1 = foo
 `
 
 const testTerraformApplyVarsStr = `
@@ -813,6 +861,21 @@ STATE:
 <no state>
 `
 
+const testTerraformPlanCountConditionalStr = `
+DIFF:
+
+CREATE: aws_instance.foo.0
+  foo:  "" => "large"
+  type: "" => "aws_instance"
+CREATE: aws_instance.foo.1
+  foo:  "" => "large"
+  type: "" => "aws_instance"
+
+STATE:
+
+<no state>
+`
+
 const testTerraformPlanCountVarStr = `
 DIFF:
 
@@ -855,6 +918,29 @@ aws_instance.foo.2:
   ID = bar
 `
 
+const testTerraformPlanForEachStr = `
+DIFF:
+
+CREATE: aws_instance.bar
+  foo:  "" => "100,200"
+  type: "" => "aws_instance"
+CREATE: aws_instance.foo["100"]
+  foo:  "" => "vgw-a"
+  num:  "" => "100"
+  type: "" => "aws_instance"
+DESTROY: aws_instance.foo["300"]
+
+STATE:
+
+aws_instance.foo["200"]:
+  ID = bar
+  foo = vgw-b
+  num = 200
+  type = aws_instance
+aws_instance.foo["300"]:
+  ID = baz
+`
+
 const testTerraformPlanCountIncreaseStr = `
 DIFF:
 
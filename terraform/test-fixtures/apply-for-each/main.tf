@@ -0,0 +1,17 @@
+variable "vlans" {
+    type = "map"
+    default = {
+        "100" = "vgw-a"
+        "200" = "vgw-b"
+    }
+}
+
+resource "aws_instance" "foo" {
+    for_each = "${var.vlans}"
+    num = "${each.key}"
+    foo = "${each.value}"
+}
+
+resource "aws_instance" "bar" {
+    foo = "${join(",", aws_instance.foo.*.num)}"
+}
@@ -0,0 +1,7 @@
+locals {
+  name = "child"
+}
+
+output "name" {
+  value = "${local.name}"
+}
@@ -0,0 +1,33 @@
+variable "prefix" {
+  default = "169.254.0.0/30"
+}
+
+locals {
+  amazon_address   = "${cidrhost(var.prefix, 1)}/30"
+  customer_address = "${cidrhost(var.prefix, 2)}/30"
+}
+
+locals {
+  addresses = "${local.amazon_address},${local.customer_address}"
+  bar_foo   = "${aws_instance.foo.id}-bar"
+}
+
+resource "aws_instance" "foo" {
+  foo = "${local.addresses}"
+}
+
+resource "aws_instance" "bar" {
+  foo = "${local.bar_foo}"
+}
+
+module "child" {
+  source = "./child"
+}
+
+output "amazon_address" {
+  value = "${local.amazon_address}"
+}
+
+output "child_name" {
+  value = "${module.child.name}"
+}
@@ -0,0 +1,17 @@
+variable "vifs" {
+    type = "list"
+}
+
+variable "peers" {
+    type = "map"
+}
+
+resource "aws_instance" "vif" {
+    count = "${length(var.vifs)}"
+    vlan = "${lookup(var.vifs[count.index], "vlan")}"
+    asn = "${lookup(var.peers["primary"], "asn")}"
+}
+
+output "vifs_out" {
+    value = "${var.vifs}"
+}
@@ -0,0 +1,5 @@
+resource "aws_instance" "app" {}
+
+module "disk" {
+    source = "./disk"
+}
@@ -0,0 +1,10 @@
+resource "aws_instance" "web" {}
+
+module "network" {
+    source = "./network"
+}
+
+module "compute" {
+    source = "./compute"
+    depends_on = ["module.network", "aws_instance.web"]
+}
@@ -0,0 +1 @@
+resource "aws_instance" "vgw" {}
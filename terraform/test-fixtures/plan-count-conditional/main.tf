@@ -0,0 +1,6 @@
+variable "env" {}
+
+resource "aws_instance" "foo" {
+    count = "${var.env == "prod" ? 2 : 1}"
+    foo = "${var.env == "prod" ? "large" : "small"}"
+}
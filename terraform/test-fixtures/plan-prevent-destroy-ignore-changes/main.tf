@@ -0,0 +1,8 @@
+resource "aws_instance" "foo" {
+  require_new = "yes"
+
+  lifecycle {
+    prevent_destroy = true
+    ignore_changes = ["require_new"]
+  }
+}
@@ -0,0 +1,7 @@
+resource "aws_instance" "foo" {
+    workspace = "${terraform.workspace}"
+}
+
+module "child" {
+    source = "./child"
+}
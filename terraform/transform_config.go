@@ -3,6 +3,7 @@ package terraform
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform/config"
@@ -72,13 +73,29 @@ func (t *ConfigTransformer) Transform(g *Graph) error {
 		copy(path, g.Path)
 		path = append(path, m.Name)
 
+		// Depending on a module means depending on all its resources
+		var dependsOn []string
+		for _, d := range m.DependsOn {
+			dependsOn = append(dependsOn, d)
+			if strings.HasPrefix(d, "module.") {
+				dependsOn = append(dependsOn, moduleResourceNames(
+					d, children[d[len("module."):]])...)
+			}
+		}
+
 		nodes = append(nodes, &GraphNodeConfigModule{
-			Path:   path,
-			Module: m,
-			Tree:   children[m.Name],
+			Path:      path,
+			Module:    m,
+			Tree:      children[m.Name],
+			DependsOn: dependsOn,
 		})
 	}
 
+	// Write all the local values out
+	for _, l := range config.Locals {
+		nodes = append(nodes, &GraphNodeConfigLocal{Local: l})
+	}
+
 	// Write all the outputs out
 	for _, o := range config.Outputs {
 		nodes = append(nodes, &GraphNodeConfigOutput{Output: o})
@@ -111,6 +128,8 @@ func (t *ConfigTransformer) Transform(g *Graph) error {
 // graph to build the graph edges.
 func varNameForVar(raw config.InterpolatedVariable) string {
 	switch v := raw.(type) {
+	case *config.LocalVariable:
+		return fmt.Sprintf("local.%s", v.Name)
 	case *config.ModuleVariable:
 		return fmt.Sprintf("module.%s.output.%s", v.Name, v.Field)
 	case *config.ResourceVariable:
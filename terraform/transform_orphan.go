@@ -171,6 +171,7 @@ func (n *graphNodeOrphanResource) ConfigType() GraphNodeConfigType {
 func (n *graphNodeOrphanResource) ResourceAddress() *ResourceAddress {
 	return &ResourceAddress{
 		Index:        n.ResourceKey.Index,
+		Key:          n.ResourceKey.Key,
 		InstanceType: TypePrimary,
 		Name:         n.ResourceKey.Name,
 		Path:         n.Path[1:],
@@ -96,7 +96,6 @@ func (t *ProviderTransformer) Transform(g *Graph) error {
 			for _, p := range pv.ProvidedBy() {
 				target := m[providerMapKey(p, pv)]
 				if target == nil {
-					println(fmt.Sprintf("%#v\n\n%#v", m, providerMapKey(p, pv)))
 					err = multierror.Append(err, fmt.Errorf(
 						"%s: provider %s couldn't be found",
 						dag.VertexName(v), p))
@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform/config"
@@ -17,38 +18,21 @@ type ResourceCountTransformer struct {
 }
 
 func (t *ResourceCountTransformer) Transform(g *Graph) error {
-	// Expand the resource count
-	count, err := t.Resource.Count()
+	expanded, err := t.expand(g)
 	if err != nil {
 		return err
 	}
 
-	// Don't allow the count to be negative
-	if count < 0 {
-		return fmt.Errorf("negative count: %d", count)
-	}
-
-	// For each count, build and add the node
-	nodes := make([]dag.Vertex, 0, count)
-	for i := 0; i < count; i++ {
-		// Set the index. If our count is 1 we special case it so that
-		// we handle the "resource.0" and "resource" boundary properly.
-		index := i
-		if count == 1 {
-			index = -1
-		}
-
+	// For each instance, build and add the node
+	nodes := make([]dag.Vertex, 0, len(expanded))
+	for _, n := range expanded {
 		// Save the node for later so we can do connections. Make the
 		// proper node depending on if we're just a destroy node or if
 		// were a regular node.
-		var node dag.Vertex = &graphNodeExpandedResource{
-			Index:    index,
-			Resource: t.Resource,
-			Path:     g.Path,
-		}
+		var node dag.Vertex = n
 		if t.Destroy {
 			node = &graphNodeExpandedResourceDestroy{
-				graphNodeExpandedResource: node.(*graphNodeExpandedResource),
+				graphNodeExpandedResource: n,
 			}
 		}
 
@@ -72,6 +56,69 @@ func (t *ResourceCountTransformer) Transform(g *Graph) error {
 	return nil
 }
 
+// expand returns the nodes of the instances of the resource, one per
+// count index, or one per key for resources with for_each.
+func (t *ResourceCountTransformer) expand(g *Graph) ([]*graphNodeExpandedResource, error) {
+	// Expand the keys of for_each, in order
+	forEach, err := t.Resource.ForEach()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", t.Resource.Id(), err)
+	}
+	if forEach != nil {
+		keys := make([]string, 0, len(forEach))
+		for k, _ := range forEach {
+			if k == "" {
+				return nil, fmt.Errorf(
+					"%s: for_each keys can't be empty", t.Resource.Id())
+			}
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		nodes := make([]*graphNodeExpandedResource, 0, len(keys))
+		for _, k := range keys {
+			nodes = append(nodes, &graphNodeExpandedResource{
+				Index:    -1,
+				Key:      k,
+				Value:    forEach[k],
+				Resource: t.Resource,
+				Path:     g.Path,
+			})
+		}
+
+		return nodes, nil
+	}
+
+	// Expand the resource count
+	count, err := t.Resource.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't allow the count to be negative
+	if count < 0 {
+		return nil, fmt.Errorf("negative count: %d", count)
+	}
+
+	nodes := make([]*graphNodeExpandedResource, 0, count)
+	for i := 0; i < count; i++ {
+		// Set the index. If our count is 1 we special case it so that
+		// we handle the "resource.0" and "resource" boundary properly.
+		index := i
+		if count == 1 {
+			index = -1
+		}
+
+		nodes = append(nodes, &graphNodeExpandedResource{
+			Index:    index,
+			Resource: t.Resource,
+			Path:     g.Path,
+		})
+	}
+
+	return nodes, nil
+}
+
 func (t *ResourceCountTransformer) nodeIsTargeted(node dag.Vertex) bool {
 	// no targets specified, everything stays in the graph
 	if len(t.Targets) == 0 {
@@ -95,9 +142,17 @@ type graphNodeExpandedResource struct {
 	Index    int
 	Resource *config.Resource
 	Path     []string
+
+	// Key and Value are the key and value of the for_each entry of this
+	// instance, if the resource has for_each.
+	Key   string
+	Value interface{}
 }
 
 func (n *graphNodeExpandedResource) Name() string {
+	if n.Key != "" {
+		return n.stateId()
+	}
 	if n.Index == -1 {
 		return n.Resource.Id()
 	}
@@ -110,12 +165,13 @@ func (n *graphNodeExpandedResource) ResourceAddress() *ResourceAddress {
 	// We want this to report the logical index properly, so we must undo the
 	// special case from the expand
 	index := n.Index
-	if index == -1 {
+	if index == -1 && n.Key == "" {
 		index = 0
 	}
 	return &ResourceAddress{
 		Path:         n.Path[1:],
 		Index:        index,
+		Key:          n.Key,
 		InstanceType: TypePrimary,
 		Name:         n.Resource.Name,
 		Type:         n.Resource.Type,
@@ -177,8 +233,8 @@ func (n *graphNodeExpandedResource) StateDependencies() []string {
 	depsRaw := n.DependentOn()
 	deps := make([]string, 0, len(depsRaw))
 	for _, d := range depsRaw {
-		// Ignore any variable dependencies
-		if strings.HasPrefix(d, "var.") {
+		// Ignore any variable and local value dependencies
+		if strings.HasPrefix(d, "var.") || strings.HasPrefix(d, "local.") {
 			continue
 		}
 
@@ -209,6 +265,8 @@ func (n *graphNodeExpandedResource) EvalTree() EvalNode {
 		Name:       n.Resource.Name,
 		Type:       n.Resource.Type,
 		CountIndex: index,
+		EachKey:    n.Key,
+		EachValue:  n.Value,
 	}
 
 	seq := &EvalSequence{Nodes: make([]EvalNode, 0, 5)}
@@ -340,11 +398,15 @@ func (n *graphNodeExpandedResource) managedResourceEvalNodes(resource *Resource,
 					Output:      &diff,
 					OutputState: &state,
 				},
-				&EvalCheckPreventDestroy{
+				// Ignore changes before checking prevent_destroy, so
+				// that ignored changes that would force a new resource
+				// don't make the plan fail.
+				&EvalIgnoreChanges{
+					Info:     info,
 					Resource: n.Resource,
 					Diff:     &diff,
 				},
-				&EvalIgnoreChanges{
+				&EvalCheckPreventDestroy{
 					Resource: n.Resource,
 					Diff:     &diff,
 				},
@@ -501,6 +563,7 @@ func (n *graphNodeExpandedResource) managedResourceEvalNodes(resource *Resource,
 					Output:    &state,
 					Error:     &err,
 					CreateNew: &createNew,
+					Tainted:   &tainted,
 				},
 				&EvalWriteState{
 					Name:         n.stateId(),
@@ -851,6 +914,9 @@ func (n *graphNodeExpandedResource) instanceInfo() *InstanceInfo {
 
 // stateId is the name used for the state key
 func (n *graphNodeExpandedResource) stateId() string {
+	if n.Key != "" {
+		return forEachStateId(n.Resource.Id(), n.Key)
+	}
 	if n.Index == -1 {
 		return n.Resource.Id()
 	}
@@ -882,6 +948,18 @@ func (n *graphNodeExpandedResourceDestroy) ConfigType() GraphNodeConfigType {
 func (n *graphNodeExpandedResourceDestroy) EvalTree() EvalNode {
 	info := n.instanceInfo()
 
+	index := n.Index
+	if index < 0 {
+		index = 0
+	}
+	resource := &Resource{
+		Name:       n.Resource.Name,
+		Type:       n.Resource.Type,
+		CountIndex: index,
+		EachKey:    n.Key,
+		EachValue:  n.Value,
+	}
+
 	var diffApply *InstanceDiff
 	var provider ResourceProvider
 	var state *InstanceState
@@ -926,13 +1004,37 @@ func (n *graphNodeExpandedResourceDestroy) EvalTree() EvalNode {
 				&EvalRequireState{
 					State: &state,
 				},
-				&EvalApply{
-					Info:     info,
-					State:    &state,
-					Diff:     &diffApply,
-					Provider: &provider,
-					Output:   &state,
-					Error:    &err,
+
+				// Run the destroy provisioners. If they fail, the
+				// resource isn't destroyed, so they run again next time.
+				&EvalApplyProvisioners{
+					Info:           info,
+					State:          &state,
+					Resource:       n.Resource,
+					InterpResource: resource,
+					Error:          &err,
+					When:           config.ProvisionerWhenDestroy,
+				},
+
+				&EvalIf{
+					If: func(ctx EvalContext) (bool, error) {
+						// Data resources have nothing to destroy, so
+						// they are only removed from the state.
+						if n.Resource.Mode == config.DataResourceMode {
+							state = nil
+							return false, nil
+						}
+
+						return err == nil, nil
+					},
+					Then: &EvalApply{
+						Info:     info,
+						State:    &state,
+						Diff:     &diffApply,
+						Provider: &provider,
+						Output:   &state,
+						Error:    &err,
+					},
 				},
 				&EvalWriteState{
 					Name:         n.stateId(),
@@ -54,7 +54,10 @@ func resourceProvider(t, alias string) string {
 
 	idx := strings.IndexRune(t, '_')
 	if idx == -1 {
-		return ""
+		// If there are no underscores, the type is also the name of the
+		// provider, e.g. for a provider with a single resource or data
+		// source such as "external".
+		return t
 	}
 
 	return t[:idx]
@@ -46,3 +46,19 @@ func TestStrSliceContains(t *testing.T) {
 		t.Fatalf("Bad")
 	}
 }
+
+func TestResourceProvider(t *testing.T) {
+	cases := []struct {
+		Type, Alias, Expected string
+	}{
+		{"aws_instance", "", "aws"},
+		{"aws_instance", "aws.west", "aws.west"},
+		{"external", "", "external"},
+	}
+
+	for _, tc := range cases {
+		if actual := resourceProvider(tc.Type, tc.Alias); actual != tc.Expected {
+			t.Fatalf("%s, %s: expected %q, got %q", tc.Type, tc.Alias, tc.Expected, actual)
+		}
+	}
+}